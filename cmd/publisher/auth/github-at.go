@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,8 +13,6 @@ import (
 )
 
 const (
-	gitHubTokenFilePath   = ".mcpregistry_github_token"   // #nosec:G101
-	registryTokenFilePath = ".mcpregistry_registry_token" // #nosec:G101
 	// GitHub OAuth URLs
 	GitHubDeviceCodeURL  = "https://github.com/login/device/code"        // #nosec:G101
 	GitHubAccessTokenURL = "https://github.com/login/oauth/access_token" // #nosec:G101
@@ -42,17 +41,12 @@ type RegistryTokenResponse struct {
 	ExpiresAt     int64  `json:"expires_at"`
 }
 
-// StoredRegistryToken represents the registry token with expiration stored locally
-type StoredRegistryToken struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"`
-}
-
 // GitHubATProvider implements the Provider interface using GitHub's device flow
 type GitHubATProvider struct {
 	clientID    string
 	forceLogin  bool
 	registryURL string
+	githubToken string
 }
 
 // ServerHealthResponse represents the response from the health endpoint
@@ -69,56 +63,33 @@ func NewGitHubATProvider(forceLogin bool, registryURL string) Provider {
 	}
 }
 
-// GetToken retrieves the registry JWT token (exchanges GitHub token if needed)
-func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
-	// Check if we have a valid registry token
-	registryToken, err := readRegistryToken()
-	if err == nil && registryToken != "" {
-		return registryToken, nil
+// NewGitHubATProviderWithToken creates a GitHub OAuth provider from a GitHub access token
+// obtained by an earlier login, so registry tokens can be refreshed without the device flow
+func NewGitHubATProviderWithToken(registryURL, githubToken string) Provider {
+	return &GitHubATProvider{
+		registryURL: registryURL,
+		githubToken: githubToken,
 	}
+}
 
-	// If no valid registry token, exchange GitHub token for registry token
-	githubToken, err := readToken()
+// GetToken exchanges the GitHub access token for a registry JWT token. Neither token is stored
+// here: the caller keeps them with the rest of the saved login, in the OS keychain where there is one.
+func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
+	githubToken, err := g.RefreshCredential()
 	if err != nil {
-		return "", fmt.Errorf("failed to read GitHub token: %w", err)
+		return "", err
 	}
 
-	// Exchange GitHub token for registry token
-	registryToken, expiresAt, err := g.exchangeTokenForRegistry(ctx, githubToken)
+	registryToken, _, err := g.exchangeTokenForRegistry(ctx, githubToken)
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange token: %w", err)
 	}
-
-	// Store the registry token
-	err = saveRegistryToken(registryToken, expiresAt)
-	if err != nil {
-		return "", fmt.Errorf("failed to save registry token: %w", err)
-	}
-
 	return registryToken, nil
 }
 
 // NeedsLogin checks if a new login is required
 func (g *GitHubATProvider) NeedsLogin() bool {
-	if g.forceLogin {
-		return true
-	}
-
-	// Check if GitHub token exists
-	_, statErr := os.Stat(gitHubTokenFilePath)
-	if os.IsNotExist(statErr) {
-		return true
-	}
-
-	// Check if valid registry token exists
-	_, err := readRegistryToken()
-	if err != nil {
-		// No valid registry token, but we have GitHub token
-		// We don't need to login, just exchange tokens
-		return false
-	}
-
-	return false
+	return g.forceLogin || g.githubToken == ""
 }
 
 // Login performs the GitHub device flow authentication
@@ -152,11 +123,7 @@ func (g *GitHubATProvider) Login(ctx context.Context) error {
 		return fmt.Errorf("error polling for token: %w", err)
 	}
 
-	// Store the token locally
-	err = saveToken(token)
-	if err != nil {
-		return fmt.Errorf("error saving token: %w", err)
-	}
+	g.githubToken = token
 
 	_, _ = fmt.Fprintln(os.Stdout, "Successfully authenticated!")
 	return nil
@@ -167,6 +134,15 @@ func (g *GitHubATProvider) Name() string {
 	return "github"
 }
 
+// RefreshCredential returns the GitHub access token, which can be exchanged for new
+// registry tokens until it is revoked
+func (g *GitHubATProvider) RefreshCredential() (string, error) {
+	if g.githubToken == "" {
+		return "", errors.New("not logged in to GitHub")
+	}
+	return g.githubToken, nil
+}
+
 // requestDeviceCode initiates the device authorization flow
 func (g *GitHubATProvider) requestDeviceCode(ctx context.Context) (string, string, string, error) {
 	if g.clientID == "" {
@@ -284,20 +260,6 @@ func (g *GitHubATProvider) pollForToken(ctx context.Context, deviceCode string)
 	return "", fmt.Errorf("device code authorization timed out")
 }
 
-// saveToken saves the GitHub access token to a local file
-func saveToken(token string) error {
-	return os.WriteFile(gitHubTokenFilePath, []byte(token), 0600)
-}
-
-// readToken reads the GitHub access token from a local file
-func readToken() (string, error) {
-	tokenData, err := os.ReadFile(gitHubTokenFilePath)
-	if err != nil {
-		return "", err
-	}
-	return string(tokenData), nil
-}
-
 func getClientID(ctx context.Context, registryURL string) (string, error) {
 	// This function should retrieve the GitHub Client ID from the registry URL
 	// For now, we will return a placeholder value
@@ -386,41 +348,3 @@ func (g *GitHubATProvider) exchangeTokenForRegistry(ctx context.Context, githubT
 
 	return tokenResp.RegistryToken, tokenResp.ExpiresAt, nil
 }
-
-// saveRegistryToken saves the registry JWT token to a local file with expiration
-func saveRegistryToken(token string, expiresAt int64) error {
-	storedToken := StoredRegistryToken{
-		Token:     token,
-		ExpiresAt: expiresAt,
-	}
-
-	data, err := json.Marshal(storedToken)
-	if err != nil {
-		return fmt.Errorf("failed to marshal token: %w", err)
-	}
-
-	return os.WriteFile(registryTokenFilePath, data, 0600)
-}
-
-// readRegistryToken reads the registry JWT token from a local file
-func readRegistryToken() (string, error) {
-	data, err := os.ReadFile(registryTokenFilePath)
-	if err != nil {
-		return "", err
-	}
-
-	var storedToken StoredRegistryToken
-	err = json.Unmarshal(data, &storedToken)
-	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal token: %w", err)
-	}
-
-	// Check if token has expired
-	if time.Now().Unix() >= storedToken.ExpiresAt {
-		// Token has expired, remove the file
-		os.Remove(registryTokenFilePath)
-		return "", fmt.Errorf("registry token has expired")
-	}
-
-	return storedToken.Token, nil
-}
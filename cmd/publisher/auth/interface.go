@@ -19,3 +19,12 @@ type Provider interface {
 	// Name returns the name of the authentication provider
 	Name() string
 }
+
+// RefreshableProvider is implemented by providers that obtain a long-lived credential
+// during login, which can later be exchanged for new registry tokens without user interaction
+type RefreshableProvider interface {
+	Provider
+
+	// RefreshCredential returns the long-lived credential obtained by Login
+	RefreshCredential() (string, error)
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// PATProvider implements the Provider interface by exchanging a personal access token created
+// with 'mcp-publisher tokens create' for registry tokens
+type PATProvider struct {
+	registryURL string
+	token       string
+}
+
+// NewPATProvider creates a new personal access token provider
+func NewPATProvider(registryURL, token string) Provider {
+	return &PATProvider{
+		registryURL: registryURL,
+		token:       token,
+	}
+}
+
+// GetToken exchanges the personal access token for a registry JWT token
+func (p *PATProvider) GetToken(ctx context.Context) (string, error) {
+	jsonData, err := json.Marshal(map[string]string{"token": p.token})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	exchangeURL := strings.TrimSuffix(p.registryURL, "/") + "/v0/auth/pat"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return tokenResp.RegistryToken, nil
+}
+
+// NeedsLogin always returns false, as the personal access token is all that is needed
+func (p *PATProvider) NeedsLogin() bool {
+	return false
+}
+
+// Login checks that a personal access token was given
+func (p *PATProvider) Login(_ context.Context) error {
+	if p.token == "" {
+		return fmt.Errorf("personal access token is required")
+	}
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (p *PATProvider) Name() string {
+	return "pat"
+}
+
+// RefreshCredential returns the personal access token, which can be exchanged for new
+// registry tokens until it expires or is revoked
+func (p *PATProvider) RefreshCredential() (string, error) {
+	return p.token, nil
+}
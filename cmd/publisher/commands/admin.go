@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const adminUsage = `Usage: mcp-publisher admin <command> <name> --reason=REASON [--registry=URL] [--token=TOKEN]
+
+Commands:
+  takedown <name>   Quarantine a server, hiding it from listings and search while it is reviewed
+  reinstate <name>  Lift the quarantine of a server, listing it again
+  delete <name>     Delete a quarantined server, purging it once the retention window passes
+  restore <name>    Bring back a deleted server that wasn't purged yet, quarantined
+
+Authenticates with --token, $REGISTRY_TOKEN (see tools/admin/auth.sh), or the saved login.
+The token must grant global edit permission.`
+
+// moderationCommand is an admin command and the moderation endpoint it calls
+type moderationCommand struct {
+	method string
+	path   string
+	done   string
+}
+
+// moderationCommands are the admin commands, by name
+var moderationCommands = map[string]moderationCommand{
+	"takedown":  {method: http.MethodPost, path: "/quarantine", done: "Quarantined"},
+	"reinstate": {method: http.MethodPost, path: "/reinstate", done: "Reinstated"},
+	"delete":    {method: http.MethodDelete, done: "Deleted"},
+	"restore":   {method: http.MethodPost, path: "/restore", done: "Restored"},
+}
+
+// AdminCommand runs moderation operations against the registry's admin API
+func AdminCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New(adminUsage)
+	}
+
+	switch args[0] {
+	case "--help", "-h", "help":
+		_, _ = fmt.Fprintln(os.Stdout, adminUsage)
+		return nil
+	}
+	command, ok := moderationCommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown admin command: %s\n\n%s", args[0], adminUsage)
+	}
+	return moderateServerCommand(args[0], command, args[1:])
+}
+
+// moderateServerCommand quarantines, reinstates, deletes or restores a server
+func moderateServerCommand(name string, command moderationCommand, args []string) error {
+	usage := fmt.Sprintf("Usage: mcp-publisher admin %s <name> --reason=REASON [--registry=URL] [--token=TOKEN]", name)
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("server name required\n\n%s", usage)
+	}
+	serverName := args[0]
+
+	moderationFlags := flag.NewFlagSet("admin "+name, flag.ExitOnError)
+	var registryURL, token, reason string
+	moderationFlags.StringVar(&registryURL, "registry", "", "Registry URL (default: $REGISTRY_URL, or the registry you are logged in to)")
+	moderationFlags.StringVar(&token, "token", "", "Registry token with global edit permission (default: $REGISTRY_TOKEN, or the saved login)")
+	moderationFlags.StringVar(&reason, "reason", "", "Why the server is moderated, recorded with the case")
+	if err := moderationFlags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if reason == "" {
+		return fmt.Errorf("--reason is required\n\n%s", usage)
+	}
+
+	ctx := context.Background()
+	registryURL, token, err := resolveAdminCredentials(ctx, registryURL, token)
+	if err != nil {
+		return err
+	}
+
+	var moderationCase apiv0.ModerationCase
+	requestURL := registryEndpoint(registryURL, "/v0/admin/servers/"+url.PathEscape(serverName)+command.path)
+	if err := sendTokenRequest(ctx, command.method, requestURL, token, apiv0.ModerationDecision{Reason: reason}, &moderationCase); err != nil {
+		return fmt.Errorf("failed to %s %s: %w", name, serverName, err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, moderationCase)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ %s %s, which is now %s (case %s)\n", command.done, serverName, moderationCase.Status, moderationCase.ID)
+	return nil
+}
+
+// resolveAdminCredentials picks the registry and token for admin commands. Explicit flags win,
+// then the REGISTRY_URL and REGISTRY_TOKEN variables used by tools/admin, then the saved login.
+func resolveAdminCredentials(ctx context.Context, registryURL, token string) (string, string, error) {
+	if registryURL == "" {
+		registryURL = os.Getenv("REGISTRY_URL")
+	}
+	if token == "" {
+		token = os.Getenv("REGISTRY_TOKEN")
+	}
+	if token != "" {
+		if registryURL == "" {
+			registryURL = DefaultRegistryURL
+		}
+		return registryURL, token, nil
+	}
+
+	savedToken, savedRegistryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if registryURL == "" {
+		registryURL = savedRegistryURL
+	}
+	return registryURL, savedToken, nil
+}
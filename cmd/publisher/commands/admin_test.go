@@ -0,0 +1,75 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestAdminCommand_CallsModerationEndpoints(t *testing.T) {
+	t.Setenv("REGISTRY_TOKEN", "admin-token")
+
+	tests := []struct {
+		command string
+		method  string
+		path    string
+		status  string
+	}{
+		{"takedown", http.MethodPost, "/v0/admin/servers/com.example%2Fweather/quarantine", "quarantined"},
+		{"reinstate", http.MethodPost, "/v0/admin/servers/com.example%2Fweather/reinstate", "reinstated"},
+		{"delete", http.MethodDelete, "/v0/admin/servers/com.example%2Fweather", "deleted"},
+		{"restore", http.MethodPost, "/v0/admin/servers/com.example%2Fweather/restore", "quarantined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.method, r.Method)
+				assert.Equal(t, tt.path, r.URL.EscapedPath())
+				assert.Equal(t, "Bearer admin-token", r.Header.Get("Authorization"))
+
+				var decision apiv0.ModerationDecision
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&decision))
+				assert.Equal(t, "Impersonates weather.gov", decision.Reason)
+				_ = json.NewEncoder(w).Encode(apiv0.ModerationCase{
+					ID: "case-1", ServerName: "com.example/weather", Status: tt.status, Reason: decision.Reason, CreatedAt: time.Now(),
+				})
+			})
+
+			output := captureStdout(t, func() {
+				require.NoError(t, commands.AdminCommand([]string{tt.command, "com.example/weather", "--registry", registry.URL, "--reason", "Impersonates weather.gov"}))
+			})
+			assert.Contains(t, output, "which is now "+tt.status)
+		})
+	}
+}
+
+func TestAdminCommand_ReportsRegistryError(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"detail": "Server is not quarantined"}`))
+	})
+
+	err := commands.AdminCommand([]string{"reinstate", "com.example/weather", "--registry", registry.URL, "--token", "admin-token", "--reason", "Cleared"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Server is not quarantined")
+}
+
+func TestAdminCommand_RequiresReason(t *testing.T) {
+	err := commands.AdminCommand([]string{"takedown", "com.example/weather", "--token", "admin-token"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--reason is required")
+}
+
+func TestAdminCommand_UnknownCommand(t *testing.T) {
+	err := commands.AdminCommand([]string{"reindex-search"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown admin command")
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const channelUsage = `Usage: mcp-publisher channel <command> [arguments]
+
+Commands:
+  list     List the release channels of a server (<name> [--registry=URL])
+  set      Tag a version into a release channel (<name> <stable|beta> <version>)
+  remove   Stop offering a release channel (<name> <stable|beta>)
+
+Clients resolve a channel with ?channel=stable instead of taking the latest
+version. latest always follows the latest version and can't be set.`
+
+// ChannelCommand manages the release channels of a server
+func ChannelCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New(channelUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return listChannelsCommand(args[1:])
+	case "set":
+		return setChannelCommand(args[1:])
+	case "remove":
+		return removeChannelCommand(args[1:])
+	case "--help", "-h", "help":
+		_, _ = fmt.Fprintln(os.Stdout, channelUsage)
+		return nil
+	default:
+		return fmt.Errorf("unknown channel command: %s\n\n%s", args[0], channelUsage)
+	}
+}
+
+func listChannelsCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New("server name required\n\nUsage: mcp-publisher channel list <name> [--registry=URL]")
+	}
+	serverName := args[0]
+
+	listFlags := flag.NewFlagSet("channel list", flag.ExitOnError)
+	var registryURL string
+	listFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	if err := listFlags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var list apiv0.ServerChannelListResponse
+	requestURL := registryEndpoint(registryURL, "/v0/servers/"+url.PathEscape(serverName)+"/channels")
+	if err := getJSON(context.Background(), requestURL, &list); err != nil {
+		return fmt.Errorf("failed to get channels of %s: %w", serverName, err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, list.Channels)
+	}
+	return printChannelTable(os.Stdout, list.Channels)
+}
+
+func setChannelCommand(args []string) error {
+	if len(args) != 3 {
+		return errors.New("server name, channel and version required\n\nUsage: mcp-publisher channel set <name> <stable|beta> <version>")
+	}
+	serverName, channelName, version := args[0], args[1], args[2]
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var channel apiv0.ServerChannel
+	if err := sendTokenRequest(ctx, http.MethodPut, channelEndpoint(registryURL, serverName, channelName), registryToken,
+		map[string]string{"version": version}, &channel); err != nil {
+		return fmt.Errorf("failed to set channel: %w", err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, channel)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ %s %s is now %s\n", serverName, channel.Version, channel.Channel)
+	return nil
+}
+
+func removeChannelCommand(args []string) error {
+	if len(args) != 2 {
+		return errors.New("server name and channel required\n\nUsage: mcp-publisher channel remove <name> <stable|beta>")
+	}
+	serverName, channelName := args[0], args[1]
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := sendTokenRequest(ctx, http.MethodDelete, channelEndpoint(registryURL, serverName, channelName), registryToken, nil, nil); err != nil {
+		return fmt.Errorf("failed to remove channel: %w", err)
+	}
+
+	if !jsonOutputEnabled() {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ Removed the %s channel of %s\n", channelName, serverName)
+	}
+	return nil
+}
+
+// channelEndpoint returns the URL of a release channel of a server
+func channelEndpoint(registryURL, serverName, channel string) string {
+	return registryEndpoint(registryURL, "/v0/servers/"+url.PathEscape(serverName)+"/channels/"+url.PathEscape(channel))
+}
+
+// printChannelTable writes a one-line-per-channel summary table
+func printChannelTable(w io.Writer, channels []apiv0.ServerChannel) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHANNEL\tVERSION\tUPDATED")
+	for _, channel := range channels {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", channel.Channel, channel.Version, channel.UpdatedAt.Format(time.DateOnly))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,55 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestChannelCommand_SetSendsVersion(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/v0/servers/io.github.alice%2Fweather/channels/beta", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer login-token", r.Header.Get("Authorization"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "2.0.0-beta.1", body["version"])
+		_ = json.NewEncoder(w).Encode(apiv0.ServerChannel{Channel: "beta", Version: body["version"], UpdatedAt: time.Now()})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.ChannelCommand([]string{"set", "io.github.alice/weather", "beta", "2.0.0-beta.1"}))
+	})
+	assert.Contains(t, output, "io.github.alice/weather 2.0.0-beta.1 is now beta")
+}
+
+func TestChannelCommand_RemoveAcceptsNoContent(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/v0/servers/io.github.alice%2Fweather/channels/stable", r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.ChannelCommand([]string{"remove", "io.github.alice/weather", "stable"}))
+	})
+	assert.Contains(t, output, "Removed the stable channel")
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// errNotFound is returned when the registry has no such server or version
+var errNotFound = errors.New("server returned status 404")
+
+// getJSON sends a GET request to the registry and decodes the JSON response into out
+func getJSON(ctx context.Context, requestURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", errNotFound, body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status %d: %s", resp.StatusCode, body)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+	return nil
+}
+
+// registryEndpoint joins the registry base URL with an API path
+func registryEndpoint(registryURL, path string) string {
+	return strings.TrimSuffix(registryURL, "/") + path
+}
+
+// listServers fetches one page of servers from the registry
+func listServers(ctx context.Context, registryURL string, query url.Values) (*apiv0.ServerListResponse, error) {
+	requestURL := registryEndpoint(registryURL, "/v0/servers")
+	if encoded := query.Encode(); encoded != "" {
+		requestURL += "?" + encoded
+	}
+
+	var response apiv0.ServerListResponse
+	if err := getJSON(ctx, requestURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// searchServers fetches one page of full-text search results from the registry, most relevant first
+func searchServers(ctx context.Context, registryURL string, query url.Values) (*apiv0.ServerSearchResponse, error) {
+	requestURL := registryEndpoint(registryURL, "/v0/servers/search") + "?" + query.Encode()
+
+	var response apiv0.ServerSearchResponse
+	if err := getJSON(ctx, requestURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// getServerVersion fetches a single server version from the registry. Use "latest" for
+// the latest version.
+func getServerVersion(ctx context.Context, registryURL, serverName, version string) (*apiv0.ServerResponse, error) {
+	requestURL := registryEndpoint(registryURL, fmt.Sprintf("/v0/servers/%s/versions/%s",
+		url.PathEscape(serverName), url.PathEscape(version)))
+
+	var response apiv0.ServerResponse
+	if err := getJSON(ctx, requestURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// getServerVersions fetches every published version of a server from the registry
+func getServerVersions(ctx context.Context, registryURL, serverName string) (*apiv0.ServerListResponse, error) {
+	requestURL := registryEndpoint(registryURL, fmt.Sprintf("/v0/servers/%s/versions", url.PathEscape(serverName)))
+
+	var response apiv0.ServerListResponse
+	if err := getJSON(ctx, requestURL, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// printJSON writes v to w as indented JSON
+func printJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
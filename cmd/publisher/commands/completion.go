@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// completionCommand describes a command and its flags for shell completion
+type completionCommand struct {
+	name  string
+	flags []string
+	// subcommands are completed as the first argument, e.g. login methods
+	subcommands []string
+}
+
+// completionCommands lists every command the publisher supports. Keep it in sync with main.go.
+var completionCommands = []completionCommand{
+	{name: "init", flags: []string{"--interactive", "--from-image"}},
+	{name: "login", flags: []string{"--registry", "--no-keychain", "--domain", "--private-key", "--algorithm", "--token"},
+		subcommands: []string{"github", "github-oidc", "dns", "http", "pat", "none"}},
+	{name: "logout"},
+	{name: "publish", flags: []string{"--signature", "--signature-format", "--print-canonical", "--sbom", "--provenance"}},
+	{name: "validate", flags: []string{"--skip-registry-validation"}},
+	{name: "lint", flags: []string{"--fix", "--config"}},
+	{name: "verify", flags: []string{"--package", "--timeout"}},
+	{name: "release", flags: []string{"--version", "--commit", "--no-publish", "--dry-run", "--skip-registry-validation"}},
+	{name: "yank", flags: []string{"--undo"}},
+	{name: "search", flags: []string{"--registry", "--limit", "--cursor", "--all", "--version", "--updated-since", "--supports", "--registry-type", "--transport", "--verified", "--max-severity", "--platform", "--max-image-size", "--json"}},
+	{name: "show", flags: []string{"--registry", "--version", "--versions", "--json"}},
+	{name: "stats", flags: []string{"--registry", "--namespace", "--json"}},
+	{name: "watch", flags: []string{"--registry", "--search", "--namespace", "--match", "--exec", "--since", "--interval", "--once"}},
+	{name: "diff", flags: []string{"--registry", "--version", "--json"}},
+	{name: "export", flags: []string{"--registry", "--all", "--namespace", "--output"}},
+	{name: "import", flags: []string{"--to", "--on-conflict", "--dry-run"}},
+	{name: "mirror", flags: []string{"--from", "--to", "--namespace", "--state", "--dry-run"}},
+	{name: "channel", flags: []string{"--registry"}, subcommands: []string{"list", "set", "remove"}},
+	{name: "rename"},
+	{name: "alias", flags: []string{"--registry"}, subcommands: []string{"list", "add", "remove"}},
+	{name: "tokens", flags: []string{"--name", "--expires-in-days", "--permission"},
+		subcommands: []string{"create", "list", "revoke"}},
+	{name: "admin", flags: []string{"--registry", "--token", "--reason"},
+		subcommands: []string{"takedown", "reinstate", "delete", "restore"}},
+	{name: "completion", subcommands: []string{"bash", "zsh", "fish"}},
+	{name: "version"},
+	{name: "help"},
+}
+
+// globalFlags are accepted before the command name
+var globalFlags = []string{"--output"}
+
+// CompletionCommand prints a shell completion script for bash, zsh or fish
+func CompletionCommand(args []string) error {
+	if len(args) != 1 {
+		return errors.New("shell required\n\nUsage: mcp-publisher completion <bash|zsh|fish>\n\nExamples:\n  source <(mcp-publisher completion bash)\n  mcp-publisher completion zsh > \"${fpath[1]}/_mcp-publisher\"\n  mcp-publisher completion fish > ~/.config/fish/completions/mcp-publisher.fish")
+	}
+
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(os.Stdout)
+	case "zsh":
+		writeZshCompletion(os.Stdout)
+	case "fish":
+		writeFishCompletion(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", args[0])
+	}
+	return nil
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(completionCommands))
+	for _, cmd := range completionCommands {
+		names = append(names, cmd.name)
+	}
+	return names
+}
+
+func writeBashCompletion(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "# bash completion for mcp-publisher")
+	_, _ = fmt.Fprintln(w, "_mcp_publisher() {")
+	_, _ = fmt.Fprintln(w, `    local cur="${COMP_WORDS[COMP_CWORD]}"`)
+	_, _ = fmt.Fprintln(w, "    # Global flags come before the command name")
+	_, _ = fmt.Fprintln(w, "    local cmd=1")
+	_, _ = fmt.Fprintln(w, `    if [[ "${COMP_WORDS[1]}" == "--output" ]]; then`)
+	_, _ = fmt.Fprintln(w, "        cmd=3")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, `    if [[ ${COMP_CWORD} -eq 2 && ${cmd} -eq 3 ]]; then`)
+	_, _ = fmt.Fprintln(w, `        COMPREPLY=($(compgen -W "text json" -- "$cur"))`)
+	_, _ = fmt.Fprintln(w, "        return")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, `    if [[ ${COMP_CWORD} -eq 1 ]]; then`)
+	_, _ = fmt.Fprintf(w, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(append(commandNames(), globalFlags...), " "))
+	_, _ = fmt.Fprintln(w, "        return")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, `    if [[ ${COMP_CWORD} -eq ${cmd} ]]; then`)
+	_, _ = fmt.Fprintf(w, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(commandNames(), " "))
+	_, _ = fmt.Fprintln(w, "        return")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, `    case "${COMP_WORDS[cmd]}" in`)
+	for _, cmd := range completionCommands {
+		words := cmd.flags
+		if len(cmd.subcommands) > 0 {
+			_, _ = fmt.Fprintf(w, "        %s)\n", cmd.name)
+			_, _ = fmt.Fprintln(w, `            if [[ ${COMP_CWORD} -eq $((cmd + 1)) ]]; then`)
+			_, _ = fmt.Fprintf(w, "                COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(cmd.subcommands, " "))
+			_, _ = fmt.Fprintln(w, "            else")
+			_, _ = fmt.Fprintf(w, "                COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(words, " "))
+			_, _ = fmt.Fprintln(w, "            fi")
+			_, _ = fmt.Fprintln(w, "            ;;")
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "        %s)\n", cmd.name)
+		_, _ = fmt.Fprintf(w, "            COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(words, " "))
+		_, _ = fmt.Fprintln(w, "            ;;")
+	}
+	_, _ = fmt.Fprintln(w, "    esac")
+	_, _ = fmt.Fprintln(w, "}")
+	_, _ = fmt.Fprintln(w, "complete -o default -F _mcp_publisher mcp-publisher")
+}
+
+func writeZshCompletion(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "#compdef mcp-publisher")
+	_, _ = fmt.Fprintln(w, "# zsh completion for mcp-publisher")
+	_, _ = fmt.Fprintln(w, "_mcp_publisher() {")
+	_, _ = fmt.Fprintln(w, "    # Global flags come before the command name")
+	_, _ = fmt.Fprintln(w, "    local cmd=2")
+	_, _ = fmt.Fprintln(w, `    if [[ "${words[2]}" == "--output" ]]; then`)
+	_, _ = fmt.Fprintln(w, "        cmd=4")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, "    if (( CURRENT == 3 && cmd == 4 )); then")
+	_, _ = fmt.Fprintln(w, "        compadd -- text json")
+	_, _ = fmt.Fprintln(w, "        return")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, "    if (( CURRENT == 2 )); then")
+	_, _ = fmt.Fprintf(w, "        compadd -- %s\n", strings.Join(append(commandNames(), globalFlags...), " "))
+	_, _ = fmt.Fprintln(w, "        return")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, "    if (( CURRENT == cmd )); then")
+	_, _ = fmt.Fprintf(w, "        compadd -- %s\n", strings.Join(commandNames(), " "))
+	_, _ = fmt.Fprintln(w, "        return")
+	_, _ = fmt.Fprintln(w, "    fi")
+	_, _ = fmt.Fprintln(w, `    case "${words[cmd]}" in`)
+	for _, cmd := range completionCommands {
+		words := cmd.flags
+		_, _ = fmt.Fprintf(w, "        %s)\n", cmd.name)
+		if len(cmd.subcommands) > 0 {
+			_, _ = fmt.Fprintf(w, "            (( CURRENT == cmd + 1 )) && compadd -- %s || compadd -- %s\n",
+				strings.Join(cmd.subcommands, " "), strings.Join(words, " "))
+		} else {
+			_, _ = fmt.Fprintf(w, "            compadd -- %s\n", strings.Join(words, " "))
+		}
+		_, _ = fmt.Fprintln(w, "            ;;")
+	}
+	_, _ = fmt.Fprintln(w, "    esac")
+	_, _ = fmt.Fprintln(w, "    _files")
+	_, _ = fmt.Fprintln(w, "}")
+	_, _ = fmt.Fprintln(w, `compdef _mcp_publisher mcp-publisher`)
+}
+
+func writeFishCompletion(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "# fish completion for mcp-publisher")
+	_, _ = fmt.Fprintf(w, "complete -c mcp-publisher -n __fish_use_subcommand -f -a %q\n", strings.Join(commandNames(), " "))
+	_, _ = fmt.Fprintln(w, `complete -c mcp-publisher -n __fish_use_subcommand -l output -x -a "text json" -d "Output format"`)
+	for _, cmd := range completionCommands {
+		condition := "__fish_seen_subcommand_from " + cmd.name
+		if len(cmd.subcommands) > 0 {
+			_, _ = fmt.Fprintf(w, "complete -c mcp-publisher -n %q -f -a %q\n", condition, strings.Join(cmd.subcommands, " "))
+		}
+		for _, flag := range cmd.flags {
+			_, _ = fmt.Fprintf(w, "complete -c mcp-publisher -n %q -l %s\n", condition, strings.TrimPrefix(flag, "--"))
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+// tokenRefreshLeeway refreshes registry tokens shortly before they expire so a publish
+// doesn't race the expiry
+const tokenRefreshLeeway = time.Minute
+
+// storedCredentials is what login persists and publish reads back. The JSON keys match
+// the original token file format so existing token files keep working.
+type storedCredentials struct {
+	Token        string `json:"token"`
+	Method       string `json:"method"`
+	Registry     string `json:"registry"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// tokenFilePath returns the path of the fallback token file in the user's home directory
+func tokenFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, TokenFileName), nil
+}
+
+// saveCredentials stores credentials in the OS keychain when useKeychain is set, failing
+// when there is none, and otherwise in the token file, which the user opted into. The
+// GitHub access token is never written to the file, so GitHub logins stored there last
+// as long as their registry token. It reports where the credentials went.
+func saveCredentials(ctx context.Context, creds *storedCredentials, useKeychain bool) (string, error) {
+	tokenPath, err := tokenFilePath()
+	if err != nil {
+		return "", err
+	}
+
+	if useKeychain {
+		kc, err := newKeychain()
+		if err != nil {
+			return "", fmt.Errorf("%w to store credentials in. Pass --no-keychain or set %s=1 to store them in %s instead",
+				err, noKeychainEnv, tokenPath)
+		}
+		jsonData, err := json.Marshal(creds)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal token data: %w", err)
+		}
+		if err := kc.Set(ctx, string(jsonData)); err != nil {
+			return "", fmt.Errorf("failed to store credentials in the OS keychain: %w", err)
+		}
+		// Don't leave an older plaintext token behind to shadow or leak
+		_ = os.Remove(tokenPath)
+		return "OS keychain", nil
+	}
+
+	fileCreds := *creds
+	if fileCreds.Method == "github" {
+		fileCreds.RefreshToken = ""
+	}
+	jsonData, err := json.Marshal(fileCreds)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token data: %w", err)
+	}
+	if err := os.WriteFile(tokenPath, jsonData, 0600); err != nil {
+		return "", fmt.Errorf("failed to save token: %w", err)
+	}
+	return tokenPath, nil
+}
+
+// loadCredentials reads credentials from the OS keychain, falling back to the token file
+func loadCredentials(ctx context.Context) (*storedCredentials, bool, error) {
+	if kc, err := newKeychain(); err == nil {
+		if data, err := kc.Get(ctx); err == nil && data != "" {
+			var creds storedCredentials
+			if err := json.Unmarshal([]byte(data), &creds); err != nil {
+				return nil, false, fmt.Errorf("invalid token data in keychain: %w", err)
+			}
+			return &creds, true, nil
+		}
+	}
+
+	tokenPath, err := tokenFilePath()
+	if err != nil {
+		return nil, false, err
+	}
+
+	tokenData, err := os.ReadFile(tokenPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, errors.New("not authenticated. Run 'mcp-publisher login <method>' first")
+		}
+		return nil, false, fmt.Errorf("failed to read token: %w", err)
+	}
+
+	var creds storedCredentials
+	if err := json.Unmarshal(tokenData, &creds); err != nil {
+		return nil, false, fmt.Errorf("invalid token data: %w", err)
+	}
+	return &creds, false, nil
+}
+
+// deleteCredentials removes credentials from both the OS keychain and the token file.
+// It reports whether anything was removed.
+func deleteCredentials(ctx context.Context) (bool, error) {
+	removed := false
+	if kc, err := newKeychain(); err == nil {
+		if data, err := kc.Get(ctx); err == nil && data != "" {
+			if err := kc.Delete(ctx); err != nil {
+				return false, fmt.Errorf("failed to remove token from keychain: %w", err)
+			}
+			removed = true
+		}
+	}
+
+	tokenPath, err := tokenFilePath()
+	if err != nil {
+		return removed, err
+	}
+	if _, err := os.Stat(tokenPath); err == nil {
+		if err := os.Remove(tokenPath); err != nil {
+			return removed, fmt.Errorf("failed to remove token: %w", err)
+		}
+		removed = true
+	}
+
+	return removed, nil
+}
+
+// loadRegistryToken returns a registry token for publishing along with the registry it
+// was issued by. Expired tokens are refreshed without user interaction when the login
+// method allows it, and the refreshed token is stored back where it came from.
+func loadRegistryToken(ctx context.Context) (string, string, error) {
+	creds, inKeychain, err := loadCredentials(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if creds.Registry == "" {
+		creds.Registry = DefaultRegistryURL
+	}
+
+	if creds.ExpiresAt == 0 || time.Now().Add(tokenRefreshLeeway).Unix() < creds.ExpiresAt {
+		return creds.Token, creds.Registry, nil
+	}
+
+	provider := refreshProvider(creds)
+	if provider == nil {
+		return "", "", fmt.Errorf("registry token expired. Run 'mcp-publisher login %s' again", creds.Method)
+	}
+
+	token, err := provider.GetToken(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to refresh expired registry token: %w. Run 'mcp-publisher login %s' again", err, creds.Method)
+	}
+	creds.Token = token
+	creds.ExpiresAt = tokenExpiry(token)
+
+	if _, err := saveCredentials(ctx, creds, inKeychain); err != nil {
+		return "", "", err
+	}
+	return creds.Token, creds.Registry, nil
+}
+
+// refreshProvider returns a provider that can mint a new registry token for the stored
+// credentials without user interaction, or nil if the method requires logging in again
+func refreshProvider(creds *storedCredentials) auth.Provider {
+	switch creds.Method {
+	case "github":
+		if creds.RefreshToken == "" {
+			return nil
+		}
+		return auth.NewGitHubATProviderWithToken(creds.Registry, creds.RefreshToken)
+	case "github-oidc":
+		// Only works while still inside the GitHub Actions job that logged in
+		return auth.NewGitHubOIDCProvider(creds.Registry)
+	case "pat":
+		if creds.RefreshToken == "" {
+			return nil
+		}
+		return auth.NewPATProvider(creds.Registry, creds.RefreshToken)
+	case "none":
+		return auth.NewNoneProvider(creds.Registry)
+	default:
+		// dns and http need the private key, which is deliberately never stored
+		return nil
+	}
+}
+
+// tokenExpiry reads the exp claim from a registry JWT without verifying it. It returns
+// 0 if the token isn't a JWT or has no expiry.
+func tokenExpiry(token string) int64 {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0
+	}
+	return claims.ExpiresAt
+}
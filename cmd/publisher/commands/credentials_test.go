@@ -0,0 +1,177 @@
+package commands_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// fakeJWT builds an unsigned token with the given expiry; the publisher never verifies signatures
+func fakeJWT(t *testing.T, expiresAt time.Time) string {
+	t.Helper()
+	payload, err := json.Marshal(map[string]int64{"exp": expiresAt.Unix()})
+	require.NoError(t, err)
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func writeTokenFile(t *testing.T, home string, creds map[string]any) {
+	t.Helper()
+	data, err := json.Marshal(creds)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(home, commands.TokenFileName), data, 0o600))
+}
+
+func TestPublishCommand_RefreshesExpiredToken(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+	t.Chdir(t.TempDir())
+
+	freshToken := fakeJWT(t, time.Now().Add(time.Hour))
+	var publishedWith string
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/auth/none":
+			_, _ = fmt.Fprintf(w, `{"registry_token": %q, "expires_at": %d}`, freshToken, time.Now().Add(time.Hour).Unix())
+		case "/v0/publish":
+			publishedWith = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: "com.example/test", Version: "1.0.0"}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	writeTokenFile(t, home, map[string]any{
+		"token":      fakeJWT(t, time.Now().Add(-time.Hour)),
+		"method":     "none",
+		"registry":   registry.URL,
+		"expires_at": time.Now().Add(-time.Hour).Unix(),
+	})
+	require.NoError(t, os.WriteFile("server.json", []byte(`{"name": "com.example/test", "version": "1.0.0"}`), 0o600))
+
+	require.NoError(t, commands.PublishCommand([]string{}))
+	assert.Equal(t, "Bearer "+freshToken, publishedWith)
+
+	// The refreshed token is persisted for the next publish
+	data, err := os.ReadFile(filepath.Join(home, commands.TokenFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), freshToken)
+}
+
+func TestPublishCommand_ExpiredTokenWithoutRefresh(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+	t.Chdir(t.TempDir())
+
+	writeTokenFile(t, home, map[string]any{
+		"token":      "expired",
+		"method":     "dns",
+		"registry":   "http://localhost:0",
+		"expires_at": time.Now().Add(-time.Hour).Unix(),
+	})
+	require.NoError(t, os.WriteFile("server.json", []byte(`{"name": "com.example/test", "version": "1.0.0"}`), 0o600))
+
+	err := commands.PublishCommand([]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mcp-publisher login dns")
+}
+
+func TestPublishCommand_KeepsGitHubTokenOutOfTokenFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+	t.Chdir(t.TempDir())
+
+	freshToken := fakeJWT(t, time.Now().Add(time.Hour))
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/auth/github-at":
+			_, _ = fmt.Fprintf(w, `{"registry_token": %q, "expires_at": %d}`, freshToken, time.Now().Add(time.Hour).Unix())
+		case "/v0/publish":
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: "com.example/test", Version: "1.0.0"}})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// Older versions wrote the GitHub access token to the file
+	writeTokenFile(t, home, map[string]any{
+		"token":         fakeJWT(t, time.Now().Add(-time.Hour)),
+		"method":        "github",
+		"registry":      registry.URL,
+		"expires_at":    time.Now().Add(-time.Hour).Unix(),
+		"refresh_token": "gho_secret",
+	})
+	require.NoError(t, os.WriteFile("server.json", []byte(`{"name": "com.example/test", "version": "1.0.0"}`), 0o600))
+
+	require.NoError(t, commands.PublishCommand([]string{}))
+
+	data, err := os.ReadFile(filepath.Join(home, commands.TokenFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), freshToken)
+	assert.NotContains(t, string(data), "gho_secret")
+}
+
+func TestLoginCommand_RequiresKeychainOrOptIn(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "")
+	// Neither security nor secret-tool can be found
+	t.Setenv("PATH", t.TempDir())
+
+	registry := newTestRegistry(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"registry_token": %q, "expires_at": %d}`, fakeJWT(t, time.Now().Add(time.Hour)), time.Now().Add(time.Hour).Unix())
+	})
+
+	err := commands.LoginCommand([]string{"none", "--registry", registry.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--no-keychain")
+	_, err = os.Stat(filepath.Join(home, commands.TokenFileName))
+	assert.True(t, os.IsNotExist(err), "credentials aren't written to a file without opting in")
+
+	captureStdout(t, func() {
+		require.NoError(t, commands.LoginCommand([]string{"none", "--registry", registry.URL, "--no-keychain"}))
+	})
+	_, err = os.Stat(filepath.Join(home, commands.TokenFileName))
+	assert.NoError(t, err)
+}
+
+func TestLogoutCommand_RemovesTokenFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	writeTokenFile(t, home, map[string]any{"token": "abc", "method": "none"})
+	require.NoError(t, commands.LogoutCommand())
+
+	_, err := os.Stat(filepath.Join(home, commands.TokenFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLogoutCommand_RemovesLegacyTokenFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+
+	legacyFile := filepath.Join(workDir, ".mcpregistry_github_token")
+	require.NoError(t, os.WriteFile(legacyFile, []byte("gho_secret"), 0o600))
+	require.NoError(t, commands.LogoutCommand())
+
+	_, err := os.Stat(legacyFile)
+	assert.True(t, os.IsNotExist(err), "plain-text tokens are removed even when not logged in")
+}
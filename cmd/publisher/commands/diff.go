@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/serverdiff"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// DiffCommand compares a local server.json with the version currently published to the
+// registry, showing what a publish would change the way the registry's diff endpoint does
+func DiffCommand(args []string) error {
+	serverFile := "server.json"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverFile = args[0]
+		args = args[1:]
+	}
+
+	diffFlags := flag.NewFlagSet("diff", flag.ExitOnError)
+	var registryURL, version string
+	var jsonOutput bool
+	diffFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	diffFlags.StringVar(&version, "version", "latest", "Published version to compare against")
+	diffFlags.BoolVar(&jsonOutput, "json", false, "Output changes as JSON")
+	if err := diffFlags.Parse(args); err != nil {
+		return err
+	}
+	jsonOutput = jsonOutput || jsonOutputEnabled()
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+
+	var local apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &local); err != nil {
+		return fmt.Errorf("invalid %s: %w", serverFile, err)
+	}
+	if local.Name == "" {
+		return fmt.Errorf("%s has no name", serverFile)
+	}
+
+	published, err := getServerVersion(context.Background(), registryURL, local.Name, version)
+	if errors.Is(err, errNotFound) {
+		_, _ = fmt.Fprintf(statusWriter(), "%s is not published yet; publishing would create it\n", local.Name)
+		if jsonOutput {
+			// Every field is new
+			return printJSON(os.Stdout, serverdiff.Diff(&apiv0.ServerJSON{Name: local.Name}, &local))
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get published %s: %w", local.Name, err)
+	}
+
+	diff := serverdiff.Diff(&published.Server, &local)
+	if jsonOutput {
+		return printJSON(os.Stdout, diff)
+	}
+
+	_, _ = fmt.Fprintf(os.Stdout, "Comparing %s (version %s) with published %s (version %s)\n\n",
+		serverFile, local.Version, local.Name, published.Server.Version)
+	if emptyServerDiff(diff) {
+		_, _ = fmt.Fprintln(os.Stdout, "No changes")
+		return nil
+	}
+	printServerDiff(os.Stdout, diff)
+
+	if local.Version == published.Server.Version {
+		_, _ = fmt.Fprintf(os.Stdout, "\n⚠ Version %s is already published. Bump the version before publishing these changes.\n", local.Version)
+	}
+	return nil
+}
+
+// printServerDiff writes one line per change, prefixed with + (added), - (removed) or ~ (changed).
+// The fields of changed packages, remotes and inputs are indented under them.
+func printServerDiff(w io.Writer, diff *apiv0.ServerDiff) {
+	if diff.From != diff.To {
+		printFieldChanges(w, "", []apiv0.FieldChange{{Field: "version", From: nonEmpty(diff.From), To: nonEmpty(diff.To)}})
+	}
+	for _, pkg := range diff.Packages {
+		_, _ = fmt.Fprintf(w, "%s package %s %s\n", changeSymbol(pkg.Change), pkg.RegistryType, pkg.Identifier)
+		if pkg.Change != apiv0.ChangeChanged {
+			continue
+		}
+		printFieldChanges(w, "    ", pkg.Fields)
+		printInputDiffs(w, "environment variable", pkg.EnvironmentVariables)
+		printInputDiffs(w, "runtime argument", pkg.RuntimeArguments)
+		printInputDiffs(w, "package argument", pkg.PackageArguments)
+	}
+	for _, remote := range diff.Remotes {
+		_, _ = fmt.Fprintf(w, "%s remote %s %s\n", changeSymbol(remote.Change), remote.Type, remote.URL)
+		if remote.Change != apiv0.ChangeChanged {
+			continue
+		}
+		printFieldChanges(w, "    ", remote.Fields)
+		printInputDiffs(w, "header", remote.Headers)
+	}
+	printFieldChanges(w, "", diff.Metadata)
+}
+
+func printInputDiffs(w io.Writer, kind string, inputs []apiv0.InputDiff) {
+	for _, input := range inputs {
+		_, _ = fmt.Fprintf(w, "    %s %s %s\n", changeSymbol(input.Change), kind, input.Name)
+		if input.Change == apiv0.ChangeChanged {
+			printFieldChanges(w, "        ", input.Fields)
+		}
+	}
+}
+
+func printFieldChanges(w io.Writer, indent string, changes []apiv0.FieldChange) {
+	for _, change := range changes {
+		switch {
+		case change.From == nil:
+			_, _ = fmt.Fprintf(w, "%s+ %s: %s\n", indent, change.Field, formatJSONValue(change.To))
+		case change.To == nil:
+			_, _ = fmt.Fprintf(w, "%s- %s: %s\n", indent, change.Field, formatJSONValue(change.From))
+		default:
+			_, _ = fmt.Fprintf(w, "%s~ %s: %s → %s\n", indent, change.Field, formatJSONValue(change.From), formatJSONValue(change.To))
+		}
+	}
+}
+
+func changeSymbol(change string) string {
+	switch change {
+	case apiv0.ChangeAdded:
+		return "+"
+	case apiv0.ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// nonEmpty returns a version as a field value, nil when there is none
+func nonEmpty(version string) any {
+	if version == "" {
+		return nil
+	}
+	return version
+}
+
+// emptyServerDiff reports whether a diff has no changes
+func emptyServerDiff(diff *apiv0.ServerDiff) bool {
+	return diff.From == diff.To && len(diff.Packages) == 0 && len(diff.Remotes) == 0 && len(diff.Metadata) == 0
+}
+
+func formatJSONValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
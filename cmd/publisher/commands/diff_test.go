@@ -0,0 +1,127 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// captureStdout returns everything written to os.Stdout while fn runs
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	original := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = original }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(output)
+}
+
+func writeLocalServerJSON(t *testing.T, server apiv0.ServerJSON) string {
+	t.Helper()
+	data, err := json.Marshal(server)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "server.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestDiffCommand(t *testing.T) {
+	published := apiv0.ServerJSON{
+		Name:        "com.example/weather",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+		WebsiteURL:  "https://example.com",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@example/weather", Version: "1.0.0"},
+		},
+	}
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers/com.example%2Fweather/versions/latest", r.URL.EscapedPath())
+		_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: published})
+	})
+
+	local := published
+	local.Version = "1.1.0"
+	local.WebsiteURL = ""
+	local.Title = "Weather"
+	local.Packages = []model.Package{
+		{RegistryType: model.RegistryTypeNPM, Identifier: "@example/weather", Version: "1.1.0"},
+	}
+	path := writeLocalServerJSON(t, local)
+
+	var diff apiv0.ServerDiff
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.DiffCommand([]string{path, "--registry", registry.URL, "--json"}))
+	})
+	require.NoError(t, json.Unmarshal([]byte(output), &diff))
+
+	// The same diff the registry's diff endpoint returns
+	assert.Equal(t, apiv0.ServerDiff{
+		Name: "com.example/weather",
+		From: "1.0.0",
+		To:   "1.1.0",
+		Packages: []apiv0.PackageDiff{{
+			Change:       apiv0.ChangeChanged,
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Fields:       []apiv0.FieldChange{{Field: "version", From: "1.0.0", To: "1.1.0"}},
+		}},
+		Remotes: []apiv0.RemoteDiff{},
+		Metadata: []apiv0.FieldChange{
+			{Field: "title", To: "Weather"},
+			{Field: "websiteUrl", From: "https://example.com"},
+		},
+	}, diff)
+
+	output = captureStdout(t, func() {
+		require.NoError(t, commands.DiffCommand([]string{path, "--registry", registry.URL}))
+	})
+	assert.Contains(t, output, "~ version: \"1.0.0\" → \"1.1.0\"\n~ package npm @example/weather\n    ~ version: \"1.0.0\" → \"1.1.0\"\n+ title: \"Weather\"\n- websiteUrl: \"https://example.com\"\n")
+}
+
+func TestDiffCommand_WarnsWhenVersionAlreadyPublished(t *testing.T) {
+	published := apiv0.ServerJSON{Name: "com.example/weather", Description: "Weather", Version: "1.0.0"}
+	registry := newTestRegistry(t, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: published})
+	})
+
+	local := published
+	local.Description = "Weather forecasts"
+	path := writeLocalServerJSON(t, local)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.DiffCommand([]string{path, "--registry", registry.URL}))
+	})
+	assert.Contains(t, output, `~ description: "Weather" → "Weather forecasts"`)
+	assert.Contains(t, output, "Version 1.0.0 is already published")
+}
+
+func TestDiffCommand_NotPublished(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	path := writeLocalServerJSON(t, apiv0.ServerJSON{Name: "com.example/weather", Version: "1.0.0"})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.DiffCommand([]string{path, "--registry", registry.URL}))
+	})
+	assert.Contains(t, output, "not published yet")
+}
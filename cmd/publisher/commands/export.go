@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Conflict policies for import when a server version already exists in the target registry
+const (
+	onConflictSkip = "skip"
+	onConflictFail = "fail"
+)
+
+// ExportCommand writes every version of every server (or of one namespace) as
+// newline-delimited JSON, one API server response per line, for import into another registry
+func ExportCommand(args []string) error {
+	exportFlags := flag.NewFlagSet("export", flag.ExitOnError)
+	var registryURL, namespace, output string
+	var all bool
+	exportFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL to export from")
+	exportFlags.BoolVar(&all, "all", false, "Export every server in the registry")
+	exportFlags.StringVar(&namespace, "namespace", "", "Only export servers in this namespace (e.g. io.github.username)")
+	exportFlags.StringVar(&output, "output", "", "Write to this file instead of stdout")
+	if err := exportFlags.Parse(args); err != nil {
+		return err
+	}
+	if !all && namespace == "" {
+		return errors.New("nothing to export\n\nUsage: mcp-publisher export --all | --namespace=NAMESPACE [--output=FILE]")
+	}
+
+	servers, err := listAllServerVersions(context.Background(), registryURL, namespace, time.Time{})
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writeNDJSON(w, servers); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if output != "" {
+		_, _ = fmt.Fprintf(statusWriter(), "✓ Exported %d server version(s) to %s\n", len(servers), output)
+	}
+	return nil
+}
+
+// listAllServerVersions pages through every version of every server, optionally limited to a
+// namespace and to versions updated after updatedSince. Versions are ordered by name and then
+// publish time, so importing them in order leaves the same version marked latest in the
+// target registry.
+func listAllServerVersions(ctx context.Context, registryURL, namespace string, updatedSince time.Time) ([]apiv0.ServerResponse, error) {
+	params := url.Values{}
+	params.Set("limit", "100")
+	if !updatedSince.IsZero() {
+		params.Set("updated_since", updatedSince.Format(time.RFC3339Nano))
+	}
+	if namespace != "" {
+		// search is a substring match, so the namespace prefix is re-checked below
+		params.Set("search", namespace+"/")
+	}
+
+	var servers []apiv0.ServerResponse
+	for {
+		page, err := listServers(ctx, registryURL, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range page.Servers {
+			if namespace == "" || strings.HasPrefix(server.Server.Name, namespace+"/") {
+				servers = append(servers, server)
+			}
+		}
+
+		if page.Metadata.NextCursor == "" {
+			break
+		}
+		params.Set("cursor", page.Metadata.NextCursor)
+	}
+
+	sort.SliceStable(servers, func(i, j int) bool {
+		if servers[i].Server.Name != servers[j].Server.Name {
+			return servers[i].Server.Name < servers[j].Server.Name
+		}
+		iMeta, jMeta := servers[i].Meta.Official, servers[j].Meta.Official
+		if iMeta == nil || jMeta == nil {
+			return false
+		}
+		return iMeta.PublishedAt.Before(jMeta.PublishedAt)
+	})
+	return servers, nil
+}
+
+func writeNDJSON(w io.Writer, servers []apiv0.ServerResponse) error {
+	encoder := json.NewEncoder(w)
+	for _, server := range servers {
+		if err := encoder.Encode(server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importResult is the --output json form of an import, listing name@version labels
+type importResult struct {
+	DryRun    bool     `json:"dryRun"`
+	Published []string `json:"published"`
+	Skipped   []string `json:"skipped"`
+}
+
+// ImportCommand publishes every server version in an export file to a registry. Versions
+// that already exist in the target are skipped (or stop the import with --on-conflict=fail).
+func ImportCommand(args []string) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		return errors.New("export file required\n\nUsage: mcp-publisher import <file.ndjson> [--to=URL] [--on-conflict=skip|fail] [--dry-run]")
+	}
+	importFile := args[0]
+
+	importFlags := flag.NewFlagSet("import", flag.ExitOnError)
+	var targetURL, onConflict string
+	var dryRun bool
+	importFlags.StringVar(&targetURL, "to", "", "Registry URL to import into (default: the registry you are logged in to)")
+	importFlags.StringVar(&onConflict, "on-conflict", onConflictSkip, "What to do when a version already exists: skip or fail")
+	importFlags.BoolVar(&dryRun, "dry-run", false, "Show what would be imported without publishing anything")
+	if err := importFlags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if onConflict != onConflictSkip && onConflict != onConflictFail {
+		return fmt.Errorf("invalid --on-conflict value %q: must be %s or %s", onConflict, onConflictSkip, onConflictFail)
+	}
+
+	servers, err := readNDJSON(importFile)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	targetURL, token, err := targetCredentials(ctx, targetURL, dryRun)
+	if err != nil {
+		return err
+	}
+
+	action := "Importing"
+	if dryRun {
+		action = "Dry run: importing"
+	}
+	out := statusWriter()
+	_, _ = fmt.Fprintf(out, "%s %d server version(s) into %s\n", action, len(servers), targetURL)
+
+	result, err := importServers(ctx, out, targetURL, token, servers, onConflict, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		_, _ = fmt.Fprintf(out, "\nWould publish %d and skip %d server version(s)\n", len(result.Published), len(result.Skipped))
+	} else {
+		_, _ = fmt.Fprintf(out, "\n✓ Published %d and skipped %d server version(s)\n", len(result.Published), len(result.Skipped))
+	}
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, result)
+	}
+	return nil
+}
+
+// targetCredentials resolves the registry to write to and the token for it. The target must
+// be the registry the user is logged in to; a dry run only reads from the target, so it
+// doesn't need credentials when the target is given explicitly.
+func targetCredentials(ctx context.Context, targetURL string, dryRun bool) (string, string, error) {
+	if dryRun && targetURL != "" {
+		return targetURL, "", nil
+	}
+
+	token, loggedInURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if targetURL == "" {
+		targetURL = loggedInURL
+	}
+	if strings.TrimSuffix(targetURL, "/") != strings.TrimSuffix(loggedInURL, "/") {
+		return "", "", fmt.Errorf("logged in to %s, not %s. Run 'mcp-publisher login <method> --registry=%s' first", loggedInURL, targetURL, targetURL)
+	}
+	return targetURL, token, nil
+}
+
+// importServers publishes each server version that doesn't exist in the target yet, in order
+func importServers(ctx context.Context, out io.Writer, targetURL, token string, servers []apiv0.ServerJSON, onConflict string, dryRun bool) (*importResult, error) {
+	result := &importResult{DryRun: dryRun, Published: []string{}, Skipped: []string{}}
+	for _, server := range servers {
+		label := server.Name + "@" + server.Version
+
+		_, err := getServerVersion(ctx, targetURL, server.Name, server.Version)
+		switch {
+		case err == nil:
+			if onConflict == onConflictFail {
+				return nil, fmt.Errorf("%s already exists in %s", label, targetURL)
+			}
+			_, _ = fmt.Fprintf(out, "  skip     %s (already exists)\n", label)
+			result.Skipped = append(result.Skipped, label)
+			continue
+		case !errors.Is(err, errNotFound):
+			return nil, fmt.Errorf("failed to check %s: %w", label, err)
+		}
+
+		if dryRun {
+			_, _ = fmt.Fprintf(out, "  publish  %s\n", label)
+			result.Published = append(result.Published, label)
+			continue
+		}
+
+		serverData, err := json.Marshal(server)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing %s: %w", label, err)
+		}
+		if _, err := publishToRegistry(targetURL, serverData, token, nil); err != nil {
+			return nil, fmt.Errorf("failed to publish %s after importing %d version(s): %w", label, len(result.Published), err)
+		}
+		_, _ = fmt.Fprintf(out, "  ✓        %s\n", label)
+		result.Published = append(result.Published, label)
+	}
+	return result, nil
+}
+
+// readNDJSON reads an export file. Each line is an API server response; only the server
+// document is imported, since registry metadata is assigned by the target on publish.
+func readNDJSON(path string) ([]apiv0.ServerJSON, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var servers []apiv0.ServerJSON
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var response apiv0.ServerResponse
+		if err := json.Unmarshal([]byte(line), &response); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, lineNumber, err)
+		}
+		if response.Server.Name == "" || response.Server.Version == "" {
+			return nil, fmt.Errorf("%s line %d: missing server name or version", path, lineNumber)
+		}
+		servers = append(servers, response.Server)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return servers, nil
+}
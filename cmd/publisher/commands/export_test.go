@@ -0,0 +1,141 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func serverVersion(name, version string, publishedAt time.Time) apiv0.ServerResponse {
+	return apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{Name: name, Description: "Test server", Version: version},
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: publishedAt},
+		},
+	}
+}
+
+func TestExportCommand_NamespaceInPublishOrder(t *testing.T) {
+	now := time.Now()
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("version"), "export must request every version")
+		response := apiv0.ServerListResponse{}
+		if r.URL.Query().Get("cursor") == "" {
+			response.Servers = []apiv0.ServerResponse{
+				serverVersion("com.example/weather", "2.0.0", now),
+				serverVersion("com.example.other/weather", "1.0.0", now),
+			}
+			response.Metadata.NextCursor = "next"
+		} else {
+			response.Servers = []apiv0.ServerResponse{
+				serverVersion("com.example/weather", "1.0.0", now.Add(-time.Hour)),
+			}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	output := filepath.Join(t.TempDir(), "dump.ndjson")
+	require.NoError(t, commands.ExportCommand([]string{"--registry", registry.URL, "--namespace", "com.example", "--output", output}))
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second apiv0.ServerResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	assert.Equal(t, "1.0.0", first.Server.Version)
+	assert.Equal(t, "2.0.0", second.Server.Version)
+}
+
+func TestExportCommand_RequiresScope(t *testing.T) {
+	err := commands.ExportCommand([]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--all")
+}
+
+func writeExportFile(t *testing.T, servers ...apiv0.ServerResponse) string {
+	t.Helper()
+	var lines []string
+	for _, server := range servers {
+		data, err := json.Marshal(server)
+		require.NoError(t, err)
+		lines = append(lines, string(data))
+	}
+	path := filepath.Join(t.TempDir(), "dump.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	return path
+}
+
+func TestImportCommand_SkipsExistingVersions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	var published []string
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v0/publish":
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			var server apiv0.ServerJSON
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&server))
+			published = append(published, server.Version)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: server})
+		case strings.HasSuffix(r.URL.Path, "/versions/1.0.0"):
+			_ = json.NewEncoder(w).Encode(serverVersion("com.example/weather", "1.0.0", time.Now()))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	writeTokenFile(t, home, map[string]any{"token": "test-token", "method": "none", "registry": registry.URL})
+
+	path := writeExportFile(t,
+		serverVersion("com.example/weather", "1.0.0", time.Now()),
+		serverVersion("com.example/weather", "2.0.0", time.Now()),
+	)
+
+	require.NoError(t, commands.ImportCommand([]string{path, "--to", registry.URL}))
+	assert.Equal(t, []string{"2.0.0"}, published)
+
+	err := commands.ImportCommand([]string{path, "--to", registry.URL, "--on-conflict", "fail"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestImportCommand_DryRunDoesNotPublish(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEqual(t, "/v0/publish", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	path := writeExportFile(t, serverVersion("com.example/weather", "1.0.0", time.Now()))
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.ImportCommand([]string{path, "--to", registry.URL, "--dry-run"}))
+	})
+	assert.Contains(t, output, "Would publish 1 and skip 0")
+}
+
+func TestImportCommand_RejectsOtherRegistry(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+	writeTokenFile(t, home, map[string]any{"token": "test-token", "method": "none", "registry": "https://staging.example.com"})
+
+	path := writeExportFile(t, serverVersion("com.example/weather", "1.0.0", time.Now()))
+	err := commands.ImportCommand([]string{path, "--to", "https://registry.example.com"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "logged in to https://staging.example.com")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
@@ -15,25 +16,50 @@ import (
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
-func InitCommand() error {
+func InitCommand(args []string) error {
+	initFlags := flag.NewFlagSet("init", flag.ExitOnError)
+	var interactive bool
+	initFlags.BoolVar(&interactive, "interactive", false, "Answer questions to fill in server.json")
+	initFlags.BoolVar(&interactive, "i", false, "Shorthand for --interactive")
+	fromImage := initFlags.String("from-image", "", "Pre-fill server.json from a Docker image (e.g. username/image:tag)")
+	if err := initFlags.Parse(args); err != nil {
+		return err
+	}
+	out := statusWriter()
+
 	// Check if server.json already exists
 	if _, err := os.Stat("server.json"); err == nil {
 		return errors.New("server.json already exists")
 	}
 
+	if *fromImage != "" {
+		return initFromImage(*fromImage)
+	}
+
+	if interactive {
+		server, err := runInitWizard(newPrompter(os.Stdin, out))
+		if err != nil {
+			return err
+		}
+		if err := writeServerJSON(server); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, "\nCreated server.json")
+		if err := reportWizardProblems(out, server); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, "\nPublish with:")
+		_, _ = fmt.Fprintln(out, "  mcp-publisher login github  # or your preferred auth method")
+		_, _ = fmt.Fprintln(out, "  mcp-publisher publish")
+		return printServerJSONResult(server)
+	}
+
 	// Try to detect values from environment
 	name := detectServerName()
 	description := detectDescription()
 	version := "1.0.0"
 	repoURL := detectRepoURL()
-	repoSource := "github"
-	if repoURL != "" && !strings.Contains(repoURL, "github.com") {
-		if strings.Contains(repoURL, "gitlab.com") {
-			repoSource = "gitlab"
-		} else {
-			repoSource = "git"
-		}
-	}
+	repoSource := detectRepoSource(repoURL)
 
 	packageType := detectPackageType()
 	packageIdentifier := detectPackageIdentifier(name, packageType)
@@ -59,7 +85,33 @@ func InitCommand() error {
 		packageType, packageIdentifier, version, envVars,
 	)
 
-	// Write to file
+	if err := writeServerJSON(&server); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out, "Created server.json")
+	_, _ = fmt.Fprintln(out, "\nEdit server.json to update:")
+	_, _ = fmt.Fprintln(out, "  • Server name and description")
+	_, _ = fmt.Fprintln(out, "  • Package details")
+	_, _ = fmt.Fprintln(out, "  • Environment variables")
+	_, _ = fmt.Fprintln(out, "\nOr run 'mcp-publisher init --interactive' to be asked for each value.")
+	_, _ = fmt.Fprintln(out, "\nThen publish with:")
+	_, _ = fmt.Fprintln(out, "  mcp-publisher login github  # or your preferred auth method")
+	_, _ = fmt.Fprintln(out, "  mcp-publisher publish")
+
+	return printServerJSONResult(&server)
+}
+
+// printServerJSONResult prints the generated server.json with --output json
+func printServerJSONResult(server *apiv0.ServerJSON) error {
+	if !jsonOutputEnabled() {
+		return nil
+	}
+	return printJSON(os.Stdout, server)
+}
+
+// writeServerJSON writes server.json to the current directory
+func writeServerJSON(server *apiv0.ServerJSON) error {
 	jsonData, err := json.MarshalIndent(server, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling JSON: %w", err)
@@ -69,16 +121,6 @@ func InitCommand() error {
 	if err != nil {
 		return fmt.Errorf("error writing file: %w", err)
 	}
-
-	_, _ = fmt.Fprintln(os.Stdout, "Created server.json")
-	_, _ = fmt.Fprintln(os.Stdout, "\nEdit server.json to update:")
-	_, _ = fmt.Fprintln(os.Stdout, "  • Server name and description")
-	_, _ = fmt.Fprintln(os.Stdout, "  • Package details")
-	_, _ = fmt.Fprintln(os.Stdout, "  • Environment variables")
-	_, _ = fmt.Fprintln(os.Stdout, "\nThen publish with:")
-	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher login github  # or your preferred auth method")
-	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher publish")
-
 	return nil
 }
 
@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Image labels read when scaffolding from a Docker image
+const (
+	labelServerName  = "io.modelcontextprotocol.server.name"
+	labelTitle       = "org.opencontainers.image.title"
+	labelDescription = "org.opencontainers.image.description"
+	labelVersion     = "org.opencontainers.image.version"
+	labelSource      = "org.opencontainers.image.source"
+	labelURL         = "org.opencontainers.image.url"
+)
+
+// imageRuntimeEnv are environment variables set by base images and language runtimes rather
+// than by the server, so they are not offered as configuration. Entries ending in "_" match
+// every variable with that prefix, others match one name exactly.
+var imageRuntimeEnv = []string{
+	"PATH", "HOME", "HOSTNAME", "TERM", "LANG", "LANGUAGE", "LC_", "TZ", "SHLVL", "PWD",
+	"NODE_", "NPM_", "YARN_", "PYTHON_", "PYTHONPATH", "PYTHONUNBUFFERED", "PYTHONDONTWRITEBYTECODE", "PYTHONIOENCODING",
+	"PIP_", "UV_", "GPG_KEY", "JAVA_", "GOLANG_", "GOPATH", "GOROOT", "GOTOOLCHAIN", "DOTNET_", "ASPNETCORE_", "SSL_CERT_",
+}
+
+// imageInspect is the subset of `docker image inspect` output used to scaffold server.json
+type imageInspect struct {
+	Config struct {
+		Labels       map[string]string   `json:"Labels"`
+		Env          []string            `json:"Env"`
+		Entrypoint   []string            `json:"Entrypoint"`
+		Cmd          []string            `json:"Cmd"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+	} `json:"Config"`
+}
+
+// inspectImage returns the configuration of a local image, pulling it first if needed
+func inspectImage(ctx context.Context, image string) (*imageInspect, error) {
+	output, err := runDocker(ctx, "image", "inspect", image)
+	if err != nil {
+		if _, pullErr := runDocker(ctx, "pull", image); pullErr != nil {
+			return nil, fmt.Errorf("failed to pull image %s: %w", image, pullErr)
+		}
+		output, err = runDocker(ctx, "image", "inspect", image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect image %s: %w", image, err)
+		}
+	}
+
+	var inspected []imageInspect
+	if err := json.Unmarshal(output, &inspected); err != nil {
+		return nil, fmt.Errorf("failed to parse docker inspect output: %w", err)
+	}
+	if len(inspected) == 0 {
+		return nil, fmt.Errorf("docker inspect returned no data for %s", image)
+	}
+	return &inspected[0], nil
+}
+
+func runDocker(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
+
+// serverJSONFromImage maps image metadata onto a server.json. Labels fill in the server
+// metadata, the image reference becomes a canonical OCI package, and environment
+// variables declared in the image become package configuration.
+func serverJSONFromImage(image string, inspected *imageInspect) (*apiv0.ServerJSON, error) {
+	ref, err := registries.ParseOCIReference(image)
+	if err != nil {
+		return nil, err
+	}
+	labels := inspected.Config.Labels
+
+	name := labels[labelServerName]
+	if name == "" {
+		name = detectServerName()
+	}
+
+	description := labels[labelDescription]
+	if description == "" {
+		description = detectDescription()
+	}
+	description = truncate(description, maxDescriptionLength)
+
+	version := labels[labelVersion]
+	if version == "" {
+		version = strings.TrimPrefix(ref.Tag, "v")
+	}
+	if version == "" || version == "latest" {
+		// "latest" is reserved by the registry, so fall back to the template default
+		version = "1.0.0"
+	}
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        name,
+		Title:       labels[labelTitle],
+		Description: description,
+		Version:     version,
+	}
+	if source := strings.TrimSuffix(labels[labelSource], ".git"); source != "" {
+		server.Repository = model.Repository{URL: source, Source: detectRepoSource(source)}
+	}
+	if website := labels[labelURL]; strings.HasPrefix(website, "https://") {
+		server.WebsiteURL = website
+	}
+
+	pkg := model.Package{
+		RegistryType:         model.RegistryTypeOCI,
+		Identifier:           ref.String(),
+		RunTimeHint:          model.RuntimeHintDocker,
+		Transport:            model.Transport{Type: model.TransportTypeStdio},
+		EnvironmentVariables: imageEnvironmentVariables(inspected.Config.Env),
+	}
+	transportType := imageTransportType(inspected)
+	if transportType != model.TransportTypeStdio {
+		port := firstExposedTCPPort(inspected.Config.ExposedPorts)
+		if port == "" {
+			port = "8080"
+		}
+		path := "/mcp"
+		if transportType == model.TransportTypeSSE {
+			path = "/sse"
+		}
+		pkg.Transport = model.Transport{
+			Type: transportType,
+			URL:  fmt.Sprintf("http://localhost:%s%s", port, path),
+		}
+	}
+	server.Packages = []model.Package{pkg}
+
+	return server, nil
+}
+
+// imageTransportType picks the transport from a --transport flag in the image's entrypoint
+// or command, falling back to streamable-http for images that expose a port and stdio otherwise
+func imageTransportType(inspected *imageInspect) string {
+	command := slices.Concat(inspected.Config.Entrypoint, inspected.Config.Cmd)
+	for i, arg := range command {
+		value, ok := strings.CutPrefix(arg, "--transport=")
+		if !ok && arg == "--transport" && i+1 < len(command) {
+			value, ok = command[i+1], true
+		}
+		if !ok {
+			continue
+		}
+		switch value {
+		case model.TransportTypeStdio, model.TransportTypeSSE, model.TransportTypeStreamableHTTP:
+			return value
+		case "http":
+			return model.TransportTypeStreamableHTTP
+		}
+	}
+
+	if firstExposedTCPPort(inspected.Config.ExposedPorts) != "" {
+		return model.TransportTypeStreamableHTTP
+	}
+	return model.TransportTypeStdio
+}
+
+// imageEnvironmentVariables converts the image's ENV entries into package inputs. Values
+// baked into the image become defaults; names that look like credentials are marked secret.
+func imageEnvironmentVariables(env []string) []model.KeyValueInput {
+	var inputs []model.KeyValueInput
+	for _, entry := range env {
+		name, value, _ := strings.Cut(entry, "=")
+		if name == "" || isImageRuntimeEnv(name) {
+			continue
+		}
+
+		upper := strings.ToUpper(name)
+		isSecret := strings.Contains(upper, "KEY") || strings.Contains(upper, "TOKEN") ||
+			strings.Contains(upper, "SECRET") || strings.Contains(upper, "PASSWORD")
+
+		input := model.Input{
+			IsRequired: value == "",
+			IsSecret:   isSecret,
+			Format:     model.FormatString,
+		}
+		if !isSecret {
+			input.Default = value
+		}
+
+		inputs = append(inputs, model.KeyValueInput{
+			Name:               name,
+			InputWithVariables: model.InputWithVariables{Input: input},
+		})
+	}
+	return inputs
+}
+
+func isImageRuntimeEnv(name string) bool {
+	for _, runtimeName := range imageRuntimeEnv {
+		if name == runtimeName || strings.HasSuffix(runtimeName, "_") && strings.HasPrefix(name, runtimeName) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstExposedTCPPort returns the lowest exposed TCP port, or "" if none are exposed
+func firstExposedTCPPort(ports map[string]struct{}) string {
+	var tcpPorts []string
+	for port := range ports {
+		number, protocol, _ := strings.Cut(port, "/")
+		if protocol == "" || protocol == "tcp" {
+			tcpPorts = append(tcpPorts, number)
+		}
+	}
+	if len(tcpPorts) == 0 {
+		return ""
+	}
+	sort.Slice(tcpPorts, func(i, j int) bool {
+		if len(tcpPorts[i]) != len(tcpPorts[j]) {
+			return len(tcpPorts[i]) < len(tcpPorts[j])
+		}
+		return tcpPorts[i] < tcpPorts[j]
+	})
+	return tcpPorts[0]
+}
+
+// initFromImage writes a server.json pre-filled from the image's labels and configuration
+func initFromImage(image string) error {
+	ctx := context.Background()
+
+	inspected, err := inspectImage(ctx, image)
+	if err != nil {
+		return err
+	}
+
+	server, err := serverJSONFromImage(image, inspected)
+	if err != nil {
+		return err
+	}
+
+	if err := writeServerJSON(server); err != nil {
+		return err
+	}
+
+	out := statusWriter()
+	_, _ = fmt.Fprintf(out, "Created server.json from %s\n", server.Packages[0].Identifier)
+	if inspected.Config.Labels[labelServerName] == "" {
+		_, _ = fmt.Fprintf(out, "\nThe image has no %s label. Add it with the server name, or the registry will reject the package:\n", labelServerName)
+		_, _ = fmt.Fprintf(out, "  LABEL %s=\"%s\"\n", labelServerName, server.Name)
+	}
+	if err := reportWizardProblems(out, server); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, "\nReview the environment variable descriptions, then publish with:")
+	_, _ = fmt.Fprintln(out, "  mcp-publisher login github  # or your preferred auth method")
+	_, _ = fmt.Fprintln(out, "  mcp-publisher publish")
+	return printServerJSONResult(server)
+}
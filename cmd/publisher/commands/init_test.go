@@ -0,0 +1,175 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// withStdin replaces os.Stdin with the given answers for the duration of the test
+func withStdin(t *testing.T, answers ...string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(answers, "\n")+"\n"), 0o600))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	original := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = original
+		f.Close()
+	})
+}
+
+func readServerJSON(t *testing.T) apiv0.ServerJSON {
+	t.Helper()
+	data, err := os.ReadFile("server.json")
+	require.NoError(t, err)
+
+	var server apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal(data, &server))
+	return server
+}
+
+func TestInitCommand_InteractivePackage(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	withStdin(t,
+		"io.github.example/weather", // name
+		"Weather forecasts",         // description
+		"",                          // version (default 1.0.0)
+		"https://github.com/example/weather",
+		"package",
+		"npm",
+		"@example/weather",
+		"", // package version (default 1.0.0)
+		"", // transport (default stdio)
+		"y", "WEATHER_API_KEY", "API key for the weather service", "y", "y",
+		"n",
+	)
+
+	require.NoError(t, commands.InitCommand([]string{"--interactive"}))
+
+	server := readServerJSON(t)
+	assert.Equal(t, model.CurrentSchemaURL, server.Schema)
+	assert.Equal(t, "io.github.example/weather", server.Name)
+	assert.Equal(t, "1.0.0", server.Version)
+	assert.Equal(t, "github", server.Repository.Source)
+	require.Len(t, server.Packages, 1)
+	assert.Equal(t, "@example/weather", server.Packages[0].Identifier)
+	assert.Equal(t, model.TransportTypeStdio, server.Packages[0].Transport.Type)
+	require.Len(t, server.Packages[0].EnvironmentVariables, 1)
+	assert.True(t, server.Packages[0].EnvironmentVariables[0].IsSecret)
+}
+
+func TestInitCommand_InteractiveRemoteRepromptsInvalidAnswers(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	withStdin(t,
+		"not-a-valid-name",    // rejected: no namespace
+		"com.example/weather", // name
+		"Weather forecasts",
+		"2.0.0",
+		"", // no repository
+		"remote",
+		"",                       // transport (default streamable-http)
+		"http://mcp.example.com", // rejected: not https
+		"https://mcp.example.com/mcp",
+		"n",
+	)
+
+	require.NoError(t, commands.InitCommand([]string{"-i"}))
+
+	server := readServerJSON(t)
+	assert.Equal(t, "com.example/weather", server.Name)
+	assert.Empty(t, server.Packages)
+	require.Len(t, server.Remotes, 1)
+	assert.Equal(t, "https://mcp.example.com/mcp", server.Remotes[0].URL)
+}
+
+func TestInitCommand_RefusesToOverwrite(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("server.json", []byte("{}"), 0o600))
+
+	err := commands.InitCommand([]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+// withFakeDocker puts a docker executable on PATH that prints the given inspect output
+func withFakeDocker(t *testing.T, inspectOutput string) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "inspect.json"), []byte(inspectOutput), 0o600))
+	script := "#!/bin/sh\ncat '" + filepath.Join(dir, "inspect.json") + "'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o700)) //nolint:gosec // test helper must be executable
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestInitCommand_FromImage(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withFakeDocker(t, `[{"Config": {
+		"Labels": {
+			"io.modelcontextprotocol.server.name": "io.github.example/weather",
+			"org.opencontainers.image.description": "Weather forecasts",
+			"org.opencontainers.image.source": "https://github.com/example/weather.git"
+		},
+		"Env": ["PATH=/usr/local/bin:/usr/bin", "NODE_VERSION=22.1.0", "GOPATH=/go", "WEATHER_API_KEY=", "WEATHER_UNITS=metric", "GOOGLE_API_KEY=", "HOMEPAGE_URL=https://example.com"],
+		"Entrypoint": ["node", "dist/index.js"]
+	}}]`)
+
+	require.NoError(t, commands.InitCommand([]string{"--from-image", "example/weather:v2.1.0"}))
+
+	server := readServerJSON(t)
+	assert.Equal(t, "io.github.example/weather", server.Name)
+	assert.Equal(t, "Weather forecasts", server.Description)
+	assert.Equal(t, "2.1.0", server.Version)
+	assert.Equal(t, "https://github.com/example/weather", server.Repository.URL)
+	assert.Equal(t, "github", server.Repository.Source)
+
+	require.Len(t, server.Packages, 1)
+	pkg := server.Packages[0]
+	assert.Equal(t, model.RegistryTypeOCI, pkg.RegistryType)
+	assert.Equal(t, "docker.io/example/weather:v2.1.0", pkg.Identifier)
+	assert.Equal(t, model.RuntimeHintDocker, pkg.RunTimeHint)
+	assert.Equal(t, model.TransportTypeStdio, pkg.Transport.Type)
+
+	require.Len(t, pkg.EnvironmentVariables, 4, "runtime variables are left out, but not the server's that share their prefixes")
+	assert.Equal(t, "WEATHER_API_KEY", pkg.EnvironmentVariables[0].Name)
+	assert.True(t, pkg.EnvironmentVariables[0].IsSecret)
+	assert.True(t, pkg.EnvironmentVariables[0].IsRequired)
+	assert.Equal(t, "WEATHER_UNITS", pkg.EnvironmentVariables[1].Name)
+	assert.Equal(t, "metric", pkg.EnvironmentVariables[1].Default)
+	assert.False(t, pkg.EnvironmentVariables[1].IsRequired)
+	assert.Equal(t, "GOOGLE_API_KEY", pkg.EnvironmentVariables[2].Name)
+	assert.Equal(t, "HOMEPAGE_URL", pkg.EnvironmentVariables[3].Name)
+}
+
+func TestInitCommand_FromImageDetectsHTTPTransport(t *testing.T) {
+	t.Chdir(t.TempDir())
+	withFakeDocker(t, `[{"Config": {
+		"Labels": {"org.opencontainers.image.version": "3.0.0"},
+		"ExposedPorts": {"9000/tcp": {}, "53/udp": {}},
+		"Cmd": ["--transport", "sse"]
+	}}]`)
+
+	require.NoError(t, commands.InitCommand([]string{"--from-image", "ghcr.io/example/weather:latest"}))
+
+	server := readServerJSON(t)
+	assert.Equal(t, "3.0.0", server.Version)
+	require.Len(t, server.Packages, 1)
+	assert.Equal(t, "ghcr.io/example/weather:latest", server.Packages[0].Identifier)
+	assert.Equal(t, model.TransportTypeSSE, server.Packages[0].Transport.Type)
+	assert.Equal(t, "http://localhost:9000/sse", server.Packages[0].Transport.URL)
+}
@@ -0,0 +1,345 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const maxDescriptionLength = 100
+
+// prompter asks questions on a terminal and reads back single-line answers
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// ask prompts for a free-text answer, returning defaultValue when the answer is empty
+func (p *prompter) ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		_, _ = fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	answer := strings.TrimSpace(line)
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// askUntil repeats a question until check accepts the answer
+func (p *prompter) askUntil(question, defaultValue string, check func(string) error) (string, error) {
+	for {
+		answer, err := p.ask(question, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		if err := check(answer); err != nil {
+			_, _ = fmt.Fprintf(p.out, "  %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// choose asks for one of a fixed set of options
+func (p *prompter) choose(question string, options []string, defaultValue string) (string, error) {
+	return p.askUntil(fmt.Sprintf("%s (%s)", question, strings.Join(options, "/")), defaultValue, func(answer string) error {
+		if !slices.Contains(options, answer) {
+			return fmt.Errorf("please choose one of: %s", strings.Join(options, ", "))
+		}
+		return nil
+	})
+}
+
+// confirm asks a yes/no question
+func (p *prompter) confirm(question string, defaultYes bool) (bool, error) {
+	defaultValue := "n"
+	if defaultYes {
+		defaultValue = "y"
+	}
+	answer, err := p.choose(question, []string{"y", "n"}, defaultValue)
+	if err != nil {
+		return false, err
+	}
+	return answer == "y", nil
+}
+
+// runInitWizard interactively builds a server.json, using the auto-detected values as defaults
+func runInitWizard(p *prompter) (*apiv0.ServerJSON, error) {
+	_, _ = fmt.Fprintln(p.out, "This will walk you through creating a server.json. Press enter to accept the [default].")
+	_, _ = fmt.Fprintln(p.out)
+
+	name, err := p.askUntil("Server name (reverse-DNS namespace/name)", detectServerName(), func(answer string) error {
+		if strings.Count(answer, "/") != 1 || strings.HasPrefix(answer, "/") || strings.HasSuffix(answer, "/") {
+			return fmt.Errorf("name must look like 'io.github.username/server-name'")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := p.askUntil("Description", detectDescription(), func(answer string) error {
+		if len([]rune(answer)) > maxDescriptionLength {
+			return fmt.Errorf("description must be at most %d characters", maxDescriptionLength)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := p.askUntil("Version", "1.0.0", func(answer string) error {
+		if answer == "latest" {
+			return fmt.Errorf("'latest' is reserved, use a specific version")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        name,
+		Description: description,
+		Version:     version,
+	}
+
+	defaultRepoURL := detectRepoURL()
+	if strings.Contains(defaultRepoURL, "YOUR_USERNAME") {
+		// Don't offer the non-interactive template's placeholder as an answer
+		defaultRepoURL = ""
+	}
+	repoURL, err := p.ask("Repository URL (optional)", defaultRepoURL)
+	if err != nil {
+		return nil, err
+	}
+	if repoURL != "" {
+		server.Repository = model.Repository{URL: repoURL, Source: detectRepoSource(repoURL)}
+	}
+
+	deployment, err := p.choose("How do clients run your server? A package they install, or a remote URL", []string{"package", "remote"}, "package")
+	if err != nil {
+		return nil, err
+	}
+
+	if deployment == "remote" {
+		remote, err := askRemote(p)
+		if err != nil {
+			return nil, err
+		}
+		server.Remotes = []model.Transport{*remote}
+	} else {
+		pkg, err := askPackage(p, name, version)
+		if err != nil {
+			return nil, err
+		}
+		server.Packages = []model.Package{*pkg}
+	}
+
+	return server, nil
+}
+
+func askPackage(p *prompter, serverName, serverVersion string) (*model.Package, error) {
+	registryTypes := []string{model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeOCI, model.RegistryTypeNuGet, model.RegistryTypeMCPB}
+	registryType, err := p.choose("Package registry", registryTypes, detectPackageType())
+	if err != nil {
+		return nil, err
+	}
+
+	identifierQuestion := "Package name"
+	switch registryType {
+	case model.RegistryTypeOCI:
+		identifierQuestion = "Image name (e.g. username/image)"
+	case model.RegistryTypeMCPB:
+		identifierQuestion = "Download URL of the .mcpb file"
+	}
+	identifier, err := p.askUntil(identifierQuestion, detectPackageIdentifier(serverName, registryType), func(answer string) error {
+		if answer == "" || strings.Contains(answer, " ") {
+			return fmt.Errorf("a value without spaces is required")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	packageVersion, err := p.ask("Package version", serverVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reuse the non-interactive template so package conventions (e.g. canonical OCI references) stay in one place
+	pkg := createServerJSON("", "", "", "", "", registryType, identifier, packageVersion, nil).Packages[0]
+
+	if registryType == model.RegistryTypeMCPB {
+		sha, err := p.askUntil("SHA-256 of the .mcpb file", "", func(answer string) error {
+			if len(answer) != 64 {
+				return fmt.Errorf("expected a 64 character hex digest (run: shasum -a 256 <file>)")
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		pkg.FileSHA256 = sha
+	}
+
+	transportType, err := p.choose("Transport", []string{model.TransportTypeStdio, model.TransportTypeStreamableHTTP, model.TransportTypeSSE}, model.TransportTypeStdio)
+	if err != nil {
+		return nil, err
+	}
+	pkg.Transport = model.Transport{Type: transportType}
+	if transportType != model.TransportTypeStdio {
+		transportURL, err := p.askUntil("URL the server listens on once started", "http://localhost:8080/mcp", requireValue)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Transport.URL = transportURL
+	}
+
+	envVars, err := askKeyValueInputs(p, "environment variable")
+	if err != nil {
+		return nil, err
+	}
+	pkg.EnvironmentVariables = envVars
+
+	return &pkg, nil
+}
+
+func askRemote(p *prompter) (*model.Transport, error) {
+	transportType, err := p.choose("Transport", []string{model.TransportTypeStreamableHTTP, model.TransportTypeSSE}, model.TransportTypeStreamableHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteURL, err := p.askUntil("Remote URL (must be on your namespace's domain)", "", func(answer string) error {
+		if !strings.HasPrefix(answer, "https://") {
+			return fmt.Errorf("remote URLs must use https")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := askKeyValueInputs(p, "HTTP header")
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Transport{Type: transportType, URL: remoteURL, Headers: headers}, nil
+}
+
+// askKeyValueInputs collects environment variables or headers, including whether they are secrets
+func askKeyValueInputs(p *prompter, kind string) ([]model.KeyValueInput, error) {
+	var inputs []model.KeyValueInput
+	for {
+		another, err := p.confirm(fmt.Sprintf("Add %s?", articleFor(kind, len(inputs))), false)
+		if err != nil {
+			return nil, err
+		}
+		if !another {
+			return inputs, nil
+		}
+
+		name, err := p.askUntil("  Name", "", requireValue)
+		if err != nil {
+			return nil, err
+		}
+		description, err := p.ask("  Description", "")
+		if err != nil {
+			return nil, err
+		}
+		required, err := p.confirm("  Required?", true)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := p.confirm("  Secret (API key, token, password)?", false)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, model.KeyValueInput{
+			Name: name,
+			InputWithVariables: model.InputWithVariables{
+				Input: model.Input{
+					Description: description,
+					IsRequired:  required,
+					IsSecret:    secret,
+					Format:      model.FormatString,
+				},
+			},
+		})
+	}
+}
+
+func articleFor(kind string, existing int) string {
+	if existing > 0 {
+		return "another " + kind
+	}
+	if strings.ContainsAny(kind[:1], "aeiouAEIOU") {
+		return "an " + kind
+	}
+	return "a " + kind
+}
+
+func requireValue(answer string) error {
+	if answer == "" {
+		return fmt.Errorf("a value is required")
+	}
+	return nil
+}
+
+// detectRepoSource maps a repository URL to the source identifier the registry expects
+func detectRepoSource(repoURL string) string {
+	switch {
+	case strings.Contains(repoURL, "github.com"):
+		return "github"
+	case strings.Contains(repoURL, "gitlab.com"):
+		return "gitlab"
+	default:
+		return "git"
+	}
+}
+
+// reportWizardProblems runs offline validation over the generated server.json so the
+// publisher learns about problems now rather than at publish time
+func reportWizardProblems(w io.Writer, server *apiv0.ServerJSON) error {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+
+	problems := validateServerData(context.Background(), data, false)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, "\n⚠ The generated server.json has problems you'll need to fix before publishing:")
+	for _, problem := range problems {
+		_, _ = fmt.Fprintf(w, "  • %s\n", problem)
+	}
+	return nil
+}
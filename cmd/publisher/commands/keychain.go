@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	keychainService = "mcp-publisher"
+	keychainAccount = "registry-credentials"
+
+	// noKeychainEnv disables keychain storage entirely, e.g. on shared CI runners
+	noKeychainEnv = "MCP_PUBLISHER_NO_KEYCHAIN"
+)
+
+// errKeychainUnavailable is returned when no supported OS keychain is present
+var errKeychainUnavailable = errors.New("OS keychain is not available")
+
+// keychain stores a single secret in the operating system's credential store. It shells
+// out to the platform tooling (security on macOS, secret-tool on Linux) so the publisher
+// stays a dependency-free static binary.
+type keychain interface {
+	Get(ctx context.Context) (string, error)
+	Set(ctx context.Context, secret string) error
+	Delete(ctx context.Context) error
+}
+
+// newKeychain returns the keychain for the current platform, or errKeychainUnavailable
+func newKeychain() (keychain, error) {
+	if os.Getenv(noKeychainEnv) != "" {
+		return nil, errKeychainUnavailable
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err == nil {
+			return macOSKeychain{}, nil
+		}
+	case "linux", "freebsd", "openbsd":
+		if _, err := exec.LookPath("secret-tool"); err == nil {
+			return secretServiceKeychain{}, nil
+		}
+	}
+	return nil, errKeychainUnavailable
+}
+
+// macOSKeychain uses the login keychain via the security CLI
+type macOSKeychain struct{}
+
+func (macOSKeychain) Get(ctx context.Context) (string, error) {
+	out, err := runKeychainCommand(ctx, "", "security", "find-generic-password", "-s", keychainService, "-a", keychainAccount, "-w")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (m macOSKeychain) Set(ctx context.Context, secret string) error {
+	// security only takes the secret as an argument, so send the command on stdin to its
+	// interactive mode, which keeps it out of the process list, hex-encoded so it needs no quoting
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", keychainService, keychainAccount, hex.EncodeToString([]byte(secret)))
+	if _, err := runKeychainCommand(ctx, command, "security", "-i"); err != nil {
+		return err
+	}
+	// Interactive mode exits successfully whether or not the command worked
+	stored, err := m.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if stored != secret {
+		return errors.New("security did not store the credentials")
+	}
+	return nil
+}
+
+func (macOSKeychain) Delete(ctx context.Context) error {
+	_, err := runKeychainCommand(ctx, "", "security", "delete-generic-password", "-s", keychainService, "-a", keychainAccount)
+	return err
+}
+
+// secretServiceKeychain uses the freedesktop Secret Service (GNOME Keyring, KWallet) via secret-tool
+type secretServiceKeychain struct{}
+
+func (secretServiceKeychain) Get(ctx context.Context) (string, error) {
+	out, err := runKeychainCommand(ctx, "", "secret-tool", "lookup", "service", keychainService, "account", keychainAccount)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (secretServiceKeychain) Set(ctx context.Context, secret string) error {
+	// secret-tool reads the secret from stdin, which keeps it out of the process list
+	_, err := runKeychainCommand(ctx, secret, "secret-tool", "store", "--label=MCP Publisher registry credentials", "service", keychainService, "account", keychainAccount)
+	return err
+}
+
+func (secretServiceKeychain) Delete(ctx context.Context) error {
+	_, err := runKeychainCommand(ctx, "", "secret-tool", "clear", "service", keychainService, "account", keychainAccount)
+	return err
+}
+
+func runKeychainCommand(ctx context.Context, stdin string, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
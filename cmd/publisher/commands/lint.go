@@ -0,0 +1,395 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Lint severities. Findings at error severity make the command fail.
+const (
+	lintSeverityError   = "error"
+	lintSeverityWarning = "warning"
+	lintSeverityOff     = "off"
+)
+
+// lintConfigFile is read from the directory of the linted server.json
+const lintConfigFile = ".mcpregistry.yaml"
+
+// minDescriptionLength is the shortest description the description-length rule accepts
+const minDescriptionLength = 20
+
+// lintRule is a style check that the registry itself doesn't enforce. check returns one
+// message per problem; fix, when set, corrects the problems mechanically.
+type lintRule struct {
+	id       string
+	severity string
+	check    func(server *apiv0.ServerJSON) []string
+	fix      func(server *apiv0.ServerJSON)
+}
+
+// lintRules are run in this order
+var lintRules = []lintRule{
+	{id: "text-whitespace", severity: lintSeverityWarning, check: checkTextWhitespace, fix: fixTextWhitespace},
+	{id: "description-length", severity: lintSeverityWarning, check: checkDescriptionLength},
+	{id: "license-spdx", severity: lintSeverityWarning, check: checkLicenseSPDX, fix: fixLicenseSPDX},
+	{id: "https-urls", severity: lintSeverityWarning, check: checkHTTPSURLs, fix: fixHTTPSURLs},
+	{id: "icon-missing", severity: lintSeverityWarning, check: checkIconMissing},
+	{id: "namespace-lowercase", severity: lintSeverityWarning, check: checkNamespaceLowercase},
+	{id: "namespace-repository", severity: lintSeverityWarning, check: checkNamespaceRepository},
+}
+
+// lintConfig is the lint section of .mcpregistry.yaml, e.g.
+//
+//	lint:
+//	  rules:
+//	    icon-missing: off
+//	    https-urls: error
+type lintConfig struct {
+	Lint struct {
+		Rules map[string]string `yaml:"rules"`
+	} `yaml:"lint"`
+}
+
+// lintFinding is a single problem reported by a rule
+type lintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fixable  bool   `json:"fixable"`
+}
+
+// lintResult is the --output json form of a lint run
+type lintResult struct {
+	File     string        `json:"file"`
+	Fixed    []string      `json:"fixed"`
+	Findings []lintFinding `json:"findings"`
+}
+
+// LintCommand checks server.json against style rules beyond what the registry requires, and
+// with --fix corrects the mechanical ones. Rules can be disabled or made errors in .mcpregistry.yaml.
+func LintCommand(args []string) error {
+	serverFile := "server.json"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverFile = args[0]
+		args = args[1:]
+	}
+
+	lintFlags := flag.NewFlagSet("lint", flag.ExitOnError)
+	var configPath string
+	var fix bool
+	lintFlags.StringVar(&configPath, "config", "", "Lint configuration file (default: "+lintConfigFile+" next to server.json)")
+	lintFlags.BoolVar(&fix, "fix", false, "Correct fixable problems in place")
+	if err := lintFlags.Parse(args); err != nil {
+		return err
+	}
+
+	severities, err := loadLintSeverities(serverFile, configPath)
+	if err != nil {
+		return err
+	}
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &server); err != nil {
+		return fmt.Errorf("invalid %s: %w", serverFile, err)
+	}
+
+	result := lintResult{File: serverFile, Fixed: []string{}}
+	if fix {
+		for _, rule := range lintRules {
+			if severities[rule.id] == lintSeverityOff || rule.fix == nil || len(rule.check(&server)) == 0 {
+				continue
+			}
+			rule.fix(&server)
+			result.Fixed = append(result.Fixed, rule.id)
+		}
+		if len(result.Fixed) > 0 {
+			fixedData, err := json.MarshalIndent(server, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error serializing %s: %w", serverFile, err)
+			}
+			if err := os.WriteFile(serverFile, append(fixedData, '\n'), 0600); err != nil {
+				return fmt.Errorf("failed to write %s: %w", serverFile, err)
+			}
+		}
+	}
+
+	result.Findings = lintServer(&server, severities)
+
+	errorCount := 0
+	for _, finding := range result.Findings {
+		if finding.Severity == lintSeverityError {
+			errorCount++
+		}
+	}
+
+	if jsonOutputEnabled() {
+		if err := printJSON(os.Stdout, result); err != nil {
+			return err
+		}
+	} else {
+		for _, rule := range result.Fixed {
+			_, _ = fmt.Fprintf(os.Stdout, "%s: fixed %s\n", serverFile, rule)
+		}
+		for _, finding := range result.Findings {
+			suffix := ""
+			if finding.Fixable {
+				suffix = " (fixable with --fix)"
+			}
+			_, _ = fmt.Fprintf(os.Stdout, "%s: %s %s: %s%s\n", serverFile, finding.Severity, finding.Rule, finding.Message, suffix)
+		}
+		if len(result.Findings) == 0 {
+			_, _ = fmt.Fprintf(os.Stdout, "✓ %s has no lint problems\n", serverFile)
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("lint failed with %d error(s)", errorCount)
+	}
+	return nil
+}
+
+// lintServer runs every enabled rule
+func lintServer(server *apiv0.ServerJSON, severities map[string]string) []lintFinding {
+	findings := []lintFinding{}
+	for _, rule := range lintRules {
+		severity := severities[rule.id]
+		if severity == lintSeverityOff {
+			continue
+		}
+		for _, message := range rule.check(server) {
+			findings = append(findings, lintFinding{Rule: rule.id, Severity: severity, Message: message, Fixable: rule.fix != nil})
+		}
+	}
+	return findings
+}
+
+// loadLintSeverities returns the severity of every rule: its default, overridden by the
+// config file. A missing default config file is fine; a missing explicit one is not.
+func loadLintSeverities(serverFile, configPath string) (map[string]string, error) {
+	severities := map[string]string{}
+	for _, rule := range lintRules {
+		severities[rule.id] = rule.severity
+	}
+
+	explicit := configPath != ""
+	if !explicit {
+		configPath = filepath.Join(filepath.Dir(serverFile), lintConfigFile)
+	}
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return severities, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint config: %w", err)
+	}
+
+	var config lintConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid lint config %s: %w", configPath, err)
+	}
+	for id, severity := range config.Lint.Rules {
+		if _, ok := severities[id]; !ok {
+			return nil, fmt.Errorf("invalid lint config %s: unknown rule %q", configPath, id)
+		}
+		switch severity {
+		case lintSeverityError, lintSeverityWarning, lintSeverityOff:
+			severities[id] = severity
+		default:
+			return nil, fmt.Errorf("invalid lint config %s: rule %s has severity %q (expected error, warning or off)", configPath, id, severity)
+		}
+	}
+	return severities, nil
+}
+
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func checkTextWhitespace(server *apiv0.ServerJSON) []string {
+	var messages []string
+	for _, field := range []struct{ name, value string }{
+		{"title", server.Title},
+		{"description", server.Description},
+	} {
+		if field.value != normalizeWhitespace(field.value) {
+			messages = append(messages, field.name+" has leading, trailing or repeated whitespace")
+		}
+	}
+	return messages
+}
+
+func fixTextWhitespace(server *apiv0.ServerJSON) {
+	server.Title = normalizeWhitespace(server.Title)
+	server.Description = normalizeWhitespace(server.Description)
+}
+
+func checkDescriptionLength(server *apiv0.ServerJSON) []string {
+	if length := len([]rune(strings.TrimSpace(server.Description))); length < minDescriptionLength {
+		return []string{fmt.Sprintf("description is %d characters; describe what the server does in at least %d", length, minDescriptionLength)}
+	}
+	return nil
+}
+
+// spdxAliases maps common ways of writing a license (lowercased) to its SPDX identifier
+var spdxAliases = map[string]string{
+	"mit":                                 "MIT",
+	"mit license":                         "MIT",
+	"apache 2.0":                          "Apache-2.0",
+	"apache-2":                            "Apache-2.0",
+	"apache license 2.0":                  "Apache-2.0",
+	"apache license, version 2.0":         "Apache-2.0",
+	"apache-2.0":                          "Apache-2.0",
+	"bsd-2-clause":                        "BSD-2-Clause",
+	"bsd 2-clause \"simplified\" license": "BSD-2-Clause",
+	"bsd-3-clause":                        "BSD-3-Clause",
+	"bsd 3-clause \"new\" or \"revised\" license": "BSD-3-Clause",
+	"gpl-2.0":                                "GPL-2.0-only",
+	"gnu general public license v2.0":        "GPL-2.0-only",
+	"gpl-3.0":                                "GPL-3.0-only",
+	"gnu general public license v3.0":        "GPL-3.0-only",
+	"agpl-3.0":                               "AGPL-3.0-only",
+	"gnu affero general public license v3.0": "AGPL-3.0-only",
+	"lgpl-3.0":                               "LGPL-3.0-only",
+	"gnu lesser general public license v3.0": "LGPL-3.0-only",
+	"mpl-2.0":                                "MPL-2.0",
+	"mozilla public license 2.0":             "MPL-2.0",
+	"isc":                                    "ISC",
+	"isc license":                            "ISC",
+	"unlicense":                              "Unlicense",
+	"the unlicense":                          "Unlicense",
+}
+
+// spdxIdentifiers are the identifiers spdxAliases normalizes to, which are accepted as is
+var spdxIdentifiers = func() map[string]bool {
+	ids := map[string]bool{}
+	for _, id := range spdxAliases {
+		ids[id] = true
+	}
+	return ids
+}()
+
+// publisherLicense returns the license from the publisher-provided metadata, if any
+func publisherLicense(server *apiv0.ServerJSON) (string, bool) {
+	if server.Meta == nil {
+		return "", false
+	}
+	license, ok := server.Meta.PublisherProvided["license"].(string)
+	return license, ok && license != ""
+}
+
+func checkLicenseSPDX(server *apiv0.ServerJSON) []string {
+	license, ok := publisherLicense(server)
+	if !ok || spdxIdentifiers[license] {
+		return nil
+	}
+	if id, known := spdxAliases[strings.ToLower(strings.TrimSpace(license))]; known {
+		return []string{fmt.Sprintf("license %q should be the SPDX identifier %q", license, id)}
+	}
+	return []string{fmt.Sprintf("license %q is not a recognized SPDX identifier", license)}
+}
+
+func fixLicenseSPDX(server *apiv0.ServerJSON) {
+	license, ok := publisherLicense(server)
+	if !ok {
+		return
+	}
+	if id, known := spdxAliases[strings.ToLower(strings.TrimSpace(license))]; known {
+		server.Meta.PublisherProvided["license"] = id
+	}
+}
+
+// insecureURL reports whether a URL uses plain http to a host other than localhost
+func insecureURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "http" {
+		return false
+	}
+	host := parsed.Hostname()
+	return host != "localhost" && host != "127.0.0.1" && host != "::1"
+}
+
+// serverURLs returns a pointer to every URL field that should use https, with its path
+func serverURLs(server *apiv0.ServerJSON) map[string]*string {
+	urls := map[string]*string{
+		"websiteUrl":     &server.WebsiteURL,
+		"repository.url": &server.Repository.URL,
+	}
+	for i := range server.Icons {
+		urls[fmt.Sprintf("icons[%d].src", i)] = &server.Icons[i].Src
+	}
+	for i := range server.Remotes {
+		urls[fmt.Sprintf("remotes[%d].url", i)] = &server.Remotes[i].URL
+	}
+	return urls
+}
+
+func checkHTTPSURLs(server *apiv0.ServerJSON) []string {
+	var messages []string
+	for path, value := range serverURLs(server) {
+		if insecureURL(*value) {
+			messages = append(messages, fmt.Sprintf("%s uses http: %s", path, *value))
+		}
+	}
+	// Map iteration order is random; keep the output stable
+	sort.Strings(messages)
+	return messages
+}
+
+func fixHTTPSURLs(server *apiv0.ServerJSON) {
+	for _, value := range serverURLs(server) {
+		if insecureURL(*value) {
+			*value = "https://" + strings.TrimPrefix(*value, "http://")
+		}
+	}
+}
+
+func checkIconMissing(server *apiv0.ServerJSON) []string {
+	if len(server.Icons) == 0 {
+		return []string{"no icons; clients show a generic icon for this server"}
+	}
+	return nil
+}
+
+func checkNamespaceLowercase(server *apiv0.ServerJSON) []string {
+	if server.Name != strings.ToLower(server.Name) {
+		return []string{fmt.Sprintf("name %q contains uppercase letters; names are easier to share in lowercase", server.Name)}
+	}
+	return nil
+}
+
+// checkNamespaceRepository flags io.github.<owner> names whose repository belongs to someone else
+func checkNamespaceRepository(server *apiv0.ServerJSON) []string {
+	namespace, _, found := strings.Cut(server.Name, "/")
+	owner, isGitHub := strings.CutPrefix(namespace, "io.github.")
+	if !found || !isGitHub || server.Repository.URL == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(server.Repository.URL)
+	if err != nil || !strings.EqualFold(parsed.Hostname(), "github.com") {
+		return nil
+	}
+	repoOwner, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if repoOwner != "" && !strings.EqualFold(repoOwner, owner) {
+		return []string{fmt.Sprintf("namespace io.github.%s doesn't match repository owner %s", owner, repoOwner)}
+	}
+	return nil
+}
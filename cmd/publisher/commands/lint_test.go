@@ -0,0 +1,76 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func lintFixture() apiv0.ServerJSON {
+	return apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/weather",
+		Description: "Weather  forecasts for any city ",
+		Version:     "1.0.0",
+		WebsiteURL:  "http://example.com/weather",
+		Repository:  model.Repository{URL: "https://github.com/someone-else/weather", Source: "github"},
+		Meta: &apiv0.ServerMeta{PublisherProvided: map[string]any{
+			"license": "Apache License 2.0",
+		}},
+	}
+}
+
+func TestLintCommand_ReportsProblems(t *testing.T) {
+	path := writeLocalServerJSON(t, lintFixture())
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.LintCommand([]string{path}))
+	})
+	assert.Contains(t, output, "warning text-whitespace: description has leading, trailing or repeated whitespace (fixable with --fix)")
+	assert.Contains(t, output, `warning license-spdx: license "Apache License 2.0" should be the SPDX identifier "Apache-2.0"`)
+	assert.Contains(t, output, "warning https-urls: websiteUrl uses http")
+	assert.Contains(t, output, "warning icon-missing")
+	assert.Contains(t, output, "warning namespace-repository: namespace io.github.example doesn't match repository owner someone-else")
+}
+
+func TestLintCommand_Fix(t *testing.T) {
+	path := writeLocalServerJSON(t, lintFixture())
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.LintCommand([]string{path, "--fix"}))
+	})
+	assert.Contains(t, output, "fixed text-whitespace")
+	assert.NotContains(t, output, "fixable with --fix")
+
+	server := readReleasedServer(t, path)
+	assert.Equal(t, "Weather forecasts for any city", server.Description)
+	assert.Equal(t, "https://example.com/weather", server.WebsiteURL)
+	assert.Equal(t, "Apache-2.0", server.Meta.PublisherProvided["license"])
+}
+
+func TestLintCommand_Config(t *testing.T) {
+	path := writeLocalServerJSON(t, lintFixture())
+	config := "lint:\n  rules:\n    icon-missing: off\n    https-urls: error\n"
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), ".mcpregistry.yaml"), []byte(config), 0o600))
+
+	var err error
+	output := captureStdout(t, func() {
+		err = commands.LintCommand([]string{path})
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lint failed with 1 error(s)")
+	assert.Contains(t, output, "error https-urls")
+	assert.NotContains(t, output, "icon-missing")
+
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), ".mcpregistry.yaml"), []byte("lint:\n  rules:\n    no-such-rule: off\n"), 0o600))
+	err = commands.LintCommand([]string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown rule "no-such-rule"`)
+}
@@ -2,12 +2,10 @@ package commands
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
 )
@@ -34,7 +32,7 @@ func (c *CryptoAlgorithm) Set(v string) error {
 
 func LoginCommand(args []string) error {
 	if len(args) < 1 {
-		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  dns           DNS-based authentication (requires --domain and --private-key)\n  http          HTTP-based authentication (requires --domain and --private-key)\n  none          Anonymous authentication (for testing)")
+		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  dns           DNS-based authentication (requires --domain and --private-key)\n  http          HTTP-based authentication (requires --domain and --private-key)\n  pat           Personal access token authentication (requires --token or $MCP_PUBLISHER_PAT)\n  none          Anonymous authentication (for testing)")
 	}
 
 	method := args[0]
@@ -45,14 +43,20 @@ func LoginCommand(args []string) error {
 	var privateKey string
 	var cryptoAlgorithm = CryptoAlgorithm(auth.AlgorithmEd25519)
 	var registryURL string
+	var noKeychain bool
+	var personalAccessToken string
 
 	loginFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	loginFlags.BoolVar(&noKeychain, "no-keychain", os.Getenv(noKeychainEnv) != "", "Store credentials in ~/"+TokenFileName+" instead of the OS keychain (default: $"+noKeychainEnv+")")
 
 	if method == "dns" || method == "http" {
 		loginFlags.StringVar(&domain, "domain", "", "Domain name")
 		loginFlags.StringVar(&privateKey, "private-key", "", "Private key (hex)")
 		loginFlags.Var(&cryptoAlgorithm, "algorithm", "Cryptographic algorithm (ed25519, ecdsap384)")
 	}
+	if method == "pat" {
+		loginFlags.StringVar(&personalAccessToken, "token", os.Getenv("MCP_PUBLISHER_PAT"), "Personal access token (default: $MCP_PUBLISHER_PAT)")
+	}
 
 	if err := loginFlags.Parse(args[1:]); err != nil {
 		return err
@@ -75,6 +79,11 @@ func LoginCommand(args []string) error {
 			return errors.New("http authentication requires --domain and --private-key")
 		}
 		authProvider = auth.NewHTTPProvider(registryURL, domain, privateKey, auth.CryptoAlgorithm(cryptoAlgorithm))
+	case "pat":
+		if personalAccessToken == "" {
+			return errors.New("pat authentication requires --token or $MCP_PUBLISHER_PAT")
+		}
+		authProvider = auth.NewPATProvider(registryURL, personalAccessToken)
 	case "none":
 		authProvider = auth.NewNoneProvider(registryURL)
 	default:
@@ -83,7 +92,7 @@ func LoginCommand(args []string) error {
 
 	// Perform login
 	ctx := context.Background()
-	_, _ = fmt.Fprintf(os.Stdout, "Logging in with %s...\n", method)
+	_, _ = fmt.Fprintf(statusWriter(), "Logging in with %s...\n", method)
 
 	if err := authProvider.Login(ctx); err != nil {
 		return fmt.Errorf("login failed: %w", err)
@@ -95,28 +104,32 @@ func LoginCommand(args []string) error {
 		return fmt.Errorf("failed to get token: %w", err)
 	}
 
-	// Save token to file
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+	creds := &storedCredentials{
+		Token:     token,
+		Method:    method,
+		Registry:  registryURL,
+		ExpiresAt: tokenExpiry(token),
 	}
-
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-	tokenData := map[string]string{
-		"token":    token,
-		"method":   method,
-		"registry": registryURL,
+	if refreshable, ok := authProvider.(auth.RefreshableProvider); ok {
+		if refreshToken, err := refreshable.RefreshCredential(); err == nil {
+			creds.RefreshToken = refreshToken
+		}
 	}
 
-	jsonData, err := json.Marshal(tokenData)
+	location, err := saveCredentials(ctx, creds, !noKeychain)
 	if err != nil {
-		return fmt.Errorf("failed to marshal token data: %w", err)
+		return err
 	}
-
-	if err := os.WriteFile(tokenPath, jsonData, 0600); err != nil {
-		return fmt.Errorf("failed to save token: %w", err)
+	removeLegacyTokenFiles()
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, map[string]any{
+			"method":    method,
+			"registry":  registryURL,
+			"storedIn":  location,
+			"expiresAt": creds.ExpiresAt,
+		})
 	}
-
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged in")
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Successfully logged in (credentials stored in %s)\n", location)
 	return nil
 }
@@ -1,43 +1,50 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 )
 
+// legacyTokenFiles are where older versions of the publisher left GitHub and registry tokens in
+// plain text, in the directory login ran in
+var legacyTokenFiles = []string{
+	".mcpregistry_github_token",
+	".mcpregistry_registry_token",
+}
+
 func LogoutCommand() error {
-	homeDir, err := os.UserHomeDir()
+	removed, err := deleteCredentials(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-
-	// Check if token file exists
-	if _, err := os.Stat(tokenPath); os.IsNotExist(err) {
+	removeLegacyTokenFiles()
+	if !removed {
+		if jsonOutputEnabled() {
+			return printJSON(os.Stdout, map[string]bool{"loggedOut": false})
+		}
 		_, _ = fmt.Fprintln(os.Stdout, "Not logged in")
 		return nil
 	}
 
-	// Remove token file
-	if err := os.Remove(tokenPath); err != nil {
-		return fmt.Errorf("failed to remove token: %w", err)
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, map[string]bool{"loggedOut": true})
 	}
+	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged out")
+	return nil
+}
 
-	// Also clean up legacy token files if they exist
-	legacyFiles := []string{
-		".mcpregistry_github_token",
-		".mcpregistry_registry_token",
+// removeLegacyTokenFiles deletes the plain-text token files older versions left in the current and
+// home directories, ignoring errors
+func removeLegacyTokenFiles() {
+	dirs := []string{"."}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, homeDir)
 	}
-
-	for _, file := range legacyFiles {
-		path := filepath.Join(homeDir, file)
-		if _, err := os.Stat(path); err == nil {
-			os.Remove(path) // Ignore errors for legacy files
+	for _, dir := range dirs {
+		for _, file := range legacyTokenFiles {
+			_ = os.Remove(filepath.Join(dir, file))
 		}
 	}
-
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged out")
-	return nil
 }
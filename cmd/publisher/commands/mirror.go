@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// mirrorState is saved between mirror runs so each run only copies what changed since the last
+type mirrorState struct {
+	Source       string    `json:"source"`
+	UpdatedSince time.Time `json:"updatedSince"`
+}
+
+// MirrorCommand copies server versions from one registry to another. With --state it is
+// incremental: only versions updated since the previous run are fetched from the source.
+func MirrorCommand(args []string) error {
+	mirrorFlags := flag.NewFlagSet("mirror", flag.ExitOnError)
+	var sourceURL, targetURL, namespace, statePath string
+	var dryRun bool
+	mirrorFlags.StringVar(&sourceURL, "from", DefaultRegistryURL, "Registry URL to mirror from")
+	mirrorFlags.StringVar(&targetURL, "to", "", "Registry URL to mirror into (default: the registry you are logged in to)")
+	mirrorFlags.StringVar(&namespace, "namespace", "", "Only mirror servers in this namespace (e.g. io.github.acme or io.github.acme/*)")
+	mirrorFlags.StringVar(&statePath, "state", "", "File that records the last sync, making repeated runs incremental")
+	mirrorFlags.BoolVar(&dryRun, "dry-run", false, "Show what would be mirrored without publishing anything")
+	if err := mirrorFlags.Parse(args); err != nil {
+		return err
+	}
+	namespace = strings.TrimSuffix(strings.TrimSuffix(namespace, "*"), "/")
+
+	state, err := loadMirrorState(statePath, sourceURL)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	targetURL, token, err := targetCredentials(ctx, targetURL, dryRun)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSuffix(sourceURL, "/") == strings.TrimSuffix(targetURL, "/") {
+		return errors.New("--from and --to are the same registry")
+	}
+
+	responses, err := listAllServerVersions(ctx, sourceURL, namespace, state.UpdatedSince)
+	if err != nil {
+		return fmt.Errorf("failed to list servers in %s: %w", sourceURL, err)
+	}
+
+	out := statusWriter()
+	if state.UpdatedSince.IsZero() {
+		_, _ = fmt.Fprintf(out, "Mirroring %d server version(s) from %s into %s\n", len(responses), sourceURL, targetURL)
+	} else {
+		_, _ = fmt.Fprintf(out, "Mirroring %d server version(s) updated since %s from %s into %s\n",
+			len(responses), state.UpdatedSince.Format(time.RFC3339), sourceURL, targetURL)
+	}
+
+	newest := state.UpdatedSince
+	servers := make([]apiv0.ServerJSON, 0, len(responses))
+	for _, response := range responses {
+		if official := response.Meta.Official; official != nil {
+			if official.UpdatedAt.After(newest) {
+				newest = official.UpdatedAt
+			}
+			if official.Status == model.StatusDeleted {
+				// Taken-down versions are not copied to the mirror
+				continue
+			}
+		}
+		servers = append(servers, response.Server)
+	}
+
+	result, err := importServers(ctx, out, targetURL, token, servers, onConflictSkip, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		_, _ = fmt.Fprintf(out, "\nWould publish %d and skip %d server version(s)\n", len(result.Published), len(result.Skipped))
+	} else {
+		_, _ = fmt.Fprintf(out, "\n✓ Published %d and skipped %d server version(s)\n", len(result.Published), len(result.Skipped))
+		if statePath != "" {
+			state.UpdatedSince = newest
+			if err := saveMirrorState(statePath, state); err != nil {
+				return err
+			}
+		}
+	}
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, result)
+	}
+	return nil
+}
+
+// loadMirrorState reads the state file, if any. A state file written for a different source
+// registry is rejected rather than silently skipping that registry's history.
+func loadMirrorState(path, sourceURL string) (*mirrorState, error) {
+	state := &mirrorState{Source: sourceURL}
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirror state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("invalid mirror state in %s: %w", path, err)
+	}
+	if strings.TrimSuffix(state.Source, "/") != strings.TrimSuffix(sourceURL, "/") {
+		return nil, fmt.Errorf("%s records a mirror of %s, not %s", path, state.Source, sourceURL)
+	}
+	return state, nil
+}
+
+func saveMirrorState(path string, state *mirrorState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to save mirror state: %w", err)
+	}
+	return nil
+}
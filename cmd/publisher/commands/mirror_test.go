@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestMirrorCommand_Incremental(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	var updatedSince []string
+	source := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers", r.URL.Path)
+		updatedSince = append(updatedSince, r.URL.Query().Get("updated_since"))
+
+		active := serverVersion("io.github.acme/weather", "1.0.0", updatedAt)
+		active.Meta.Official.UpdatedAt = updatedAt
+		deleted := serverVersion("io.github.acme/spam", "1.0.0", updatedAt)
+		deleted.Meta.Official.Status = model.StatusDeleted
+		_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{Servers: []apiv0.ServerResponse{
+			active,
+			deleted,
+			serverVersion("io.github.other/weather", "1.0.0", updatedAt),
+		}})
+	})
+
+	var published []string
+	target := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/publish" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var server apiv0.ServerJSON
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&server))
+		published = append(published, server.Name)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: server})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "test-token", "method": "none", "registry": target.URL})
+
+	statePath := filepath.Join(t.TempDir(), "mirror-state.json")
+	args := []string{"--from", source.URL, "--to", target.URL, "--namespace", "io.github.acme/*", "--state", statePath}
+	require.NoError(t, commands.MirrorCommand(args))
+	assert.Equal(t, []string{"io.github.acme/weather"}, published)
+
+	stateData, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(stateData), "2025-10-01T12:00:00Z")
+
+	// The second run continues from the newest update seen by the first
+	require.NoError(t, commands.MirrorCommand(args))
+	require.Len(t, updatedSince, 2)
+	assert.Empty(t, updatedSince[0])
+	assert.Equal(t, "2025-10-01T12:00:00Z", updatedSince[1])
+}
+
+func TestMirrorCommand_RejectsStateFromOtherSource(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "mirror-state.json")
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"source": "https://other.example.com"}`), 0o600))
+
+	err := commands.MirrorCommand([]string{"--from", "https://registry.example.com", "--to", "https://mirror.example.com", "--state", statePath, "--dry-run"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "records a mirror of https://other.example.com")
+}
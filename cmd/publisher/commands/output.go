@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Output formats accepted by the global --output flag
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+var outputFormat = OutputText
+
+// SetOutputFormat selects how every command prints its results
+func SetOutputFormat(format string) error {
+	switch format {
+	case OutputText, OutputJSON:
+		outputFormat = format
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (allowed: %s, %s)", format, OutputText, OutputJSON)
+	}
+}
+
+// jsonOutputEnabled reports whether --output json was given
+func jsonOutputEnabled() bool {
+	return outputFormat == OutputJSON
+}
+
+// statusWriter is where progress and success messages go. With --output json they move
+// to stderr so stdout only carries the JSON result.
+func statusWriter() io.Writer {
+	if jsonOutputEnabled() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// ExtractGlobalFlags applies the global --output flag given before the command name, and returns
+// the command and its arguments. Flags after the command name are the command's own, such as
+// export --output=FILE.
+func ExtractGlobalFlags(args []string) ([]string, error) {
+	for len(args) > 0 {
+		arg := args[0]
+		var value string
+		switch {
+		case arg == "--output" || arg == "-output" || arg == "-o":
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%s requires a value (%s or %s)", arg, OutputText, OutputJSON)
+			}
+			value, args = args[1], args[2:]
+		case strings.HasPrefix(arg, "--output=") || strings.HasPrefix(arg, "-output="):
+			value, args = arg[strings.Index(arg, "=")+1:], args[1:]
+		default:
+			return args, nil
+		}
+		if err := SetOutputFormat(value); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
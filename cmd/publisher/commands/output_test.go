@@ -0,0 +1,80 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+// withJSONOutput switches every command to --output json for the duration of the test
+func withJSONOutput(t *testing.T) {
+	t.Helper()
+	require.NoError(t, commands.SetOutputFormat(commands.OutputJSON))
+	t.Cleanup(func() { _ = commands.SetOutputFormat(commands.OutputText) })
+}
+
+func TestExtractGlobalFlags(t *testing.T) {
+	t.Cleanup(func() { _ = commands.SetOutputFormat(commands.OutputText) })
+
+	args, err := commands.ExtractGlobalFlags([]string{"--output", "json", "search", "weather", "--limit", "5"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"search", "weather", "--limit", "5"}, args)
+	require.NoError(t, commands.SetOutputFormat(commands.OutputText))
+
+	// Flags after the command name are the command's own, like export's --output FILE
+	args, err = commands.ExtractGlobalFlags([]string{"export", "--all", "--output", "json"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"export", "--all", "--output", "json"}, args)
+	args, err = commands.ExtractGlobalFlags([]string{"--output=json", "export", "--all", "--output=dump.ndjson"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"export", "--all", "--output=dump.ndjson"}, args)
+
+	_, err = commands.ExtractGlobalFlags([]string{"--output", "yaml", "search"})
+	require.Error(t, err)
+	_, err = commands.ExtractGlobalFlags([]string{"--output"})
+	require.Error(t, err)
+}
+
+func TestSetOutputFormat_RejectsUnknownFormat(t *testing.T) {
+	require.Error(t, commands.SetOutputFormat("yaml"))
+}
+
+func TestValidateCommand_JSONOutput(t *testing.T) {
+	withJSONOutput(t)
+	path := filepath.Join(t.TempDir(), "server.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "invalid"}`), 0o600))
+
+	var result struct {
+		File     string   `json:"file"`
+		Valid    bool     `json:"valid"`
+		Problems []string `json:"problems"`
+	}
+	output := captureStdout(t, func() {
+		require.Error(t, commands.ValidateCommand([]string{path, "--skip-registry-validation"}))
+	})
+	require.NoError(t, json.Unmarshal([]byte(output), &result))
+	assert.Equal(t, path, result.File)
+	assert.False(t, result.Valid)
+	assert.NotEmpty(t, result.Problems)
+}
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				require.NoError(t, commands.CompletionCommand([]string{shell}))
+			})
+			assert.Contains(t, output, "mcp-publisher")
+			assert.Contains(t, output, "publish")
+			assert.Contains(t, output, "github-oidc")
+		})
+	}
+
+	require.Error(t, commands.CompletionCommand([]string{"powershell"}))
+}
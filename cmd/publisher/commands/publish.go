@@ -3,24 +3,57 @@ package commands
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
-	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/modelcontextprotocol/registry/internal/signing"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+// serverSignature is a detached signature over the canonical server.json being published
+type serverSignature struct {
+	format string
+	data   []byte
+}
+
+// packageDocument is an SBOM or provenance document to attach to an OCI package once it's published
+type packageDocument struct {
+	kind       string
+	identifier string
+	file       string
+	data       []byte
+}
+
 func PublishCommand(args []string) error {
 	// Check for server.json file
 	serverFile := "server.json"
 	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
 		serverFile = args[0]
+		args = args[1:]
+	}
+
+	publishFlags := flag.NewFlagSet("publish", flag.ExitOnError)
+	var signatureFile, signatureFormat string
+	var printCanonical bool
+	publishFlags.StringVar(&signatureFile, "signature", "", "Detached signature over the canonical server.json to publish it with")
+	publishFlags.StringVar(&signatureFormat, "signature-format", signing.FormatMinisign, "Tool that made the signature: minisign or cosign")
+	publishFlags.BoolVar(&printCanonical, "print-canonical", false, "Print the canonical server.json to sign rather than publishing")
+	var documents []packageDocument
+	publishFlags.Func("sbom", "Attach an SPDX or CycloneDX JSON SBOM to an OCI package, as FILE or IDENTIFIER=FILE (repeatable)",
+		documentFlag(&documents, "sbom"))
+	publishFlags.Func("provenance", "Attach SLSA provenance to an OCI package, as FILE or IDENTIFIER=FILE (repeatable)",
+		documentFlag(&documents, "provenance"))
+	if err := publishFlags.Parse(args); err != nil {
+		return err
 	}
 
 	// Read server.json
@@ -49,46 +82,71 @@ Migrate to the current schema format for new servers.
 📖 Full changelog with examples: https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md`, serverJSON.Schema)
 	}
 
-	// Load saved token
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+	if printCanonical {
+		canonical, err := signing.Canonicalize(&serverJSON)
+		if err != nil {
+			return fmt.Errorf("failed to canonicalize server.json: %w", err)
+		}
+		_, err = os.Stdout.Write(canonical)
+		return err
 	}
 
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-	tokenData, err := os.ReadFile(tokenPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return errors.New("not authenticated. Run 'mcp-publisher login <method>' first")
+	var signature *serverSignature
+	if signatureFile != "" {
+		if signatureFormat != signing.FormatMinisign && signatureFormat != signing.FormatCosign {
+			return fmt.Errorf("unknown signature format %q, expected %s or %s", signatureFormat, signing.FormatMinisign, signing.FormatCosign)
+		}
+		data, err := os.ReadFile(signatureFile)
+		if err != nil {
+			return fmt.Errorf("failed to read signature: %w", err)
 		}
-		return fmt.Errorf("failed to read token: %w", err)
+		signature = &serverSignature{format: signatureFormat, data: data}
 	}
 
-	var tokenInfo map[string]string
-	if err := json.Unmarshal(tokenData, &tokenInfo); err != nil {
-		return fmt.Errorf("invalid token data: %w", err)
+	for i := range documents {
+		if _, err := attachmentIndex(&serverJSON, documents[i].identifier); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(documents[i].file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", documents[i].kind, err)
+		}
+		documents[i].data = data
 	}
 
-	token := tokenInfo["token"]
-	registryURL := tokenInfo["registry"]
-	if registryURL == "" {
-		registryURL = DefaultRegistryURL
+	// Load saved token, refreshing it if it has expired
+	token, registryURL, err := loadRegistryToken(context.Background())
+	if err != nil {
+		return err
 	}
 
 	// Publish to registry
-	_, _ = fmt.Fprintf(os.Stdout, "Publishing to %s...\n", registryURL)
-	response, err := publishToRegistry(registryURL, serverData, token)
+	_, _ = fmt.Fprintf(statusWriter(), "Publishing to %s...\n", registryURL)
+	response, err := publishToRegistry(registryURL, serverData, token, signature)
 	if err != nil {
 		return fmt.Errorf("publish failed: %w", err)
 	}
 
+	for _, document := range documents {
+		index, _ := attachmentIndex(&response.Server, document.identifier)
+		if err := attachPackageDocument(registryURL, token, &response.Server, index, document); err != nil {
+			return fmt.Errorf("published, but failed to attach %s: %w", document.file, err)
+		}
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, response)
+	}
 	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully published")
 	_, _ = fmt.Fprintf(os.Stdout, "✓ Server %s version %s\n", response.Server.Name, response.Server.Version)
+	for _, document := range documents {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ Attached %s %s\n", document.kind, document.file)
+	}
 
 	return nil
 }
 
-func publishToRegistry(registryURL string, serverData []byte, token string) (*apiv0.ServerResponse, error) {
+func publishToRegistry(registryURL string, serverData []byte, token string, signature *serverSignature) (*apiv0.ServerResponse, error) {
 	// Parse the server JSON data
 	var serverJSON apiv0.ServerJSON
 	err := json.Unmarshal(serverData, &serverJSON)
@@ -115,6 +173,10 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
+	if signature != nil {
+		req.Header.Set("Server-Signature", base64.StdEncoding.EncodeToString(signature.data))
+		req.Header.Set("Server-Signature-Format", signature.format)
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -140,3 +202,65 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 
 	return &serverResponse, nil
 }
+
+// documentFlag parses a --sbom or --provenance flag, FILE or IDENTIFIER=FILE, onto documents
+func documentFlag(documents *[]packageDocument, kind string) func(string) error {
+	return func(value string) error {
+		document := packageDocument{kind: kind, file: value}
+		if identifier, file, found := strings.Cut(value, "="); found {
+			document.identifier, document.file = identifier, file
+		}
+		if document.file == "" {
+			return fmt.Errorf("--%s needs a file", kind)
+		}
+		*documents = append(*documents, document)
+		return nil
+	}
+}
+
+// attachmentIndex returns the index of the OCI package with an identifier, or of the only OCI
+// package when the identifier is empty
+func attachmentIndex(server *apiv0.ServerJSON, identifier string) (int, error) {
+	index := -1
+	for i, pkg := range server.Packages {
+		if pkg.RegistryType != model.RegistryTypeOCI || (identifier != "" && pkg.Identifier != identifier) {
+			continue
+		}
+		if index >= 0 {
+			return 0, fmt.Errorf("server.json has several OCI packages, attach documents as IDENTIFIER=FILE")
+		}
+		index = i
+	}
+	if index < 0 {
+		if identifier != "" {
+			return 0, fmt.Errorf("server.json has no OCI package %s", identifier)
+		}
+		return 0, fmt.Errorf("server.json has no OCI package to attach documents to")
+	}
+	return index, nil
+}
+
+// attachPackageDocument uploads a document to a package of a published server version as is, so
+// that its digest matches the file's
+func attachPackageDocument(registryURL, token string, server *apiv0.ServerJSON, index int, document packageDocument) error {
+	attachURL := registryEndpoint(registryURL, "/v0/servers/"+url.PathEscape(server.Name)+"/versions/"+url.PathEscape(server.Version)+
+		"/packages/"+strconv.Itoa(index)+"/"+document.kind)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, attachURL, bytes.NewReader(document.data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("server returned status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
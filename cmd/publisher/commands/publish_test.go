@@ -2,11 +2,16 @@ package commands_test
 
 import (
 	"encoding/json"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -131,3 +136,57 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishCommand_AttachesSBOM(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	server := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@alice/weather", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/alice/weather:1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+		},
+	}
+	sbom := `{"spdxVersion": "SPDX-2.3", "name": "weather"}` + "\n"
+
+	var attached string
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer login-token", r.Header.Get("Authorization"))
+		switch r.URL.EscapedPath() {
+		case "/v0/publish":
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: server})
+		case "/v0/servers/io.github.alice%2Fweather/versions/1.0.0/packages/1/sbom":
+			assert.Equal(t, http.MethodPut, r.Method)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			attached = string(body)
+			_ = json.NewEncoder(w).Encode(apiv0.PackageAttachment{PackageIndex: 1, Kind: "sbom"})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	dir := t.TempDir()
+	serverFile := filepath.Join(dir, "server.json")
+	sbomFile := filepath.Join(dir, "sbom.spdx.json")
+	serverData, err := json.Marshal(server)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(serverFile, serverData, 0o600))
+	require.NoError(t, os.WriteFile(sbomFile, []byte(sbom), 0o600))
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.PublishCommand([]string{serverFile, "--sbom", sbomFile}))
+	})
+	assert.Equal(t, sbom, attached, "documents are uploaded as is")
+	assert.Contains(t, output, "Attached sbom")
+
+	err = commands.PublishCommand([]string{serverFile, "--sbom", "ghcr.io/alice/other:1.0.0=" + sbomFile})
+	assert.ErrorContains(t, err, "no OCI package ghcr.io/alice/other:1.0.0")
+}
@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/serverdiff"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// releaseResult is the --output json form of a release
+type releaseResult struct {
+	File      string                `json:"file"`
+	Version   string                `json:"version"`
+	Changes   *apiv0.ServerDiff     `json:"changes"`
+	Committed bool                  `json:"committed"`
+	Published *apiv0.ServerResponse `json:"published,omitempty"`
+}
+
+// ReleaseCommand bumps the version in server.json (and the package versions and image tags
+// that follow it), validates the result, optionally commits it, and publishes it
+func ReleaseCommand(args []string) error {
+	serverFile := "server.json"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverFile = args[0]
+		args = args[1:]
+	}
+
+	releaseFlags := flag.NewFlagSet("release", flag.ExitOnError)
+	var version string
+	var commit, noPublish, dryRun, skipRegistryValidation bool
+	releaseFlags.StringVar(&version, "version", "", "Version to release (e.g. 1.2.3 or v1.2.3)")
+	releaseFlags.BoolVar(&commit, "commit", false, "Commit the updated server.json with git before publishing")
+	releaseFlags.BoolVar(&noPublish, "no-publish", false, "Update and validate server.json without publishing")
+	releaseFlags.BoolVar(&dryRun, "dry-run", false, "Show the changes without writing, committing or publishing anything")
+	releaseFlags.BoolVar(&skipRegistryValidation, "skip-registry-validation", false, "Skip package ownership checks against npm, PyPI, NuGet, OCI and MCPB sources")
+	if err := releaseFlags.Parse(args); err != nil {
+		return err
+	}
+	if version == "" {
+		return errors.New("version required\n\nUsage: mcp-publisher release [server.json] --version=VERSION [--commit] [--no-publish] [--dry-run]")
+	}
+	version = normalizeReleaseVersion(version)
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &server); err != nil {
+		return fmt.Errorf("invalid %s: %w", serverFile, err)
+	}
+	if server.Version == version {
+		return fmt.Errorf("%s is already at version %s", serverFile, version)
+	}
+
+	released := server
+	released.Packages = bumpPackageVersions(server.Packages, server.Version, version)
+	released.Version = version
+
+	changes := serverdiff.Diff(&server, &released)
+	out := statusWriter()
+	_, _ = fmt.Fprintf(out, "Releasing %s %s -> %s\n", server.Name, server.Version, version)
+	printServerDiff(out, changes)
+
+	releasedData, err := json.MarshalIndent(released, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing %s: %w", serverFile, err)
+	}
+
+	// Validate before touching the file, so a failed release leaves it as it was
+	ctx := context.Background()
+	if problems := validateServerData(ctx, releasedData, !skipRegistryValidation); len(problems) > 0 {
+		for _, problem := range problems {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: %s\n", serverFile, problem)
+		}
+		return fmt.Errorf("release %s is invalid: validation failed with %d error(s)", version, len(problems))
+	}
+
+	result := releaseResult{File: serverFile, Version: version, Changes: changes}
+	if dryRun {
+		_, _ = fmt.Fprintln(out, "\nDry run: nothing was written, committed or published")
+		if jsonOutputEnabled() {
+			return printJSON(os.Stdout, result)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(serverFile, append(releasedData, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", serverFile, err)
+	}
+	_, _ = fmt.Fprintf(out, "✓ Updated %s\n", serverFile)
+
+	if commit {
+		if err := commitRelease(ctx, serverFile, server.Name, version); err != nil {
+			return err
+		}
+		result.Committed = true
+		_, _ = fmt.Fprintf(out, "✓ Committed %s\n", serverFile)
+	}
+
+	if !noPublish {
+		token, registryURL, err := loadRegistryToken(ctx)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "Publishing to %s...\n", registryURL)
+		response, err := publishToRegistry(registryURL, releasedData, token, nil)
+		if err != nil {
+			return fmt.Errorf("publish failed (%s was updated; run 'mcp-publisher publish' to retry): %w", serverFile, err)
+		}
+		result.Published = response
+		_, _ = fmt.Fprintf(out, "✓ Published %s version %s\n", response.Server.Name, response.Server.Version)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, result)
+	}
+	return nil
+}
+
+// normalizeReleaseVersion turns a git-style tag such as v1.2.3 into the server version 1.2.3
+func normalizeReleaseVersion(version string) string {
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && version[1] >= '0' && version[1] <= '9' {
+		return version[1:]
+	}
+	return version
+}
+
+// bumpPackageVersions moves packages that were released in lockstep with the server to the
+// new version. Package versions that equal the old server version are replaced, as are OCI
+// image tags that equal it (with or without a leading v, which is kept). Packages versioned
+// independently of the server are left alone.
+func bumpPackageVersions(packages []model.Package, oldVersion, newVersion string) []model.Package {
+	if len(packages) == 0 {
+		return packages
+	}
+
+	bumped := make([]model.Package, len(packages))
+	copy(bumped, packages)
+	for i := range bumped {
+		pkg := &bumped[i]
+		if pkg.Version != "" {
+			pkg.Version = bumpVersionString(pkg.Version, oldVersion, newVersion)
+		}
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			pkg.Identifier = bumpImageTag(pkg.Identifier, oldVersion, newVersion)
+		}
+	}
+	return bumped
+}
+
+func bumpVersionString(current, oldVersion, newVersion string) string {
+	switch current {
+	case oldVersion:
+		return newVersion
+	case "v" + oldVersion:
+		return "v" + newVersion
+	default:
+		return current
+	}
+}
+
+// bumpImageTag replaces the tag of an image reference when it tracks the server version. A
+// pinned digest no longer matches the new tag, so it is dropped.
+func bumpImageTag(image, oldVersion, newVersion string) string {
+	ref, err := registries.ParseOCIReference(image)
+	if err != nil || ref.Tag == "" {
+		return image
+	}
+	newTag := bumpVersionString(ref.Tag, oldVersion, newVersion)
+	if newTag == ref.Tag {
+		return image
+	}
+
+	base := image
+	if ref.Digest != "" {
+		base = strings.TrimSuffix(base, "@"+ref.Digest)
+	}
+	return strings.TrimSuffix(base, ":"+ref.Tag) + ":" + newTag
+}
+
+// commitRelease commits the updated server.json, and nothing else, with git
+func commitRelease(ctx context.Context, serverFile, serverName, version string) error {
+	message := fmt.Sprintf("Release %s %s", serverName, version)
+	for _, args := range [][]string{
+		{"add", "--", serverFile},
+		{"commit", "-m", message, "--", serverFile},
+	} {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func releaseFixture() apiv0.ServerJSON {
+	return apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather forecasts",
+		Version:     "1.2.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeOCI,
+				Identifier:   "ghcr.io/example/weather:v1.2.0@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/weather",
+				Version:      "1.2.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+			{
+				RegistryType: model.RegistryTypePyPI,
+				Identifier:   "weather-client",
+				Version:      "0.4.1",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+		},
+	}
+}
+
+func readReleasedServer(t *testing.T, path string) apiv0.ServerJSON {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var server apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal(data, &server))
+	return server
+}
+
+func TestReleaseCommand_BumpsVersions(t *testing.T) {
+	path := writeLocalServerJSON(t, releaseFixture())
+
+	require.NoError(t, commands.ReleaseCommand([]string{path, "--version", "v1.3.0", "--no-publish", "--skip-registry-validation"}))
+
+	server := readReleasedServer(t, path)
+	assert.Equal(t, "1.3.0", server.Version)
+	assert.Equal(t, "ghcr.io/example/weather:v1.3.0", server.Packages[0].Identifier)
+	assert.Equal(t, "1.3.0", server.Packages[1].Version)
+	assert.Equal(t, "0.4.1", server.Packages[2].Version, "independently versioned packages are left alone")
+}
+
+func TestReleaseCommand_DryRunLeavesFileUnchanged(t *testing.T) {
+	path := writeLocalServerJSON(t, releaseFixture())
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	require.NoError(t, commands.ReleaseCommand([]string{path, "--version", "1.3.0", "--dry-run", "--skip-registry-validation"}))
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+}
+
+func TestReleaseCommand_Errors(t *testing.T) {
+	path := writeLocalServerJSON(t, releaseFixture())
+
+	err := commands.ReleaseCommand([]string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "version required")
+
+	err = commands.ReleaseCommand([]string{path, "--version", "1.2.0", "--no-publish"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already at version 1.2.0")
+
+	// "latest" is rejected by validation, and the file is not touched
+	err = commands.ReleaseCommand([]string{path, "--version", "latest", "--no-publish", "--skip-registry-validation"})
+	require.Error(t, err)
+	assert.Equal(t, "1.2.0", readReleasedServer(t, path).Version)
+}
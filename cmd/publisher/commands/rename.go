@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const aliasUsage = `Usage: mcp-publisher alias <command> [arguments]
+
+Commands:
+  list     List the names that redirect to a server (<name> [--registry=URL])
+  add      Make an unpublished name redirect to a server (<name> <alias>)
+  remove   Stop a name redirecting to a server (<name> <alias>)
+
+Reads of an alias answer with a redirect to the server. Removing an alias,
+or a redirect left by a rename, frees the name to be published again.`
+
+// RenameCommand moves every version of a server to a new name
+func RenameCommand(args []string) error {
+	if len(args) != 2 {
+		return errors.New("server name and new name required\n\nUsage: mcp-publisher rename <name> <new-name>")
+	}
+	serverName, newName := args[0], args[1]
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var redirect apiv0.ServerRedirect
+	if err := sendTokenRequest(ctx, http.MethodPost, serverEndpoint(registryURL, serverName, "/rename"), registryToken,
+		map[string]string{"name": newName}, &redirect); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", serverName, err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, redirect)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Renamed %s to %s\n", redirect.From, redirect.To)
+	_, _ = fmt.Fprintln(os.Stdout, "  Reads of the old name redirect to the new one. Update the name in server.json before publishing again.")
+	return nil
+}
+
+// AliasCommand manages the names that redirect to a server
+func AliasCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New(aliasUsage)
+	}
+
+	switch args[0] {
+	case "list":
+		return listAliasesCommand(args[1:])
+	case "add":
+		return addAliasCommand(args[1:])
+	case "remove":
+		return removeAliasCommand(args[1:])
+	case "--help", "-h", "help":
+		_, _ = fmt.Fprintln(os.Stdout, aliasUsage)
+		return nil
+	default:
+		return fmt.Errorf("unknown alias command: %s\n\n%s", args[0], aliasUsage)
+	}
+}
+
+func listAliasesCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New("server name required\n\nUsage: mcp-publisher alias list <name> [--registry=URL]")
+	}
+	serverName := args[0]
+
+	listFlags := flag.NewFlagSet("alias list", flag.ExitOnError)
+	var registryURL string
+	listFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	if err := listFlags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var list apiv0.ServerRedirectListResponse
+	if err := getJSON(context.Background(), serverEndpoint(registryURL, serverName, "/aliases"), &list); err != nil {
+		return fmt.Errorf("failed to get aliases of %s: %w", serverName, err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, list.Redirects)
+	}
+	return printRedirectTable(os.Stdout, list.Redirects)
+}
+
+func addAliasCommand(args []string) error {
+	if len(args) != 2 {
+		return errors.New("server name and alias required\n\nUsage: mcp-publisher alias add <name> <alias>")
+	}
+	serverName, alias := args[0], args[1]
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var redirect apiv0.ServerRedirect
+	if err := sendTokenRequest(ctx, http.MethodPost, serverEndpoint(registryURL, serverName, "/aliases"), registryToken,
+		map[string]string{"alias": alias}, &redirect); err != nil {
+		return fmt.Errorf("failed to add alias: %w", err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, redirect)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ %s now redirects to %s\n", redirect.From, redirect.To)
+	return nil
+}
+
+func removeAliasCommand(args []string) error {
+	if len(args) != 2 {
+		return errors.New("server name and alias required\n\nUsage: mcp-publisher alias remove <name> <alias>")
+	}
+	serverName, alias := args[0], args[1]
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := sendTokenRequest(ctx, http.MethodDelete, serverEndpoint(registryURL, serverName, "/aliases/"+url.PathEscape(alias)),
+		registryToken, nil, nil); err != nil {
+		return fmt.Errorf("failed to remove alias: %w", err)
+	}
+
+	if !jsonOutputEnabled() {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ %s no longer redirects to %s\n", alias, serverName)
+	}
+	return nil
+}
+
+// serverEndpoint returns the URL of a path under a server
+func serverEndpoint(registryURL, serverName, subPath string) string {
+	return registryEndpoint(registryURL, "/v0/servers/"+url.PathEscape(serverName)+subPath)
+}
+
+// printRedirectTable writes a one-line-per-name summary table
+func printRedirectTable(w io.Writer, redirects []apiv0.ServerRedirect) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tREASON\tSINCE")
+	for _, redirect := range redirects {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", redirect.From, redirect.Reason, redirect.CreatedAt.Format(time.DateOnly))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,62 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestRenameCommand(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v0/servers/io.github.alice%2Fweather/rename", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer login-token", r.Header.Get("Authorization"))
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_ = json.NewEncoder(w).Encode(apiv0.ServerRedirect{From: "io.github.alice/weather", To: body["name"], Reason: "rename"})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.RenameCommand([]string{"io.github.alice/weather", "io.github.acme/weather"}))
+	})
+	assert.Contains(t, output, "Renamed io.github.alice/weather to io.github.acme/weather")
+}
+
+func TestAliasCommand_AddAndRemove(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	var requests []string
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(apiv0.ServerRedirect{From: "io.github.alice/weather-mcp", To: "io.github.alice/weather", Reason: "alias"})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.AliasCommand([]string{"add", "io.github.alice/weather", "io.github.alice/weather-mcp"}))
+		require.NoError(t, commands.AliasCommand([]string{"remove", "io.github.alice/weather", "io.github.alice/weather-mcp"}))
+	})
+	assert.Equal(t, []string{
+		"POST /v0/servers/io.github.alice%2Fweather/aliases",
+		"DELETE /v0/servers/io.github.alice%2Fweather/aliases/io.github.alice%2Fweather-mcp",
+	}, requests)
+	assert.Contains(t, output, "io.github.alice/weather-mcp now redirects to io.github.alice/weather")
+	assert.Contains(t, output, "io.github.alice/weather-mcp no longer redirects to io.github.alice/weather")
+}
@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const maxDescriptionColumnWidth = 60
+
+// listingFilters are the search flags that filter the server listing, which full-text searches
+// don't take
+var listingFilters = []string{"version", "updated-since", "supports", "registry-type", "transport", "verified", "max-severity", "platform", "max-image-size"}
+
+// SearchCommand searches the latest versions of servers in the registry by the words in their
+// names, titles, descriptions, tags and tools, most relevant first. Without a query it lists
+// servers instead, filtered by the listing flags.
+func SearchCommand(args []string) error {
+	var query string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		query = args[0]
+		args = args[1:]
+	}
+
+	searchFlags := flag.NewFlagSet("search", flag.ExitOnError)
+	var registryURL, cursor, version, updatedSince, supports, registryType, transport, maxSeverity, platform string
+	var limit int
+	var maxImageSize int64
+	var all, verified, jsonOutput bool
+	searchFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	searchFlags.IntVar(&limit, "limit", 30, "Number of results per page (1-100)")
+	searchFlags.StringVar(&cursor, "cursor", "", "Pagination cursor from a previous search")
+	searchFlags.BoolVar(&all, "all", false, "Fetch every page of results")
+	searchFlags.StringVar(&version, "version", "latest", "Version filter ('latest', an exact version, or empty for all versions)")
+	searchFlags.StringVar(&updatedSince, "updated-since", "", "Only include servers updated since this RFC3339 timestamp")
+	searchFlags.StringVar(&supports, "supports", "", "Only include servers usable by a client supporting these transports and auth methods (e.g. stdio,oauth)")
+	searchFlags.StringVar(&registryType, "registry-type", "", "Only include servers with a package from this registry (npm, pypi, oci, nuget or mcpb)")
+	searchFlags.StringVar(&transport, "transport", "", "Only include servers with a package or remote using this transport (stdio, streamable-http or sse)")
+	searchFlags.BoolVar(&verified, "verified", false, "Only include servers in verified namespaces")
+	searchFlags.StringVar(&maxSeverity, "max-severity", "", "Leave out versions whose scanned images have more severe vulnerabilities (low, moderate, high or critical)")
+	searchFlags.StringVar(&platform, "platform", "", "Leave out versions whose OCI images aren't built for this platform (e.g. linux/arm64)")
+	searchFlags.Int64Var(&maxImageSize, "max-image-size", 0, "Leave out versions with an OCI image larger than this many bytes")
+	searchFlags.BoolVar(&jsonOutput, "json", false, "Output results as JSON")
+	if err := searchFlags.Parse(args); err != nil {
+		return err
+	}
+	jsonOutput = jsonOutput || jsonOutputEnabled()
+	if query == "" && searchFlags.NArg() > 0 {
+		query = searchFlags.Arg(0)
+	}
+
+	if query != "" {
+		var filters []string
+		searchFlags.Visit(func(f *flag.Flag) {
+			if slices.Contains(listingFilters, f.Name) && (f.Name != "version" || version != "latest") {
+				filters = append(filters, "--"+f.Name)
+			}
+		})
+		if len(filters) > 0 {
+			return fmt.Errorf("%s can't be combined with a search query, which matches the latest versions only. Run without a query to filter the listing", strings.Join(filters, ", "))
+		}
+		return runFullTextSearch(registryURL, query, cursor, limit, all, jsonOutput)
+	}
+
+	params := url.Values{}
+	if version != "" {
+		params.Set("version", version)
+	}
+	if updatedSince != "" {
+		params.Set("updated_since", updatedSince)
+	}
+	if supports != "" {
+		params.Set("supports", supports)
+	}
+	if registryType != "" {
+		params.Set("registryType", registryType)
+	}
+	if transport != "" {
+		params.Set("transport", transport)
+	}
+	if verified {
+		params.Set("verified", "true")
+	}
+	if maxSeverity != "" {
+		params.Set("maxSeverity", maxSeverity)
+	}
+	if platform != "" {
+		params.Set("platform", platform)
+	}
+	if maxImageSize > 0 {
+		params.Set("maxImageSize", strconv.FormatInt(maxImageSize, 10))
+	}
+	params.Set("limit", strconv.Itoa(limit))
+
+	ctx := context.Background()
+	var servers []apiv0.ServerResponse
+	for {
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		page, err := listServers(ctx, registryURL, params)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		servers = append(servers, page.Servers...)
+		cursor = page.Metadata.NextCursor
+
+		if !all || cursor == "" {
+			break
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(os.Stdout, apiv0.ServerListResponse{
+			Servers:  servers,
+			Metadata: apiv0.Metadata{NextCursor: cursor, Count: len(servers)},
+		})
+	}
+
+	if len(servers) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "No servers found")
+		return nil
+	}
+
+	if err := printServerTable(os.Stdout, servers); err != nil {
+		return err
+	}
+
+	if cursor != "" {
+		_, _ = fmt.Fprintf(os.Stdout, "\nMore results available. Fetch the next page with --cursor=%q\n", cursor)
+	}
+	return nil
+}
+
+// runFullTextSearch prints the servers matching a query, most relevant first
+func runFullTextSearch(registryURL, query, cursor string, limit int, all, jsonOutput bool) error {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("limit", strconv.Itoa(limit))
+
+	ctx := context.Background()
+	var results []apiv0.ServerSearchResult
+	for {
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		page, err := searchServers(ctx, registryURL, params)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		results = append(results, page.Results...)
+		cursor = page.Metadata.NextCursor
+
+		if !all || cursor == "" {
+			break
+		}
+	}
+
+	if jsonOutput {
+		return printJSON(os.Stdout, apiv0.ServerSearchResponse{
+			Results:  results,
+			Metadata: apiv0.Metadata{NextCursor: cursor, Count: len(results)},
+		})
+	}
+
+	if len(results) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "No servers found")
+		return nil
+	}
+
+	servers := make([]apiv0.ServerResponse, len(results))
+	for i, result := range results {
+		servers[i] = apiv0.ServerResponse{Server: result.Server, Meta: result.Meta}
+	}
+	if err := printServerTable(os.Stdout, servers); err != nil {
+		return err
+	}
+
+	if cursor != "" {
+		_, _ = fmt.Fprintf(os.Stdout, "\nMore results available. Fetch the next page with --cursor=%q\n", cursor)
+	}
+	return nil
+}
+
+// printServerTable writes a one-line-per-server summary table
+func printServerTable(w io.Writer, servers []apiv0.ServerResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tVERSION\tSTATUS\tDESCRIPTION")
+	for _, server := range servers {
+		status := ""
+		if server.Meta.Official != nil {
+			status = string(server.Meta.Official.Status)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			server.Server.Name, server.Server.Version, status, truncate(server.Server.Description, maxDescriptionColumnWidth))
+	}
+	return tw.Flush()
+}
+
+// truncate shortens s to at most maxLen runes, marking the cut with an ellipsis
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-1]) + "…"
+}
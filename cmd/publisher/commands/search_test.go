@@ -0,0 +1,111 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func newTestRegistry(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSearchCommand_FollowsCursorWithAll(t *testing.T) {
+	var queries []string
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers/search", r.URL.Path)
+		assert.Equal(t, "weather", r.URL.Query().Get("q"))
+		queries = append(queries, r.URL.Query().Get("cursor"))
+
+		response := apiv0.ServerSearchResponse{}
+		if r.URL.Query().Get("cursor") == "" {
+			response.Results = []apiv0.ServerSearchResult{{Server: apiv0.ServerJSON{Name: "com.example/weather", Version: "1.0.0"}, Rank: 0.9}}
+			response.Metadata = apiv0.Metadata{NextCursor: "1", Count: 1}
+		} else {
+			response.Results = []apiv0.ServerSearchResult{{Server: apiv0.ServerJSON{Name: "com.example/weather-pro", Version: "2.0.0"}, Rank: 0.4}}
+			response.Metadata = apiv0.Metadata{Count: 1}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.SearchCommand([]string{"weather", "--registry", registry.URL, "--all"}))
+	})
+	assert.Equal(t, []string{"", "1"}, queries)
+	assert.Regexp(t, `(?s)com\.example/weather .*com\.example/weather-pro `, output, "most relevant first")
+}
+
+func TestSearchCommand_ListsWithoutQuery(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers", r.URL.Path)
+		assert.Equal(t, "latest", r.URL.Query().Get("version"))
+		assert.Equal(t, "stdio,oauth", r.URL.Query().Get("supports"))
+		_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{
+			Servers: []apiv0.ServerResponse{{Server: apiv0.ServerJSON{Name: "com.example/weather", Version: "1.0.0"}}},
+		})
+	})
+
+	require.NoError(t, commands.SearchCommand([]string{"--registry", registry.URL, "--supports", "stdio,oauth"}))
+
+	err := commands.SearchCommand([]string{"weather", "--registry", registry.URL, "--supports", "stdio,oauth"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--supports can't be combined with a search query")
+}
+
+func TestSearchCommand_ServerError(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := commands.SearchCommand([]string{"weather", "--registry", registry.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+}
+
+func TestShowCommand(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/v0/servers/com.example%2Fweather/versions/latest":
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{
+				Server: apiv0.ServerJSON{
+					Name:          "com.example/weather",
+					Description:   "Weather data",
+					Version:       "1.0.0",
+					Remotes:       []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://api.example.com/mcp"}},
+					Relationships: []model.Relationship{{Type: model.RelationshipSupersedes, Name: "com.example/weather-legacy"}},
+				},
+			})
+		case "/v0/servers/com.example%2Fweather/versions":
+			_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{
+				Servers: []apiv0.ServerResponse{{Server: apiv0.ServerJSON{Name: "com.example/weather", Version: "1.0.0"}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.ShowCommand([]string{"com.example/weather", "--registry", registry.URL}))
+	})
+	assert.Contains(t, output, "Relationships:\n  supersedes  com.example/weather-legacy")
+	require.NoError(t, commands.ShowCommand([]string{"com.example/weather", "--registry", registry.URL, "--versions", "--json"}))
+
+	err := commands.ShowCommand([]string{"com.example/missing", "--registry", registry.URL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+
+	err = commands.ShowCommand([]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server name required")
+}
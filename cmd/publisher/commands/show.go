@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ShowCommand prints the details of a single server from the registry
+func ShowCommand(args []string) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		return errors.New("server name required\n\nUsage: mcp-publisher show <name> [--version=VERSION] [--versions] [--json]")
+	}
+	serverName := args[0]
+
+	showFlags := flag.NewFlagSet("show", flag.ExitOnError)
+	var registryURL, version string
+	var listVersions, jsonOutput bool
+	showFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	showFlags.StringVar(&version, "version", "latest", "Version to show")
+	showFlags.BoolVar(&listVersions, "versions", false, "List all published versions instead of a single version")
+	showFlags.BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	if err := showFlags.Parse(args[1:]); err != nil {
+		return err
+	}
+	jsonOutput = jsonOutput || jsonOutputEnabled()
+
+	ctx := context.Background()
+
+	if listVersions {
+		versions, err := getServerVersions(ctx, registryURL, serverName)
+		if err != nil {
+			return fmt.Errorf("failed to get versions of %s: %w", serverName, err)
+		}
+		if jsonOutput {
+			return printJSON(os.Stdout, versions)
+		}
+		return printVersionTable(os.Stdout, versions.Servers)
+	}
+
+	server, err := getServerVersion(ctx, registryURL, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", serverName, err)
+	}
+	if jsonOutput {
+		return printJSON(os.Stdout, server)
+	}
+	printServerDetails(os.Stdout, server)
+	return nil
+}
+
+// printVersionTable writes a one-line-per-version summary table
+func printVersionTable(w io.Writer, versions []apiv0.ServerResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "VERSION\tSTATUS\tPUBLISHED\tLATEST")
+	for _, server := range versions {
+		var status, published string
+		var isLatest bool
+		if official := server.Meta.Official; official != nil {
+			status = string(official.Status)
+			published = official.PublishedAt.Format(time.RFC3339)
+			isLatest = official.IsLatest
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", server.Server.Version, status, published, isLatest)
+	}
+	return tw.Flush()
+}
+
+// printServerDetails writes a human-readable description of a server version
+func printServerDetails(w io.Writer, server *apiv0.ServerResponse) {
+	s := server.Server
+	_, _ = fmt.Fprintf(w, "Name:         %s\n", s.Name)
+	if s.Title != "" {
+		_, _ = fmt.Fprintf(w, "Title:        %s\n", s.Title)
+	}
+	_, _ = fmt.Fprintf(w, "Description:  %s\n", s.Description)
+	_, _ = fmt.Fprintf(w, "Version:      %s\n", s.Version)
+	if official := server.Meta.Official; official != nil {
+		_, _ = fmt.Fprintf(w, "Status:       %s\n", official.Status)
+		_, _ = fmt.Fprintf(w, "Published:    %s\n", official.PublishedAt.Format(time.RFC3339))
+		_, _ = fmt.Fprintf(w, "Latest:       %t\n", official.IsLatest)
+	}
+	if s.Repository.URL != "" {
+		_, _ = fmt.Fprintf(w, "Repository:   %s\n", s.Repository.URL)
+	}
+	if s.WebsiteURL != "" {
+		_, _ = fmt.Fprintf(w, "Website:      %s\n", s.WebsiteURL)
+	}
+
+	if len(s.Packages) > 0 {
+		_, _ = fmt.Fprintln(w, "\nPackages:")
+		for _, pkg := range s.Packages {
+			identifier := pkg.Identifier
+			if pkg.Version != "" {
+				identifier += "@" + pkg.Version
+			}
+			_, _ = fmt.Fprintf(w, "  %s  %s  (%s)\n", pkg.RegistryType, identifier, pkg.Transport.Type)
+			for _, env := range pkg.EnvironmentVariables {
+				_, _ = fmt.Fprintf(w, "    env %s%s\n", env.Name, describeInputFlags(env.Input))
+			}
+		}
+	}
+
+	if len(s.Remotes) > 0 {
+		_, _ = fmt.Fprintln(w, "\nRemotes:")
+		for _, remote := range s.Remotes {
+			_, _ = fmt.Fprintf(w, "  %s  %s\n", remote.Type, remote.URL)
+			for _, header := range remote.Headers {
+				_, _ = fmt.Fprintf(w, "    header %s%s\n", header.Name, describeInputFlags(header.Input))
+			}
+		}
+	}
+
+	if len(s.Relationships) > 0 {
+		_, _ = fmt.Fprintln(w, "\nRelationships:")
+		for _, rel := range s.Relationships {
+			_, _ = fmt.Fprintf(w, "  %s  %s\n", rel.Type, rel.Name)
+		}
+	}
+}
+
+// describeInputFlags summarises the required/secret flags of an input, e.g. " (required, secret)"
+func describeInputFlags(input model.Input) string {
+	var flags []string
+	if input.IsRequired {
+		flags = append(flags, "required")
+	}
+	if input.IsSecret {
+		flags = append(flags, "secret")
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(flags, ", ") + ")"
+}
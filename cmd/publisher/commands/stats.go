@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// statsCounters are the usage counters read from publisher-provided metadata, in display order
+var statsCounters = []struct {
+	key    string
+	header string
+}{
+	{"pulls", "PULLS"},
+	{"stars", "STARS"},
+	{"githubStars", "GITHUB STARS"},
+}
+
+// sparkTicks are the bar characters used to draw a trend, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// serverStats is the usage of one server: the counters of its latest version and, when the
+// server has several versions, the counters recorded with each of them
+type serverStats struct {
+	Name    string             `json:"name"`
+	Version string             `json:"version"`
+	Counts  map[string]float64 `json:"counts"`
+	History []statsPoint       `json:"history,omitempty"`
+}
+
+type statsPoint struct {
+	Version     string             `json:"version"`
+	PublishedAt time.Time          `json:"publishedAt"`
+	Counts      map[string]float64 `json:"counts"`
+}
+
+// StatsCommand prints usage counters (pulls, stars, GitHub stars) for a server or for every
+// server in a namespace. The registry doesn't track usage itself, so the counters come from
+// the publisher-provided metadata of each version; the trend shows how they changed from
+// version to version.
+func StatsCommand(args []string) error {
+	var serverName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverName = args[0]
+		args = args[1:]
+	}
+
+	statsFlags := flag.NewFlagSet("stats", flag.ExitOnError)
+	var registryURL, namespace string
+	var jsonOutput bool
+	statsFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	statsFlags.StringVar(&namespace, "namespace", "", "Show every server in this namespace (e.g. io.github.username)")
+	statsFlags.BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	if err := statsFlags.Parse(args); err != nil {
+		return err
+	}
+	jsonOutput = jsonOutput || jsonOutputEnabled()
+	if serverName == "" && namespace == "" {
+		return errors.New("server name or namespace required\n\nUsage: mcp-publisher stats <name> | --namespace=NAMESPACE [--json]")
+	}
+
+	ctx := context.Background()
+	var versions []apiv0.ServerResponse
+	if serverName != "" {
+		list, err := getServerVersions(ctx, registryURL, serverName)
+		if err != nil {
+			return fmt.Errorf("failed to get versions of %s: %w", serverName, err)
+		}
+		versions = list.Servers
+	} else {
+		var err error
+		versions, err = listAllServerVersions(ctx, registryURL, namespace, time.Time{})
+		if err != nil {
+			return fmt.Errorf("failed to list servers in %s: %w", namespace, err)
+		}
+	}
+
+	stats := collectServerStats(versions)
+	if jsonOutput {
+		return printJSON(os.Stdout, stats)
+	}
+	if len(stats) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "No servers found")
+		return nil
+	}
+	return printStatsTable(os.Stdout, stats)
+}
+
+// collectServerStats groups versions by server, ordered by name and then publish time
+func collectServerStats(versions []apiv0.ServerResponse) []serverStats {
+	byName := map[string][]apiv0.ServerResponse{}
+	for _, version := range versions {
+		byName[version.Server.Name] = append(byName[version.Server.Name], version)
+	}
+
+	stats := make([]serverStats, 0, len(byName))
+	for name, serverVersions := range byName {
+		sort.SliceStable(serverVersions, func(i, j int) bool {
+			iMeta, jMeta := serverVersions[i].Meta.Official, serverVersions[j].Meta.Official
+			if iMeta == nil || jMeta == nil {
+				return false
+			}
+			return iMeta.PublishedAt.Before(jMeta.PublishedAt)
+		})
+
+		entry := serverStats{Name: name}
+		for _, version := range serverVersions {
+			point := statsPoint{Version: version.Server.Version, Counts: publisherCounters(&version.Server)}
+			if official := version.Meta.Official; official != nil {
+				point.PublishedAt = official.PublishedAt
+			}
+			entry.History = append(entry.History, point)
+
+			if official := version.Meta.Official; official == nil || official.IsLatest || entry.Version == "" {
+				entry.Version = point.Version
+				entry.Counts = point.Counts
+			}
+		}
+		if len(entry.History) < 2 {
+			entry.History = nil
+		}
+		stats = append(stats, entry)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// publisherCounters reads the numeric usage counters from publisher-provided metadata
+func publisherCounters(server *apiv0.ServerJSON) map[string]float64 {
+	counts := map[string]float64{}
+	if server.Meta == nil {
+		return counts
+	}
+	for _, counter := range statsCounters {
+		if value, ok := server.Meta.PublisherProvided[counter.key].(float64); ok {
+			counts[counter.key] = value
+		}
+	}
+	return counts
+}
+
+func printStatsTable(w io.Writer, stats []serverStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := []string{"NAME", "VERSION"}
+	for _, counter := range statsCounters {
+		headers = append(headers, counter.header)
+	}
+	_, _ = fmt.Fprintln(tw, strings.Join(append(headers, "TREND (PULLS)"), "\t"))
+
+	for _, entry := range stats {
+		columns := []string{entry.Name, entry.Version}
+		for _, counter := range statsCounters {
+			value, ok := entry.Counts[counter.key]
+			if !ok {
+				columns = append(columns, "-")
+				continue
+			}
+			columns = append(columns, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+
+		var trend []float64
+		for _, point := range entry.History {
+			if value, ok := point.Counts["pulls"]; ok {
+				trend = append(trend, value)
+			}
+		}
+		columns = append(columns, sparkline(trend))
+		_, _ = fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	}
+	return tw.Flush()
+}
+
+// sparkline draws values as a row of bars scaled between their minimum and maximum
+func sparkline(values []float64) string {
+	if len(values) < 2 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+
+	var b strings.Builder
+	for _, value := range values {
+		tick := 0
+		if high > low {
+			tick = int((value - low) / (high - low) * float64(len(sparkTicks)-1))
+		}
+		b.WriteRune(sparkTicks[tick])
+	}
+	return b.String()
+}
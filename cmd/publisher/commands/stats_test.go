@@ -0,0 +1,60 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func versionWithCounters(version string, publishedAt time.Time, isLatest bool, pulls float64) apiv0.ServerResponse {
+	server := serverVersion("com.example/weather", version, publishedAt)
+	server.Meta.Official.IsLatest = isLatest
+	server.Server.Meta = &apiv0.ServerMeta{PublisherProvided: map[string]any{"pulls": pulls, "stars": float64(3)}}
+	return server
+}
+
+func TestStatsCommand(t *testing.T) {
+	now := time.Now()
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers/com.example%2Fweather/versions", r.URL.EscapedPath())
+		_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{Servers: []apiv0.ServerResponse{
+			versionWithCounters("1.1.0", now.Add(-time.Hour), false, 150),
+			versionWithCounters("1.0.0", now.Add(-2*time.Hour), false, 100),
+			versionWithCounters("1.2.0", now, true, 400),
+		}})
+	})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.StatsCommand([]string{"com.example/weather", "--registry", registry.URL}))
+	})
+	assert.Contains(t, output, "com.example/weather  1.2.0    400")
+	assert.Contains(t, output, "▁▂█")
+
+	output = captureStdout(t, func() {
+		require.NoError(t, commands.StatsCommand([]string{"com.example/weather", "--registry", registry.URL, "--json"}))
+	})
+	var stats []struct {
+		Version string             `json:"version"`
+		Counts  map[string]float64 `json:"counts"`
+		History []struct {
+			Version string `json:"version"`
+		} `json:"history"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &stats))
+	require.Len(t, stats, 1)
+	assert.Equal(t, "1.2.0", stats[0].Version)
+	assert.InDelta(t, 400, stats[0].Counts["pulls"], 0)
+	require.Len(t, stats[0].History, 3)
+	assert.Equal(t, "1.0.0", stats[0].History[0].Version)
+}
+
+func TestStatsCommand_RequiresScope(t *testing.T) {
+	require.Error(t, commands.StatsCommand(nil))
+}
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const tokensUsage = `Usage: mcp-publisher tokens <command> [arguments]
+
+Commands:
+  create   Create a personal access token (--name=NAME [--expires-in-days=90] [--permission=ACTION:RESOURCE]...)
+  list     List your personal access tokens and when they were last used
+  revoke   Revoke a personal access token (<id>)
+
+Personal access tokens are long-lived credentials for automation. Use one with
+'mcp-publisher login pat --token=TOKEN' or by setting $MCP_PUBLISHER_PAT.
+Managing tokens requires an interactive login, not one made with a token.`
+
+// tokenPermissions collects repeated --permission flags of the form action:resource
+type tokenPermissions []apiv0.TokenPermission
+
+func (p *tokenPermissions) String() string {
+	parts := make([]string, 0, len(*p))
+	for _, perm := range *p {
+		parts = append(parts, perm.Action+":"+perm.Resource)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (p *tokenPermissions) Set(v string) error {
+	action, resource, found := strings.Cut(v, ":")
+	if !found || resource == "" || (action != "publish" && action != "edit") {
+		return fmt.Errorf("invalid permission: %q (expected publish:RESOURCE or edit:RESOURCE)", v)
+	}
+	*p = append(*p, apiv0.TokenPermission{Action: action, Resource: resource})
+	return nil
+}
+
+// TokensCommand manages personal access tokens
+func TokensCommand(args []string) error {
+	if len(args) < 1 {
+		return errors.New(tokensUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		return createTokenCommand(args[1:])
+	case "list":
+		return listTokensCommand()
+	case "revoke":
+		return revokeTokenCommand(args[1:])
+	case "--help", "-h", "help":
+		_, _ = fmt.Fprintln(os.Stdout, tokensUsage)
+		return nil
+	default:
+		return fmt.Errorf("unknown tokens command: %s\n\n%s", args[0], tokensUsage)
+	}
+}
+
+func createTokenCommand(args []string) error {
+	createFlags := flag.NewFlagSet("tokens create", flag.ExitOnError)
+	var name string
+	var expiresInDays int
+	var permissions tokenPermissions
+	createFlags.StringVar(&name, "name", "", "Name to recognize the token by")
+	createFlags.IntVar(&expiresInDays, "expires-in-days", 90, "Number of days until the token expires (at most 365)")
+	createFlags.Var(&permissions, "permission", "Permission to give the token, as action:resource (repeatable; default: your publish permissions)")
+	if err := createFlags.Parse(args); err != nil {
+		return err
+	}
+	if name == "" {
+		return errors.New("token name required\n\nUsage: mcp-publisher tokens create --name=NAME [--expires-in-days=90] [--permission=publish:io.github.you/*]")
+	}
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]any{"name": name, "expiresInDays": expiresInDays}
+	if len(permissions) > 0 {
+		body["permissions"] = permissions
+	}
+	var created apiv0.CreatedPersonalAccessToken
+	if err := sendTokenRequest(ctx, http.MethodPost, registryEndpoint(registryURL, "/v0/tokens"), registryToken, body, &created); err != nil {
+		return fmt.Errorf("failed to create token: %w", err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, created)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Created token %q (%s), expiring %s\n\n%s\n\n", created.Name, created.ID, created.ExpiresAt.Format(time.DateOnly), created.Token)
+	_, _ = fmt.Fprintln(os.Stdout, "Copy it now: it won't be shown again.")
+	return nil
+}
+
+func listTokensCommand() error {
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var list apiv0.PersonalAccessTokenListResponse
+	if err := sendTokenRequest(ctx, http.MethodGet, registryEndpoint(registryURL, "/v0/tokens"), registryToken, nil, &list); err != nil {
+		return fmt.Errorf("failed to list tokens: %w", err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, list.Tokens)
+	}
+	if len(list.Tokens) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "No personal access tokens")
+		return nil
+	}
+	return printTokenTable(os.Stdout, list.Tokens, time.Now())
+}
+
+func revokeTokenCommand(args []string) error {
+	if len(args) != 1 {
+		return errors.New("token ID required\n\nUsage: mcp-publisher tokens revoke <id>")
+	}
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var revoked apiv0.PersonalAccessToken
+	revokeURL := registryEndpoint(registryURL, "/v0/tokens/"+url.PathEscape(args[0]))
+	if err := sendTokenRequest(ctx, http.MethodDelete, revokeURL, registryToken, nil, &revoked); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, revoked)
+	}
+	_, _ = fmt.Fprintf(os.Stdout, "✓ Revoked token %q (%s)\n", revoked.Name, revoked.ID)
+	return nil
+}
+
+// printTokenTable writes a one-line-per-token summary table
+func printTokenTable(w io.Writer, tokens []apiv0.PersonalAccessToken, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tPERMISSIONS\tEXPIRES\tLAST USED\tSTATUS")
+	for _, token := range tokens {
+		permissions := tokenPermissions(token.Permissions)
+		lastUsed := "never"
+		if token.LastUsedAt != nil {
+			lastUsed = token.LastUsedAt.Format(time.DateOnly)
+		}
+		status := "active"
+		switch {
+		case token.RevokedAt != nil:
+			status = "revoked"
+		case now.After(token.ExpiresAt):
+			status = "expired"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s…\t%s\t%s\t%s\t%s\n",
+			token.ID, token.Name, token.Prefix, permissions.String(), token.ExpiresAt.Format(time.DateOnly), lastUsed, status)
+	}
+	return tw.Flush()
+}
+
+// sendTokenRequest sends an authenticated request to the registry and decodes the JSON response
+// into out, unless out is nil
+func sendTokenRequest(ctx context.Context, method, requestURL, registryToken string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		reader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+registryToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("server returned status %d: %s", resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("error parsing response: %w", err)
+	}
+	return nil
+}
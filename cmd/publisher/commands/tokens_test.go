@@ -0,0 +1,103 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestTokensCommand_CreateSendsPermissions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v0/tokens", r.URL.Path)
+		assert.Equal(t, "Bearer login-token", r.Header.Get("Authorization"))
+
+		var body struct {
+			Name          string                  `json:"name"`
+			ExpiresInDays int                     `json:"expiresInDays"`
+			Permissions   []apiv0.TokenPermission `json:"permissions"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "ci", body.Name)
+		assert.Equal(t, 30, body.ExpiresInDays)
+		assert.Equal(t, []apiv0.TokenPermission{{Action: "publish", Resource: "io.github.alice/weather"}}, body.Permissions)
+
+		_ = json.NewEncoder(w).Encode(apiv0.CreatedPersonalAccessToken{
+			PersonalAccessToken: apiv0.PersonalAccessToken{ID: "tok-1", Name: body.Name, ExpiresAt: time.Now().AddDate(0, 0, 30)},
+			Token:               "mcp_pat_secret",
+		})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.TokensCommand([]string{"create", "--name", "ci", "--expires-in-days", "30", "--permission", "publish:io.github.alice/weather"}))
+	})
+	assert.Contains(t, output, "mcp_pat_secret")
+	assert.Contains(t, output, "won't be shown again")
+}
+
+func TestTokensCommand_ListShowsStatus(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	lastUsed := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		_ = json.NewEncoder(w).Encode(apiv0.PersonalAccessTokenListResponse{Tokens: []apiv0.PersonalAccessToken{
+			{ID: "tok-2", Name: "release", Prefix: "mcp_pat_ab12", ExpiresAt: time.Now().Add(time.Hour), LastUsedAt: &lastUsed,
+				Permissions: []apiv0.TokenPermission{{Action: "publish", Resource: "io.github.alice/*"}}},
+			{ID: "tok-1", Name: "old", Prefix: "mcp_pat_cd34", ExpiresAt: time.Now().Add(-time.Hour)},
+		}})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.TokensCommand([]string{"list"}))
+	})
+	assert.Contains(t, output, "publish:io.github.alice/*")
+	assert.Contains(t, output, "2026-03-01")
+	assert.Regexp(t, `tok-2 .*active`, output)
+	assert.Regexp(t, `tok-1 .*never\s+expired`, output)
+}
+
+func TestLoginCommand_PersonalAccessTokenRefreshes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+	t.Setenv("MCP_PUBLISHER_PAT", "mcp_pat_secret")
+
+	var exchanges int
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/auth/pat", r.URL.Path)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "mcp_pat_secret", body["token"])
+		exchanges++
+		_, _ = fmt.Fprintf(w, `{"registry_token": %q, "expires_at": %d}`, fakeJWT(t, time.Now().Add(time.Hour)), time.Now().Add(time.Hour).Unix())
+	})
+
+	captureStdout(t, func() {
+		require.NoError(t, commands.LoginCommand([]string{"pat", "--registry", registry.URL}))
+	})
+	assert.Equal(t, 1, exchanges)
+
+	// The personal access token is kept to refresh registry tokens
+	data, err := os.ReadFile(filepath.Join(home, commands.TokenFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"refresh_token":"mcp_pat_secret"`)
+}
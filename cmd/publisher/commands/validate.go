@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// validationResult is the --output json form of a validation run
+type validationResult struct {
+	File     string   `json:"file"`
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+}
+
+// ValidateCommand runs the registry's publish-time validation against a local server.json
+// without contacting the registry itself. It exits non-zero on any problem, so it can be
+// used from pre-commit hooks and CI.
+func ValidateCommand(args []string) error {
+	serverFile := "server.json"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverFile = args[0]
+		args = args[1:]
+	}
+
+	validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var skipRegistryValidation bool
+	validateFlags.BoolVar(&skipRegistryValidation, "skip-registry-validation", false, "Skip package ownership checks against npm, PyPI, NuGet, OCI and MCPB sources")
+	if err := validateFlags.Parse(args); err != nil {
+		return err
+	}
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+
+	problems := validateServerData(context.Background(), serverData, !skipRegistryValidation)
+	if jsonOutputEnabled() {
+		if err := printJSON(os.Stdout, validationResult{File: serverFile, Valid: len(problems) == 0, Problems: problems}); err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("validation failed with %d error(s)", len(problems))
+		}
+		return nil
+	}
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ %s is valid\n", serverFile)
+		return nil
+	}
+
+	for _, problem := range problems {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %s\n", serverFile, problem)
+	}
+	return fmt.Errorf("validation failed with %d error(s)", len(problems))
+}
+
+// validateServerData runs the same stages the registry runs on publish: JSON decoding,
+// schema validation, then the Go validators (including registry ownership checks when
+// enabled). Like the registry, it stops at the first stage that reports problems.
+func validateServerData(ctx context.Context, data []byte, enableRegistryValidation bool) []string {
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(data, &serverJSON); err != nil {
+		return []string{describeJSONError(data, err)}
+	}
+
+	schemaErrors, err := validators.ValidateServerJSONSchema(data)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if len(schemaErrors) > 0 {
+		problems := make([]string, 0, len(schemaErrors))
+		for _, detail := range schemaErrors {
+			if detail.Location == "" {
+				problems = append(problems, detail.Message)
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("%s: %s", detail.Location, detail.Message))
+		}
+		return problems
+	}
+
+	cfg := &config.Config{EnableRegistryValidation: enableRegistryValidation}
+	if err := validators.ValidatePublishRequest(ctx, serverJSON, cfg); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+// describeJSONError annotates JSON decoding errors with a line and column so editors
+// and pre-commit output can point at the offending location
+func describeJSONError(data []byte, err error) string {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return fmt.Sprintf("invalid JSON: %v", err)
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("line %d, column %d: invalid JSON: %v", line, column, err)
+}
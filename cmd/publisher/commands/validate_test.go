@@ -0,0 +1,92 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+func TestValidateCommand(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+		errorSubstr string
+	}{
+		{
+			name: "valid server.json passes",
+			content: `{
+  "$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json",
+  "name": "com.example/test-server",
+  "description": "A test server",
+  "version": "1.0.0",
+  "remotes": [{"type": "streamable-http", "url": "https://api.example.com/mcp"}]
+}`,
+			expectError: false,
+		},
+		{
+			name: "invalid JSON fails",
+			content: `{
+  "name": "com.example/test-server",
+}`,
+			expectError: true,
+			errorSubstr: "validation failed with 1 error(s)",
+		},
+		{
+			name: "schema violations fail",
+			content: `{
+  "$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json",
+  "name": "no-slash",
+  "description": "",
+  "version": "1.0.0"
+}`,
+			expectError: true,
+			errorSubstr: "validation failed with 2 error(s)",
+		},
+		{
+			name: "Go validator failures fail",
+			content: `{
+  "$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json",
+  "name": "com.example/test-server",
+  "description": "A test server",
+  "version": "latest"
+}`,
+			expectError: true,
+			errorSubstr: "validation failed with 1 error(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverFile := filepath.Join(tempDir, "server.json")
+			if err := os.WriteFile(serverFile, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("Failed to write server.json: %v", err)
+			}
+
+			err := commands.ValidateCommand([]string{serverFile, "--skip-registry-validation"})
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, but got none")
+				}
+				if !strings.Contains(err.Error(), tt.errorSubstr) {
+					t.Errorf("Expected error containing '%s', got: %v", tt.errorSubstr, err)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCommand_MissingFile(t *testing.T) {
+	err := commands.ValidateCommand([]string{filepath.Join(t.TempDir(), "server.json")})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,432 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// mcpProtocolVersion is the MCP protocol version offered in the initialize request
+const mcpProtocolVersion = "2025-06-18"
+
+// verifyResult is the --output json form of a verify run
+type verifyResult struct {
+	Command         []string `json:"command"`
+	ServerName      string   `json:"serverName"`
+	ServerVersion   string   `json:"serverVersion"`
+	ProtocolVersion string   `json:"protocolVersion"`
+	Tools           []string `json:"tools"`
+	MissingTools    []string `json:"missingTools"`
+	UndeclaredTools []string `json:"undeclaredTools"`
+	Verified        bool     `json:"verified"`
+}
+
+// VerifyCommand starts a package declared in server.json, performs an MCP initialize and
+// tools/list over stdio, and checks the served tools against the tools declared in
+// server.json's publisher-provided metadata
+func VerifyCommand(args []string) error {
+	serverFile := "server.json"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverFile = args[0]
+		args = args[1:]
+	}
+
+	verifyFlags := flag.NewFlagSet("verify", flag.ExitOnError)
+	var packageIndex int
+	var timeout time.Duration
+	verifyFlags.IntVar(&packageIndex, "package", 0, "Index of the package to verify, for servers with several packages")
+	verifyFlags.DurationVar(&timeout, "timeout", 2*time.Minute, "How long to wait for the package to start and answer")
+	if err := verifyFlags.Parse(args); err != nil {
+		return err
+	}
+
+	serverData, err := os.ReadFile(serverFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", serverFile)
+		}
+		return fmt.Errorf("failed to read %s: %w", serverFile, err)
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &server); err != nil {
+		return fmt.Errorf("invalid %s: %w", serverFile, err)
+	}
+	if len(server.Packages) == 0 {
+		return fmt.Errorf("%s declares no packages to run", serverFile)
+	}
+	if packageIndex < 0 || packageIndex >= len(server.Packages) {
+		return fmt.Errorf("invalid --package %d: %s declares %d package(s)", packageIndex, serverFile, len(server.Packages))
+	}
+	pkg := &server.Packages[packageIndex]
+
+	command, env, err := packageCommand(pkg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	out := statusWriter()
+	_, _ = fmt.Fprintf(out, "Starting %s...\n", strings.Join(command, " "))
+	session, err := startMCPSession(ctx, command, env)
+	if err != nil {
+		return err
+	}
+	defer session.close()
+
+	var initResult struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	err = session.request("initialize", map[string]any{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": "mcp-publisher", "version": "verify"},
+	}, &initResult)
+	if err != nil {
+		return session.failure("initialize", err)
+	}
+	_, _ = fmt.Fprintf(out, "✓ Initialized %s %s (protocol %s)\n", initResult.ServerInfo.Name, initResult.ServerInfo.Version, initResult.ProtocolVersion)
+
+	if err := session.notify("notifications/initialized"); err != nil {
+		return session.failure("initialized notification", err)
+	}
+
+	tools, err := session.listTools()
+	if err != nil {
+		return session.failure("tools/list", err)
+	}
+	_, _ = fmt.Fprintf(out, "✓ Server lists %d tool(s): %s\n", len(tools), strings.Join(tools, ", "))
+
+	result := verifyResult{
+		Command:         command,
+		ServerName:      initResult.ServerInfo.Name,
+		ServerVersion:   initResult.ServerInfo.Version,
+		ProtocolVersion: initResult.ProtocolVersion,
+		Tools:           tools,
+		MissingTools:    []string{},
+		UndeclaredTools: []string{},
+	}
+	if declared := declaredToolNames(&server); declared != nil {
+		for _, name := range declared {
+			if !slices.Contains(tools, name) {
+				result.MissingTools = append(result.MissingTools, name)
+				_, _ = fmt.Fprintf(out, "✗ Declared tool %q is not served\n", name)
+			}
+		}
+		for _, name := range tools {
+			if !slices.Contains(declared, name) {
+				result.UndeclaredTools = append(result.UndeclaredTools, name)
+				_, _ = fmt.Fprintf(out, "! Served tool %q is not declared in %s\n", name, serverFile)
+			}
+		}
+	}
+	result.Verified = len(result.MissingTools) == 0
+
+	if jsonOutputEnabled() {
+		if err := printJSON(os.Stdout, result); err != nil {
+			return err
+		}
+	}
+	if !result.Verified {
+		return fmt.Errorf("verification failed: %d declared tool(s) are not served", len(result.MissingTools))
+	}
+	_, _ = fmt.Fprintf(out, "✓ %s verified\n", server.Name)
+	return nil
+}
+
+// packageCommand builds the command line that runs a stdio package the way an MCP client
+// would, and the environment variables to pass to it. Declared environment variables are
+// taken from the current environment, falling back to their declared value or default.
+func packageCommand(pkg *model.Package) ([]string, []string, error) {
+	if pkg.Transport.Type != model.TransportTypeStdio {
+		return nil, nil, fmt.Errorf("only stdio packages can be verified (package uses %s)", pkg.Transport.Type)
+	}
+
+	var env, envNames, missing []string
+	for _, variable := range pkg.EnvironmentVariables {
+		value, ok := os.LookupEnv(variable.Name)
+		if !ok {
+			value = inputValue(&variable.Input)
+		}
+		if value == "" {
+			if variable.IsRequired {
+				missing = append(missing, variable.Name)
+			}
+			continue
+		}
+		env = append(env, variable.Name+"="+value)
+		envNames = append(envNames, variable.Name)
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("required environment variable(s) not set: %s", strings.Join(missing, ", "))
+	}
+
+	runtimeArgs, err := argumentValues(pkg.RuntimeArguments)
+	if err != nil {
+		return nil, nil, err
+	}
+	packageArgs, err := argumentValues(pkg.PackageArguments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var command []string
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		if len(runtimeArgs) == 0 {
+			runtimeArgs = []string{"-y"}
+		}
+		command = append(append([]string{"npx"}, runtimeArgs...), pkg.Identifier+"@"+pkg.Version)
+	case model.RegistryTypePyPI:
+		command = append(append([]string{"uvx"}, runtimeArgs...), pkg.Identifier+"=="+pkg.Version)
+	case model.RegistryTypeOCI:
+		command = append([]string{"docker", "run", "-i", "--rm"}, runtimeArgs...)
+		for _, name := range envNames {
+			// Pass variables by name so secret values stay out of the process list
+			command = append(command, "-e", name)
+		}
+		command = append(command, pkg.Identifier)
+	default:
+		return nil, nil, fmt.Errorf("verifying %s packages is not supported (supported: npm, pypi, oci)", pkg.RegistryType)
+	}
+	return append(command, packageArgs...), env, nil
+}
+
+// argumentValues resolves declared arguments to command line words. Optional arguments
+// without a value are left out; templated values can't be resolved and are an error.
+func argumentValues(arguments []model.Argument) ([]string, error) {
+	var values []string
+	for _, argument := range arguments {
+		value := inputValue(&argument.Input)
+		if strings.Contains(value, "{") {
+			return nil, fmt.Errorf("argument %s uses template variables, which verify can't fill in", argumentLabel(&argument))
+		}
+		switch {
+		case argument.Type == model.ArgumentTypeNamed && value == "" && argument.IsRequired:
+			// A required flag without a value is a switch, e.g. --stdio
+			values = append(values, argument.Name)
+		case value == "" && argument.IsRequired:
+			return nil, fmt.Errorf("required argument %s has no value or default", argumentLabel(&argument))
+		case value == "":
+			continue
+		case argument.Type == model.ArgumentTypeNamed:
+			values = append(values, argument.Name, value)
+		default:
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+func argumentLabel(argument *model.Argument) string {
+	if argument.Name != "" {
+		return argument.Name
+	}
+	return argument.ValueHint
+}
+
+func inputValue(input *model.Input) string {
+	if input.Value != "" {
+		return input.Value
+	}
+	return input.Default
+}
+
+// declaredToolNames returns the tool names listed under "tools" in the publisher-provided
+// metadata, or nil if server.json doesn't declare any
+func declaredToolNames(server *apiv0.ServerJSON) []string {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return nil
+	}
+	tools, ok := server.Meta.PublisherProvided["tools"].([]any)
+	if !ok {
+		return nil
+	}
+	names := []string{}
+	for _, tool := range tools {
+		switch tool := tool.(type) {
+		case string:
+			names = append(names, tool)
+		case map[string]any:
+			if name, ok := tool["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// jsonRPCMessage is any JSON-RPC 2.0 message exchanged with the server
+type jsonRPCMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int            `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// mcpSession is a minimal MCP client speaking newline-delimited JSON-RPC over a child's stdio
+type mcpSession struct {
+	ctx    context.Context
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+	stderr bytes.Buffer
+	nextID int
+	closed bool
+}
+
+func startMCPSession(ctx context.Context, command, env []string) (*mcpSession, error) {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+
+	session := &mcpSession{ctx: ctx, cmd: cmd}
+	cmd.Stderr = &session.stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", command[0], err)
+	}
+
+	session.stdin = stdin
+	session.stdout = bufio.NewScanner(stdout)
+	session.stdout.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	return session, nil
+}
+
+func (s *mcpSession) send(message jsonRPCMessage) error {
+	message.JSONRPC = "2.0"
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = s.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (s *mcpSession) notify(method string) error {
+	return s.send(jsonRPCMessage{Method: method})
+}
+
+// request sends a request and waits for its response, declining any requests the server
+// makes in the meantime and skipping notifications and non-JSON log lines
+func (s *mcpSession) request(method string, params any, result any) error {
+	s.nextID++
+	id := s.nextID
+	if err := s.send(jsonRPCMessage{ID: &id, Method: method, Params: params}); err != nil {
+		return err
+	}
+
+	for s.stdout.Scan() {
+		var message jsonRPCMessage
+		if err := json.Unmarshal(s.stdout.Bytes(), &message); err != nil {
+			continue
+		}
+		if message.Method != "" {
+			if message.ID != nil {
+				_ = s.send(jsonRPCMessage{ID: message.ID, Result: json.RawMessage(`{}`)})
+			}
+			continue
+		}
+		if message.ID == nil || *message.ID != id {
+			continue
+		}
+		if message.Error != nil {
+			return fmt.Errorf("server returned error %d: %s", message.Error.Code, message.Error.Message)
+		}
+		return json.Unmarshal(message.Result, result)
+	}
+	if err := s.stdout.Err(); err != nil {
+		return err
+	}
+	if s.ctx.Err() != nil {
+		return errors.New("timed out waiting for the server (use --timeout to wait longer)")
+	}
+	return errors.New("server closed its output without responding")
+}
+
+// listTools returns the names of every tool the server lists, following pagination
+func (s *mcpSession) listTools() ([]string, error) {
+	names := []string{}
+	params := map[string]any{}
+	for {
+		var page struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+			NextCursor string `json:"nextCursor"`
+		}
+		if err := s.request("tools/list", params, &page); err != nil {
+			return nil, err
+		}
+		for _, tool := range page.Tools {
+			names = append(names, tool.Name)
+		}
+		if page.NextCursor == "" {
+			return names, nil
+		}
+		params = map[string]any{"cursor": page.NextCursor}
+	}
+}
+
+// failure stops the server and describes a failed handshake step, including the end of
+// the server's stderr
+func (s *mcpSession) failure(step string, err error) error {
+	s.close()
+	if output := strings.TrimSpace(s.stderr.String()); output != "" {
+		const maxOutput = 2000
+		if len(output) > maxOutput {
+			output = "..." + output[len(output)-maxOutput:]
+		}
+		return fmt.Errorf("%s failed: %w\n\nServer output:\n%s", step, err, output)
+	}
+	return fmt.Errorf("%s failed: %w", step, err)
+}
+
+func (s *mcpSession) close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	_ = s.stdin.Close()
+	done := make(chan struct{})
+	go func() {
+		_ = s.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		_ = s.cmd.Process.Kill()
+		<-done
+	}
+}
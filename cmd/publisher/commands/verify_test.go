@@ -0,0 +1,88 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// withFakeNPX puts an npx on PATH that answers initialize and tools/list like a stdio MCP server
+func withFakeNPX(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := `#!/bin/sh
+read -r initialize
+echo "starting weather server"
+echo '{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2025-06-18","capabilities":{"tools":{}},"serverInfo":{"name":"weather","version":"1.2.0"}}}'
+read -r initialized
+read -r list
+echo '{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","data":"listing tools"}}'
+echo '{"jsonrpc":"2.0","id":2,"result":{"tools":[{"name":"get_forecast"},{"name":"get_alerts"}]}}'
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "npx"), []byte(script), 0o700)) //nolint:gosec // test helper must be executable
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func verifyFixture(declaredTools ...string) apiv0.ServerJSON {
+	server := apiv0.ServerJSON{
+		Name:        "com.example/weather",
+		Description: "Weather forecasts",
+		Version:     "1.2.0",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather",
+			Version:      "1.2.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		}},
+	}
+	if declaredTools != nil {
+		tools := make([]any, 0, len(declaredTools))
+		for _, name := range declaredTools {
+			tools = append(tools, map[string]any{"name": name})
+		}
+		server.Meta = &apiv0.ServerMeta{PublisherProvided: map[string]any{"tools": tools}}
+	}
+	return server
+}
+
+func TestVerifyCommand(t *testing.T) {
+	withFakeNPX(t)
+
+	path := writeLocalServerJSON(t, verifyFixture("get_forecast"))
+	require.NoError(t, commands.VerifyCommand([]string{path, "--timeout", "10s"}))
+
+	path = writeLocalServerJSON(t, verifyFixture("get_forecast", "get_history"))
+	err := commands.VerifyCommand([]string{path, "--timeout", "10s"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 declared tool(s) are not served")
+}
+
+func TestVerifyCommand_RequiredEnvironmentVariable(t *testing.T) {
+	server := verifyFixture()
+	server.Packages[0].EnvironmentVariables = []model.KeyValueInput{{
+		Name:               "WEATHER_TEST_API_KEY",
+		InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true}},
+	}}
+	path := writeLocalServerJSON(t, server)
+
+	err := commands.VerifyCommand([]string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WEATHER_TEST_API_KEY")
+}
+
+func TestVerifyCommand_UnsupportedTransport(t *testing.T) {
+	server := verifyFixture()
+	server.Packages[0].Transport = model.Transport{Type: model.TransportTypeStreamableHTTP, URL: "http://localhost:8080/mcp"}
+	path := writeLocalServerJSON(t, server)
+
+	err := commands.VerifyCommand([]string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only stdio packages")
+}
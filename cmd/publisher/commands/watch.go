@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// watchOptions selects which changes the watch command reports
+type watchOptions struct {
+	registryURL string
+	search      string
+	namespace   string
+	match       string
+	command     string
+}
+
+// WatchCommand polls the registry for servers published or updated since it started and
+// prints (or runs a command for) every change that matches the filters
+func WatchCommand(args []string) error {
+	watchFlags := flag.NewFlagSet("watch", flag.ExitOnError)
+	var opts watchOptions
+	var since string
+	var interval time.Duration
+	var once bool
+	watchFlags.StringVar(&opts.registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	watchFlags.StringVar(&opts.search, "search", "", "Only servers whose name contains this text")
+	watchFlags.StringVar(&opts.namespace, "namespace", "", "Only servers in this namespace (e.g. io.github.username)")
+	watchFlags.StringVar(&opts.match, "match", "", "Only servers whose name, title or description contains this keyword (case-insensitive)")
+	watchFlags.StringVar(&opts.command, "exec", "", "Shell command to run for each change, with the server response as JSON on stdin")
+	watchFlags.StringVar(&since, "since", "", "Report changes since this RFC3339 timestamp (default: now)")
+	watchFlags.DurationVar(&interval, "interval", time.Minute, "How often to poll the registry")
+	watchFlags.BoolVar(&once, "once", false, "Poll once and exit, e.g. from cron")
+	if err := watchFlags.Parse(args); err != nil {
+		return err
+	}
+
+	cursor := time.Now().UTC()
+	if since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return fmt.Errorf("invalid --since timestamp: %w", err)
+		}
+		cursor = parsed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !once {
+		_, _ = fmt.Fprintf(statusWriter(), "Watching %s for changes since %s (Ctrl+C to stop)\n", opts.registryURL, cursor.Format(time.RFC3339))
+	}
+
+	for {
+		next, err := pollChanges(ctx, opts, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if once {
+				return err
+			}
+			// Keep watching through transient registry or network failures
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			cursor = next
+		}
+
+		if once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pollChanges reports every matching server updated after since, returning the newest
+// update time seen so the next poll continues from there
+func pollChanges(ctx context.Context, opts watchOptions, since time.Time) (time.Time, error) {
+	params := url.Values{}
+	params.Set("updated_since", since.Format(time.RFC3339Nano))
+	params.Set("limit", "100")
+	if opts.search != "" {
+		params.Set("search", opts.search)
+	}
+
+	newest := since
+	for {
+		page, err := listServers(ctx, opts.registryURL, params)
+		if err != nil {
+			return since, fmt.Errorf("failed to poll registry: %w", err)
+		}
+
+		for _, server := range page.Servers {
+			if server.Meta.Official != nil && server.Meta.Official.UpdatedAt.After(newest) {
+				newest = server.Meta.Official.UpdatedAt
+			}
+			if !opts.matches(&server) {
+				continue
+			}
+			if err := reportChange(ctx, opts, &server); err != nil {
+				return since, err
+			}
+		}
+
+		if page.Metadata.NextCursor == "" {
+			return newest, nil
+		}
+		params.Set("cursor", page.Metadata.NextCursor)
+	}
+}
+
+func (opts watchOptions) matches(server *apiv0.ServerResponse) bool {
+	if opts.namespace != "" && !strings.HasPrefix(server.Server.Name, opts.namespace+"/") {
+		return false
+	}
+	if opts.match != "" {
+		keyword := strings.ToLower(opts.match)
+		text := strings.ToLower(server.Server.Name + " " + server.Server.Title + " " + server.Server.Description)
+		if !strings.Contains(text, keyword) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportChange prints a change and runs the --exec command for it
+func reportChange(ctx context.Context, opts watchOptions, server *apiv0.ServerResponse) error {
+	var status, updatedAt string
+	if official := server.Meta.Official; official != nil {
+		status = string(official.Status)
+		updatedAt = official.UpdatedAt.Format(time.RFC3339)
+	}
+
+	if jsonOutputEnabled() {
+		// One JSON document per line, so the stream can be piped into jq
+		data, err := json.Marshal(server)
+		if err != nil {
+			return fmt.Errorf("error marshaling JSON: %w", err)
+		}
+		_, _ = fmt.Fprintln(os.Stdout, string(data))
+	} else {
+		_, _ = fmt.Fprintf(os.Stdout, "%s  %s@%s  %s\n", updatedAt, server.Server.Name, server.Server.Version, status)
+	}
+
+	if opts.command == "" {
+		return nil
+	}
+
+	input, err := json.Marshal(server)
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+
+	shell, shellFlag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, shellFlag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, shellFlag, opts.command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"MCP_SERVER_NAME="+server.Server.Name,
+		"MCP_SERVER_VERSION="+server.Server.Version,
+		"MCP_SERVER_STATUS="+status,
+	)
+	if err := cmd.Run(); err != nil {
+		// A failing hook shouldn't stop the watch
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: --exec failed for %s@%s: %v\n", server.Server.Name, server.Server.Version, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestWatchCommand_OnceFiltersAndRunsHook(t *testing.T) {
+	since := "2025-10-01T00:00:00Z"
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers", r.URL.Path)
+		assert.Equal(t, since, r.URL.Query().Get("updated_since"))
+
+		database := serverVersion("com.example/postgres", "1.0.0", time.Now())
+		database.Server.Description = "Query a Database"
+		_ = json.NewEncoder(w).Encode(apiv0.ServerListResponse{Servers: []apiv0.ServerResponse{
+			database,
+			serverVersion("com.example/weather", "1.0.0", time.Now()),
+		}})
+	})
+
+	hookOutput := filepath.Join(t.TempDir(), "hook")
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.WatchCommand([]string{
+			"--registry", registry.URL, "--since", since, "--once", "--match", "database",
+			"--exec", `echo "$MCP_SERVER_NAME@$MCP_SERVER_VERSION" >> ` + hookOutput,
+		}))
+	})
+
+	assert.Contains(t, output, "com.example/postgres@1.0.0")
+	assert.NotContains(t, output, "com.example/weather")
+
+	hookData, err := os.ReadFile(hookOutput)
+	require.NoError(t, err)
+	assert.Equal(t, "com.example/postgres@1.0.0", strings.TrimSpace(string(hookData)))
+}
+
+func TestWatchCommand_OnceReportsRegistryErrors(t *testing.T) {
+	registry := newTestRegistry(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	err := commands.WatchCommand([]string{"--registry", registry.URL, "--once"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// YankCommand yanks a published version, or restores a yanked one with --undo
+func YankCommand(args []string) error {
+	const usage = "Usage: mcp-publisher yank <name> <version> [--undo]"
+	if len(args) < 2 || strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[1], "-") {
+		return errors.New("server name and version required\n\n" + usage)
+	}
+	serverName, version := args[0], args[1]
+
+	yankFlags := flag.NewFlagSet("yank", flag.ExitOnError)
+	var undo bool
+	yankFlags.BoolVar(&undo, "undo", false, "Restore a yanked version")
+	if err := yankFlags.Parse(args[2:]); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	registryToken, registryURL, err := loadRegistryToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	if undo {
+		method = http.MethodDelete
+	}
+	yankURL := registryEndpoint(registryURL, fmt.Sprintf("/v0/servers/%s/versions/%s/yank", url.PathEscape(serverName), url.PathEscape(version)))
+	var server apiv0.ServerResponse
+	if err := sendTokenRequest(ctx, method, yankURL, registryToken, nil, &server); err != nil {
+		return fmt.Errorf("failed to yank %s %s: %w", serverName, version, err)
+	}
+
+	if jsonOutputEnabled() {
+		return printJSON(os.Stdout, server)
+	}
+	if undo {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ Restored %s %s\n", serverName, version)
+	} else {
+		_, _ = fmt.Fprintf(os.Stdout, "✓ Yanked %s %s\n", serverName, version)
+	}
+	if server.Meta.Official != nil && server.Meta.Official.IsLatest {
+		_, _ = fmt.Fprintln(os.Stdout, "  It is now the latest version")
+	}
+	return nil
+}
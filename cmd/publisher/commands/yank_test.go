@@ -0,0 +1,39 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestYankCommand_UndoRestores(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MCP_PUBLISHER_NO_KEYCHAIN", "1")
+
+	var methods []string
+	registry := newTestRegistry(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers/io.github.alice%2Fweather/versions/1.2.0/yank", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer login-token", r.Header.Get("Authorization"))
+		methods = append(methods, r.Method)
+		_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Name: "io.github.alice/weather", Version: "1.2.0"},
+			Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{IsLatest: r.Method == http.MethodDelete}},
+		})
+	})
+	writeTokenFile(t, home, map[string]any{"token": "login-token", "method": "github", "registry": registry.URL})
+
+	output := captureStdout(t, func() {
+		require.NoError(t, commands.YankCommand([]string{"io.github.alice/weather", "1.2.0"}))
+		require.NoError(t, commands.YankCommand([]string{"io.github.alice/weather", "1.2.0", "--undo"}))
+	})
+	assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, methods)
+	assert.Contains(t, output, "Yanked io.github.alice/weather 1.2.0")
+	assert.Contains(t, output, "Restored io.github.alice/weather 1.2.0\n  It is now the latest version")
+}
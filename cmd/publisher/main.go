@@ -22,28 +22,70 @@ var (
 )
 
 func main() {
-	if len(os.Args) < 2 {
+	args, err := commands.ExtractGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(args) < 1 {
 		printUsage()
 		os.Exit(1)
 	}
 
-	var err error
-	switch os.Args[1] {
+	switch args[0] {
 	case "init":
-		err = commands.InitCommand()
+		err = commands.InitCommand(args[1:])
 	case "login":
-		err = commands.LoginCommand(os.Args[2:])
+		err = commands.LoginCommand(args[1:])
 	case "logout":
 		err = commands.LogoutCommand()
 	case "publish":
-		err = commands.PublishCommand(os.Args[2:])
+		err = commands.PublishCommand(args[1:])
+	case "validate":
+		err = commands.ValidateCommand(args[1:])
+	case "lint":
+		err = commands.LintCommand(args[1:])
+	case "verify":
+		err = commands.VerifyCommand(args[1:])
+	case "release":
+		err = commands.ReleaseCommand(args[1:])
+	case "yank":
+		err = commands.YankCommand(args[1:])
+	case "search":
+		err = commands.SearchCommand(args[1:])
+	case "show":
+		err = commands.ShowCommand(args[1:])
+	case "stats":
+		err = commands.StatsCommand(args[1:])
+	case "watch":
+		err = commands.WatchCommand(args[1:])
+	case "diff":
+		err = commands.DiffCommand(args[1:])
+	case "export":
+		err = commands.ExportCommand(args[1:])
+	case "import":
+		err = commands.ImportCommand(args[1:])
+	case "mirror":
+		err = commands.MirrorCommand(args[1:])
+	case "channel":
+		err = commands.ChannelCommand(args[1:])
+	case "rename":
+		err = commands.RenameCommand(args[1:])
+	case "alias":
+		err = commands.AliasCommand(args[1:])
+	case "tokens":
+		err = commands.TokensCommand(args[1:])
+	case "admin":
+		err = commands.AdminCommand(args[1:])
+	case "completion":
+		err = commands.CompletionCommand(args[1:])
 	case "--version", "-v", "version":
 		log.Printf("mcp-publisher %s (commit: %s, built: %s)", Version, GitCommit, BuildTime)
 		return
 	case "--help", "-h", "help":
 		printUsage()
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", os.Args[1])
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", args[0])
 		printUsage()
 		os.Exit(1)
 	}
@@ -58,13 +100,35 @@ func printUsage() {
 	_, _ = fmt.Fprintln(os.Stdout, "MCP Registry Publisher Tool")
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Usage:")
-	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher <command> [arguments]")
+	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher [global options] <command> [arguments]")
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Commands:")
 	_, _ = fmt.Fprintln(os.Stdout, "  init          Create a server.json file template")
 	_, _ = fmt.Fprintln(os.Stdout, "  login         Authenticate with the registry")
 	_, _ = fmt.Fprintln(os.Stdout, "  logout        Clear saved authentication")
 	_, _ = fmt.Fprintln(os.Stdout, "  publish       Publish server.json to the registry")
+	_, _ = fmt.Fprintln(os.Stdout, "  validate      Validate server.json locally without publishing")
+	_, _ = fmt.Fprintln(os.Stdout, "  lint          Check server.json style and fix mechanical problems")
+	_, _ = fmt.Fprintln(os.Stdout, "  verify        Run a package from server.json and check its MCP handshake")
+	_, _ = fmt.Fprintln(os.Stdout, "  release       Bump the version in server.json and publish it")
+	_, _ = fmt.Fprintln(os.Stdout, "  yank          Hide a broken version without deleting it")
+	_, _ = fmt.Fprintln(os.Stdout, "  search        Search the registry for servers")
+	_, _ = fmt.Fprintln(os.Stdout, "  show          Show details of a server in the registry")
+	_, _ = fmt.Fprintln(os.Stdout, "  stats         Show usage counters for servers")
+	_, _ = fmt.Fprintln(os.Stdout, "  watch         Watch the registry for new and updated servers")
+	_, _ = fmt.Fprintln(os.Stdout, "  diff          Compare server.json with the published version")
+	_, _ = fmt.Fprintln(os.Stdout, "  export        Export servers from a registry as NDJSON")
+	_, _ = fmt.Fprintln(os.Stdout, "  import        Publish servers from an export file to a registry")
+	_, _ = fmt.Fprintln(os.Stdout, "  mirror        Copy new servers from one registry into another")
+	_, _ = fmt.Fprintln(os.Stdout, "  channel       Tag versions into stable and beta release channels")
+	_, _ = fmt.Fprintln(os.Stdout, "  rename        Move a server to a new name, redirecting the old one")
+	_, _ = fmt.Fprintln(os.Stdout, "  alias         Add and remove names that redirect to a server")
+	_, _ = fmt.Fprintln(os.Stdout, "  tokens        Create, list and revoke personal access tokens")
+	_, _ = fmt.Fprintln(os.Stdout, "  admin         Moderate servers (registry admins only)")
+	_, _ = fmt.Fprintln(os.Stdout, "  completion    Print a shell completion script (bash, zsh, fish)")
+	_, _ = fmt.Fprintln(os.Stdout)
+	_, _ = fmt.Fprintln(os.Stdout, "Global options:")
+	_, _ = fmt.Fprintln(os.Stdout, "  --output json Print results as JSON (messages go to stderr)")
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Use 'mcp-publisher <command> --help' for more information about a command.")
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const backupPageSize = 1000
+
+// backupCommand writes every server version in the database as a seed file, which
+// `registry seed` can import into an empty database
+func backupCommand(cfg *config.Config, args []string) error {
+	flags := newFlagSet("backup", "backup [-output FILE]")
+	output := flags.String("output", "", "Write the backup to this file instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := connectDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	servers, err := listAllServers(ctx, service.NewRegistryService(db, cfg))
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(servers); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	log.Printf("Backed up %d server versions", len(servers))
+	return nil
+}
+
+// listAllServers reads every server version in the registry from a snapshot
+func listAllServers(ctx context.Context, registryService service.RegistryService) ([]apiv0.ServerJSON, error) {
+	responses, err := listAllServerResponses(ctx, registryService)
+	if err != nil {
+		return nil, err
+	}
+	servers := make([]apiv0.ServerJSON, 0, len(responses))
+	for _, response := range responses {
+		servers = append(servers, response.Server)
+	}
+	return servers, nil
+}
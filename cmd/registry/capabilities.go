@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/sandbox"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// extractCapabilitiesCommand runs the sandbox worker, which extracts the tools, resources and
+// prompts of the server versions publishers and admins queue for extraction. It needs a
+// container runtime, so it runs apart from the API server.
+func extractCapabilitiesCommand(cfg *config.Config, args []string) error {
+	flags := newFlagSet("extract-capabilities", "extract-capabilities [-once] [-interval DURATION] [-timeout DURATION] [-runtime CLI] [-network NAME]")
+	once := flags.Bool("once", false, "Extract the queued server versions and exit instead of polling")
+	interval := flags.Duration("interval", 30*time.Second, "How often to check for queued server versions")
+	options := sandbox.DefaultOptions
+	flags.StringVar(&options.Runtime, "runtime", options.Runtime, "Docker-compatible CLI to run sandboxes with")
+	flags.StringVar(&options.Network, "network", options.Network, "Network for npm and PyPI packages, which are downloaded as they start. OCI images always run without a network")
+	flags.StringVar(&options.Memory, "memory", options.Memory, "Memory limit of each sandbox")
+	flags.StringVar(&options.CPUs, "cpus", options.CPUs, "CPU limit of each sandbox")
+	flags.StringVar(&options.NodeImage, "node-image", options.NodeImage, "Image that runs npm packages with npx")
+	flags.StringVar(&options.PythonImage, "python-image", options.PythonImage, "Image that runs PyPI packages with uvx")
+	flags.DurationVar(&options.Timeout, "timeout", options.Timeout, "How long a server has to start and list its capabilities")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *interval <= 0 || options.Timeout <= 0 || options.Timeout >= service.StaleCapabilityExtraction {
+		flags.Usage()
+		return fmt.Errorf("the interval must be positive and the timeout between 0 and %s", service.StaleCapabilityExtraction)
+	}
+
+	db, err := connectDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	worker := sandbox.NewWorker(service.NewRegistryService(db, cfg), options)
+	if *once {
+		return worker.Poll(ctx)
+	}
+
+	log.Printf("Extracting capabilities every %s with %s", *interval, options.Runtime)
+	worker.Run(ctx, *interval)
+	return nil
+}
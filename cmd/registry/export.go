@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// staticManifest describes a static export. It is written to v0/export.json.
+type staticManifest struct {
+	GeneratedAt  time.Time `json:"generatedAt"`
+	Servers      int       `json:"servers"`
+	Versions     int       `json:"versions"`
+	ListPages    int       `json:"listPages"`
+	SearchShards int       `json:"searchShards"`
+}
+
+// staticSearchEntry is the part of a server's latest version that static search matches on
+type staticSearchEntry struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// exportStaticCommand renders the registry into a directory of JSON files that mirror the read
+// API, so that a read-only mirror can be served from a CDN or GitHub Pages without a backend
+func exportStaticCommand(cfg *config.Config, args []string) error {
+	flags := newFlagSet("export-static", "export-static -output DIR [-page-size N] [-shard-size N] [-root-key FILE -targets-key FILE [-root-version N] [-previous-root-key FILE]]")
+	output := flags.String("output", "", "Directory to write the export to (required)")
+	pageSize := flags.Int("page-size", 100, "Number of servers per list page")
+	shardSize := flags.Int("shard-size", 1000, "Number of servers per search index shard")
+	rootKeyFile := flags.String("root-key", "", "File with the hex-encoded Ed25519 seed of the root key, to sign the export")
+	targetsKeyFile := flags.String("targets-key", "", "File with the hex-encoded Ed25519 seed of the targets key, to sign the export")
+	previousRootKeyFile := flags.String("previous-root-key", "", "File with the seed of the previous root key, when rotating the root key")
+	rootVersion := flags.Int("root-version", 1, "Version of the root metadata; increase it whenever a key changes")
+	rootExpires := flags.Duration("root-expires", 365*24*time.Hour, "How long the root metadata is valid for")
+	targetsExpires := flags.Duration("targets-expires", 7*24*time.Hour, "How long the targets metadata is valid for; export again before it runs out")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" || *pageSize < 1 || *shardSize < 1 {
+		flags.Usage()
+		return errors.New("an output directory and positive page and shard sizes are required")
+	}
+
+	var signing *exportSigningOptions
+	if *rootKeyFile != "" || *targetsKeyFile != "" {
+		if *rootKeyFile == "" || *targetsKeyFile == "" || *rootVersion < 1 {
+			flags.Usage()
+			return errors.New("signing needs both a root and a targets key, and a positive root version")
+		}
+		signing = &exportSigningOptions{rootVersion: *rootVersion, rootExpires: *rootExpires, targetsExpires: *targetsExpires}
+		var err error
+		if signing.rootKey, err = loadSigningKey(*rootKeyFile); err != nil {
+			return err
+		}
+		if signing.targetsKey, err = loadSigningKey(*targetsKeyFile); err != nil {
+			return err
+		}
+		if *previousRootKeyFile != "" {
+			if signing.previousRootKey, err = loadSigningKey(*previousRootKeyFile); err != nil {
+				return err
+			}
+		}
+	}
+
+	db, err := connectDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	versions, err := listAllServerResponses(ctx, service.NewRegistryService(db, cfg))
+	if err != nil {
+		return err
+	}
+
+	manifest, err := writeStaticExport(*output, versions, *pageSize, *shardSize)
+	if err != nil {
+		return err
+	}
+
+	if signing != nil {
+		if err := signStaticExport(*output, signing); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Exported %d servers (%d versions) to %s", manifest.Servers, manifest.Versions, *output)
+	return nil
+}
+
+// listAllServerResponses pages through a snapshot of every server version in the registry with
+// its metadata, so that versions published during the read don't leave it inconsistent
+func listAllServerResponses(ctx context.Context, registryService service.RegistryService) ([]*apiv0.ServerResponse, error) {
+	snapshot, err := registryService.CreateSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	filter := &database.ServerFilter{Snapshot: &snapshot.ID}
+
+	var servers []*apiv0.ServerResponse
+	cursor := ""
+	for {
+		page, nextCursor, err := registryService.ListServers(ctx, filter, cursor, backupPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		servers = append(servers, page...)
+		if nextCursor == "" {
+			return servers, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// writeStaticExport writes the export into dir:
+//   - v0/servers/{serverName}/versions/{version}.json and latest.json, each a server version
+//   - v0/servers/{serverName}/versions/index.json, the versions that aren't yanked
+//   - v0/pages/{n}.json, the latest versions by name, linked by metadata.nextCursor
+//   - v0/search/{n}.json, shards of a search index of the latest versions
+//   - v0/export.json, the manifest
+//
+// Pages and shards live outside v0/servers, where their names could clash with server names.
+func writeStaticExport(dir string, versions []*apiv0.ServerResponse, pageSize, shardSize int) (*staticManifest, error) {
+	byName := map[string][]*apiv0.ServerResponse{}
+	var latest []*apiv0.ServerResponse
+	for _, version := range versions {
+		name := version.Server.Name
+		if !filepath.IsLocal(name) || !filepath.IsLocal(version.Server.Version) {
+			log.Printf("Skipping %s %s: not a safe path", name, version.Server.Version)
+			continue
+		}
+		byName[name] = append(byName[name], version)
+		if version.Meta.Official != nil && version.Meta.Official.IsLatest && isListed(version) {
+			latest = append(latest, version)
+		}
+	}
+	sort.Slice(latest, func(i, j int) bool { return latest[i].Server.Name < latest[j].Server.Name })
+
+	manifest := &staticManifest{GeneratedAt: time.Now().UTC(), Servers: len(byName)}
+
+	for name, serverVersions := range byName {
+		listed := []apiv0.ServerResponse{}
+		for _, version := range serverVersions {
+			if err := writeStaticJSON(dir, version, "v0", "servers", name, "versions", version.Server.Version+".json"); err != nil {
+				return nil, err
+			}
+			if version.Meta.Official != nil && version.Meta.Official.IsLatest {
+				if err := writeStaticJSON(dir, version, "v0", "servers", name, "versions", "latest.json"); err != nil {
+					return nil, err
+				}
+			}
+			if isListed(version) {
+				listed = append(listed, *version)
+			}
+			manifest.Versions++
+		}
+
+		index := apiv0.ServerListResponse{Servers: listed, Metadata: apiv0.Metadata{Count: len(listed)}}
+		if err := writeStaticJSON(dir, index, "v0", "servers", name, "versions", "index.json"); err != nil {
+			return nil, err
+		}
+	}
+
+	// An empty registry still gets an empty first page
+	manifest.ListPages = max(1, (len(latest)+pageSize-1)/pageSize)
+	for n := 1; n <= manifest.ListPages; n++ {
+		start, end := (n-1)*pageSize, min(n*pageSize, len(latest))
+		page := apiv0.ServerListResponse{Servers: []apiv0.ServerResponse{}, Metadata: apiv0.Metadata{Count: end - start}}
+		for _, server := range latest[start:end] {
+			page.Servers = append(page.Servers, *server)
+		}
+		if n < manifest.ListPages {
+			page.Metadata.NextCursor = strconv.Itoa(n + 1)
+		}
+		if err := writeStaticJSON(dir, page, "v0", "pages", strconv.Itoa(n)+".json"); err != nil {
+			return nil, err
+		}
+	}
+
+	for start := 0; start < len(latest); start += shardSize {
+		end := min(start+shardSize, len(latest))
+		manifest.SearchShards++
+		shard := []staticSearchEntry{}
+		for _, server := range latest[start:end] {
+			shard = append(shard, staticSearchEntry{
+				Name:        server.Server.Name,
+				Title:       server.Server.Title,
+				Description: server.Server.Description,
+				Version:     server.Server.Version,
+			})
+		}
+		if err := writeStaticJSON(dir, shard, "v0", "search", strconv.Itoa(manifest.SearchShards)+".json"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeStaticJSON(dir, manifest, "v0", "export.json"); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// isListed reports whether a version shows up in listings, which leave out yanked versions
+func isListed(version *apiv0.ServerResponse) bool {
+	return version.Meta.Official == nil || version.Meta.Official.YankedAt == nil
+}
+
+// writeStaticJSON writes a value as JSON to a file under dir, creating its directories
+func writeStaticJSON(dir string, value any, path ...string) error {
+	file := filepath.Join(append([]string{dir}, path...)...)
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", file, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil { //nolint:gosec // The export is published as a public website
+		return fmt.Errorf("failed to create directory for %s: %w", file, err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil { //nolint:gosec // The export is published as a public website
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// healthPath is the liveness probe the healthcheck subcommand checks
+const healthPath = "/healthz"
+
+// healthcheckCommand checks that the registry listening on the configured address responds
+// to the health endpoint, returning an error (exit code 1) if it doesn't. It backs the
+// Docker image's HEALTHCHECK, which has no curl or wget to call the endpoint with.
+func healthcheckCommand(cfg *config.Config, args []string) error {
+	flags := newFlagSet("healthcheck", "healthcheck [-timeout DURATION] [-url URL]")
+	timeout := flags.Duration("timeout", 5*time.Second, "How long to wait for a response")
+	healthURL := flags.String("url", "", "URL to check (default: the liveness probe on $MCP_REGISTRY_SERVER_ADDRESS)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *healthURL == "" {
+		localURL, err := localHealthURL(cfg.ServerAddress)
+		if err != nil {
+			return err
+		}
+		*healthURL = localURL
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *healthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("registry is not responding: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry is unhealthy: %s returned status %d", *healthURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// localHealthURL turns the server's listen address into a URL on the loopback interface
+func localHealthURL(serverAddress string) (string, error) {
+	host, port, err := net.SplitHostPort(serverAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid server address %q: %w", serverAddress, err)
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, port) + healthPath, nil
+}
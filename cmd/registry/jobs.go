@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/exports"
+	"github.com/modelcontextprotocol/registry/internal/jobs"
+	"github.com/modelcontextprotocol/registry/internal/ownership"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/webhooks"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// serverPurgeInterval is how often servers deleted longer ago than their retention are purged
+	serverPurgeInterval = time.Hour
+	// jobPruneInterval is how often finished jobs are deleted
+	jobPruneInterval = time.Hour
+	// succeededJobRetention and deadJobRetention are how long finished jobs are kept: dead ones
+	// longer, for operators to look into
+	succeededJobRetention = 24 * time.Hour
+	deadJobRetention      = 30 * 24 * time.Hour
+)
+
+// newJobRunner creates the runner of the registry's background jobs, scheduling the recurring ones
+// whose interval is configured. A recurring job gets a single attempt, as the next interval's job
+// runs again anyway; failed ones are kept dead so that they show up.
+func newJobRunner(registry service.RegistryService, cfg *config.Config, metrics *telemetry.Metrics) *jobs.Runner {
+	runner := jobs.NewRunner(registry, metrics)
+	recurring := jobs.Options{MaxAttempts: 1}
+
+	dispatcher := webhooks.NewDispatcher(registry)
+	runner.Handle("webhooks.deliver", func(ctx context.Context, _ *apiv0.Job) error {
+		return dispatcher.Poll(ctx)
+	}, recurring)
+	if cfg.WebhookDeliveryInterval > 0 {
+		runner.Every("webhooks.deliver", cfg.WebhookDeliveryInterval)
+	}
+
+	verifier := ownership.NewVerifier(registry)
+	runner.Handle("namespace_claims.check", func(ctx context.Context, _ *apiv0.Job) error {
+		return verifier.Poll(ctx)
+	}, recurring)
+	if cfg.NamespaceClaimCheckInterval > 0 {
+		runner.Every("namespace_claims.check", cfg.NamespaceClaimCheckInterval)
+	}
+
+	generator := exports.NewGenerator(registry, cfg)
+	runner.Handle("exports.generate", func(ctx context.Context, _ *apiv0.Job) error {
+		return generator.Generate(ctx)
+	}, jobs.Options{MaxAttempts: 1, Timeout: 30 * time.Minute})
+	if cfg.ExportInterval > 0 {
+		runner.Every("exports.generate", cfg.ExportInterval)
+	}
+
+	runner.Handle("servers.purge", func(ctx context.Context, _ *apiv0.Job) error {
+		purged, err := registry.PurgeDeletedServers(ctx, time.Now().Add(-cfg.DeletedServerRetention))
+		if purged > 0 {
+			log.Printf("Purged %d deleted servers", purged)
+		}
+		return err
+	}, recurring)
+	runner.Every("servers.purge", serverPurgeInterval)
+
+	runner.Handle("jobs.prune", func(ctx context.Context, _ *apiv0.Job) error {
+		now := time.Now()
+		pruned, err := registry.PruneJobs(ctx, now.Add(-succeededJobRetention), now.Add(-deadJobRetention))
+		if err == nil && pruned > 0 {
+			log.Printf("Deleted %d finished jobs", pruned)
+		}
+		return err
+	}, recurring)
+	runner.Every("jobs.prune", jobPruneInterval)
+
+	return runner
+}
@@ -2,22 +2,15 @@ package main
 
 import (
 	"context"
-	"errors"
 	"flag"
+	"fmt"
 	"log"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"strings"
 	"time"
 
-	"github.com/modelcontextprotocol/registry/internal/api"
-	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
-	"github.com/modelcontextprotocol/registry/internal/importer"
-	"github.com/modelcontextprotocol/registry/internal/service"
-	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 // Version info for the MCP Registry application
@@ -34,108 +27,128 @@ var (
 )
 
 func main() {
-	// Parse command line flags
-	showVersion := flag.Bool("version", false, "Display version information")
-	flag.Parse()
-
-	// Show version information if requested
-	if *showVersion {
-		log.Printf("MCP Registry %s\n", Version)
-		log.Printf("Git commit: %s\n", GitCommit)
-		log.Printf("Build time: %s\n", BuildTime)
-		return
+	// Without a subcommand the binary serves the API, so existing deployments that run
+	// ./registry (optionally with -version) keep working
+	command := "serve"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
 	}
 
-	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
-
-	var (
-		registryService service.RegistryService
-		db              database.Database
-		err             error
-	)
-
-	// Initialize configuration
+	// Initialize configuration shared by every subcommand
 	cfg := config.NewConfig()
 
-	// Create a context with timeout for PostgreSQL connection
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	var err error
+	switch command {
+	case "serve":
+		err = serveCommand(cfg, args)
+	case "seed":
+		err = seedCommand(cfg, args)
+	case "backup":
+		err = backupCommand(cfg, args)
+	case "export-static":
+		err = exportStaticCommand(cfg, args)
+	case "verify-export":
+		err = verifyExportCommand(args)
+	case "extract-capabilities":
+		err = extractCapabilitiesCommand(cfg, args)
+	case "healthcheck":
+		err = healthcheckCommand(cfg, args)
+	case "migrate":
+		err = migrateCommand(cfg, args)
+	case "version":
+		printVersion()
+	case "help":
+		printUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
+		printUsage()
+		os.Exit(2)
+	}
 
-	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
 	if err != nil {
-		log.Printf("Failed to connect to PostgreSQL: %v", err)
-		return
+		log.Printf("Error: %v", err)
+		os.Exit(1)
 	}
+}
 
-	// Store the PostgreSQL instance for later cleanup
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Printf("Error closing PostgreSQL connection: %v", err)
-		} else {
-			log.Println("PostgreSQL connection closed successfully")
-		}
-	}()
-
-	registryService = service.NewRegistryService(db, cfg)
+func printVersion() {
+	log.Printf("MCP Registry %s\n", Version)
+	log.Printf("Git commit: %s\n", GitCommit)
+	log.Printf("Build time: %s\n", BuildTime)
+}
 
-	// Import seed data if seed source is provided
-	if cfg.SeedFrom != "" {
-		log.Printf("Importing data from %s...", cfg.SeedFrom)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
+func printUsage() {
+	_, _ = fmt.Fprintln(os.Stderr, "MCP Registry")
+	_, _ = fmt.Fprintln(os.Stderr)
+	_, _ = fmt.Fprintln(os.Stderr, "Usage:")
+	_, _ = fmt.Fprintln(os.Stderr, "  registry [command] [arguments]")
+	_, _ = fmt.Fprintln(os.Stderr)
+	_, _ = fmt.Fprintln(os.Stderr, "Commands:")
+	_, _ = fmt.Fprintln(os.Stderr, "  serve                Run the registry API server (default)")
+	_, _ = fmt.Fprintln(os.Stderr, "  seed                 Import servers from a seed file, URL or another registry")
+	_, _ = fmt.Fprintln(os.Stderr, "  backup               Write every server version to a seed file")
+	_, _ = fmt.Fprintln(os.Stderr, "  export-static        Render the registry into static JSON files for a read-only mirror")
+	_, _ = fmt.Fprintln(os.Stderr, "  verify-export        Check the signatures, freshness and files of a signed static export")
+	_, _ = fmt.Fprintln(os.Stderr, "  extract-capabilities Run queued server versions in sandboxes to record their tools, resources and prompts")
+	_, _ = fmt.Fprintln(os.Stderr, "  healthcheck          Check that the local registry is responding")
+	_, _ = fmt.Fprintln(os.Stderr, "  migrate              Apply, roll back or list the database migrations")
+	_, _ = fmt.Fprintln(os.Stderr, "  version              Print version information")
+	_, _ = fmt.Fprintln(os.Stderr)
+	_, _ = fmt.Fprintln(os.Stderr, "All commands read their configuration from MCP_REGISTRY_* environment variables (see .env.example).")
+}
 
-		importerService := importer.NewService(registryService)
-		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
-			log.Printf("Failed to import seed data: %v", err)
-		}
+// newFlagSet creates the flag set for a subcommand with a usage line
+func newFlagSet(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage: registry %s\n", usage)
+		flags.PrintDefaults()
 	}
+	return flags
+}
 
-	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
+// connectDatabase connects to PostgreSQL, applying pending migrations unless auto-migration is
+// turned off
+func connectDatabase(cfg *config.Config) (database.Database, error) {
+	// Create a context with timeout for PostgreSQL connection
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	db, err := database.ConnectPostgreSQL(ctx, cfg.DatabaseURL)
 	if err != nil {
-		log.Printf("Failed to initialize metrics: %v", err)
-		return
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
 
-	defer func() {
-		if err := shutdownTelemetry(context.Background()); err != nil {
-			log.Printf("Failed to shutdown telemetry: %v", err)
+	if cfg.DatabaseAutoMigrate {
+		if err := db.Migrate(ctx); err != nil {
+			closeDatabase(db)
+			return nil, err
 		}
-	}()
-
-	// Prepare version information
-	versionInfo := &v0.VersionBody{
-		Version:   Version,
-		GitCommit: GitCommit,
-		BuildTime: BuildTime,
+	} else if pending, err := db.PendingMigrations(ctx, nil); err == nil && len(pending) > 0 {
+		log.Printf("Database has %d pending migrations; run `registry migrate up` to apply them", len(pending))
 	}
 
-	// Initialize HTTP server
-	server := api.NewServer(cfg, registryService, metrics, versionInfo)
-
-	// Start server in a goroutine so it doesn't block signal handling
-	go func() {
-		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Printf("Failed to start server: %v", err)
-			os.Exit(1)
+	if cfg.DatabaseReplicaURLs != "" {
+		var urls []string
+		for _, url := range strings.Split(cfg.DatabaseReplicaURLs, ",") {
+			if url = strings.TrimSpace(url); url != "" {
+				urls = append(urls, url)
+			}
+		}
+		if err := db.AddReadReplicas(ctx, urls, cfg.DatabaseReplicaCheckInterval); err != nil {
+			closeDatabase(db)
+			return nil, err
 		}
-	}()
-
-	// Wait for interrupt signal to gracefully shutdown the server
-	quit := make(chan os.Signal, 1)
-
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Println("Shutting down server...")
-
-	// Create context with timeout for shutdown
-	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer scancel()
-
-	// Gracefully shutdown the server
-	if err := server.Shutdown(sctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
 	}
+	return db, nil
+}
 
-	log.Println("Server exiting")
+// closeDatabase closes the database connection, logging any error
+func closeDatabase(db database.Database) {
+	if err := db.Close(); err != nil {
+		log.Printf("Error closing PostgreSQL connection: %v", err)
+	} else {
+		log.Println("PostgreSQL connection closed successfully")
+	}
 }
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// migrateCommand applies, rolls back or lists the migrations embedded in the binary, so that
+// deployments can migrate the database as a step of their pipeline rather than on startup
+func migrateCommand(cfg *config.Config, args []string) error {
+	action := "up"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	flags := newFlagSet("migrate", "migrate [up|down|status] [-to VERSION] [-steps N] [-dry-run]")
+	to := flags.Int("to", 0, "With up, apply the pending migrations up to this version only")
+	steps := flags.Int("steps", 1, "With down, how many of the latest applied migrations to roll back")
+	dryRun := flags.Bool("dry-run", false, "Print the SQL that would run instead of running it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *to < 0 || *steps < 1 {
+		flags.Usage()
+		return errors.New("-to can't be negative and -steps has to be positive")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	// Migrations run on a single connection, which holds the migration lock while they're applied
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(context.Background()); err != nil {
+			log.Printf("Error closing database connection: %v", err)
+		}
+	}()
+	migrator := database.NewMigrator(conn)
+
+	switch action {
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	case "up":
+		if *dryRun {
+			pending, err := migrator.Pending(ctx, *to)
+			if err != nil {
+				return err
+			}
+			printMigrationPlan("up", pending)
+			return nil
+		}
+		return migrator.MigrateTo(ctx, *to)
+	case "down":
+		if *dryRun {
+			rollbacks, err := migrator.Rollbacks(ctx, *steps)
+			if err != nil {
+				return err
+			}
+			printMigrationPlan("down", rollbacks)
+			return nil
+		}
+		return migrator.Rollback(ctx, *steps)
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown migrate action %q", action)
+	}
+}
+
+// printMigrationStatus lists every migration with when it was applied
+func printMigrationStatus(ctx context.Context, migrator *database.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tDOWN")
+	pending := 0
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.UTC().Format(time.RFC3339)
+		} else {
+			pending++
+		}
+		down := "no"
+		switch {
+		case !status.Embedded:
+			down = "newer build"
+		case status.DownSQL != "":
+			down = "yes"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, applied, down)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	log.Printf("%d migrations, %d pending", len(statuses), pending)
+	return nil
+}
+
+// printMigrationPlan prints the SQL a dry run would have run, in order
+func printMigrationPlan(direction string, migrations []database.Migration) {
+	if len(migrations) == 0 {
+		log.Println("Nothing to migrate")
+		return
+	}
+	verb := "applied"
+	for _, migration := range migrations {
+		sql := migration.SQL
+		if direction == "down" {
+			sql, verb = migration.DownSQL, "rolled back"
+		}
+		fmt.Printf("-- %d %s (%s)\n%s\n", migration.Version, migration.Name, direction, strings.TrimSpace(sql))
+		fmt.Println()
+	}
+	log.Printf("Dry run: %d migrations would be %s", len(migrations), verb)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// seedCommand imports servers into the database and exits, so seeding can run as a
+// one-off job instead of on every server start
+func seedCommand(cfg *config.Config, args []string) error {
+	flags := newFlagSet("seed", "seed [path or URL] (default: $MCP_REGISTRY_SEED_FROM)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	source := cfg.SeedFrom
+	if flags.NArg() > 0 {
+		source = flags.Arg(0)
+	}
+	if source == "" {
+		return errors.New("no seed source: pass a path or URL, or set MCP_REGISTRY_SEED_FROM")
+	}
+
+	db, err := connectDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	return importSeed(service.NewRegistryService(db, cfg), source)
+}
+
+// importSeed imports seed data from a file, URL or registry
+func importSeed(registryService service.RegistryService, source string) error {
+	log.Printf("Importing data from %s...", source)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	importerService := importer.NewService(registryService)
+	return importerService.ImportFromPath(ctx, source)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/rpc"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/watcher"
+	"google.golang.org/grpc"
+)
+
+// serveCommand runs the registry API server until it receives SIGINT or SIGTERM
+func serveCommand(cfg *config.Config, args []string) error {
+	flags := newFlagSet("serve", "serve [-version]")
+	showVersion := flags.Bool("version", false, "Display version information")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Show version information if requested
+	if *showVersion {
+		printVersion()
+		return nil
+	}
+
+	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
+
+	db, err := connectDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	registryService := service.NewRegistryService(db, cfg)
+
+	// Import seed data if seed source is provided
+	if cfg.SeedFrom != "" {
+		if err := importSeed(registryService, cfg.SeedFrom); err != nil {
+			log.Printf("Failed to import seed data: %v", err)
+		}
+	}
+
+	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := shutdownTelemetry(context.Background()); err != nil {
+			log.Printf("Failed to shutdown telemetry: %v", err)
+		}
+	}()
+
+	// Serve the most requested servers and listings from memory
+	registryService = service.NewCachedRegistryService(registryService, cfg, metrics)
+
+	// Watch upstream repositories for new releases if enabled
+	if cfg.UpstreamWatchInterval > 0 {
+		watchCtx, cancelWatch := context.WithCancel(context.Background())
+		defer cancelWatch()
+		go watcher.NewWatcher(registryService, cfg).Run(watchCtx, cfg.UpstreamWatchInterval)
+	}
+
+	// Run background jobs: webhook deliveries, namespace claim checks and export rendering, as
+	// scheduled by this instance or others
+	if cfg.JobWorkers > 0 {
+		jobsCtx, cancelJobs := context.WithCancel(context.Background())
+		defer cancelJobs()
+		go newJobRunner(registryService, cfg, metrics).Run(jobsCtx, cfg.JobWorkers, cfg.JobPollInterval)
+	}
+
+	// Prepare version information
+	versionInfo := &v0.VersionBody{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildTime: BuildTime,
+	}
+
+	// HTTP and gRPC requests share the same rate limits
+	rateLimitStore, err := ratelimit.NewStore(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create rate limit store: %w", err)
+	}
+
+	// Initialize HTTP server
+	server := api.NewServer(cfg, registryService, rateLimitStore, metrics, versionInfo)
+
+	// Start server in a goroutine so it doesn't block signal handling
+	go func() {
+		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to start server: %v", err)
+			os.Exit(1)
+		}
+	}()
+
+	// Serve gRPC alongside HTTP when an address is configured
+	var grpcServer *grpc.Server
+	if cfg.GRPCAddress != "" {
+		grpcServer, err = rpc.NewServer(registryService, cfg, rateLimitStore)
+		if err != nil {
+			return fmt.Errorf("failed to create gRPC server: %w", err)
+		}
+		listener, err := net.Listen("tcp", cfg.GRPCAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", cfg.GRPCAddress, err)
+		}
+		log.Printf("gRPC server listening on %s", cfg.GRPCAddress)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Printf("Failed to serve gRPC: %v", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Wait for interrupt signal to gracefully shutdown the server
+	quit := make(chan os.Signal, 1)
+
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down server...")
+
+	// Create context with timeout for shutdown
+	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer scancel()
+
+	// Gracefully shutdown the server
+	if err := server.Shutdown(sctx); err != nil {
+		log.Printf("Server forced to shutdown: %v", err)
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+
+	log.Println("Server exiting")
+	return nil
+}
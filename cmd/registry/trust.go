@@ -0,0 +1,381 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Signed metadata follows the roles of The Update Framework (TUF): the root metadata lists the
+// keys trusted to sign each role, and the targets metadata lists the length and SHA-256 of every
+// file in the export. Unlike TUF, signatures are over the bytes of the signed object exactly as
+// they appear in the file, rather than over canonical JSON.
+const (
+	rootRole    = "root"
+	targetsRole = "targets"
+)
+
+// signedMetadata is a metadata file: a signed object and the signatures over its bytes
+type signedMetadata struct {
+	Signed     json.RawMessage     `json:"signed"`
+	Signatures []metadataSignature `json:"signatures"`
+}
+
+type metadataSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+type metadataKey struct {
+	KeyType string `json:"keytype"`
+	Scheme  string `json:"scheme"`
+	KeyVal  struct {
+		Public string `json:"public"`
+	} `json:"keyval"`
+}
+
+type metadataRole struct {
+	KeyIDs    []string `json:"keyids"`
+	Threshold int      `json:"threshold"`
+}
+
+// rootMetadata lists the keys trusted to sign each role. A new version must be signed by the
+// root keys of the previous version as well as its own, so clients can follow key rotations.
+type rootMetadata struct {
+	Type    string                  `json:"_type"`
+	Version int                     `json:"version"`
+	Expires time.Time               `json:"expires"`
+	Keys    map[string]metadataKey  `json:"keys"`
+	Roles   map[string]metadataRole `json:"roles"`
+}
+
+// targetsMetadata lists the files of an export. Its version is the time the export was signed,
+// so it only grows, and it expires soon after so that mirrors serving a stale export are caught.
+type targetsMetadata struct {
+	Type    string                `json:"_type"`
+	Version int64                 `json:"version"`
+	Expires time.Time             `json:"expires"`
+	Targets map[string]targetFile `json:"targets"`
+}
+
+type targetFile struct {
+	Length int64             `json:"length"`
+	Hashes map[string]string `json:"hashes"`
+}
+
+// exportSigningOptions configures signing a static export
+type exportSigningOptions struct {
+	rootKey         ed25519.PrivateKey
+	previousRootKey ed25519.PrivateKey
+	targetsKey      ed25519.PrivateKey
+	rootVersion     int
+	rootExpires     time.Duration
+	targetsExpires  time.Duration
+}
+
+// signStaticExport writes signed metadata for the export in dir:
+//   - metadata/root.json and metadata/{version}.root.json, the keys trusted for each role
+//   - metadata/targets.json, the length and SHA-256 of every file under v0
+//
+// Earlier {version}.root.json files already in dir are left in place, so clients that trust an
+// older root can follow the chain of rotations to the current one. Exports should therefore be
+// written over the previous export, or into a copy of its metadata directory.
+func signStaticExport(dir string, opts *exportSigningOptions) error {
+	now := time.Now().UTC()
+
+	rootPublic, ok := opts.rootKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return errors.New("invalid root key")
+	}
+	targetsPublic, ok := opts.targetsKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return errors.New("invalid targets key")
+	}
+	root := rootMetadata{
+		Type:    rootRole,
+		Version: opts.rootVersion,
+		Expires: now.Add(opts.rootExpires),
+		Keys: map[string]metadataKey{
+			metadataKeyID(rootPublic):    newMetadataKey(rootPublic),
+			metadataKeyID(targetsPublic): newMetadataKey(targetsPublic),
+		},
+		Roles: map[string]metadataRole{
+			rootRole:    {KeyIDs: []string{metadataKeyID(rootPublic)}, Threshold: 1},
+			targetsRole: {KeyIDs: []string{metadataKeyID(targetsPublic)}, Threshold: 1},
+		},
+	}
+	signedRoot, err := rootForExport(dir, &root, opts)
+	if err != nil {
+		return err
+	}
+
+	targets := targetsMetadata{
+		Type:    targetsRole,
+		Version: now.Unix(),
+		Expires: now.Add(opts.targetsExpires),
+		Targets: map[string]targetFile{},
+	}
+	err = filepath.WalkDir(filepath.Join(dir, "v0"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		file, err := hashTargetFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		targets.Targets[filepath.ToSlash(rel)] = *file
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash export: %w", err)
+	}
+	signedTargets, err := signMetadata(targets, opts.targetsKey)
+	if err != nil {
+		return err
+	}
+
+	if err := writeStaticJSON(dir, signedRoot, "metadata", "root.json"); err != nil {
+		return err
+	}
+	if err := writeStaticJSON(dir, signedRoot, "metadata", strconv.Itoa(root.Version)+".root.json"); err != nil {
+		return err
+	}
+	return writeStaticJSON(dir, signedTargets, "metadata", "targets.json")
+}
+
+// rootForExport signs the root metadata, or reuses the root of the same version left by an
+// earlier export into dir. Reusing it keeps the signatures of the previous root key a rotation
+// was signed with, so that key is only needed for the first export after rotating.
+func rootForExport(dir string, root *rootMetadata, opts *exportSigningOptions) (*signedMetadata, error) {
+	existing, err := readSignedMetadata(filepath.Join(dir, "metadata", strconv.Itoa(root.Version)+".root.json"))
+	if err == nil {
+		var existingRoot rootMetadata
+		if err := json.Unmarshal(existing.Signed, &existingRoot); err != nil {
+			return nil, fmt.Errorf("failed to parse root version %d: %w", root.Version, err)
+		}
+		if !reflect.DeepEqual(existingRoot.Keys, root.Keys) || !reflect.DeepEqual(existingRoot.Roles, root.Roles) {
+			return nil, fmt.Errorf("root version %d was published with other keys; increase the root version to rotate keys", root.Version)
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	rootKeys := []ed25519.PrivateKey{opts.rootKey}
+	if opts.previousRootKey != nil {
+		rootKeys = append(rootKeys, opts.previousRootKey)
+	}
+	return signMetadata(root, rootKeys...)
+}
+
+// verifyExportCommand checks a static export against a trusted root, the way a client or mirror
+// would: it follows root rotations, then checks that the targets metadata is signed by the
+// targets keys, hasn't expired, and matches every file it lists
+func verifyExportCommand(args []string) error {
+	flags := newFlagSet("verify-export", "verify-export -dir DIR -root FILE")
+	dir := flags.String("dir", "", "Directory of the export to verify (required)")
+	rootFile := flags.String("root", "", "Trusted root.json, e.g. one saved from an earlier export (required)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" || *rootFile == "" {
+		flags.Usage()
+		return errors.New("an export directory and a trusted root are required")
+	}
+
+	now := time.Now()
+	trusted, err := readSignedMetadata(*rootFile)
+	if err != nil {
+		return err
+	}
+	var root rootMetadata
+	if err := json.Unmarshal(trusted.Signed, &root); err != nil {
+		return fmt.Errorf("failed to parse trusted root: %w", err)
+	}
+	if err := verifyMetadataSignatures(trusted, &root, rootRole); err != nil {
+		return fmt.Errorf("trusted root: %w", err)
+	}
+
+	// Follow rotations: each new root must be signed by the current root keys and its own
+	for {
+		next, err := readSignedMetadata(filepath.Join(*dir, "metadata", strconv.Itoa(root.Version+1)+".root.json"))
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		var nextRoot rootMetadata
+		if err := json.Unmarshal(next.Signed, &nextRoot); err != nil {
+			return fmt.Errorf("failed to parse root version %d: %w", root.Version+1, err)
+		}
+		if nextRoot.Type != rootRole || nextRoot.Version != root.Version+1 {
+			return fmt.Errorf("root version %d is not the next root", root.Version+1)
+		}
+		if err := verifyMetadataSignatures(next, &root, rootRole); err != nil {
+			return fmt.Errorf("root version %d is not signed by version %d: %w", nextRoot.Version, root.Version, err)
+		}
+		if err := verifyMetadataSignatures(next, &nextRoot, rootRole); err != nil {
+			return fmt.Errorf("root version %d: %w", nextRoot.Version, err)
+		}
+		root = nextRoot
+	}
+	if now.After(root.Expires) {
+		return fmt.Errorf("root version %d expired at %s", root.Version, root.Expires.Format(time.RFC3339))
+	}
+
+	signedTargets, err := readSignedMetadata(filepath.Join(*dir, "metadata", "targets.json"))
+	if err != nil {
+		return err
+	}
+	var targets targetsMetadata
+	if err := json.Unmarshal(signedTargets.Signed, &targets); err != nil {
+		return fmt.Errorf("failed to parse targets: %w", err)
+	}
+	if err := verifyMetadataSignatures(signedTargets, &root, targetsRole); err != nil {
+		return fmt.Errorf("targets: %w", err)
+	}
+	if now.After(targets.Expires) {
+		return fmt.Errorf("targets expired at %s: the export is stale", targets.Expires.Format(time.RFC3339))
+	}
+
+	for path, expected := range targets.Targets {
+		if !filepath.IsLocal(filepath.FromSlash(path)) {
+			return fmt.Errorf("target %s is outside the export", path)
+		}
+		actual, err := hashTargetFile(filepath.Join(*dir, filepath.FromSlash(path)))
+		if err != nil {
+			return err
+		}
+		if actual.Length != expected.Length || actual.Hashes["sha256"] != expected.Hashes["sha256"] {
+			return fmt.Errorf("target %s doesn't match the targets metadata", path)
+		}
+	}
+
+	log.Printf("Verified %d files against root version %d (targets version %d, expires %s)",
+		len(targets.Targets), root.Version, targets.Version, targets.Expires.Format(time.RFC3339))
+	return nil
+}
+
+// loadSigningKey reads a hex-encoded Ed25519 seed from a file, the format of MCP_REGISTRY_JWT_PRIVATE_KEY
+func loadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	seed, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s must be hex-encoded: %w", path, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signing key %s must be a %d byte Ed25519 seed, got %d bytes", path, ed25519.SeedSize, len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// metadataKeyID identifies a key by the SHA-256 of its public key
+func metadataKeyID(public ed25519.PublicKey) string {
+	sum := sha256.Sum256(public)
+	return hex.EncodeToString(sum[:])
+}
+
+func newMetadataKey(public ed25519.PublicKey) metadataKey {
+	key := metadataKey{KeyType: "ed25519", Scheme: "ed25519"}
+	key.KeyVal.Public = hex.EncodeToString(public)
+	return key
+}
+
+// signMetadata encodes an object and signs its bytes with each key
+func signMetadata(signed any, keys ...ed25519.PrivateKey) (*signedMetadata, error) {
+	data, err := json.Marshal(signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	metadata := &signedMetadata{Signed: data}
+	for _, key := range keys {
+		public, ok := key.Public().(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("invalid signing key")
+		}
+		metadata.Signatures = append(metadata.Signatures, metadataSignature{
+			KeyID: metadataKeyID(public),
+			Sig:   hex.EncodeToString(ed25519.Sign(key, data)),
+		})
+	}
+	return metadata, nil
+}
+
+// verifyMetadataSignatures checks that enough of the keys the root trusts for a role signed the metadata
+func verifyMetadataSignatures(metadata *signedMetadata, root *rootMetadata, role string) error {
+	trusted, ok := root.Roles[role]
+	if !ok {
+		return fmt.Errorf("root has no %s role", role)
+	}
+	var signedBy []string
+	for _, signature := range metadata.Signatures {
+		if !slices.Contains(trusted.KeyIDs, signature.KeyID) || slices.Contains(signedBy, signature.KeyID) {
+			continue
+		}
+		public, err := hex.DecodeString(root.Keys[signature.KeyID].KeyVal.Public)
+		if err != nil || len(public) != ed25519.PublicKeySize {
+			continue
+		}
+		sig, err := hex.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+		if ed25519.Verify(public, metadata.Signed, sig) {
+			signedBy = append(signedBy, signature.KeyID)
+		}
+	}
+	if len(signedBy) < max(1, trusted.Threshold) {
+		return fmt.Errorf("signed by %d of the %d %s keys required", len(signedBy), max(1, trusted.Threshold), role)
+	}
+	return nil
+}
+
+func readSignedMetadata(path string) (*signedMetadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var metadata signedMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &metadata, nil
+}
+
+// hashTargetFile returns the length and SHA-256 of a file
+func hashTargetFile(path string) (*targetFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	length, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return &targetFile{Length: length, Hashes: map[string]string{"sha256": hex.EncodeToString(hash.Sum(nil))}}, nil
+}
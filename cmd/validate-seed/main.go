@@ -0,0 +1,255 @@
+// validate-seed checks seed data against the server.json schema and the rules the registry
+// applies when importing it, without needing a running registry or database.
+//
+// Usage:
+//
+//	validate-seed [-registry-validation] [-json] <seed.json | server.json | directory>...
+//
+// A seed file is a JSON array of server.json documents, or of server responses as the list
+// endpoint returns them, whose official metadata gives their initial status; a directory is
+// searched recursively for *.json files, each holding one server.json document or a seed array.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// seedEntry is one server document and where it came from
+type seedEntry struct {
+	source string
+	raw    json.RawMessage
+	server apiv0.ServerJSON
+}
+
+// problem is a single validation failure in the report
+type problem struct {
+	Source  string `json:"source"`
+	Server  string `json:"server,omitempty"`
+	Message string `json:"message"`
+}
+
+// report summarises a validation run
+type report struct {
+	Checked  int       `json:"checked"`
+	Invalid  int       `json:"invalid"`
+	Problems []problem `json:"problems"`
+}
+
+func main() {
+	log.SetFlags(0) // Remove timestamp from logs
+
+	registryValidation := flag.Bool("registry-validation", false, "Also check package ownership against npm, PyPI, NuGet, OCI and MCPB sources (requires network access)")
+	jsonOutput := flag.Bool("json", false, "Print the report as JSON")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: validate-seed [-registry-validation] [-json] <seed.json | server.json | directory>...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	entries, loadProblems := loadEntries(flag.Args())
+	result := validateEntries(context.Background(), entries, *registryValidation)
+	result.Problems = append(loadProblems, result.Problems...)
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+	} else {
+		printReport(result)
+	}
+
+	if len(result.Problems) > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadEntries reads every server document from the given files and directories
+func loadEntries(paths []string) ([]seedEntry, []problem) {
+	var entries []seedEntry
+	var problems []problem
+
+	for _, path := range paths {
+		files, err := jsonFiles(path)
+		if err != nil {
+			problems = append(problems, problem{Source: path, Message: err.Error()})
+			continue
+		}
+		for _, file := range files {
+			fileEntries, err := readEntries(file)
+			if err != nil {
+				problems = append(problems, problem{Source: file, Message: err.Error()})
+				continue
+			}
+			entries = append(entries, fileEntries...)
+		}
+	}
+	return entries, problems
+}
+
+// jsonFiles returns path itself, or every *.json file below it if it is a directory
+func jsonFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(file, ".json") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readEntries reads a seed array or a single server document from a file
+func readEntries(file string) ([]seedEntry, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []json.RawMessage
+	trimmed := bytes.TrimSpace(data)
+	isSeedArray := len(trimmed) > 0 && trimmed[0] == '['
+	if isSeedArray {
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		raws = []json.RawMessage{data}
+	}
+
+	entries := make([]seedEntry, 0, len(raws))
+	for i, raw := range raws {
+		source := file
+		if isSeedArray {
+			source = fmt.Sprintf("%s[%d]", file, i)
+		}
+		var response struct {
+			Server json.RawMessage    `json:"server"`
+			Meta   apiv0.ResponseMeta `json:"_meta"`
+		}
+		if err := json.Unmarshal(raw, &response); err != nil {
+			return nil, fmt.Errorf("%s: invalid server.json: %w", source, err)
+		}
+		if response.Server != nil {
+			raw = response.Server
+		}
+		entry := seedEntry{source: source, raw: raw}
+		if err := json.Unmarshal(raw, &entry.server); err != nil {
+			return nil, fmt.Errorf("%s: invalid server.json: %w", source, err)
+		}
+		if official := response.Meta.Official; official != nil {
+			switch official.Status {
+			case "", model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+			default:
+				return nil, fmt.Errorf("%s: unknown status %q (expected active, deprecated or deleted)", source, official.Status)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// validateEntries applies the schema, the publish validators the importer runs, and the
+// cross-server rules the registry enforces on insert (unique versions and remote URLs)
+func validateEntries(ctx context.Context, entries []seedEntry, registryValidation bool) report {
+	result := report{Checked: len(entries)}
+	cfg := &config.Config{EnableRegistryValidation: registryValidation}
+
+	versions := make(map[string]string)   // name@version -> first source
+	remoteURLs := make(map[string]string) // remote URL -> server name
+
+	for _, entry := range entries {
+		name := entry.server.Name
+		var entryProblems []string
+
+		schemaErrors, err := validators.ValidateServerJSONSchema(entry.raw)
+		if err != nil {
+			entryProblems = append(entryProblems, err.Error())
+		}
+		for _, detail := range schemaErrors {
+			if detail.Location == "" {
+				entryProblems = append(entryProblems, "schema: "+detail.Message)
+			} else {
+				entryProblems = append(entryProblems, fmt.Sprintf("schema: %s: %s", detail.Location, detail.Message))
+			}
+		}
+
+		if err := validators.ValidatePublishRequest(ctx, entry.server, cfg); err != nil {
+			entryProblems = append(entryProblems, err.Error())
+		}
+
+		key := name + "@" + entry.server.Version
+		if first, ok := versions[key]; ok {
+			entryProblems = append(entryProblems, fmt.Sprintf("duplicate version %s (also in %s)", key, first))
+		} else {
+			versions[key] = entry.source
+		}
+
+		for _, remote := range entry.server.Remotes {
+			if owner, ok := remoteURLs[remote.URL]; ok && owner != name {
+				entryProblems = append(entryProblems, fmt.Sprintf("remote URL %s is already used by server %s", remote.URL, owner))
+			} else if !ok {
+				remoteURLs[remote.URL] = name
+			}
+		}
+
+		if len(entryProblems) > 0 {
+			result.Invalid++
+		}
+		for _, message := range entryProblems {
+			result.Problems = append(result.Problems, problem{Source: entry.source, Server: key, Message: message})
+		}
+	}
+
+	return result
+}
+
+func printReport(result report) {
+	for _, p := range result.Problems {
+		if p.Server != "" {
+			log.Printf("✗ %s (%s): %s", p.Server, p.Source, p.Message)
+		} else {
+			log.Printf("✗ %s: %s", p.Source, p.Message)
+		}
+	}
+	if len(result.Problems) > 0 {
+		log.Println()
+	}
+	log.Printf("Checked %d server(s): %d valid, %d invalid", result.Checked, result.Checked-result.Invalid, result.Invalid)
+}
@@ -164,7 +164,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 							},
 							LivenessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
+									Path: pulumi.String("/healthz"),
 									Port: pulumi.Int(8080),
 								},
 								InitialDelaySeconds: pulumi.Int(30),
@@ -172,7 +172,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 							},
 							ReadinessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
+									Path: pulumi.String("/readyz"),
 									Port: pulumi.Int(8080),
 								},
 								InitialDelaySeconds: pulumi.Int(5),
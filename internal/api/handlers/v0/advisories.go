@@ -0,0 +1,251 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerAdvisoriesInput represents the input for listing the security advisories of a server
+type ServerAdvisoriesInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// AdvisoryBody is the content of a security advisory that publishers write
+type AdvisoryBody struct {
+	Summary          string   `json:"summary" minLength:"1" maxLength:"200" doc:"One-line summary of the vulnerability" example:"Path traversal in file resources"`
+	Details          string   `json:"details,omitempty" maxLength:"10000" doc:"Description of the vulnerability and its impact, in Markdown"`
+	Severity         string   `json:"severity" enum:"low,moderate,high,critical" doc:"Severity of the vulnerability" example:"high"`
+	AffectedVersions []string `json:"affectedVersions" minItems:"1" maxItems:"500" doc:"Published versions of the server the vulnerability affects"`
+	Remediation      string   `json:"remediation,omitempty" maxLength:"1000" doc:"How to fix or mitigate it, e.g. the version to upgrade to" example:"Upgrade to 1.0.2"`
+	Aliases          []string `json:"aliases,omitempty" maxItems:"10" doc:"IDs of the vulnerability in other databases, such as CVE or GHSA IDs"`
+}
+
+// PublishAdvisoryInput represents the input for publishing a security advisory
+type PublishAdvisoryInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          AdvisoryBody
+}
+
+// UpdateAdvisoryInput represents the input for updating a security advisory
+type UpdateAdvisoryInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	ID            string `path:"id" doc:"Advisory ID"`
+	Body          AdvisoryBody
+}
+
+// WithdrawAdvisoryInput represents the input for withdrawing a security advisory
+type WithdrawAdvisoryInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	ID            string `path:"id" doc:"Advisory ID"`
+}
+
+// AdvisoryFeedInput represents the input for following the security advisories of every server
+type AdvisoryFeedInput struct {
+	Cursor       string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit        int    `query:"limit" doc:"Number of advisories per page" default:"100" minimum:"1" maximum:"500"`
+	UpdatedSince string `query:"updated_since" doc:"Only return advisories published or updated since this RFC3339 timestamp" required:"false" example:"2025-08-07T13:15:04.280Z"`
+}
+
+// RegisterAdvisoryEndpoints registers the security advisory endpoints with a custom path prefix
+func RegisterAdvisoryEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-advisories" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/advisories",
+		Summary:     "List server advisories",
+		Description: "Get the security advisories of a server, most recently published first.",
+		Tags:        []string{"advisories"},
+	}, func(ctx context.Context, input *ServerAdvisoriesInput) (*Response[apiv0.SecurityAdvisoryListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		advisories, err := registry.ListServerAdvisories(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/advisories")
+			}
+			return nil, huma.Error500InternalServerError("Failed to list server advisories", err)
+		}
+		return &Response[apiv0.SecurityAdvisoryListResponse]{
+			Body: apiv0.SecurityAdvisoryListResponse{
+				Advisories: advisories,
+				Metadata:   apiv0.Metadata{Count: len(advisories)},
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-server-advisory" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/advisories",
+		Summary:     "Publish server advisory",
+		Description: "Attach a security advisory to the versions of a server it affects, which are then flagged in listings. " +
+			"Publishers of the server and admins can publish advisories.",
+		Tags:     []string{"advisories"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *PublishAdvisoryInput) (*Response[apiv0.SecurityAdvisory], error) {
+		claims, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		advisory, err := registry.PublishServerAdvisory(ctx, advisoryFromBody(serverName, &input.Body, claims))
+		if err != nil {
+			return nil, advisoryError(ctx, registry, pathPrefix, serverName, err)
+		}
+		return &Response[apiv0.SecurityAdvisory]{Body: *advisory}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-server-advisory" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/advisories/{id}",
+		Summary:     "Update server advisory",
+		Description: "Replace the contents of a security advisory, e.g. to add remediation once a fix is released.",
+		Tags:        []string{"advisories"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *UpdateAdvisoryInput) (*Response[apiv0.SecurityAdvisory], error) {
+		claims, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		advisory := advisoryFromBody(serverName, &input.Body, claims)
+		advisory.ID = input.ID
+		updated, err := registry.UpdateServerAdvisory(ctx, advisory)
+		if err != nil {
+			return nil, advisoryError(ctx, registry, pathPrefix, serverName, err)
+		}
+		return &Response[apiv0.SecurityAdvisory]{Body: *updated}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "withdraw-server-advisory" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/advisories/{id}",
+		Summary:       "Withdraw server advisory",
+		Description:   "Delete a security advisory published in error.",
+		Tags:          []string{"advisories"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WithdrawAdvisoryInput) (*struct{}, error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.WithdrawServerAdvisory(ctx, serverName, input.ID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Advisory not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to withdraw server advisory", err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-advisories" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/advisories",
+		Summary:     "Follow security advisories",
+		Description: "Get the security advisories of every server, in the order they were published or last updated, " +
+			"so that clients and downstream registries can follow new and changed advisories.",
+		Tags: []string{"advisories"},
+	}, func(ctx context.Context, input *AdvisoryFeedInput) (*Response[apiv0.SecurityAdvisoryListResponse], error) {
+		var since time.Time
+		if input.UpdatedSince != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339, input.UpdatedSince); err != nil {
+				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+		}
+
+		advisories, nextCursor, err := registry.ListAdvisoryFeed(ctx, since, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to list advisories", err)
+		}
+		return &Response[apiv0.SecurityAdvisoryListResponse]{
+			Body: apiv0.SecurityAdvisoryListResponse{
+				Advisories: advisories,
+				Metadata:   apiv0.Metadata{NextCursor: nextCursor, Count: len(advisories)},
+			},
+		}, nil
+	})
+}
+
+// authorizePublisherOrAdmin validates the token and checks that it may manage the server on its
+// publisher's behalf: admins may for any server, and publishers for the servers they publish
+func authorizePublisherOrAdmin(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, authHeader, encodedName string,
+) (*auth.JWTClaims, string, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	serverName, err := url.PathUnescape(encodedName)
+	if err != nil {
+		return nil, "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+	if hasGlobalPermission(claims, auth.PermissionActionEdit) {
+		return claims, serverName, nil
+	}
+
+	allowed, reason, err := canPublish(ctx, registry, jwtManager, claims, serverName)
+	if err != nil {
+		return nil, "", huma.Error500InternalServerError("Failed to check namespace permissions", err)
+	}
+	if !allowed {
+		return nil, "", huma.Error403Forbidden(reason)
+	}
+	return claims, serverName, nil
+}
+
+func advisoryFromBody(serverName string, body *AdvisoryBody, claims *auth.JWTClaims) *apiv0.SecurityAdvisory {
+	return &apiv0.SecurityAdvisory{
+		ServerName:       serverName,
+		Summary:          body.Summary,
+		Details:          body.Details,
+		Severity:         body.Severity,
+		AffectedVersions: body.AffectedVersions,
+		Remediation:      body.Remediation,
+		Aliases:          body.Aliases,
+		PublishedBy:      principalFromClaims(claims),
+	}
+}
+
+// advisoryError maps service errors for publishing or updating an advisory to HTTP errors
+func advisoryError(ctx context.Context, registry service.RegistryService, pathPrefix, serverName string, err error) error {
+	switch {
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		if _, getErr := registry.GetServerByName(ctx, serverName); getErr == nil {
+			return huma.Error404NotFound("Advisory not found")
+		}
+		return serverNotFound(ctx, registry, pathPrefix, serverName, "/advisories")
+	default:
+		return huma.Error500InternalServerError("Failed to save server advisory", err)
+	}
+}
@@ -0,0 +1,139 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerAdvisories(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, version := range []string{"1.0.0", "1.0.1"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterAdvisoryEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(subject string, permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice := tokenFor("alice", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"})
+	bob := tokenFor("bob", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.bob/*"})
+	admin := tokenFor("admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	advisoryOf := func(version string) string {
+		w := call(http.MethodGet, "/v0/servers/io.github.alice%2Fweather/versions/"+version, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Meta.Official.Advisory
+	}
+	const path = "/v0/servers/io.github.alice%2Fweather/advisories"
+	advisory := map[string]any{
+		"summary":          "Path traversal in file resources",
+		"severity":         "moderate",
+		"affectedVersions": []string{"1.0.0"},
+	}
+
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPost, path, bob, advisory).Code)
+	assert.Equal(t, http.StatusBadRequest, call(http.MethodPost, path, alice, map[string]any{
+		"summary": "Unknown version", "severity": "low", "affectedVersions": []string{"2.0.0"},
+	}).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodPost, "/v0/servers/io.github.alice%2Fmissing/advisories", alice, advisory).Code)
+
+	w := call(http.MethodPost, path, alice, advisory)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var published apiv0.SecurityAdvisory
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &published))
+	assert.Equal(t, "alice", published.PublishedBy.Subject)
+	assert.Equal(t, "moderate", advisoryOf("1.0.0"))
+	assert.Empty(t, advisoryOf("1.0.1"))
+
+	// Admins can publish advisories for any server, and the most severe one is flagged
+	require.Equal(t, http.StatusOK, call(http.MethodPost, path, admin, map[string]any{
+		"summary": "Token leak in logs", "severity": "critical", "affectedVersions": []string{"1.0.0", "1.0.1"},
+	}).Code)
+	assert.Equal(t, "critical", advisoryOf("1.0.0"))
+	assert.Equal(t, "critical", advisoryOf("1.0.1"))
+
+	advisory["remediation"] = "Upgrade to 1.0.1"
+	w = call(http.MethodPut, path+"/"+published.ID, alice, advisory)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, call(http.MethodPut, path+"/00000000-0000-0000-0000-000000000000", alice, advisory).Code)
+
+	w = call(http.MethodGet, path, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list apiv0.SecurityAdvisoryListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Advisories, 2)
+
+	// The feed lists advisories in the order they were last updated, one page at a time
+	w = call(http.MethodGet, "/v0/advisories?limit=1", "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Advisories, 1)
+	assert.Equal(t, "critical", list.Advisories[0].Severity)
+	require.NotEmpty(t, list.Metadata.NextCursor)
+	w = call(http.MethodGet, "/v0/advisories?limit=1&cursor="+list.Metadata.NextCursor, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Advisories, 1)
+	assert.Equal(t, "Upgrade to 1.0.1", list.Advisories[0].Remediation)
+	assert.Equal(t, http.StatusBadRequest, call(http.MethodGet, "/v0/advisories?cursor=bogus", "", nil).Code)
+
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, path+"/"+published.ID, alice, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, path+"/"+published.ID, alice, nil).Code)
+}
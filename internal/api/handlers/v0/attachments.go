@@ -0,0 +1,204 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxAttachmentBytes is the largest SBOM or provenance document that can be attached to a package
+const maxAttachmentBytes = 10 << 20
+
+// PackageAttachmentInput represents the input for getting a document attached to a package
+type PackageAttachmentInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Index      int    `path:"index" minimum:"0" doc:"Index of the package in the version's packages" example:"0"`
+	Kind       string `path:"kind" enum:"sbom,provenance" doc:"Document to get" example:"sbom"`
+	Version    string `query:"version" doc:"Version whose document to get, the latest by default" required:"false" example:"1.0.0"`
+	ConditionalParams
+}
+
+// PackageAttachmentOutput is a document attached to a package, as it was published
+type PackageAttachmentOutput struct {
+	ContentType string `header:"Content-Type"`
+	ETag        string `header:"ETag"`
+	Body        []byte
+}
+
+// ListPackageAttachmentsInput represents the input for listing the documents attached to the packages of a version
+type ListPackageAttachmentsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// AttachPackageDocumentInput represents the input for attaching a document to a package
+type AttachPackageDocumentInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Index         int    `path:"index" minimum:"0" doc:"Index of the OCI package in the version's packages" example:"0"`
+	Kind          string `path:"kind" enum:"sbom,provenance" doc:"Document to attach: an SPDX or CycloneDX JSON SBOM, or an in-toto statement with a SLSA provenance predicate" example:"sbom"`
+	RawBody       []byte
+}
+
+// DeletePackageAttachmentInput represents the input for deleting a document attached to a package
+type DeletePackageAttachmentInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Index         int    `path:"index" minimum:"0" doc:"Index of the OCI package in the version's packages" example:"0"`
+	Kind          string `path:"kind" enum:"sbom,provenance" doc:"Document to delete" example:"sbom"`
+}
+
+// RegisterAttachmentEndpoints registers the package SBOM and provenance endpoints with a custom path prefix
+func RegisterAttachmentEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-package-attachment" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/packages/{index}/{kind}",
+		Summary:     "Get package SBOM or provenance",
+		Description: "Get the SBOM or SLSA provenance attached to an OCI package of a server version, as it was published. " +
+			"Its digest is the ETag.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "The document, as application/spdx+json, application/vnd.cyclonedx+json or application/vnd.in-toto+json",
+				Content:     map[string]*huma.MediaType{"application/json": {}},
+			},
+		},
+	}, func(ctx context.Context, input *PackageAttachmentInput) (*PackageAttachmentOutput, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		document, err := registry.GetPackageAttachment(ctx, serverName, input.Version, input.Index, input.Kind)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				if _, serverErr := registry.GetServerByName(ctx, serverName); errors.Is(serverErr, database.ErrNotFound) {
+					return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/packages/"+strconv.Itoa(input.Index)+"/"+input.Kind)
+				}
+				return nil, huma.Error404NotFound("Server version not found, or the package has no " + input.Kind)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get package "+input.Kind, err)
+		}
+
+		etag := `"` + document.Attachment.Digest + `"`
+		if err := input.notModified(etag, document.Attachment.CreatedAt); err != nil {
+			return nil, err
+		}
+		return &PackageAttachmentOutput{
+			ContentType: document.Attachment.MediaType,
+			ETag:        etag,
+			Body:        document.Content,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-package-attachments" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/attachments",
+		Summary:     "List package SBOMs and provenance",
+		Description: "List the SBOMs and SLSA provenance attached to the OCI packages of a server version, with their digests.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ListPackageAttachmentsInput) (*Response[apiv0.PackageAttachmentListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		attachments, err := registry.ListPackageAttachments(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server version not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to list package attachments", err)
+		}
+		return &Response[apiv0.PackageAttachmentListResponse]{
+			Body: apiv0.PackageAttachmentListResponse{Attachments: attachments},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "attach-package-document" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/packages/{index}/{kind}",
+		Summary:     "Attach package SBOM or provenance",
+		Description: "Attach an SBOM (SPDX or CycloneDX JSON) or SLSA provenance (an in-toto statement, bare or in a DSSE envelope) " +
+			"to an OCI package of a server version, replacing the one attached before. The document is stored as sent.",
+		Tags:         []string{"publish"},
+		Security:     []map[string][]string{{"bearer": {}}},
+		MaxBodyBytes: maxAttachmentBytes,
+	}, func(ctx context.Context, input *AttachPackageDocumentInput) (*Response[apiv0.PackageAttachment], error) {
+		editor, serverName, err := serverEditor(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		attachment, err := registry.AttachPackageDocument(ctx, serverName, version, input.Index, input.Kind, input.RawBody, editor)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server version or package not found")
+			case errors.Is(err, service.ErrInvalidAttachment):
+				return nil, huma.Error422UnprocessableEntity(err.Error())
+			case errors.Is(err, service.ErrNotServerPublisher):
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to attach package "+input.Kind, err)
+		}
+		return &Response[apiv0.PackageAttachment]{Body: *attachment}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-package-attachment" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/versions/{version}/packages/{index}/{kind}",
+		Summary:       "Delete package SBOM or provenance",
+		Description:   "Delete the SBOM or SLSA provenance attached to an OCI package of a server version.",
+		Tags:          []string{"publish"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeletePackageAttachmentInput) (*struct{}, error) {
+		editor, serverName, err := serverEditor(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		if err := registry.DeletePackageAttachment(ctx, serverName, version, input.Index, input.Kind, editor); err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Package has no " + input.Kind)
+			case errors.Is(err, service.ErrNotServerPublisher):
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete package "+input.Kind, err)
+		}
+		return nil, nil
+	})
+}
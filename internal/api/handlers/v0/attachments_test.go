@@ -0,0 +1,132 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAttachments(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAttachmentEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(subject string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + subject + "/*"}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob := tokenFor("alice"), tokenFor("bob")
+
+	call := func(method, path, token string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	publisher := &service.Publisher{Principal: apiv0.Principal{AuthMethod: string(auth.MethodGitHubAT), Subject: "alice"}}
+	_, _, err = registryService.PublishServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@alice/weather", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/alice/weather:1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+		},
+	}, "", nil, publisher)
+	require.NoError(t, err)
+
+	sbom := []byte(`{"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT", "name": "weather"}`)
+	statement := `{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://slsa.dev/provenance/v1", "predicate": {}}`
+	envelope, err := json.Marshal(map[string]any{
+		"payloadType": "application/vnd.in-toto+json",
+		"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		"signatures":  []map[string]string{{"sig": "c2ln"}},
+	})
+	require.NoError(t, err)
+	const versionPath = "/v0/servers/io.github.alice%2Fweather/versions/1.0.0"
+	const sbomPath = "/v0/servers/io.github.alice%2Fweather/packages/1/sbom"
+
+	// Only the publisher attaches documents, only to OCI packages and only in supported formats
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, versionPath+"/packages/1/sbom", bob, sbom, nil).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, versionPath+"/packages/0/sbom", alice, sbom, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodPut, versionPath+"/packages/2/sbom", alice, sbom, nil).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, versionPath+"/packages/1/sbom", alice, []byte(statement), nil).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, versionPath+"/packages/1/provenance", alice, sbom, nil).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, versionPath+"/packages/1/sbom", alice, []byte("not json"), nil).Code)
+
+	w := call(http.MethodPut, versionPath+"/packages/1/sbom", alice, sbom, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var attachment apiv0.PackageAttachment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &attachment))
+	sum := sha256.Sum256(sbom)
+	assert.Equal(t, "sha256:"+hex.EncodeToString(sum[:]), attachment.Digest)
+	assert.Equal(t, "spdx", attachment.Format)
+	assert.Equal(t, "ghcr.io/alice/weather:1.0.0", attachment.Identifier)
+	assert.Equal(t, int64(len(sbom)), attachment.Size)
+
+	w = call(http.MethodPut, versionPath+"/packages/1/provenance", alice, envelope, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// Documents are served as they were attached
+	w = call(http.MethodGet, sbomPath, "", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, sbom, w.Body.Bytes())
+	assert.Equal(t, "application/spdx+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `"`+attachment.Digest+`"`, w.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotModified, call(http.MethodGet, sbomPath, "", nil, map[string]string{"If-None-Match": w.Header().Get("ETag")}).Code)
+
+	w = call(http.MethodGet, "/v0/servers/io.github.alice%2Fweather/packages/1/provenance?version=1.0.0", "", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, envelope, w.Body.Bytes())
+	assert.Equal(t, "application/vnd.in-toto+json", w.Header().Get("Content-Type"))
+
+	w = call(http.MethodGet, versionPath+"/attachments", "", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var list apiv0.PackageAttachmentListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Attachments, 2)
+
+	assert.Equal(t, http.StatusForbidden, call(http.MethodDelete, versionPath+"/packages/1/sbom", bob, nil, nil).Code)
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, versionPath+"/packages/1/sbom", alice, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, versionPath+"/packages/1/sbom", alice, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodGet, sbomPath, "", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodGet, "/v0/servers/io.github.alice%2Fmissing/packages/1/sbom", "", nil, nil).Code)
+}
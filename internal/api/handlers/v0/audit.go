@@ -0,0 +1,236 @@
+package v0
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxAuditedResponseBytes bounds how much of a response is kept to find the server it returns
+const maxAuditedResponseBytes = 1 << 20
+
+// unauditedActions are operations that change the registry but aren't recorded: install reports
+// are anonymous and sent on every install, so they would flood the log without saying who did what
+var unauditedActions = map[string]bool{
+	"report-server-install": true,
+}
+
+// AuditLogInput represents the input for reading the audit log
+type AuditLogInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Actor         string `query:"actor" doc:"Only return events by actors with this subject, such as a GitHub username" required:"false" example:"octocat"`
+	AuthMethod    string `query:"authMethod" doc:"Only return events by actors who authenticated with this method" required:"false" example:"github-at"`
+	ServerName    string `query:"serverName" doc:"Only return events on this server" required:"false" example:"io.github.octocat/weather"`
+	Since         string `query:"since" doc:"Only return events recorded at or after this RFC3339 timestamp" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Until         string `query:"until" doc:"Only return events recorded before this RFC3339 timestamp" required:"false" example:"2025-08-08T00:00:00Z"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit         int    `query:"limit" doc:"Number of events per page" default:"100" minimum:"1" maximum:"500"`
+}
+
+// RegisterAuditEndpoints registers the audit log endpoint with a custom path prefix
+func RegisterAuditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-events" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/audit",
+		Summary:     "List audit events",
+		Description: "List the publishes, edits, status changes and admin actions recorded in the audit log, newest first (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *AuditLogInput) (*Response[apiv0.AuditEventListResponse], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		filter := &database.AuditFilter{}
+		if input.Actor != "" {
+			filter.ActorSubject = &input.Actor
+		}
+		if input.AuthMethod != "" {
+			filter.ActorAuthMethod = &input.AuthMethod
+		}
+		if input.ServerName != "" {
+			filter.ServerName = &input.ServerName
+		}
+		for _, bound := range []struct {
+			name  string
+			value string
+			time  **time.Time
+		}{{"since", input.Since, &filter.Since}, {"until", input.Until, &filter.Until}} {
+			if bound.value == "" {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, bound.value)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid " + bound.name + " format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			*bound.time = &parsed
+		}
+
+		events, nextCursor, err := registry.ListAuditEvents(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to list audit events", err)
+		}
+		return &Response[apiv0.AuditEventListResponse]{
+			Body: apiv0.AuditEventListResponse{
+				Events:   events,
+				Metadata: apiv0.Metadata{NextCursor: nextCursor, Count: len(events)},
+			},
+		}, nil
+	})
+}
+
+// AuditMiddleware records every successful request that changes the registry in the audit log:
+// who made it, from where, and the digests of the server.json of the version it changed before
+// and after. Reads, including reads sent as POST such as batch gets and GraphQL queries, and token
+// exchanges aren't recorded. Failing to record an event is logged rather than failing the request,
+// which has already been answered.
+func AuditMiddleware(cfg *config.Config, registry service.RegistryService) func(ctx huma.Context, next func(huma.Context)) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		op := ctx.Operation()
+		action := auditAction(op)
+		if action == "" {
+			next(ctx)
+			return
+		}
+
+		requestURL := ctx.URL()
+		event := &apiv0.AuditEvent{
+			Action: action,
+			Method: ctx.Method(),
+			Path:   requestURL.EscapedPath(),
+			IP:     ClientIP(ctx.RemoteAddr(), ctx.Header("X-Forwarded-For"), cfg.TrustForwardedFor),
+		}
+		event.ServerName, _ = url.PathUnescape(ctx.Param("serverName"))
+		event.Version, _ = url.PathUnescape(ctx.Param("version"))
+		if event.ServerName != "" && event.Version != "" {
+			event.BeforeDigest = versionDigest(ctx.Context(), registry, event.ServerName, event.Version)
+		}
+
+		response := &limitedBuffer{limit: maxAuditedResponseBytes}
+		next(&auditContext{humaContext: ctx, response: response})
+
+		event.Status = ctx.Status()
+		if event.Status < http.StatusOK || event.Status >= http.StatusMultipleChoices {
+			return
+		}
+
+		if claims, err := bearerClaims(ctx.Context(), jwtManager, ctx.Header("Authorization")); err == nil {
+			actor := principalFromClaims(claims)
+			event.Actor = &actor
+		}
+
+		// The version the response returns, such as the one published, or else the one the path names
+		var returned struct {
+			Server *apiv0.ServerJSON `json:"server"`
+		}
+		if json.Unmarshal(response.Bytes(), &returned) == nil && returned.Server != nil && returned.Server.Name != "" {
+			if event.ServerName == "" || event.ServerName == returned.Server.Name {
+				event.ServerName, event.Version = returned.Server.Name, returned.Server.Version
+			}
+			event.AfterDigest, _ = ServerDocumentDigest(returned.Server)
+		} else if event.ServerName != "" && event.Version != "" {
+			event.AfterDigest = versionDigest(ctx.Context(), registry, event.ServerName, event.Version)
+		}
+
+		if err := registry.RecordAuditEvent(context.WithoutCancel(ctx.Context()), event); err != nil {
+			log.Printf("Failed to record audit event %s %s: %v", event.Method, event.Path, err)
+		}
+	}
+}
+
+// auditAction names what an operation does for the audit log, its operation ID without the API
+// version, or returns an empty string for operations that aren't audited
+func auditAction(op *huma.Operation) string {
+	if op == nil || op.Method == http.MethodGet || op.Method == http.MethodHead || slices.Contains(op.Tags, "auth") {
+		return ""
+	}
+	action := op.OperationID
+	if i := strings.LastIndex(action, "-v0"); i > 0 {
+		action = action[:i]
+	}
+	if strings.HasPrefix(action, "get-") || strings.HasPrefix(action, "list-") || strings.HasPrefix(action, "query-") || unauditedActions[action] {
+		return ""
+	}
+	return action
+}
+
+// versionDigest returns the digest of a version's server.json, or an empty string if it doesn't exist
+func versionDigest(ctx context.Context, registry service.RegistryService, serverName, version string) string {
+	server, err := registry.GetServerByNameAndVersion(ctx, serverName, version)
+	if err != nil {
+		return ""
+	}
+	digest, _ := ServerDocumentDigest(&server.Server)
+	return digest
+}
+
+// bearerClaims validates the token of an Authorization header
+func bearerClaims(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, errors.New("no bearer token")
+	}
+	return jwtManager.ValidateToken(ctx, authHeader[len(bearerPrefix):])
+}
+
+// ClientIP returns the IP address of a client: the last X-Forwarded-For entry when the registry
+// is behind a proxy that sets it, and the address of the connection otherwise
+func ClientIP(remoteAddr, forwardedFor string, trustForwardedFor bool) string {
+	if trustForwardedFor && forwardedFor != "" {
+		// Earlier entries are set by the client, so only the one the proxy added can be trusted
+		addresses := strings.Split(forwardedFor, ",")
+		return strings.TrimSpace(addresses[len(addresses)-1])
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// auditContext is a huma context that keeps the start of the response it writes
+type auditContext struct {
+	humaContext
+	response *limitedBuffer
+}
+
+func (c *auditContext) BodyWriter() io.Writer {
+	return io.MultiWriter(c.humaContext.BodyWriter(), c.response)
+}
+
+// limitedBuffer keeps up to a limit of what is written to it, discarding the rest
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,130 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), TrustForwardedFor: true}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(v0.AuditMiddleware(testConfig, registryService))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+	v0.RegisterEditEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterAuditEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterStatsEndpoints(api, "/v0", registryService)
+
+	token := func(method auth.Method, subject string, permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{AuthMethod: method, AuthMethodSubject: subject, Permissions: permissions})
+		require.NoError(t, err)
+		return token
+	}
+	aliceToken := token(auth.MethodGitHubAT, "alice", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"})
+	adminToken := token(auth.MethodOIDC, "admin@example.com", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	request := func(method, path, bearer string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		req.Header.Set("X-Forwarded-For", "198.51.100.7, 192.0.2.1")
+		req.Header.Set("If-Match", "*")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listEvents := func(query string) apiv0.AuditEventListResponse {
+		w := request(http.MethodGet, "/v0/admin/audit"+query, adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response apiv0.AuditEventListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	server := apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: "io.github.alice/weather", Description: "Weather server", Version: "1.0.0"}
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "/v0/publish", aliceToken, server).Code)
+	other := server
+	other.Name = "io.github.bob/weather"
+	require.Equal(t, http.StatusForbidden, request(http.MethodPost, "/v0/publish", aliceToken, other).Code)
+	edited := server
+	edited.Description = "Edited weather server"
+	w := request(http.MethodPut, "/v0/servers/io.github.alice%2Fweather/versions/1.0.0", adminToken, edited)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, http.StatusNoContent, request(http.MethodPost, "/v0/servers/io.github.alice%2Fweather/installs", "", nil).Code)
+
+	events := listEvents("").Events
+	require.Len(t, events, 2, "failed requests, reads and install reports aren't recorded")
+
+	edit, publish := events[0], events[1]
+	assert.Equal(t, "publish-server", publish.Action)
+	assert.Equal(t, http.MethodPost, publish.Method)
+	assert.Equal(t, http.StatusOK, publish.Status)
+	assert.Equal(t, &apiv0.Principal{AuthMethod: "github-at", Subject: "alice"}, publish.Actor)
+	assert.Equal(t, "192.0.2.1", publish.IP, "only the entry the proxy added is trusted")
+	assert.Equal(t, "io.github.alice/weather", publish.ServerName)
+	assert.Equal(t, "1.0.0", publish.Version)
+	assert.Empty(t, publish.BeforeDigest)
+	assert.Len(t, publish.AfterDigest, 64)
+
+	assert.Equal(t, "edit-server", edit.Action)
+	assert.Equal(t, "admin@example.com", edit.Actor.Subject)
+	assert.Equal(t, "io.github.alice/weather", edit.ServerName)
+	assert.Equal(t, publish.AfterDigest, edit.BeforeDigest)
+	assert.NotEqual(t, edit.BeforeDigest, edit.AfterDigest)
+
+	t.Run("filters by actor, server and time", func(t *testing.T) {
+		assert.Len(t, listEvents("?actor=alice").Events, 1)
+		assert.Len(t, listEvents("?authMethod=oidc").Events, 1)
+		assert.Len(t, listEvents("?serverName=io.github.alice%2Fweather").Events, 2)
+		assert.Empty(t, listEvents("?serverName=io.github.bob%2Fweather").Events)
+		assert.Empty(t, listEvents("?since="+time.Now().Add(time.Hour).Format(time.RFC3339)).Events)
+		assert.Len(t, listEvents("?until="+time.Now().Add(time.Hour).Format(time.RFC3339)).Events, 2)
+		assert.Equal(t, http.StatusBadRequest, request(http.MethodGet, "/v0/admin/audit?since=yesterday", adminToken, nil).Code)
+	})
+
+	t.Run("pages newest first", func(t *testing.T) {
+		page := listEvents("?limit=1")
+		require.Len(t, page.Events, 1)
+		assert.Equal(t, edit.ID, page.Events[0].ID)
+		require.NotEmpty(t, page.Metadata.NextCursor)
+
+		page = listEvents("?limit=1&cursor=" + page.Metadata.NextCursor)
+		require.Len(t, page.Events, 1)
+		assert.Equal(t, publish.ID, page.Events[0].ID)
+		assert.Equal(t, http.StatusBadRequest, request(http.MethodGet, "/v0/admin/audit?cursor=abc", adminToken, nil).Code)
+	})
+
+	t.Run("is admin only", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/v0/admin/audit", aliceToken, nil).Code)
+	})
+}
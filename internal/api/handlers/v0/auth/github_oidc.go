@@ -29,6 +29,7 @@ type GitHubOIDCTokenExchangeInput struct {
 type GitHubOIDCClaims struct {
 	jwt.RegisteredClaims
 	RepositoryOwner string `json:"repository_owner"` // e.g., "octo-org"
+	Repository      string `json:"repository"`       // e.g., "octo-org/octo-repo"
 }
 
 // JWKS represents a JSON Web Key Set
@@ -131,6 +132,10 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 	if claims.RepositoryOwner == "" {
 		return nil, fmt.Errorf("repository owner claim is required")
 	}
+	owner, _, found := strings.Cut(claims.Repository, "/")
+	if !found || !strings.EqualFold(owner, claims.RepositoryOwner) {
+		return nil, fmt.Errorf("invalid repository: expected a repository of %s, got %q", claims.RepositoryOwner, claims.Repository)
+	}
 
 	return claims, nil
 }
@@ -257,6 +262,9 @@ func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
 	}
+	if !h.repositoryAllowed(claims.Repository) {
+		return nil, fmt.Errorf("repository %s is not allowed to exchange GitHub OIDC tokens", claims.Repository)
+	}
 
 	// Extract repository information and build permissions
 	permissions := h.buildPermissions(claims)
@@ -295,3 +303,20 @@ func (h *GitHubOIDCHandler) buildPermissions(claims *GitHubOIDCClaims) []auth.Pe
 
 	return permissions
 }
+
+// repositoryAllowed reports whether the configured allowlist, if any, lets a repository's
+// workflows exchange OIDC tokens
+func (h *GitHubOIDCHandler) repositoryAllowed(repository string) bool {
+	if h.config.GitHubOIDCAllowedRepositories == "" {
+		return true
+	}
+
+	owner, _, _ := strings.Cut(repository, "/")
+	for _, pattern := range strings.Split(h.config.GitHubOIDCAllowedRepositories, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if strings.EqualFold(pattern, repository) || strings.EqualFold(pattern, owner+"/*") {
+			return true
+		}
+	}
+	return false
+}
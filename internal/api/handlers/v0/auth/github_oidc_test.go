@@ -2,7 +2,14 @@ package auth_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -214,3 +221,110 @@ func TestBuildPermissionsFromOIDC(t *testing.T) {
 		})
 	}
 }
+
+func TestGitHubOIDCValidator_ValidateToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(auth.JWKS{Keys: []auth.JWK{{
+			KTY: "RSA",
+			KID: "test-key",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(jwks.Close)
+
+	const issuer = "https://token.actions.githubusercontent.com"
+	validator := auth.NewMockOIDCValidator(jwks.URL, issuer)
+	sign := func(claims auth.GitHubOIDCClaims) string {
+		claims.Issuer = issuer
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(5 * time.Minute))
+		if claims.Audience == nil {
+			claims.Audience = jwt.ClaimStrings{"mcp-registry"}
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+		return signed
+	}
+
+	tests := []struct {
+		name        string
+		claims      auth.GitHubOIDCClaims
+		expectError string
+	}{
+		{
+			name:   "repository of the owner",
+			claims: auth.GitHubOIDCClaims{RepositoryOwner: "octo-org", Repository: "octo-org/octo-repo"},
+		},
+		{
+			name:        "repository of another owner",
+			claims:      auth.GitHubOIDCClaims{RepositoryOwner: "octo-org", Repository: "other-org/octo-repo"},
+			expectError: "invalid repository",
+		},
+		{
+			name:        "missing repository",
+			claims:      auth.GitHubOIDCClaims{RepositoryOwner: "octo-org"},
+			expectError: "invalid repository",
+		},
+		{
+			name: "another audience",
+			claims: auth.GitHubOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Audience: jwt.ClaimStrings{"sigstore"}},
+				RepositoryOwner:  "octo-org",
+				Repository:       "octo-org/octo-repo",
+			},
+			expectError: "invalid audience",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := validator.ValidateToken(context.Background(), sign(tt.claims), "mcp-registry")
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "octo-org/octo-repo", claims.Repository)
+		})
+	}
+}
+
+func TestGitHubOIDCHandler_AllowedRepositories(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:                 "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		GitHubOIDCAllowedRepositories: "octo-org/octo-repo, acme/*",
+	}
+	handler := auth.NewGitHubOIDCHandler(cfg)
+
+	for repository, allowed := range map[string]bool{
+		"octo-org/octo-repo":  true,
+		"Octo-Org/Octo-Repo":  true,
+		"octo-org/other-repo": false,
+		"acme/anything":       true,
+		"acmecorp/anything":   false,
+	} {
+		t.Run(repository, func(t *testing.T) {
+			owner, _, _ := strings.Cut(repository, "/")
+			handler.SetValidator(&MockOIDCValidator{
+				validateFunc: func(_ context.Context, _ string, _ string) (*auth.GitHubOIDCClaims, error) {
+					return &auth.GitHubOIDCClaims{
+						RegisteredClaims: jwt.RegisteredClaims{Subject: "repo:" + repository + ":ref:refs/heads/main"},
+						RepositoryOwner:  owner,
+						Repository:       repository,
+					}, nil
+				},
+			})
+
+			_, err := handler.ExchangeToken(context.Background(), "test-oidc-token")
+			if allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "not allowed")
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Badge types other than the default version badge
+const (
+	badgeTypeStatus = "status"
+	badgeTypePulls  = "pulls"
+)
+
+// Badge colors, named as shields.io names them
+const (
+	badgeColorBlue      = "blue"
+	badgeColorGreen     = "brightgreen"
+	badgeColorOrange    = "orange"
+	badgeColorRed       = "red"
+	badgeColorLightGrey = "lightgrey"
+)
+
+// badgeColorHex maps badge colors to the hex values used when rendering SVG
+var badgeColorHex = map[string]string{
+	badgeColorBlue:      "#007ec6",
+	badgeColorGreen:     "#4c1",
+	badgeColorOrange:    "#fe7d37",
+	badgeColorRed:       "#e05d44",
+	badgeColorLightGrey: "#9f9f9f",
+}
+
+// badgeCacheControl keeps badges fresh enough for READMEs without hitting the registry on every view
+const badgeCacheControl = "public, max-age=300"
+
+// BadgeInput represents the input for rendering a server badge
+type BadgeInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Type       string `query:"type" enum:"version,status,pulls" default:"version" doc:"What the badge shows: the latest version, the server status, or the publisher-reported pull count"`
+	Label      string `query:"label" maxLength:"50" required:"false" doc:"Text for the left side of the badge (default: 'mcp registry')"`
+}
+
+// BadgeJSON is the shields.io endpoint badge format (https://shields.io/badges/endpoint-badge)
+type BadgeJSON struct {
+	SchemaVersion int    `json:"schemaVersion" doc:"Always 1" example:"1"`
+	Label         string `json:"label" doc:"Left side text" example:"mcp registry"`
+	Message       string `json:"message" doc:"Right side text" example:"v1.2.3"`
+	Color         string `json:"color" doc:"Right side color" example:"blue"`
+}
+
+// BadgeSVGOutput is a rendered SVG badge
+type BadgeSVGOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// BadgeJSONOutput is a badge in shields.io endpoint format. The body is encoded by hand
+// because shields.io rejects the $schema property huma adds to structured responses.
+type BadgeJSONOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// RegisterBadgeEndpoints registers the README badge endpoints with a custom path prefix
+func RegisterBadgeEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-badge-svg" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/badge.svg",
+		Summary:     "Get server badge",
+		Description: "Render an SVG badge showing the latest version, status or pull count of a server, for embedding in READMEs",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "SVG badge",
+				Content:     map[string]*huma.MediaType{"image/svg+xml": {}},
+			},
+		},
+	}, func(ctx context.Context, input *BadgeInput) (*BadgeSVGOutput, error) {
+		badge, err := getServerBadge(ctx, registry, input)
+		if err != nil {
+			return nil, err
+		}
+		return &BadgeSVGOutput{
+			ContentType:  "image/svg+xml;charset=utf-8",
+			CacheControl: badgeCacheControl,
+			Body:         renderBadgeSVG(badge),
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-badge-json" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/badge.json",
+		Summary:     "Get server badge (shields.io format)",
+		Description: "Get a server badge in the shields.io endpoint format, for use with https://img.shields.io/endpoint",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Badge in shields.io endpoint format",
+				Content: map[string]*huma.MediaType{"application/json": {
+					Schema: huma.SchemaFromType(api.OpenAPI().Components.Schemas, reflect.TypeOf(BadgeJSON{})),
+				}},
+			},
+		},
+	}, func(ctx context.Context, input *BadgeInput) (*BadgeJSONOutput, error) {
+		badge, err := getServerBadge(ctx, registry, input)
+		if err != nil {
+			return nil, err
+		}
+		body, err := json.Marshal(badge)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode badge", err)
+		}
+		return &BadgeJSONOutput{ContentType: "application/json", CacheControl: badgeCacheControl, Body: body}, nil
+	})
+}
+
+// getServerBadge looks up the latest version of a server and describes it as a badge
+func getServerBadge(ctx context.Context, registry service.RegistryService, input *BadgeInput) (*BadgeJSON, error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	server, err := registry.GetServerByName(ctx, serverName)
+	if err != nil {
+		if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server details", err)
+	}
+
+	badge := serverBadge(server, input.Type)
+	badge.Label = "mcp registry"
+	if input.Label != "" {
+		badge.Label = input.Label
+	}
+	return badge, nil
+}
+
+// serverBadge builds the message and color of a badge for a server
+func serverBadge(server *apiv0.ServerResponse, badgeType string) *BadgeJSON {
+	status := model.StatusActive
+	if server.Meta.Official != nil {
+		status = server.Meta.Official.Status
+	}
+
+	badge := &BadgeJSON{SchemaVersion: 1}
+	switch badgeType {
+	case badgeTypeStatus:
+		badge.Message = string(status)
+		badge.Color = statusBadgeColor(status, badgeColorGreen)
+	case badgeTypePulls:
+		// The registry doesn't count installs, so this is the publisher-reported figure
+		pulls, ok := publisherProvidedNumber(&server.Server, "pulls")
+		if !ok {
+			badge.Message = "unknown"
+			badge.Color = badgeColorLightGrey
+			break
+		}
+		badge.Message = formatBadgeCount(pulls)
+		badge.Color = badgeColorBlue
+	default:
+		badge.Message = "v" + strings.TrimPrefix(server.Server.Version, "v")
+		badge.Color = statusBadgeColor(status, badgeColorBlue)
+	}
+	return badge
+}
+
+func statusBadgeColor(status model.Status, activeColor string) string {
+	switch status {
+	case model.StatusDeprecated:
+		return badgeColorOrange
+	case model.StatusDeleted:
+		return badgeColorRed
+	default:
+		return activeColor
+	}
+}
+
+func publisherProvidedNumber(server *apiv0.ServerJSON, key string) (float64, bool) {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return 0, false
+	}
+	value, ok := server.Meta.PublisherProvided[key].(float64)
+	return value, ok
+}
+
+// formatBadgeCount abbreviates large counts the way shields.io does, e.g. 2179 -> 2.2k
+func formatBadgeCount(count float64) string {
+	for _, unit := range []struct {
+		size   float64
+		suffix string
+	}{{1e9, "G"}, {1e6, "M"}, {1e3, "k"}} {
+		if count >= unit.size {
+			return strconv.FormatFloat(count/unit.size, 'f', 1, 64) + unit.suffix
+		}
+	}
+	return strconv.FormatFloat(count, 'f', -1, 64)
+}
+
+// badgeTextWidth estimates the rendered width of badge text in 11px Verdana
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// renderBadgeSVG draws a flat, shields.io-style badge
+func renderBadgeSVG(badge *BadgeJSON) []byte {
+	labelWidth := badgeTextWidth(badge.Label)
+	messageWidth := badgeTextWidth(badge.Message)
+	width := labelWidth + messageWidth
+	color, ok := badgeColorHex[badge.Color]
+	if !ok {
+		color = badgeColorHex[badgeColorLightGrey]
+	}
+	label := html.EscapeString(badge.Label)
+	message := html.EscapeString(badge.Message)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, width, label, message)
+	fmt.Fprintf(&b, `<title>%s: %s</title>`, label, message)
+	b.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&b, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width)
+	b.WriteString(`<g clip-path="url(#r)">`)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="#555"/>`, labelWidth)
+	fmt.Fprintf(&b, `<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, color)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="url(#s)"/>`, width)
+	b.WriteString(`</g>`)
+	b.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`,
+		labelWidth/2, label, labelWidth/2, label)
+	fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`,
+		labelWidth+messageWidth/2, message, labelWidth+messageWidth/2, message)
+	b.WriteString(`</g></svg>`)
+	return []byte(b.String())
+}
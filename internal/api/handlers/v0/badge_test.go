@@ -0,0 +1,111 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgeEndpoints(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/badge-server",
+		Description: "Badge test server",
+		Version:     "1.2.3",
+		Meta: &apiv0.ServerMeta{
+			PublisherProvided: map[string]interface{}{"pulls": 2179},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterBadgeEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedStatus  int
+		expectedMessage string
+		expectedColor   string
+	}{
+		{
+			name:            "version badge",
+			path:            "/v0/servers/com.example%2Fbadge-server/badge.json",
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "v1.2.3",
+			expectedColor:   "blue",
+		},
+		{
+			name:            "status badge",
+			path:            "/v0/servers/com.example%2Fbadge-server/badge.json?type=status",
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "active",
+			expectedColor:   "brightgreen",
+		},
+		{
+			name:            "pulls badge",
+			path:            "/v0/servers/com.example%2Fbadge-server/badge.json?type=pulls",
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "2.2k",
+			expectedColor:   "blue",
+		},
+		{
+			name:           "unknown badge type",
+			path:           "/v0/servers/com.example%2Fbadge-server/badge.json?type=stars",
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "unknown server",
+			path:           "/v0/servers/com.example%2Fmissing/badge.json",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var badge v0.BadgeJSON
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&badge))
+			assert.Equal(t, 1, badge.SchemaVersion)
+			assert.Equal(t, "mcp registry", badge.Label)
+			assert.Equal(t, tt.expectedMessage, badge.Message)
+			assert.Equal(t, tt.expectedColor, badge.Color)
+		})
+	}
+
+	t.Run("svg badge", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/com.example%2Fbadge-server/badge.svg?label=<mcp>", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "image/svg+xml")
+		assert.NotEmpty(t, w.Header().Get("Cache-Control"))
+		assert.Contains(t, w.Body.String(), "<title>&lt;mcp&gt;: v1.2.3</title>")
+	})
+}
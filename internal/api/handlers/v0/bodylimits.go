@@ -0,0 +1,170 @@
+package v0
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// serverJSONLimits bounds the server.json bodies of publishes and edits, so that a hostile body
+// is rejected while it's being read rather than after it's buffered and decoded
+type serverJSONLimits struct {
+	maxBytes     int64
+	maxMetaBytes int64
+	maxDepth     int
+}
+
+// newServerJSONLimits returns the deployment's limits, or the defaults of those it doesn't set
+func newServerJSONLimits(cfg *config.Config) serverJSONLimits {
+	limits := serverJSONLimits{
+		maxBytes:     cfg.PublishMaxBodyBytes,
+		maxMetaBytes: cfg.PublishMaxMetaBytes,
+		maxDepth:     cfg.PublishMaxJSONDepth,
+	}
+	if limits.maxBytes <= 0 {
+		limits.maxBytes = 1 << 20
+	}
+	if limits.maxMetaBytes <= 0 {
+		limits.maxMetaBytes = 64 << 10
+	}
+	if limits.maxDepth <= 0 {
+		limits.maxDepth = 32
+	}
+	return limits
+}
+
+// operationMaxBodyBytes is the MaxBodyBytes of the operation. Huma rejects bodies as large as its
+// limit, so it's one more than the largest body the middleware accepts.
+func (l serverJSONLimits) operationMaxBodyBytes() int64 {
+	return l.maxBytes + 1
+}
+
+// bodyLimitError is a body rejected by the limits
+type bodyLimitError struct {
+	status int
+	detail *huma.ErrorDetail
+}
+
+// middleware checks the body against the limits as it streams in, passing what it read on to the
+// operation. Bodies that aren't valid JSON are passed on too, for the operation to reject.
+func (l serverJSONLimits) middleware(api huma.API) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if length, err := strconv.ParseInt(ctx.Header("Content-Length"), 10, 64); err == nil && length > l.maxBytes {
+			_ = huma.WriteErr(api, ctx, http.StatusRequestEntityTooLarge, l.tooLargeMessage())
+			return
+		}
+
+		body := ctx.BodyReader()
+		var read bytes.Buffer
+		limitErr := l.check(io.TeeReader(io.LimitReader(body, l.maxBytes+1), &read))
+		if int64(read.Len()) > l.maxBytes {
+			_ = huma.WriteErr(api, ctx, http.StatusRequestEntityTooLarge, l.tooLargeMessage())
+			return
+		}
+		if limitErr != nil {
+			_ = huma.WriteErr(api, ctx, limitErr.status, limitErr.detail.Message, limitErr.detail)
+			return
+		}
+
+		next(&bodyContext{humaContext: ctx, body: io.MultiReader(&read, body)})
+	}
+}
+
+// CheckServerJSONLimits checks a server.json body that was read in full against the deployment's
+// limits, for APIs besides REST that take server.json bodies
+func CheckServerJSONLimits(cfg *config.Config, body []byte) error {
+	limits := newServerJSONLimits(cfg)
+	if int64(len(body)) > limits.maxBytes {
+		return errors.New(limits.tooLargeMessage())
+	}
+	if limitErr := limits.check(bytes.NewReader(body)); limitErr != nil {
+		return errors.New(limitErr.detail.Message)
+	}
+	return nil
+}
+
+func (l serverJSONLimits) tooLargeMessage() string {
+	return fmt.Sprintf("Request body is larger than the limit of %d bytes", l.maxBytes)
+}
+
+// check streams the tokens of a JSON value, tracking how deeply it nests and how large its
+// top-level _meta is
+func (l serverJSONLimits) check(r io.Reader) *bodyLimitError {
+	// Each open object or array, and whether the next token in an object is a key
+	type frame struct {
+		object    bool
+		expectKey bool
+	}
+	var stack []frame
+	decoder := json.NewDecoder(r)
+	metaStart := int64(-1)
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// The end of the body, or invalid JSON the operation rejects
+			return nil
+		}
+		if metaStart >= 0 && decoder.InputOffset()-metaStart > l.maxMetaBytes {
+			return &bodyLimitError{status: http.StatusRequestEntityTooLarge, detail: &huma.ErrorDetail{
+				Message:  fmt.Sprintf("_meta is larger than the limit of %d bytes", l.maxMetaBytes),
+				Location: "body._meta",
+			}}
+		}
+
+		valueDone := false
+		switch {
+		case token == json.Delim('{') || token == json.Delim('['):
+			stack = append(stack, frame{object: token == json.Delim('{'), expectKey: token == json.Delim('{')})
+			if len(stack) > l.maxDepth {
+				return &bodyLimitError{status: http.StatusBadRequest, detail: &huma.ErrorDetail{
+					Message:  fmt.Sprintf("Request body nests objects and arrays deeper than the limit of %d", l.maxDepth),
+					Location: "body",
+				}}
+			}
+		case token == json.Delim('}') || token == json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			valueDone = true
+		case len(stack) > 0 && stack[len(stack)-1].expectKey:
+			stack[len(stack)-1].expectKey = false
+			if len(stack) == 1 && token == "_meta" {
+				metaStart = decoder.InputOffset()
+			}
+		default:
+			valueDone = true
+		}
+
+		if valueDone {
+			if len(stack) == 1 {
+				metaStart = -1
+			}
+			if len(stack) > 0 && stack[len(stack)-1].object {
+				stack[len(stack)-1].expectKey = true
+			}
+			if len(stack) == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// humaContext names the embedded context of bodyContext, since a field named Context would hide
+// the Context method
+type humaContext = huma.Context
+
+// bodyContext is a huma context whose body is read from another reader
+type bodyContext struct {
+	humaContext
+	body io.Reader
+}
+
+func (c *bodyContext) BodyReader() io.Reader {
+	return c.body
+}
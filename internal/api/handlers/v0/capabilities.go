@@ -0,0 +1,60 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ExtractCapabilitiesInput represents the input for requesting the extraction of a server
+// version's capabilities
+type ExtractCapabilitiesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// RegisterCapabilityEndpoints registers the capability extraction endpoints with a custom path prefix
+func RegisterCapabilityEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "extract-server-capabilities" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/capabilities",
+		Summary:     "Extract server capabilities",
+		Description: "Queue the version to be run in an isolated sandbox, where the registry lists its tools, resources and prompts " +
+			"and records them as capabilities in its official metadata. Publishers of the server and admins can request extractions.",
+		Tags:          []string{"servers"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *ExtractCapabilitiesInput) (*Response[apiv0.ServerResponse], error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		server, err := registry.RequestCapabilityExtraction(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server version not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to request capability extraction", err)
+		}
+		return &Response[apiv0.ServerResponse]{Body: *server}, nil
+	})
+}
@@ -0,0 +1,113 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilityExtraction(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Test server",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeOCI, Identifier: "docker.io/example/weather:1.0.0", Transport: model.Transport{Type: "stdio"}},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterCapabilityEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(pattern string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	extract := func(token, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/servers/"+path+"/capabilities", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	getCapabilities := func() *apiv0.Capabilities {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/com.example%2Fweather/versions/1.0.0", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		return server.Meta.Official.Capabilities
+	}
+
+	publisher := tokenFor("com.example/*")
+	assert.Nil(t, getCapabilities())
+	assert.Equal(t, http.StatusForbidden, extract(tokenFor("org.other/*"), "com.example%2Fweather/versions/1.0.0").Code)
+	assert.Equal(t, http.StatusNotFound, extract(publisher, "com.example%2Fweather/versions/2.0.0").Code)
+
+	w := extract(publisher, "com.example%2Fweather/versions/1.0.0")
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	assert.Equal(t, service.CapabilitiesPending, getCapabilities().Status)
+
+	// A sandbox worker claims the extraction and records what the server listed
+	server, err := registryService.ClaimCapabilityExtraction(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "com.example/weather", server.Server.Name)
+	assert.Equal(t, service.CapabilitiesRunning, getCapabilities().Status)
+	_, err = registryService.ClaimCapabilityExtraction(ctx)
+	require.ErrorIs(t, err, database.ErrNotFound, "running extractions aren't handed out twice")
+
+	require.NoError(t, registryService.RecordCapabilities(ctx, "com.example/weather", "1.0.0", &apiv0.Capabilities{
+		Status: service.CapabilitiesSucceeded,
+		Tools:  []apiv0.CapabilityTool{{Name: "get_forecast", Description: "Get the forecast"}},
+	}))
+	capabilities := getCapabilities()
+	assert.Equal(t, service.CapabilitiesSucceeded, capabilities.Status)
+	assert.NotNil(t, capabilities.ExtractedAt)
+	assert.Equal(t, []apiv0.CapabilityTool{{Name: "get_forecast", Description: "Get the forecast"}}, capabilities.Tools)
+
+	// A failed extraction keeps the inventory of the last successful one
+	require.Equal(t, http.StatusAccepted, extract(publisher, "com.example%2Fweather/versions/1.0.0").Code)
+	_, err = registryService.ClaimCapabilityExtraction(ctx)
+	require.NoError(t, err)
+	require.NoError(t, registryService.RecordCapabilities(ctx, "com.example/weather", "1.0.0", &apiv0.Capabilities{
+		Status: service.CapabilitiesFailed,
+		Error:  "initialize failed",
+	}))
+	capabilities = getCapabilities()
+	assert.Equal(t, service.CapabilitiesFailed, capabilities.Status)
+	assert.Equal(t, "initialize failed", capabilities.Error)
+	assert.Len(t, capabilities.Tools, 1)
+}
@@ -0,0 +1,145 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerChannelsInput represents the input for listing the release channels of a server
+type ServerChannelsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// SetServerChannelInput represents the input for tagging a version into a release channel
+type SetServerChannelInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Channel       string `path:"channel" enum:"stable,beta" doc:"Release channel" example:"stable"`
+	Body          struct {
+		Version string `json:"version" minLength:"1" doc:"Version to tag into the channel" example:"1.0.2"`
+	}
+}
+
+// DeleteServerChannelInput represents the input for removing a release channel
+type DeleteServerChannelInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Channel       string `path:"channel" enum:"stable,beta" doc:"Release channel" example:"beta"`
+}
+
+// RegisterChannelEndpoints registers the release channel endpoints with a custom path prefix
+func RegisterChannelEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-channels" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/channels",
+		Summary:     "List release channels",
+		Description: "Get the versions the release channels of a server point at. latest always follows the latest version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerChannelsInput) (*Response[apiv0.ServerChannelListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		channels, err := registry.ListServerChannels(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/channels")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server channels", err)
+		}
+		return &Response[apiv0.ServerChannelListResponse]{
+			Body: apiv0.ServerChannelListResponse{Channels: channels},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-channel" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/channels/{channel}",
+		Summary:     "Tag version into release channel",
+		Description: "Point a release channel of a server at one of its versions. Requires permission to publish the server.",
+		Tags:        []string{"publish"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetServerChannelInput) (*Response[apiv0.ServerChannel], error) {
+		serverName, err := authorizePublisher(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		channel, err := registry.SetServerChannel(ctx, serverName, input.Channel, input.Body.Version)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server version not found")
+			case errors.Is(err, database.ErrInvalidInput), errors.Is(err, service.ErrUnknownReleaseChannel), errors.Is(err, service.ErrLatestChannelReadOnly):
+				return nil, huma.Error400BadRequest(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to set server channel", err)
+			}
+		}
+		return &Response[apiv0.ServerChannel]{Body: *channel}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-channel" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/channels/{channel}",
+		Summary:       "Remove release channel",
+		Description:   "Stop offering a release channel of a server. Requires permission to publish the server.",
+		Tags:          []string{"publish"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteServerChannelInput) (*struct{}, error) {
+		serverName, err := authorizePublisher(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteServerChannel(ctx, serverName, input.Channel); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server channel not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete server channel", err)
+		}
+		return nil, nil
+	})
+}
+
+// authorizePublisher validates the token and checks that it may publish the server, returning
+// the decoded server name
+func authorizePublisher(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, authHeader, encodedName string,
+) (string, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return "", err
+	}
+	serverName, err := url.PathUnescape(encodedName)
+	if err != nil {
+		return "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	allowed, reason, err := canPublish(ctx, registry, jwtManager, claims, serverName)
+	if err != nil {
+		return "", huma.Error500InternalServerError("Failed to check namespace permissions", err)
+	}
+	if !allowed {
+		return "", huma.Error403Forbidden(reason)
+	}
+	return serverName, nil
+}
@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseChannels(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0-beta.1"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterChannelEndpoints(api, "/v0", registryService, testConfig)
+
+	githubToken := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + login + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	const path = "/v0/servers/io.github.alice%2Fweather"
+
+	t.Run("only publishers can tag", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPut, path+"/channels/stable", githubToken("bob"), map[string]string{"version": "1.1.0"}).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, path+"/channels/latest", githubToken("alice"), map[string]string{"version": "1.1.0"}).Code)
+		assert.Equal(t, http.StatusNotFound, call(http.MethodPut, path+"/channels/stable", githubToken("alice"), map[string]string{"version": "9.9.9"}).Code)
+	})
+
+	w := call(http.MethodGet, path+"/versions/latest?channel=stable", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "stable channel")
+
+	require.Equal(t, http.StatusOK, call(http.MethodPut, path+"/channels/stable", githubToken("alice"), map[string]string{"version": "1.1.0"}).Code)
+
+	t.Run("clients resolve channels", func(t *testing.T) {
+		var server apiv0.ServerResponse
+		w := call(http.MethodGet, path+"/versions/latest?channel=stable", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		assert.Equal(t, "1.1.0", server.Server.Version)
+
+		w = call(http.MethodGet, path+"/versions/latest", "", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		assert.Equal(t, "2.0.0-beta.1", server.Server.Version)
+
+		var list apiv0.ServerListResponse
+		w = call(http.MethodGet, "/v0/servers?channel=stable", "", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		assert.Equal(t, "1.1.0", list.Servers[0].Server.Version)
+
+		var channels apiv0.ServerChannelListResponse
+		w = call(http.MethodGet, path+"/channels", "", nil)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &channels))
+		require.Len(t, channels.Channels, 2)
+		assert.Equal(t, "latest", channels.Channels[0].Channel)
+		assert.Equal(t, "2.0.0-beta.1", channels.Channels[0].Version)
+	})
+
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, path+"/channels/stable", githubToken("alice"), nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, path+"/channels/stable", githubToken("alice"), nil).Code)
+}
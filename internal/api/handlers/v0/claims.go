@@ -0,0 +1,115 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ClaimServerInput represents the input for claiming a seeded server
+type ClaimServerInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from GitHub authentication" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded name of the seeded server" example:"com.docker.mcp%2Fweather"`
+	Body          *struct {
+		Name string `json:"name,omitempty" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Name to move the server to. Defaults to the same name in the namespace of the repository owner." example:"io.github.octocat/weather"`
+	}
+}
+
+// RegisterClaimEndpoint registers the seeded server claim endpoint with a custom path prefix
+func RegisterClaimEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "claim-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/claim",
+		Summary:     "Claim seeded server",
+		Description: "Take over a server seeded into the " + service.SeededNamespace + " namespace as a maintainer of its declared GitHub repository. " +
+			"Every version moves to a name you can publish to, and reads of the old name redirect to it.",
+		Tags:     []string{"publish"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ClaimServerInput) (*Response[apiv0.ServerRedirect], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		verify := func(server *apiv0.ServerJSON) (string, error) {
+			namespace := maintainedRepositoryNamespace(claims, server.Repository)
+			if namespace == "" {
+				return "", huma.Error403Forbidden("You must be logged in with GitHub as the owner of " + server.Repository.URL +
+					", or a member of the organization that owns it, to claim " + server.Name)
+			}
+
+			newName := namespace + "/" + strings.TrimPrefix(server.Name, service.SeededNamespace+"/")
+			if input.Body != nil && input.Body.Name != "" {
+				newName = input.Body.Name
+			}
+			allowed, reason, err := canPublish(ctx, registry, jwtManager, claims, newName)
+			if err != nil {
+				return "", huma.Error500InternalServerError("Failed to check namespace permissions", err)
+			}
+			if !allowed {
+				return "", huma.Error403Forbidden(reason)
+			}
+			return newName, nil
+		}
+
+		redirect, err := registry.ClaimServer(ctx, serverName, principalFromClaims(claims), verify)
+		if err != nil {
+			var statusErr huma.StatusError
+			switch {
+			case errors.As(err, &statusErr):
+				return nil, err
+			case errors.Is(err, service.ErrNotSeededServer):
+				return nil, huma.Error400BadRequest(err.Error())
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrAlreadyExists):
+				return nil, huma.Error409Conflict(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to claim server", err)
+			}
+		}
+		return &Response[apiv0.ServerRedirect]{Body: *redirect}, nil
+	})
+}
+
+// maintainedRepositoryNamespace returns the io.github namespace of a GitHub repository's owner
+// if the token grants it, as GitHub login does for users and the organizations they belong
+// to. GitHub logins are case-insensitive, so the namespace is returned as the token spells it.
+func maintainedRepositoryNamespace(claims *auth.JWTClaims, repo model.Repository) string {
+	if repo.Source != "github" {
+		return ""
+	}
+	parsed, err := url.Parse(repo.URL)
+	if err != nil || !strings.EqualFold(parsed.Host, "github.com") {
+		return ""
+	}
+	owner, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if owner == "" {
+		return ""
+	}
+
+	for _, perm := range claims.Permissions {
+		namespace, found := strings.CutSuffix(perm.ResourcePattern, "/*")
+		if perm.Action == auth.PermissionActionPublish && found && strings.EqualFold(namespace, "io.github."+owner) {
+			return namespace
+		}
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimServer(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.docker.mcp/weather",
+			Description: "Seeded weather server",
+			Version:     version,
+			Repository:  model.Repository{URL: "https://github.com/Alice/weather-mcp", Source: "github"},
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterClaimEndpoint(api, "/v0", registryService, testConfig)
+
+	githubToken := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + login + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+
+	claim := func(serverName, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(http.MethodPost, "/v0/servers/"+serverName+"/claim", &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("only repository maintainers", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, claim("com.docker.mcp%2Fweather", githubToken("bob"), nil).Code)
+		assert.Equal(t, http.StatusForbidden, claim("com.docker.mcp%2Fweather", githubToken("alice"),
+			map[string]string{"name": "io.github.bob/weather"}).Code, "must be able to publish the new name")
+		assert.Equal(t, http.StatusNotFound, claim("com.docker.mcp%2Fmissing", githubToken("alice"), nil).Code)
+		assert.Equal(t, http.StatusBadRequest, claim("io.github.alice%2Fweather", githubToken("alice"), nil).Code)
+	})
+
+	w := claim("com.docker.mcp%2Fweather", githubToken("alice"), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var redirect apiv0.ServerRedirect
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &redirect))
+	assert.Equal(t, "com.docker.mcp/weather", redirect.From)
+	assert.Equal(t, "io.github.alice/weather", redirect.To)
+
+	versions, err := registryService.GetAllVersionsByServerName(context.Background(), "io.github.alice/weather")
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	t.Run("old name redirects", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/com.docker.mcp%2Fweather/versions/latest", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/v0/servers/io.github.alice%2Fweather/versions/latest", w.Header().Get("Location"))
+	})
+
+	t.Run("seeding cannot bring back the old name", func(t *testing.T) {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.docker.mcp/weather",
+			Description: "Seeded weather server",
+			Version:     "1.2.0",
+		})
+		assert.ErrorIs(t, err, service.ErrServerMoved)
+	})
+}
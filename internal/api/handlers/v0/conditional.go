@@ -0,0 +1,84 @@
+package v0
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ConditionalParams are the headers that make a read conditional, so that clients polling for
+// changes get 304 Not Modified rather than the same body again
+type ConditionalParams struct {
+	IfNoneMatch     string    `header:"If-None-Match" doc:"ETags of cached responses. If the response still has one of them, 304 Not Modified is returned without a body." required:"false"`
+	IfModifiedSince time.Time `header:"If-Modified-Since" doc:"Last-Modified of a cached response. If nothing in the response was updated since, 304 Not Modified is returned without a body. Ignored when If-None-Match is set." required:"false"`
+}
+
+// notModified returns 304 Not Modified, carrying the response's validators, when the request's
+// conditions show the client's cached copy is current, and nil otherwise. As in RFC 9110,
+// If-Modified-Since only counts without If-None-Match.
+func (p *ConditionalParams) notModified(etag string, lastModified time.Time) error {
+	matched := false
+	if p.IfNoneMatch != "" {
+		for _, candidate := range strings.Split(p.IfNoneMatch, ",") {
+			// Reads compare ETags weakly, so W/ prefixes added by proxies still match
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				matched = true
+			}
+		}
+	} else if !p.IfModifiedSince.IsZero() && !lastModified.IsZero() {
+		// HTTP dates have whole seconds
+		matched = !lastModified.Truncate(time.Second).After(p.IfModifiedSince)
+	}
+	if !matched {
+		return nil
+	}
+
+	headers := http.Header{"ETag": {etag}}
+	if !lastModified.IsZero() {
+		headers.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	return huma.ErrorWithHeaders(huma.Status304NotModified(), headers)
+}
+
+// ifMatch reports whether an If-Match header names a resource's current ETag, or is * to match
+// whatever it is. Edits compare ETags strongly, so weak ones never match.
+func ifMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// responseETag returns a strong ETag for a response body: the quoted hex-encoded SHA-256 of its
+// JSON encoding. It covers the registry's metadata as well as the server.json, so it changes when
+// a version is yanked or gets an advisory, not just when it is edited.
+func responseETag(body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`, nil
+}
+
+// lastModified returns when the most recently updated of some server versions was updated, or
+// the zero time if there are none
+func lastModified(servers ...*apiv0.ServerResponse) time.Time {
+	var latest time.Time
+	for _, server := range servers {
+		if server.Meta.Official != nil && server.Meta.Official.UpdatedAt.After(latest) {
+			latest = server.Meta.Official.UpdatedAt
+		}
+	}
+	return latest
+}
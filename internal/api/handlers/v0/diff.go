@@ -0,0 +1,71 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/serverdiff"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerDiffInput represents the input for comparing two versions of a server
+type ServerDiffInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	From       string `query:"from" required:"true" minLength:"1" doc:"Version to compare from, or 'latest'" example:"1.2.0"`
+	To         string `query:"to" required:"true" minLength:"1" doc:"Version to compare to, or 'latest'" example:"1.3.0"`
+}
+
+// RegisterDiffEndpoint registers the endpoint comparing two versions of a server with a custom path prefix
+func RegisterDiffEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-server-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/diff",
+		Summary:     "Compare two versions of an MCP server",
+		Description: "List what changed between two versions of a server: the packages and remotes added, removed or changed, " +
+			"with their environment variables, arguments and headers, and the other server.json fields that changed, including _meta.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ServerDiffInput) (*Response[apiv0.ServerDiff], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		getVersion := func(version string) (*apiv0.ServerResponse, error) {
+			var server *apiv0.ServerResponse
+			var err error
+			if version == "latest" {
+				server, err = registry.GetServerByName(ctx, serverName)
+			} else {
+				server, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
+			}
+			if errors.Is(err, database.ErrNotFound) {
+				if _, err := registry.GetServerByName(ctx, serverName); err == nil {
+					return nil, huma.Error404NotFound("Version " + version + " of " + serverName + " not found")
+				}
+				subPath := "/diff?from=" + url.QueryEscape(input.From) + "&to=" + url.QueryEscape(input.To)
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, subPath)
+			}
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to get server details", err)
+			}
+			return server, nil
+		}
+
+		from, err := getVersion(input.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := getVersion(input.To)
+		if err != nil {
+			return nil, err
+		}
+		return &Response[apiv0.ServerDiff]{Body: *serverdiff.Diff(&from.Server, &to.Server)}, nil
+	})
+}
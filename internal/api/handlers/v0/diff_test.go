@@ -0,0 +1,62 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+			Packages:    []model.Package{{RegistryType: "npm", Identifier: "@example/weather", Version: version, Transport: model.Transport{Type: "stdio"}}},
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDiffEndpoint(api, "/v0", registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/v0/servers/com.example%2Fweather/diff?from=1.0.0&to=latest")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var diff apiv0.ServerDiff
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &diff))
+	assert.Equal(t, "1.0.0", diff.From)
+	assert.Equal(t, "1.1.0", diff.To)
+	require.Len(t, diff.Packages, 1)
+	assert.Equal(t, apiv0.ChangeChanged, diff.Packages[0].Change)
+	assert.Equal(t, []apiv0.FieldChange{{Field: "version", From: "1.0.0", To: "1.1.0"}}, diff.Packages[0].Fields)
+	assert.Empty(t, diff.Remotes)
+	assert.Empty(t, diff.Metadata)
+
+	assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fweather/diff?from=0.9.0&to=1.1.0").Code)
+	assert.Equal(t, http.StatusNotFound, get("/v0/servers/com.example%2Fmissing/diff?from=1.0.0&to=1.1.0").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, get("/v0/servers/com.example%2Fweather/diff?from=1.0.0").Code)
+}
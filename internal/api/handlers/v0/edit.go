@@ -21,13 +21,21 @@ type EditServerInput struct {
 	Authorization string           `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
 	ServerName    string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version       string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
+	IfMatch       string           `header:"If-Match" doc:"The ETag of the version as last read, so that the edit fails with 412 Precondition Failed if the version was changed since. * edits whatever the version holds." required:"false"`
 	Status        string           `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
 	Body          apiv0.ServerJSON `body:""`
 }
 
+// EditServerOutput is the edited server version, with its new ETag
+type EditServerOutput struct {
+	ETag string `header:"ETag"`
+	Body apiv0.ServerResponse
+}
+
 // RegisterEditEndpoints registers the edit endpoint with a custom path prefix
 func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	jwtManager := auth.NewJWTManager(cfg)
+	limits := newServerJSONLimits(cfg)
 
 	// Edit server endpoint
 	huma.Register(api, huma.Operation{
@@ -35,12 +43,15 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		Method:      http.MethodPut,
 		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 		Summary:     "Edit MCP server",
-		Description: "Update a specific version of an existing MCP server (admin only).",
-		Tags:        []string{"admin"},
+		Description: "Update a specific version of an existing MCP server. Admins may edit any server. Otherwise editing takes the identity that published the server first or a role in its namespace, and deleting takes the owner role in the organization owning the namespace. " +
+			"Edits send the ETag of the version as last read as If-Match and fail with 412 Precondition Failed when someone else changed the version since, rather than overwriting their change.",
+		Tags: []string{"admin"},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *EditServerInput) (*Response[apiv0.ServerResponse], error) {
+		MaxBodyBytes: limits.operationMaxBodyBytes(),
+		Middlewares:  huma.Middlewares{limits.middleware(api)},
+	}, func(ctx context.Context, input *EditServerInput) (*EditServerOutput, error) {
 		// Extract bearer token
 		const bearerPrefix = "Bearer "
 		authHeader := input.Authorization
@@ -55,6 +66,11 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
 		}
 
+		// Edits are conditional, so that concurrent edits don't silently overwrite each other
+		if input.IfMatch == "" {
+			return nil, huma.NewError(http.StatusPreconditionRequired, "If-Match is required: send the ETag of the version as last read, or * to edit whatever it holds")
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -76,8 +92,33 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			return nil, huma.Error500InternalServerError("Failed to get current server", err)
 		}
 
-		// Verify edit permissions for this server using the existing server name
-		if !jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionEdit, claims.Permissions) {
+		// The ETag covers the revision, so the service checks the version is still at the
+		// revision the client read while it holds the version's lock. * edits any revision.
+		revision := 0
+		if strings.TrimSpace(input.IfMatch) != "*" {
+			etag, err := responseETag(currentServer)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to encode current server", err)
+			}
+			if !ifMatch(input.IfMatch, etag) {
+				return nil, huma.Error412PreconditionFailed("The server version was changed since it was read: fetch it again and reapply the edit")
+			}
+			if currentServer.Meta.Official != nil {
+				revision = currentServer.Meta.Official.Revision
+			}
+		}
+
+		// Verify edit permissions for this server using the existing server name. Besides admins,
+		// members of the organization owning the namespace may edit according to their role.
+		deleting := model.Status(input.Status) == model.StatusDeleted
+		allowed, err := canEdit(ctx, registry, jwtManager, claims, currentServer.Server.Name, deleting)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check edit permissions", err)
+		}
+		if !allowed {
+			if deleting {
+				return nil, huma.Error403Forbidden("You do not have permission to delete this server")
+			}
 			return nil, huma.Error403Forbidden("You do not have edit permissions for this server")
 		}
 
@@ -102,9 +143,16 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 				return nil, huma.Error400BadRequest("Cannot change status of deleted server. Deleted servers cannot be undeleted.")
 			}
 
-			// For now, only allow status changes for admins
-			// Future: Implement logic to allow server authors to change active <-> deprecated
-			// but only admins can set to deleted
+			// Admins may make any status change. In organization namespaces, publishers may
+			// switch between active and deprecated and only owners may delete (checked above).
+			// Elsewhere, original publishers may switch between active and deprecated.
+		}
+
+		// Only the server's original publisher may edit it, unless the token overrides that
+		admin := jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions)
+		editor, err := serverPublisher(ctx, registry, claims, serverName, admin)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check edit permissions", err)
 		}
 
 		// Update the server using the service
@@ -112,16 +160,27 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		if input.Status != "" {
 			statusPtr = &input.Status
 		}
-		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr)
+		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr, editor, revision)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
+			switch {
+			case errors.Is(err, service.ErrRevisionMismatch):
+				return nil, huma.Error412PreconditionFailed("The server version was changed since it was read: fetch it again and reapply the edit")
+			case errors.Is(err, service.ErrNotServerPublisher):
+				return nil, huma.Error403Forbidden("You do not have edit permissions for this server: " + err.Error())
+			case errors.Is(err, database.ErrNotFound):
 				return nil, huma.Error404NotFound("Server not found")
 			}
 			return nil, huma.Error400BadRequest("Failed to edit server", err)
 		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *updatedServer,
-		}, nil
+		// Return the version as reads serve it, so that its ETag can be sent with the next edit
+		if edited, err := registry.GetServerByNameAndVersion(ctx, serverName, version); err == nil {
+			updatedServer = edited
+		}
+		etag, err := responseETag(updatedServer)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode server details", err)
+		}
+		return &EditServerOutput{ETag: etag, Body: *updatedServer}, nil
 	})
 }
@@ -87,7 +87,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	require.NoError(t, err)
 
 	// Set the server to deleted status
-	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)))
+	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)), nil, 0)
 	require.NoError(t, err)
 
 	// Create a server with build metadata for URL encoding test
@@ -387,6 +387,7 @@ func TestEditServerEndpoint(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPut, requestURL, bytes.NewReader(requestBody))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", "*")
 
 			// Set authorization header
 			if tc.authHeader != "" {
@@ -461,7 +462,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 				Name:        server.name,
 				Description: "Test server for editing",
 				Version:     server.version,
-			}, stringPtr(string(server.status)))
+			}, stringPtr(string(server.status)), nil, 0)
 			require.NoError(t, err)
 		}
 	}
@@ -470,6 +471,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
 	v0.RegisterEditEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
 
 	t.Run("status transitions", func(t *testing.T) {
 		tests := []struct {
@@ -529,6 +531,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 
 				req := httptest.NewRequest(http.MethodPut, requestURL, bytes.NewReader(bodyBytes))
 				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("If-Match", "*")
 
 				// Generate admin token
 				jwtManager := auth.NewJWTManager(cfg)
@@ -586,6 +589,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPut, requestURL, bytes.NewReader(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", "*")
 
 		// Generate admin token
 		jwtManager := auth.NewJWTManager(cfg)
@@ -627,6 +631,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 
 		req := httptest.NewRequest(http.MethodPut, requestURL, bytes.NewReader(bodyBytes))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", "*")
 
 		// Generate admin token
 		jwtManager := auth.NewJWTManager(cfg)
@@ -655,6 +660,65 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotEqual(t, "Updated v1.0.0 specifically", otherVersion.Server.Description)
 	})
+
+	t.Run("conditional edits", func(t *testing.T) {
+		jwtManager := auth.NewJWTManager(cfg)
+		tokenResponse, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod: auth.MethodNone,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+			},
+		})
+		require.NoError(t, err)
+
+		edit := func(ifMatch, description string) *httptest.ResponseRecorder {
+			bodyBytes, err := json.Marshal(apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/multi-version-server",
+				Description: description,
+				Version:     "2.0.0",
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/v0/servers/com.example%2Fmulti-version-server/versions/2.0.0", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+tokenResponse.RegistryToken)
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			return w
+		}
+
+		get := httptest.NewRecorder()
+		mux.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/v0/servers/com.example%2Fmulti-version-server/versions/2.0.0", nil))
+		require.Equal(t, http.StatusOK, get.Code, get.Body.String())
+		read := get.Header().Get("ETag")
+		require.NotEmpty(t, read)
+
+		assert.Equal(t, http.StatusPreconditionRequired, edit("", "Unconditional edit").Code)
+		assert.Equal(t, http.StatusPreconditionFailed, edit("W/"+read, "Weakly conditional edit").Code)
+
+		// The ETag the version is read with is the one its edits send back
+		w := edit(read, "First edit")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		edited := w.Header().Get("ETag")
+		assert.NotEqual(t, read, edited)
+		get = httptest.NewRecorder()
+		mux.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/v0/servers/com.example%2Fmulti-version-server/versions/2.0.0", nil))
+		require.Equal(t, http.StatusOK, get.Code, get.Body.String())
+		assert.Equal(t, edited, get.Header().Get("ETag"), "edits and reads agree on the version's ETag")
+
+		// A second editor who read the same version doesn't overwrite the first edit
+		w = edit(read, "Second edit")
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		current, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/multi-version-server", "2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "First edit", current.Server.Description)
+
+		assert.Equal(t, http.StatusOK, edit(edited, "Second edit, reapplied").Code)
+		assert.Equal(t, http.StatusOK, edit("*", "Forced edit").Code)
+	})
 }
 
 // Helper function
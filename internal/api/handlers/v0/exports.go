@@ -0,0 +1,130 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// exportCacheControl lets CDNs cache exports for a few minutes, well within how often they are rendered
+const exportCacheControl = "public, max-age=300"
+
+// ExportInput represents the input for getting an export
+type ExportInput struct {
+	AcceptEncoding string `header:"Accept-Encoding" doc:"gzip to get the export gzip-encoded" required:"false"`
+	ConditionalParams
+}
+
+// ExportOutput is an export, gzip-encoded when the client accepts it, with the headers CDNs cache it by
+type ExportOutput struct {
+	ContentType     string    `header:"Content-Type"`
+	ContentEncoding string    `header:"Content-Encoding"`
+	CacheControl    string    `header:"Cache-Control"`
+	Vary            string    `header:"Vary"`
+	ETag            string    `header:"ETag"`
+	LastModified    time.Time `header:"Last-Modified"`
+	Body            []byte
+}
+
+// RegisterExportEndpoints registers the JSON export endpoint with a custom path prefix
+func RegisterExportEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-export" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/export/servers.json",
+		Summary:     "Export all MCP servers",
+		Description: "Get a snapshot of every version of every server that isn't quarantined, for CDNs and offline mirrors. " +
+			"The snapshot is rendered on an interval rather than on request, so it can be a few minutes behind the other endpoints. " +
+			"It is gzip-encoded for clients that accept it, and conditional requests get 304 Not Modified until it is rendered again.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Snapshot of every server version, as an apiv0.ServerExport",
+				Content:     map[string]*huma.MediaType{"application/json": {}},
+			},
+		},
+	}, func(ctx context.Context, input *ExportInput) (*ExportOutput, error) {
+		return getExport(ctx, registry, service.ExportServers, input)
+	})
+}
+
+// RegisterSitemapEndpoint registers the sitemap for web crawlers, which is served at the root
+func RegisterSitemapEndpoint(api huma.API, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-sitemap",
+		Method:      http.MethodGet,
+		Path:        "/sitemap.xml",
+		Summary:     "Get sitemap",
+		Description: "Get a sitemap linking to the latest version of every server listings show, for web crawlers. " +
+			"It is rendered with the JSON export, and only when the registry's public URL is configured.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Sitemap",
+				Content:     map[string]*huma.MediaType{"application/xml": {}},
+			},
+		},
+	}, func(ctx context.Context, input *ExportInput) (*ExportOutput, error) {
+		return getExport(ctx, registry, service.ExportSitemap, input)
+	})
+}
+
+// getExport returns the latest rendering of an export in the encoding the client accepts. Each
+// encoding has its own ETag, as strong ETags must differ between encodings.
+func getExport(ctx context.Context, registry service.RegistryService, name string, input *ExportInput) (*ExportOutput, error) {
+	gzipped := acceptsGzip(input.AcceptEncoding)
+	export, err := registry.GetExport(ctx, name, gzipped)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error404NotFound(name + " hasn't been generated")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get "+name, err)
+	}
+
+	output := &ExportOutput{
+		ContentType:  export.ContentType,
+		CacheControl: exportCacheControl,
+		Vary:         "Accept-Encoding",
+		ETag:         export.ETag,
+		LastModified: export.GeneratedAt,
+		Body:         export.Body,
+	}
+	if gzipped {
+		output.ContentEncoding = "gzip"
+		output.ETag = strings.TrimSuffix(export.ETag, `"`) + `-gzip"`
+		output.Body = export.GzipBody
+	}
+	if err := input.notModified(output.ETag, export.GeneratedAt); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// acceptsGzip reports whether an Accept-Encoding header accepts gzip, by name or as *, with a
+// nonzero quality
+func acceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > 0 {
+			return true
+		}
+	}
+	return false
+}
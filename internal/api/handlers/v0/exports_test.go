@@ -0,0 +1,98 @@
+package v0_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/exports"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportEndpoints(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false, PublicURL: "https://registry.example.com"}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoints(api, "/v0", registryService)
+	v0.RegisterSitemapEndpoint(api, registryService)
+
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("/v0/export/servers.json", nil).Code, "not generated yet")
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Export test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, exports.NewGenerator(registryService, cfg).Generate(ctx))
+
+	t.Run("export holds every version", func(t *testing.T) {
+		w := get("/v0/export/servers.json", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+		var export apiv0.ServerExport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+		assert.Equal(t, 2, export.Metadata.Count)
+		require.Len(t, export.Servers, 2)
+
+		assert.Equal(t, http.StatusNotModified, get("/v0/export/servers.json", map[string]string{"If-None-Match": w.Header().Get("ETag")}).Code)
+	})
+
+	t.Run("export is gzipped for clients that accept it", func(t *testing.T) {
+		plain := get("/v0/export/servers.json", map[string]string{"Accept-Encoding": "gzip;q=0, identity"})
+		assert.Empty(t, plain.Header().Get("Content-Encoding"))
+
+		w := get("/v0/export/servers.json", map[string]string{"Accept-Encoding": "br, gzip"})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		assert.NotEqual(t, plain.Header().Get("ETag"), w.Header().Get("ETag"))
+
+		reader, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+		require.NoError(t, err)
+		unzipped, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, plain.Body.Bytes(), unzipped)
+	})
+
+	t.Run("sitemap links the latest version", func(t *testing.T) {
+		w := get("/sitemap.xml", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<loc>https://registry.example.com/v0/servers/com.example%2Fweather/versions/latest</loc>")
+		assert.Equal(t, 1, bytes.Count(w.Body.Bytes(), []byte("<url>")))
+	})
+}
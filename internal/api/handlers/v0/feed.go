@@ -0,0 +1,200 @@
+package v0
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	atomContentType = "application/atom+xml"
+	// feedCacheControl caches the feed briefly, since feed readers poll it
+	feedCacheControl = "public, max-age=60"
+)
+
+// FeedInput represents the input for getting the feed of server activity
+type FeedInput struct {
+	Limit int `query:"limit" doc:"Number of entries" default:"50" minimum:"1" maximum:"100" example:"20"`
+	ConditionalParams
+
+	// host is the host the feed was requested from, which its links use when the public URL isn't configured
+	host string
+}
+
+// Resolve records the host the feed was requested from
+func (i *FeedInput) Resolve(ctx huma.Context) []error {
+	i.host = ctx.Host()
+	return nil
+}
+
+// FeedOutput is the Atom feed with the headers feed readers and CDNs cache it by
+type FeedOutput struct {
+	ContentType  string    `header:"Content-Type"`
+	CacheControl string    `header:"Cache-Control"`
+	ETag         string    `header:"ETag"`
+	LastModified time.Time `header:"Last-Modified"`
+	Body         []byte
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Updated   string       `xml:"updated"`
+	Published string       `xml:"published"`
+	Link      atomLink     `xml:"link"`
+	Author    *atomAuthor  `xml:"author,omitempty"`
+	Category  atomCategory `xml:"category"`
+	Summary   string       `xml:"summary"`
+}
+
+// RegisterFeedEndpoints registers the Atom feed of server activity with a custom path prefix
+func RegisterFeedEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-feed" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/feed.atom",
+		Summary:     "Get feed of server activity",
+		Description: "Get an Atom feed of the server versions published or updated most recently, most recent first, " +
+			"with who published each server. Quarantined servers are left out. Conditional requests get 304 Not Modified until there is new activity.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Atom feed",
+				Content:     map[string]*huma.MediaType{atomContentType: {}},
+			},
+		},
+	}, func(ctx context.Context, input *FeedInput) (*FeedOutput, error) {
+		activity, err := registry.ListServerActivity(ctx, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list server activity", err)
+		}
+
+		baseURL := strings.TrimSuffix(cfg.PublicURL, "/")
+		if baseURL == "" {
+			baseURL = "https://" + input.host
+		}
+		feed := renderFeed(baseURL, pathPrefix, activity)
+		body, err := xml.Marshal(feed)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode feed", err)
+		}
+		body = append([]byte(xml.Header), body...)
+
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:]) + `"`
+		var updated time.Time
+		if len(activity) > 0 {
+			updated = activity[0].Server.Meta.Official.UpdatedAt
+		}
+		if err := input.notModified(etag, updated); err != nil {
+			return nil, err
+		}
+
+		return &FeedOutput{
+			ContentType:  atomContentType,
+			CacheControl: feedCacheControl,
+			ETag:         etag,
+			LastModified: updated,
+			Body:         body,
+		}, nil
+	})
+}
+
+// renderFeed builds the feed of server activity. Entry IDs are tag URIs naming the version and
+// when it was updated, so that each publish and update is a new entry.
+func renderFeed(baseURL, pathPrefix string, activity []service.ServerActivity) *atomFeed {
+	feedURL := baseURL + pathPrefix + "/feed.atom"
+	host := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Hostname() != "" {
+		host = parsed.Hostname()
+	}
+
+	feed := &atomFeed{
+		ID:      feedURL,
+		Title:   "MCP Registry: recently published and updated servers",
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Link:    atomLink{Rel: "self", Type: atomContentType, Href: feedURL},
+		Author:  atomAuthor{Name: "MCP Registry"},
+		Entries: []atomEntry{},
+	}
+	for i, item := range activity {
+		server := item.Server.Server
+		official := item.Server.Meta.Official
+		if i == 0 {
+			feed.Updated = official.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+
+		change := "updated"
+		if official.UpdatedAt.Equal(official.PublishedAt) {
+			change = "published"
+		}
+		entry := atomEntry{
+			ID: "tag:" + host + "," + official.PublishedAt.UTC().Format(time.DateOnly) + ":servers/" + server.Name +
+				"/versions/" + server.Version + "/" + strconv.FormatInt(official.UpdatedAt.UnixMilli(), 10),
+			Title:     server.Name + " " + server.Version + " " + change,
+			Updated:   official.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: official.PublishedAt.UTC().Format(time.RFC3339),
+			Link: atomLink{
+				Rel:  "alternate",
+				Type: "application/json",
+				Href: baseURL + pathPrefix + "/servers/" + url.PathEscape(server.Name) + "/versions/" + url.PathEscape(server.Version),
+			},
+			Category: atomCategory{Term: change},
+			Summary:  server.Description,
+		}
+		if item.Publisher != nil {
+			entry.Author = feedAuthor(*item.Publisher)
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+// feedAuthor names the identity that published a server, linking to its GitHub account or domain
+func feedAuthor(publisher apiv0.Principal) *atomAuthor {
+	author := &atomAuthor{Name: publisher.Subject}
+	switch auth.Method(publisher.AuthMethod) {
+	case auth.MethodGitHubAT, auth.MethodGitHubOIDC:
+		author.URI = "https://github.com/" + publisher.Subject
+	case auth.MethodDNS, auth.MethodHTTP:
+		author.URI = "https://" + publisher.Subject
+	}
+	return author
+}
@@ -0,0 +1,99 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAtomFeed struct {
+	ID      string `xml:"id"`
+	Entries []struct {
+		ID       string `xml:"id"`
+		Title    string `xml:"title"`
+		Category struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+		Link struct {
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Author *struct {
+			Name string `xml:"name"`
+			URI  string `xml:"uri"`
+		} `xml:"author"`
+	} `xml:"entry"`
+}
+
+func TestFeedEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false, PublicURL: "https://registry.example.com"}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	alice := &service.Publisher{Principal: apiv0.Principal{AuthMethod: "github-at", Subject: "alice"}}
+	server := func(version, description string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: description,
+			Version:     version,
+		}
+	}
+	_, _, err := registryService.PublishServer(ctx, server("1.0.0", "Weather forecasts"), "", nil, alice)
+	require.NoError(t, err)
+	_, _, err = registryService.PublishServer(ctx, server("1.1.0", "Weather forecasts"), "", nil, alice)
+	require.NoError(t, err)
+	_, err = registryService.UpdateServer(ctx, "io.github.alice/weather", "1.0.0", server("1.0.0", "Weather forecasts and alerts"), nil, alice, 0)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterFeedEndpoints(api, "/v0", registryService, cfg)
+
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/v0/feed.atom", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/atom+xml", w.Header().Get("Content-Type"))
+
+	var feed testAtomFeed
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &feed))
+	assert.Equal(t, "https://registry.example.com/v0/feed.atom", feed.ID)
+	require.Len(t, feed.Entries, 2, "one entry per version, as last published or updated")
+
+	latest := feed.Entries[0]
+	assert.Equal(t, "io.github.alice/weather 1.0.0 updated", latest.Title)
+	assert.Equal(t, "updated", latest.Category.Term)
+	assert.Equal(t, "https://registry.example.com/v0/servers/io.github.alice%2Fweather/versions/1.0.0", latest.Link.Href)
+	assert.Regexp(t, `^tag:registry\.example\.com,\d{4}-\d{2}-\d{2}:servers/io\.github\.alice/weather/versions/1\.0\.0/\d+$`, latest.ID)
+	require.NotNil(t, latest.Author)
+	assert.Equal(t, "alice", latest.Author.Name)
+	assert.Equal(t, "https://github.com/alice", latest.Author.URI)
+	assert.Equal(t, "io.github.alice/weather 1.1.0 published", feed.Entries[1].Title)
+
+	var limited testAtomFeed
+	require.NoError(t, xml.Unmarshal(get("/v0/feed.atom?limit=1", "").Body.Bytes(), &limited))
+	assert.Len(t, limited.Entries, 1)
+
+	assert.Equal(t, http.StatusNotModified, get("/v0/feed.atom", w.Header().Get("ETag")).Code)
+}
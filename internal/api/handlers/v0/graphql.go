@@ -0,0 +1,209 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/graphql"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GraphQLInput represents the input for a GraphQL query
+type GraphQLInput struct {
+	Body graphql.Request
+}
+
+// GraphQLOutput represents the result of a GraphQL query
+type GraphQLOutput struct {
+	Body *graphql.Response
+}
+
+// RegisterGraphQLEndpoint registers the GraphQL endpoint, which is served at the root rather than
+// under each API version
+func RegisterGraphQLEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	schema := newGraphQLSchema(registry, cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "query-graphql",
+		Method:      http.MethodPost,
+		Path:        "/graphql",
+		Summary:     "Query servers with GraphQL",
+		Description: "Select just the fields of servers, versions, packages and publishers a client needs. " +
+			"The query root has servers (filtered like GET /v0/servers), server and versions fields, and server responses have a publisher field. " +
+			"Arguments on list fields filter their items by field values, and fields are named after their JSON names, with _meta keys shortened to their last segment (official, publisherProvided). " +
+			"Errors are returned in the GraphQL response with status 200. Introspection isn't supported.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *GraphQLInput) (*GraphQLOutput, error) {
+		return &GraphQLOutput{Body: schema.Execute(ctx, input.Body)}, nil
+	})
+}
+
+// newGraphQLSchema builds the resolvers of the GraphQL endpoint
+func newGraphQLSchema(registry service.RegistryService, cfg *config.Config) *graphql.Schema {
+	return &graphql.Schema{
+		Query: map[string]graphql.Resolver{
+			"servers": func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+				return resolveServers(ctx, registry, cfg, args)
+			},
+			"server": func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+				name, version, err := serverArgs(args)
+				if err != nil {
+					return nil, err
+				}
+				var server *apiv0.ServerResponse
+				if version == "latest" {
+					server, err = registry.GetServerByName(ctx, name)
+				} else {
+					server, err = registry.GetServerByNameAndVersion(ctx, name, version)
+				}
+				if errors.Is(err, database.ErrNotFound) {
+					return nil, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to get server: %w", err)
+				}
+				return server, nil
+			},
+			"versions": func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+				name, _, err := serverArgs(args)
+				if err != nil {
+					return nil, err
+				}
+				includeYanked, _, err := args.Bool("includeYanked")
+				if err != nil {
+					return nil, err
+				}
+				servers, err := registry.GetAllVersionsByServerName(ctx, name)
+				if errors.Is(err, database.ErrNotFound) {
+					return []apiv0.ServerResponse{}, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to get server versions: %w", err)
+				}
+				versions := make([]apiv0.ServerResponse, 0, len(servers))
+				for _, server := range servers {
+					if !includeYanked && server.Meta.Official != nil && server.Meta.Official.YankedAt != nil {
+						continue
+					}
+					versions = append(versions, *server)
+				}
+				return versions, nil
+			},
+		},
+		Fields: map[reflect.Type]map[string]graphql.Resolver{
+			reflect.TypeFor[apiv0.ServerResponse](): {
+				"publisher": func(ctx context.Context, parent any, _ graphql.Args) (any, error) {
+					server := parent.(*apiv0.ServerResponse)
+					publisher, err := registry.GetServerPublisher(ctx, server.Server.Name)
+					if errors.Is(err, database.ErrNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, fmt.Errorf("failed to get server publisher: %w", err)
+					}
+					return publisher, nil
+				},
+			},
+		},
+	}
+}
+
+// serverArgs returns the name and version arguments of the server and versions fields
+func serverArgs(args graphql.Args) (string, string, error) {
+	name, ok, err := args.String("name")
+	if err != nil {
+		return "", "", err
+	}
+	if !ok || name == "" {
+		return "", "", errors.New("argument \"name\" is required")
+	}
+	version, ok, err := args.String("version")
+	if err != nil {
+		return "", "", err
+	}
+	if !ok || version == "" {
+		version = "latest"
+	}
+	return name, version, nil
+}
+
+// resolveServers lists servers the way GET /v0/servers does, leaving out yanked versions and
+// quarantined servers
+func resolveServers(ctx context.Context, registry service.RegistryService, cfg *config.Config, args graphql.Args) (any, error) {
+	filter := &database.ServerFilter{}
+	quarantined := false
+	filter.Quarantined = &quarantined
+
+	values := map[string]string{}
+	for _, name := range []string{"search", "version", "updatedSince", "status", "registryType", "transport", "cursor"} {
+		value, _, err := args.String(name)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = value
+	}
+	limit, _, err := args.Int("limit")
+	if err != nil {
+		return nil, err
+	}
+	includeYanked, _, err := args.Bool("includeYanked")
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedSince := values["updatedSince"]; updatedSince != "" {
+		updatedTime, err := time.Parse(time.RFC3339, updatedSince)
+		if err != nil {
+			return nil, errors.New("invalid updatedSince: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+		}
+		filter.UpdatedSince = &updatedTime
+	}
+	if search := values["search"]; search != "" {
+		filter.SubstringName = &search
+	}
+	if version := values["version"]; version == "latest" {
+		isLatest := true
+		filter.IsLatest = &isLatest
+	} else if version != "" {
+		filter.Version = &version
+	}
+	if !includeYanked {
+		yanked := false
+		filter.Yanked = &yanked
+	}
+	if status := values["status"]; status != "" {
+		statuses, err := parseStatuses(status)
+		if err != nil {
+			return nil, err
+		}
+		filter.Statuses = statuses
+	}
+	if registryType := values["registryType"]; registryType != "" {
+		filter.RegistryType = &registryType
+	}
+	if transport := values["transport"]; transport != "" {
+		filter.Transport = &transport
+	}
+
+	servers, nextCursor, err := registry.ListServers(ctx, filter, values["cursor"], listPageSize(cfg, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	serverValues := make([]apiv0.ServerResponse, len(servers))
+	for i, server := range servers {
+		serverValues[i] = *server
+	}
+	return apiv0.ServerListResponse{
+		Servers:  serverValues,
+		Metadata: apiv0.Metadata{NextCursor: nextCursor, Count: len(servers)},
+	}, nil
+}
@@ -0,0 +1,120 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{EnableRegistryValidation: false}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	alice := &service.Publisher{Principal: apiv0.Principal{AuthMethod: "github-at", Subject: "alice"}}
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, _, err := registryService.PublishServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+			Packages: []model.Package{
+				{RegistryType: "npm", Identifier: "@alice/weather", Version: version, Transport: model.Transport{Type: "stdio"}},
+				{RegistryType: "pypi", Identifier: "alice-weather", Version: version, Transport: model.Transport{Type: "streamable-http", URL: "http://localhost:8080/mcp"}},
+			},
+		}, "", nil, alice)
+		require.NoError(t, err)
+	}
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/maps",
+		Description: "Maps",
+		Version:     "2.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterGraphQLEndpoint(api, registryService, cfg)
+
+	query := func(query string, variables map[string]any) (map[string]any, []any) {
+		body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp struct {
+			Data   map[string]any `json:"data"`
+			Errors []any          `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data, resp.Errors
+	}
+
+	t.Run("lists servers with selected fields", func(t *testing.T) {
+		data, errs := query(`{ servers(version: "latest", registryType: "npm") { servers { server { name version } } metadata { count } } }`, nil)
+		require.Empty(t, errs)
+		assert.Equal(t, map[string]any{
+			"servers":  []any{map[string]any{"server": map[string]any{"name": "io.github.alice/weather", "version": "1.1.0"}}},
+			"metadata": map[string]any{"count": float64(1)},
+		}, data["servers"])
+	})
+
+	t.Run("gets a server with filtered packages and its publisher", func(t *testing.T) {
+		data, errs := query(`query ($name: String!) {
+			server(name: $name, version: "1.0.0") {
+				server { packages(registryType: pypi) { identifier transport { type } } }
+				_meta { official { isLatest } }
+				publisher { subject }
+			}
+		}`, map[string]any{"name": "io.github.alice/weather"})
+		require.Empty(t, errs)
+		assert.Equal(t, map[string]any{
+			"server": map[string]any{"packages": []any{map[string]any{
+				"identifier": "alice-weather",
+				"transport":  map[string]any{"type": "streamable-http"},
+			}}},
+			"_meta":     map[string]any{"official": map[string]any{"isLatest": false}},
+			"publisher": map[string]any{"subject": "alice"},
+		}, data["server"])
+	})
+
+	t.Run("lists versions and leaves unknown servers null", func(t *testing.T) {
+		data, errs := query(`{
+			versions(name: "io.github.alice/weather") { server { version } }
+			maps: server(name: "com.example/maps") { publisher { subject } }
+			missing: server(name: "com.example/missing") { server { name } }
+		}`, nil)
+		require.Empty(t, errs)
+		assert.Len(t, data["versions"], 2)
+		assert.Equal(t, map[string]any{"publisher": nil}, data["maps"], "no publisher is recorded for servers created directly")
+		assert.Nil(t, data["missing"])
+	})
+
+	t.Run("reports errors in the response", func(t *testing.T) {
+		data, errs := query(`{ server { server { name } } }`, nil)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, map[string]any{"server": nil}, data)
+
+		data, errs = query(`{ servers(`, nil)
+		assert.Len(t, errs, 1)
+		assert.Nil(t, data)
+	})
+}
@@ -0,0 +1,68 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxImportBodyBytes bounds the size of an imported seed, which is far larger than other bodies
+const maxImportBodyBytes = 64 << 20
+
+// ImportServersInput represents the input for importing a seed
+type ImportServersInput struct {
+	Authorization string            `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	DryRun        bool              `query:"dryRun" doc:"Validate the seed and report how each entry would fare without saving anything"`
+	Body          []json.RawMessage `doc:"Seed entries, as in seed.json: server.json documents, or server responses whose official metadata sets a status"`
+}
+
+// RegisterImportEndpoints registers the admin import endpoint with a custom path prefix
+func RegisterImportEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "import-servers" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/import",
+		Summary:     "Import servers",
+		Description: "Publish a seed.json array in a single transaction (admin only). Every entry is validated and published as if it were published on its own; " +
+			"the batch is saved only if every entry succeeds. The report gives the result of each entry and whether the batch was applied.",
+		Tags:         []string{"admin"},
+		Security:     []map[string][]string{{"bearer": {}}},
+		MaxBodyBytes: maxImportBodyBytes,
+	}, func(ctx context.Context, input *ImportServersInput) (*Response[apiv0.ImportReport], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		entries := make([]*apiv0.ServerResponse, len(input.Body))
+		var invalid []error
+		for i, raw := range input.Body {
+			entry, err := importer.ParseSeedEntry(raw)
+			if err != nil {
+				invalid = append(invalid, &huma.ErrorDetail{Location: fmt.Sprintf("body[%d]", i), Message: err.Error()})
+				continue
+			}
+			entries[i] = entry
+		}
+		if len(invalid) > 0 {
+			return nil, huma.Error400BadRequest("Invalid seed entries", invalid...)
+		}
+
+		report, err := registry.ImportServers(ctx, entries, input.DryRun)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to import servers", err)
+		}
+		return &Response[apiv0.ImportReport]{Body: *report}, nil
+	})
+}
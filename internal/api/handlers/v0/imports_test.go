@@ -0,0 +1,127 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportServers(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterImportEndpoints(api, "/v0", registryService, testConfig)
+
+	token := func(pattern string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	adminToken := token("*")
+
+	importSeed := func(bearer, query string, seed []any) (*httptest.ResponseRecorder, apiv0.ImportReport) {
+		body, err := json.Marshal(seed)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/admin/import"+query, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var report apiv0.ImportReport
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		}
+		return w, report
+	}
+	server := func(name, version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Weather server", Version: version}
+	}
+	countServers := func() int {
+		servers, _, err := registryService.ListServers(context.Background(), nil, "", 100)
+		require.NoError(t, err)
+		return len(servers)
+	}
+
+	seed := []any{
+		apiv0.ServerResponse{
+			Server: server("io.github.test/weather", "1.0.0"),
+			Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{
+				Status:        model.StatusDeprecated,
+				StatusDetails: &apiv0.StatusDetails{ReplacedBy: "io.github.test/weather-v2"},
+			}},
+		},
+		server("io.github.test/weather-v2", "2.0.0"),
+	}
+
+	t.Run("admins only", func(t *testing.T) {
+		w, _ := importSeed(token("io.github.test/*"), "", seed)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("malformed entries are rejected", func(t *testing.T) {
+		w, _ := importSeed(adminToken, "", []any{server("io.github.test/weather", "1.0.0"), "not a server"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "body[1]")
+	})
+
+	t.Run("dry runs save nothing", func(t *testing.T) {
+		w, report := importSeed(adminToken, "?dryRun=true", seed)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.False(t, report.Applied)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, 0, countServers())
+	})
+
+	t.Run("a failing entry rolls back the batch", func(t *testing.T) {
+		failing := append(append([]any{}, seed...), server("io.github.test/weather-v2", "2.0.0"))
+		w, report := importSeed(adminToken, "", failing)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.False(t, report.Applied)
+		assert.Equal(t, 2, report.Created)
+		assert.Equal(t, 1, report.Failed)
+		require.Len(t, report.Results, 3)
+		assert.Equal(t, service.ImportResultFailed, report.Results[2].Status)
+		assert.NotEmpty(t, report.Results[2].Error)
+		assert.Equal(t, 0, countServers())
+	})
+
+	w, report := importSeed(adminToken, "", seed)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.True(t, report.Applied)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 2, countServers())
+
+	imported, err := registryService.GetServerByNameAndVersion(context.Background(), "io.github.test/weather", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, imported.Meta.Official.Status)
+	require.NotNil(t, imported.Meta.Official.StatusDetails)
+	assert.Equal(t, "io.github.test/weather-v2", imported.Meta.Official.StatusDetails.ReplacedBy)
+}
@@ -0,0 +1,77 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// JobsInput represents the input for listing background jobs
+type JobsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Status        string `query:"status" enum:"queued,running,succeeded,dead" default:"dead" doc:"Status of the jobs to list"`
+	Limit         int    `query:"limit" doc:"Number of jobs to return" default:"100" minimum:"1" maximum:"1000"`
+}
+
+// RetryJobInput represents the input for retrying a dead job
+type RetryJobInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ID            string `path:"id" doc:"Job ID"`
+}
+
+// RegisterJobEndpoints registers the background job endpoints with a custom path prefix
+func RegisterJobEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-jobs" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/jobs",
+		Summary:     "List background jobs",
+		Description: "List the background jobs with a status, most recently created first: by default the dead ones, which ran out of attempts (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *JobsInput) (*Response[apiv0.JobListResponse], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		jobs, err := registry.ListJobs(ctx, input.Status, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list jobs", err)
+		}
+		return &Response[apiv0.JobListResponse]{Body: apiv0.JobListResponse{Jobs: jobs}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "retry-job" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/jobs/{id}/retry",
+		Summary:     "Retry a dead job",
+		Description: "Queue a job that ran out of attempts again, with its attempts reset (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *RetryJobInput) (*Response[apiv0.Job], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		job, err := registry.RetryJob(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Dead job not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to retry job", err)
+		}
+		return &Response[apiv0.Job]{Body: *job}, nil
+	})
+}
@@ -0,0 +1,148 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ModerateServerInput represents the input for quarantining, reinstating, deleting or restoring a server
+type ModerateServerInput struct {
+	Authorization string                   `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ServerName    string                   `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          apiv0.ModerationDecision `doc:"Why the server is moderated"`
+}
+
+// ListModerationQueueInput represents the input for the moderation queue
+type ListModerationQueueInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Status        string `query:"status" doc:"Only return cases with this status, or every case with 'all'" default:"quarantined" enum:"quarantined,reinstated,deleted,all"`
+	Limit         int    `query:"limit" doc:"Number of cases to return" default:"30" minimum:"1" maximum:"100"`
+}
+
+// RegisterModerationEndpoints registers the admin moderation endpoints with a custom path prefix
+func RegisterModerationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	type moderationAction func(registry service.RegistryService, ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error)
+	moderate := func(action moderationAction) func(context.Context, *ModerateServerInput) (*Response[apiv0.ModerationCase], error) {
+		return func(ctx context.Context, input *ModerateServerInput) (*Response[apiv0.ModerationCase], error) {
+			moderator, err := authorizeModerator(ctx, jwtManager, input.Authorization)
+			if err != nil {
+				return nil, err
+			}
+			serverName, err := url.PathUnescape(input.ServerName)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+			}
+
+			moderationCase, err := action(registry, ctx, serverName, input.Body.Reason, moderator)
+			if err != nil {
+				switch {
+				case errors.Is(err, database.ErrNotFound):
+					return nil, huma.Error404NotFound("Server not found")
+				case errors.Is(err, database.ErrAlreadyExists):
+					return nil, huma.Error409Conflict("Server is already quarantined")
+				case errors.Is(err, service.ErrNotQuarantined):
+					return nil, huma.Error409Conflict("Server is not quarantined")
+				}
+				return nil, huma.Error500InternalServerError("Failed to moderate server", err)
+			}
+			return &Response[apiv0.ModerationCase]{Body: *moderationCase}, nil
+		}
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "quarantine-server" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/servers/{serverName}/quarantine",
+		Summary:     "Quarantine server",
+		Description: "Hide a server from listings and search while it is reviewed (admin only). Its versions can still be fetched by name, " +
+			"with the quarantine and its reason in their official metadata, and the namespace's subscribers are notified.",
+		Tags:     []string{"admin"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, moderate(service.RegistryService.QuarantineServer))
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reinstate-server" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/servers/{serverName}/reinstate",
+		Summary:     "Reinstate server",
+		Description: "Lift the quarantine of a server, listing it again (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, moderate(service.RegistryService.ReinstateServer))
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-quarantined-server" + operationSuffix,
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/admin/servers/{serverName}",
+		Summary:     "Delete quarantined server",
+		Description: "Delete every version of a quarantined server, hiding it from every read (admin only). It can be restored until " +
+			"it is purged with its reviews, advisories and redirects once the retention window passes, when the name can be published again.",
+		Tags:     []string{"admin"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, moderate(service.RegistryService.DeleteQuarantinedServer))
+
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-server" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/servers/{serverName}/restore",
+		Summary:     "Restore deleted server",
+		Description: "Bring back a deleted server that wasn't purged yet (admin only). It comes back quarantined, with a new case " +
+			"for the reason it was restored, until it is reinstated.",
+		Tags:     []string{"admin"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, moderate(service.RegistryService.RestoreServer))
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-moderation-queue" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/moderation",
+		Summary:     "List moderation queue",
+		Description: "Get the moderation cases of every server, most recent first, quarantined ones by default (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ListModerationQueueInput) (*Response[apiv0.ModerationCaseListResponse], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		status := input.Status
+		if status == "all" {
+			status = ""
+		}
+		cases, err := registry.ListModerationCases(ctx, status, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list moderation cases", err)
+		}
+		return &Response[apiv0.ModerationCaseListResponse]{
+			Body: apiv0.ModerationCaseListResponse{
+				Cases:    cases,
+				Metadata: apiv0.Metadata{Count: len(cases)},
+			},
+		}, nil
+	})
+}
+
+// authorizeModerator checks that a token belongs to a registry admin, returning who they are
+func authorizeModerator(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (apiv0.Principal, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return apiv0.Principal{}, err
+	}
+	if !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+		return apiv0.Principal{}, huma.Error403Forbidden("This endpoint requires registry admin permissions")
+	}
+	return principalFromClaims(claims), nil
+}
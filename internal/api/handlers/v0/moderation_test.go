@@ -0,0 +1,187 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), EnableRegistryValidation: false}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, name := range []string{"io.github.alice/weather", "io.github.alice/news"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterModerationEndpoints(api, "/v0", registryService, testConfig)
+
+	adminToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodOIDC,
+		AuthMethodSubject: "admin@example.com",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+	publisherToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+	})
+	require.NoError(t, err)
+
+	request := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var data []byte
+		if body != nil {
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listedNames := func(query string) []string {
+		w := request(http.MethodGet, "/v0/servers"+query, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		var names []string
+		for _, server := range response.Servers {
+			names = append(names, server.Server.Name)
+		}
+		return names
+	}
+	queue := func(status string) []apiv0.ModerationCase {
+		w := request(http.MethodGet, "/v0/admin/moderation?status="+status, adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response apiv0.ModerationCaseListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Cases
+	}
+	decision := apiv0.ModerationDecision{Reason: "Impersonates another server"}
+
+	t.Run("is admin only", func(t *testing.T) {
+		w := request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fweather/quarantine", publisherToken, decision)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/v0/admin/moderation", publisherToken, nil).Code)
+	})
+
+	t.Run("quarantine hides the server from listings", func(t *testing.T) {
+		w := request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fweather/quarantine", adminToken, decision)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var quarantined apiv0.ModerationCase
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &quarantined))
+		assert.Equal(t, service.ModerationQuarantined, quarantined.Status)
+		assert.Equal(t, "admin@example.com", quarantined.Moderator.Subject)
+
+		assert.Equal(t, []string{"io.github.alice/news"}, listedNames(""))
+		assert.ElementsMatch(t, []string{"io.github.alice/news", "io.github.alice/weather"}, listedNames("?include_quarantined=true"))
+
+		w = request(http.MethodGet, "/v0/servers/io.github.alice%2Fweather/versions/1.0.0", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		require.NotNil(t, server.Meta.Official.Quarantine)
+		assert.Equal(t, decision.Reason, server.Meta.Official.Quarantine.Reason)
+
+		assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fweather/quarantine", adminToken, decision).Code)
+		assert.Len(t, queue("quarantined"), 1)
+	})
+
+	t.Run("reinstate lists the server again", func(t *testing.T) {
+		w := request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fweather/reinstate", adminToken, apiv0.ModerationDecision{Reason: "Publisher proved ownership"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var reinstated apiv0.ModerationCase
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reinstated))
+		assert.Equal(t, service.ModerationReinstated, reinstated.Status)
+		assert.Equal(t, "Publisher proved ownership", reinstated.Resolution)
+		require.NotNil(t, reinstated.ResolvedAt)
+
+		assert.ElementsMatch(t, []string{"io.github.alice/news", "io.github.alice/weather"}, listedNames(""))
+		assert.Empty(t, queue("quarantined"))
+		assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fweather/reinstate", adminToken, decision).Code)
+	})
+
+	t.Run("delete requires quarantine and removes every version", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, request(http.MethodDelete, "/v0/admin/servers/io.github.alice%2Fnews", adminToken, decision).Code)
+
+		require.Equal(t, http.StatusOK, request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fnews/quarantine", adminToken, decision).Code)
+		w := request(http.MethodDelete, "/v0/admin/servers/io.github.alice%2Fnews", adminToken, apiv0.ModerationDecision{Reason: "Malware"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, request(http.MethodGet, "/v0/servers/io.github.alice%2Fnews/versions/1.0.0", "", nil).Code)
+		assert.Len(t, queue("deleted"), 1)
+		assert.Len(t, queue("all"), 2)
+	})
+
+	t.Run("deleted servers can be restored until they're purged", func(t *testing.T) {
+		republish := func() error {
+			_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.alice/news",
+				Description: "Takeover",
+				Version:     "2.0.0",
+			})
+			return err
+		}
+		require.ErrorIs(t, republish(), service.ErrServerAwaitingPurge, "the name stays reserved")
+
+		w := request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fnews/restore", adminToken, apiv0.ModerationDecision{Reason: "Deleted by mistake"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var restored apiv0.ModerationCase
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &restored))
+		assert.Equal(t, service.ModerationQuarantined, restored.Status, "restored servers stay quarantined")
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v0/servers/io.github.alice%2Fnews/versions/1.0.0", "", nil).Code)
+		assert.NotContains(t, listedNames(""), "io.github.alice/news")
+		assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fnews/restore", adminToken, decision).Code)
+
+		require.Equal(t, http.StatusOK, request(http.MethodDelete, "/v0/admin/servers/io.github.alice%2Fnews", adminToken, decision).Code)
+		purged, err := registryService.PurgeDeletedServers(context.Background(), time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 0, purged, "servers are kept for the retention window")
+		purged, err = registryService.PurgeDeletedServers(context.Background(), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fnews/restore", adminToken, decision).Code)
+		require.NoError(t, republish(), "purged names can be published again")
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		w := request(http.MethodPost, "/v0/admin/servers/io.github.alice%2Fmissing/quarantine", adminToken, decision)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
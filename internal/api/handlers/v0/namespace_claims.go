@@ -0,0 +1,163 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ClaimNamespaceInput represents the input for claiming a namespace
+type ClaimNamespaceInput struct {
+	Authorization string                      `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string                      `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+	Body          apiv0.NamespaceClaimRequest `doc:"How to prove control of the namespace"`
+}
+
+// ListNamespaceClaimsInput represents the input for listing the claims on a namespace
+type ListNamespaceClaimsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+}
+
+// NamespaceClaimInput represents the input for withdrawing a claim on a namespace
+type NamespaceClaimInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+	ID            string `path:"id" doc:"Claim ID"`
+}
+
+// RegisterNamespaceClaimEndpoints registers the namespace claim endpoints with a custom path prefix
+func RegisterNamespaceClaimEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "claim-namespace" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/claims",
+		Summary:     "Claim namespace",
+		Description: "Claim a namespace by proving control of what it is named after. " +
+			"A domain namespace such as com.example is claimed with a challenge token, published in a DNS TXT record (dns) or an HTTPS well-known file (http), " +
+			"which the registry looks for until it is found and keeps looking for afterwards. " +
+			"An io.github namespace is claimed with a GitHub token of the user or an organization member (github), and is verified straight away. " +
+			"A verified claim lets the claimant publish to the namespace like its owner until the claim expires. Claiming again starts the claim over.",
+		Tags:     []string{"namespaces"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ClaimNamespaceInput) (*Response[apiv0.NamespaceClaim], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		// The registry checks the proof of DNS and HTTP claims itself
+		if input.Body.Method == service.NamespaceClaimGitHub && strings.HasPrefix(input.Namespace, "io.github.") {
+			if err := checkGitHubClaimProof(jwtManager, claims, input.Namespace); err != nil {
+				return nil, err
+			}
+		}
+
+		claim, err := registry.ClaimNamespace(ctx, input.Namespace, input.Body.Method, principalFromClaims(claims))
+		if err != nil {
+			if errors.Is(err, service.ErrClaimMethodNotAllowed) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to claim namespace", err)
+		}
+		return &Response[apiv0.NamespaceClaim]{Body: *claim}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-claims" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/claims",
+		Summary:     "List namespace claims",
+		Description: "Get your claims on a namespace with their challenge and status, or every claim on it for registry admins.",
+		Tags:        []string{"namespaces"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ListNamespaceClaimsInput) (*Response[apiv0.NamespaceClaimListResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		var claimant *apiv0.Principal
+		if !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+			caller := principalFromClaims(claims)
+			claimant = &caller
+		}
+
+		namespaceClaims, err := registry.ListNamespaceClaims(ctx, input.Namespace, claimant)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespace claims", err)
+		}
+		return &Response[apiv0.NamespaceClaimListResponse]{
+			Body: apiv0.NamespaceClaimListResponse{
+				Claims:   namespaceClaims,
+				Metadata: apiv0.Metadata{Count: len(namespaceClaims)},
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "withdraw-namespace-claim" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/namespaces/{namespace}/claims/{id}",
+		Summary:       "Withdraw namespace claim",
+		Description:   "Withdraw a claim on a namespace (claimant or registry admin only). The namespace's verification is revoked too if the claim is what verified it.",
+		Tags:          []string{"namespaces"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *NamespaceClaimInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		claim, err := registry.GetNamespaceClaim(ctx, input.Namespace, input.ID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace claim not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get namespace claim", err)
+		}
+		caller := principalFromClaims(claims)
+		if claim.Claimant != caller && !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+			return nil, huma.Error403Forbidden("Only the claimant or a registry admin can withdraw this claim")
+		}
+
+		if err := registry.WithdrawNamespaceClaim(ctx, input.Namespace, input.ID, caller); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace claim not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to withdraw namespace claim", err)
+		}
+		return nil, nil
+	})
+}
+
+// checkGitHubClaimProof checks that a GitHub token proves control of an io.github namespace: the
+// publish permission for the whole namespace that signing in with GitHub granted, for the
+// caller's own login and the organizations they are a member of
+func checkGitHubClaimProof(jwtManager *auth.JWTManager, claims *auth.JWTClaims, namespace string) error {
+	if claims.AuthMethod != auth.MethodGitHubAT && claims.AuthMethod != auth.MethodGitHubOIDC {
+		return huma.Error400BadRequest("GitHub claims require a token obtained by signing in with GitHub")
+	}
+
+	// Global permissions come from being an admin rather than from proof
+	granted := slices.DeleteFunc(slices.Clone(claims.Permissions), func(perm auth.Permission) bool {
+		return perm.ResourcePattern == "*"
+	})
+	if !jwtManager.HasPermission(namespace+"/", auth.PermissionActionPublish, granted) {
+		return huma.Error403Forbidden("Your token doesn't prove control of namespace " + namespace)
+	}
+	return nil
+}
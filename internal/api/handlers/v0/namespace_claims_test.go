@@ -0,0 +1,142 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceClaims(t *testing.T) {
+	testConfig := newVerificationTestConfig(t)
+	testConfig.RequireNamespaceClaims = true
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterNamespaceClaimEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	token := func(claims auth.JWTClaims) string {
+		token, err := generateTestJWTToken(testConfig, claims)
+		require.NoError(t, err)
+		return token
+	}
+	domainToken := token(auth.JWTClaims{
+		AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.com",
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	})
+	aliceToken := token(auth.JWTClaims{
+		AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "alice",
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+	})
+	bobToken := token(auth.JWTClaims{
+		AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "bob",
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.bob/*"}},
+	})
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	claim := func(namespace, method, token string) *httptest.ResponseRecorder {
+		return call(http.MethodPost, "/v0/namespaces/"+namespace+"/claims", token, apiv0.NamespaceClaimRequest{Method: method})
+	}
+	publish := func(name, token string) int {
+		return call(http.MethodPost, "/v0/publish", token, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		}).Code
+	}
+
+	t.Run("method must fit the namespace", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, claim("com.example", service.NamespaceClaimGitHub, aliceToken).Code)
+		assert.Equal(t, http.StatusBadRequest, claim("io.github.alice", service.NamespaceClaimDNS, aliceToken).Code)
+		assert.Equal(t, http.StatusForbidden, claim("io.github.bob", service.NamespaceClaimGitHub, aliceToken).Code)
+	})
+
+	t.Run("domain claim grants publishing once its proof is found", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, publish("com.example/weather", domainToken))
+
+		w := claim("com.example", service.NamespaceClaimDNS, domainToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var pending apiv0.NamespaceClaim
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pending))
+		assert.Equal(t, service.NamespaceClaimPending, pending.Status)
+		require.NotNil(t, pending.Challenge)
+		assert.Equal(t, "_mcp-registry-challenge.example.com", pending.Challenge.RecordName)
+		assert.Equal(t, "mcp-registry-challenge="+pending.Challenge.Token, pending.Challenge.RecordValue)
+
+		// What the verifier does once it finds the TXT record
+		due, err := registryService.LeaseNamespaceClaimCheck(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, pending.ID, due.ID)
+		require.NoError(t, registryService.RecordNamespaceClaimCheck(context.Background(), due, nil))
+
+		assert.Equal(t, http.StatusOK, publish("com.example/weather", domainToken))
+
+		namespace, err := registryService.GetNamespace(context.Background(), "com.example")
+		require.NoError(t, err)
+		assert.Equal(t, []apiv0.Principal{{AuthMethod: "dns", Subject: "example.com"}}, namespace.Claimants)
+		require.NotNil(t, namespace.Verification)
+		assert.Equal(t, service.NamespaceVerificationDomain, namespace.Verification.Method)
+	})
+
+	t.Run("GitHub claim is verified straight away", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, publish("io.github.alice/weather", aliceToken))
+
+		w := claim("io.github.alice", service.NamespaceClaimGitHub, aliceToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var verified apiv0.NamespaceClaim
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verified))
+		assert.Equal(t, service.NamespaceClaimVerified, verified.Status)
+		assert.Nil(t, verified.Challenge)
+
+		assert.Equal(t, http.StatusOK, publish("io.github.alice/weather", aliceToken))
+	})
+
+	t.Run("only the claimant sees and withdraws their claims", func(t *testing.T) {
+		w := call(http.MethodGet, "/v0/namespaces/io.github.alice/claims", bobToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var others apiv0.NamespaceClaimListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &others))
+		assert.Empty(t, others.Claims)
+
+		w = call(http.MethodGet, "/v0/namespaces/io.github.alice/claims", aliceToken, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var own apiv0.NamespaceClaimListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &own))
+		require.Len(t, own.Claims, 1)
+
+		path := "/v0/namespaces/io.github.alice/claims/" + own.Claims[0].ID
+		assert.Equal(t, http.StatusForbidden, call(http.MethodDelete, path, bobToken, nil).Code)
+		assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, path, aliceToken, nil).Code)
+		assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, path, aliceToken, nil).Code)
+
+		assert.Equal(t, http.StatusForbidden, publish("io.github.alice/weather", aliceToken))
+	})
+}
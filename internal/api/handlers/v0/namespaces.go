@@ -0,0 +1,474 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// NamespaceInput represents the input for looking up a namespace
+type NamespaceInput struct {
+	Namespace string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+}
+
+// NamespaceAuditInput represents the input for reading a namespace's audit log
+type NamespaceAuditInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the namespace owner" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+	Limit         int    `query:"limit" doc:"Number of entries to return" default:"100" minimum:"1" maximum:"1000"`
+}
+
+// NamespaceChangeInput represents the input for requesting a namespace transfer or delegation
+type NamespaceChangeInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the namespace owner" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+	Body          struct {
+		To apiv0.Principal `json:"to" doc:"Principal who must accept the request"`
+	}
+}
+
+// RevokeDelegationInput represents the input for revoking a delegation
+type RevokeDelegationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the namespace owner or of the delegate" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+	AuthMethod    string `path:"authMethod" doc:"Authentication method of the delegate" example:"github-at"`
+	Subject       string `path:"subject" doc:"URL-encoded subject of the delegate" example:"octocat"`
+}
+
+// NamespaceRequestsInput represents the input for listing the caller's pending requests
+type NamespaceRequestsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+}
+
+// NamespaceRequestInput represents the input for accepting or declining a request
+type NamespaceRequestInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the requester or recipient" required:"true"`
+	ID            string `path:"id" doc:"Request ID"`
+}
+
+// RegisterNamespaceEndpoints registers the namespace transfer and delegation endpoints with a custom path prefix
+func RegisterNamespaceEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}",
+		Summary:     "Get namespace",
+		Description: "Get the recorded owner of a namespace and the principals it has delegated publish rights to.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *NamespaceInput) (*Response[apiv0.NamespaceResponse], error) {
+		namespace, err := registry.GetNamespace(ctx, input.Namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+		}
+		return &Response[apiv0.NamespaceResponse]{Body: *namespace}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-audit-log" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/audit",
+		Summary:     "Get namespace audit log",
+		Description: "List the transfers, delegations and revocations recorded for a namespace (owner only).",
+		Tags:        []string{"namespaces"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *NamespaceAuditInput) (*Response[apiv0.NamespaceAuditResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := registry.GetNamespace(ctx, input.Namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+		}
+		owner, err := ownsNamespace(ctx, registry, jwtManager, claims, namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+		}
+		if !owner && !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+			return nil, huma.Error403Forbidden("Only the owner of " + input.Namespace + " can read its audit log")
+		}
+
+		entries, err := registry.GetNamespaceAuditLog(ctx, input.Namespace, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get namespace audit log", err)
+		}
+		return &Response[apiv0.NamespaceAuditResponse]{Body: apiv0.NamespaceAuditResponse{Entries: entries}}, nil
+	})
+
+	for _, kind := range []string{service.NamespaceRequestTransfer, service.NamespaceRequestDelegation} {
+		summary, description := "Request namespace transfer",
+			"Propose transferring ownership of a namespace to another principal. The transfer takes effect once the recipient accepts it."
+		if kind == service.NamespaceRequestDelegation {
+			summary, description = "Request publish delegation",
+				"Propose giving another principal the right to publish servers in a namespace. The delegation takes effect once the recipient accepts it."
+		}
+
+		huma.Register(api, huma.Operation{
+			OperationID: "request-namespace-" + kind + operationSuffix,
+			Method:      http.MethodPost,
+			Path:        pathPrefix + "/namespaces/{namespace}/" + kind + "s",
+			Summary:     summary,
+			Description: description,
+			Tags:        []string{"namespaces"},
+			Security:    []map[string][]string{{"bearer": {}}},
+		}, func(ctx context.Context, input *NamespaceChangeInput) (*Response[apiv0.NamespaceRequest], error) {
+			claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+			if err != nil {
+				return nil, err
+			}
+			namespace, err := registry.GetNamespace(ctx, input.Namespace)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+			}
+			owner, err := ownsNamespace(ctx, registry, jwtManager, claims, namespace)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+			}
+			if !owner {
+				return nil, huma.Error403Forbidden("Only the owner of " + input.Namespace + " can " + kind + " it")
+			}
+			if kind == service.NamespaceRequestTransfer && namespace.Organization != "" {
+				return nil, huma.Error409Conflict("Namespace " + input.Namespace + " belongs to organization " + namespace.Organization +
+					". Remove it from the organization before transferring it")
+			}
+
+			req, err := registry.RequestNamespaceChange(ctx, kind, input.Namespace, principalFromClaims(claims), input.Body.To)
+			if err != nil {
+				return nil, namespaceRequestError(err)
+			}
+			return &Response[apiv0.NamespaceRequest]{Body: *req}, nil
+		})
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-namespace-delegation" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/namespaces/{namespace}/delegations/{authMethod}/{subject}",
+		Summary:       "Revoke publish delegation",
+		Description:   "Remove a principal's delegated publish rights to a namespace. The namespace owner can revoke any delegation; a delegate can give up their own.",
+		Tags:          []string{"namespaces"},
+		DefaultStatus: http.StatusNoContent,
+		Security:      []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *RevokeDelegationInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := url.PathUnescape(input.Subject)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid subject encoding", err)
+		}
+		delegate := apiv0.Principal{AuthMethod: input.AuthMethod, Subject: subject}
+
+		namespace, err := registry.GetNamespace(ctx, input.Namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+		}
+		caller := principalFromClaims(claims)
+		if caller != delegate {
+			owner, err := ownsNamespace(ctx, registry, jwtManager, claims, namespace)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+			}
+			if !owner {
+				return nil, huma.Error403Forbidden("Only the owner of " + input.Namespace + " or the delegate can revoke a delegation")
+			}
+		}
+
+		if err := registry.RevokeNamespaceDelegate(ctx, input.Namespace, delegate, caller); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Delegation not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke delegation", err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-requests" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespace-requests",
+		Summary:     "List namespace requests",
+		Description: "List the pending transfer and delegation requests made by or addressed to the caller.",
+		Tags:        []string{"namespaces"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *NamespaceRequestsInput) (*Response[apiv0.NamespaceRequestListResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		requests, err := registry.ListNamespaceRequests(ctx, principalFromClaims(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespace requests", err)
+		}
+		return &Response[apiv0.NamespaceRequestListResponse]{Body: apiv0.NamespaceRequestListResponse{Requests: requests}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-namespace-request" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespace-requests/{id}/accept",
+		Summary:     "Accept namespace request",
+		Description: "Accept a transfer or delegation request addressed to the caller, which applies it.",
+		Tags:        []string{"namespaces"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *NamespaceRequestInput) (*Response[apiv0.NamespaceRequest], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		req, err := registry.AcceptNamespaceRequest(ctx, input.ID, principalFromClaims(claims))
+		if err != nil {
+			return nil, namespaceRequestError(err)
+		}
+		return &Response[apiv0.NamespaceRequest]{Body: *req}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "decline-namespace-request" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespace-requests/{id}/decline",
+		Summary:     "Decline namespace request",
+		Description: "Decline a request addressed to the caller, or cancel one the caller made.",
+		Tags:        []string{"namespaces"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *NamespaceRequestInput) (*Response[apiv0.NamespaceRequest], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		req, err := registry.DeclineNamespaceRequest(ctx, input.ID, principalFromClaims(claims))
+		if err != nil {
+			return nil, namespaceRequestError(err)
+		}
+		return &Response[apiv0.NamespaceRequest]{Body: *req}, nil
+	})
+}
+
+// validateBearerToken extracts and validates the Registry JWT from an Authorization header.
+// Anonymous tokens are rejected: they don't identify anyone who could own a namespace.
+func validateBearerToken(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx, authHeader[len(bearerPrefix):])
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+	if claims.AuthMethod == auth.MethodNone {
+		return nil, huma.Error403Forbidden("Anonymous tokens cannot manage namespaces")
+	}
+	if claims.PersonalAccessTokenID != "" {
+		return nil, huma.Error403Forbidden("Tokens obtained with a personal access token can only publish and edit servers")
+	}
+	return claims, nil
+}
+
+// principalFromClaims identifies who a token was issued to
+func principalFromClaims(claims *auth.JWTClaims) apiv0.Principal {
+	return apiv0.Principal{AuthMethod: string(claims.AuthMethod), Subject: claims.AuthMethodSubject}
+}
+
+// namespaceRole works out what a token may do in a namespace, expressed as an organization role:
+//   - in an organization's namespace, the caller's role in that organization
+//   - in a transferred namespace, owner for the recorded owner
+//   - otherwise owner for principals with a verified claim on the namespace
+//   - unless the registry requires claims, owner for whoever the token grants the whole
+//     namespace to, and publisher for tokens that only grant the server being published
+//     (serverName may be empty)
+//
+// Delegates are publishers in any namespace.
+func namespaceRole(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager,
+	claims *auth.JWTClaims, namespace *apiv0.NamespaceResponse, serverName string,
+) (string, error) {
+	caller := principalFromClaims(claims)
+
+	var role string
+	switch {
+	case namespace.Organization != "":
+		org, err := registry.GetOrganization(ctx, namespace.Organization)
+		if err != nil {
+			return "", err
+		}
+		role = service.MemberRole(org, caller)
+	case namespace.Owner != nil:
+		if *namespace.Owner == caller {
+			role = service.OrganizationRoleOwner
+		}
+	case slices.Contains(namespace.Claimants, caller):
+		role = service.OrganizationRoleOwner
+	case namespace.ClaimRequired:
+		// Without a verified claim, a token that grants the namespace isn't enough
+	case jwtManager.HasPermission(namespace.Namespace+"/*", auth.PermissionActionPublish, claims.Permissions):
+		role = service.OrganizationRoleOwner
+	case serverName != "" && jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions):
+		role = service.OrganizationRolePublisher
+	}
+
+	if !service.RoleAtLeast(role, service.OrganizationRolePublisher) && slices.Contains(namespace.Delegates, caller) {
+		role = service.OrganizationRolePublisher
+	}
+	return role, nil
+}
+
+// ownsNamespace reports whether a token belongs to an owner of a namespace
+func ownsNamespace(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, claims *auth.JWTClaims, namespace *apiv0.NamespaceResponse) (bool, error) {
+	role, err := namespaceRole(ctx, registry, jwtManager, claims, namespace, "")
+	return role == service.OrganizationRoleOwner, err
+}
+
+// canPublish reports whether a token may publish a server, taking namespace transfers,
+// delegations and organizations into account. Admins with global permissions may always publish.
+// When publishing isn't allowed, the returned message explains why.
+func canPublish(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, claims *auth.JWTClaims, serverName string) (bool, string, error) {
+	if !withinTokenScope(jwtManager, claims, serverName) {
+		return false, buildPermissionErrorMessage(serverName, claims.Permissions), nil
+	}
+	if hasGlobalPermission(claims, auth.PermissionActionPublish) {
+		return true, "", nil
+	}
+
+	namespaceName, _, _ := strings.Cut(serverName, "/")
+	namespace, err := registry.GetNamespace(ctx, namespaceName)
+	if err != nil {
+		return false, "", err
+	}
+	role, err := namespaceRole(ctx, registry, jwtManager, claims, namespace, serverName)
+	if err != nil {
+		return false, "", err
+	}
+	if service.RoleAtLeast(role, service.OrganizationRolePublisher) {
+		return true, "", nil
+	}
+
+	switch {
+	case namespace.Organization != "":
+		return false, fmt.Sprintf("You do not have permission to publish this server. Namespace %s belongs to organization %s, "+
+			"where publishing requires the publisher or owner role", namespaceName, namespace.Organization), nil
+	case namespace.Owner != nil:
+		return false, fmt.Sprintf("You do not have permission to publish this server. Namespace %s has been transferred to %s:%s",
+			namespaceName, namespace.Owner.AuthMethod, namespace.Owner.Subject), nil
+	case namespace.ClaimRequired:
+		return false, fmt.Sprintf("You do not have permission to publish this server. Publishing to namespace %s requires a verified claim, "+
+			"see POST /v0/namespaces/%s/claims", namespaceName, namespaceName), nil
+	default:
+		return false, buildPermissionErrorMessage(serverName, claims.Permissions), nil
+	}
+}
+
+// canEdit reports whether a token may edit a server. Admins with edit permissions may edit any
+// server; in an organization's namespace, publishers may also edit servers and owners may
+// delete them, provided a personal access token's scopes cover the server. Elsewhere, whoever
+// may publish the server may edit it but not delete it, which the service only allows its
+// original publisher unless they may override that (see serverPublisher).
+func canEdit(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, claims *auth.JWTClaims, serverName string, deleting bool) (bool, error) {
+	if jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions) {
+		return true, nil
+	}
+
+	if !withinTokenScope(jwtManager, claims, serverName) {
+		return false, nil
+	}
+
+	namespaceName, _, _ := strings.Cut(serverName, "/")
+	namespace, err := registry.GetNamespace(ctx, namespaceName)
+	if err != nil {
+		return false, err
+	}
+	role, err := namespaceRole(ctx, registry, jwtManager, claims, namespace, serverName)
+	if err != nil {
+		return false, err
+	}
+	if namespace.Organization == "" {
+		return !deleting && service.RoleAtLeast(role, service.OrganizationRolePublisher), nil
+	}
+	if deleting {
+		return role == service.OrganizationRoleOwner, nil
+	}
+	return service.RoleAtLeast(role, service.OrganizationRolePublisher), nil
+}
+
+// serverPublisher is who a token publishes or edits a server as. Only the server's original
+// publisher may do so, unless the token belongs to an admin or to an owner of the namespace as
+// the registry records it: an owner of the organization owning it, the owner it was transferred
+// to, or a verified claimant. Organization members, delegates and tokens that grant a namespace
+// to everyone in a GitHub organization thus can't take over each other's servers.
+func serverPublisher(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims, serverName string, admin bool) (*service.Publisher, error) {
+	caller := principalFromClaims(claims)
+	if admin {
+		return &service.Publisher{Principal: caller, Override: true}, nil
+	}
+
+	namespaceName, _, _ := strings.Cut(serverName, "/")
+	namespace, err := registry.GetNamespace(ctx, namespaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var owner bool
+	switch {
+	case namespace.Organization != "":
+		org, err := registry.GetOrganization(ctx, namespace.Organization)
+		if err != nil {
+			return nil, err
+		}
+		owner = service.MemberRole(org, caller) == service.OrganizationRoleOwner
+	case namespace.Owner != nil:
+		owner = *namespace.Owner == caller
+	default:
+		owner = slices.Contains(namespace.Claimants, caller)
+	}
+	return &service.Publisher{Principal: caller, Override: owner}, nil
+}
+
+// withinTokenScope reports whether a server is within the publish scopes of a token exchanged
+// for a personal access token. Namespace roles apply on top of the scopes rather than widening
+// them. Other tokens aren't limited by scopes.
+func withinTokenScope(jwtManager *auth.JWTManager, claims *auth.JWTClaims, serverName string) bool {
+	return claims.PersonalAccessTokenID == "" ||
+		jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions)
+}
+
+func hasGlobalPermission(claims *auth.JWTClaims, action auth.PermissionAction) bool {
+	for _, perm := range claims.Permissions {
+		if perm.Action == action && perm.ResourcePattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceRequestError maps service errors for namespace requests to HTTP errors
+func namespaceRequestError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Namespace request not found")
+	case errors.Is(err, service.ErrSelfNamespaceRequest):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, service.ErrNotRequestRecipient), errors.Is(err, service.ErrNotRequestParty):
+		return huma.Error403Forbidden(err.Error())
+	case errors.Is(err, service.ErrRequestNotPending), errors.Is(err, service.ErrNamespaceOwnerChanged):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, service.ErrRequestExpired):
+		return huma.Error410Gone(err.Error())
+	default:
+		return huma.Error500InternalServerError("Failed to process namespace request", err)
+	}
+}
@@ -0,0 +1,173 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceTransferAndDelegation(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	githubToken := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + login + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob, carol := githubToken("alice"), githubToken("bob"), githubToken("carol")
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	request := func(kind, token, to string) apiv0.NamespaceRequest {
+		w := call(http.MethodPost, "/v0/namespaces/io.github.alice/"+kind, token,
+			map[string]any{"to": apiv0.Principal{AuthMethod: "github-at", Subject: to}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var req apiv0.NamespaceRequest
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &req))
+		return req
+	}
+	publish := func(token, version string) int {
+		return call(http.MethodPost, "/v0/publish", token, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather server",
+			Version:     version,
+		}).Code
+	}
+
+	t.Run("only the owner can request a transfer", func(t *testing.T) {
+		w := call(http.MethodPost, "/v0/namespaces/io.github.alice/transfers", bob,
+			map[string]any{"to": apiv0.Principal{AuthMethod: "github-at", Subject: "bob"}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("transfer takes effect once the recipient accepts", func(t *testing.T) {
+		transfer := request("transfers", alice, "bob")
+		assert.Equal(t, service.NamespaceRequestPending, transfer.Status)
+
+		// Still pending: alice owns the namespace, bob can't publish to it
+		assert.Equal(t, http.StatusForbidden, publish(bob, "1.0.0"))
+
+		w := call(http.MethodGet, "/v0/namespace-requests", bob, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var pending apiv0.NamespaceRequestListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pending))
+		require.Len(t, pending.Requests, 1)
+		assert.Equal(t, transfer.ID, pending.Requests[0].ID)
+
+		// The requester can't confirm on the recipient's behalf
+		w = call(http.MethodPost, "/v0/namespace-requests/"+transfer.ID+"/accept", alice, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = call(http.MethodPost, "/v0/namespace-requests/"+transfer.ID+"/accept", bob, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = call(http.MethodPost, "/v0/namespace-requests/"+transfer.ID+"/accept", bob, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		assert.Equal(t, http.StatusForbidden, publish(alice, "1.0.0"))
+		assert.Equal(t, http.StatusOK, publish(bob, "1.0.0"))
+	})
+
+	t.Run("delegation grants publish rights until revoked", func(t *testing.T) {
+		delegation := request("delegations", bob, "carol")
+		w := call(http.MethodPost, "/v0/namespace-requests/"+delegation.ID+"/accept", carol, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = call(http.MethodGet, "/v0/namespaces/io.github.alice", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var namespace apiv0.NamespaceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &namespace))
+		require.NotNil(t, namespace.Owner)
+		assert.Equal(t, "bob", namespace.Owner.Subject)
+		assert.Equal(t, []apiv0.Principal{{AuthMethod: "github-at", Subject: "carol"}}, namespace.Delegates)
+
+		assert.Equal(t, http.StatusOK, publish(carol, "1.1.0"))
+
+		w = call(http.MethodDelete, "/v0/namespaces/io.github.alice/delegations/github-at/carol", alice, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		w = call(http.MethodDelete, "/v0/namespaces/io.github.alice/delegations/github-at/carol", bob, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		assert.Equal(t, http.StatusForbidden, publish(carol, "1.2.0"))
+	})
+
+	t.Run("declined requests are not applied", func(t *testing.T) {
+		delegation := request("delegations", bob, "alice")
+		w := call(http.MethodPost, "/v0/namespace-requests/"+delegation.ID+"/decline", alice, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var declined apiv0.NamespaceRequest
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &declined))
+		assert.Equal(t, service.NamespaceRequestDeclined, declined.Status)
+
+		assert.Equal(t, http.StatusForbidden, publish(alice, "1.2.0"))
+	})
+
+	t.Run("audit log records every step", func(t *testing.T) {
+		w := call(http.MethodGet, "/v0/namespaces/io.github.alice/audit", alice, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = call(http.MethodGet, "/v0/namespaces/io.github.alice/audit", bob, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var audit apiv0.NamespaceAuditResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &audit))
+
+		var actions []string
+		for _, entry := range audit.Entries {
+			actions = append(actions, entry.Action)
+		}
+		assert.Equal(t, []string{
+			"delegation-declined",
+			"delegation-requested",
+			"delegation-revoked",
+			"delegation-accepted",
+			"delegation-requested",
+			"transfer-accepted",
+			"transfer-requested",
+		}, actions)
+	})
+}
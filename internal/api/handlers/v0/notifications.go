@@ -0,0 +1,182 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// NotificationSubscriptionsInput represents the input for listing the caller's subscriptions for a namespace
+type NotificationSubscriptionsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a namespace owner" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+}
+
+// CreateNotificationSubscriptionInput represents the input for subscribing to events in a namespace
+type CreateNotificationSubscriptionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a namespace owner" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+	Body          struct {
+		Channel string   `json:"channel" enum:"email,webhook" doc:"How notifications are delivered"`
+		Target  string   `json:"target" maxLength:"2048" doc:"Email address or HTTPS webhook URL" example:"https://example.com/hooks/mcp-registry"`
+		Events  []string `json:"events,omitempty" doc:"Events to be notified of. Defaults to all of them."`
+		Secret  string   `json:"secret,omitempty" maxLength:"255" doc:"Secret to sign webhook deliveries with, sent as an HMAC-SHA256 in the X-MCP-Registry-Signature header"`
+	}
+}
+
+// DeleteNotificationSubscriptionInput represents the input for removing a subscription
+type DeleteNotificationSubscriptionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the subscription's owner" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+	ID            string `path:"id" doc:"Subscription ID"`
+}
+
+// RegisterNotificationEndpoints registers the namespace notification endpoints with a custom path prefix
+func RegisterNotificationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-notification-subscriptions" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/notifications",
+		Summary:     "List notification subscriptions",
+		Description: "List the caller's notification subscriptions for a namespace they own.",
+		Tags:        []string{"namespaces"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *NotificationSubscriptionsInput) (*Response[apiv0.NotificationSubscriptionListResponse], error) {
+		claims, err := authorizeNamespaceOwner(ctx, registry, jwtManager, input.Authorization, input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions, err := registry.ListNotificationSubscriptions(ctx, input.Namespace, principalFromClaims(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list notification subscriptions", err)
+		}
+		return &Response[apiv0.NotificationSubscriptionListResponse]{
+			Body: apiv0.NotificationSubscriptionListResponse{Subscriptions: subscriptions},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-notification-subscription" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/notifications",
+		Summary:     "Subscribe to namespace notifications",
+		Description: "Get notified by email or webhook of events in a namespace the caller owns: " +
+			strings.Join(notifications.EventTypes, ", ") + ".",
+		Tags:     []string{"namespaces"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *CreateNotificationSubscriptionInput) (*Response[apiv0.NotificationSubscription], error) {
+		claims, err := authorizeNamespaceOwner(ctx, registry, jwtManager, input.Authorization, input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		events := input.Body.Events
+		if len(events) == 0 {
+			events = notifications.EventTypes
+		}
+		for _, event := range events {
+			if !slices.Contains(notifications.EventTypes, event) {
+				return nil, huma.Error400BadRequest("Unknown event " + event + ". Events are: " + strings.Join(notifications.EventTypes, ", "))
+			}
+		}
+		if err := validateNotificationTarget(cfg, input.Body.Channel, input.Body.Target); err != nil {
+			return nil, err
+		}
+
+		sub, err := registry.CreateNotificationSubscription(ctx, &apiv0.NotificationSubscription{
+			Namespace: input.Namespace,
+			Owner:     principalFromClaims(claims),
+			Channel:   input.Body.Channel,
+			Target:    input.Body.Target,
+			Events:    events,
+			Secret:    input.Body.Secret,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to create notification subscription", err)
+		}
+		return &Response[apiv0.NotificationSubscription]{Body: *sub}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-notification-subscription" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/namespaces/{namespace}/notifications/{id}",
+		Summary:       "Unsubscribe from namespace notifications",
+		Description:   "Remove one of the caller's notification subscriptions. This works even after the caller stopped owning the namespace.",
+		Tags:          []string{"namespaces"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteNotificationSubscriptionInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteNotificationSubscription(ctx, input.Namespace, input.ID, principalFromClaims(claims)); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Notification subscription not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete notification subscription", err)
+		}
+		return nil, nil
+	})
+}
+
+// authorizeNamespaceOwner validates the token and checks that it belongs to an owner of the namespace
+func authorizeNamespaceOwner(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, authHeader, namespaceName string,
+) (*auth.JWTClaims, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, err := registry.GetNamespace(ctx, namespaceName)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+	}
+	owner, err := ownsNamespace(ctx, registry, jwtManager, claims, namespace)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+	}
+	if !owner {
+		return nil, huma.Error403Forbidden("Only owners of " + namespaceName + " can manage its notifications")
+	}
+	return claims, nil
+}
+
+// validateNotificationTarget checks that a target is a valid address for its channel
+func validateNotificationTarget(cfg *config.Config, channel, target string) error {
+	switch channel {
+	case notifications.ChannelEmail:
+		if !notifications.EmailEnabled(cfg) {
+			return huma.Error400BadRequest("Email notifications are not enabled on this registry; use a webhook instead")
+		}
+		address, err := mail.ParseAddress(target)
+		if err != nil || address.Address != target {
+			return huma.Error400BadRequest("Target must be a plain email address such as owner@example.com")
+		}
+	case notifications.ChannelWebhook:
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return huma.Error400BadRequest("Target must be an https:// webhook URL")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSubscriptions(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNotificationEndpoints(api, "/v0", registryService, testConfig)
+
+	githubToken := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + login + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob := githubToken("alice"), githubToken("bob")
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	const path = "/v0/namespaces/io.github.alice/notifications"
+
+	t.Run("only owners and valid targets", func(t *testing.T) {
+		webhook := map[string]any{"channel": "webhook", "target": "https://example.com/hook"}
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPost, path, bob, webhook).Code)
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPost, path, alice,
+			map[string]any{"channel": "webhook", "target": "http://example.com/hook"}).Code)
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPost, path, alice,
+			map[string]any{"channel": "email", "target": "alice@example.com"}).Code, "email is not configured")
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPost, path, alice,
+			map[string]any{"channel": "webhook", "target": "https://example.com/hook", "events": []string{"server-starred"}}).Code)
+	})
+
+	w := call(http.MethodPost, path, alice, map[string]any{"channel": "webhook", "target": "https://example.com/hook", "secret": "s3cret"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "s3cret")
+	var sub apiv0.NotificationSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sub))
+	assert.Len(t, sub.Events, 4, "defaults to every event")
+
+	w = call(http.MethodGet, path, alice, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var list apiv0.NotificationSubscriptionListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Subscriptions, 1)
+	assert.Equal(t, sub.ID, list.Subscriptions[0].ID)
+
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, path+"/"+sub.ID, bob, nil).Code)
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, path+"/"+sub.ID, alice, nil).Code)
+}
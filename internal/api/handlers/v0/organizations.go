@@ -0,0 +1,263 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// CreateOrganizationInput represents the input for creating an organization
+type CreateOrganizationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the first owner" required:"true"`
+	Body          struct {
+		Name string `json:"name" pattern:"^[a-z0-9][a-z0-9-]{1,62}$" doc:"Organization name: lowercase letters, digits and hyphens" example:"acme"`
+	}
+}
+
+// OrganizationInput represents the input for reading an organization
+type OrganizationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a member" required:"true"`
+	Organization  string `path:"organization" doc:"Organization name" example:"acme"`
+}
+
+// OrganizationMemberInput represents the input for adding, changing or removing a member
+type OrganizationMemberInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an owner" required:"true"`
+	Organization  string `path:"organization" doc:"Organization name" example:"acme"`
+	AuthMethod    string `path:"authMethod" enum:"github-at,github-oidc,oidc,dns,http" doc:"Authentication method of the member" example:"github-at"`
+	Subject       string `path:"subject" doc:"URL-encoded subject of the member" example:"octocat"`
+}
+
+// SetOrganizationMemberInput represents the input for adding a member or changing their role
+type SetOrganizationMemberInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an owner" required:"true"`
+	Organization  string `path:"organization" doc:"Organization name" example:"acme"`
+	AuthMethod    string `path:"authMethod" enum:"github-at,github-oidc,oidc,dns,http" doc:"Authentication method of the member" example:"github-at"`
+	Subject       string `path:"subject" doc:"URL-encoded subject of the member" example:"octocat"`
+	Body          struct {
+		Role string `json:"role" enum:"owner,publisher,viewer" doc:"Role to give the member"`
+	}
+}
+
+// OrganizationNamespaceInput represents the input for adding a namespace to or removing it from an organization
+type OrganizationNamespaceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an owner" required:"true"`
+	Organization  string `path:"organization" doc:"Organization name" example:"acme"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"com.acme"`
+}
+
+// RegisterOrganizationEndpoints registers the organization endpoints with a custom path prefix
+func RegisterOrganizationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-organization" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/organizations",
+		Summary:     "Create organization",
+		Description: "Create an organization, with the caller as its first owner.",
+		Tags:        []string{"organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *CreateOrganizationInput) (*Response[apiv0.Organization], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		org, err := registry.CreateOrganization(ctx, input.Body.Name, principalFromClaims(claims))
+		if err != nil {
+			return nil, organizationError(err)
+		}
+		return &Response[apiv0.Organization]{Body: *org}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-organization" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/organizations/{organization}",
+		Summary:     "Get organization",
+		Description: "Get an organization's members and namespaces (members only).",
+		Tags:        []string{"organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *OrganizationInput) (*Response[apiv0.Organization], error) {
+		org, _, err := authorizeOrganization(ctx, registry, jwtManager, input.Authorization, input.Organization, service.OrganizationRoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		return &Response[apiv0.Organization]{Body: *org}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-organization-member" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/organizations/{organization}/members/{authMethod}/{subject}",
+		Summary:     "Set organization member",
+		Description: "Add a member to an organization or change their role (owners only).",
+		Tags:        []string{"organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetOrganizationMemberInput) (*Response[apiv0.Organization], error) {
+		_, _, err := authorizeOrganization(ctx, registry, jwtManager, input.Authorization, input.Organization, service.OrganizationRoleOwner)
+		if err != nil {
+			return nil, err
+		}
+		member, err := memberPrincipal(input.AuthMethod, input.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		org, err := registry.SetOrganizationMember(ctx, input.Organization, apiv0.OrganizationMember{Principal: member, Role: input.Body.Role})
+		if err != nil {
+			return nil, organizationError(err)
+		}
+		return &Response[apiv0.Organization]{Body: *org}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-organization-member" + operationSuffix,
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/organizations/{organization}/members/{authMethod}/{subject}",
+		Summary:     "Remove organization member",
+		Description: "Remove a member from an organization. Owners can remove anyone; other members can only leave.",
+		Tags:        []string{"organizations"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *OrganizationMemberInput) (*Response[apiv0.Organization], error) {
+		current, claims, err := authorizeOrganization(ctx, registry, jwtManager, input.Authorization, input.Organization, service.OrganizationRoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		member, err := memberPrincipal(input.AuthMethod, input.Subject)
+		if err != nil {
+			return nil, err
+		}
+		caller := principalFromClaims(claims)
+		if member != caller && service.MemberRole(current, caller) != service.OrganizationRoleOwner &&
+			!hasGlobalPermission(claims, auth.PermissionActionEdit) {
+			return nil, huma.Error403Forbidden("Only owners can remove other members of organization " + input.Organization)
+		}
+
+		org, err := registry.RemoveOrganizationMember(ctx, input.Organization, member)
+		if err != nil {
+			return nil, organizationError(err)
+		}
+		return &Response[apiv0.Organization]{Body: *org}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "add-organization-namespace" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/organizations/{organization}/namespaces/{namespace}",
+		Summary:     "Add namespace to organization",
+		Description: "Hand a namespace over to an organization, after which the members' roles decide who may publish, edit and delete its servers. " +
+			"The caller must own both the organization and the namespace.",
+		Tags:     []string{"organizations"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *OrganizationNamespaceInput) (*Response[apiv0.Organization], error) {
+		_, claims, err := authorizeOrganization(ctx, registry, jwtManager, input.Authorization, input.Organization, service.OrganizationRoleOwner)
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := registry.GetNamespace(ctx, input.Namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+		}
+		if namespace.Organization != "" {
+			return nil, huma.Error409Conflict("Namespace " + input.Namespace + " already belongs to organization " + namespace.Organization)
+		}
+		owner, err := ownsNamespace(ctx, registry, jwtManager, claims, namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+		}
+		if !owner {
+			return nil, huma.Error403Forbidden("Only the owner of " + input.Namespace + " can add it to an organization")
+		}
+
+		org, err := registry.AddOrganizationNamespace(ctx, input.Organization, input.Namespace, principalFromClaims(claims))
+		if err != nil {
+			return nil, organizationError(err)
+		}
+		return &Response[apiv0.Organization]{Body: *org}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-organization-namespace" + operationSuffix,
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/organizations/{organization}/namespaces/{namespace}",
+		Summary:     "Remove namespace from organization",
+		Description: "Release a namespace from an organization (owners only). Ownership falls back to the namespace's recorded owner, " +
+			"or to whoever its authentication method grants it to.",
+		Tags:     []string{"organizations"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *OrganizationNamespaceInput) (*Response[apiv0.Organization], error) {
+		_, claims, err := authorizeOrganization(ctx, registry, jwtManager, input.Authorization, input.Organization, service.OrganizationRoleOwner)
+		if err != nil {
+			return nil, err
+		}
+
+		org, err := registry.RemoveOrganizationNamespace(ctx, input.Organization, input.Namespace, principalFromClaims(claims))
+		if err != nil {
+			return nil, organizationError(err)
+		}
+		return &Response[apiv0.Organization]{Body: *org}, nil
+	})
+}
+
+// authorizeOrganization validates the token and checks that its principal has at least the
+// given role in the organization. Admins with global edit permissions pass any check.
+func authorizeOrganization(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager,
+	authHeader, organization, minimumRole string,
+) (*apiv0.Organization, *auth.JWTClaims, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	org, err := registry.GetOrganization(ctx, organization)
+	if err != nil {
+		return nil, nil, organizationError(err)
+	}
+	if hasGlobalPermission(claims, auth.PermissionActionEdit) {
+		return org, claims, nil
+	}
+
+	role := service.MemberRole(org, principalFromClaims(claims))
+	if role == "" {
+		// Don't reveal to non-members which organizations exist
+		return nil, nil, huma.Error404NotFound("Organization not found")
+	}
+	if !service.RoleAtLeast(role, minimumRole) {
+		return nil, nil, huma.Error403Forbidden("This requires the " + minimumRole + " role in organization " + organization)
+	}
+	return org, claims, nil
+}
+
+func memberPrincipal(authMethod, encodedSubject string) (apiv0.Principal, error) {
+	subject, err := url.PathUnescape(encodedSubject)
+	if err != nil {
+		return apiv0.Principal{}, huma.Error400BadRequest("Invalid subject encoding", err)
+	}
+	return apiv0.Principal{AuthMethod: authMethod, Subject: subject}, nil
+}
+
+// organizationError maps service errors for organizations to HTTP errors
+func organizationError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict("Already exists")
+	case errors.Is(err, service.ErrLastOrganizationOwner):
+		return huma.Error409Conflict(err.Error())
+	default:
+		return huma.Error500InternalServerError("Failed to update organization", err)
+	}
+}
@@ -0,0 +1,125 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationRoles(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterOrganizationEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+	v0.RegisterEditEndpoints(api, "/v0", registryService, testConfig)
+
+	githubToken := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + login + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	owner, publisher, viewer, outsider := githubToken("acme"), githubToken("bob"), githubToken("carol"), githubToken("dave")
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("If-Match", "*")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	server := func(version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.acme/weather",
+			Description: "Weather server",
+			Version:     version,
+		}
+	}
+
+	w := call(http.MethodPost, "/v0/organizations", owner, map[string]string{"name": "acme"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = call(http.MethodPost, "/v0/organizations", outsider, map[string]string{"name": "acme"})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// Only the namespace owner can hand it to the organization
+	w = call(http.MethodPut, "/v0/organizations/acme/namespaces/io.github.dave", owner, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = call(http.MethodPut, "/v0/organizations/acme/namespaces/io.github.acme", owner, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = call(http.MethodPut, "/v0/organizations/acme/members/github-at/bob", owner, map[string]string{"role": "publisher"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = call(http.MethodPut, "/v0/organizations/acme/members/github-at/carol", owner, map[string]string{"role": "viewer"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = call(http.MethodPut, "/v0/organizations/acme/members/github-at/dave", publisher, map[string]string{"role": "owner"})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	t.Run("members can see the organization", func(t *testing.T) {
+		w := call(http.MethodGet, "/v0/organizations/acme", viewer, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var org apiv0.Organization
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &org))
+		assert.Equal(t, []string{"io.github.acme"}, org.Namespaces)
+		assert.Len(t, org.Members, 3)
+
+		w = call(http.MethodGet, "/v0/organizations/acme", outsider, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("roles decide who can publish, edit and delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPost, "/v0/publish", viewer, server("1.0.0")).Code)
+		assert.Equal(t, http.StatusOK, call(http.MethodPost, "/v0/publish", publisher, server("1.0.0")).Code)
+
+		edit := "/v0/servers/io.github.acme%2Fweather/versions/1.0.0"
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPut, edit+"?status=deprecated", viewer, server("1.0.0")).Code)
+		assert.Equal(t, http.StatusOK, call(http.MethodPut, edit+"?status=deprecated", publisher, server("1.0.0")).Code)
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPut, edit+"?status=deleted", publisher, server("1.0.0")).Code)
+		assert.Equal(t, http.StatusOK, call(http.MethodPut, edit+"?status=deleted", owner, server("1.0.0")).Code)
+	})
+
+	t.Run("organization keeps an owner", func(t *testing.T) {
+		w := call(http.MethodDelete, "/v0/organizations/acme/members/github-at/acme", owner, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		w = call(http.MethodPut, "/v0/organizations/acme/members/github-at/acme", owner, map[string]string{"role": "viewer"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// Members can leave on their own
+		w = call(http.MethodDelete, "/v0/organizations/acme/members/github-at/carol", viewer, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
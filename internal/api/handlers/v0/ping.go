@@ -20,8 +20,9 @@ func RegisterPingEndpoint(api huma.API, pathPrefix string) {
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/ping",
 		Summary:     "Ping",
-		Description: "Simple ping endpoint",
+		Description: "Simple ping endpoint. Deprecated: probes should use /healthz and /readyz, which report on the registry's dependencies.",
 		Tags:        []string{"ping"},
+		Deprecated:  true,
 	}, func(_ context.Context, _ *struct{}) (*Response[PingBody], error) {
 		return &Response[PingBody]{
 			Body: PingBody{
@@ -0,0 +1,202 @@
+package v0
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// Readiness statuses
+const (
+	readinessOK          = "ok"
+	readinessDegraded    = "degraded"
+	readinessUnavailable = "unavailable"
+)
+
+// Dependency statuses
+const (
+	dependencyOK      = "ok"
+	dependencyFailing = "failing"
+)
+
+const (
+	// dependencyCheckTimeout bounds each dependency check, so that a hanging dependency fails the
+	// probe rather than making it time out
+	dependencyCheckTimeout = 3 * time.Second
+	// authProviderCheckInterval is how long an auth provider check is remembered, so that every
+	// replica probing every few seconds doesn't turn into traffic to the providers
+	authProviderCheckInterval = time.Minute
+)
+
+// DependencyCheck checks a dependency the registry needs to serve requests
+type DependencyCheck struct {
+	Name string
+	// Required dependencies take the instance out of service when they fail; others only mark it degraded
+	Required bool
+	Check    func(ctx context.Context) error
+}
+
+// LivenessBody represents the liveness probe response body
+type LivenessBody struct {
+	Status string `json:"status" example:"ok" doc:"Always ok: the process is serving requests"`
+}
+
+// DependencyStatus is the result of checking one dependency
+type DependencyStatus struct {
+	Name       string `json:"name" example:"database" doc:"Dependency checked"`
+	Status     string `json:"status" enum:"ok,failing" doc:"Whether the dependency is usable"`
+	Required   bool   `json:"required" doc:"Whether the instance is taken out of service while the dependency fails"`
+	Error      string `json:"error,omitempty" doc:"Why the check failed"`
+	DurationMS int64  `json:"durationMs" doc:"How long the check took, in milliseconds"`
+}
+
+// ReadinessBody represents the readiness probe response body
+type ReadinessBody struct {
+	Status       string             `json:"status" enum:"ok,degraded,unavailable" doc:"ok when every dependency is usable, degraded when an optional one fails, unavailable when a required one fails"`
+	Dependencies []DependencyStatus `json:"dependencies" doc:"Result of each dependency check"`
+}
+
+// ReadinessOutput is the readiness probe response, 503 Service Unavailable when a required dependency fails
+type ReadinessOutput struct {
+	Status int
+	Body   ReadinessBody
+}
+
+// RegisterProbeEndpoints registers the Kubernetes liveness and readiness probes
+func RegisterProbeEndpoints(api huma.API, checks []DependencyCheck) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-liveness",
+		Method:      http.MethodGet,
+		Path:        "/healthz",
+		Summary:     "Liveness probe",
+		Description: "Check the process is serving requests, without checking its dependencies, so that restarts aren't triggered by outages elsewhere",
+		Tags:        []string{"health"},
+	}, func(_ context.Context, _ *struct{}) (*Response[LivenessBody], error) {
+		return &Response[LivenessBody]{Body: LivenessBody{Status: "ok"}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-readiness",
+		Method:      http.MethodGet,
+		Path:        "/readyz",
+		Summary:     "Readiness probe",
+		Description: "Check the dependencies of the registry: the database, its migrations, and the configured auth providers. " +
+			"Returns 503 Service Unavailable when the database is unreachable or not fully migrated. " +
+			"Auth provider outages only mark the instance degraded, since reads don't need them.",
+		Tags: []string{"health"},
+		Responses: map[string]*huma.Response{
+			"503": {Description: "A required dependency is failing"},
+		},
+	}, func(ctx context.Context, _ *struct{}) (*ReadinessOutput, error) {
+		body := checkDependencies(ctx, checks)
+		status := http.StatusOK
+		if body.Status == readinessUnavailable {
+			status = http.StatusServiceUnavailable
+		}
+		return &ReadinessOutput{Status: status, Body: body}, nil
+	})
+}
+
+// checkDependencies runs every check concurrently and sums up their results
+func checkDependencies(ctx context.Context, checks []DependencyCheck) ReadinessBody {
+	body := ReadinessBody{Status: readinessOK, Dependencies: make([]DependencyStatus, len(checks))}
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(checkCtx)
+			result := DependencyStatus{Name: check.Name, Status: dependencyOK, Required: check.Required, DurationMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = dependencyFailing
+				result.Error = err.Error()
+			}
+			body.Dependencies[i] = result
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range body.Dependencies {
+		switch {
+		case result.Status == dependencyOK:
+		case result.Required:
+			body.Status = readinessUnavailable
+		case body.Status == readinessOK:
+			body.Status = readinessDegraded
+		}
+	}
+	return body
+}
+
+// DefaultDependencyChecks returns the checks of the database, its migrations, and the auth
+// providers tokens are exchanged with
+func DefaultDependencyChecks(cfg *config.Config, registry service.RegistryService) []DependencyCheck {
+	checks := []DependencyCheck{
+		{Name: "database", Required: true, Check: registry.PingDatabase},
+		{Name: "migrations", Required: true, Check: func(ctx context.Context) error {
+			pending, err := registry.PendingMigrations(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d migrations not applied: %s", len(pending), strings.Join(pending, ", "))
+			}
+			return nil
+		}},
+		{Name: "github", Check: cachedCheck(authProviderCheckInterval, httpCheck("https://api.github.com"))},
+		{Name: "github-actions-oidc", Check: cachedCheck(authProviderCheckInterval,
+			httpCheck("https://token.actions.githubusercontent.com/.well-known/openid-configuration"))},
+	}
+	if cfg.OIDCEnabled && cfg.OIDCIssuer != "" {
+		checks = append(checks, DependencyCheck{Name: "oidc", Check: cachedCheck(authProviderCheckInterval,
+			httpCheck(strings.TrimSuffix(cfg.OIDCIssuer, "/")+"/.well-known/openid-configuration"))})
+	}
+	return checks
+}
+
+// httpCheck checks a URL answers without a server error
+func httpCheck(url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%s answered with status %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// cachedCheck remembers the result of a check for an interval
+func cachedCheck(interval time.Duration, check func(ctx context.Context) error) func(ctx context.Context) error {
+	var mu sync.Mutex
+	var checkedAt time.Time
+	var result error
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if checkedAt.IsZero() || time.Since(checkedAt) >= interval {
+			result = check(ctx)
+			checkedAt = time.Now()
+		}
+		return result
+	}
+}
@@ -0,0 +1,73 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+)
+
+func TestProbeEndpoints(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("connection refused") }
+
+	testCases := []struct {
+		name           string
+		checks         []v0.DependencyCheck
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "every dependency is usable",
+			checks:         []v0.DependencyCheck{{Name: "database", Required: true, Check: ok}, {Name: "github", Check: ok}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name:           "an optional dependency fails",
+			checks:         []v0.DependencyCheck{{Name: "database", Required: true, Check: ok}, {Name: "github", Check: failing}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "degraded",
+		},
+		{
+			name:           "a required dependency fails",
+			checks:         []v0.DependencyCheck{{Name: "database", Required: true, Check: failing}, {Name: "github", Check: failing}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "unavailable",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterProbeEndpoints(api, tc.checks)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			assert.Equal(t, http.StatusOK, w.Code, "liveness doesn't depend on dependencies")
+
+			w = httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body v0.ReadinessBody
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body.Status)
+			require.Len(t, body.Dependencies, len(tc.checks))
+			for i, check := range tc.checks {
+				assert.Equal(t, check.Name, body.Dependencies[i].Name)
+				assert.Equal(t, check.Required, body.Dependencies[i].Required)
+			}
+		})
+	}
+}
@@ -2,26 +2,42 @@ package v0
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/signing"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
-	Body          apiv0.ServerJSON `body:""`
+	Authorization   string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	IdempotencyKey  string           `header:"Idempotency-Key" doc:"Unique key for this publish. Retrying with the same key and server.json returns the version the first attempt published rather than a 409." required:"false" maxLength:"255" example:"5f0c8e2a-6a0b-4f7e-9d51-3c0f1a9b7e42"`
+	Signature       string           `header:"Server-Signature" doc:"Base64-encoded detached signature over the canonical server.json, as minisign or cosign wrote it. Required once the server has a signing key." required:"false" maxLength:"8192"`
+	SignatureFormat string           `header:"Server-Signature-Format" doc:"Tool that made the signature" required:"false" enum:"minisign,cosign"`
+	Body            apiv0.ServerJSON `body:""`
+}
+
+// PublishServerOutput is the published server version, and whether it was published by an earlier
+// request with the same idempotency key
+type PublishServerOutput struct {
+	IdempotentReplayed string `header:"Idempotent-Replayed"`
+	Body               apiv0.ServerResponse
 }
 
 // RegisterPublishEndpoint registers the publish endpoint with a custom path prefix
 func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	// Create JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg)
+	limits := newServerJSONLimits(cfg)
 
 	huma.Register(api, huma.Operation{
 		OperationID: "publish-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -33,7 +49,9 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
+		MaxBodyBytes: limits.operationMaxBodyBytes(),
+		Middlewares:  huma.Middlewares{limits.middleware(api)},
+	}, func(ctx context.Context, input *PublishServerInput) (*PublishServerOutput, error) {
 		// Extract bearer token
 		const bearerPrefix = "Bearer "
 		authHeader := input.Authorization
@@ -49,23 +67,94 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 		}
 
 		// Verify that the token has permission to publish the server
-		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
-			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
+		publisher, reason, err := AuthorizePublish(ctx, registry, jwtManager, claims, input.Body.Name)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+		}
+		if publisher == nil {
+			return nil, huma.Error403Forbidden(reason)
+		}
+
+		signature, err := publishSignature(input)
+		if err != nil {
+			return nil, err
 		}
 
 		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		publishedServer, replayed, err := registry.PublishServer(ctx, &input.Body, input.IdempotencyKey, signature, publisher)
 		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrNotServerPublisher):
+				return nil, huma.Error403Forbidden("You do not have permission to publish this server: " + err.Error())
+			case errors.Is(err, database.ErrInvalidVersion):
+				return nil, duplicateVersionError(pathPrefix, input.Body.Name, input.Body.Version)
+			case errors.Is(err, service.ErrIdempotencyKeyReused), errors.Is(err, service.ErrSignatureRequired),
+				errors.Is(err, signing.ErrInvalidSignature):
+				return nil, huma.Error422UnprocessableEntity(err.Error())
+			}
 			return nil, huma.Error400BadRequest("Failed to publish server", err)
 		}
 
 		// Return the published server response with metadata
-		return &Response[apiv0.ServerResponse]{
-			Body: *publishedServer,
-		}, nil
+		output := &PublishServerOutput{Body: *publishedServer}
+		if replayed {
+			output.IdempotentReplayed = "true"
+		}
+		return output, nil
 	})
 }
 
+// publishSignature decodes the signature a server.json is published with, or returns nil when
+// it's published unsigned
+func publishSignature(input *PublishServerInput) (*service.Signature, error) {
+	if input.Signature == "" {
+		if input.SignatureFormat != "" {
+			return nil, huma.Error400BadRequest("Server-Signature-Format was sent without a Server-Signature")
+		}
+		return nil, nil
+	}
+	if input.SignatureFormat == "" {
+		return nil, huma.Error400BadRequest("Server-Signature-Format is required with a Server-Signature")
+	}
+	signature, err := base64.StdEncoding.DecodeString(input.Signature)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Server-Signature must be the base64-encoded signature file", err)
+	}
+	return &service.Signature{Format: input.SignatureFormat, Signature: string(signature)}, nil
+}
+
+// AuthorizePublish checks that a token may publish a server, taking namespace transfers and
+// delegations into account, and returns who publishes it: only the server's original publisher
+// may publish new versions, unless the token overrides that. When the token may not publish the
+// server, the publisher is nil and the reason says why.
+func AuthorizePublish(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, claims *auth.JWTClaims, serverName string) (*service.Publisher, string, error) {
+	allowed, reason, err := canPublish(ctx, registry, jwtManager, claims, serverName)
+	if err != nil || !allowed {
+		return nil, reason, err
+	}
+	publisher, err := serverPublisher(ctx, registry, claims, serverName, hasGlobalPermission(claims, auth.PermissionActionPublish))
+	if err != nil {
+		return nil, "", err
+	}
+	return publisher, "", nil
+}
+
+// duplicateVersionError is the problem returned when a version is already published, with the
+// path of the existing version as its instance so that clients can fetch it
+func duplicateVersionError(pathPrefix, serverName, version string) error {
+	return &huma.ErrorModel{
+		Status:   http.StatusConflict,
+		Title:    http.StatusText(http.StatusConflict),
+		Detail:   "Version " + version + " of " + serverName + " is already published. Publish a new version instead.",
+		Instance: pathPrefix + "/servers/" + url.PathEscape(serverName) + "/versions/" + url.PathEscape(version),
+		Errors: []*huma.ErrorDetail{{
+			Message:  database.ErrInvalidVersion.Error(),
+			Location: "body.version",
+			Value:    version,
+		}},
+	}
+}
+
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
 // the user has and what they're trying to publish
 func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Permission) string {
@@ -7,8 +7,10 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -201,7 +203,7 @@ func TestPublishEndpoint(t *testing.T) {
 				}
 				_, _ = registry.CreateServer(context.Background(), &existingServer)
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 			expectedError:  "invalid version: cannot publish duplicate version",
 		},
 		{
@@ -524,3 +526,296 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishEndpoint_Idempotency(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publish := func(description, idempotencyKey string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/idempotent",
+			Description: description,
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	first := publish("Idempotent server", "key-1")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	t.Run("retry with the same key returns the published version", func(t *testing.T) {
+		w := publish("Idempotent server", "key-1")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+
+		var published, replayed apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(first.Body.Bytes(), &published))
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &replayed))
+		assert.Equal(t, published.Server, replayed.Server)
+		assert.True(t, published.Meta.Official.PublishedAt.Equal(replayed.Meta.Official.PublishedAt))
+	})
+
+	t.Run("same key with another server.json is rejected", func(t *testing.T) {
+		w := publish("Changed description", "key-1")
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), service.ErrIdempotencyKeyReused.Error())
+	})
+
+	for _, key := range []string{"", "key-2"} {
+		t.Run("duplicate version with key "+key+" points at the existing version", func(t *testing.T) {
+			w := publish("Idempotent server", key)
+			require.Equal(t, http.StatusConflict, w.Code)
+
+			var problem huma.ErrorModel
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, "/v0/servers/com.example%2Fidempotent/versions/1.0.0", problem.Instance)
+			require.Len(t, problem.Errors, 1)
+			assert.Equal(t, "body.version", problem.Errors[0].Location)
+		})
+	}
+}
+
+func TestPublishEndpoint_OriginalPublisher(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+	v0.RegisterEditEndpoints(api, "/v0", registryService, testConfig)
+
+	// Signing in with GitHub grants every member of an organization its whole namespace
+	memberToken := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob := memberToken("alice"), memberToken("bob")
+	admin, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	call := func(method, path, token, version string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.acme/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("If-Match", "*")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	edit := "/v0/servers/io.github.acme%2Fweather/versions/1.0.0"
+
+	w := call(http.MethodPost, "/v0/publish", alice, "1.0.0")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("only the original publisher publishes new versions", func(t *testing.T) {
+		w := call(http.MethodPost, "/v0/publish", bob, "1.1.0")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "github-at:alice")
+
+		assert.Equal(t, http.StatusOK, call(http.MethodPost, "/v0/publish", alice, "1.1.0").Code)
+	})
+
+	t.Run("only the original publisher edits the server", func(t *testing.T) {
+		w := call(http.MethodPut, edit, bob, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "github-at:alice")
+
+		assert.Equal(t, http.StatusOK, call(http.MethodPut, edit+"?status=deprecated", alice, "1.0.0").Code)
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPut, edit+"?status=deleted", alice, "1.0.0").Code)
+	})
+
+	t.Run("delegates don't override the original publisher", func(t *testing.T) {
+		carol := apiv0.Principal{AuthMethod: string(auth.MethodGitHubAT), Subject: "carol"}
+		request, err := registryService.RequestNamespaceChange(context.Background(), service.NamespaceRequestDelegation, "io.github.acme",
+			apiv0.Principal{AuthMethod: string(auth.MethodGitHubAT), Subject: "alice"}, carol)
+		require.NoError(t, err)
+		_, err = registryService.AcceptNamespaceRequest(context.Background(), request.ID, carol)
+		require.NoError(t, err)
+		carolToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "carol"})
+		require.NoError(t, err)
+
+		w := call(http.MethodPut, edit, carolToken, "1.0.0")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "github-at:alice")
+	})
+
+	t.Run("servers without a recorded publisher take namespace permissions", func(t *testing.T) {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.acme/legacy",
+			Description: "Published before publishers were recorded",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.acme/legacy",
+			Description: "Edited by another member",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/io.github.acme%2Flegacy/versions/1.0.0", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+bob)
+		req.Header.Set("If-Match", "*")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("admins override the original publisher", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, call(http.MethodPost, "/v0/publish", admin, "1.2.0").Code)
+		assert.Equal(t, http.StatusOK, call(http.MethodPut, edit, admin, "1.0.0").Code)
+
+		// Overriding doesn't change who the publisher is
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPost, "/v0/publish", bob, "1.3.0").Code)
+	})
+}
+
+// countingReader streams an endless JSON string after a prefix, counting how much was read
+type countingReader struct {
+	prefix string
+	read   int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.read < len(r.prefix) {
+			p[i] = r.prefix[r.read]
+		} else {
+			p[i] = 'a'
+		}
+		r.read++
+	}
+	return len(p), nil
+}
+
+func TestPublishEndpoint_BodyLimits(t *testing.T) {
+	testConfig := &config.Config{
+		JWTPrivateKey:       hex.EncodeToString(make([]byte, ed25519.SeedSize)),
+		PublishMaxBodyBytes: 4096,
+		PublishMaxMetaBytes: 256,
+		PublishMaxJSONDepth: 8,
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	// Rejected bodies never reach the registry
+	v0.RegisterPublishEndpoint(api, "/v0", nil, testConfig)
+
+	publish := func(body io.Reader, contentLength string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer invalid")
+		if contentLength != "" {
+			req.Header.Set("Content-Length", contentLength)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	problem := func(w *httptest.ResponseRecorder) huma.ErrorModel {
+		var problem huma.ErrorModel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		return problem
+	}
+
+	serverJSON := func(remoteURL, meta string) io.Reader {
+		return strings.NewReader(`{"$schema":"` + model.CurrentSchemaURL + `","name":"com.example/server","description":"A server",` +
+			`"version":"1.0.0","remotes":[{"type":"streamable-http","url":"` + remoteURL + `"}],` +
+			`"_meta":{"io.modelcontextprotocol.registry/publisher-provided":` + meta + `}}`)
+	}
+
+	t.Run("bodies within the limits reach the handler", func(t *testing.T) {
+		w := publish(serverJSON("https://example.com/mcp", `{"tags":[["a"],["b"]]}`), "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	})
+
+	t.Run("declared sizes over the limit are rejected before reading", func(t *testing.T) {
+		body := &countingReader{prefix: `{"description":"`}
+		w := publish(body, "104857600")
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Zero(t, body.read)
+	})
+
+	t.Run("streamed bodies stop being read at the limit", func(t *testing.T) {
+		body := &countingReader{prefix: `{"description":"`}
+		w := publish(body, "")
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, problem(w).Detail, "4096 bytes")
+		assert.Less(t, body.read, 16384)
+	})
+
+	t.Run("oversized _meta is rejected", func(t *testing.T) {
+		w := publish(serverJSON("https://example.com/mcp", `"`+strings.Repeat("a", 300)+`"`), "")
+		require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Len(t, problem(w).Errors, 1)
+		assert.Equal(t, "body._meta", problem(w).Errors[0].Location)
+	})
+
+	t.Run("fields other than _meta aren't limited as _meta", func(t *testing.T) {
+		w := publish(serverJSON("https://example.com/"+strings.Repeat("a", 300), `{}`), "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	})
+
+	t.Run("deeply nested bodies are rejected", func(t *testing.T) {
+		w := publish(strings.NewReader(`{"_meta":`+strings.Repeat("[", 10)+strings.Repeat("]", 10)+`}`), "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, problem(w).Detail, "deeper than the limit of 8")
+	})
+}
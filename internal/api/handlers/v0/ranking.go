@@ -0,0 +1,107 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// SearchRankingInput represents the input for reading or resetting the search ranking
+type SearchRankingInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+}
+
+// SetSearchRankingInput represents the input for overriding the search ranking
+type SetSearchRankingInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Body          apiv0.SearchRanking
+}
+
+// RegisterSearchRankingEndpoints registers the admin search ranking endpoints with a custom path prefix
+func RegisterSearchRankingEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-search-ranking" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/search-ranking",
+		Summary:     "Get search ranking",
+		Description: "Get the field weights and boosts search results are ordered by (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SearchRankingInput) (*Response[apiv0.SearchRanking], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		ranking, err := registry.GetSearchRanking(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get search ranking", err)
+		}
+		return &Response[apiv0.SearchRanking]{Body: *ranking}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-search-ranking" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/search-ranking",
+		Summary:     "Set search ranking",
+		Description: "Override the deployment's configured search ranking (admin only). Takes effect for the next search.",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetSearchRankingInput) (*Response[apiv0.SearchRanking], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		ranking, err := registry.SetSearchRanking(ctx, &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to set search ranking", err)
+		}
+		return &Response[apiv0.SearchRanking]{Body: *ranking}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reset-search-ranking" + operationSuffix,
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/admin/search-ranking",
+		Summary:     "Reset search ranking",
+		Description: "Drop the overridden search ranking so the deployment's configured one applies again (admin only). Returns the configured ranking.",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SearchRankingInput) (*Response[apiv0.SearchRanking], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		ranking, err := registry.ResetSearchRanking(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to reset search ranking", err)
+		}
+		return &Response[apiv0.SearchRanking]{Body: *ranking}, nil
+	})
+}
+
+// authorizeAdmin validates the token and checks it grants global edit permissions
+func authorizeAdmin(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) error {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return err
+	}
+	if !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+		return huma.Error403Forbidden("This endpoint requires registry admin permissions")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRanking(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), SearchNameWeight: 1}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "com.example/forecast", Title: "Weather forecasts", Description: "Forecasts from the national weather service"},
+		{Name: "com.example/weather", Description: "Current conditions"},
+		{Name: "com.example/maps", Description: "Maps with weather overlays"},
+	} {
+		server.Schema = model.CurrentSchemaURL
+		server.Version = "1.0.0"
+		_, err := registryService.CreateServer(context.Background(), &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterSearchRankingEndpoints(api, "/v0", registryService, testConfig)
+
+	token := func(pattern string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	adminToken := token("*")
+
+	setRanking := func(bearer string, ranking apiv0.SearchRanking) *httptest.ResponseRecorder {
+		body, err := json.Marshal(ranking)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/v0/admin/search-ranking", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	search := func(query string) []string {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?search="+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		names := []string{}
+		for _, server := range resp.Servers {
+			names = append(names, server.Server.Name)
+		}
+		return names
+	}
+
+	// The configured ranking searches names only
+	assert.Equal(t, []string{"com.example/weather"}, search("weather"))
+
+	assert.Equal(t, http.StatusForbidden, setRanking(token("com.example/*"), apiv0.SearchRanking{NameWeight: 1, FreshnessHalfLifeDays: 90}).Code)
+	assert.Equal(t, http.StatusBadRequest, setRanking(adminToken, apiv0.SearchRanking{FreshnessHalfLifeDays: 90}).Code)
+
+	w := setRanking(adminToken, apiv0.SearchRanking{NameWeight: 3, TitleWeight: 2, DescriptionWeight: 1, FreshnessHalfLifeDays: 90})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []string{"com.example/forecast", "com.example/weather", "com.example/maps"}, search("weather"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/v0/admin/search-ranking", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"com.example/weather"}, search("weather"))
+}
@@ -0,0 +1,161 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/readme"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// README media types
+const (
+	readmeMarkdown = "text/markdown"
+	readmeHTML     = "text/html"
+)
+
+// readmeCacheControl matches how long the registry itself caches READMEs by default
+const readmeCacheControl = "public, max-age=3600"
+
+// ReadmeInput represents the input for getting a server's README
+type ReadmeInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `query:"version" doc:"Version whose README to get (default: latest)" example:"1.0.0"`
+	Accept     string `header:"Accept" doc:"text/markdown for the sanitized markdown (the default) or text/html for it rendered as an HTML fragment"`
+}
+
+// ReadmeOutput is a README as markdown or HTML
+type ReadmeOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Vary         string `header:"Vary"`
+	// Content-Location is the README's own URL, which relative links in the markdown are relative to
+	ContentLocation string `header:"Content-Location"`
+	Body            []byte
+}
+
+// RegisterReadmeEndpoints registers the README endpoint with a custom path prefix
+func RegisterReadmeEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, fetcher *readme.Fetcher) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/readme",
+		Summary:     "Get server README",
+		Description: "Get the README a server links to in the 'readme' key of its publisher-provided metadata, fetched by the registry so clients needn't fetch it cross-origin. " +
+			"Raw HTML and links other than http, https and mailto are stripped. " +
+			"Ask for text/html in the Accept header to get it rendered as an HTML fragment.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "README",
+				Content:     map[string]*huma.MediaType{readmeMarkdown: {}, readmeHTML: {}},
+			},
+		},
+	}, func(ctx context.Context, input *ReadmeInput) (*ReadmeOutput, error) {
+		contentType := negotiateReadmeType(input.Accept)
+		if contentType == "" {
+			return nil, huma.Error406NotAcceptable("READMEs are available as " + readmeMarkdown + " or " + readmeHTML)
+		}
+
+		server, err := getReadmeServer(ctx, registry, input)
+		if err != nil {
+			return nil, err
+		}
+		readmeURL, err := readme.URL(&server.Server)
+		if err != nil {
+			return nil, huma.Error404NotFound("Server has no README")
+		}
+		markdown, _, err := fetcher.Fetch(ctx, readmeURL)
+		if err != nil {
+			return nil, huma.Error502BadGateway("Failed to fetch README", err)
+		}
+
+		body := markdown
+		if contentType == readmeHTML {
+			body = readme.RenderHTML(markdown, readmeURL)
+		}
+		return &ReadmeOutput{
+			ContentType:     contentType + ";charset=utf-8",
+			CacheControl:    readmeCacheControl,
+			Vary:            "Accept",
+			ContentLocation: readmeURL,
+			Body:            []byte(body),
+		}, nil
+	})
+}
+
+// getReadmeServer looks up the server version whose README is asked for
+func getReadmeServer(ctx context.Context, registry service.RegistryService, input *ReadmeInput) (*apiv0.ServerResponse, error) {
+	serverName, err := url.PathUnescape(input.ServerName)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	var server *apiv0.ServerResponse
+	if input.Version == "" || input.Version == "latest" {
+		server, err = registry.GetServerByName(ctx, serverName)
+	} else {
+		server, err = registry.GetServerByNameAndVersion(ctx, serverName, input.Version)
+	}
+	if err != nil {
+		if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server details", err)
+	}
+	return server, nil
+}
+
+// negotiateReadmeType picks markdown or HTML by the Accept header, preferring markdown on ties,
+// and returns "" when the client accepts neither
+func negotiateReadmeType(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return readmeMarkdown
+	}
+
+	// Exact media types take precedence over wildcards
+	exact := map[string]float64{}
+	wildcard := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		switch mediaType {
+		case readmeMarkdown, readmeHTML:
+			exact[mediaType] = quality
+		case "*/*", "text/*":
+			wildcard = max(wildcard, quality)
+		}
+	}
+	qualities := map[string]float64{}
+	for _, candidate := range []string{readmeMarkdown, readmeHTML} {
+		quality, ok := exact[candidate]
+		if !ok {
+			quality = wildcard
+		}
+		qualities[candidate] = quality
+	}
+
+	markdown, html := qualities[readmeMarkdown], qualities[readmeHTML]
+	switch {
+	case markdown <= 0 && html <= 0:
+		return ""
+	case html > markdown:
+		return readmeHTML
+	default:
+		return readmeMarkdown
+	}
+}
@@ -0,0 +1,72 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/readme"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadmeEndpoint(t *testing.T) {
+	readmeHost := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("# Weather\n\nSee [usage](docs/usage.md).\n<script>alert(1)</script>"))
+	}))
+	defer readmeHost.Close()
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "com.example/weather", Meta: &apiv0.ServerMeta{PublisherProvided: map[string]any{"readme": readmeHost.URL + "/repo/README.md"}}},
+		{Name: "com.example/maps"},
+	} {
+		server.Schema = model.CurrentSchemaURL
+		server.Description = "Test server"
+		server.Version = "1.0.0"
+		_, err := registryService.CreateServer(context.Background(), &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterReadmeEndpoints(api, "/v0", registryService, readme.NewFetcherWithClient(readmeHost.Client(), time.Hour))
+
+	get := func(serverName, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+serverName+"/readme", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("com.example%2Fweather", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/markdown;charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	assert.Contains(t, w.Body.String(), "[usage](docs/usage.md)")
+	assert.NotContains(t, w.Body.String(), "<script>")
+
+	w = get("com.example%2Fweather", "text/html, text/markdown;q=0.5")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/html;charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<h1>Weather</h1>")
+	assert.Contains(t, w.Body.String(), `href="`+readmeHost.URL+`/repo/docs/usage.md"`)
+
+	assert.Equal(t, http.StatusOK, get("com.example%2Fweather", "*/*").Code)
+	assert.Equal(t, http.StatusNotAcceptable, get("com.example%2Fweather", "application/json").Code)
+	assert.Equal(t, http.StatusNotFound, get("com.example%2Fmaps", "").Code, "servers without a README")
+	assert.Equal(t, http.StatusNotFound, get("com.example%2Fmissing", "").Code)
+}
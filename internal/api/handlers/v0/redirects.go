@@ -0,0 +1,185 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RenameServerInput represents the input for renaming a server
+type RenameServerInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for both names" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded current server name" example:"io.github.octocat%2Fweather"`
+	Body          struct {
+		Name string `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"New server name" example:"io.github.octocat/forecast"`
+	}
+}
+
+// ServerAliasesInput represents the input for listing the names that redirect to a server
+type ServerAliasesInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"io.github.octocat%2Fforecast"`
+}
+
+// AddServerAliasInput represents the input for adding an alias to a server
+type AddServerAliasInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server and the alias" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.octocat%2Fforecast"`
+	Body          struct {
+		Alias string `json:"alias" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Unpublished name to redirect to the server" example:"io.github.octocat/weather-forecast"`
+	}
+}
+
+// DeleteServerAliasInput represents the input for removing an alias or former name of a server
+type DeleteServerAliasInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.octocat%2Fforecast"`
+	Alias         string `path:"alias" doc:"URL-encoded name that redirects to the server" example:"io.github.octocat%2Fweather"`
+}
+
+// RegisterRedirectEndpoints registers the server rename and alias endpoints with a custom path prefix
+func RegisterRedirectEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rename-server" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/rename",
+		Summary:     "Rename server",
+		Description: "Move every version of a server to a new name. Reads of the old name redirect to the new one. " +
+			"Requires permission to publish both names.",
+		Tags:     []string{"publish"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *RenameServerInput) (*Response[apiv0.ServerRedirect], error) {
+		serverName, err := authorizeRedirect(ctx, registry, jwtManager, input.Authorization, input.ServerName, input.Body.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		redirect, err := registry.RenameServer(ctx, serverName, input.Body.Name)
+		if err != nil {
+			return nil, redirectError(err, "Failed to rename server")
+		}
+		return &Response[apiv0.ServerRedirect]{Body: *redirect}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-aliases" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/aliases",
+		Summary:     "List server aliases",
+		Description: "List the names that redirect to a server: its aliases and the names it was renamed or claimed away from.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerAliasesInput) (*Response[apiv0.ServerRedirectListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		redirects, err := registry.ListServerRedirects(ctx, serverName)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list server aliases", err)
+		}
+		return &Response[apiv0.ServerRedirectListResponse]{
+			Body: apiv0.ServerRedirectListResponse{Redirects: redirects},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "add-server-alias" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/aliases",
+		Summary:     "Add server alias",
+		Description: "Make an unpublished name redirect to a server without moving it. Requires permission to publish both names.",
+		Tags:        []string{"publish"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *AddServerAliasInput) (*Response[apiv0.ServerRedirect], error) {
+		serverName, err := authorizeRedirect(ctx, registry, jwtManager, input.Authorization, input.ServerName, input.Body.Alias)
+		if err != nil {
+			return nil, err
+		}
+
+		redirect, err := registry.AddServerAlias(ctx, serverName, input.Body.Alias)
+		if err != nil {
+			return nil, redirectError(err, "Failed to add server alias")
+		}
+		return &Response[apiv0.ServerRedirect]{Body: *redirect}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-alias" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/aliases/{alias}",
+		Summary:       "Remove server alias",
+		Description:   "Stop a name redirecting to a server, freeing it to be published again. Requires permission to publish the server.",
+		Tags:          []string{"publish"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteServerAliasInput) (*struct{}, error) {
+		serverName, err := authorizePublisher(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		alias, err := url.PathUnescape(input.Alias)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid alias encoding", err)
+		}
+
+		if err := registry.DeleteServerRedirect(ctx, serverName, alias); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound(alias + " does not redirect to " + serverName)
+			}
+			return nil, huma.Error500InternalServerError("Failed to remove server alias", err)
+		}
+		return nil, nil
+	})
+}
+
+// authorizeRedirect checks that the token may publish both the server and the name that will
+// redirect to it, returning the decoded server name
+func authorizeRedirect(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, authHeader, encodedName, otherName string,
+) (string, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return "", err
+	}
+	serverName, err := url.PathUnescape(encodedName)
+	if err != nil {
+		return "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	for _, name := range []string{serverName, otherName} {
+		allowed, reason, err := canPublish(ctx, registry, jwtManager, claims, name)
+		if err != nil {
+			return "", huma.Error500InternalServerError("Failed to check namespace permissions", err)
+		}
+		if !allowed {
+			return "", huma.Error403Forbidden(reason)
+		}
+	}
+	return serverName, nil
+}
+
+// redirectError maps errors from renaming and aliasing servers to API errors
+func redirectError(err error, message string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Server not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(message, err)
+	}
+}
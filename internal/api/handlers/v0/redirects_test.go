@@ -0,0 +1,98 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerRenamesAndAliases(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, name := range []string{"io.github.alice/weather", "io.github.alice/taken"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterRedirectEndpoints(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+	})
+	require.NoError(t, err)
+
+	call := func(method, path string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("rename checks both names", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPost, "/v0/servers/io.github.alice%2Fweather/rename",
+			map[string]string{"name": "io.github.bob/weather"}).Code)
+		assert.Equal(t, http.StatusConflict, call(http.MethodPost, "/v0/servers/io.github.alice%2Fweather/rename",
+			map[string]string{"name": "io.github.alice/taken"}).Code)
+	})
+
+	w := call(http.MethodPost, "/v0/servers/io.github.alice%2Fweather/rename", map[string]string{"name": "io.github.alice/forecast"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = call(http.MethodPost, "/v0/servers/io.github.alice%2Fforecast/aliases", map[string]string{"alias": "io.github.alice/weather-forecast"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusConflict, call(http.MethodPost, "/v0/servers/io.github.alice%2Fforecast/aliases",
+		map[string]string{"alias": "io.github.alice/taken"}).Code)
+
+	for _, oldName := range []string{"io.github.alice%2Fweather", "io.github.alice%2Fweather-forecast"} {
+		w := call(http.MethodGet, "/v0/servers/"+oldName+"/versions/1.0.0", nil)
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/v0/servers/io.github.alice%2Fforecast/versions/1.0.0", w.Header().Get("Location"))
+	}
+
+	var list apiv0.ServerRedirectListResponse
+	require.NoError(t, json.Unmarshal(call(http.MethodGet, "/v0/servers/io.github.alice%2Fforecast/aliases", nil).Body.Bytes(), &list))
+	require.Len(t, list.Redirects, 2)
+	assert.Equal(t, "rename", list.Redirects[0].Reason)
+	assert.Equal(t, "alias", list.Redirects[1].Reason)
+
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, "/v0/servers/io.github.alice%2Fforecast/aliases/io.github.alice%2Fweather-forecast", nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodGet, "/v0/servers/io.github.alice%2Fweather-forecast/versions/1.0.0", nil).Code)
+}
@@ -0,0 +1,46 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerRelationshipsInput represents the input for getting the relationship graph of a server
+type ServerRelationshipsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// RegisterRelationshipEndpoints registers the server relationship graph endpoint with a custom path prefix
+func RegisterRelationshipEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-relationships" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/relationships",
+		Summary:     "Get server relationships",
+		Description: "Get the relationships the latest version of a server declares, and those the latest versions of other servers declare to it. " +
+			"A server that another one supersedes has a replacement; one that others require is a companion of theirs.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ServerRelationshipsInput) (*Response[apiv0.ServerRelationshipGraph], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		graph, err := registry.GetServerRelationships(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/relationships")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server relationships", err)
+		}
+		return &Response[apiv0.ServerRelationshipGraph]{Body: *graph}, nil
+	})
+}
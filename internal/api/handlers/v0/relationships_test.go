@@ -0,0 +1,78 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerRelationships(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	publish := func(name string, relationships ...model.Relationship) error {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:        model.CurrentSchemaURL,
+			Name:          name,
+			Description:   "Test server",
+			Version:       "1.0.0",
+			Relationships: relationships,
+		})
+		return err
+	}
+	require.NoError(t, publish("com.example/weather-legacy"))
+	require.NoError(t, publish("com.example/auth"))
+	require.NoError(t, publish("com.example/weather",
+		model.Relationship{Type: model.RelationshipSupersedes, Name: "com.example/weather-legacy"},
+		model.Relationship{Type: model.RelationshipRequires, Name: "com.example/auth"},
+	))
+
+	t.Run("related servers must be published", func(t *testing.T) {
+		err := publish("com.example/suite", model.Relationship{Type: model.RelationshipBundleOf, Name: "com.example/missing"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "related server com.example/missing is not published in the registry")
+	})
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterRelationshipEndpoints(api, "/v0", registryService)
+
+	get := func(encodedName string) (int, apiv0.ServerRelationshipGraph) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers/"+encodedName+"/relationships", nil))
+		var graph apiv0.ServerRelationshipGraph
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &graph))
+		}
+		return w.Code, graph
+	}
+
+	code, graph := get("com.example%2Fweather")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []apiv0.ServerRelationshipEdge{
+		{From: "com.example/weather", Type: model.RelationshipSupersedes, To: "com.example/weather-legacy"},
+		{From: "com.example/weather", Type: model.RelationshipRequires, To: "com.example/auth"},
+	}, graph.Edges)
+
+	code, graph = get("com.example%2Fweather-legacy")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []apiv0.ServerRelationshipEdge{
+		{From: "com.example/weather", Type: model.RelationshipSupersedes, To: "com.example/weather-legacy"},
+	}, graph.Edges)
+
+	code, _ = get("com.example%2Fmissing")
+	assert.Equal(t, http.StatusNotFound, code)
+}
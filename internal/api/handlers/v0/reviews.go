@@ -0,0 +1,183 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerReviewsInput represents the input for listing the reviews of a server
+type ServerReviewsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Cursor     string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit      int    `query:"limit" doc:"Number of reviews per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// ReviewServerInput represents the input for reviewing a server
+type ReviewServerInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          struct {
+		Rating int    `json:"rating" minimum:"1" maximum:"5" doc:"Rating from 1 to 5" example:"4"`
+		Review string `json:"review,omitempty" maxLength:"500" doc:"Short review" example:"Reliable forecasts, easy to set up."`
+	}
+}
+
+// DeleteServerReviewInput represents the input for deleting the caller's review of a server
+type DeleteServerReviewInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// ModerateServerReviewInput represents the input for hiding or showing a review
+type ModerateServerReviewInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ID            string `path:"id" doc:"Review ID"`
+	Body          struct {
+		Hidden bool `json:"hidden" doc:"Whether to hide the review"`
+	}
+}
+
+// RegisterReviewEndpoints registers the server review endpoints with a custom path prefix
+func RegisterReviewEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-reviews" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/reviews",
+		Summary:     "List server reviews",
+		Description: "Get the average rating of a server and its reviews, most recently updated first.",
+		Tags:        []string{"reviews"},
+	}, func(ctx context.Context, input *ServerReviewsInput) (*Response[apiv0.ServerReviewListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		offset := 0
+		if input.Cursor != "" {
+			if offset, err = strconv.Atoi(input.Cursor); err != nil || offset < 0 {
+				return nil, huma.Error400BadRequest("Invalid cursor")
+			}
+		}
+
+		reviews, err := registry.ListServerReviews(ctx, serverName, offset, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/reviews")
+			}
+			return nil, huma.Error500InternalServerError("Failed to list server reviews", err)
+		}
+		return &Response[apiv0.ServerReviewListResponse]{Body: *reviews}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "review-server" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/reviews",
+		Summary:     "Review server",
+		Description: "Rate a server from 1 to 5, with an optional short review, replacing the caller's earlier review. " +
+			"Publishers can't review their own servers, and each caller can review a limited number of servers a day.",
+		Tags:     []string{"reviews"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ReviewServerInput) (*Response[apiv0.ServerReview], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		// Publishers rating their own servers would make ratings meaningless
+		publisher, _, err := canPublish(ctx, registry, jwtManager, claims, serverName)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check namespace permissions", err)
+		}
+		if publisher {
+			return nil, huma.Error403Forbidden("You can't review a server you can publish")
+		}
+
+		review, err := registry.ReviewServer(ctx, &apiv0.ServerReview{
+			ServerName: serverName,
+			Reviewer:   principalFromClaims(claims),
+			Rating:     input.Body.Rating,
+			Review:     strings.TrimSpace(input.Body.Review),
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/reviews")
+			case errors.Is(err, service.ErrReviewLimitReached):
+				return nil, huma.Error429TooManyRequests(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to review server", err)
+			}
+		}
+		return &Response[apiv0.ServerReview]{Body: *review}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-review" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/reviews",
+		Summary:       "Delete server review",
+		Description:   "Delete the caller's review of a server.",
+		Tags:          []string{"reviews"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteServerReviewInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if err := registry.DeleteServerReview(ctx, serverName, principalFromClaims(claims)); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Review not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete server review", err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "moderate-server-review" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/reviews/{id}",
+		Summary:     "Moderate server review",
+		Description: "Hide an abusive review, or show a hidden one again (admin only). Hidden reviews don't show or count towards ratings, " +
+			"and stay hidden when their reviewer updates them.",
+		Tags:     []string{"admin"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ModerateServerReviewInput) (*Response[apiv0.ServerReview], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		review, err := registry.SetServerReviewHidden(ctx, input.ID, input.Body.Hidden)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Review not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to moderate server review", err)
+		}
+		return &Response[apiv0.ServerReview]{Body: *review}, nil
+	})
+}
@@ -0,0 +1,119 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerReviews(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, name := range []string{"io.github.alice/weather", "io.github.alice/maps"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterReviewEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(subject string, permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice := tokenFor("alice", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"})
+	bob := tokenFor("bob")
+	carol := tokenFor("carol")
+	admin := tokenFor("admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listReviews := func() apiv0.ServerReviewListResponse {
+		w := call(http.MethodGet, "/v0/servers/io.github.alice%2Fweather/reviews", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp apiv0.ServerReviewListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+	const path = "/v0/servers/io.github.alice%2Fweather/reviews"
+
+	// Publishers can't review their own servers
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, path, alice, map[string]any{"rating": 5}).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, path, bob, map[string]any{"rating": 6}).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodPut, "/v0/servers/io.github.alice%2Fmissing/reviews", bob, map[string]any{"rating": 5}).Code)
+
+	w := call(http.MethodPut, path, bob, map[string]any{"rating": 2, "review": "Flaky"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, http.StatusOK, call(http.MethodPut, path, bob, map[string]any{"rating": 4, "review": "Fixed now"}).Code)
+	require.Equal(t, http.StatusOK, call(http.MethodPut, path, carol, map[string]any{"rating": 5}).Code)
+
+	reviews := listReviews()
+	assert.Equal(t, apiv0.ServerRating{Average: 4.5, Count: 2}, reviews.Rating, "a reviewer's update replaces their review")
+	require.Len(t, reviews.Reviews, 2)
+	assert.Equal(t, "carol", reviews.Reviews[0].Reviewer.Subject)
+
+	// Rated servers sort ahead of unrated ones
+	var list apiv0.ServerListResponse
+	require.NoError(t, json.Unmarshal(call(http.MethodGet, "/v0/servers?sort=rating", "", nil).Body.Bytes(), &list))
+	require.Len(t, list.Servers, 2)
+	assert.Equal(t, "io.github.alice/weather", list.Servers[0].Server.Name)
+
+	// Admins hide abusive reviews, which then no longer count
+	carolReview := reviews.Reviews[0]
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, "/v0/admin/reviews/"+carolReview.ID, bob, map[string]any{"hidden": true}).Code)
+	require.Equal(t, http.StatusOK, call(http.MethodPut, "/v0/admin/reviews/"+carolReview.ID, admin, map[string]any{"hidden": true}).Code)
+	assert.Equal(t, apiv0.ServerRating{Average: 4, Count: 1}, listReviews().Rating)
+	require.Equal(t, http.StatusOK, call(http.MethodPut, path, carol, map[string]any{"rating": 1}).Code)
+	assert.Equal(t, apiv0.ServerRating{Average: 4, Count: 1}, listReviews().Rating, "updating a hidden review keeps it hidden")
+
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, path, bob, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, path, bob, nil).Code)
+	assert.Equal(t, 0, listReviews().Rating.Count)
+}
@@ -0,0 +1,80 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RecordPackageScanInput represents the input for recording the vulnerability scan of an OCI image
+type RecordPackageScanInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Body          struct {
+		Identifier string    `json:"identifier" minLength:"1" doc:"Identifier of the scanned OCI package of the version" example:"docker.io/example/weather:1.0.0"`
+		Critical   int       `json:"critical" minimum:"0" doc:"Number of critical vulnerabilities found"`
+		High       int       `json:"high" minimum:"0" doc:"Number of high severity vulnerabilities found"`
+		Moderate   int       `json:"moderate" minimum:"0" doc:"Number of moderate severity vulnerabilities found"`
+		Low        int       `json:"low" minimum:"0" doc:"Number of low severity vulnerabilities found"`
+		ScannedAt  time.Time `json:"scannedAt,omitempty" format:"date-time" required:"false" doc:"When the image was scanned. Defaults to now."`
+	}
+}
+
+// RegisterScanEndpoints registers the vulnerability scan endpoints with a custom path prefix
+func RegisterScanEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "record-package-scan" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/servers/{serverName}/versions/{version}/scan",
+		Summary:     "Record vulnerability scan",
+		Description: "Record the vulnerabilities an image scanner found in one of the OCI packages of a server version, " +
+			"replacing the package's earlier scan (admin only). The version's official metadata sums the scans of its packages.",
+		Tags:     []string{"admin"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *RecordPackageScanInput) (*Response[apiv0.ServerResponse], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		server, err := registry.RecordPackageScan(ctx, serverName, version, input.Body.Identifier, &apiv0.VulnerabilityScan{
+			Critical:  input.Body.Critical,
+			High:      input.Body.High,
+			Moderate:  input.Body.Moderate,
+			Low:       input.Body.Low,
+			ScannedAt: input.Body.ScannedAt,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server version not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to record vulnerability scan", err)
+			}
+		}
+		return &Response[apiv0.ServerResponse]{Body: *server}, nil
+	})
+}
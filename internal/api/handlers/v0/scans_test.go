@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVulnerabilityScans(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, name := range []string{"com.example/weather", "com.example/maps"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeOCI, Identifier: "docker.io/" + name + "-server:1.0.0", Transport: model.Transport{Type: "stdio"}},
+				{RegistryType: model.RegistryTypeOCI, Identifier: "docker.io/" + name + "-worker:1.0.0", Transport: model.Transport{Type: "stdio"}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterScanEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	admin := tokenFor(auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	publisher := tokenFor(auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"})
+
+	recordScan := func(token, serverName string, body map[string]any) *httptest.ResponseRecorder {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/v0/admin/servers/"+serverName+"/versions/1.0.0/scan", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listNames := func(query string) []string {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		names := []string{}
+		for _, server := range list.Servers {
+			names = append(names, server.Server.Name)
+		}
+		return names
+	}
+
+	scan := map[string]any{"identifier": "docker.io/com.example/weather-server:1.0.0", "high": 1, "low": 3}
+	assert.Equal(t, http.StatusForbidden, recordScan(publisher, "com.example%2Fweather", scan).Code)
+	assert.Equal(t, http.StatusNotFound, recordScan(admin, "com.example%2Fmissing", scan).Code)
+	assert.Equal(t, http.StatusBadRequest, recordScan(admin, "com.example%2Fweather",
+		map[string]any{"identifier": "docker.io/other/image:1.0.0", "high": 1}).Code)
+
+	require.Equal(t, http.StatusOK, recordScan(admin, "com.example%2Fweather", scan).Code)
+	w := recordScan(admin, "com.example%2Fweather", map[string]any{
+		"identifier": "docker.io/com.example/weather-worker:1.0.0", "moderate": 2, "low": 1,
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var server apiv0.ServerResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+	require.NotNil(t, server.Meta.Official.Vulnerabilities)
+	vulnerabilities := *server.Meta.Official.Vulnerabilities
+	assert.Equal(t, []int{0, 1, 2, 4}, []int{vulnerabilities.Critical, vulnerabilities.High, vulnerabilities.Moderate, vulnerabilities.Low},
+		"the scans of a version's images are summed")
+
+	// Unscanned versions are kept
+	assert.Equal(t, []string{"com.example/maps"}, listNames("?maxSeverity=moderate"))
+	assert.ElementsMatch(t, []string{"com.example/maps", "com.example/weather"}, listNames("?maxSeverity=high"))
+
+	// Rescanning an image replaces its earlier scan
+	scan["high"] = 0
+	require.Equal(t, http.StatusOK, recordScan(admin, "com.example%2Fweather", scan).Code)
+	assert.ElementsMatch(t, []string{"com.example/maps", "com.example/weather"}, listNames("?maxSeverity=moderate"))
+	assert.Equal(t, []string{"com.example/maps"}, listNames("?maxSeverity=low"))
+}
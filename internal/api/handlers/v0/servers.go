@@ -2,27 +2,129 @@ package v0
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 const errRecordNotFound = "record not found"
 
+const (
+	// latestCacheControl keeps "latest" lookups short-lived, since they move on every publish
+	latestCacheControl = "public, max-age=60"
+	// versionCacheControl caches exact versions for longer. The server.json doesn't change, but the
+	// registry metadata alongside it does when a version is yanked, superseded or gets an advisory.
+	versionCacheControl = "public, max-age=3600"
+	// documentCacheControl caches content-addressed documents forever: an edit changes the digest,
+	// and so the URL
+	documentCacheControl = "public, max-age=31536000, immutable"
+)
+
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor       string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
-	Limit        int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
-	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Cursor             string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Limit              int    `query:"limit" doc:"Number of items per page. Defaults to 30 and is lowered to 100 when larger, unless the deployment configures other page sizes." required:"false" minimum:"1" example:"50"`
+	UpdatedSince       string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Search             string `query:"search" doc:"Search servers by name (substring match), ordered by relevance. Deployments can also search titles and descriptions and tune the ranking." required:"false" example:"filesystem"`
+	Version            string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	IncludeYanked      bool   `query:"include_yanked" doc:"Include yanked versions, which are left out by default" required:"false"`
+	IncludeQuarantined bool   `query:"include_quarantined" doc:"Include servers quarantined by the registry's moderators, which are left out by default" required:"false"`
+	Status             string `query:"status" doc:"Comma-separated statuses (active, deprecated, deleted) to keep. Versions of every status are returned by default; use 'active' to leave out deprecated and deleted versions." required:"false" example:"active,deprecated"`
+	Channel            string `query:"channel" doc:"Only return the version each server's release channel points at. Servers without the channel are left out." required:"false" enum:"latest,stable,beta" example:"stable"`
+	Supports           string `query:"supports" doc:"Comma-separated transports (stdio, streamable-http, sse) and auth methods (oauth, headers) the client supports. Only servers with a package or remote the client can use are returned. Remotes that declare required headers need headers; others are assumed to use OAuth. Auth is only checked when an auth method is listed." required:"false" example:"stdio,oauth"`
+	RegistryType       string `query:"registryType" doc:"Only return servers with a package from this registry. Combined with transport, the same package must use the transport." required:"false" enum:"npm,pypi,oci,nuget,mcpb" example:"oci"`
+	Transport          string `query:"transport" doc:"Only return servers with a package or remote using this transport" required:"false" enum:"stdio,streamable-http,sse" example:"stdio"`
+	Sort               string `query:"sort" doc:"Order by rating, best first, or by the total size of a version's OCI images, smallest first, instead of by name (or relevance for searches). Servers start off with five ratings of 3, so that a few ratings don't outrank many. Versions whose image sizes aren't known sort last by size." required:"false" enum:"rating,size" example:"rating"`
+	Category           string `query:"category" doc:"Only return server versions in this category, as listed by GET /v0/categories. Matching ignores case." required:"false" example:"weather"`
+	Tag                string `query:"tag" doc:"Only return server versions with this tag, as listed by GET /v0/tags. Matching ignores case." required:"false" example:"forecast"`
+	Verified           bool   `query:"verified" doc:"Only return servers in verified namespaces, whose owners proved control of the domain or GitHub organization they are named after" required:"false"`
+	MaxSeverity        string `query:"maxSeverity" doc:"Leave out versions whose scanned OCI images have vulnerabilities more severe than this. Versions that haven't been scanned are kept." required:"false" enum:"low,moderate,high,critical" example:"high"`
+	Platform           string `query:"platform" doc:"Leave out versions whose OCI images aren't built for this platform, as os/architecture with an optional /variant. Versions whose images' platforms aren't known are kept." required:"false" pattern:"^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$" example:"linux/arm64"`
+	MaxImageSize       int64  `query:"maxImageSize" doc:"Leave out versions with an OCI image whose compressed size is larger than this many bytes. Versions whose image sizes aren't known are kept." required:"false" minimum:"1" example:"104857600"`
+	Snapshot           string `query:"snapshot" doc:"Read a snapshot of the registry instead of its current state, so that paging through results sees one consistent state. Use 'new' on the first page to get a snapshot, returned in metadata.snapshot, and pass it on every later page. Snapshots last an hour." required:"false" example:"new"`
+	Count              bool   `query:"count" doc:"Include the total number of matching servers in the metadata. Totals above 10000 are estimated." required:"false"`
+	Fields             string `query:"fields" doc:"Comma-separated top-level fields of each server.json to return, leaving out the others. The registry metadata then only has each version's status, dates and isLatest, unless _meta is one of the fields." required:"false" example:"name,description,version"`
+	ConditionalParams
+}
+
+// ServerListOutput is a page of server versions with the validators conditional requests check.
+// TotalCount repeats metadata.total for clients that read it from the X-Total-Count header.
+type ServerListOutput struct {
+	ETag         string    `header:"ETag"`
+	LastModified time.Time `header:"Last-Modified"`
+	TotalCount   string    `header:"X-Total-Count"`
+	Body         ServerListBody
+}
+
+// ServerListBody is a page of server versions. With fields selected, each server.json only has
+// those of its top-level fields.
+type ServerListBody struct {
+	apiv0.ServerListResponse
+	fields []string
+}
+
+// MarshalJSON encodes the page, leaving out the server.json fields that weren't selected
+func (b ServerListBody) MarshalJSON() ([]byte, error) {
+	if len(b.fields) == 0 {
+		return json.Marshal(b.ServerListResponse)
+	}
+
+	type sparseServer struct {
+		Server map[string]json.RawMessage `json:"server"`
+		Meta   apiv0.ResponseMeta         `json:"_meta"`
+	}
+	servers := make([]sparseServer, len(b.Servers))
+	for i, server := range b.Servers {
+		data, err := json.Marshal(server.Server)
+		if err != nil {
+			return nil, err
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		for name := range fields {
+			if !slices.Contains(b.fields, name) {
+				delete(fields, name)
+			}
+		}
+		servers[i] = sparseServer{Server: fields, Meta: server.Meta}
+	}
+	return json.Marshal(struct {
+		Servers  []sparseServer `json:"servers"`
+		Metadata apiv0.Metadata `json:"metadata"`
+	}{servers, b.Metadata})
+}
+
+// SearchServersInput represents the input for a full-text search of servers
+type SearchServersInput struct {
+	Query  string `query:"q" doc:"Words to search for. Supports \"quoted phrases\", -excluded words and 'or'" minLength:"1" maxLength:"200" required:"true" example:"weather forecast"`
+	Cursor string `query:"cursor" doc:"Pagination cursor" required:"false"`
+	Limit  int    `query:"limit" doc:"Number of results per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// ServerBatchInput represents the input for getting many server versions at once
+type ServerBatchInput struct {
+	Body struct {
+		Servers []apiv0.ServerBatchItem `json:"servers" minItems:"1" maxItems:"100" doc:"Server versions to get, up to 100"`
+	}
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -34,24 +136,55 @@ type ServerDetailInput struct {
 type ServerVersionDetailInput struct {
 	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Channel    string `query:"channel" doc:"With version 'latest', resolve this release channel instead" required:"false" enum:"latest,stable,beta" example:"stable"`
+	UserAgent  string `header:"User-Agent" doc:"Client making the request. Requests from bots aren't counted towards the server's usage" required:"false"`
+	ConditionalParams
+}
+
+// ServerVersionOutput is a server version with the headers CDNs cache it by. Link points at the
+// version's server.json under its immutable, content-addressed URL.
+type ServerVersionOutput struct {
+	CacheControl string    `header:"Cache-Control"`
+	ETag         string    `header:"ETag"`
+	LastModified time.Time `header:"Last-Modified"`
+	Link         string    `header:"Link"`
+	Body         apiv0.ServerResponse
+}
+
+// ServerDocumentInput represents the input for getting a server.json by its digest
+type ServerDocumentInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Digest     string `path:"digest" doc:"Hex-encoded SHA-256 of the server.json, as in the Link of the version" pattern:"^[0-9a-f]{64}$"`
+}
+
+// ServerDocumentOutput is a server.json served under its content-addressed URL
+type ServerDocumentOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	ETag         string `header:"ETag"`
+	Body         apiv0.ServerJSON
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	IncludeYanked bool   `query:"include_yanked" doc:"Include yanked versions, which are left out by default" required:"false"`
+	Status        string `query:"status" doc:"Comma-separated statuses (active, deprecated, deleted) to keep. Versions of every status are returned by default; use 'active' to leave out deprecated and deleted versions." required:"false" example:"active,deprecated"`
+	ConditionalParams
 }
 
 // RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
-func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	// List servers endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "list-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers",
 		Summary:     "List MCP servers",
-		Description: "Get a paginated list of MCP servers from the registry",
-		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
+		Description: "Get a paginated list of MCP servers from the registry. " +
+			"Responses carry an ETag and Last-Modified, and conditional requests get 304 Not Modified when the page hasn't changed.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ListServersInput) (*ServerListOutput, error) {
 		// Build filter from input parameters
 		filter := &database.ServerFilter{}
 
@@ -82,9 +215,117 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			}
 		}
 
+		// Leave out yanked versions unless asked for
+		if !input.IncludeYanked {
+			yanked := false
+			filter.Yanked = &yanked
+		}
+
+		// Leave out quarantined servers unless asked for
+		if !input.IncludeQuarantined {
+			quarantined := false
+			filter.Quarantined = &quarantined
+		}
+
+		// Handle status parameter
+		if input.Status != "" {
+			statuses, err := parseStatuses(input.Status)
+			if err != nil {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			filter.Statuses = statuses
+		}
+
+		// Handle channel parameter
+		if input.Channel == service.ReleaseChannelLatest {
+			isLatest := true
+			filter.IsLatest = &isLatest
+		} else if input.Channel != "" {
+			filter.Channel = &input.Channel
+		}
+
+		// Handle supports parameter
+		if input.Supports != "" {
+			supports, err := parseClientSupports(input.Supports)
+			if err != nil {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			filter.Supports = supports
+		}
+
+		// Handle registryType parameter
+		if input.RegistryType != "" {
+			filter.RegistryType = &input.RegistryType
+		}
+
+		// Handle transport parameter
+		if input.Transport != "" {
+			filter.Transport = &input.Transport
+		}
+
+		// Handle category and tag parameters
+		if input.Category != "" {
+			filter.Category = &input.Category
+		}
+		if input.Tag != "" {
+			filter.Tag = &input.Tag
+		}
+
+		// Handle verified parameter
+		if input.Verified {
+			filter.Verified = &input.Verified
+		}
+
+		// Handle maxSeverity parameter
+		if input.MaxSeverity != "" {
+			filter.MaxSeverity = &input.MaxSeverity
+		}
+
+		// Handle platform parameter
+		if input.Platform != "" {
+			filter.Platform = &input.Platform
+		}
+
+		// Handle maxImageSize parameter
+		if input.MaxImageSize > 0 {
+			filter.MaxImageSize = &input.MaxImageSize
+		}
+
+		// Handle fields parameter
+		if input.Fields != "" {
+			fields, err := parseFields(input.Fields)
+			if err != nil {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			filter.Fields = fields
+		}
+
+		// Handle sort parameter
+		filter.SortByRating = input.Sort == "rating"
+		filter.SortBySize = input.Sort == "size"
+
+		// Handle snapshot parameter, taking a snapshot for the first page of a consistent read
+		switch input.Snapshot {
+		case "":
+		case "new":
+			snapshot, err := registry.CreateSnapshot(ctx)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to create snapshot", err)
+			}
+			filter.Snapshot = &snapshot.ID
+		default:
+			filter.Snapshot = &input.Snapshot
+		}
+
 		// Get paginated results with filtering
-		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, listPageSize(cfg, input.Limit))
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			if errors.Is(err, service.ErrSnapshotExpired) {
+				return nil, huma.Error410Gone("Snapshot not found or expired; start again with snapshot=new")
+			}
 			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
 		}
 
@@ -94,13 +335,119 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					NextCursor: nextCursor,
-					Count:      len(servers),
-				},
+		metadata := apiv0.Metadata{
+			NextCursor: nextCursor,
+			Count:      len(servers),
+		}
+		if filter.Snapshot != nil {
+			metadata.Snapshot = *filter.Snapshot
+		}
+
+		// Count every match when asked, since it costs an extra query
+		if input.Count {
+			total, estimated, err := registry.CountServers(ctx, filter)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to count servers", err)
+			}
+			metadata.Total = &total
+			metadata.Estimated = estimated
+		}
+
+		body := ServerListBody{
+			ServerListResponse: apiv0.ServerListResponse{
+				Servers:  serverValues,
+				Metadata: metadata,
+			},
+			fields: filter.Fields,
+		}
+		etag, err := responseETag(body)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode registry list", err)
+		}
+		modified := lastModified(servers...)
+		if err := input.notModified(etag, modified); err != nil {
+			return nil, err
+		}
+
+		output := &ServerListOutput{
+			ETag:         etag,
+			LastModified: modified,
+			Body:         body,
+		}
+		if metadata.Total != nil {
+			output.TotalCount = strconv.Itoa(*metadata.Total)
+		}
+		return output, nil
+	})
+
+	// Full-text search endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "search-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/search",
+		Summary:     "Search MCP servers",
+		Description: "Search the latest version of every server by the words in its name, title, description, and publisher-provided tags and tools, " +
+			"most relevant first, with the matches in each description highlighted.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *SearchServersInput) (*Response[apiv0.ServerSearchResponse], error) {
+		results, nextCursor, err := registry.SearchServers(ctx, input.Query, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to search servers", err)
+		}
+		return &Response[apiv0.ServerSearchResponse]{
+			Body: apiv0.ServerSearchResponse{
+				Results:  results,
+				Metadata: apiv0.Metadata{NextCursor: nextCursor, Count: len(results)},
+			},
+		}, nil
+	})
+
+	// Batch fetch endpoint, for clients syncing a subset of servers
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-batch" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/batch",
+		Summary:     "Get many MCP server versions",
+		Description: "Get up to 100 server versions in one request, each by name and an exact version or 'latest'. " +
+			"Every item gets a result with its own status, so a server that moved or doesn't exist doesn't fail the others.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ServerBatchInput) (*Response[apiv0.ServerBatchResponse], error) {
+		names := make([]string, len(input.Body.Servers))
+		versions := make([]string, len(input.Body.Servers))
+		for i, item := range input.Body.Servers {
+			names[i] = item.Name
+			versions[i] = item.Version
+		}
+
+		servers, err := registry.GetServerBatch(ctx, names, versions)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get servers", err)
+		}
+
+		results := make([]apiv0.ServerBatchResult, len(servers))
+		for i, server := range servers {
+			result := apiv0.ServerBatchResult{Name: names[i], Version: versions[i], Status: http.StatusOK, Server: server}
+			if result.Version == "" {
+				result.Version = "latest"
+			}
+			if server == nil {
+				// Report where moved servers can be found, as the single server endpoints redirect
+				result.Status = http.StatusNotFound
+				if redirect, err := registry.GetServerRedirect(ctx, names[i]); err == nil {
+					result.Status = http.StatusMovedPermanently
+					result.Location = redirect.To
+				}
+			}
+			results[i] = result
+		}
+
+		return &Response[apiv0.ServerBatchResponse]{
+			Body: apiv0.ServerBatchResponse{
+				Results:  results,
+				Metadata: apiv0.Metadata{Count: len(results)},
 			},
 		}, nil
 	})
@@ -111,9 +458,12 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 		Summary:     "Get specific MCP server version",
-		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version.",
-		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
+		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version. " +
+			"Responses for 'latest' are cached briefly and for exact versions for an hour. " +
+			"The Link header points at the version's server.json under an immutable URL that can be cached forever. " +
+			"Conditional requests get 304 Not Modified when the version hasn't changed.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*ServerVersionOutput, error) {
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -127,22 +477,104 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		}
 
 		var serverResponse *apiv0.ServerResponse
-		// Handle "latest" as a special version
-		if version == "latest" {
+		// Handle "latest" as a special version, resolving the requested release channel
+		switch {
+		case version == "latest" && input.Channel != "":
+			serverResponse, err = registry.GetServerByChannel(ctx, serverName, input.Channel)
+		case version == "latest":
 			serverResponse, err = registry.GetServerByName(ctx, serverName)
-		} else {
+		case input.Channel != "":
+			return nil, huma.Error400BadRequest("The channel parameter can only be used with version 'latest'")
+		default:
 			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
 		}
 
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+				subPath := "/versions/" + url.PathEscape(version)
+				if input.Channel != "" {
+					if _, err := registry.GetServerByName(ctx, serverName); err == nil {
+						return nil, huma.Error404NotFound("No version of " + serverName + " is in the " + input.Channel + " channel")
+					}
+					subPath += "?channel=" + url.QueryEscape(input.Channel)
+				}
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, subPath)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		// Counting is best effort, a failure shouldn't fail the request
+		if err := registry.RecordServerUsage(ctx, serverResponse.Server.Name, service.ServerUsageRequest, input.UserAgent); err != nil {
+			log.Printf("Failed to record request for %s: %v", serverResponse.Server.Name, err)
+		}
+
+		digest, err := ServerDocumentDigest(&serverResponse.Server)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode server details", err)
+		}
+		cacheControl := versionCacheControl
+		if version == "latest" {
+			cacheControl = latestCacheControl
+		}
+		documentPath := pathPrefix + "/servers/" + url.PathEscape(serverResponse.Server.Name) +
+			"/versions/" + url.PathEscape(serverResponse.Server.Version) + "/documents/" + digest
+
+		etag, err := responseETag(serverResponse)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode server details", err)
+		}
+		modified := lastModified(serverResponse)
+		if err := input.notModified(etag, modified); err != nil {
+			return nil, huma.ErrorWithHeaders(err, http.Header{"Cache-Control": {cacheControl}})
+		}
+
+		return &ServerVersionOutput{
+			CacheControl: cacheControl,
+			ETag:         etag,
+			LastModified: modified,
+			Link:         "<" + documentPath + `>; rel="alternate"; type="application/json"`,
+			Body:         *serverResponse,
+		}, nil
+	})
+
+	// Get a server.json by its digest, which never changes and so can be cached forever
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-document" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/documents/{digest}",
+		Summary:     "Get server.json by digest",
+		Description: "Get the server.json of a version by its SHA-256 digest, as linked from the version. " +
+			"The response is immutable and can be cached forever. Once an admin edits the version, its old digest is no longer found.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ServerDocumentInput) (*ServerDocumentOutput, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		serverResponse, err := registry.GetServerByNameAndVersion(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
+		digest, err := ServerDocumentDigest(&serverResponse.Server)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode server details", err)
+		}
+		if digest != input.Digest {
+			return nil, huma.Error404NotFound("No server.json of this version has that digest")
+		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *serverResponse,
+		return &ServerDocumentOutput{
+			CacheControl: documentCacheControl,
+			ETag:         `"` + digest + `"`,
+			Body:         serverResponse.Server,
 		}, nil
 	})
 
@@ -152,9 +584,9 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions",
 		Summary:     "Get all versions of an MCP server",
-		Description: "Get all available versions for a specific MCP server",
+		Description: "Get all available versions for a specific MCP server. Conditional requests get 304 Not Modified when none has changed.",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionsInput) (*ServerListOutput, error) {
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -165,24 +597,159 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		servers, err := registry.GetAllVersionsByServerName(ctx, serverName)
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Server not found")
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/versions")
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server versions", err)
 		}
 
-		// Convert []*ServerResponse to []ServerResponse
-		serverValues := make([]apiv0.ServerResponse, len(servers))
-		for i, server := range servers {
-			serverValues[i] = *server
+		// Convert []*ServerResponse to []ServerResponse, leaving out yanked versions unless asked for
+		serverValues := make([]apiv0.ServerResponse, 0, len(servers))
+		for _, server := range servers {
+			if !input.IncludeYanked && server.Meta.Official != nil && server.Meta.Official.YankedAt != nil {
+				continue
+			}
+			serverValues = append(serverValues, *server)
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					Count: len(servers),
-				},
+		body := ServerListBody{ServerListResponse: apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				Count: len(serverValues),
 			},
+		}}
+		etag, err := responseETag(body)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to encode server versions", err)
+		}
+		modified := lastModified(servers...)
+		if err := input.notModified(etag, modified); err != nil {
+			return nil, err
+		}
+
+		return &ServerListOutput{
+			ETag:         etag,
+			LastModified: modified,
+			Body:         body,
 		}, nil
 	})
 }
+
+// listPageSize returns the number of servers to list for a requested limit: the deployment's
+// default page size when none was requested, and at most its maximum page size
+func listPageSize(cfg *config.Config, limit int) int {
+	defaultSize, maxSize := cfg.DefaultPageSize, cfg.MaxPageSize
+	if defaultSize <= 0 {
+		defaultSize = 30
+	}
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+	if limit <= 0 {
+		limit = defaultSize
+	}
+	return min(limit, maxSize)
+}
+
+// serverNotFound returns a permanent redirect to the same path under a server's current name if
+// the name was renamed away from or is an alias, and a 404 otherwise
+func serverNotFound(ctx context.Context, registry service.RegistryService, pathPrefix, serverName, subPath string) error {
+	redirect, err := registry.GetServerRedirect(ctx, serverName)
+	if err != nil {
+		return huma.Error404NotFound("Server not found")
+	}
+	message := "Server moved to " + redirect.To
+	if redirect.Reason == service.ServerRedirectAlias {
+		message = serverName + " is an alias of " + redirect.To
+	}
+	location := pathPrefix + "/servers/" + url.PathEscape(redirect.To) + subPath
+	return huma.ErrorWithHeaders(
+		huma.NewError(http.StatusMovedPermanently, message),
+		http.Header{"Location": {location}},
+	)
+}
+
+// ServerDocumentDigest returns the hex-encoded SHA-256 of a server.json as the registry encodes it
+func ServerDocumentDigest(server *apiv0.ServerJSON) (string, error) {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// parseClientSupports splits the supports query parameter, checking it names known transports
+// and auth methods and at least one transport
+func parseClientSupports(raw string) ([]string, error) {
+	var supports []string
+	hasTransport := false
+	for _, capability := range strings.Split(raw, ",") {
+		capability = strings.TrimSpace(capability)
+		switch capability {
+		case "":
+			continue
+		case model.TransportTypeStdio, model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
+			hasTransport = true
+		case model.ClientCapabilityOAuth, model.ClientCapabilityHeaders:
+		default:
+			return nil, fmt.Errorf("unknown capability in supports: %s (expected stdio, streamable-http, sse, oauth or headers)", capability)
+		}
+		supports = append(supports, capability)
+	}
+	if !hasTransport {
+		return nil, errors.New("supports must list at least one transport: stdio, streamable-http or sse")
+	}
+	return supports, nil
+}
+
+// serverFields are the top-level fields of a server.json, which the fields parameter selects from
+var serverFields = func() []string {
+	t := reflect.TypeFor[apiv0.ServerJSON]()
+	fields := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}()
+
+// parseFields splits the fields query parameter, checking it names top-level server.json fields
+func parseFields(raw string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "":
+			continue
+		case !slices.Contains(serverFields, field):
+			return nil, fmt.Errorf("unknown field: %s (expected one of %s)", field, strings.Join(serverFields, ", "))
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("fields must list at least one server.json field")
+	}
+	return fields, nil
+}
+
+// parseStatuses splits the status query parameter, checking it names known statuses
+func parseStatuses(raw string) ([]string, error) {
+	var statuses []string
+	for _, status := range strings.Split(raw, ",") {
+		status = strings.TrimSpace(status)
+		switch model.Status(status) {
+		case "":
+			continue
+		case model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+		default:
+			return nil, fmt.Errorf("unknown status: %s (expected active, deprecated or deleted)", status)
+		}
+		statuses = append(statuses, status)
+	}
+	if len(statuses) == 0 {
+		return nil, errors.New("status must list at least one of active, deprecated or deleted")
+	}
+	return statuses, nil
+}
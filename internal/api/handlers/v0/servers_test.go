@@ -3,9 +3,12 @@ package v0_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -44,7 +47,7 @@ func TestListServersEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -130,7 +133,7 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -210,7 +213,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -321,7 +324,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	tests := []struct {
 		name           string
@@ -420,7 +423,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
 
 	t.Run("URL encoding edge cases", func(t *testing.T) {
 		tests := []struct {
@@ -458,7 +461,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 			expectedStatus int
 			expectedError  string
 		}{
-			{"limit too high", "?limit=1000", http.StatusUnprocessableEntity, "validation failed"},
+			{"limit too high is lowered", "?limit=1000", http.StatusOK, ""},
 			{"negative limit", "?limit=-1", http.StatusUnprocessableEntity, "validation failed"},
 			{"invalid updated_since format", "?updated_since=invalid", http.StatusBadRequest, "Invalid updated_since format"},
 			{"future updated_since", "?updated_since=2030-01-01T00:00:00Z", http.StatusOK, ""},
@@ -518,3 +521,437 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestListServersSupportsFilter(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	servers := []apiv0.ServerJSON{
+		{
+			Name: "com.example/local",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM, Identifier: "@example/local", Version: "1.0.0",
+				Transport: model.Transport{Type: model.TransportTypeStdio},
+			}},
+		},
+		{
+			Name:    "com.example/hosted",
+			Remotes: []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://mcp.example.com/hosted"}},
+		},
+		{
+			Name: "com.example/keyed",
+			Remotes: []model.Transport{{
+				Type: model.TransportTypeStreamableHTTP, URL: "https://mcp.example.com/keyed",
+				Headers: []model.KeyValueInput{{Name: "X-API-Key", InputWithVariables: model.InputWithVariables{
+					Input: model.Input{IsRequired: true, IsSecret: true},
+				}}},
+			}},
+		},
+	}
+	for _, server := range servers {
+		server.Schema = model.CurrentSchemaURL
+		server.Description = "Test server"
+		server.Version = "1.0.0"
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+
+	tests := []struct {
+		supports string
+		expected []string
+	}{
+		{supports: "stdio", expected: []string{"com.example/local"}},
+		{supports: "streamable-http", expected: []string{"com.example/hosted", "com.example/keyed"}},
+		{supports: "streamable-http,oauth", expected: []string{"com.example/hosted"}},
+		{supports: "streamable-http,headers", expected: []string{"com.example/keyed"}},
+		{supports: "stdio, sse,oauth", expected: []string{"com.example/local"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.supports, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?supports="+url.QueryEscape(tt.supports), nil))
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			names := make([]string, 0, len(resp.Servers))
+			for _, server := range resp.Servers {
+				names = append(names, server.Server.Name)
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+
+	for _, supports := range []string{"oauth", "stdio,carrier-pigeon"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?supports="+url.QueryEscape(supports), nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, supports)
+	}
+}
+
+func TestListServersCount(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	for i := range 3 {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        fmt.Sprintf("com.example/count-%d", i),
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedCount int
+		expectedTotal *int
+	}{
+		{name: "total not requested", query: "limit=2", expectedCount: 2},
+		{name: "total of all servers", query: "limit=2&count=true", expectedCount: 2, expectedTotal: intPtr(3)},
+		{name: "total of search", query: "search=count-1&count=true", expectedCount: 1, expectedTotal: intPtr(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?"+tt.query, nil))
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp apiv0.ServerListResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, tt.expectedCount, resp.Metadata.Count)
+			assert.Equal(t, tt.expectedTotal, resp.Metadata.Total)
+			assert.False(t, resp.Metadata.Estimated)
+			if tt.expectedTotal != nil {
+				assert.Equal(t, strconv.Itoa(*tt.expectedTotal), w.Header().Get("X-Total-Count"))
+			} else {
+				assert.Empty(t, w.Header().Get("X-Total-Count"))
+			}
+		})
+	}
+}
+
+func TestListServersPageSize(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	for i := range 5 {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        fmt.Sprintf("com.example/page-%d", i),
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.DefaultPageSize = 2
+	cfg.MaxPageSize = 3
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+
+	list := func(query string) apiv0.ServerListResponse {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	assert.Equal(t, 2, list("").Metadata.Count, "pages without a limit use the default page size")
+	assert.Equal(t, 3, list("limit=50").Metadata.Count, "larger limits are lowered to the maximum")
+
+	// Paging through with the opaque cursors returns every server once
+	var names []string
+	cursor := ""
+	for {
+		resp := list("limit=2&cursor=" + url.QueryEscape(cursor))
+		for _, s := range resp.Servers {
+			names = append(names, s.Server.Name)
+		}
+		if resp.Metadata.NextCursor == "" {
+			break
+		}
+		assert.NotContains(t, resp.Metadata.NextCursor, ":", "cursors are opaque")
+		cursor = resp.Metadata.NextCursor
+	}
+	assert.Equal(t, []string{
+		"com.example/page-0", "com.example/page-1", "com.example/page-2", "com.example/page-3", "com.example/page-4",
+	}, names)
+
+	// Cursors from before they were opaque are still accepted
+	resp := list("limit=2&cursor=" + url.QueryEscape("com.example/page-1:1.0.0"))
+	require.Len(t, resp.Servers, 2)
+	assert.Equal(t, "com.example/page-2", resp.Servers[0].Server.Name)
+}
+
+func TestListServersFields(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	for i := range 3 {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        fmt.Sprintf("com.example/fields-%d", i),
+			Description: "Test server",
+			Version:     "1.0.0",
+			Packages:    []model.Package{{RegistryType: "npm", Identifier: "@example/fields", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}}},
+			Meta:        &apiv0.ServerMeta{PublisherProvided: map[string]any{"tier": "free"}},
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+
+	list := func(query string) (map[string]any, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?"+query, nil))
+		var resp map[string]any
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		}
+		return resp, w
+	}
+
+	t.Run("returns only the selected fields", func(t *testing.T) {
+		resp, w := list("fields=name,description&limit=2")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		servers := resp["servers"].([]any)
+		require.Len(t, servers, 2)
+		first := servers[0].(map[string]any)
+		assert.Equal(t, map[string]any{"name": "com.example/fields-0", "description": "Test server"}, first["server"])
+		official := first["_meta"].(map[string]any)["io.modelcontextprotocol.registry/official"].(map[string]any)
+		assert.Equal(t, "active", official["status"])
+		assert.Contains(t, official, "isLatest")
+
+		// Cursors page on even though name and version may not be selected
+		cursor := resp["metadata"].(map[string]any)["nextCursor"].(string)
+		resp, w = list("fields=description&limit=2&cursor=" + url.QueryEscape(cursor))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		servers = resp["servers"].([]any)
+		require.Len(t, servers, 1)
+		assert.Equal(t, map[string]any{"description": "Test server"}, servers[0].(map[string]any)["server"])
+	})
+
+	t.Run("selects _meta", func(t *testing.T) {
+		resp, w := list("fields=_meta,version&search=fields-1")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		servers := resp["servers"].([]any)
+		require.Len(t, servers, 1)
+		assert.Equal(t, map[string]any{
+			"version": "1.0.0",
+			"_meta":   map[string]any{"io.modelcontextprotocol.registry/publisher-provided": map[string]any{"tier": "free"}},
+		}, servers[0].(map[string]any)["server"])
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		for _, fields := range []string{"name,tools", "server", ","} {
+			_, w := list("fields=" + url.QueryEscape(fields))
+			assert.Equal(t, http.StatusBadRequest, w.Code, fields)
+		}
+	})
+}
+
+func TestServerVersionCaching(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/cached-server"
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Cached server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	latest := get("/v0/servers/" + url.PathEscape(serverName) + "/versions/latest")
+	require.Equal(t, http.StatusOK, latest.Code)
+	assert.Equal(t, "public, max-age=60", latest.Header().Get("Cache-Control"))
+
+	exact := get("/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0")
+	require.Equal(t, http.StatusOK, exact.Code)
+	assert.Equal(t, "public, max-age=3600", exact.Header().Get("Cache-Control"))
+	assert.Equal(t, latest.Header().Get("ETag"), exact.Header().Get("ETag"))
+
+	// The Link header points at the immutable server.json
+	var documentPath string
+	for _, link := range exact.Header().Values("Link") {
+		if strings.Contains(link, `rel="alternate"`) {
+			documentPath = link[strings.Index(link, "<")+1 : strings.Index(link, ">")]
+		}
+	}
+	require.NotEmpty(t, documentPath)
+
+	document := get(documentPath)
+	require.Equal(t, http.StatusOK, document.Code)
+	assert.Equal(t, "public, max-age=31536000, immutable", document.Header().Get("Cache-Control"))
+	var server apiv0.ServerJSON
+	require.NoError(t, json.NewDecoder(document.Body).Decode(&server))
+	assert.Equal(t, serverName, server.Name)
+	assert.Equal(t, "1.0.0", server.Version)
+
+	// Digests of other content aren't found
+	stale := get("/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0/documents/" + strings.Repeat("0", 64))
+	assert.Equal(t, http.StatusNotFound, stale.Code)
+}
+
+func TestConditionalRequests(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/conditional-server"
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Conditional server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, path := range []string{
+		"/v0/servers?search=conditional",
+		"/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0",
+		"/v0/servers/" + url.PathEscape(serverName) + "/versions",
+	} {
+		t.Run(path, func(t *testing.T) {
+			first := get(path, nil)
+			require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+			etag := first.Header().Get("ETag")
+			modified := first.Header().Get("Last-Modified")
+			require.NotEmpty(t, etag)
+			require.NotEmpty(t, modified)
+
+			cached := get(path, http.Header{"If-None-Match": {`"other", ` + etag}})
+			assert.Equal(t, http.StatusNotModified, cached.Code)
+			assert.Empty(t, cached.Body.String())
+			assert.Equal(t, etag, cached.Header().Get("ETag"))
+
+			assert.Equal(t, http.StatusNotModified, get(path, http.Header{"If-Modified-Since": {modified}}).Code)
+			assert.Equal(t, http.StatusOK, get(path, http.Header{"If-None-Match": {`"other"`}}).Code)
+			// If-Modified-Since is ignored alongside If-None-Match
+			assert.Equal(t, http.StatusOK, get(path, http.Header{"If-None-Match": {`"other"`}, "If-Modified-Since": {modified}}).Code)
+		})
+	}
+
+	// Yanking changes the ETag even though the server.json is the same
+	path := "/v0/servers/" + url.PathEscape(serverName) + "/versions/1.0.0"
+	etag := get(path, nil).Header().Get("ETag")
+	_, err = registryService.YankServerVersion(ctx, serverName, "1.0.0", true)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, get(path, http.Header{"If-None-Match": {etag}}).Code)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestGetServerBatchEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/batch",
+			Description: "Batch server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/old-name",
+		Description: "Renamed server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	_, err = registryService.RenameServer(ctx, "com.example/old-name", "com.example/new-name")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+
+	body := `{"servers":[
+		{"name":"com.example/batch"},
+		{"name":"com.example/batch","version":"1.0.0"},
+		{"name":"com.example/batch","version":"3.0.0"},
+		{"name":"com.example/old-name"},
+		{"name":"com.example/missing","version":"latest"}
+	]}`
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v0/servers/batch", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp apiv0.ServerBatchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Results, 5)
+	assert.Equal(t, 5, resp.Metadata.Count)
+
+	assert.Equal(t, http.StatusOK, resp.Results[0].Status)
+	assert.Equal(t, "latest", resp.Results[0].Version)
+	require.NotNil(t, resp.Results[0].Server)
+	assert.Equal(t, "2.0.0", resp.Results[0].Server.Server.Version)
+
+	assert.Equal(t, http.StatusOK, resp.Results[1].Status)
+	require.NotNil(t, resp.Results[1].Server)
+	assert.Equal(t, "1.0.0", resp.Results[1].Server.Server.Version)
+
+	assert.Equal(t, http.StatusNotFound, resp.Results[2].Status)
+	assert.Nil(t, resp.Results[2].Server)
+
+	assert.Equal(t, http.StatusMovedPermanently, resp.Results[3].Status)
+	assert.Equal(t, "com.example/new-name", resp.Results[3].Location)
+
+	assert.Equal(t, http.StatusNotFound, resp.Results[4].Status)
+
+	// Batches are bounded
+	items := make([]string, 101)
+	for i := range items {
+		items[i] = `{"name":"com.example/batch"}`
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v0/servers/batch", strings.NewReader(`{"servers":[`+strings.Join(items, ",")+`]}`)))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
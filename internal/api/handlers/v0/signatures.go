@@ -0,0 +1,168 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerSignatureInput represents the input for getting the signature of a server version
+type ServerSignatureInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version    string `query:"version" doc:"Version whose signature to get, the latest by default" required:"false" example:"1.0.0"`
+}
+
+// GetSigningKeyInput represents the input for getting the signing key of a server
+type GetSigningKeyInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// SetSigningKeyInput represents the input for registering the signing key of a server
+type SetSigningKeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          apiv0.SigningKey
+}
+
+// DeleteSigningKeyInput represents the input for deleting the signing key of a server
+type DeleteSigningKeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// RegisterSignatureEndpoints registers the server signature and signing key endpoints with a custom path prefix
+func RegisterSignatureEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-signature" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/signature",
+		Summary:     "Get server signature",
+		Description: "Get the detached signature a server version was published with, over its canonical server.json: " +
+			"the server.json as the registry returns it with object keys sorted and no whitespace.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ServerSignatureInput) (*Response[apiv0.ServerSignature], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		signature, err := registry.GetServerSignature(ctx, serverName, input.Version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				if _, serverErr := registry.GetServerByName(ctx, serverName); errors.Is(serverErr, database.ErrNotFound) {
+					return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/signature")
+				}
+				return nil, huma.Error404NotFound("Server version not found or published unsigned")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server signature", err)
+		}
+		return &Response[apiv0.ServerSignature]{Body: *signature}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-signing-key" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/signing-key",
+		Summary:     "Get signing key",
+		Description: "Get the public key the versions of a server must be signed with.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *GetSigningKeyInput) (*Response[apiv0.SigningKey], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		key, err := registry.GetSigningKey(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server has no signing key")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get signing key", err)
+		}
+		return &Response[apiv0.SigningKey]{Body: *key}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-signing-key" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/signing-key",
+		Summary:     "Register signing key",
+		Description: "Register the public key the versions of a server must be signed with from now on, replacing any other. " +
+			"Keys may be registered before the server's first version is published.",
+		Tags:     []string{"publish"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetSigningKeyInput) (*Response[apiv0.SigningKey], error) {
+		editor, serverName, err := serverEditor(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := registry.SetSigningKey(ctx, serverName, &input.Body, editor)
+		if err != nil {
+			switch {
+			case errors.Is(err, signing.ErrInvalidSignature):
+				return nil, huma.Error422UnprocessableEntity(err.Error())
+			case errors.Is(err, service.ErrNotServerPublisher):
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to set signing key", err)
+		}
+		return &Response[apiv0.SigningKey]{Body: *key}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-signing-key" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/signing-key",
+		Summary:       "Delete signing key",
+		Description:   "Delete the signing key of a server, so that its versions may be published unsigned again. Signatures it verified are kept.",
+		Tags:          []string{"publish"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteSigningKeyInput) (*struct{}, error) {
+		editor, serverName, err := serverEditor(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteSigningKey(ctx, serverName, editor); err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server has no signing key")
+			case errors.Is(err, service.ErrNotServerPublisher):
+				return nil, huma.Error403Forbidden(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete signing key", err)
+		}
+		return nil, nil
+	})
+}
+
+// serverEditor authorizes a token to change what is attached to a server and returns who it
+// changes it as: only the server's original publisher may, unless the token overrides that
+func serverEditor(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, authHeader, encodedName string,
+) (*service.Publisher, string, error) {
+	claims, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, authHeader, encodedName)
+	if err != nil {
+		return nil, "", err
+	}
+	editor, err := serverPublisher(ctx, registry, claims, serverName, hasGlobalPermission(claims, auth.PermissionActionEdit))
+	if err != nil {
+		return nil, "", huma.Error500InternalServerError("Failed to check edit permissions", err)
+	}
+	return editor, serverName, nil
+}
@@ -0,0 +1,145 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSignatures(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+	v0.RegisterSignatureEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(subject string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + subject + "/*"}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob := tokenFor("alice"), tokenFor("bob")
+
+	call := func(method, path, token string, body any, headers map[string]string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// A cosign key pair, which cosign makes for Ed25519 too
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	server := func(version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+		}
+	}
+	signed := func(server *apiv0.ServerJSON) map[string]string {
+		canonical, err := signing.Canonicalize(server)
+		require.NoError(t, err)
+		signature := base64.StdEncoding.EncodeToString(ed25519.Sign(private, canonical))
+		return map[string]string{
+			"Server-Signature":        base64.StdEncoding.EncodeToString([]byte(signature)),
+			"Server-Signature-Format": signing.FormatCosign,
+		}
+	}
+	const keyPath = "/v0/servers/io.github.alice%2Fweather/signing-key"
+	const signaturePath = "/v0/servers/io.github.alice%2Fweather/signature"
+
+	// Keys can be registered before the first version is published
+	key := map[string]any{"format": signing.FormatCosign, "publicKey": publicKey}
+	assert.Equal(t, http.StatusForbidden, call(http.MethodPut, keyPath, bob, key, nil).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, call(http.MethodPut, keyPath, alice, map[string]any{"format": signing.FormatMinisign, "publicKey": publicKey}, nil).Code)
+	w := call(http.MethodPut, keyPath, alice, key, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var registered apiv0.SigningKey
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registered))
+	assert.Len(t, registered.KeyID, 64)
+
+	// Nor can the registry publish unsigned versions itself, from update proposals or imports
+	_, err = registryService.CreateServer(context.Background(), server("1.0.0"))
+	assert.ErrorIs(t, err, service.ErrSignatureRequired)
+	report, err := registryService.ImportServers(context.Background(), []*apiv0.ServerResponse{{Server: *server("1.0.0")}}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Failed)
+	assert.False(t, report.Applied)
+
+	w = call(http.MethodPost, "/v0/publish", alice, server("1.0.0"), nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "servers with a key must be signed: %s", w.Body.String())
+	w = call(http.MethodPost, "/v0/publish", alice, server("1.0.0"), signed(server("0.9.0")))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "signatures over another server.json don't verify: %s", w.Body.String())
+	w = call(http.MethodPost, "/v0/publish", alice, server("1.0.0"), map[string]string{"Server-Signature": "c2lnbmF0dXJl"})
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	w = call(http.MethodPost, "/v0/publish", alice, server("1.0.0"), signed(server("1.0.0")))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = call(http.MethodGet, signaturePath, "", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var signature apiv0.ServerSignature
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &signature))
+	canonical, err := signing.Canonicalize(server("1.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", signature.Version)
+	assert.Equal(t, signing.Digest(canonical), signature.Digest)
+	assert.Equal(t, registered.KeyID, signature.KeyID)
+	assert.True(t, signature.Verified)
+	assert.NoError(t, signing.Verify(signature.Format, publicKey, canonical, signature.Signature))
+
+	// Without the key, versions may be published unsigned again
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, keyPath, alice, nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodGet, keyPath, "", nil, nil).Code)
+	w = call(http.MethodPost, "/v0/publish", alice, server("1.1.0"), nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusNotFound, call(http.MethodGet, signaturePath, "", nil, nil).Code)
+	assert.Equal(t, http.StatusOK, call(http.MethodGet, signaturePath+"?version=1.0.0", "", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodGet, "/v0/servers/io.github.alice%2Fmissing/signature", "", nil, nil).Code)
+}
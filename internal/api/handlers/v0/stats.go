@@ -0,0 +1,121 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// statsCacheControl caches statistics briefly: they are aggregates that are costly to compute and
+// don't need to be current to the second
+const statsCacheControl = "public, max-age=300"
+
+// TrendingServersInput represents the input for listing trending servers
+type TrendingServersInput struct {
+	Days  int `query:"days" doc:"Number of days, up to today, usage is counted over" default:"7" minimum:"1" maximum:"30" example:"7"`
+	Limit int `query:"limit" doc:"Number of servers to return" default:"20" minimum:"1" maximum:"100" example:"10"`
+}
+
+// ServerInstallInput represents the input for reporting an install of a server
+type ServerInstallInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	UserAgent  string `header:"User-Agent" doc:"Client reporting the install. Installs reported by bots aren't counted" required:"false"`
+}
+
+// RegistryStatsOutput is the registry statistics with the header CDNs cache them by
+type RegistryStatsOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         apiv0.RegistryStats
+}
+
+// TrendingServersOutput is the trending servers with the header CDNs cache them by
+type TrendingServersOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         apiv0.TrendingServersResponse
+}
+
+// RegisterStatsEndpoints registers the registry statistics, trending servers and install reporting endpoints with a custom path prefix
+func RegisterStatsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-registry-stats" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/stats",
+		Summary:     "Get registry statistics",
+		Description: "Get the number of servers listings show, by the registries of their packages and the transports of their packages and remotes, " +
+			"and the number of versions published on each of the last 30 days. Responses are cached for five minutes.",
+		Tags: []string{"stats"},
+	}, func(ctx context.Context, _ *struct{}) (*RegistryStatsOutput, error) {
+		stats, err := registry.GetRegistryStats(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get registry statistics", err)
+		}
+		return &RegistryStatsOutput{CacheControl: statsCacheControl, Body: *stats}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-trending-servers" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/trending",
+		Summary:     "List trending MCP servers",
+		Description: "List the latest versions of the active servers used most over the last days, most used first. " +
+			"Usage is the requests the registry served for a server's details plus how much the pull count its publisher reports under " +
+			"io.modelcontextprotocol.registry/publisher-provided grew, as of the versions published or edited. Responses are cached for five minutes.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *TrendingServersInput) (*TrendingServersOutput, error) {
+		servers, err := registry.ListTrendingServers(ctx, input.Days, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list trending servers", err)
+		}
+		return &TrendingServersOutput{
+			CacheControl: statsCacheControl,
+			Body: apiv0.TrendingServersResponse{
+				Servers:  servers,
+				Metadata: apiv0.Metadata{Count: len(servers)},
+			},
+		}, nil
+	})
+	huma.Register(api, huma.Operation{
+		OperationID: "report-server-install" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/installs",
+		Summary:     "Report MCP server install",
+		Description: "Report that a client installed a server, counting it towards the server's usage under _meta. " +
+			"Installs of renamed servers and aliases are counted for the server they point to. No authentication is needed.",
+		Tags:          []string{"servers"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *ServerInstallInput) (*struct{}, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		server, err := registry.GetServerByName(ctx, serverName)
+		switch {
+		case err == nil:
+			serverName = server.Server.Name
+		case errors.Is(err, database.ErrNotFound):
+			// Clients can't be told to repeat a POST elsewhere, so moves are followed here
+			redirect, err := registry.GetServerRedirect(ctx, serverName)
+			if err != nil {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			serverName = redirect.To
+		default:
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		if err := registry.RecordServerUsage(ctx, serverName, service.ServerUsageInstall, input.UserAgent); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to record install", err)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,120 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/jackc/pgx/v5"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsEndpoints(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	registryService := service.NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, UsageSampleRate: 1})
+
+	publish := func(name, version string, pulls int, packages []model.Package, remotes []model.Transport) {
+		server := &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Stats test server",
+			Version:     version,
+			Packages:    packages,
+			Remotes:     remotes,
+		}
+		if pulls > 0 {
+			server.Meta = &apiv0.ServerMeta{PublisherProvided: map[string]interface{}{"pulls": pulls}}
+		}
+		_, err := registryService.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+	npm := []model.Package{{RegistryType: "npm", Identifier: "weather-mcp", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}}}
+	remote := []model.Transport{{Type: "streamable-http", URL: "https://mcp.example.com/mcp"}}
+
+	publish("com.example/weather", "1.0.0", 100, npm, nil)
+	publish("com.example/weather", "1.1.0", 400, npm, nil)
+	publish("com.example/remote", "1.0.0", 0, nil, remote)
+	publish("com.example/unused", "1.0.0", 0, nil, nil)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, &config.Config{})
+	v0.RegisterStatsEndpoints(api, "/v0", registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	for range 2 {
+		require.Equal(t, http.StatusOK, get("/v0/servers/com.example%2Fremote/versions/latest").Code)
+	}
+
+	t.Run("stats count the servers listings show", func(t *testing.T) {
+		w := get("/v0/stats")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+		var stats apiv0.RegistryStats
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+		assert.Equal(t, 3, stats.TotalServers)
+		assert.Equal(t, map[string]int{"npm": 1}, stats.ByRegistryType)
+		assert.Equal(t, map[string]int{"stdio": 1, "streamable-http": 1}, stats.ByTransport)
+		require.Len(t, stats.PublishesPerDay, service.StatsDays)
+		assert.Equal(t, 4, stats.PublishesPerDay[service.StatsDays-1].Count)
+	})
+
+	t.Run("trending servers are ranked by requests and pull growth", func(t *testing.T) {
+		w := get("/v0/servers/trending?days=7")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var trending apiv0.TrendingServersResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &trending))
+		require.Len(t, trending.Servers, 2)
+		assert.Equal(t, "com.example/weather", trending.Servers[0].Server.Name)
+		assert.Equal(t, "1.1.0", trending.Servers[0].Server.Version)
+		assert.Equal(t, int64(300), trending.Servers[0].Pulls)
+		assert.Equal(t, "com.example/remote", trending.Servers[1].Server.Name)
+		assert.Equal(t, int64(2), trending.Servers[1].Requests)
+	})
+
+	t.Run("installs and requests show in _meta from the next day", func(t *testing.T) {
+		call := func(method, path, userAgent string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(method, path, nil)
+			req.Header.Set("User-Agent", userAgent)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			return w
+		}
+		usage := func() *apiv0.Usage {
+			w := call(http.MethodGet, "/v0/servers/com.example%2Funused/versions/latest", "mcp-client/1.0")
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var server apiv0.ServerResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+			return server.Meta.Official.Usage
+		}
+
+		assert.Equal(t, http.StatusNoContent, call(http.MethodPost, "/v0/servers/com.example%2Funused/installs", "mcp-client/1.0").Code)
+		assert.Equal(t, http.StatusNoContent, call(http.MethodPost, "/v0/servers/com.example%2Funused/installs", "Googlebot/2.1").Code)
+		assert.Equal(t, http.StatusNotFound, call(http.MethodPost, "/v0/servers/com.example%2Fmissing/installs", "mcp-client/1.0").Code)
+		assert.Nil(t, usage())
+
+		require.NoError(t, testDB.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, "UPDATE server_daily_stats SET day = day - 1")
+			return err
+		}))
+		assert.Equal(t, &apiv0.Usage{Requests: 1, Installs: 1}, usage())
+	})
+}
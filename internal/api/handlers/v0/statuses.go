@@ -0,0 +1,138 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SetServerStatusInput represents the input for changing the status of every version of a server
+type SetServerStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server, or admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          apiv0.ServerStatusUpdate
+}
+
+// SetServerVersionStatusInput represents the input for changing the status of a server version
+type SetServerVersionStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server, or admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded version" example:"1.0.0"`
+	Body          apiv0.ServerStatusUpdate
+}
+
+// RegisterStatusEndpoints registers the server status endpoints with a custom path prefix
+func RegisterStatusEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-status" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/status",
+		Summary:     "Set server status",
+		Description: "Deprecate or delete every version of a server, optionally saying why and which server replaces it, or make its versions active again. " +
+			"Deleted versions stay deleted. Requires permission to publish the server, or admin; in organization namespaces only owners may delete.",
+		Tags:     []string{"publish"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetServerStatusInput) (*Response[apiv0.ServerListResponse], error) {
+		serverName, err := authorizeStatusChange(ctx, registry, jwtManager, input.Authorization, input.ServerName, input.Body.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		servers, err := registry.SetServerStatus(ctx, serverName, "", &input.Body)
+		if err != nil {
+			return nil, statusChangeError(err)
+		}
+
+		body := apiv0.ServerListResponse{Servers: make([]apiv0.ServerResponse, 0, len(servers))}
+		for _, server := range servers {
+			body.Servers = append(body.Servers, *server)
+		}
+		body.Metadata.Count = len(body.Servers)
+		return &Response[apiv0.ServerListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-version-status" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/status",
+		Summary:     "Set server version status",
+		Description: "Deprecate or delete a server version, optionally saying why and which server replaces it, or make it active again. " +
+			"Deleted versions can't be restored. Requires permission to publish the server, or admin; in organization namespaces only owners may delete.",
+		Tags:     []string{"publish"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetServerVersionStatusInput) (*Response[apiv0.ServerResponse], error) {
+		serverName, err := authorizeStatusChange(ctx, registry, jwtManager, input.Authorization, input.ServerName, input.Body.Status)
+		if err != nil {
+			return nil, err
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		servers, err := registry.SetServerStatus(ctx, serverName, version, &input.Body)
+		if err != nil {
+			return nil, statusChangeError(err)
+		}
+		return &Response[apiv0.ServerResponse]{Body: *servers[0]}, nil
+	})
+}
+
+// authorizeStatusChange validates the token and checks that it may change the server's status to
+// the given one: admins and publishers of the server may, except that deleting a server in an
+// organization's namespace takes an owner of the organization. It returns the decoded server name.
+func authorizeStatusChange(
+	ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, authHeader, encodedName string, status model.Status,
+) (string, error) {
+	claims, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, authHeader, encodedName)
+	if err != nil {
+		return "", err
+	}
+	if status != model.StatusDeleted || hasGlobalPermission(claims, auth.PermissionActionEdit) {
+		return serverName, nil
+	}
+
+	namespaceName, _, _ := strings.Cut(serverName, "/")
+	namespace, err := registry.GetNamespace(ctx, namespaceName)
+	if err != nil {
+		return "", huma.Error500InternalServerError("Failed to get namespace", err)
+	}
+	if namespace.Organization == "" {
+		return serverName, nil
+	}
+	allowed, err := canEdit(ctx, registry, jwtManager, claims, serverName, true)
+	if err != nil {
+		return "", huma.Error500InternalServerError("Failed to check namespace permissions", err)
+	}
+	if !allowed {
+		return "", huma.Error403Forbidden("You do not have permission to delete this server")
+	}
+	return serverName, nil
+}
+
+// statusChangeError maps service errors for status changes to HTTP errors
+func statusChangeError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Server not found")
+	case errors.Is(err, service.ErrServerDeleted),
+		errors.Is(err, service.ErrUnknownReplacement),
+		errors.Is(err, service.ErrServerReplacesItself):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError("Failed to set server status", err)
+	}
+}
@@ -0,0 +1,128 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerStatusEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, server := range []struct{ name, version string }{
+		{"io.github.alice/weather", "1.0.0"},
+		{"io.github.alice/weather", "1.1.0"},
+		{"io.github.alice/weather-v2", "2.0.0"},
+	} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "Weather server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterStatusEndpoints(api, "/v0", registryService, testConfig)
+
+	token := func(login string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + login + "/*"}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob := token("alice"), token("bob")
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	const path = "/v0/servers/io.github.alice%2Fweather"
+
+	t.Run("publishers and valid replacements only", func(t *testing.T) {
+		deprecate := map[string]any{"status": "deprecated"}
+		assert.Equal(t, http.StatusForbidden, call(http.MethodPut, path+"/status", bob, deprecate).Code)
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPut, path+"/status", alice,
+			map[string]any{"status": "deprecated", "replacedBy": "io.github.alice/missing"}).Code)
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPut, path+"/status", alice,
+			map[string]any{"status": "deprecated", "replacedBy": "io.github.alice/weather"}).Code)
+		assert.Equal(t, http.StatusNotFound, call(http.MethodPut, path+"/versions/9.9.9/status", alice, deprecate).Code)
+	})
+
+	w := call(http.MethodPut, path+"/status", alice, map[string]any{
+		"status": "deprecated", "reason": "Moved to v2", "replacedBy": "io.github.alice/weather-v2",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var changed apiv0.ServerListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &changed))
+	require.Len(t, changed.Servers, 2, "every version changes")
+	for _, server := range changed.Servers {
+		assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+		require.NotNil(t, server.Meta.Official.StatusDetails)
+		assert.Equal(t, "Moved to v2", server.Meta.Official.StatusDetails.Reason)
+		assert.Equal(t, "io.github.alice/weather-v2", server.Meta.Official.StatusDetails.ReplacedBy)
+	}
+
+	t.Run("list filters", func(t *testing.T) {
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(call(http.MethodGet, "/v0/servers?status=active", alice, nil).Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		assert.Equal(t, "io.github.alice/weather-v2", list.Servers[0].Server.Name)
+		require.NoError(t, json.Unmarshal(call(http.MethodGet, "/v0/servers?status=active,deprecated", alice, nil).Body.Bytes(), &list))
+		assert.Len(t, list.Servers, 3)
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodGet, "/v0/servers?status=archived", alice, nil).Code)
+	})
+
+	w = call(http.MethodPut, path+"/versions/1.1.0/status", alice, map[string]any{"status": "active"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var server apiv0.ServerResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+	assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+	assert.Nil(t, server.Meta.Official.StatusDetails, "details belong to the previous change")
+
+	require.Equal(t, http.StatusOK, call(http.MethodPut, path+"/versions/1.0.0/status", alice, map[string]any{"status": "deleted"}).Code)
+	assert.Equal(t, http.StatusBadRequest, call(http.MethodPut, path+"/versions/1.0.0/status", alice,
+		map[string]any{"status": "active"}).Code, "deleted versions can't be restored")
+
+	w = call(http.MethodPut, path+"/status", alice, map[string]any{"status": "active"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &changed))
+	require.Len(t, changed.Servers, 1, "deleted versions are left as they are")
+	assert.Equal(t, "1.1.0", changed.Servers[0].Server.Version)
+}
@@ -0,0 +1,60 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// CategoriesOutput is the categories of the registry's servers with the header CDNs cache them by
+type CategoriesOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         apiv0.CategoriesResponse
+}
+
+// TagsOutput is the tags of the registry's servers with the header CDNs cache them by
+type TagsOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         apiv0.TagsResponse
+}
+
+// RegisterTaxonomyEndpoints registers the category and tag listing endpoints with a custom path prefix
+func RegisterTaxonomyEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-categories" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/categories",
+		Summary:     "List server categories",
+		Description: "List the categories publishers give their servers under io.modelcontextprotocol.registry/publisher-provided, lower-cased, " +
+			"with the number of servers whose latest version listings show is in each, most common first. Responses are cached for five minutes.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, _ *struct{}) (*CategoriesOutput, error) {
+		categories, err := registry.ListCategories(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list categories", err)
+		}
+		return &CategoriesOutput{CacheControl: statsCacheControl, Body: apiv0.CategoriesResponse{Categories: categories}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-tags" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/tags",
+		Summary:     "List server tags",
+		Description: "List the tags publishers give their servers under io.modelcontextprotocol.registry/publisher-provided, lower-cased, " +
+			"with the number of servers whose latest version listings show has each, most common first. Responses are cached for five minutes.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, _ *struct{}) (*TagsOutput, error) {
+		tags, err := registry.ListTags(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list tags", err)
+		}
+		return &TagsOutput{CacheControl: statsCacheControl, Body: apiv0.TagsResponse{Tags: tags}}, nil
+	})
+}
@@ -0,0 +1,87 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxonomyEndpoints(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	publish := func(name, version string, publisherProvided map[string]any) {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Taxonomy test server",
+			Version:     version,
+			Meta:        &apiv0.ServerMeta{PublisherProvided: publisherProvided},
+		})
+		require.NoError(t, err)
+	}
+	publish("com.example/weather", "1.0.0", map[string]any{"categories": []any{"Data"}, "tags": []any{"legacy"}})
+	publish("com.example/weather", "1.1.0", map[string]any{"categories": []any{"Weather", "data "}, "tags": []any{"forecast", "forecast", 42}})
+	publish("com.example/maps", "1.0.0", map[string]any{"categories": []any{"data"}, "tags": "not-a-list"})
+	publish("com.example/plain", "1.0.0", nil)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, &config.Config{})
+	v0.RegisterTaxonomyEndpoints(api, "/v0", registryService)
+
+	get := func(path string, v any) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), v))
+	}
+
+	t.Run("counts servers by the categories and tags of their latest version", func(t *testing.T) {
+		var categories apiv0.CategoriesResponse
+		get("/v0/categories", &categories)
+		assert.Equal(t, []apiv0.TaxonomyTerm{{Name: "data", Count: 2}, {Name: "weather", Count: 1}}, categories.Categories)
+
+		var tags apiv0.TagsResponse
+		get("/v0/tags", &tags)
+		assert.Equal(t, []apiv0.TaxonomyTerm{{Name: "forecast", Count: 1}}, tags.Tags)
+	})
+
+	t.Run("filters the server list", func(t *testing.T) {
+		var resp apiv0.ServerListResponse
+		get("/v0/servers?category=DATA", &resp)
+		require.Len(t, resp.Servers, 3, "every version in the category")
+
+		get("/v0/servers?tag=forecast&version=latest", &resp)
+		require.Len(t, resp.Servers, 1)
+		assert.Equal(t, "1.1.0", resp.Servers[0].Server.Version)
+
+		get("/v0/servers?category=weather&tag=legacy", &resp)
+		assert.Empty(t, resp.Servers)
+	})
+
+	t.Run("follows edits", func(t *testing.T) {
+		server, err := registryService.GetServerByNameAndVersion(ctx, "com.example/maps", "1.0.0")
+		require.NoError(t, err)
+		server.Server.Meta = &apiv0.ServerMeta{PublisherProvided: map[string]any{"categories": []any{"maps"}}}
+		_, err = registryService.UpdateServer(ctx, "com.example/maps", "1.0.0", &server.Server, nil, nil, 0)
+		require.NoError(t, err)
+
+		var categories apiv0.CategoriesResponse
+		get("/v0/categories", &categories)
+		assert.Equal(t, []apiv0.TaxonomyTerm{{Name: "data", Count: 1}, {Name: "maps", Count: 1}, {Name: "weather", Count: 1}}, categories.Categories)
+	})
+}
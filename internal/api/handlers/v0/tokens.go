@@ -0,0 +1,293 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// defaultTokenLifetimeDays is how long personal access tokens last when no expiry is given
+const defaultTokenLifetimeDays = 90
+
+// CreatePersonalAccessTokenInput represents the input for creating a personal access token
+type CreatePersonalAccessTokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the principal the token will act as" required:"true"`
+	Body          struct {
+		Name          string                  `json:"name" minLength:"1" maxLength:"100" doc:"Name to recognize the token by" example:"release workflow"`
+		ExpiresInDays int                     `json:"expiresInDays,omitempty" minimum:"1" maximum:"365" default:"90" doc:"Number of days until the token expires"`
+		Permissions   []apiv0.TokenPermission `json:"permissions,omitempty" doc:"What the token may do. Defaults to the publish permissions of the Registry JWT token."`
+	}
+}
+
+// PersonalAccessTokensInput represents the input for listing the caller's personal access tokens
+type PersonalAccessTokensInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+}
+
+// RevokePersonalAccessTokenInput represents the input for revoking a personal access token
+type RevokePersonalAccessTokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the token's owner" required:"true"`
+	ID            string `path:"id" doc:"Token ID"`
+}
+
+// GetPersonalAccessTokenInput represents the input for getting one of the caller's personal access tokens
+type GetPersonalAccessTokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the token's owner" required:"true"`
+	ID            string `path:"id" doc:"Token ID"`
+}
+
+// RotatePersonalAccessTokenInput represents the input for rotating a personal access token
+type RotatePersonalAccessTokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the token's owner" required:"true"`
+	ID            string `path:"id" doc:"Token ID"`
+	Body          *struct {
+		ExpiresInDays int `json:"expiresInDays,omitempty" minimum:"1" maximum:"365" doc:"Number of days until the rotated token expires. Defaults to keeping its current expiry."`
+	}
+}
+
+// ExchangePersonalAccessTokenInput represents the input for exchanging a personal access token
+type ExchangePersonalAccessTokenInput struct {
+	Body struct {
+		Token string `json:"token" doc:"Personal access token" required:"true"`
+	}
+}
+
+// RegisterTokenEndpoints registers the personal access token endpoints with a custom path prefix
+func RegisterTokenEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-personal-access-token" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/tokens",
+		Summary:     "Create personal access token",
+		Description: "Create a long-lived personal access token for automation, acting as the caller with at most the caller's permissions. " +
+			"The token is only returned in this response.",
+		Tags:     []string{"tokens"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *CreatePersonalAccessTokenInput) (*Response[apiv0.CreatedPersonalAccessToken], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions := input.Body.Permissions
+		if len(permissions) == 0 {
+			for _, perm := range claims.Permissions {
+				if perm.Action == auth.PermissionActionPublish {
+					permissions = append(permissions, apiv0.TokenPermission{Action: string(perm.Action), Resource: perm.ResourcePattern})
+				}
+			}
+		}
+		if len(permissions) == 0 {
+			return nil, huma.Error400BadRequest("Your token has no permissions to give to a personal access token")
+		}
+		for _, perm := range permissions {
+			allowed, err := holdsPermission(ctx, registry, jwtManager, claims, perm)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to check permissions", err)
+			}
+			if !allowed {
+				return nil, huma.Error403Forbidden("You cannot give a token permission to " + perm.Action + " " + perm.Resource + " since you do not have it yourself")
+			}
+		}
+
+		expiresInDays := input.Body.ExpiresInDays
+		if expiresInDays == 0 {
+			expiresInDays = defaultTokenLifetimeDays
+		}
+		expiresAt := time.Now().AddDate(0, 0, expiresInDays)
+
+		token, err := registry.CreatePersonalAccessToken(ctx, principalFromClaims(claims), input.Body.Name, permissions, expiresAt)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to create personal access token", err)
+		}
+		return &Response[apiv0.CreatedPersonalAccessToken]{Body: *token}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-personal-access-tokens" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/tokens",
+		Summary:     "List personal access tokens",
+		Description: "List the caller's personal access tokens, including expired and revoked ones, with when each was last used.",
+		Tags:        []string{"tokens"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *PersonalAccessTokensInput) (*Response[apiv0.PersonalAccessTokenListResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens, err := registry.ListPersonalAccessTokens(ctx, principalFromClaims(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list personal access tokens", err)
+		}
+		return &Response[apiv0.PersonalAccessTokenListResponse]{Body: apiv0.PersonalAccessTokenListResponse{Tokens: tokens}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-personal-access-token" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/tokens/{id}",
+		Summary:     "Get personal access token",
+		Description: "Get one of the caller's personal access tokens, with when it was last used, rotated or revoked.",
+		Tags:        []string{"tokens"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *GetPersonalAccessTokenInput) (*Response[apiv0.PersonalAccessToken], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		token, err := registry.GetPersonalAccessToken(ctx, input.ID, principalFromClaims(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Personal access token not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get personal access token", err)
+		}
+		return &Response[apiv0.PersonalAccessToken]{Body: *token}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rotate-personal-access-token" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/tokens/{id}/rotate",
+		Summary:     "Rotate personal access token",
+		Description: "Replace the secret of one of the caller's personal access tokens, keeping its name and permissions. " +
+			"The old secret stops working straight away. The new one is only returned in this response.",
+		Tags:     []string{"tokens"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *RotatePersonalAccessTokenInput) (*Response[apiv0.CreatedPersonalAccessToken], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		var expiresAt *time.Time
+		if input.Body != nil && input.Body.ExpiresInDays > 0 {
+			at := time.Now().AddDate(0, 0, input.Body.ExpiresInDays)
+			expiresAt = &at
+		}
+
+		token, err := registry.RotatePersonalAccessToken(ctx, input.ID, principalFromClaims(claims), expiresAt)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Personal access token not found")
+			case errors.Is(err, service.ErrPersonalAccessTokenRevoked):
+				return nil, huma.Error409Conflict("Revoked personal access tokens can't be rotated")
+			case errors.Is(err, service.ErrPersonalAccessTokenExpired):
+				return nil, huma.Error400BadRequest("The token has expired; give expiresInDays to rotate it")
+			}
+			return nil, huma.Error500InternalServerError("Failed to rotate personal access token", err)
+		}
+		return &Response[apiv0.CreatedPersonalAccessToken]{Body: *token}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-personal-access-token" + operationSuffix,
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/tokens/{id}",
+		Summary:     "Revoke personal access token",
+		Description: "Revoke one of the caller's personal access tokens. Registry JWT tokens already obtained with it stay valid until they expire, within minutes.",
+		Tags:        []string{"tokens"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *RevokePersonalAccessTokenInput) (*Response[apiv0.PersonalAccessToken], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		token, err := registry.RevokePersonalAccessToken(ctx, input.ID, principalFromClaims(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Personal access token not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke personal access token", err)
+		}
+		return &Response[apiv0.PersonalAccessToken]{Body: *token}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-personal-access-token" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/pat",
+		Summary:     "Exchange personal access token for Registry JWT",
+		Description: "Exchange a personal access token for a short-lived Registry JWT token limited to the token's permissions.",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *ExchangePersonalAccessTokenInput) (*Response[auth.TokenResponse], error) {
+		token, err := registry.UsePersonalAccessToken(ctx, input.Body.Token)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidPersonalAccessToken),
+				errors.Is(err, service.ErrPersonalAccessTokenRevoked),
+				errors.Is(err, service.ErrPersonalAccessTokenExpired):
+				return nil, huma.Error401Unauthorized(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to check personal access token", err)
+			}
+		}
+
+		claims := auth.JWTClaims{
+			AuthMethod:            auth.Method(token.Owner.AuthMethod),
+			AuthMethodSubject:     token.Owner.Subject,
+			PersonalAccessTokenID: token.ID,
+		}
+		for _, perm := range token.Permissions {
+			claims.Permissions = append(claims.Permissions, auth.Permission{
+				Action:          auth.PermissionAction(perm.Action),
+				ResourcePattern: perm.Resource,
+			})
+		}
+		// Don't let the registry token outlive the personal access token
+		if time.Until(token.ExpiresAt) < 5*time.Minute {
+			claims.ExpiresAt = jwt.NewNumericDate(token.ExpiresAt)
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx, claims)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to generate token", err)
+		}
+		return &Response[auth.TokenResponse]{Body: *response}, nil
+	})
+}
+
+// holdsPermission reports whether a token may hand a permission on to a personal access token:
+// either the token grants it, or for publishing, the caller is a publisher in the namespace
+// through an organization, transfer or delegation
+func holdsPermission(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, claims *auth.JWTClaims, perm apiv0.TokenPermission) (bool, error) {
+	action := auth.PermissionAction(perm.Action)
+	if jwtManager.HasPermission(perm.Resource, action, claims.Permissions) {
+		return true, nil
+	}
+	if action != auth.PermissionActionPublish {
+		return false, nil
+	}
+
+	namespaceName, _, found := strings.Cut(perm.Resource, "/")
+	if !found || strings.Contains(namespaceName, "*") {
+		return false, nil
+	}
+	namespace, err := registry.GetNamespace(ctx, namespaceName)
+	if err != nil {
+		return false, err
+	}
+	role, err := namespaceRole(ctx, registry, jwtManager, claims, namespace, "")
+	if err != nil {
+		return false, err
+	}
+	return service.RoleAtLeast(role, service.OrganizationRolePublisher), nil
+}
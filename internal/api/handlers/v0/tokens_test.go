@@ -0,0 +1,144 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalAccessTokens(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterTokenEndpoints(api, "/v0", registryService, testConfig)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	alice, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	exchange := func(pat string) *httptest.ResponseRecorder {
+		return call(http.MethodPost, "/v0/auth/pat", "", map[string]string{"token": pat})
+	}
+	server := func(name string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Test server", Version: "1.0.0"}
+	}
+
+	t.Run("tokens cannot exceed the caller's permissions", func(t *testing.T) {
+		w := call(http.MethodPost, "/v0/tokens", alice, map[string]any{
+			"name":        "too broad",
+			"permissions": []apiv0.TokenPermission{{Action: "publish", Resource: "io.github.*"}},
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	w := call(http.MethodPost, "/v0/tokens", alice, map[string]any{
+		"name":        "weather release",
+		"permissions": []apiv0.TokenPermission{{Action: "publish", Resource: "io.github.alice/weather"}},
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created apiv0.CreatedPersonalAccessToken
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Contains(t, created.Token, created.Prefix)
+	assert.Equal(t, apiv0.Principal{AuthMethod: "github-at", Subject: "alice"}, created.Owner)
+
+	w = exchange(created.Token)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var exchanged auth.TokenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &exchanged))
+
+	t.Run("exchanged tokens are limited to the token's scopes", func(t *testing.T) {
+		w := call(http.MethodPost, "/v0/publish", exchanged.RegistryToken, server("io.github.alice/weather"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = call(http.MethodPost, "/v0/publish", exchanged.RegistryToken, server("io.github.alice/other"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = call(http.MethodPost, "/v0/tokens", exchanged.RegistryToken, map[string]any{"name": "nested"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("listing shows last use but never the token", func(t *testing.T) {
+		w := call(http.MethodGet, "/v0/tokens", alice, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), created.Token)
+
+		var list apiv0.PersonalAccessTokenListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Tokens, 1)
+		assert.NotNil(t, list.Tokens[0].LastUsedAt)
+	})
+
+	t.Run("rotation replaces the secret", func(t *testing.T) {
+		w := call(http.MethodPost, "/v0/tokens/"+created.ID+"/rotate", alice, map[string]any{"expiresInDays": 30})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var rotated apiv0.CreatedPersonalAccessToken
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+		assert.Equal(t, created.ID, rotated.ID)
+		assert.Equal(t, created.Permissions, rotated.Permissions)
+		assert.NotEqual(t, created.Token, rotated.Token)
+		assert.NotNil(t, rotated.RotatedAt)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), rotated.ExpiresAt, time.Minute)
+
+		assert.Equal(t, http.StatusUnauthorized, exchange(created.Token).Code)
+		assert.Equal(t, http.StatusOK, exchange(rotated.Token).Code)
+
+		w = call(http.MethodGet, "/v0/tokens/"+created.ID, alice, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), rotated.Token)
+		created = rotated
+	})
+
+	t.Run("revoked tokens can no longer be exchanged", func(t *testing.T) {
+		bob, err := generateTestJWTToken(testConfig, auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "bob"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, "/v0/tokens/"+created.ID, bob, nil).Code)
+
+		w := call(http.MethodDelete, "/v0/tokens/"+created.ID, alice, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, http.StatusUnauthorized, exchange(created.Token).Code)
+		assert.Equal(t, http.StatusUnauthorized, exchange("mcp_pat_unknown").Code)
+		assert.Equal(t, http.StatusConflict, call(http.MethodPost, "/v0/tokens/"+created.ID+"/rotate", alice, nil).Code)
+	})
+}
@@ -0,0 +1,214 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListUpdateProposalsInput represents the input for listing the update proposals of a server
+type ListUpdateProposalsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Status        string `query:"status" doc:"Only return proposals with this status" required:"false" enum:"pending,published,dismissed"`
+	Limit         int    `query:"limit" doc:"Number of proposals to return" default:"30" minimum:"1" maximum:"100"`
+}
+
+// UpdateProposalInput represents the input for publishing or dismissing an update proposal
+type UpdateProposalInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	ID            string `path:"id" doc:"Proposal ID"`
+}
+
+// GetUpdatePolicyInput represents the input for getting the update policy of a server
+type GetUpdatePolicyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// SetUpdatePolicyInput represents the input for setting the update policy of a server
+type SetUpdatePolicyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of a publisher of the server, or an admin" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          apiv0.UpdatePolicy
+}
+
+// ListUpdateQueueInput represents the input for the admin queue of update proposals
+type ListUpdateQueueInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Status        string `query:"status" doc:"Only return proposals with this status" default:"pending" enum:"pending,published,dismissed"`
+	Limit         int    `query:"limit" doc:"Number of proposals to return" default:"30" minimum:"1" maximum:"100"`
+}
+
+// RegisterUpdateProposalEndpoints registers the upstream update proposal endpoints with a custom path prefix
+func RegisterUpdateProposalEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-update-proposals" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/update-proposals",
+		Summary:     "List update proposals",
+		Description: "Get the new versions of a server proposed for releases of its upstream repository, most recent first.",
+		Tags:        []string{"servers"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ListUpdateProposalsInput) (*Response[apiv0.UpdateProposalListResponse], error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		proposals, err := registry.ListUpdateProposals(ctx, serverName, input.Status, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/update-proposals")
+			}
+			return nil, huma.Error500InternalServerError("Failed to list update proposals", err)
+		}
+		return &Response[apiv0.UpdateProposalListResponse]{
+			Body: apiv0.UpdateProposalListResponse{
+				Proposals: proposals,
+				Metadata:  apiv0.Metadata{Count: len(proposals)},
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-update-proposal" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/update-proposals/{id}/publish",
+		Summary:     "Publish update proposal",
+		Description: "Publish the version an update proposal proposes. When it fails validation, the proposal stays pending and records why.",
+		Tags:        []string{"servers"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *UpdateProposalInput) (*Response[apiv0.ServerResponse], error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		published, err := registry.PublishUpdateProposal(ctx, serverName, input.ID)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Update proposal not found")
+			case errors.Is(err, service.ErrProposalResolved):
+				return nil, huma.Error409Conflict(err.Error())
+			default:
+				return nil, huma.Error400BadRequest("Failed to publish server", err)
+			}
+		}
+		return &Response[apiv0.ServerResponse]{Body: *published}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "dismiss-update-proposal" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/servers/{serverName}/update-proposals/{id}",
+		Summary:       "Dismiss update proposal",
+		Description:   "Dismiss an update proposal. Its release isn't proposed again.",
+		Tags:          []string{"servers"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *UpdateProposalInput) (*struct{}, error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DismissUpdateProposal(ctx, serverName, input.ID); err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Update proposal not found")
+			case errors.Is(err, service.ErrProposalResolved):
+				return nil, huma.Error409Conflict(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to dismiss update proposal", err)
+			}
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-update-policy" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/update-policy",
+		Summary:     "Get update policy",
+		Description: "Get whether new upstream releases of a server are published automatically or proposed.",
+		Tags:        []string{"servers"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *GetUpdatePolicyInput) (*Response[apiv0.UpdatePolicy], error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := registry.GetUpdatePolicy(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/update-policy")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get update policy", err)
+		}
+		return &Response[apiv0.UpdatePolicy]{Body: *policy}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-update-policy" + operationSuffix,
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/update-policy",
+		Summary:     "Set update policy",
+		Description: "Choose whether new upstream releases of a server are published automatically or proposed for review.",
+		Tags:        []string{"servers"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *SetUpdatePolicyInput) (*Response[apiv0.UpdatePolicy], error) {
+		_, serverName, err := authorizePublisherOrAdmin(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+		if err != nil {
+			return nil, err
+		}
+
+		policy, err := registry.SetUpdatePolicy(ctx, serverName, &input.Body)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, serverNotFound(ctx, registry, pathPrefix, serverName, "/update-policy")
+			}
+			return nil, huma.Error500InternalServerError("Failed to set update policy", err)
+		}
+		return &Response[apiv0.UpdatePolicy]{Body: *policy}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-update-queue" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/update-proposals",
+		Summary:     "List update queue",
+		Description: "Get the update proposals of every server, most recent first, pending ones by default (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *ListUpdateQueueInput) (*Response[apiv0.UpdateProposalListResponse], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		proposals, err := registry.ListUpdateProposals(ctx, "", input.Status, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list update proposals", err)
+		}
+		return &Response[apiv0.UpdateProposalListResponse]{
+			Body: apiv0.UpdateProposalListResponse{
+				Proposals: proposals,
+				Metadata:  apiv0.Metadata{Count: len(proposals)},
+			},
+		}, nil
+	})
+}
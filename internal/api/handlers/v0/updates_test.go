@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateProposals(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Test server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	var proposals []*apiv0.UpdateProposal
+	for _, tag := range []string{"v1.1.0", "v1.2.0"} {
+		proposal, err := registryService.ProposeServerUpdate(ctx, "io.github.alice/weather", service.UpstreamRelease{Tag: tag})
+		require.NoError(t, err)
+		proposals = append(proposals, proposal)
+	}
+	_, err = registryService.ProposeServerUpdate(ctx, "io.github.alice/weather", service.UpstreamRelease{Tag: "v1.1.0"})
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterUpdateProposalEndpoints(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(subject string, permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice := tokenFor("alice", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"})
+	bob := tokenFor("bob", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.bob/*"})
+	admin := tokenFor("admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listProposals := func(path, token string) []apiv0.UpdateProposal {
+		w := call(http.MethodGet, path, token, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp apiv0.UpdateProposalListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Proposals
+	}
+	const path = "/v0/servers/io.github.alice%2Fweather/update-proposals"
+
+	assert.Equal(t, http.StatusForbidden, call(http.MethodGet, path, bob, nil).Code)
+	assert.Len(t, listProposals(path, alice), 2)
+	assert.Len(t, listProposals("/v0/admin/update-proposals", admin), 2)
+	assert.Equal(t, http.StatusForbidden, call(http.MethodGet, "/v0/admin/update-proposals", alice, nil).Code)
+
+	w := call(http.MethodPost, path+"/"+proposals[1].ID+"/publish", alice, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var published apiv0.ServerResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &published))
+	assert.Equal(t, "1.2.0", published.Server.Version)
+	assert.Equal(t, http.StatusConflict, call(http.MethodPost, path+"/"+proposals[1].ID+"/publish", alice, nil).Code)
+
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, path+"/"+proposals[0].ID, alice, nil).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, path+"/00000000-0000-0000-0000-000000000000", alice, nil).Code)
+	assert.Empty(t, listProposals("/v0/admin/update-proposals", admin), "the queue lists pending proposals")
+	assert.Len(t, listProposals(path+"?status=dismissed", alice), 1)
+
+	const policyPath = "/v0/servers/io.github.alice%2Fweather/update-policy"
+	w = call(http.MethodPut, policyPath, alice, map[string]any{"autoPublish": true})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = call(http.MethodGet, policyPath, alice, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var policy apiv0.UpdatePolicy
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &policy))
+	assert.True(t, policy.AutoPublish)
+}
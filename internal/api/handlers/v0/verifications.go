@@ -0,0 +1,114 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// NamespaceVerificationInput represents the input for verifying a namespace or revoking its verification
+type NamespaceVerificationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string `path:"namespace" pattern:"^[a-zA-Z0-9.-]+$" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+}
+
+// RegisterNamespaceVerificationEndpoints registers the namespace verification endpoints with a custom path prefix
+func RegisterNamespaceVerificationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-namespace" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/verification",
+		Summary:     "Verify namespace",
+		Description: "Verify a namespace with a token that proves control of what it is named after: " +
+			"a DNS or HTTP token for a domain namespace such as com.example, or a GitHub token of an organization member for io.github.<org>. " +
+			"Servers in verified namespaces are flagged as verified.",
+		Tags:     []string{"namespaces"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *NamespaceVerificationInput) (*Response[apiv0.NamespaceVerification], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		method, err := namespaceVerificationMethod(jwtManager, claims, input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		verification, err := registry.VerifyNamespace(ctx, input.Namespace, method, principalFromClaims(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to verify namespace", err)
+		}
+		return &Response[apiv0.NamespaceVerification]{Body: *verification}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-namespace-verification" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/namespaces/{namespace}/verification",
+		Summary:       "Revoke namespace verification",
+		Description:   "Revoke the verification of a namespace, e.g. once its domain has changed hands (admin only).",
+		Tags:          []string{"admin"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *NamespaceVerificationInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+			return nil, huma.Error403Forbidden("This endpoint requires registry admin permissions")
+		}
+
+		if err := registry.RevokeNamespaceVerification(ctx, input.Namespace, principalFromClaims(claims)); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace is not verified")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke namespace verification", err)
+		}
+		return nil, nil
+	})
+}
+
+// namespaceVerificationMethod works out what a token proves about a namespace. Proof is the
+// publish permission for the whole namespace that signing in granted: DNS and HTTP
+// authentication grant it for domains the caller controls and their subdomains, and GitHub
+// authentication for the organizations the caller is a member of. GitHub user namespaces can't
+// be verified, since anyone can create a GitHub account.
+func namespaceVerificationMethod(jwtManager *auth.JWTManager, claims *auth.JWTClaims, namespace string) (string, error) {
+	var method string
+	switch claims.AuthMethod {
+	case auth.MethodDNS, auth.MethodHTTP:
+		method = service.NamespaceVerificationDomain
+	case auth.MethodGitHubAT, auth.MethodGitHubOIDC:
+		login, isGitHub := strings.CutPrefix(namespace, "io.github.")
+		if isGitHub && !strings.EqualFold(login, claims.AuthMethodSubject) {
+			method = service.NamespaceVerificationGitHubOrg
+		}
+	}
+	if method == "" {
+		return "", huma.Error400BadRequest("Only domain namespaces, with a DNS or HTTP token, " +
+			"and GitHub organization namespaces, with a GitHub token of a member, can be verified")
+	}
+
+	// Global permissions come from being an admin rather than from proof
+	granted := slices.DeleteFunc(slices.Clone(claims.Permissions), func(perm auth.Permission) bool {
+		return perm.ResourcePattern == "*"
+	})
+	if !jwtManager.HasPermission(namespace+"/", auth.PermissionActionPublish, granted) {
+		return "", huma.Error403Forbidden("Your token doesn't prove control of namespace " + namespace)
+	}
+	return method, nil
+}
@@ -0,0 +1,184 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVerificationTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	return &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+}
+
+func TestVerifyNamespaceRequiresProof(t *testing.T) {
+	testConfig := newVerificationTestConfig(t)
+
+	// Rejected before the registry is consulted
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceVerificationEndpoints(api, "/v0", nil, testConfig)
+
+	tests := []struct {
+		name           string
+		namespace      string
+		claims         auth.JWTClaims
+		expectedStatus int
+	}{
+		{
+			name:      "GitHub user namespace",
+			namespace: "io.github.octocat",
+			claims: auth.JWTClaims{
+				AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "octocat",
+				Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.octocat/*"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "OIDC token",
+			namespace: "com.example",
+			claims: auth.JWTClaims{
+				AuthMethod: auth.MethodOIDC, AuthMethodSubject: "admin",
+				Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "GitHub organization the caller isn't a member of",
+			namespace: "io.github.acme",
+			claims: auth.JWTClaims{
+				AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "octocat",
+				Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.octocat/*"}},
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "another domain",
+			namespace: "com.example",
+			claims: auth.JWTClaims{
+				AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.org",
+				Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "org.example/*"}},
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:      "global permission",
+			namespace: "com.example",
+			claims: auth.JWTClaims{
+				AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.org",
+				Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := generateTestJWTToken(testConfig, tt.claims)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/namespaces/"+tt.namespace+"/verification", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestVerifiedNamespaces(t *testing.T) {
+	testConfig := newVerificationTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, name := range []string{"com.example.api/weather", "io.github.acme/maps", "io.github.acne/maps"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterNamespaceVerificationEndpoints(api, "/v0", registryService, testConfig)
+
+	call := func(method, path string, claims auth.JWTClaims) *httptest.ResponseRecorder {
+		token, err := generateTestJWTToken(testConfig, claims)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	listVerified := func() []string {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?verified=true", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		names := []string{}
+		for _, server := range list.Servers {
+			assert.True(t, server.Meta.Official.Verified)
+			names = append(names, server.Server.Name)
+		}
+		return names
+	}
+
+	// A DNS token for a domain verifies its subdomains too
+	dnsClaims := auth.JWTClaims{
+		AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.com",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*"},
+		},
+	}
+	w := call(http.MethodPost, "/v0/namespaces/com.example.api/verification", dnsClaims)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var verification apiv0.NamespaceVerification
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verification))
+	assert.Equal(t, service.NamespaceVerificationDomain, verification.Method)
+
+	githubClaims := auth.JWTClaims{
+		AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "octocat",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.octocat/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"},
+		},
+	}
+	require.Equal(t, http.StatusOK, call(http.MethodPost, "/v0/namespaces/io.github.acme/verification", githubClaims).Code)
+
+	assert.ElementsMatch(t, []string{"com.example.api/weather", "io.github.acme/maps"}, listVerified(),
+		"the lookalike io.github.acne isn't verified")
+
+	adminClaims := auth.JWTClaims{
+		AuthMethod: auth.MethodNone, AuthMethodSubject: "admin",
+		Permissions: []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}},
+	}
+	assert.Equal(t, http.StatusForbidden, call(http.MethodDelete, "/v0/namespaces/io.github.acme/verification", githubClaims).Code)
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, "/v0/namespaces/io.github.acme/verification", adminClaims).Code)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, "/v0/namespaces/io.github.acme/verification", adminClaims).Code)
+	assert.Equal(t, []string{"com.example.api/weather"}, listVerified())
+}
@@ -0,0 +1,191 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// WebhooksInput represents the input for listing the caller's webhooks
+type WebhooksInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+}
+
+// CreateWebhookInput represents the input for registering a webhook
+type CreateWebhookInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Body          struct {
+		URL    string   `json:"url" maxLength:"2048" doc:"HTTPS URL events are posted to" example:"https://example.com/hooks/mcp-registry"`
+		Events []string `json:"events,omitempty" doc:"Events to be sent. Defaults to all of them."`
+	}
+}
+
+// WebhookInput represents the input for acting on one of the caller's webhooks
+type WebhookInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the webhook's owner" required:"true"`
+	ID            string `path:"id" doc:"Webhook ID"`
+}
+
+// WebhookDeliveriesInput represents the input for listing a webhook's deliveries
+type WebhookDeliveriesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the webhook's owner, or with global edit permissions" required:"true"`
+	ID            string `path:"id" doc:"Webhook ID"`
+	Limit         int    `query:"limit" doc:"Number of deliveries to return" default:"30" minimum:"1" maximum:"100"`
+}
+
+// FailingWebhooksInput represents the input for listing failing webhooks
+type FailingWebhooksInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Limit         int    `query:"limit" doc:"Number of webhooks to return" default:"100" minimum:"1" maximum:"1000"`
+}
+
+// RegisterWebhookEndpoints registers the webhook endpoints with a custom path prefix
+func RegisterWebhookEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-webhooks" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/webhooks",
+		Summary:     "List webhooks",
+		Description: "List the caller's webhooks, with how their latest deliveries went.",
+		Tags:        []string{"webhooks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *WebhooksInput) (*Response[apiv0.WebhookListResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks, err := registry.ListWebhooks(ctx, principalFromClaims(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list webhooks", err)
+		}
+		return &Response[apiv0.WebhookListResponse]{Body: apiv0.WebhookListResponse{Webhooks: webhooks}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-webhook" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/webhooks",
+		Summary:     "Register a webhook",
+		Description: "Get registry events posted to a URL: " + strings.Join(service.WebhookEventTypes, ", ") + ". " +
+			"Deliveries are signed with the returned secret, which isn't shown again, and failed deliveries are retried with exponential backoff.",
+		Tags:     []string{"webhooks"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *CreateWebhookInput) (*Response[apiv0.CreatedWebhook], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		events := input.Body.Events
+		if len(events) == 0 {
+			events = service.WebhookEventTypes
+		}
+		for _, event := range events {
+			if !slices.Contains(service.WebhookEventTypes, event) {
+				return nil, huma.Error400BadRequest("Unknown event " + event + ". Events are: " + strings.Join(service.WebhookEventTypes, ", "))
+			}
+		}
+		if err := validateNotificationTarget(cfg, notifications.ChannelWebhook, input.Body.URL); err != nil {
+			return nil, err
+		}
+
+		webhook, err := registry.CreateWebhook(ctx, principalFromClaims(claims), input.Body.URL, events)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to create webhook", err)
+		}
+		return &Response[apiv0.CreatedWebhook]{Body: *webhook}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-webhook" + operationSuffix,
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/webhooks/{id}",
+		Summary:       "Delete a webhook",
+		Description:   "Remove one of the caller's webhooks. Its pending deliveries are dropped.",
+		Tags:          []string{"webhooks"},
+		Security:      []map[string][]string{{"bearer": {}}},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WebhookInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteWebhook(ctx, input.ID, principalFromClaims(claims)); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete webhook", err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-webhook-deliveries" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/webhooks/{id}/deliveries",
+		Summary:     "List webhook deliveries",
+		Description: "List the most recent deliveries to one of the caller's webhooks, with the outcome of their latest attempt. Admins can list the deliveries of any webhook.",
+		Tags:        []string{"webhooks"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *WebhookDeliveriesInput) (*Response[apiv0.WebhookDeliveryListResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook, err := registry.GetWebhook(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get webhook", err)
+		}
+		// Other principals' webhooks are reported missing rather than forbidden, so IDs can't be probed
+		if webhook.Owner != principalFromClaims(claims) && !hasGlobalPermission(claims, auth.PermissionActionEdit) {
+			return nil, huma.Error404NotFound("Webhook not found")
+		}
+
+		deliveries, err := registry.ListWebhookDeliveries(ctx, webhook.ID, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list webhook deliveries", err)
+		}
+		return &Response[apiv0.WebhookDeliveryListResponse]{
+			Body: apiv0.WebhookDeliveryListResponse{Deliveries: deliveries},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-failing-webhooks" + operationSuffix,
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/webhooks/failing",
+		Summary:     "List failing webhooks",
+		Description: "List the webhooks whose latest delivery attempt failed, those with the most consecutive failures first (admin only).",
+		Tags:        []string{"admin"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, func(ctx context.Context, input *FailingWebhooksInput) (*Response[apiv0.WebhookListResponse], error) {
+		if err := authorizeAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		webhooks, err := registry.ListFailingWebhooks(ctx, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list failing webhooks", err)
+		}
+		return &Response[apiv0.WebhookListResponse]{Body: apiv0.WebhookListResponse{Webhooks: webhooks}}, nil
+	})
+}
@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterWebhookEndpoints(api, "/v0", registryService, testConfig)
+
+	token := func(login string, permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: login,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	alice, bob := token("alice"), token("bob")
+	admin := token("admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	call := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, path, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("valid URLs and events only", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPost, "/v0/webhooks", alice,
+			map[string]any{"url": "http://example.com/hook"}).Code)
+		assert.Equal(t, http.StatusBadRequest, call(http.MethodPost, "/v0/webhooks", alice,
+			map[string]any{"url": "https://example.com/hook", "events": []string{"server.starred"}}).Code)
+	})
+
+	w := call(http.MethodPost, "/v0/webhooks", alice, map[string]any{"url": "https://example.com/hook"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created apiv0.CreatedWebhook
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.True(t, strings.HasPrefix(created.Secret, service.WebhookSecretPrefix))
+	assert.Equal(t, service.WebhookEventTypes, created.Events, "defaults to every event")
+
+	w = call(http.MethodGet, "/v0/webhooks", alice, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Secret, "the secret is only shown once")
+	var list apiv0.WebhookListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Webhooks, 1)
+	assert.Equal(t, created.ID, list.Webhooks[0].ID)
+
+	t.Run("publishing queues a delivery", func(t *testing.T) {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+
+		deliveriesPath := "/v0/webhooks/" + created.ID + "/deliveries"
+		assert.Equal(t, http.StatusNotFound, call(http.MethodGet, deliveriesPath, bob, nil).Code)
+		for _, caller := range []string{alice, admin} {
+			w := call(http.MethodGet, deliveriesPath, caller, nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var deliveries apiv0.WebhookDeliveryListResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deliveries))
+			require.Len(t, deliveries.Deliveries, 1)
+			assert.Equal(t, service.WebhookEventServerPublished, deliveries.Deliveries[0].Event)
+			assert.Equal(t, service.WebhookDeliveryPending, deliveries.Deliveries[0].Status)
+		}
+	})
+
+	t.Run("failing webhooks are admin only", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, call(http.MethodGet, "/v0/admin/webhooks/failing", alice, nil).Code)
+		assert.Equal(t, http.StatusOK, call(http.MethodGet, "/v0/admin/webhooks/failing", admin, nil).Code)
+	})
+
+	assert.Equal(t, http.StatusNotFound, call(http.MethodDelete, "/v0/webhooks/"+created.ID, bob, nil).Code)
+	assert.Equal(t, http.StatusNoContent, call(http.MethodDelete, "/v0/webhooks/"+created.ID, alice, nil).Code)
+}
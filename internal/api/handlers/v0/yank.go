@@ -0,0 +1,72 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// YankServerVersionInput represents the input for yanking or restoring a server version
+type YankServerVersionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded version to yank" example:"1.0.0"`
+}
+
+// RegisterYankEndpoints registers the version yank endpoints with a custom path prefix
+func RegisterYankEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	operationSuffix := strings.ReplaceAll(pathPrefix, "/", "-")
+
+	yank := func(yanked bool) func(context.Context, *YankServerVersionInput) (*Response[apiv0.ServerResponse], error) {
+		return func(ctx context.Context, input *YankServerVersionInput) (*Response[apiv0.ServerResponse], error) {
+			serverName, err := authorizePublisher(ctx, registry, jwtManager, input.Authorization, input.ServerName)
+			if err != nil {
+				return nil, err
+			}
+			version, err := url.PathUnescape(input.Version)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid version encoding", err)
+			}
+
+			server, err := registry.YankServerVersion(ctx, serverName, version, yanked)
+			if err != nil {
+				if errors.Is(err, database.ErrNotFound) {
+					return nil, huma.Error404NotFound("Server version not found")
+				}
+				return nil, huma.Error500InternalServerError("Failed to update server version", err)
+			}
+			return &Response[apiv0.ServerResponse]{Body: *server}, nil
+		}
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "yank-server-version" + operationSuffix,
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/yank",
+		Summary:     "Yank server version",
+		Description: "Hide a broken version from listings and latest-resolution while keeping it fetchable by exact version. " +
+			"Unlike deprecation and deletion, yanking can be undone by the publisher. Requires permission to publish the server.",
+		Tags:     []string{"publish"},
+		Security: []map[string][]string{{"bearer": {}}},
+	}, yank(true))
+
+	huma.Register(api, huma.Operation{
+		OperationID: "unyank-server-version" + operationSuffix,
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/yank",
+		Summary:     "Restore yanked server version",
+		Description: "Undo a yank, making the version listed again and eligible to be latest. Requires permission to publish the server.",
+		Tags:        []string{"publish"},
+		Security:    []map[string][]string{{"bearer": {}}},
+	}, yank(false))
+}
@@ -0,0 +1,86 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYankServerVersion(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, config.NewConfig())
+	v0.RegisterYankEndpoints(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+	})
+	require.NoError(t, err)
+
+	call := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	getVersion := func(path string) apiv0.ServerResponse {
+		w := call(http.MethodGet, path)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		return server
+	}
+	const path = "/v0/servers/io.github.alice%2Fweather/versions"
+
+	require.Equal(t, http.StatusOK, call(http.MethodPost, path+"/1.1.0/yank").Code)
+
+	assert.Equal(t, "1.0.0", getVersion(path+"/latest").Server.Version, "yanked versions are never latest")
+	yanked := getVersion(path + "/1.1.0")
+	assert.NotNil(t, yanked.Meta.Official.YankedAt, "still fetchable by exact version")
+
+	var list apiv0.ServerListResponse
+	require.NoError(t, json.Unmarshal(call(http.MethodGet, path).Body.Bytes(), &list))
+	assert.Len(t, list.Servers, 1)
+	require.NoError(t, json.Unmarshal(call(http.MethodGet, path+"?include_yanked=true").Body.Bytes(), &list))
+	assert.Len(t, list.Servers, 2)
+
+	require.Equal(t, http.StatusOK, call(http.MethodDelete, path+"/1.1.0/yank").Code)
+	assert.Equal(t, "1.1.0", getVersion(path+"/latest").Server.Version)
+	assert.Equal(t, http.StatusNotFound, call(http.MethodPost, path+"/9.9.9/yank").Code)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+)
+
+// rateLimitExemptPaths are the paths probes and scrapers poll, which aren't rate limited
+var rateLimitExemptPaths = map[string]bool{
+	"/healthz":     true,
+	"/readyz":      true,
+	"/metrics":     true,
+	"/v0/health":   true,
+	"/v0/ping":     true,
+	"/v0.1/health": true,
+	"/v0.1/ping":   true,
+}
+
+// rateLimitReadPaths are the paths of reads sent as POST, which are limited like other reads
+var rateLimitReadPaths = map[string]bool{
+	"/graphql": true,
+}
+
+// RateLimitMiddleware limits the requests of each caller with token buckets: one for reads and one
+// for publishes and other writes. Callers are identified by their registry token, or by their IP
+// address when they don't have a valid one. Registry admins aren't limited.
+func RateLimitMiddleware(cfg *config.Config, store ratelimit.Store, next http.Handler) http.Handler {
+	readLimit := ratelimit.Limit{PerMinute: cfg.RateLimitReadPerMinute, Burst: cfg.RateLimitReadBurst}
+	publishLimit := ratelimit.Limit{PerMinute: cfg.RateLimitPublishPerMinute, Burst: cfg.RateLimitPublishBurst}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, bucket := publishLimit, "publish"
+		if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions || rateLimitReadPaths[r.URL.Path] {
+			limit, bucket = readLimit, "read"
+		}
+		if !limit.Enabled() || rateLimitExemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		clientIP := v0.ClientIP(r.RemoteAddr, r.Header.Get("X-Forwarded-For"), cfg.TrustForwardedFor)
+		caller, exempt := RateLimitCaller(r.Context(), jwtManager, r.Header.Get("Authorization"), clientIP)
+		if exempt {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		result, err := store.Take(r.Context(), bucket+":"+caller, limit)
+		if err != nil {
+			// An unreachable store shouldn't take the registry down with it
+			log.Printf("Failed to check rate limit: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(huma.ErrorModel{
+				Title:  http.StatusText(http.StatusTooManyRequests),
+				Status: http.StatusTooManyRequests,
+				Detail: "Rate limit of " + strconv.Itoa(limit.PerMinute) + " " + bucket + " requests per minute exceeded, retry in " + strconv.Itoa(retryAfter) + " seconds",
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RateLimitCaller identifies the caller of a request for rate limiting from its Authorization
+// header and IP address, and whether they're an admin who isn't limited. Anonymous tokens are
+// shared by anyone, so they identify callers by IP address like requests without tokens.
+func RateLimitCaller(ctx context.Context, jwtManager *auth.JWTManager, authHeader, clientIP string) (string, bool) {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) > len(bearerPrefix) && strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		claims, err := jwtManager.ValidateToken(ctx, authHeader[len(bearerPrefix):])
+		if err == nil && claims.AuthMethod != auth.MethodNone {
+			for _, perm := range claims.Permissions {
+				if perm.Action == auth.PermissionActionEdit && perm.ResourcePattern == "*" {
+					return "", true
+				}
+			}
+			return "principal:" + string(claims.AuthMethod) + ":" + claims.AuthMethodSubject, false
+		}
+	}
+
+	return "ip:" + clientIP, false
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
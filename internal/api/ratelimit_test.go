@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:             hex.EncodeToString(seed),
+		RateLimitReadPerMinute:    60,
+		RateLimitReadBurst:        2,
+		RateLimitPublishPerMinute: 60,
+		RateLimitPublishBurst:     1,
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+	token := func(method auth.Method, subject string, permissions ...auth.Permission) string {
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod: method, AuthMethodSubject: subject, Permissions: permissions,
+		})
+		require.NoError(t, err)
+		return "Bearer " + response.RegistryToken
+	}
+
+	handler := api.RateLimitMiddleware(cfg, ratelimit.NewMemoryStore(), http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	request := func(method, path, remoteAddr, authHeader string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = remoteAddr
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("reads are limited by IP address", func(t *testing.T) {
+		w := request(http.MethodGet, "/v0/servers", "192.0.2.1:1234", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Reset"))
+
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v0/servers", "192.0.2.1:5678", "").Code)
+
+		w = request(http.MethodGet, "/v0/servers", "192.0.2.1:1234", "")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		var problem huma.ErrorModel
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusTooManyRequests, problem.Status)
+		assert.Contains(t, problem.Detail, "60 read requests per minute")
+
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v0/servers", "192.0.2.2:1234", "").Code, "other addresses have their own limit")
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/healthz", "192.0.2.1:1234", "").Code, "probes aren't limited")
+	})
+
+	t.Run("publishes are limited separately by identity", func(t *testing.T) {
+		alice := token(auth.MethodGitHubAT, "alice")
+		assert.Equal(t, http.StatusOK, request(http.MethodPost, "/v0/publish", "192.0.2.3:1234", alice).Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodPost, "/v0/publish", "192.0.2.4:1234", alice).Code,
+			"the limit follows the identity across addresses")
+		assert.Equal(t, http.StatusOK, request(http.MethodPost, "/v0/publish", "192.0.2.3:1234", token(auth.MethodGitHubAT, "bob")).Code)
+		assert.Equal(t, http.StatusOK, request(http.MethodGet, "/v0/servers", "192.0.2.3:1234", alice).Code, "reads have their own limit")
+		assert.Equal(t, http.StatusOK, request(http.MethodPost, "/graphql", "192.0.2.3:1234", alice).Code, "GraphQL queries are reads")
+	})
+
+	t.Run("anonymous and invalid tokens are limited by IP address", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request(http.MethodPost, "/v0/publish", "192.0.2.5:1234", token(auth.MethodNone, "anonymous")).Code)
+		assert.Equal(t, http.StatusTooManyRequests, request(http.MethodPost, "/v0/publish", "192.0.2.5:1234", "Bearer invalid").Code)
+	})
+
+	t.Run("admins aren't limited", func(t *testing.T) {
+		admin := token(auth.MethodOIDC, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+		for range 3 {
+			w := request(http.MethodPost, "/v0/publish", "192.0.2.6:1234", admin)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+		}
+	})
+}
@@ -174,12 +174,16 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 
 	// Add metrics middleware with options
 	api.UseMiddleware(MetricTelemetryMiddleware(metrics,
-		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
+		WithSkipPaths("/health", "/healthz", "/readyz", "/metrics", "/ping", "/docs"),
 	))
 
+	// Record changes to the registry in the audit log
+	api.UseMiddleware(v0.AuditMiddleware(cfg, registry))
+
 	// Register routes for all API versions
 	RegisterV0Routes(api, cfg, registry, metrics, versionInfo)
 	RegisterV0_1Routes(api, cfg, registry, metrics, versionInfo)
+	v0.RegisterProbeEndpoints(api, v0.DefaultDependencyChecks(cfg, registry))
 
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
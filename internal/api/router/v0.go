@@ -7,6 +7,7 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/readme"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -17,10 +18,46 @@ func RegisterV0Routes(
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterVersionEndpoint(api, "/v0", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0", registry)
+	v0.RegisterServersEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterReadmeEndpoints(api, "/v0", registry, readme.NewFetcher(cfg))
+	v0.RegisterBadgeEndpoints(api, "/v0", registry)
+	v0.RegisterStatsEndpoints(api, "/v0", registry)
+	v0.RegisterTaxonomyEndpoints(api, "/v0", registry)
+	v0.RegisterExportEndpoints(api, "/v0", registry)
+	v0.RegisterFeedEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterSitemapEndpoint(api, registry)
+	if cfg.EnableGraphQL {
+		v0.RegisterGraphQLEndpoint(api, registry, cfg)
+	}
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterChannelEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterYankEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterStatusEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterRelationshipEndpoints(api, "/v0", registry)
+	v0.RegisterDiffEndpoint(api, "/v0", registry)
+	v0.RegisterAdvisoryEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterScanEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterCapabilityEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterUpdateProposalEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterSignatureEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterAttachmentEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterSearchRankingEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterImportEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterModerationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterReviewEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterNamespaceVerificationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterNamespaceClaimEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterNotificationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterWebhookEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterJobEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterOrganizationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterTokenEndpoints(api, "/v0", registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
 	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterClaimEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterRedirectEndpoints(api, "/v0", registry, cfg)
 }
 
 func RegisterV0_1Routes(
@@ -29,8 +66,39 @@ func RegisterV0_1Routes(
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterVersionEndpoint(api, "/v0.1", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0.1", registry)
+	v0.RegisterServersEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterReadmeEndpoints(api, "/v0.1", registry, readme.NewFetcher(cfg))
+	v0.RegisterStatsEndpoints(api, "/v0.1", registry)
+	v0.RegisterTaxonomyEndpoints(api, "/v0.1", registry)
+	v0.RegisterExportEndpoints(api, "/v0.1", registry)
+	v0.RegisterFeedEndpoints(api, "/v0.1", registry, cfg)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterChannelEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterYankEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterStatusEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterRelationshipEndpoints(api, "/v0.1", registry)
+	v0.RegisterDiffEndpoint(api, "/v0.1", registry)
+	v0.RegisterAdvisoryEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterScanEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterCapabilityEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterUpdateProposalEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterSignatureEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterAttachmentEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterSearchRankingEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterImportEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterModerationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterReviewEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterNamespaceVerificationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterNamespaceClaimEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterNotificationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterWebhookEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterJobEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterOrganizationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterTokenEndpoints(api, "/v0.1", registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
 	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterClaimEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterRedirectEndpoints(api, "/v0.1", registry, cfg)
 }
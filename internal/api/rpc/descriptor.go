@@ -0,0 +1,299 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/structpb"    // google/protobuf/struct.proto
+	_ "google.golang.org/protobuf/types/known/timestamppb" // google/protobuf/timestamp.proto
+)
+
+const (
+	// ProtoPackage is the protobuf package of the registry's messages and service
+	ProtoPackage = "mcp.registry.v0"
+	// ServiceName is the full name of the registry's gRPC service
+	ServiceName = ProtoPackage + ".Registry"
+
+	protoFileName = "mcp/registry/v0/registry.proto"
+)
+
+// method is an RPC of the registry service, with the Go types its messages are generated from
+type method struct {
+	name            string
+	request         reflect.Type
+	response        reflect.Type
+	serverStreaming bool
+}
+
+// methods are the RPCs of the registry service, in the order they are declared
+var methods = []method{
+	{name: "ListServers", request: reflect.TypeFor[ListServersRequest](), response: reflect.TypeFor[apiv0.ServerResponse](), serverStreaming: true},
+	{name: "GetServer", request: reflect.TypeFor[GetServerRequest](), response: reflect.TypeFor[apiv0.ServerResponse]()},
+	{name: "SearchServers", request: reflect.TypeFor[SearchServersRequest](), response: reflect.TypeFor[apiv0.ServerSearchResponse]()},
+	{name: "PublishServer", request: reflect.TypeFor[PublishServerRequest](), response: reflect.TypeFor[apiv0.ServerResponse]()},
+}
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+)
+
+// File returns the protobuf definitions of the registry service, generated from the Go types of
+// the REST API so that the two can't drift apart. Messages are named after their Go types and
+// their fields after the JSON names, numbered in the order the Go fields are declared: new fields
+// must be added after the existing ones so that clients built from older definitions keep working.
+var File = sync.OnceValues(func() (protoreflect.FileDescriptor, error) {
+	b := &fileBuilder{
+		names: map[reflect.Type]string{},
+		types: map[string]reflect.Type{},
+		file: &descriptorpb.FileDescriptorProto{
+			Name:       proto.String(protoFileName),
+			Package:    proto.String(ProtoPackage),
+			Syntax:     proto.String("proto3"),
+			Dependency: []string{"google/protobuf/struct.proto", "google/protobuf/timestamp.proto"},
+		},
+	}
+
+	service := &descriptorpb.ServiceDescriptorProto{Name: proto.String(strings.TrimPrefix(ServiceName, ProtoPackage+"."))}
+	for _, m := range methods {
+		request, err := b.message(m.request)
+		if err != nil {
+			return nil, err
+		}
+		response, err := b.message(m.response)
+		if err != nil {
+			return nil, err
+		}
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(m.name),
+			InputType:       proto.String(request),
+			OutputType:      proto.String(response),
+			ServerStreaming: proto.Bool(m.serverStreaming),
+		})
+	}
+	b.file.Service = []*descriptorpb.ServiceDescriptorProto{service}
+
+	file, err := protodesc.NewFile(b.file, protoregistry.GlobalFiles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protobuf definitions: %w", err)
+	}
+	// Registering the file lets the reflection service describe it to clients such as grpcurl
+	if err := protoregistry.GlobalFiles.RegisterFile(file); err != nil {
+		return nil, err
+	}
+	return file, nil
+})
+
+// fileBuilder generates messages from Go types
+type fileBuilder struct {
+	file  *descriptorpb.FileDescriptorProto
+	names map[reflect.Type]string
+	types map[string]reflect.Type
+}
+
+// message generates the message of a struct type, returning its full name
+func (b *fileBuilder) message(t reflect.Type) (string, error) {
+	if name, ok := b.names[t]; ok {
+		return name, nil
+	}
+
+	// Types of different packages may share a name, so later ones are prefixed with their package
+	name := t.Name()
+	if _, taken := b.types[name]; taken {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	if name == "" {
+		return "", fmt.Errorf("unnamed struct type %s can't be a message", t)
+	}
+	fullName := "." + ProtoPackage + "." + name
+	b.names[t] = fullName
+	b.types[name] = t
+
+	message := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	b.file.MessageType = append(b.file.MessageType, message)
+	if err := b.addFields(message, t); err != nil {
+		return "", fmt.Errorf("%s: %w", t, err)
+	}
+	return fullName, nil
+}
+
+// addFields adds a field for each field of a struct, flattening embedded structs the way
+// encoding/json does
+func (b *fileBuilder) addFields(message *descriptorpb.DescriptorProto, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && jsonName == "" && sf.Type.Kind() == reflect.Struct {
+			if err := b.addFields(message, sf.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if jsonName == "" {
+			jsonName = sf.Name
+		}
+
+		field := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(protoFieldName(jsonName)),
+			JsonName: proto.String(jsonName),
+			Number:   proto.Int32(int32(len(message.Field) + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+		if err := b.setType(message, field, sf.Type); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		message.Field = append(message.Field, field)
+	}
+	return nil
+}
+
+// setType sets the type of a field from the Go type of its values
+func (b *fileBuilder) setType(message *descriptorpb.DescriptorProto, field *descriptorpb.FieldDescriptorProto, t reflect.Type) error {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return setMessageType(field, ".google.protobuf.Timestamp")
+	case t.Kind() == reflect.Interface, t.Implements(jsonMarshaler):
+		return setMessageType(field, ".google.protobuf.Value")
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	case reflect.Bool:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()
+	case reflect.Int32:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()
+	case reflect.Int, reflect.Int64:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	case reflect.Float32, reflect.Float64:
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum()
+	case reflect.Struct:
+		name, err := b.message(t)
+		if err != nil {
+			return err
+		}
+		return setMessageType(field, name)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			field.Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+			return nil
+		}
+		if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED || t.Elem().Kind() == reflect.Slice {
+			return fmt.Errorf("nested lists aren't supported")
+		}
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return b.setType(message, field, t.Elem())
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return fmt.Errorf("maps with %s keys aren't supported", t.Key())
+		}
+		// Maps of arbitrary JSON are objects, and others are protobuf maps
+		if t.Elem().Kind() == reflect.Interface {
+			return setMessageType(field, ".google.protobuf.Struct")
+		}
+		return b.setMapType(message, field, t)
+	default:
+		return fmt.Errorf("%s values aren't supported", t)
+	}
+	return nil
+}
+
+// setMapType makes a field a protobuf map, which is a repeated field of a nested entry message
+func (b *fileBuilder) setMapType(message *descriptorpb.DescriptorProto, field *descriptorpb.FieldDescriptorProto, t reflect.Type) error {
+	if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return fmt.Errorf("lists of maps aren't supported")
+	}
+	entryName := snakeToCamel(field.GetName()) + "Entry"
+	entry := &descriptorpb.DescriptorProto{
+		Name:    proto.String(entryName),
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{
+				Name:     proto.String("key"),
+				JsonName: proto.String("key"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			},
+		},
+	}
+	value := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("value"),
+		JsonName: proto.String("value"),
+		Number:   proto.Int32(2),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if t.Elem().Kind() == reflect.Slice || t.Elem().Kind() == reflect.Map {
+		return fmt.Errorf("maps of %s aren't supported", t.Elem())
+	}
+	if err := b.setType(entry, value, t.Elem()); err != nil {
+		return err
+	}
+	entry.Field = append(entry.Field, value)
+	message.NestedType = append(message.NestedType, entry)
+
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return setMessageType(field, "."+ProtoPackage+"."+message.GetName()+"."+entryName)
+}
+
+func setMessageType(field *descriptorpb.FieldDescriptorProto, typeName string) error {
+	field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	field.TypeName = proto.String(typeName)
+	return nil
+}
+
+// protoFieldName converts a JSON name to a protobuf field name: the last segment of a path, in
+// snake case, with characters protobuf doesn't allow in names dropped
+func protoFieldName(jsonName string) string {
+	if i := strings.LastIndex(jsonName, "/"); i >= 0 && i < len(jsonName)-1 {
+		jsonName = jsonName[i+1:]
+	}
+	var b strings.Builder
+	separate := false
+	for i, r := range jsonName {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if unicode.IsUpper(r) && i > 0 {
+				separate = true
+			}
+			if separate && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			separate = false
+		default:
+			separate = true
+		}
+	}
+	return b.String()
+}
+
+// snakeToCamel converts a snake case name to upper camel case
+func snakeToCamel(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
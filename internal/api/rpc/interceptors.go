@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// publishMethod is the full name of the RPC that changes the registry
+const publishMethod = "/" + ServiceName + "/PublishServer"
+
+// rateLimitInterceptor limits unary RPCs with the same token buckets as the REST API: publishes
+// take from the publish bucket and other RPCs from the read bucket, keyed by the caller's
+// registry token or IP address
+func (s *server) rateLimitInterceptor(store ratelimit.Store) grpc.UnaryServerInterceptor {
+	readLimit := ratelimit.Limit{PerMinute: s.cfg.RateLimitReadPerMinute, Burst: s.cfg.RateLimitReadBurst}
+	publishLimit := ratelimit.Limit{PerMinute: s.cfg.RateLimitPublishPerMinute, Burst: s.cfg.RateLimitPublishBurst}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		limit, bucket := readLimit, "read"
+		if info.FullMethod == publishMethod {
+			limit, bucket = publishLimit, "publish"
+		}
+		if !limit.Enabled() {
+			return handler(ctx, req)
+		}
+
+		caller, exempt := api.RateLimitCaller(ctx, s.jwtManager, incomingMetadata(ctx, "authorization"), s.clientIP(ctx))
+		if exempt {
+			return handler(ctx, req)
+		}
+
+		result, err := store.Take(ctx, bucket+":"+caller, limit)
+		if err != nil {
+			// An unreachable store shouldn't take the registry down with it
+			log.Printf("Failed to check rate limit: %v", err)
+			return handler(ctx, req)
+		}
+		if !result.Allowed {
+			retryAfter := int((result.RetryAfter + time.Second - 1) / time.Second)
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryAfter)))
+			return nil, status.Errorf(codes.ResourceExhausted, "Rate limit of %d %s requests per minute exceeded, retry in %d seconds", limit.PerMinute, bucket, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// auditInterceptor records successful publishes in the audit log like the REST API's audit
+// middleware. Failing to record an event is logged rather than failing the RPC.
+func (s *server) auditInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	response, err := handler(ctx, req)
+	if err != nil || info.FullMethod != publishMethod {
+		return response, err
+	}
+
+	event := &apiv0.AuditEvent{
+		Action: "publish-server",
+		Method: http.MethodPost,
+		Path:   info.FullMethod,
+		Status: http.StatusOK,
+		IP:     s.clientIP(ctx),
+	}
+	if token := bearerToken(ctx); token != "" {
+		if claims, err := s.jwtManager.ValidateToken(ctx, token); err == nil {
+			event.Actor = &apiv0.Principal{AuthMethod: string(claims.AuthMethod), Subject: claims.AuthMethodSubject}
+		}
+	}
+	var published apiv0.ServerResponse
+	if message, ok := response.(proto.Message); ok && fromMessage(message, &published) == nil {
+		event.ServerName, event.Version = published.Server.Name, published.Server.Version
+		event.AfterDigest, _ = v0.ServerDocumentDigest(&published.Server)
+	}
+
+	if err := s.registry.RecordAuditEvent(context.WithoutCancel(ctx), event); err != nil {
+		log.Printf("Failed to record audit event %s: %v", event.Path, err)
+	}
+	return response, nil
+}
+
+// incomingMetadata returns the first value of a key in the metadata a client sent
+func incomingMetadata(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// bearerToken returns the registry token sent as "authorization: Bearer <token>" metadata
+func bearerToken(ctx context.Context) string {
+	const bearerPrefix = "Bearer "
+	value := incomingMetadata(ctx, "authorization")
+	if len(value) > len(bearerPrefix) && strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) {
+		return value[len(bearerPrefix):]
+	}
+	return ""
+}
+
+// clientIP returns the IP address of a client, from x-forwarded-for metadata when the registry
+// is behind a proxy that sets it like for REST requests
+func (s *server) clientIP(ctx context.Context) string {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	return v0.ClientIP(remoteAddr, incomingMetadata(ctx, "x-forwarded-for"), s.cfg.TrustForwardedFor)
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Proto renders the protobuf definitions of the registry service as a .proto file, for clients
+// to generate code from
+func Proto() (string, error) {
+	file, err := File()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by tools/generate-proto from the registry's Go types. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", file.Package())
+	for i := 0; i < file.Imports().Len(); i++ {
+		fmt.Fprintf(&b, "import %q;\n", file.Imports().Get(i).Path())
+	}
+
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		fmt.Fprintf(&b, "\nservice %s {\n", service.Name())
+		for j := 0; j < service.Methods().Len(); j++ {
+			m := service.Methods().Get(j)
+			stream := ""
+			if m.IsStreamingServer() {
+				stream = "stream "
+			}
+			fmt.Fprintf(&b, "  rpc %s(%s) returns (%s%s);\n", m.Name(), typeName(file, m.Input()), stream, typeName(file, m.Output()))
+		}
+		b.WriteString("}\n")
+	}
+
+	messages := file.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		fmt.Fprintf(&b, "\nmessage %s {\n", message.Name())
+		for j := 0; j < message.Fields().Len(); j++ {
+			field := message.Fields().Get(j)
+			fmt.Fprintf(&b, "  %s %s = %d", fieldType(file, field), field.Name(), field.Number())
+			if field.JSONName() != defaultJSONName(string(field.Name())) {
+				fmt.Fprintf(&b, " [json_name = %q]", field.JSONName())
+			}
+			b.WriteString(";\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String(), nil
+}
+
+// fieldType renders the type of a field, with its label
+func fieldType(file protoreflect.FileDescriptor, field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", kindName(file, field.MapKey()), kindName(file, field.MapValue()))
+	}
+	if field.IsList() {
+		return "repeated " + kindName(file, field)
+	}
+	return kindName(file, field)
+}
+
+func kindName(file protoreflect.FileDescriptor, field protoreflect.FieldDescriptor) string {
+	if field.Kind() == protoreflect.MessageKind {
+		return typeName(file, field.Message())
+	}
+	return field.Kind().String()
+}
+
+// typeName names a message relative to the file's package
+func typeName(file protoreflect.FileDescriptor, message protoreflect.MessageDescriptor) string {
+	if message.ParentFile().Package() == file.Package() {
+		return strings.TrimPrefix(string(message.FullName()), string(file.Package())+".")
+	}
+	return string(message.FullName())
+}
+
+// defaultJSONName is the JSON name protobuf gives a field without a json_name option
+func defaultJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		b.WriteRune(r)
+		upper = false
+	}
+	return b.String()
+}
@@ -0,0 +1,248 @@
+package rpc_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/api/rpc"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestProto_MatchesDocs(t *testing.T) {
+	rendered, err := rpc.Proto()
+	require.NoError(t, err)
+	existing, err := os.ReadFile("../../../docs/reference/api/registry.proto")
+	require.NoError(t, err)
+	assert.Equal(t, string(existing), rendered, "docs/reference/api/registry.proto is out of date, run 'make generate-proto'")
+}
+
+func TestFile_KeepsJSONNames(t *testing.T) {
+	file, err := rpc.File()
+	require.NoError(t, err)
+
+	server := file.Messages().ByName("ServerJSON").Fields()
+	assert.Equal(t, "$schema", server.ByName("schema").JSONName())
+	assert.Equal(t, "websiteUrl", server.ByName("website_url").JSONName())
+	official := file.Messages().ByName("ResponseMeta").Fields().ByName("official")
+	assert.Equal(t, "io.modelcontextprotocol.registry/official", official.JSONName())
+	assert.True(t, file.Messages().ByName("KeyValueInput").Fields().ByName("variables").IsMap())
+
+	list := file.Services().ByName("Registry").Methods().ByName("ListServers")
+	assert.True(t, list.IsStreamingServer())
+}
+
+// registryClient calls the registry service with messages built from JSON
+type registryClient struct {
+	conn *grpc.ClientConn
+	file protoreflect.FileDescriptor
+}
+
+// newRegistryClient serves the registry service in memory and connects a client to it
+func newRegistryClient(t *testing.T, registryService service.RegistryService, cfg *config.Config) *registryClient {
+	t.Helper()
+	grpcServer, err := rpc.NewServer(registryService, cfg, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	file, err := rpc.File()
+	require.NoError(t, err)
+	return &registryClient{conn: conn, file: file}
+}
+
+func (c *registryClient) message(name protoreflect.Name, data string) *dynamicpb.Message {
+	message := dynamicpb.NewMessage(c.file.Messages().ByName(name))
+	if data != "" {
+		if err := protojson.Unmarshal([]byte(data), message); err != nil {
+			panic(err)
+		}
+	}
+	return message
+}
+
+func (c *registryClient) call(ctx context.Context, method string, request, response *dynamicpb.Message, into any) error {
+	if err := c.conn.Invoke(ctx, "/"+rpc.ServiceName+"/"+method, request, response); err != nil {
+		return err
+	}
+	data, err := protojson.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, into)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), EnableRegistryValidation: false}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/weather",
+			Description: "Weather forecasts",
+			Version:     version,
+			Packages:    []model.Package{{RegistryType: "npm", Identifier: "@example/weather", Version: version, Transport: model.Transport{Type: "stdio"}}},
+		})
+		require.NoError(t, err)
+	}
+
+	client := newRegistryClient(t, registryService, cfg)
+	conn := client.conn
+
+	t.Run("streams servers", func(t *testing.T) {
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/"+rpc.ServiceName+"/ListServers")
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(client.message("ListServersRequest", `{"registryType": "npm"}`)))
+		require.NoError(t, stream.CloseSend())
+
+		var versions []string
+		for {
+			response := client.message("ServerResponse", "")
+			err := stream.RecvMsg(response)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			data, err := protojson.Marshal(response)
+			require.NoError(t, err)
+			var server apiv0.ServerResponse
+			require.NoError(t, json.Unmarshal(data, &server))
+			assert.Equal(t, "com.example/weather", server.Server.Name)
+			versions = append(versions, server.Server.Version)
+		}
+		assert.ElementsMatch(t, []string{"1.0.0", "1.1.0"}, versions)
+	})
+
+	t.Run("gets the latest version", func(t *testing.T) {
+		var server apiv0.ServerResponse
+		err := client.call(ctx, "GetServer", client.message("GetServerRequest", `{"name": "com.example/weather"}`), client.message("ServerResponse", ""), &server)
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", server.Server.Version)
+		assert.Equal(t, "@example/weather", server.Server.Packages[0].Identifier)
+		require.NotNil(t, server.Meta.Official)
+		assert.True(t, server.Meta.Official.IsLatest)
+	})
+
+	t.Run("returns not found for unknown servers", func(t *testing.T) {
+		var server apiv0.ServerResponse
+		err := client.call(ctx, "GetServer", client.message("GetServerRequest", `{"name": "com.example/missing"}`), client.message("ServerResponse", ""), &server)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("publishes with a registry token", func(t *testing.T) {
+		request := client.message("PublishServerRequest", `{"server": {
+			"$schema": "`+model.CurrentSchemaURL+`",
+			"name": "io.github.alice/forecast",
+			"description": "Forecasts",
+			"version": "1.0.0"
+		}}`)
+
+		var published apiv0.ServerResponse
+		err := client.call(ctx, "PublishServer", request, client.message("ServerResponse", ""), &published)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "alice",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.bob/*"}},
+		})
+		require.NoError(t, err)
+		authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token.RegistryToken)
+		err = client.call(authCtx, "PublishServer", request, client.message("ServerResponse", ""), &published)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		token, err = auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "alice",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+		})
+		require.NoError(t, err)
+		authCtx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token.RegistryToken)
+		require.NoError(t, client.call(authCtx, "PublishServer", request, client.message("ServerResponse", ""), &published))
+		assert.Equal(t, "io.github.alice/forecast", published.Server.Name)
+		assert.Equal(t, model.CurrentSchemaURL, published.Server.Schema)
+
+		err = client.call(authCtx, "PublishServer", request, client.message("ServerResponse", ""), &published)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
+
+func TestServer_RateLimitsAndAuditsPublishes(t *testing.T) {
+	ctx := context.Background()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:             hex.EncodeToString(testSeed),
+		RateLimitPublishPerMinute: 1,
+		RateLimitPublishBurst:     1,
+	}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	client := newRegistryClient(t, registryService, cfg)
+
+	token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}},
+	})
+	require.NoError(t, err)
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token.RegistryToken)
+	publish := func(version string) error {
+		request := client.message("PublishServerRequest", `{"server": {
+			"$schema": "`+model.CurrentSchemaURL+`",
+			"name": "io.github.alice/forecast",
+			"description": "Forecasts",
+			"version": "`+version+`"
+		}}`)
+		var published apiv0.ServerResponse
+		return client.call(authCtx, "PublishServer", request, client.message("ServerResponse", ""), &published)
+	}
+
+	require.NoError(t, publish("1.0.0"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(publish("1.1.0")))
+
+	events, _, err := registryService.ListAuditEvents(ctx, &database.AuditFilter{}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "publish-server", events[0].Action)
+	assert.Equal(t, "io.github.alice/forecast", events[0].ServerName)
+	assert.Equal(t, "1.0.0", events[0].Version)
+	assert.NotEmpty(t, events[0].AfterDigest)
+	require.NotNil(t, events[0].Actor)
+	assert.Equal(t, "alice", events[0].Actor.Subject)
+}
@@ -0,0 +1,370 @@
+// Package rpc serves the registry over gRPC, for consumers such as gateways that sync thousands of
+// servers and would rather stream them than page through GET /v0/servers.
+//
+// The protobuf definitions aren't compiled from .proto files but generated from the Go types of
+// the REST API when the server starts (see File), and messages are converted to and from those
+// types through their shared JSON form. docs/reference/api/registry.proto is rendered from the
+// same definitions for clients to generate code from.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// listPageSize is how many servers ListServers reads from the database at a time
+const listPageSize = 100
+
+// ListServersRequest represents the input for streaming servers. Every matching version is streamed,
+// read from a snapshot of the registry so that the stream sees one consistent state.
+type ListServersRequest struct {
+	Search        string     `json:"search,omitempty"`
+	Version       string     `json:"version,omitempty"`
+	UpdatedSince  *time.Time `json:"updatedSince,omitempty"`
+	RegistryType  string     `json:"registryType,omitempty"`
+	Transport     string     `json:"transport,omitempty"`
+	Statuses      []string   `json:"statuses,omitempty"`
+	IncludeYanked bool       `json:"includeYanked,omitempty"`
+}
+
+// GetServerRequest represents the input for getting a server version, the latest by default
+type GetServerRequest struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// SearchServersRequest represents the input for a full-text search of servers
+type SearchServersRequest struct {
+	Query  string `json:"query"`
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int32  `json:"limit,omitempty"`
+}
+
+// PublishServerRequest represents the input for publishing a server. The registry token is sent
+// as "authorization: Bearer <token>" metadata.
+type PublishServerRequest struct {
+	Server         apiv0.ServerJSON `json:"server"`
+	IdempotencyKey string           `json:"idempotencyKey,omitempty"`
+}
+
+// server implements the registry service on top of the same service.RegistryService as REST
+type server struct {
+	registry   service.RegistryService
+	cfg        *config.Config
+	jwtManager *auth.JWTManager
+}
+
+// NewServer returns a gRPC server serving the registry service, along with the reflection service
+// so that clients can discover it without the .proto file. Unary RPCs share the rate limits of
+// the REST API, and publishes are recorded in the audit log like REST ones.
+func NewServer(registry service.RegistryService, cfg *config.Config, rateLimitStore ratelimit.Store) (*grpc.Server, error) {
+	file, err := File()
+	if err != nil {
+		return nil, err
+	}
+	srv := &server{registry: registry, cfg: cfg, jwtManager: auth.NewJWTManager(cfg)}
+
+	desc := &grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Metadata:    protoFileName,
+	}
+	rpcs := file.Services().ByName(protoreflect.Name(strings.TrimPrefix(ServiceName, ProtoPackage+"."))).Methods()
+	for _, m := range methods {
+		rpc := rpcs.ByName(protoreflect.Name(m.name))
+		if m.serverStreaming {
+			desc.Streams = append(desc.Streams, grpc.StreamDesc{
+				StreamName:    m.name,
+				ServerStreams: true,
+				Handler:       srv.streamHandler(rpc),
+			})
+		} else {
+			desc.Methods = append(desc.Methods, grpc.MethodDesc{
+				MethodName: m.name,
+				Handler:    srv.unaryHandler(rpc),
+			})
+		}
+	}
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(srv.rateLimitInterceptor(rateLimitStore), srv.auditInterceptor))
+	grpcServer.RegisterService(desc, srv)
+	reflection.Register(grpcServer)
+	return grpcServer, nil
+}
+
+// unaryHandler decodes an RPC's request into its Go type, calls the method and encodes its response
+func (s *server) unaryHandler(rpc protoreflect.MethodDescriptor) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(_ any, ctx context.Context, decode func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		request := dynamicpb.NewMessage(rpc.Input())
+		if err := decode(request); err != nil {
+			return nil, err
+		}
+		handle := func(ctx context.Context, req any) (any, error) {
+			var response any
+			var err error
+			switch rpc.Name() {
+			case "GetServer":
+				var input GetServerRequest
+				if err := fromMessage(req.(proto.Message), &input); err != nil {
+					return nil, err
+				}
+				response, err = s.getServer(ctx, &input)
+			case "SearchServers":
+				var input SearchServersRequest
+				if err := fromMessage(req.(proto.Message), &input); err != nil {
+					return nil, err
+				}
+				response, err = s.searchServers(ctx, &input)
+			case "PublishServer":
+				var input PublishServerRequest
+				if err := fromMessage(req.(proto.Message), &input); err != nil {
+					return nil, err
+				}
+				response, err = s.publishServer(ctx, &input)
+			default:
+				return nil, status.Errorf(codes.Unimplemented, "method %s not implemented", rpc.Name())
+			}
+			if err != nil {
+				return nil, err
+			}
+			return toMessage(response, rpc.Output())
+		}
+		if interceptor == nil {
+			return handle(ctx, request)
+		}
+		info := &grpc.UnaryServerInfo{Server: s, FullMethod: "/" + ServiceName + "/" + string(rpc.Name())}
+		return interceptor(ctx, request, info, handle)
+	}
+}
+
+// streamHandler decodes a streaming RPC's request into its Go type and sends each response
+func (s *server) streamHandler(rpc protoreflect.MethodDescriptor) grpc.StreamHandler {
+	return func(_ any, stream grpc.ServerStream) error {
+		request := dynamicpb.NewMessage(rpc.Input())
+		if err := stream.RecvMsg(request); err != nil {
+			return err
+		}
+		send := func(response any) error {
+			message, err := toMessage(response, rpc.Output())
+			if err != nil {
+				return err
+			}
+			return stream.SendMsg(message)
+		}
+
+		switch rpc.Name() {
+		case "ListServers":
+			var input ListServersRequest
+			if err := fromMessage(request, &input); err != nil {
+				return err
+			}
+			return s.listServers(stream.Context(), &input, send)
+		default:
+			return status.Errorf(codes.Unimplemented, "method %s not implemented", rpc.Name())
+		}
+	}
+}
+
+// listServers streams every matching server version, page by page from a snapshot
+func (s *server) listServers(ctx context.Context, input *ListServersRequest, send func(any) error) error {
+	snapshot, err := s.registry.CreateSnapshot(ctx)
+	if err != nil {
+		return rpcError(err, "failed to create snapshot")
+	}
+
+	quarantined := false
+	filter := &database.ServerFilter{Snapshot: &snapshot.ID, Quarantined: &quarantined, UpdatedSince: input.UpdatedSince}
+	if input.Search != "" {
+		filter.SubstringName = &input.Search
+	}
+	if input.Version == "latest" {
+		isLatest := true
+		filter.IsLatest = &isLatest
+	} else if input.Version != "" {
+		filter.Version = &input.Version
+	}
+	if !input.IncludeYanked {
+		yanked := false
+		filter.Yanked = &yanked
+	}
+	for _, serverStatus := range input.Statuses {
+		switch model.Status(serverStatus) {
+		case model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+		default:
+			return status.Errorf(codes.InvalidArgument, "unknown status: %s (expected active, deprecated or deleted)", serverStatus)
+		}
+	}
+	filter.Statuses = input.Statuses
+	if input.RegistryType != "" {
+		filter.RegistryType = &input.RegistryType
+	}
+	if input.Transport != "" {
+		filter.Transport = &input.Transport
+	}
+
+	cursor := ""
+	for {
+		servers, nextCursor, err := s.registry.ListServers(ctx, filter, cursor, listPageSize)
+		if err != nil {
+			return rpcError(err, "failed to list servers")
+		}
+		for _, server := range servers {
+			if err := send(server); err != nil {
+				return err
+			}
+		}
+		if nextCursor == "" {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// getServer returns a server version, naming where a server that moved can now be found
+func (s *server) getServer(ctx context.Context, input *GetServerRequest) (*apiv0.ServerResponse, error) {
+	if input.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	var serverResponse *apiv0.ServerResponse
+	var err error
+	if input.Version == "" || input.Version == "latest" {
+		serverResponse, err = s.registry.GetServerByName(ctx, input.Name)
+	} else {
+		serverResponse, err = s.registry.GetServerByNameAndVersion(ctx, input.Name, input.Version)
+	}
+	if errors.Is(err, database.ErrNotFound) {
+		if redirect, redirectErr := s.registry.GetServerRedirect(ctx, input.Name); redirectErr == nil {
+			return nil, status.Errorf(codes.NotFound, "server moved to %s", redirect.To)
+		}
+		return nil, status.Error(codes.NotFound, "server not found")
+	}
+	if err != nil {
+		return nil, rpcError(err, "failed to get server details")
+	}
+	return serverResponse, nil
+}
+
+// searchServers returns a page of full-text search results
+func (s *server) searchServers(ctx context.Context, input *SearchServersRequest) (*apiv0.ServerSearchResponse, error) {
+	if strings.TrimSpace(input.Query) == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	limit := int(input.Limit)
+	if limit <= 0 {
+		limit = 30
+	}
+	limit = min(limit, 100)
+
+	results, nextCursor, err := s.registry.SearchServers(ctx, input.Query, input.Cursor, limit)
+	if err != nil {
+		return nil, rpcError(err, "failed to search servers")
+	}
+	return &apiv0.ServerSearchResponse{
+		Results:  results,
+		Metadata: apiv0.Metadata{NextCursor: nextCursor, Count: len(results)},
+	}, nil
+}
+
+// publishServer publishes a server version with the same checks as POST /v0/publish
+func (s *server) publishServer(ctx context.Context, input *PublishServerRequest) (*apiv0.ServerResponse, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization metadata, expected 'Bearer <token>'")
+	}
+	claims, err := s.jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired Registry JWT token")
+	}
+
+	body, err := json.Marshal(input.Server)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid server: %v", err)
+	}
+	if err := v0.CheckServerJSONLimits(s.cfg, body); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	publisher, reason, err := v0.AuthorizePublish(ctx, s.registry, s.jwtManager, claims, input.Server.Name)
+	if err != nil {
+		return nil, rpcError(err, "failed to check namespace permissions")
+	}
+	if publisher == nil {
+		return nil, status.Error(codes.PermissionDenied, reason)
+	}
+
+	// Signatures are sent as HTTP headers, so signed servers are published over REST
+	published, _, err := s.registry.PublishServer(ctx, &input.Server, input.IdempotencyKey, nil, publisher)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotServerPublisher):
+			return nil, status.Error(codes.PermissionDenied, "You do not have permission to publish this server: "+err.Error())
+		case errors.Is(err, service.ErrSignatureRequired):
+			return nil, status.Error(codes.FailedPrecondition, err.Error()+"; publish it over the REST API with its signature")
+		case errors.Is(err, database.ErrInvalidVersion):
+			return nil, status.Errorf(codes.AlreadyExists, "version %s of %s is already published", input.Server.Version, input.Server.Name)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "failed to publish server: %v", err)
+	}
+	return published, nil
+}
+
+// rpcError maps a service error to a gRPC status, logging internal errors rather than returning them
+func rpcError(err error, message string) error {
+	switch {
+	case errors.Is(err, database.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	log.Printf("gRPC: %s: %v", message, err)
+	return status.Error(codes.Internal, message)
+}
+
+// toMessage converts a value of a Go type to the message generated from the type
+func toMessage(v any, desc protoreflect.MessageDescriptor) (proto.Message, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	message := dynamicpb.NewMessage(desc)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	return message, nil
+}
+
+// fromMessage converts a message to the Go type it was generated from
+func fromMessage(message proto.Message, v any) error {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid request: %v", err))
+	}
+	return nil
+}
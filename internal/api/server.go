@@ -13,6 +13,7 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -70,14 +71,15 @@ type Server struct {
 }
 
 // NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, registryService service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) *Server {
+func NewServer(cfg *config.Config, registryService service.RegistryService, rateLimitStore ratelimit.Store, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) *Server {
 	// Create HTTP mux and Huma API
 	mux := http.NewServeMux()
 
 	api := router.NewHumaAPI(cfg, registryService, mux, metrics, versionInfo)
 
-	// Wrap the mux with middleware
-	handler := TrailingSlashMiddleware(CORSMiddleware(cfg, mux))
+	// Wrap the mux with middleware. Rate limiting comes after CORS, so that preflight requests
+	// aren't limited and rejected requests can still be read by browsers.
+	handler := TrailingSlashMiddleware(CORSMiddleware(cfg, RateLimitMiddleware(cfg, rateLimitStore, mux)))
 
 	server := &Server{
 		config:   cfg,
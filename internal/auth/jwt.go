@@ -33,6 +33,8 @@ type JWTClaims struct {
 	AuthMethod        Method       `json:"auth_method"`
 	AuthMethodSubject string       `json:"auth_method_sub"`
 	Permissions       []Permission `json:"permissions"`
+	// ID of the personal access token this token was exchanged for, if any
+	PersonalAccessTokenID string `json:"pat_id,omitempty"`
 }
 
 type TokenResponse struct {
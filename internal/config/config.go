@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
@@ -18,6 +20,16 @@ type Config struct {
 	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
 
+	// Whether commands apply pending migrations when they connect to the database. Deployments that
+	// run `registry migrate up` as a separate step turn it off.
+	DatabaseAutoMigrate bool `env:"DATABASE_AUTO_MIGRATE" envDefault:"true"`
+
+	// Read replicas of the database, as a comma-separated list of connection URLs, that public reads
+	// of servers are spread across, and how often they're checked to answer. Reads go to the primary
+	// while no replica answers.
+	DatabaseReplicaURLs          string        `env:"DATABASE_REPLICA_URLS" envDefault:""`
+	DatabaseReplicaCheckInterval time.Duration `env:"DATABASE_REPLICA_CHECK_INTERVAL" envDefault:"10s"`
+
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
 	OIDCIssuer       string `env:"OIDC_ISSUER" envDefault:""`
@@ -25,6 +37,98 @@ type Config struct {
 	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
 	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
 	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
+
+	// Repositories whose GitHub Actions workflows may exchange OIDC tokens, as a comma-separated
+	// list of owner/repo or owner/*, or empty to allow every repository
+	GitHubOIDCAllowedRepositories string `env:"GITHUB_OIDC_ALLOWED_REPOSITORIES" envDefault:""`
+
+	// SMTP configuration for email notifications, which are disabled unless an address and sender are set
+	SMTPAddress  string `env:"SMTP_ADDRESS" envDefault:""`
+	SMTPFrom     string `env:"SMTP_FROM" envDefault:""`
+	SMTPUsername string `env:"SMTP_USERNAME" envDefault:""`
+	SMTPPassword string `env:"SMTP_PASSWORD" envDefault:""`
+
+	// Page sizes of the server list: the size of pages that don't ask for one, and the largest
+	// a page can ask for
+	DefaultPageSize int `env:"DEFAULT_PAGE_SIZE" envDefault:"30"`
+	MaxPageSize     int `env:"MAX_PAGE_SIZE" envDefault:"100"`
+
+	// Search ranking, which admins can override at runtime. The defaults search names only,
+	// ordered by name.
+	SearchNameWeight            float64 `env:"SEARCH_NAME_WEIGHT" envDefault:"1"`
+	SearchTitleWeight           float64 `env:"SEARCH_TITLE_WEIGHT" envDefault:"0"`
+	SearchDescriptionWeight     float64 `env:"SEARCH_DESCRIPTION_WEIGHT" envDefault:"0"`
+	SearchFreshnessBoost        float64 `env:"SEARCH_FRESHNESS_BOOST" envDefault:"0"`
+	SearchFreshnessHalfLifeDays float64 `env:"SEARCH_FRESHNESS_HALF_LIFE_DAYS" envDefault:"90"`
+	SearchPopularityBoost       float64 `env:"SEARCH_POPULARITY_BOOST" envDefault:"0"`
+	SearchVerifiedBoost         float64 `env:"SEARCH_VERIFIED_BOOST" envDefault:"0"`
+
+	// Upstream release watching, which is disabled unless an interval is set
+	UpstreamWatchInterval     time.Duration `env:"UPSTREAM_WATCH_INTERVAL" envDefault:"0"`
+	UpstreamWatchGitHubAPIURL string        `env:"UPSTREAM_WATCH_GITHUB_API_URL" envDefault:"https://api.github.com"`
+	UpstreamWatchGitHubToken  string        `env:"UPSTREAM_WATCH_GITHUB_TOKEN" envDefault:""`
+
+	// How many workers run background jobs, such as webhook deliveries, claim checks and exports,
+	// and how often they look for due ones. 0 workers leaves the jobs to other instances.
+	JobWorkers      int           `env:"JOB_WORKERS" envDefault:"4"`
+	JobPollInterval time.Duration `env:"JOB_POLL_INTERVAL" envDefault:"5s"`
+
+	// How often due webhook deliveries are sent, or 0 to leave them queued for another instance
+	WebhookDeliveryInterval time.Duration `env:"WEBHOOK_DELIVERY_INTERVAL" envDefault:"10s"`
+
+	// How often namespace claims due for a check are checked, or 0 to leave them to another
+	// instance, and how long a claim grants its namespace after its proof was last found
+	NamespaceClaimCheckInterval time.Duration `env:"NAMESPACE_CLAIM_CHECK_INTERVAL" envDefault:"1m"`
+	NamespaceClaimValidity      time.Duration `env:"NAMESPACE_CLAIM_VALIDITY" envDefault:"720h"`
+
+	// Whether publishing to a namespace that hasn't been transferred or added to an organization
+	// requires a verified claim on it, rather than only a token that grants it
+	RequireNamespaceClaims bool `env:"REQUIRE_NAMESPACE_CLAIMS" envDefault:"false"`
+
+	// How long servers admins delete can be restored before they're purged, freeing their names
+	DeletedServerRetention time.Duration `env:"DELETED_SERVER_RETENTION" envDefault:"720h"`
+
+	// How often the JSON export of every server version and the sitemap are rendered, or 0 to leave
+	// them to another instance, and the URL the registry is served at, which the sitemap links to
+	ExportInterval time.Duration `env:"EXPORT_INTERVAL" envDefault:"15m"`
+	PublicURL      string        `env:"PUBLIC_URL" envDefault:""`
+
+	// How long READMEs fetched for GET /v0/servers/{serverName}/readme are served before fetching them again
+	ReadmeCacheTTL time.Duration `env:"README_CACHE_TTL" envDefault:"1h"`
+
+	// Share of the requests for server details and reported installs counted towards the usage of
+	// servers, from 0 to 1. Each one counted stands in for those skipped, and 0 turns counting off.
+	UsageSampleRate float64 `env:"USAGE_SAMPLE_RATE" envDefault:"1"`
+
+	// How many server versions, and how many first pages of server listings, are kept in memory, and
+	// for how long. A size or time of 0 turns caching off.
+	ServerCacheSize int           `env:"SERVER_CACHE_SIZE" envDefault:"10000"`
+	ServerCacheTTL  time.Duration `env:"SERVER_CACHE_TTL" envDefault:"30s"`
+
+	// Whether POST /graphql serves queries of servers, their versions, packages and publishers
+	EnableGraphQL bool `env:"ENABLE_GRAPHQL" envDefault:"false"`
+
+	// Address the gRPC service listens on, such as ":9090". Empty turns the gRPC service off.
+	GRPCAddress string `env:"GRPC_ADDRESS" envDefault:""`
+
+	// Limits of the server.json bodies of publishes and edits: their size, the size of their _meta,
+	// and how deeply they nest objects and arrays
+	PublishMaxBodyBytes int64 `env:"PUBLISH_MAX_BODY_BYTES" envDefault:"1048576"`
+	PublishMaxMetaBytes int64 `env:"PUBLISH_MAX_META_BYTES" envDefault:"65536"`
+	PublishMaxJSONDepth int   `env:"PUBLISH_MAX_JSON_DEPTH" envDefault:"32"`
+
+	// Rate limits per caller, as token buckets refilled at a rate per minute up to a burst. Reads
+	// are GET and HEAD requests; publishes are every other request. A rate of 0 disables a limit.
+	RateLimitReadPerMinute    int `env:"RATE_LIMIT_READ_PER_MINUTE" envDefault:"600"`
+	RateLimitReadBurst        int `env:"RATE_LIMIT_READ_BURST" envDefault:"120"`
+	RateLimitPublishPerMinute int `env:"RATE_LIMIT_PUBLISH_PER_MINUTE" envDefault:"30"`
+	RateLimitPublishBurst     int `env:"RATE_LIMIT_PUBLISH_BURST" envDefault:"30"`
+	// Redis to share rate limits between replicas, or empty to limit each replica on its own
+	RateLimitRedisURL string `env:"RATE_LIMIT_REDIS_URL" envDefault:""`
+
+	// Take the IP address of clients, which rate limits and the audit log use, from the last
+	// X-Forwarded-For entry, when behind a proxy that sets it
+	TrustForwardedFor bool `env:"TRUST_FORWARDED_FOR" envDefault:"false"`
 }
 
 // NewConfig creates a new configuration with default values
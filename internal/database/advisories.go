@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const serverAdvisoryColumns = `
+	id::text, server_name, summary, details, severity, affected_versions, remediation, aliases,
+	auth_method, subject, published_at, updated_at
+`
+
+// advisoryExpression reads the highest severity of the advisories affecting a server version, or
+// an empty string
+const advisoryExpression = `COALESCE((SELECT a.severity FROM server_advisories a
+	WHERE a.server_name = servers.server_name AND servers.version = ANY(a.affected_versions)
+	ORDER BY array_position(ARRAY['low', 'moderate', 'high', 'critical'], a.severity::text) DESC LIMIT 1), '')`
+
+// CreateServerAdvisory stores a new security advisory
+func (db *PostgreSQL) CreateServerAdvisory(ctx context.Context, tx pgx.Tx, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_advisories (server_name, summary, details, severity, affected_versions, remediation, aliases, auth_method, subject)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + serverAdvisoryColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, advisory.ServerName, advisory.Summary, advisory.Details, advisory.Severity,
+		advisory.AffectedVersions, advisory.Remediation, nonNilStrings(advisory.Aliases),
+		advisory.PublishedBy.AuthMethod, advisory.PublishedBy.Subject)
+	created, err := scanServerAdvisory(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert server advisory: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateServerAdvisory replaces the contents of a security advisory of a server
+func (db *PostgreSQL) UpdateServerAdvisory(ctx context.Context, tx pgx.Tx, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE server_advisories
+		SET summary = $3, details = $4, severity = $5, affected_versions = $6, remediation = $7, aliases = $8, updated_at = NOW()
+		WHERE id = $1 AND server_name = $2
+		RETURNING ` + serverAdvisoryColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, advisory.ID, advisory.ServerName, advisory.Summary, advisory.Details,
+		advisory.Severity, advisory.AffectedVersions, advisory.Remediation, nonNilStrings(advisory.Aliases))
+	updated, err := scanServerAdvisory(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update server advisory: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteServerAdvisory withdraws a security advisory of a server
+func (db *PostgreSQL) DeleteServerAdvisory(ctx context.Context, tx pgx.Tx, serverName, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM server_advisories WHERE id = $1 AND server_name = $2`, id, serverName)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete server advisory: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListServerAdvisories lists the security advisories of a server, most recently published first
+func (db *PostgreSQL) ListServerAdvisories(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.SecurityAdvisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + serverAdvisoryColumns + `
+		FROM server_advisories
+		WHERE server_name = $1
+		ORDER BY published_at DESC, id
+	`
+
+	return db.queryServerAdvisories(ctx, tx, query, serverName)
+}
+
+// ListAdvisoriesUpdatedSince lists the security advisories of every server updated after a time,
+// least recently updated first, so that the feed can be followed by updated time. Advisories
+// updated at exactly that time are listed if their ID sorts after afterID.
+func (db *PostgreSQL) ListAdvisoriesUpdatedSince(ctx context.Context, tx pgx.Tx, since time.Time, afterID string, limit int) ([]apiv0.SecurityAdvisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + serverAdvisoryColumns + `
+		FROM server_advisories
+		WHERE updated_at > $1 OR (updated_at = $1 AND id::text > $2)
+		ORDER BY updated_at, id::text
+		LIMIT $3
+	`
+
+	return db.queryServerAdvisories(ctx, tx, query, since, afterID, limit)
+}
+
+func (db *PostgreSQL) queryServerAdvisories(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]apiv0.SecurityAdvisory, error) {
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server advisories: %w", err)
+	}
+	defer rows.Close()
+
+	advisories := []apiv0.SecurityAdvisory{}
+	for rows.Next() {
+		advisory, err := scanServerAdvisory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server advisory: %w", err)
+		}
+		advisories = append(advisories, *advisory)
+	}
+
+	return advisories, rows.Err()
+}
+
+func scanServerAdvisory(row pgx.Row) (*apiv0.SecurityAdvisory, error) {
+	var advisory apiv0.SecurityAdvisory
+	if err := row.Scan(&advisory.ID, &advisory.ServerName, &advisory.Summary, &advisory.Details, &advisory.Severity,
+		&advisory.AffectedVersions, &advisory.Remediation, &advisory.Aliases,
+		&advisory.PublishedBy.AuthMethod, &advisory.PublishedBy.Subject, &advisory.PublishedAt, &advisory.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if len(advisory.Aliases) == 0 {
+		advisory.Aliases = nil
+	}
+	return &advisory, nil
+}
+
+// nonNilStrings returns an empty slice for nil, which pgx would otherwise store as NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
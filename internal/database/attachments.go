@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PackageDocument is a document attached to a package of a server version, with its contents
+type PackageDocument struct {
+	Attachment apiv0.PackageAttachment
+	Content    []byte
+}
+
+const packageAttachmentColumns = `package_index, package_identifier, kind, format, media_type, digest, octet_length(content), created_at`
+
+// SetPackageAttachment stores a document attached to a package of a server version, replacing
+// the one of the same kind
+func (db *PostgreSQL) SetPackageAttachment(ctx context.Context, tx pgx.Tx, serverName, version string, document *PackageDocument) (*apiv0.PackageAttachment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	attachment := document.Attachment
+	query := `
+		INSERT INTO package_attachments (server_name, version, package_index, package_identifier, kind, format, media_type, digest, content)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (server_name, version, package_index, kind) DO UPDATE
+		SET package_identifier = $4, format = $6, media_type = $7, digest = $8, content = $9, created_at = NOW()
+		RETURNING ` + packageAttachmentColumns
+
+	stored, err := scanPackageAttachment(db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, version, attachment.PackageIndex,
+		attachment.Identifier, attachment.Kind, attachment.Format, attachment.MediaType, attachment.Digest, document.Content))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set package attachment: %w", err)
+	}
+
+	return stored, nil
+}
+
+// GetPackageAttachment retrieves a document attached to a package of a server version, with its
+// contents
+func (db *PostgreSQL) GetPackageAttachment(ctx context.Context, tx pgx.Tx, serverName, version string, packageIndex int, kind string) (*PackageDocument, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + packageAttachmentColumns + `, content FROM package_attachments
+		WHERE server_name = $1 AND version = $2 AND package_index = $3 AND kind = $4`
+
+	var document PackageDocument
+	attachment := &document.Attachment
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, version, packageIndex, kind).Scan(&attachment.PackageIndex,
+		&attachment.Identifier, &attachment.Kind, &attachment.Format, &attachment.MediaType, &attachment.Digest,
+		&attachment.Size, &attachment.CreatedAt, &document.Content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get package attachment: %w", err)
+	}
+
+	return &document, nil
+}
+
+// ListPackageAttachments lists the documents attached to the packages of a server version,
+// without their contents, in package order
+func (db *PostgreSQL) ListPackageAttachments(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageAttachment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + packageAttachmentColumns + ` FROM package_attachments
+		WHERE server_name = $1 AND version = $2 ORDER BY package_index, kind DESC`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list package attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []apiv0.PackageAttachment{}
+	for rows.Next() {
+		attachment, err := scanPackageAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan package attachment: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating package attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// DeletePackageAttachment deletes a document attached to a package of a server version
+func (db *PostgreSQL) DeletePackageAttachment(ctx context.Context, tx pgx.Tx, serverName, version string, packageIndex int, kind string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx,
+		`DELETE FROM package_attachments WHERE server_name = $1 AND version = $2 AND package_index = $3 AND kind = $4`,
+		serverName, version, packageIndex, kind)
+	if err != nil {
+		return fmt.Errorf("failed to delete package attachment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteStalePackageAttachments deletes the documents attached to packages of a server version
+// that are no longer at their index, given the identifiers of its packages in order
+func (db *PostgreSQL) DeleteStalePackageAttachments(ctx context.Context, tx pgx.Tx, serverName, version string, identifiers []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		DELETE FROM package_attachments
+		WHERE server_name = $1 AND version = $2
+			AND (package_index >= cardinality($3::text[]) OR package_identifier <> ($3::text[])[package_index + 1])
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version, identifiers); err != nil {
+		return fmt.Errorf("failed to delete stale package attachments: %w", err)
+	}
+
+	return nil
+}
+
+func scanPackageAttachment(row pgx.Row) (*apiv0.PackageAttachment, error) {
+	var attachment apiv0.PackageAttachment
+	err := row.Scan(&attachment.PackageIndex, &attachment.Identifier, &attachment.Kind, &attachment.Format,
+		&attachment.MediaType, &attachment.Digest, &attachment.Size, &attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
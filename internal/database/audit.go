@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// AuditFilter defines filtering options for audit log queries
+type AuditFilter struct {
+	ActorSubject    *string    // for the events of an actor
+	ActorAuthMethod *string    // for the events of actors who authenticated with a method
+	ServerName      *string    // for the events on a server
+	Since           *time.Time // for events recorded at or after a time
+	Until           *time.Time // for events recorded before a time
+	BeforeID        int64      // for continuing a page after the event with this ID
+}
+
+// AddAuditEvent appends an event to the audit log
+func (db *PostgreSQL) AddAuditEvent(ctx context.Context, tx pgx.Tx, event *apiv0.AuditEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var actorMethod, actorSubject *string
+	if event.Actor != nil {
+		actorMethod, actorSubject = &event.Actor.AuthMethod, &event.Actor.Subject
+	}
+
+	query := `
+		INSERT INTO audit_log (action, method, path, status, actor_auth_method, actor_subject, ip, server_name, version, before_digest, after_digest)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, ''))
+	`
+
+	_, err := db.getExecutor(ctx, tx).Exec(ctx, query,
+		event.Action,
+		event.Method,
+		event.Path,
+		event.Status,
+		actorMethod,
+		actorSubject,
+		event.IP,
+		event.ServerName,
+		event.Version,
+		event.BeforeDigest,
+		event.AfterDigest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents lists the audit events matching a filter, newest first
+func (db *PostgreSQL) ListAuditEvents(ctx context.Context, tx pgx.Tx, filter *AuditFilter, limit int) ([]apiv0.AuditEvent, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.ActorSubject != nil {
+		addCondition("actor_subject = $%d", *filter.ActorSubject)
+	}
+	if filter.ActorAuthMethod != nil {
+		addCondition("actor_auth_method = $%d", *filter.ActorAuthMethod)
+	}
+	if filter.ServerName != nil {
+		addCondition("server_name = $%d", *filter.ServerName)
+	}
+	if filter.Since != nil {
+		addCondition("created_at >= $%d", *filter.Since)
+	}
+	if filter.Until != nil {
+		addCondition("created_at < $%d", *filter.Until)
+	}
+	if filter.BeforeID > 0 {
+		addCondition("id < $%d", filter.BeforeID)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, action, method, path, status, actor_auth_method, actor_subject, ip, server_name, version, before_digest, after_digest, created_at
+		FROM audit_log
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, whereClause, len(args))
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	events := []apiv0.AuditEvent{}
+	for rows.Next() {
+		var event apiv0.AuditEvent
+		var actorMethod, actorSubject, ip, serverName, version, beforeDigest, afterDigest *string
+		if err := rows.Scan(&event.ID, &event.Action, &event.Method, &event.Path, &event.Status, &actorMethod, &actorSubject,
+			&ip, &serverName, &version, &beforeDigest, &afterDigest, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		if actorMethod != nil && actorSubject != nil {
+			event.Actor = &apiv0.Principal{AuthMethod: *actorMethod, Subject: *actorSubject}
+		}
+		event.IP = valueOrEmpty(ip)
+		event.ServerName = valueOrEmpty(serverName)
+		event.Version = valueOrEmpty(version)
+		event.BeforeDigest = valueOrEmpty(beforeDigest)
+		event.AfterDigest = valueOrEmpty(afterDigest)
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// GetServerBatch looks up many server versions in one query. versions holds the version to get
+// for the server at the same index of names, or an empty string for its latest version. The
+// result is in the order of names, with nil where no version matches.
+func (db *PostgreSQL) GetServerBatch(ctx context.Context, tx pgx.Tx, names, versions []string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(names) != len(versions) {
+		return nil, fmt.Errorf("%w: got %d names and %d versions", ErrInvalidInput, len(names), len(versions))
+	}
+
+	query := `
+		SELECT DISTINCT ON (ref.ord) ref.ord, server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value, ` + serverFlagColumns + `
+		FROM unnest($1::text[], $2::text[]) WITH ORDINALITY AS ref(ref_name, ref_version, ord)
+		JOIN servers ON server_name = ref.ref_name
+			AND CASE WHEN ref.ref_version = '' THEN is_latest ELSE version = ref.ref_version END
+			AND deleted_at IS NULL
+		ORDER BY ref.ord, published_at DESC
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, names, versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server batch: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*apiv0.ServerResponse, len(names))
+	for rows.Next() {
+		var ord int
+		var serverName, version, status string
+		var publishedAt, updatedAt time.Time
+		var isLatest bool
+		var yankedAt *time.Time
+		var revision int
+		var valueJSON []byte
+		var verified bool
+		var advisory string
+		var vulnerabilities *apiv0.VulnerabilityScan
+		var images []apiv0.OCIImage
+		var capabilities *apiv0.Capabilities
+		var statusDetails *apiv0.StatusDetails
+		var quarantine *apiv0.Quarantine
+		var usage *apiv0.Usage
+
+		err := rows.Scan(&ord, &serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &valueJSON,
+			&verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server batch row: %w", err)
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		// Ordinalities count from 1
+		results[ord-1] = &apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta: apiv0.ResponseMeta{
+				Official: &apiv0.RegistryExtensions{
+					Status:          model.Status(status),
+					PublishedAt:     publishedAt,
+					UpdatedAt:       updatedAt,
+					Revision:        revision,
+					IsLatest:        isLatest,
+					YankedAt:        yankedAt,
+					Verified:        verified,
+					Advisory:        advisory,
+					Vulnerabilities: vulnerabilities,
+					Images:          images,
+					Capabilities:    capabilities,
+					StatusDetails:   statusDetails,
+					Quarantine:      quarantine,
+					Usage:           usage,
+				},
+			},
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return results, nil
+}
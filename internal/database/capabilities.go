@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// capabilitiesExpression reads the capability inventory of a server version, or NULL when its
+// capabilities were never extracted
+const capabilitiesExpression = `(SELECT jsonb_strip_nulls(jsonb_build_object(
+		'status', vc.status, 'requestedAt', vc.requested_at, 'extractedAt', vc.extracted_at, 'error', vc.error,
+		'tools', vc.tools, 'resources', vc.resources, 'prompts', vc.prompts))
+	FROM server_version_capabilities vc
+	WHERE vc.server_name = servers.server_name AND vc.version = servers.version)`
+
+// RequestCapabilityExtraction queues the extraction of the capabilities of a server version. It
+// does nothing while an extraction of the version is already pending or running.
+func (db *PostgreSQL) RequestCapabilityExtraction(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_version_capabilities (server_name, version, status)
+		VALUES ($1, $2, 'pending')
+		ON CONFLICT (server_name, version)
+		DO UPDATE SET status = 'pending', requested_at = NOW(), started_at = NULL
+		WHERE server_version_capabilities.status IN ('succeeded', 'failed')`
+
+	_, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to request capability extraction: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimCapabilityExtraction marks the oldest pending extraction as running and returns the server
+// version to extract. Extractions that have been running since before staleBefore are claimed
+// again, since the worker running them is assumed to have died.
+func (db *PostgreSQL) ClaimCapabilityExtraction(ctx context.Context, tx pgx.Tx, staleBefore time.Time) (string, string, error) {
+	if ctx.Err() != nil {
+		return "", "", ctx.Err()
+	}
+
+	query := `
+		UPDATE server_version_capabilities SET status = 'running', started_at = NOW()
+		WHERE (server_name, version) = (
+			SELECT server_name, version FROM server_version_capabilities
+			WHERE status = 'pending' OR (status = 'running' AND started_at < $1)
+			ORDER BY requested_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING server_name, version`
+
+	var serverName, version string
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, staleBefore).Scan(&serverName, &version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", ErrNotFound
+		}
+		return "", "", fmt.Errorf("failed to claim capability extraction: %w", err)
+	}
+
+	return serverName, version, nil
+}
+
+// SetCapabilities records the outcome of a running extraction. A failed extraction keeps the
+// inventory of the last one that succeeded.
+func (db *PostgreSQL) SetCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string, capabilities *apiv0.Capabilities) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var query string
+	args := []any{serverName, version}
+	if capabilities.Status == "failed" {
+		query = `
+			UPDATE server_version_capabilities SET status = 'failed', started_at = NULL, error = $3
+			WHERE server_name = $1 AND version = $2 AND status = 'running'`
+		args = append(args, capabilities.Error)
+	} else {
+		query = `
+			UPDATE server_version_capabilities
+			SET status = 'succeeded', started_at = NULL, error = NULL, extracted_at = $3, tools = $4, resources = $5, prompts = $6
+			WHERE server_name = $1 AND version = $2 AND status = 'running'`
+		args = append(args, capabilities.ExtractedAt, capabilities.Tools, capabilities.Resources, capabilities.Prompts)
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set capabilities: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// pgForeignKeyViolation is returned by PostgreSQL when a row references one that doesn't exist
+const pgForeignKeyViolation = "23503"
+
+// SetServerChannel points a release channel of a server at one of its versions
+func (db *PostgreSQL) SetServerChannel(ctx context.Context, tx pgx.Tx, serverName, channel, version string) (*apiv0.ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_channels (server_name, channel, version)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (server_name, channel) DO UPDATE SET version = EXCLUDED.version, updated_at = NOW()
+		RETURNING channel, version, updated_at
+	`
+
+	var stored apiv0.ServerChannel
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, channel, version).Scan(&stored.Channel, &stored.Version, &stored.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set server channel: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// GetServerChannel retrieves the version a release channel of a server points at
+func (db *PostgreSQL) GetServerChannel(ctx context.Context, tx pgx.Tx, serverName, channel string) (*apiv0.ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT channel, version, updated_at FROM server_channels WHERE server_name = $1 AND channel = $2`
+
+	var stored apiv0.ServerChannel
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, channel).Scan(&stored.Channel, &stored.Version, &stored.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server channel: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// ListServerChannels lists the release channels set for a server
+func (db *PostgreSQL) ListServerChannels(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT channel, version, updated_at FROM server_channels WHERE server_name = $1 ORDER BY channel`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server channels: %w", err)
+	}
+	defer rows.Close()
+
+	channels := []apiv0.ServerChannel{}
+	for rows.Next() {
+		var channel apiv0.ServerChannel
+		if err := rows.Scan(&channel.Channel, &channel.Version, &channel.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server channel: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return channels, nil
+}
+
+// DeleteServerChannel removes a release channel from a server
+func (db *PostgreSQL) DeleteServerChannel(ctx context.Context, tx pgx.Tx, serverName, channel string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM server_channels WHERE server_name = $1 AND channel = $2`, serverName, channel)
+	if err != nil {
+		return fmt.Errorf("failed to delete server channel: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteVersionChannels removes every release channel pointing at a version of a server
+func (db *PostgreSQL) DeleteVersionChannels(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM server_channels WHERE server_name = $1 AND version = $2`, serverName, version); err != nil {
+		return fmt.Errorf("failed to delete version channels: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// listCursor is where a page of servers ends: the name and version of its last server when
+// listing by name, or the number of servers listed so far for ranked lists
+type listCursor struct {
+	Name    string `json:"n,omitempty"`
+	Version string `json:"v,omitempty"`
+	Offset  int    `json:"o,omitempty"`
+}
+
+// encode returns the cursor as an opaque, URL-safe string, so that clients pass it back as is
+// rather than building their own
+func (c listCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursor parses a cursor returned by a previous page. Cursors from before they were
+// opaque, "serverName:version" and bare offsets, are still accepted, so that clients paging
+// through a deploy carry on where they were.
+func decodeListCursor(cursor string, ranked bool) (listCursor, error) {
+	var c listCursor
+	if data, err := base64.RawURLEncoding.DecodeString(cursor); err == nil && json.Unmarshal(data, &c) == nil {
+		if c.Offset < 0 || (ranked && c.Name != "") || (!ranked && c.Offset > 0) {
+			return listCursor{}, fmt.Errorf("%w: the cursor is from a list with another order", ErrInvalidInput)
+		}
+		return c, nil
+	}
+
+	if ranked {
+		offset, err := strconv.Atoi(cursor)
+		if err != nil || offset < 0 {
+			return listCursor{}, fmt.Errorf("%w: invalid cursor for a search or rating sort", ErrInvalidInput)
+		}
+		return listCursor{Offset: offset}, nil
+	}
+	name, version, _ := strings.Cut(cursor, ":")
+	return listCursor{Name: name, Version: version}, nil
+}
@@ -21,12 +21,31 @@ var (
 
 // ServerFilter defines filtering options for server queries
 type ServerFilter struct {
-	Name          *string    // for finding versions of same server
-	RemoteURL     *string    // for duplicate URL detection
-	UpdatedSince  *time.Time // for incremental sync filtering
-	SubstringName *string    // for substring search on name
-	Version       *string    // for exact version matching
-	IsLatest      *bool      // for filtering latest versions only
+	Name          *string              // for finding versions of same server
+	RemoteURL     *string              // for duplicate URL detection
+	UpdatedSince  *time.Time           // for incremental sync filtering
+	SubstringName *string              // for substring search on name
+	Version       *string              // for exact version matching
+	IsLatest      *bool                // for filtering latest versions only
+	Channel       *string              // for filtering the versions a release channel points at
+	Yanked        *bool                // for leaving out (false) or only listing (true) yanked versions
+	Quarantined   *bool                // for leaving out (false) or only listing (true) quarantined servers
+	Statuses      []string             // for keeping versions with one of these statuses
+	Supports      []string             // for keeping servers a client with these transports and auth methods can use
+	RegistryType  *string              // for keeping servers with a package from this registry type
+	Transport     *string              // for keeping servers with a package or remote using this transport
+	Category      *string              // for keeping server versions in this publisher-provided category
+	Tag           *string              // for keeping server versions with this publisher-provided tag
+	Ranking       *apiv0.SearchRanking // for matching SubstringName against weighted fields and ordering by relevance
+	SortByRating  bool                 // for ordering by rating ahead of relevance and name
+	SortBySize    bool                 // for ordering by total OCI image size, smallest first, ahead of relevance and name
+	SortByUpdated bool                 // for ordering by when versions were last updated, most recent first, ahead of name
+	Verified      *bool                // for filtering servers in verified (true) or unverified (false) namespaces
+	MaxSeverity   *string              // for leaving out versions with scanned vulnerabilities more severe than this
+	Platform      *string              // for leaving out versions whose OCI images aren't built for this os/architecture
+	MaxImageSize  *int64               // for leaving out versions with an OCI image larger than this many bytes
+	Snapshot      *string              // for reading a snapshot of the servers instead of their current state
+	Fields        []string             // for reading only these top-level fields of each server.json, and only the registry metadata kept in columns unless "_meta" is one of them
 }
 
 // Database defines the interface for database operations
@@ -37,12 +56,20 @@ type Database interface {
 	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
 	// SetServerStatus updates the status of a specific server version
 	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error)
+	// SetServerStatusDetails record why a server version's status changed, or remove the details when nil
+	SetServerStatusDetails(ctx context.Context, tx pgx.Tx, serverName, version string, details *apiv0.StatusDetails) error
 	// ListServers retrieve server entries with optional filtering
 	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// CountServers count the server entries matching a filter, estimating (true) past a threshold
+	CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (int, bool, error)
+	// SearchServers match a full-text query against the latest version of every server, most relevant first
+	SearchServers(ctx context.Context, tx pgx.Tx, query, cursor string, limit int) ([]apiv0.ServerSearchResult, string, error)
 	// GetServerByName retrieve a single server by its name
 	GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetServerBatch retrieve many server versions in one query, in order, with nil where none matches
+	GetServerBatch(ctx context.Context, tx pgx.Tx, names, versions []string) ([]*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
@@ -51,11 +78,277 @@ type Database interface {
 	CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error)
 	// CheckVersionExists check if a specific version exists for a server
 	CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error)
+	// GetServerVersionByIdempotencyKey retrieve the version of a server published with an idempotency key
+	GetServerVersionByIdempotencyKey(ctx context.Context, tx pgx.Tx, serverName, key string) (string, error)
+	// SetServerIdempotencyKey record the idempotency key a version of a server was published with
+	SetServerIdempotencyKey(ctx context.Context, tx pgx.Tx, serverName, version, key string) error
 	// UnmarkAsLatest marks the current latest version of a server as no longer latest
 	UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error
+	// MarkAsLatest marks a version of a server as its latest
+	MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// SetServerYanked yanks a version of a server, or restores a yanked one
+	SetServerYanked(ctx context.Context, tx pgx.Tx, serverName, version string, yanked bool) error
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 	// This prevents race conditions when multiple versions are published concurrently
 	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
+	// GetNamespaceOwner retrieve the recorded owner of a namespace
+	GetNamespaceOwner(ctx context.Context, tx pgx.Tx, namespace string) (*apiv0.Principal, error)
+	// SetNamespaceOwner record the owner of a namespace, replacing any previous owner
+	SetNamespaceOwner(ctx context.Context, tx pgx.Tx, namespace string, owner apiv0.Principal) error
+	// ListNamespaceDelegates list the principals with delegated publish rights to a namespace
+	ListNamespaceDelegates(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.Principal, error)
+	// AddNamespaceDelegate grant a principal publish rights to a namespace
+	AddNamespaceDelegate(ctx context.Context, tx pgx.Tx, namespace string, delegate apiv0.Principal) error
+	// RemoveNamespaceDelegate revoke a principal's delegated publish rights to a namespace
+	RemoveNamespaceDelegate(ctx context.Context, tx pgx.Tx, namespace string, delegate apiv0.Principal) error
+	// CreateNamespaceRequest store a new pending transfer or delegation request
+	CreateNamespaceRequest(ctx context.Context, tx pgx.Tx, req *apiv0.NamespaceRequest) (*apiv0.NamespaceRequest, error)
+	// GetNamespaceRequest retrieve a transfer or delegation request by ID
+	GetNamespaceRequest(ctx context.Context, tx pgx.Tx, id string) (*apiv0.NamespaceRequest, error)
+	// ListPendingNamespaceRequests list the pending requests made by or addressed to a principal
+	ListPendingNamespaceRequests(ctx context.Context, tx pgx.Tx, principal apiv0.Principal) ([]apiv0.NamespaceRequest, error)
+	// ResolveNamespaceRequest move a pending request to its final status
+	ResolveNamespaceRequest(ctx context.Context, tx pgx.Tx, id, status string) (*apiv0.NamespaceRequest, error)
+	// AddNamespaceAuditEntry append an entry to the namespace audit log
+	AddNamespaceAuditEntry(ctx context.Context, tx pgx.Tx, entry *apiv0.NamespaceAuditEntry) error
+	// ListNamespaceAuditEntries list the audit log of a namespace, newest first
+	ListNamespaceAuditEntries(ctx context.Context, tx pgx.Tx, namespace string, limit int) ([]apiv0.NamespaceAuditEntry, error)
+	// SetNamespaceClaim store a principal's claim on a namespace, replacing their previous claim on it
+	SetNamespaceClaim(ctx context.Context, tx pgx.Tx, claim *NamespaceClaimCheck) (*NamespaceClaimCheck, error)
+	// GetNamespaceClaim retrieve a claim on a namespace by ID
+	GetNamespaceClaim(ctx context.Context, tx pgx.Tx, namespace, id string) (*NamespaceClaimCheck, error)
+	// ListNamespaceClaims list the claims on a namespace, or only a claimant's, most recent first
+	ListNamespaceClaims(ctx context.Context, tx pgx.Tx, namespace string, claimant *apiv0.Principal) ([]NamespaceClaimCheck, error)
+	// ListNamespaceClaimants list the principals with a verified claim on a namespace that hasn't expired
+	ListNamespaceClaimants(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.Principal, error)
+	// DeleteNamespaceClaim withdraw a claim on a namespace
+	DeleteNamespaceClaim(ctx context.Context, tx pgx.Tx, namespace, id string) error
+	// LeaseDueNamespaceClaim hand the claim that has been due for a check longest to a verifier
+	LeaseDueNamespaceClaim(ctx context.Context, tx pgx.Tx, leaseUntil time.Time) (*NamespaceClaimCheck, error)
+	// CreateOrganization create an organization without members
+	CreateOrganization(ctx context.Context, tx pgx.Tx, name string) error
+	// GetOrganization retrieve an organization with its members and namespaces
+	GetOrganization(ctx context.Context, tx pgx.Tx, name string) (*apiv0.Organization, error)
+	// SetOrganizationMember add a member to an organization or change their role
+	SetOrganizationMember(ctx context.Context, tx pgx.Tx, organization string, member apiv0.OrganizationMember) error
+	// RemoveOrganizationMember remove a member from an organization
+	RemoveOrganizationMember(ctx context.Context, tx pgx.Tx, organization string, member apiv0.Principal) error
+	// GetNamespaceOrganization retrieve the organization a namespace belongs to
+	GetNamespaceOrganization(ctx context.Context, tx pgx.Tx, namespace string) (string, error)
+	// AddOrganizationNamespace assign a namespace to an organization
+	AddOrganizationNamespace(ctx context.Context, tx pgx.Tx, organization, namespace string) error
+	// RemoveOrganizationNamespace release a namespace from an organization
+	RemoveOrganizationNamespace(ctx context.Context, tx pgx.Tx, organization, namespace string) error
+	// CreatePersonalAccessToken store a new personal access token under the hash of its secret
+	CreatePersonalAccessToken(ctx context.Context, tx pgx.Tx, token *apiv0.PersonalAccessToken, tokenHash string) (*apiv0.PersonalAccessToken, error)
+	// GetPersonalAccessTokenByHash retrieve a personal access token by the hash of its secret
+	GetPersonalAccessTokenByHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*apiv0.PersonalAccessToken, error)
+	// GetPersonalAccessToken retrieve one of a principal's personal access tokens by ID
+	GetPersonalAccessToken(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error)
+	// ListPersonalAccessTokens list the personal access tokens of a principal, newest first
+	ListPersonalAccessTokens(ctx context.Context, tx pgx.Tx, owner apiv0.Principal) ([]apiv0.PersonalAccessToken, error)
+	// RevokePersonalAccessToken revoke one of a principal's personal access tokens
+	RevokePersonalAccessToken(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error)
+	// RotatePersonalAccessToken replace the secret of one of a principal's unrevoked tokens
+	RotatePersonalAccessToken(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal, prefix, tokenHash string, expiresAt time.Time) (*apiv0.PersonalAccessToken, error)
+	// TouchPersonalAccessToken record that a personal access token was just used
+	TouchPersonalAccessToken(ctx context.Context, tx pgx.Tx, id string) error
+	// CreateNotificationSubscription store a new notification subscription
+	CreateNotificationSubscription(ctx context.Context, tx pgx.Tx, sub *apiv0.NotificationSubscription) (*apiv0.NotificationSubscription, error)
+	// ListNotificationSubscriptions list every notification subscription for a namespace
+	ListNotificationSubscriptions(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.NotificationSubscription, error)
+	// DeleteNotificationSubscription delete one of an owner's subscriptions for a namespace
+	DeleteNotificationSubscription(ctx context.Context, tx pgx.Tx, namespace, id string, owner apiv0.Principal) error
+	// DeleteOwnerNotificationSubscriptions delete all of a principal's subscriptions for a namespace
+	DeleteOwnerNotificationSubscriptions(ctx context.Context, tx pgx.Tx, namespace string, owner apiv0.Principal) error
+	// CreateWebhook store a new webhook
+	CreateWebhook(ctx context.Context, tx pgx.Tx, webhook *apiv0.Webhook) (*apiv0.Webhook, error)
+	// GetWebhook retrieve a webhook by ID
+	GetWebhook(ctx context.Context, tx pgx.Tx, id string) (*apiv0.Webhook, error)
+	// ListWebhooks list a principal's webhooks
+	ListWebhooks(ctx context.Context, tx pgx.Tx, owner apiv0.Principal) ([]apiv0.Webhook, error)
+	// ListFailingWebhooks list the webhooks whose last delivery attempt failed, those failing the longest first
+	ListFailingWebhooks(ctx context.Context, tx pgx.Tx, limit int) ([]apiv0.Webhook, error)
+	// DeleteWebhook delete one of a principal's webhooks
+	DeleteWebhook(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal) error
+	// EnqueueWebhookDeliveries queue an event for delivery to every webhook that wants it
+	EnqueueWebhookDeliveries(ctx context.Context, tx pgx.Tx, event string, payload []byte) error
+	// ListWebhookDeliveries list the most recent deliveries to a webhook, newest first
+	ListWebhookDeliveries(ctx context.Context, tx pgx.Tx, webhookID string, limit int) ([]apiv0.WebhookDelivery, error)
+	// ClaimWebhookDelivery count an attempt of the pending delivery that has been due longest, leasing it until leaseUntil
+	ClaimWebhookDelivery(ctx context.Context, tx pgx.Tx, leaseUntil time.Time) (*WebhookDeliveryAttempt, error)
+	// RecordWebhookDeliveryAttempt record the outcome of a delivery attempt on the delivery and its webhook
+	RecordWebhookDeliveryAttempt(ctx context.Context, tx pgx.Tx, outcome *apiv0.WebhookDelivery) error
+	// EnqueueJob queue a job, reporting false when a job with the same dedupe key was already queued
+	EnqueueJob(ctx context.Context, tx pgx.Tx, job *apiv0.Job) (bool, error)
+	// ClaimJob count an attempt of the due job of one of the given kinds that has been due longest, leasing it until leaseUntil
+	ClaimJob(ctx context.Context, tx pgx.Tx, kinds []string, leaseUntil time.Time) (*apiv0.Job, error)
+	// FinishJob record that a job's attempt succeeded, or failed and is retried at retryAt or dead when it is nil
+	FinishJob(ctx context.Context, tx pgx.Tx, id, jobErr string, retryAt *time.Time) error
+	// ListJobs list the jobs with a status, most recently created first
+	ListJobs(ctx context.Context, tx pgx.Tx, status string, limit int) ([]apiv0.Job, error)
+	// RetryJob queue a dead job again, with its attempts reset
+	RetryJob(ctx context.Context, tx pgx.Tx, id string) (*apiv0.Job, error)
+	// DeleteFinishedJobs delete the jobs with a status, succeeded or dead, that finished before a time, returning how many
+	DeleteFinishedJobs(ctx context.Context, tx pgx.Tx, status string, before time.Time) (int, error)
+	// RenameServer move every version of a server to a new name
+	RenameServer(ctx context.Context, tx pgx.Tx, oldName, newName string) error
+	// SetServerRedirect record that a server moved to a new name
+	SetServerRedirect(ctx context.Context, tx pgx.Tx, redirect *apiv0.ServerRedirect) (*apiv0.ServerRedirect, error)
+	// GetServerRedirect retrieve where a server that moved can now be found
+	GetServerRedirect(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerRedirect, error)
+	// ListServerRedirects list the redirects pointing at a server
+	ListServerRedirects(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.ServerRedirect, error)
+	// DeleteServerRedirect remove a redirect to a server
+	DeleteServerRedirect(ctx context.Context, tx pgx.Tx, fromName, toName string) error
+	// SetServerChannel point a release channel of a server at one of its versions
+	SetServerChannel(ctx context.Context, tx pgx.Tx, serverName, channel, version string) (*apiv0.ServerChannel, error)
+	// GetServerChannel retrieve the version a release channel of a server points at
+	GetServerChannel(ctx context.Context, tx pgx.Tx, serverName, channel string) (*apiv0.ServerChannel, error)
+	// ListServerChannels list the release channels set for a server
+	ListServerChannels(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.ServerChannel, error)
+	// DeleteServerChannel remove a release channel from a server
+	DeleteServerChannel(ctx context.Context, tx pgx.Tx, serverName, channel string) error
+	// DeleteVersionChannels remove every release channel pointing at a version of a server
+	DeleteVersionChannels(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// ListRelationshipsTo list the relationships the latest versions of other servers declare to a server
+	ListRelationshipsTo(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.ServerRelationshipEdge, error)
+	// GetSearchRanking retrieve the search ranking an admin set, overriding the configured one
+	GetSearchRanking(ctx context.Context, tx pgx.Tx) (*apiv0.SearchRanking, error)
+	// SetSearchRanking store the search ranking, overriding the configured one
+	SetSearchRanking(ctx context.Context, tx pgx.Tx, ranking *apiv0.SearchRanking) (*apiv0.SearchRanking, error)
+	// DeleteSearchRanking remove the search ranking an admin set, restoring the configured one
+	DeleteSearchRanking(ctx context.Context, tx pgx.Tx) error
+	// GetNamespaceVerification retrieve how a namespace was verified
+	GetNamespaceVerification(ctx context.Context, tx pgx.Tx, namespace string) (*apiv0.NamespaceVerification, error)
+	// SetNamespaceVerification record that a namespace is verified
+	SetNamespaceVerification(ctx context.Context, tx pgx.Tx, verification *apiv0.NamespaceVerification) (*apiv0.NamespaceVerification, error)
+	// DeleteNamespaceVerification revoke the verification of a namespace
+	DeleteNamespaceVerification(ctx context.Context, tx pgx.Tx, namespace string) error
+	// CreateServerAdvisory store a new security advisory
+	CreateServerAdvisory(ctx context.Context, tx pgx.Tx, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error)
+	// UpdateServerAdvisory replace the contents of a security advisory of a server
+	UpdateServerAdvisory(ctx context.Context, tx pgx.Tx, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error)
+	// DeleteServerAdvisory withdraw a security advisory of a server
+	DeleteServerAdvisory(ctx context.Context, tx pgx.Tx, serverName, id string) error
+	// ListServerAdvisories list the security advisories of a server, most recently published first
+	ListServerAdvisories(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.SecurityAdvisory, error)
+	// ListAdvisoriesUpdatedSince list the security advisories of every server updated after a time, oldest first
+	ListAdvisoriesUpdatedSince(ctx context.Context, tx pgx.Tx, since time.Time, afterID string, limit int) ([]apiv0.SecurityAdvisory, error)
+	// SetPackageScan record the vulnerabilities found in an OCI image of a server version, replacing its earlier scan
+	SetPackageScan(ctx context.Context, tx pgx.Tx, serverName, version, identifier string, scan *apiv0.VulnerabilityScan) error
+	// SetPackageImage record what the registry found out about an OCI image of a server version
+	SetPackageImage(ctx context.Context, tx pgx.Tx, serverName, version string, image *apiv0.OCIImage) error
+	// RequestCapabilityExtraction queue the extraction of the capabilities of a server version
+	RequestCapabilityExtraction(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// ClaimCapabilityExtraction mark the oldest pending extraction as running, returning its server name and version
+	ClaimCapabilityExtraction(ctx context.Context, tx pgx.Tx, staleBefore time.Time) (string, string, error)
+	// SetCapabilities record the outcome of a running extraction
+	SetCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string, capabilities *apiv0.Capabilities) error
+	// CreateSnapshot copy every server version into a new snapshot, deleting expired snapshots
+	CreateSnapshot(ctx context.Context, tx pgx.Tx, expiresAt time.Time) (*Snapshot, error)
+	// GetSnapshot retrieve a snapshot by ID
+	GetSnapshot(ctx context.Context, tx pgx.Tx, id string) (*Snapshot, error)
+	// GetNewestSnapshot retrieve the most recently created snapshot
+	GetNewestSnapshot(ctx context.Context, tx pgx.Tx) (*Snapshot, error)
+	// PutExport store a document rendered from the whole registry, replacing the one of the same name
+	PutExport(ctx context.Context, tx pgx.Tx, export *Export) error
+	// GetExport retrieve an export by name, with its body gzip-encoded or not
+	GetExport(ctx context.Context, tx pgx.Tx, name string, gzipped bool) (*Export, error)
+	// CreateUpdateProposal store a proposal to publish a new version of a server
+	CreateUpdateProposal(ctx context.Context, tx pgx.Tx, proposal *apiv0.UpdateProposal) (*apiv0.UpdateProposal, error)
+	// GetUpdateProposal retrieve an update proposal of a server
+	GetUpdateProposal(ctx context.Context, tx pgx.Tx, serverName, id string) (*apiv0.UpdateProposal, error)
+	// ListUpdateProposals list the update proposals of a server, or of every server, most recent first
+	ListUpdateProposals(ctx context.Context, tx pgx.Tx, serverName, status string, limit int) ([]apiv0.UpdateProposal, error)
+	// SetUpdateProposalStatus record the status of an update proposal and why publishing it failed
+	SetUpdateProposalStatus(ctx context.Context, tx pgx.Tx, id, status, errorMessage string) error
+	// GetUpdatePolicy retrieve how new upstream releases of a server are handled
+	GetUpdatePolicy(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.UpdatePolicy, error)
+	// SetUpdatePolicy set how new upstream releases of a server are handled
+	SetUpdatePolicy(ctx context.Context, tx pgx.Tx, serverName string, policy *apiv0.UpdatePolicy) error
+	// GetSigningKey retrieve the public key a server's versions must be signed with
+	GetSigningKey(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.SigningKey, error)
+	// SetSigningKey set the public key a server's versions must be signed with, replacing any other
+	SetSigningKey(ctx context.Context, tx pgx.Tx, serverName string, key *apiv0.SigningKey) (*apiv0.SigningKey, error)
+	// DeleteSigningKey delete a server's signing key
+	DeleteSigningKey(ctx context.Context, tx pgx.Tx, serverName string) error
+	// SetServerSignature store the signature of a server version, replacing any other
+	SetServerSignature(ctx context.Context, tx pgx.Tx, signature *apiv0.ServerSignature) (*apiv0.ServerSignature, error)
+	// GetServerSignature retrieve the signature of a server version
+	GetServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.ServerSignature, error)
+	// DeleteServerSignature delete the signature of a server version, if it has one
+	DeleteServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// SetPackageAttachment store a document attached to a package of a server version, replacing the one of the same kind
+	SetPackageAttachment(ctx context.Context, tx pgx.Tx, serverName, version string, document *PackageDocument) (*apiv0.PackageAttachment, error)
+	// GetPackageAttachment retrieve a document attached to a package of a server version, with its contents
+	GetPackageAttachment(ctx context.Context, tx pgx.Tx, serverName, version string, packageIndex int, kind string) (*PackageDocument, error)
+	// ListPackageAttachments list the documents attached to the packages of a server version, without their contents
+	ListPackageAttachments(ctx context.Context, tx pgx.Tx, serverName, version string) ([]apiv0.PackageAttachment, error)
+	// DeletePackageAttachment delete a document attached to a package of a server version
+	DeletePackageAttachment(ctx context.Context, tx pgx.Tx, serverName, version string, packageIndex int, kind string) error
+	// DeleteStalePackageAttachments delete the documents attached to packages no longer at their index
+	DeleteStalePackageAttachments(ctx context.Context, tx pgx.Tx, serverName, version string, identifiers []string) error
+	// UpsertServerReview store a principal's review of a server, replacing their earlier one
+	UpsertServerReview(ctx context.Context, tx pgx.Tx, review *apiv0.ServerReview) (*apiv0.ServerReview, error)
+	// GetServerReview retrieve a principal's review of a server, even if hidden
+	GetServerReview(ctx context.Context, tx pgx.Tx, serverName string, reviewer apiv0.Principal) (*apiv0.ServerReview, error)
+	// ListServerReviews list the reviews of a server that aren't hidden, most recently updated first
+	ListServerReviews(ctx context.Context, tx pgx.Tx, serverName string, offset, limit int) ([]apiv0.ServerReview, error)
+	// GetServerRating aggregate the ratings of a server that aren't hidden
+	GetServerRating(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerRating, error)
+	// CountServerReviewsSince count the reviews a principal left since a time
+	CountServerReviewsSince(ctx context.Context, tx pgx.Tx, reviewer apiv0.Principal, since time.Time) (int, error)
+	// DeleteServerReview delete a principal's review of a server
+	DeleteServerReview(ctx context.Context, tx pgx.Tx, serverName string, reviewer apiv0.Principal) error
+	// SetServerReviewHidden hide a review, or show a hidden one again
+	SetServerReviewHidden(ctx context.Context, tx pgx.Tx, id string, hidden bool) (*apiv0.ServerReview, error)
+	// AddAuditEvent append an event to the audit log
+	AddAuditEvent(ctx context.Context, tx pgx.Tx, event *apiv0.AuditEvent) error
+	// ListAuditEvents list the audit events matching a filter, newest first
+	ListAuditEvents(ctx context.Context, tx pgx.Tx, filter *AuditFilter, limit int) ([]apiv0.AuditEvent, error)
+	// CreateModerationCase quarantine a server, failing if it is already quarantined
+	CreateModerationCase(ctx context.Context, tx pgx.Tx, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error)
+	// GetOpenModerationCase retrieve the case a server is quarantined by
+	GetOpenModerationCase(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ModerationCase, error)
+	// ResolveModerationCase close an open case with the server reinstated or deleted
+	ResolveModerationCase(ctx context.Context, tx pgx.Tx, id, status, resolution string, resolvedBy apiv0.Principal) (*apiv0.ModerationCase, error)
+	// ListModerationCases list the moderation cases with a status, or every case, most recent first
+	ListModerationCases(ctx context.Context, tx pgx.Tx, status string, limit int) ([]apiv0.ModerationCase, error)
+	// PurgeServer permanently delete every version of a server and what the registry knows about it
+	PurgeServer(ctx context.Context, tx pgx.Tx, serverName string) error
+	// SoftDeleteServer hide every version of a server from reads until it is restored or purged
+	SoftDeleteServer(ctx context.Context, tx pgx.Tx, serverName string) error
+	// RestoreDeletedServer bring back the versions of a soft-deleted server
+	RestoreDeletedServer(ctx context.Context, tx pgx.Tx, serverName string) error
+	// GetServerDeletedAt retrieve when a server was soft-deleted
+	GetServerDeletedAt(ctx context.Context, tx pgx.Tx, serverName string) (time.Time, error)
+	// ListDeletedServers list the names of the servers soft-deleted before a time
+	ListDeletedServers(ctx context.Context, tx pgx.Tx, deletedBefore time.Time) ([]string, error)
+	// GetServerPublisher retrieve the identity that first published a server
+	GetServerPublisher(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.Principal, error)
+	// SetServerPublisher record the identity that first published a server, keeping one already recorded
+	SetServerPublisher(ctx context.Context, tx pgx.Tx, serverName string, publisher apiv0.Principal) error
+	// RecordServerUsage count requests for a server's details and installs of it towards today's statistics
+	RecordServerUsage(ctx context.Context, tx pgx.Tx, serverName string, requests, installs int64) error
+	// RecordServerPulls snapshot the pull count the latest version of a server reports into today's statistics
+	RecordServerPulls(ctx context.Context, tx pgx.Tx, serverName string) error
+	// SetServerTaxonomy record the categories and tags a server version's publisher-provided metadata lists
+	SetServerTaxonomy(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// ListCategories count the listed servers in each category, most common first
+	ListCategories(ctx context.Context, tx pgx.Tx) ([]apiv0.TaxonomyTerm, error)
+	// ListTags count the listed servers with each tag, most common first
+	ListTags(ctx context.Context, tx pgx.Tx) ([]apiv0.TaxonomyTerm, error)
+	// GetRegistryStats count the listed servers by registry type and transport, and the publishes of each of the last days
+	GetRegistryStats(ctx context.Context, tx pgx.Tx, days int) (*apiv0.RegistryStats, error)
+	// ListTrendingServers rank the active listed servers by their requests and pull growth over the last days
+	ListTrendingServers(ctx context.Context, tx pgx.Tx, days, limit int) ([]ServerUsage, error)
+	// PendingMigrations list the embedded migrations not applied to the database
+	PendingMigrations(ctx context.Context, tx pgx.Tx) ([]string, error)
+	// Migrate apply the embedded migrations not applied to the database
+	Migrate(ctx context.Context) error
+	// Ping check the database is reachable
+	Ping(ctx context.Context) error
 	// InTransaction executes a function within a database transaction
 	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
 	// Close closes the database connection
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Export is a document rendered from the whole registry, such as the JSON snapshot of every
+// server version or the sitemap
+type Export struct {
+	Name        string
+	ContentType string
+	ETag        string
+	GeneratedAt time.Time
+	Body        []byte
+	// GzipBody is Body gzip-encoded. Reads set one of the two, whichever encoding they asked for.
+	GzipBody []byte
+}
+
+// PutExport stores an export, replacing the one of the same name
+func (db *PostgreSQL) PutExport(ctx context.Context, tx pgx.Tx, export *Export) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO registry_exports (name, content_type, etag, body, gzip_body, generated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO UPDATE SET
+			content_type = EXCLUDED.content_type,
+			etag = EXCLUDED.etag,
+			body = EXCLUDED.body,
+			gzip_body = EXCLUDED.gzip_body,
+			generated_at = EXCLUDED.generated_at
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query,
+		export.Name, export.ContentType, export.ETag, export.Body, export.GzipBody, export.GeneratedAt,
+	); err != nil {
+		return fmt.Errorf("failed to store export %s: %w", export.Name, err)
+	}
+
+	return nil
+}
+
+// GetExport retrieves an export by name, with its body gzip-encoded or not
+func (db *PostgreSQL) GetExport(ctx context.Context, tx pgx.Tx, name string, gzipped bool) (*Export, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT name, content_type, etag, generated_at, CASE WHEN $2 THEN gzip_body ELSE body END
+		FROM registry_exports
+		WHERE name = $1
+	`
+
+	var export Export
+	var body []byte
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, name, gzipped).Scan(
+		&export.Name, &export.ContentType, &export.ETag, &export.GeneratedAt, &body,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get export %s: %w", name, err)
+	}
+
+	if gzipped {
+		export.GzipBody = body
+	} else {
+		export.Body = body
+	}
+	return &export, nil
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetServerVersionByIdempotencyKey retrieves the version of a server published with an idempotency key
+func (db *PostgreSQL) GetServerVersionByIdempotencyKey(ctx context.Context, tx pgx.Tx, serverName, key string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	query := `SELECT version FROM servers WHERE server_name = $1 AND idempotency_key = $2`
+
+	var version string
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, key).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get server by idempotency key: %w", err)
+	}
+
+	return version, nil
+}
+
+// SetServerIdempotencyKey records the idempotency key a version of a server was published with
+func (db *PostgreSQL) SetServerIdempotencyKey(ctx context.Context, tx pgx.Tx, serverName, version, key string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE servers SET idempotency_key = $3 WHERE server_name = $1 AND version = $2`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version, key)
+	if err != nil {
+		return fmt.Errorf("failed to set server idempotency key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
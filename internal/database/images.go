@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// imagesExpression collects what the registry recorded about the OCI images of a server version,
+// or NULL when it recorded nothing
+const imagesExpression = `(SELECT jsonb_agg(jsonb_strip_nulls(jsonb_build_object(
+		'identifier', vi.package_identifier, 'platforms', vi.platforms,
+		'size', vi.compressed_size, 'memory', vi.memory, 'cpus', vi.cpus))
+		ORDER BY vi.package_identifier)
+	FROM server_version_images vi
+	WHERE vi.server_name = servers.server_name AND vi.version = servers.version)`
+
+// SetPackageImage records what the registry found out about an OCI image of a server version,
+// replacing what it recorded before
+func (db *PostgreSQL) SetPackageImage(ctx context.Context, tx pgx.Tx, serverName, version string, image *apiv0.OCIImage) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_version_images (server_name, version, package_identifier, platforms, compressed_size, memory, cpus)
+		VALUES ($1, $2, $3, $4, NULLIF($5::bigint, 0), NULLIF($6::text, ''), NULLIF($7::text, ''))
+		ON CONFLICT (server_name, version, package_identifier)
+		DO UPDATE SET platforms = $4, compressed_size = NULLIF($5::bigint, 0), memory = NULLIF($6::text, ''), cpus = NULLIF($7::text, '')`
+
+	_, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version, image.Identifier, image.Platforms, image.Size, image.Memory, image.CPUs)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set package image: %w", err)
+	}
+
+	return nil
+}
+
+// platformCondition builds the condition keeping server versions with an OCI image built for a
+// platform, or whose images' platforms weren't recorded. A platform without a variant matches
+// every variant of it.
+func platformCondition(argIndex int) string {
+	return fmt.Sprintf(`(NOT EXISTS (SELECT 1 FROM server_version_images vi
+		WHERE vi.server_name = servers.server_name AND vi.version = servers.version AND cardinality(vi.platforms) > 0)
+	OR EXISTS (SELECT 1 FROM server_version_images vi, unnest(vi.platforms) AS p
+		WHERE vi.server_name = servers.server_name AND vi.version = servers.version AND (p = $%d OR p LIKE $%d || '/%%')))`,
+		argIndex, argIndex)
+}
+
+// imageSizeExpression sums the compressed sizes of the OCI images of a server version, or NULL
+// when none was recorded
+const imageSizeExpression = `(SELECT SUM(vi.compressed_size) FROM server_version_images vi
+	WHERE vi.server_name = servers.server_name AND vi.version = servers.version)`
+
+// maxImageSizeCondition builds the condition keeping server versions none of whose OCI images is
+// larger than a number of bytes. Versions whose image sizes weren't recorded are kept.
+func maxImageSizeCondition(argIndex int) string {
+	return fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM server_version_images vi
+		WHERE vi.server_name = servers.server_name AND vi.version = servers.version AND vi.compressed_size > $%d)`, argIndex)
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const jobColumns = `
+	id::text, kind, payload, COALESCE(dedupe_key, ''), status, attempts, max_attempts, run_at, COALESCE(last_error, ''),
+	created_at, finished_at
+`
+
+// EnqueueJob queues a job to run at its RunAt, or at once when it is zero. It reports false
+// without queueing anything when a job with the same dedupe key was already queued.
+func (db *PostgreSQL) EnqueueJob(ctx context.Context, tx pgx.Tx, job *apiv0.Job) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	payload := job.Payload
+	if payload == nil {
+		payload = json.RawMessage("{}")
+	}
+	var runAt *time.Time
+	if !job.RunAt.IsZero() {
+		runAt = &job.RunAt
+	}
+
+	query := `
+		INSERT INTO jobs (kind, payload, dedupe_key, max_attempts, run_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, COALESCE($5, NOW()))
+		ON CONFLICT (dedupe_key) DO NOTHING`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, job.Kind, payload, job.DedupeKey, job.MaxAttempts, runAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ClaimJob counts an attempt of the job of one of the given kinds that has been due longest and
+// returns it. Running jobs whose lease ran out are due again, so that a job whose worker died is
+// run again rather than left running; the lease of the claimed job runs until leaseUntil.
+func (db *PostgreSQL) ClaimJob(ctx context.Context, tx pgx.Tx, kinds []string, leaseUntil time.Time) (*apiv0.Job, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE jobs SET status = 'running', attempts = attempts + 1, run_at = $2
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status IN ('queued', 'running') AND run_at <= NOW() AND kind = ANY($1)
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(db.getExecutor(ctx, tx).QueryRow(ctx, query, kinds, leaseUntil))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return job, nil
+}
+
+// FinishJob records the outcome of a job's attempt: it succeeded without an error, and otherwise
+// is queued again at retryAt, or dead when retryAt is nil
+func (db *PostgreSQL) FinishJob(ctx context.Context, tx pgx.Tx, id, jobErr string, retryAt *time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE jobs SET
+			status = CASE WHEN $2 = '' THEN 'succeeded' WHEN $3::timestamptz IS NULL THEN 'dead' ELSE 'queued' END,
+			last_error = NULLIF($2, ''),
+			run_at = COALESCE($3, run_at),
+			finished_at = CASE WHEN $2 = '' OR $3::timestamptz IS NULL THEN NOW() END
+		WHERE id = $1 AND status = 'running'`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, id, jobErr, retryAt)
+	if err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListJobs lists the jobs with a status, most recently created first
+func (db *PostgreSQL) ListJobs(ctx context.Context, tx pgx.Tx, status string, limit int) ([]apiv0.Job, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE status = $1 ORDER BY created_at DESC LIMIT $2`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []apiv0.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// RetryJob queues a dead job again, with its attempts reset
+func (db *PostgreSQL) RetryJob(ctx context.Context, tx pgx.Tx, id string) (*apiv0.Job, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE jobs SET status = 'queued', attempts = 0, run_at = NOW(), finished_at = NULL
+		WHERE id::text = $1 AND status = 'dead'
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(db.getExecutor(ctx, tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to retry job: %w", err)
+	}
+	return job, nil
+}
+
+// DeleteFinishedJobs deletes the jobs with a status, succeeded or dead, that finished before a
+// time, returning how many
+func (db *PostgreSQL) DeleteFinishedJobs(ctx context.Context, tx pgx.Tx, status string, before time.Time) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM jobs WHERE status = $1 AND finished_at < $2`, status, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func scanJob(row pgx.Row) (*apiv0.Job, error) {
+	var job apiv0.Job
+	if err := row.Scan(&job.ID, &job.Kind, &job.Payload, &job.DedupeKey, &job.Status, &job.Attempts, &job.MaxAttempts,
+		&job.RunAt, &job.LastError, &job.CreatedAt, &job.FinishedAt); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
@@ -0,0 +1,73 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgreSQL_Jobs(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewTestDB(t)
+
+	enqueued, err := db.EnqueueJob(ctx, nil, &apiv0.Job{Kind: "test.job", Payload: []byte(`{"n":1}`), DedupeKey: "once", MaxAttempts: 2})
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+	enqueued, err = db.EnqueueJob(ctx, nil, &apiv0.Job{Kind: "test.job", DedupeKey: "once", MaxAttempts: 2})
+	require.NoError(t, err)
+	assert.False(t, enqueued, "a dedupe key is enqueued once")
+	_, err = db.EnqueueJob(ctx, nil, &apiv0.Job{Kind: "test.job", MaxAttempts: 2, RunAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = db.ClaimJob(ctx, nil, []string{"other.job"}, time.Now().Add(time.Minute))
+	require.ErrorIs(t, err, database.ErrNotFound, "only jobs of the given kinds are claimed")
+
+	job, err := db.ClaimJob(ctx, nil, []string{"test.job"}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "running", job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.JSONEq(t, `{"n":1}`, string(job.Payload))
+	_, err = db.ClaimJob(ctx, nil, []string{"test.job"}, time.Now().Add(time.Minute))
+	require.ErrorIs(t, err, database.ErrNotFound, "leased and future jobs aren't due")
+
+	// A failed attempt queues the job again, and a job whose worker died is claimed again once its lease runs out
+	retryAt := time.Now().Add(-time.Second)
+	require.NoError(t, db.FinishJob(ctx, nil, job.ID, "unavailable", &retryAt))
+	job, err = db.ClaimJob(ctx, nil, []string{"test.job"}, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "unavailable", job.LastError)
+	job, err = db.ClaimJob(ctx, nil, []string{"test.job"}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 3, job.Attempts)
+
+	require.NoError(t, db.FinishJob(ctx, nil, job.ID, "still unavailable", nil))
+	dead, err := db.ListJobs(ctx, nil, "dead", 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "still unavailable", dead[0].LastError)
+	assert.NotNil(t, dead[0].FinishedAt)
+
+	retried, err := db.RetryJob(ctx, nil, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "queued", retried.Status)
+	assert.Equal(t, 0, retried.Attempts)
+	_, err = db.RetryJob(ctx, nil, job.ID)
+	require.ErrorIs(t, err, database.ErrNotFound, "only dead jobs are retried")
+
+	job, err = db.ClaimJob(ctx, nil, []string{"test.job"}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, db.FinishJob(ctx, nil, job.ID, "", nil))
+	require.ErrorIs(t, db.FinishJob(ctx, nil, job.ID, "", nil), database.ErrNotFound, "finished jobs aren't running")
+
+	pruned, err := db.DeleteFinishedJobs(ctx, nil, "succeeded", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	succeeded, err := db.ListJobs(ctx, nil, "succeeded", 10)
+	require.NoError(t, err)
+	assert.Empty(t, succeeded)
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -17,11 +19,34 @@ import (
 //go:embed migrations/*.sql
 var migrationFiles embed.FS
 
+// downSuffix ends the names of the files that roll a migration back, such as
+// "043_add_jobs.down.sql" for "043_add_jobs.sql"
+const downSuffix = ".down.sql"
+
+// rollbackFloor is the first migration that ships a down migration. Every migration from it on
+// must ship one; those before it transform data in ways that can't be undone, so rolling back
+// past it takes restoring a backup instead.
+const rollbackFloor = 40
+
+// migrationLockID is the advisory lock held while migrating, so that instances starting together
+// don't apply the same migration twice
+const migrationLockID = 7_246_132_001
+
 // Migration represents a database migration
 type Migration struct {
 	Version int
 	Name    string
 	SQL     string
+	// DownSQL rolls the migration back, empty when it can't be
+	DownSQL string
+}
+
+// MigrationStatus is a migration and when it was applied, if it was
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+	// Embedded is false for migrations applied by a newer build than this one
+	Embedded bool
 }
 
 // Migrator handles database migrations
@@ -47,22 +72,31 @@ func (m *Migrator) ensureMigrationsTable(ctx context.Context) error {
 	return err
 }
 
-// getAppliedMigrations returns a map of already applied migration versions
-func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]bool, error) {
-	query := "SELECT version FROM schema_migrations ORDER BY version"
+// getAppliedMigrations returns the applied migrations by version, without creating the tracking
+// table when it doesn't exist yet
+func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]MigrationStatus, error) {
+	var exists bool
+	if err := m.conn.QueryRow(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	applied := make(map[int]MigrationStatus)
+	if !exists {
+		return applied, nil
+	}
+
+	query := "SELECT version, name, applied_at FROM schema_migrations ORDER BY version"
 	rows, err := m.conn.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
 	}
 	defer rows.Close()
 
-	applied := make(map[int]bool)
 	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
+		var status MigrationStatus
+		if err := rows.Scan(&status.Version, &status.Name, &status.AppliedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan migration version: %w", err)
 		}
-		applied[version] = true
+		applied[status.Version] = status
 	}
 
 	return applied, rows.Err()
@@ -76,6 +110,7 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 	}
 
 	var migrations []Migration
+	downs := make(map[int]string)
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
 			continue
@@ -101,6 +136,10 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 			return nil, fmt.Errorf("failed to read migration file %s: %w", name, err)
 		}
 
+		if strings.HasSuffix(name, downSuffix) {
+			downs[version] = string(content)
+			continue
+		}
 		migrations = append(migrations, Migration{
 			Version: version,
 			Name:    strings.TrimSuffix(name, ".sql"),
@@ -108,6 +147,14 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 		})
 	}
 
+	for i := range migrations {
+		migrations[i].DownSQL = downs[migrations[i].Version]
+		if migrations[i].Version >= rollbackFloor && migrations[i].DownSQL == "" {
+			return nil, fmt.Errorf("migration %d (%s) has no down migration, which migrations from %03d on must ship",
+				migrations[i].Version, migrations[i].Name, rollbackFloor)
+		}
+	}
+
 	// Sort migrations by version
 	sort.Slice(migrations, func(i, j int) bool {
 		return migrations[i].Version < migrations[j].Version
@@ -116,31 +163,100 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 	return migrations, nil
 }
 
-// Migrate runs all pending migrations
-func (m *Migrator) Migrate(ctx context.Context) error {
-	// Ensure the migrations table exists
-	if err := m.ensureMigrationsTable(ctx); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
-	}
-
-	// Get applied migrations
+// Status lists the embedded migrations, and those applied by newer builds, with when they were
+// applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
 	applied, err := m.getAppliedMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return nil, err
 	}
-
-	// Load all migration files
 	migrations, err := m.loadMigrations()
 	if err != nil {
-		return fmt.Errorf("failed to load migrations: %w", err)
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
 	}
 
-	// Find pending migrations
-	var pending []Migration
+	statuses := make([]MigrationStatus, 0, len(migrations))
 	for _, migration := range migrations {
-		if !applied[migration.Version] {
-			pending = append(pending, migration)
+		status := MigrationStatus{Migration: migration, Embedded: true}
+		if a, ok := applied[migration.Version]; ok {
+			status.AppliedAt = a.AppliedAt
+			delete(applied, migration.Version)
 		}
+		statuses = append(statuses, status)
+	}
+	for _, a := range applied {
+		statuses = append(statuses, a)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// Pending lists the migrations Migrate would apply up to a version, or all of them when it is 0
+func (m *Migrator) Pending(ctx context.Context, target int) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil && (target <= 0 || status.Version <= target) {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Rollbacks lists the migrations Rollback would roll back, the latest applied first. It fails when
+// any of them can't be rolled back, as those before the rollback floor can't.
+func (m *Migrator) Rollbacks(ctx context.Context, steps int) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rollbacks []Migration
+	for _, status := range slices.Backward(statuses) {
+		if len(rollbacks) >= steps {
+			break
+		}
+		if status.AppliedAt == nil {
+			continue
+		}
+		switch {
+		case !status.Embedded:
+			return nil, fmt.Errorf("migration %d (%s) was applied by a newer build; roll it back with that build", status.Version, status.Name)
+		case status.Version < rollbackFloor:
+			return nil, fmt.Errorf("migration %d (%s) is before the rollback floor %03d and has no down migration; restore a backup to go back further",
+				status.Version, status.Name, rollbackFloor)
+		}
+		rollbacks = append(rollbacks, status.Migration)
+	}
+	return rollbacks, nil
+}
+
+// Migrate runs all pending migrations
+func (m *Migrator) Migrate(ctx context.Context) error {
+	return m.MigrateTo(ctx, 0)
+}
+
+// MigrateTo runs the pending migrations up to a version, or all of them when it is 0
+func (m *Migrator) MigrateTo(ctx context.Context, target int) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Ensure the migrations table exists
+	if err := m.ensureMigrationsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	// Find pending migrations, once no other instance is applying them
+	pending, err := m.Pending(ctx, target)
+	if err != nil {
+		return fmt.Errorf("failed to get pending migrations: %w", err)
 	}
 
 	if len(pending) == 0 {
@@ -162,8 +278,58 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	return nil
 }
 
+// Rollback rolls back the latest applied migrations, a number of steps, with their down migrations
+func (m *Migrator) Rollback(ctx context.Context, steps int) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	rollbacks, err := m.Rollbacks(ctx, steps)
+	if err != nil {
+		return err
+	}
+	if len(rollbacks) == 0 {
+		log.Println("No migrations to roll back")
+		return nil
+	}
+
+	for _, migration := range rollbacks {
+		if err := m.rollbackMigration(ctx, migration); err != nil {
+			return fmt.Errorf("failed to roll back migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Rolled back migration %d: %s", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// lock waits for the migration lock, returning a function that releases it
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	if _, err := m.conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	return func() {
+		if _, err := m.conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			log.Printf("Failed to release migration lock: %v", err)
+		}
+	}, nil
+}
+
 // applyMigration applies a single migration in a transaction
 func (m *Migrator) applyMigration(ctx context.Context, migration Migration) error {
+	return m.inTransaction(ctx, migration.SQL,
+		"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+}
+
+// rollbackMigration rolls back a single migration in a transaction
+func (m *Migrator) rollbackMigration(ctx context.Context, migration Migration) error {
+	return m.inTransaction(ctx, migration.DownSQL,
+		"DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+}
+
+// inTransaction executes migration SQL and records it with a tracking statement in one transaction
+func (m *Migrator) inTransaction(ctx context.Context, sql, record string, args ...any) error {
 	tx, err := m.conn.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -175,18 +341,59 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 	}()
 
 	// Execute the migration SQL
-	_, err = tx.Exec(ctx, migration.SQL)
-	if err != nil {
+	if _, err := tx.Exec(ctx, sql); err != nil {
 		return fmt.Errorf("failed to execute migration SQL: %w", err)
 	}
 
-	// Record the migration as applied
-	_, err = tx.Exec(ctx,
-		"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
-		migration.Version, migration.Name)
-	if err != nil {
+	// Record the migration as applied or rolled back
+	if _, err := tx.Exec(ctx, record, args...); err != nil {
 		return fmt.Errorf("failed to record migration: %w", err)
 	}
 
 	return tx.Commit(ctx)
 }
+
+// Migrate applies the embedded migrations the database hasn't applied
+func (db *PostgreSQL) Migrate(ctx context.Context) error {
+	// Run migrations using a single connection from the pool, which holds the migration lock
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for migrations: %w", err)
+	}
+	defer conn.Release()
+
+	if err := NewMigrator(conn.Conn()).Migrate(ctx); err != nil {
+		return fmt.Errorf("failed to run database migrations: %w", err)
+	}
+	return nil
+}
+
+// PendingMigrations lists the names of the embedded migrations that aren't applied to the
+// database, such as when a newer instance is migrating it
+func (db *PostgreSQL) PendingMigrations(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	migrations, err := (&Migrator{}).loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	applied, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan migration version: %w", err)
+	}
+
+	var pending []string
+	for _, migration := range migrations {
+		if !slices.Contains(applied, migration.Version) {
+			pending = append(pending, migration.Name)
+		}
+	}
+	return pending, nil
+}
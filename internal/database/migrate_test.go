@@ -0,0 +1,63 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, database.NewTestDatabaseURI(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close(context.Background()) })
+	migrator := database.NewMigrator(conn)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	latest := statuses[len(statuses)-1]
+	assert.NotNil(t, latest.AppliedAt, "the test database is fully migrated")
+	assert.NotEmpty(t, latest.DownSQL)
+
+	pending, err := migrator.Pending(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	rollbacks, err := migrator.Rollbacks(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, rollbacks, 2)
+	assert.Equal(t, latest.Version, rollbacks[0].Version, "the latest migration is rolled back first")
+
+	require.NoError(t, migrator.Rollback(ctx, 2))
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, statuses[len(statuses)-1].AppliedAt)
+
+	pending, err = migrator.Pending(ctx, rollbacks[1].Version)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "pending migrations stop at the target version")
+	assert.Equal(t, rollbacks[1].Version, pending[0].Version)
+
+	require.NoError(t, migrator.MigrateTo(ctx, rollbacks[1].Version))
+	require.NoError(t, migrator.Migrate(ctx))
+	pending, err = migrator.Pending(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Every migration from the rollback floor on ships a down migration, and those before it
+	// can't be rolled back
+	for _, status := range statuses {
+		if status.Version >= 40 {
+			assert.NotEmpty(t, status.DownSQL, "migration %d", status.Version)
+		}
+	}
+	_, err = migrator.Rollbacks(ctx, len(statuses))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "migration 39 ")
+	assert.Contains(t, err.Error(), "before the rollback floor 040")
+}
@@ -0,0 +1,58 @@
+-- Namespace ownership transfers and publish delegation
+-- Until a namespace is transferred, ownership comes from the authentication method (e.g. the
+-- GitHub user for io.github.<user>). A transfer records an explicit owner, which then takes
+-- precedence. Transfers and delegations are two-step requests: the owner proposes, the
+-- recipient accepts. Every step is recorded in the audit log.
+
+BEGIN;
+
+CREATE TABLE namespace_owners (
+    namespace VARCHAR(255) PRIMARY KEY,
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE namespace_delegates (
+    namespace VARCHAR(255) NOT NULL,
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (namespace, auth_method, subject)
+);
+
+CREATE INDEX idx_namespace_delegates_principal ON namespace_delegates (auth_method, subject);
+
+CREATE TABLE namespace_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('transfer', 'delegation')),
+    namespace VARCHAR(255) NOT NULL,
+    from_auth_method VARCHAR(50) NOT NULL,
+    from_subject VARCHAR(255) NOT NULL,
+    to_auth_method VARCHAR(50) NOT NULL,
+    to_subject VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_namespace_requests_namespace ON namespace_requests (namespace);
+CREATE INDEX idx_namespace_requests_to ON namespace_requests (to_auth_method, to_subject) WHERE status = 'pending';
+CREATE INDEX idx_namespace_requests_from ON namespace_requests (from_auth_method, from_subject) WHERE status = 'pending';
+
+CREATE TABLE namespace_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    namespace VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor_auth_method VARCHAR(50) NOT NULL,
+    actor_subject VARCHAR(255) NOT NULL,
+    target_auth_method VARCHAR(50),
+    target_subject VARCHAR(255),
+    request_id UUID REFERENCES namespace_requests (id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_namespace_audit_log_namespace ON namespace_audit_log (namespace, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- Organization accounts
+-- An organization owns namespaces on behalf of its members. Members have a role: owners
+-- manage the organization and may delete servers, publishers may publish and edit servers,
+-- viewers can only see the organization.
+
+BEGIN;
+
+CREATE TABLE organizations (
+    name VARCHAR(63) PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE organization_members (
+    organization VARCHAR(63) NOT NULL REFERENCES organizations (name) ON DELETE CASCADE,
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'publisher', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization, auth_method, subject)
+);
+
+CREATE INDEX idx_organization_members_principal ON organization_members (auth_method, subject);
+
+CREATE TABLE organization_namespaces (
+    namespace VARCHAR(255) PRIMARY KEY,
+    organization VARCHAR(63) NOT NULL REFERENCES organizations (name) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_organization_namespaces_organization ON organization_namespaces (organization);
+
+-- Audit entries for namespaces joining or leaving an organization name the organization
+ALTER TABLE namespace_audit_log ADD COLUMN organization VARCHAR(63);
+
+COMMIT;
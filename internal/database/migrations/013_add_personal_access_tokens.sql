@@ -0,0 +1,23 @@
+-- Personal access tokens
+-- Long-lived credentials a principal creates for automation. Only a SHA-256 hash of each
+-- token is stored; the token is exchanged for a short-lived registry JWT on every use.
+
+BEGIN;
+
+CREATE TABLE personal_access_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    token_prefix VARCHAR(20) NOT NULL,
+    permissions JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_personal_access_tokens_principal ON personal_access_tokens (auth_method, subject);
+
+COMMIT;
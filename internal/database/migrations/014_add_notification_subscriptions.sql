@@ -0,0 +1,21 @@
+-- Notification subscriptions
+-- Each owner of a namespace chooses which events about it they want to hear of, and where:
+-- an email address or a webhook URL. Webhook deliveries are signed with the optional secret.
+
+BEGIN;
+
+CREATE TABLE notification_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace VARCHAR(255) NOT NULL,
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'webhook')),
+    target VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255),
+    events TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_subscriptions_namespace ON notification_subscriptions (namespace);
+
+COMMIT;
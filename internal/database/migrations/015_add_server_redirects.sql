@@ -0,0 +1,15 @@
+-- Server redirects
+-- When a server moves to a new name, reads of the old name are redirected to the new one.
+
+BEGIN;
+
+CREATE TABLE server_redirects (
+    from_name VARCHAR(255) PRIMARY KEY,
+    to_name VARCHAR(255) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_server_redirects_to_name ON server_redirects (to_name);
+
+COMMIT;
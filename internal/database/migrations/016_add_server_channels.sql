@@ -0,0 +1,19 @@
+-- Release channels
+-- Publishers tag a version of a server into a channel such as stable or beta, and clients can
+-- resolve the channel instead of always taking the latest version.
+
+BEGIN;
+
+CREATE TABLE server_channels (
+    server_name VARCHAR(255) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, channel),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_channels_version ON server_channels (server_name, version);
+
+COMMIT;
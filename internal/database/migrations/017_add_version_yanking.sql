@@ -0,0 +1,9 @@
+-- Version yanking
+-- A yanked version is left out of listings and latest-resolution but can still be fetched by
+-- exact version, so anything pinned to it keeps working.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN yanked_at TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Declared relationships between servers
+-- Relationships live in the server document; this index lets the registry find the servers
+-- whose latest version declares a relationship to a given server without scanning them all.
+
+BEGIN;
+
+CREATE INDEX idx_servers_relationships ON servers USING GIN ((value->'relationships') jsonb_path_ops) WHERE is_latest = true;
+
+COMMIT;
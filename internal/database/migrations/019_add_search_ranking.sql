@@ -0,0 +1,13 @@
+-- Search ranking overrides
+-- Deployments configure default ranking through the environment; admins can override it at
+-- runtime. The table holds at most one row.
+
+BEGIN;
+
+CREATE TABLE search_ranking (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    settings JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,23 @@
+-- Server reviews
+-- Signed-in users rate a server from 1 to 5 and can leave a short review, one per server that
+-- they can update. Admins hide abusive reviews rather than deleting them, so that they can't
+-- simply be posted again.
+
+BEGIN;
+
+CREATE TABLE server_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_name VARCHAR(255) NOT NULL,
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    review VARCHAR(500) NOT NULL DEFAULT '',
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (server_name, auth_method, subject)
+);
+
+CREATE INDEX idx_server_reviews_reviewer ON server_reviews (auth_method, subject, created_at);
+
+COMMIT;
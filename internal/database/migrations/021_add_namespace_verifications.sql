@@ -0,0 +1,16 @@
+-- Verified namespaces
+-- A namespace is verified once its owner proves control of the domain or GitHub organization
+-- it is named after. Servers in verified namespaces are flagged so that users can tell them
+-- apart from lookalikes.
+
+BEGIN;
+
+CREATE TABLE namespace_verifications (
+    namespace VARCHAR(255) PRIMARY KEY,
+    method VARCHAR(20) NOT NULL CHECK (method IN ('domain', 'github-org')),
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
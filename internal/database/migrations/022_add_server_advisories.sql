@@ -0,0 +1,25 @@
+-- Security advisories
+-- Publishers and admins attach advisories to the versions of a server they affect, so that
+-- listings can flag those versions and clients can follow a feed of advisories.
+
+BEGIN;
+
+CREATE TABLE server_advisories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_name VARCHAR(255) NOT NULL,
+    summary VARCHAR(200) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    severity VARCHAR(20) NOT NULL CHECK (severity IN ('low', 'moderate', 'high', 'critical')),
+    affected_versions TEXT[] NOT NULL,
+    remediation VARCHAR(1000) NOT NULL DEFAULT '',
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_server_advisories_server_name ON server_advisories (server_name);
+CREATE INDEX idx_server_advisories_updated_at ON server_advisories (updated_at, id);
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Vulnerability scans
+-- An image scanning backend reports the vulnerabilities it found in each OCI image of a server
+-- version, replacing its earlier report when it rescans the image.
+
+BEGIN;
+
+CREATE TABLE server_version_scans (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    package_identifier VARCHAR(255) NOT NULL,
+    critical INTEGER NOT NULL DEFAULT 0 CHECK (critical >= 0),
+    high INTEGER NOT NULL DEFAULT 0 CHECK (high >= 0),
+    moderate INTEGER NOT NULL DEFAULT 0 CHECK (moderate >= 0),
+    low INTEGER NOT NULL DEFAULT 0 CHECK (low >= 0),
+    scanned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (server_name, version, package_identifier),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMIT;
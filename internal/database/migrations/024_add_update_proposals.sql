@@ -0,0 +1,32 @@
+-- Update proposals
+-- The upstream watcher proposes a new version of a server when its GitHub repository publishes a
+-- newer release. Publishers publish or dismiss proposals, or opt a server into publishing them
+-- automatically.
+
+BEGIN;
+
+CREATE TABLE server_update_proposals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    current_version VARCHAR(255) NOT NULL,
+    release_tag VARCHAR(255) NOT NULL,
+    release_url TEXT NOT NULL DEFAULT '',
+    value JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'published', 'dismissed')),
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    -- A release is only ever proposed once, even after it was dismissed
+    UNIQUE (server_name, version)
+);
+
+CREATE INDEX idx_server_update_proposals_status ON server_update_proposals (status, created_at);
+
+CREATE TABLE server_update_policies (
+    server_name VARCHAR(255) PRIMARY KEY,
+    auto_publish BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- OCI image metadata
+-- When a version is published, the registry records what it finds out about each of its OCI
+-- images while validating them, such as the platforms they are built for.
+
+BEGIN;
+
+CREATE TABLE server_version_images (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    package_identifier VARCHAR(255) NOT NULL,
+    -- os/architecture, with /variant when the image declares one, e.g. linux/arm/v7
+    platforms TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (server_name, version, package_identifier),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_version_images_platforms ON server_version_images USING GIN (platforms);
+
+COMMIT;
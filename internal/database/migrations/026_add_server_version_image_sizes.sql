@@ -0,0 +1,11 @@
+-- OCI image sizes and resources
+-- Alongside platforms, the registry records the compressed size of each OCI image and the
+-- memory and CPUs its labels declare it expects.
+
+BEGIN;
+
+ALTER TABLE server_version_images ADD COLUMN compressed_size BIGINT CHECK (compressed_size >= 0);
+ALTER TABLE server_version_images ADD COLUMN memory TEXT;
+ALTER TABLE server_version_images ADD COLUMN cpus TEXT;
+
+COMMIT;
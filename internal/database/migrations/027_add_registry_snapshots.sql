@@ -0,0 +1,30 @@
+-- Registry snapshots
+-- A snapshot copies every server version at a point in time, so that clients paging through
+-- the list endpoint (federation sync, backups) read one consistent state of the registry.
+-- Snapshots expire after a while and are deleted when the next one is taken.
+
+BEGIN;
+
+CREATE TABLE registry_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_registry_snapshots_created_at ON registry_snapshots (created_at);
+
+-- The columns of servers that list reads use, so queries can read a snapshot in its place
+CREATE TABLE registry_snapshot_servers (
+    snapshot_id UUID NOT NULL REFERENCES registry_snapshots (id) ON DELETE CASCADE,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    status VARCHAR(50),
+    published_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    is_latest BOOLEAN,
+    yanked_at TIMESTAMP WITH TIME ZONE,
+    value JSONB NOT NULL,
+    PRIMARY KEY (snapshot_id, server_name, version)
+);
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Capability inventories
+-- Publishers and admins ask for the tools, resources and prompts of a server version to be
+-- extracted by running it in a sandbox. A sandbox worker claims pending extractions and records
+-- what the server listed, or why running it failed, replacing the earlier inventory.
+
+BEGIN;
+
+CREATE TABLE server_version_capabilities (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    -- When a worker claimed the extraction, so that extractions abandoned by a crashed worker
+    -- can be claimed again
+    started_at TIMESTAMP WITH TIME ZONE,
+    extracted_at TIMESTAMP WITH TIME ZONE,
+    error TEXT,
+    tools JSONB,
+    resources JSONB,
+    prompts JSONB,
+    PRIMARY KEY (server_name, version),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_version_capabilities_pending ON server_version_capabilities (requested_at) WHERE status IN ('pending', 'running');
+
+COMMIT;
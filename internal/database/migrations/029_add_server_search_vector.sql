@@ -0,0 +1,19 @@
+-- Full-text search
+-- Each server version gets a weighted search vector over its name and title (A), its
+-- description and publisher-provided tags (B), and its publisher-provided tools (C), which
+-- GET /v0/servers/search matches queries against.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    -- Split names on dots, slashes, dashes and underscores so their parts match as words
+    setweight(to_tsvector('english', regexp_replace(server_name, '[^a-zA-Z0-9]+', ' ', 'g')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(value->>'title', '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(value->>'description', '')), 'B') ||
+    setweight(jsonb_to_tsvector('english', COALESCE(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'tags', '[]'), '["string"]'), 'B') ||
+    setweight(jsonb_to_tsvector('english', COALESCE(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'tools', '[]'), '["string"]'), 'C')
+) STORED;
+
+CREATE INDEX idx_servers_search_vector ON servers USING GIN (search_vector);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Registry type and transport indexes
+-- Gateways list only the servers they can run by filtering on package registry types and
+-- package and remote transports, which are matched with JSONB containment on these indexes.
+
+BEGIN;
+
+CREATE INDEX idx_servers_packages ON servers USING GIN ((value->'packages') jsonb_path_ops);
+CREATE INDEX idx_servers_remotes ON servers USING GIN ((value->'remotes') jsonb_path_ops);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Publish idempotency keys
+-- Publishers can send an Idempotency-Key with a publish. The key is stored with the version it
+-- published, so that a retry after a timeout or dropped connection returns that version rather
+-- than failing as a duplicate.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN idempotency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_servers_idempotency_key ON servers (server_name, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,21 @@
+-- Server status details
+-- Publishers deprecating or deleting a version can say why and point to the server replacing it.
+-- The details belong to the status change, so they are removed when the status changes again.
+
+BEGIN;
+
+CREATE TABLE server_status_details (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    reason TEXT,
+    -- Name of the server clients should move to
+    replaced_by VARCHAR(255),
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_status_details_replaced_by ON server_status_details (replaced_by) WHERE replaced_by IS NOT NULL;
+
+COMMIT;
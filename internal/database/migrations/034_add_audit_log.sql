@@ -0,0 +1,44 @@
+-- Audit log
+-- Every successful publish, edit, status change and admin action is recorded with who made it,
+-- from where, and the digests of the server.json it changed before and after. Entries keep the
+-- server name they were recorded with, so they outlive renames and deletions. The log is append
+-- only: a trigger rejects updating or deleting entries.
+
+BEGIN;
+
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(100) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(2048) NOT NULL,
+    status INTEGER NOT NULL,
+    actor_auth_method VARCHAR(50),
+    actor_subject VARCHAR(255),
+    ip VARCHAR(45),
+    server_name VARCHAR(255),
+    version VARCHAR(255),
+    -- SHA-256 of the version's server.json before and after the action, when it has one
+    before_digest VARCHAR(64),
+    after_digest VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_actor ON audit_log (actor_subject, id DESC);
+CREATE INDEX idx_audit_log_server_name ON audit_log (server_name, id DESC);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+
+CREATE FUNCTION reject_audit_log_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_log_change();
+
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
+
+COMMIT;
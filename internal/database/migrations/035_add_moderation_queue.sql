@@ -0,0 +1,27 @@
+-- Moderation queue
+-- Admins quarantine servers that break the registry's rules, with a reason. A quarantined server
+-- is left out of listings until an admin reinstates it or permanently deletes it. Cases keep the
+-- server name they were opened with, so resolved cases outlive deletions.
+
+BEGIN;
+
+CREATE TABLE moderation_queue (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_name VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    moderator_auth_method VARCHAR(50) NOT NULL,
+    moderator_subject VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'quarantined' CHECK (status IN ('quarantined', 'reinstated', 'deleted')),
+    -- Why the server was reinstated or deleted, and by whom
+    resolution TEXT NOT NULL DEFAULT '',
+    resolved_by_auth_method VARCHAR(50),
+    resolved_by_subject VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A server is in quarantine at most once at a time
+CREATE UNIQUE INDEX idx_moderation_queue_quarantined ON moderation_queue (server_name) WHERE status = 'quarantined';
+CREATE INDEX idx_moderation_queue_status ON moderation_queue (status, created_at);
+
+COMMIT;
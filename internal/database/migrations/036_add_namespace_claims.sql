@@ -0,0 +1,31 @@
+-- Namespace claims
+-- A principal claims a namespace by proving control of what it is named after: a DNS TXT record
+-- or HTTPS well-known file with a challenge token for a domain namespace, or their GitHub
+-- identity for io.github.<login>. The verifier checks pending claims until they pass, and checks
+-- verified claims again so that a claim expires once its proof is taken down.
+
+BEGIN;
+
+CREATE TABLE namespace_claims (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    namespace VARCHAR(255) NOT NULL,
+    method VARCHAR(20) NOT NULL CHECK (method IN ('dns', 'http', 'github')),
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    -- Value the claimant publishes in the TXT record or well-known file
+    token VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'verified', 'expired', 'failed')),
+    -- Checks that failed in a row, and why the latest did
+    failed_checks INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    verified_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    -- When the verifier checks the claim next, or NULL when it no longer does
+    next_check_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (namespace, auth_method, subject)
+);
+
+CREATE INDEX idx_namespace_claims_next_check_at ON namespace_claims (next_check_at) WHERE next_check_at IS NOT NULL;
+
+COMMIT;
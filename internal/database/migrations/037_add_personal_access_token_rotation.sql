@@ -0,0 +1,9 @@
+-- Personal access token rotation
+-- Rotating a token replaces its secret in place, keeping its ID, name and permissions, so that
+-- the old secret stops working as soon as the new one is issued.
+
+BEGIN;
+
+ALTER TABLE personal_access_tokens ADD COLUMN rotated_at TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- Server publishers
+-- The identity that first published a server owns it: publishing new versions and editing it
+-- takes that identity, unless the caller is an admin or a namespace owner. Servers published
+-- before this migration get their publisher the next time a version is published, and until
+-- then are edited with namespace permissions alone.
+
+BEGIN;
+
+CREATE TABLE server_publishers (
+    server_name VARCHAR(255) PRIMARY KEY,
+    auth_method VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
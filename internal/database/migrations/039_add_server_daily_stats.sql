@@ -0,0 +1,27 @@
+-- Daily server statistics
+-- Counts the requests for each server's details per day, and snapshots the pull count its
+-- publisher reports in its server.json on the days a version is published or edited, so that
+-- trending servers can be ranked by their recent requests and pulls.
+
+BEGIN;
+
+CREATE TABLE server_daily_stats (
+    server_name VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    -- Publisher-reported pull count of the latest version, first and last as reported that day
+    first_pulls BIGINT,
+    pulls BIGINT,
+    PRIMARY KEY (server_name, day)
+);
+
+-- Pulls reported before statistics were kept are the baseline recent pulls are counted from
+INSERT INTO server_daily_stats (server_name, day, first_pulls, pulls)
+SELECT server_name, (NOW() AT TIME ZONE 'UTC')::date, pulls, pulls
+FROM (
+    SELECT server_name, GREATEST((value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->>'pulls')::float8, 0)::BIGINT AS pulls
+    FROM servers
+    WHERE is_latest AND jsonb_typeof(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'pulls') = 'number'
+) AS reported;
+
+COMMIT;
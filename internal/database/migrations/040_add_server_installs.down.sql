@@ -0,0 +1,7 @@
+-- Rolls back 040_add_server_installs, dropping the install counts
+
+BEGIN;
+
+ALTER TABLE server_daily_stats DROP COLUMN installs;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Server installs
+-- Clients report installing a server to the registry, which counts the installs per day next to
+-- the requests for the server's details, so that both can be shown in the server's _meta.
+
+BEGIN;
+
+ALTER TABLE server_daily_stats ADD COLUMN installs BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
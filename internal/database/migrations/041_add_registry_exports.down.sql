@@ -0,0 +1,7 @@
+-- Rolls back 041_add_registry_exports. Exports are rendered again on their next interval.
+
+BEGIN;
+
+DROP TABLE registry_exports;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Registry exports
+-- Documents rendered from the whole registry on an interval, such as the JSON snapshot of every
+-- server version and the sitemap, stored with their gzip encoding so that every instance can
+-- serve them whichever instance rendered them.
+
+BEGIN;
+
+CREATE TABLE registry_exports (
+    name VARCHAR(255) PRIMARY KEY,
+    content_type VARCHAR(255) NOT NULL,
+    etag VARCHAR(255) NOT NULL,
+    body BYTEA NOT NULL,
+    gzip_body BYTEA NOT NULL,
+    generated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+COMMIT;
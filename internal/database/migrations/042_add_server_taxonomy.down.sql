@@ -0,0 +1,8 @@
+-- Rolls back 042_add_server_taxonomy. The categories and tags stay in each version's server.json.
+
+BEGIN;
+
+DROP TABLE server_version_tags;
+DROP TABLE server_version_categories;
+
+COMMIT;
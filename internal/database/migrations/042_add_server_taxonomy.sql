@@ -0,0 +1,45 @@
+-- Server categories and tags
+-- The categories and tags publishers list under io.modelcontextprotocol.registry/publisher-provided,
+-- lower-cased and trimmed, with a row for each server version, so that they can be enumerated
+-- with their server counts and servers filtered by them without scanning the server.json of
+-- every version.
+
+BEGIN;
+
+CREATE TABLE server_version_categories (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    PRIMARY KEY (server_name, version, category),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_version_categories_category ON server_version_categories (category);
+
+CREATE TABLE server_version_tags (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    tag VARCHAR(100) NOT NULL,
+    PRIMARY KEY (server_name, version, tag),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_version_tags_tag ON server_version_tags (tag);
+
+-- Versions published before the tables were added
+INSERT INTO server_version_categories (server_name, version, category)
+SELECT DISTINCT server_name, version, lower(btrim(term #>> '{}'))
+FROM servers, jsonb_array_elements(CASE
+    WHEN jsonb_typeof(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'categories') = 'array'
+    THEN value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'categories' ELSE '[]'::jsonb END) AS term
+WHERE jsonb_typeof(term) = 'string' AND length(btrim(term #>> '{}')) BETWEEN 1 AND 100;
+
+INSERT INTO server_version_tags (server_name, version, tag)
+SELECT DISTINCT server_name, version, lower(btrim(term #>> '{}'))
+FROM servers, jsonb_array_elements(CASE
+    WHEN jsonb_typeof(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'tags') = 'array'
+    THEN value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'tags' ELSE '[]'::jsonb END) AS term
+WHERE jsonb_typeof(term) = 'string' AND length(btrim(term #>> '{}')) BETWEEN 1 AND 100;
+
+COMMIT;
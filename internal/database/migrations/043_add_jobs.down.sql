@@ -0,0 +1,7 @@
+-- Rolls back 043_add_jobs, dropping queued and dead jobs with the queue
+
+BEGIN;
+
+DROP TABLE jobs;
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Job queue
+-- Asynchronous registry tasks, claimed by workers of any instance. A job that fails is queued
+-- again after a delay until it runs out of attempts, when it is kept as dead for operators to
+-- look into. A dedupe key, when set, lets a job be enqueued once however many instances try.
+
+BEGIN;
+
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    dedupe_key VARCHAR(255) UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    -- When a queued job is due, or when the lease of a running one runs out
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_jobs_due ON jobs (run_at) WHERE status IN ('queued', 'running');
+CREATE INDEX idx_jobs_status ON jobs (status, created_at DESC);
+CREATE INDEX idx_jobs_finished ON jobs (finished_at) WHERE finished_at IS NOT NULL;
+
+COMMIT;
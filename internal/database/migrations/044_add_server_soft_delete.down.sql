@@ -0,0 +1,8 @@
+-- Rolls back 044_add_server_soft_delete. Servers deleted but not purged yet are listed again.
+
+BEGIN;
+
+DROP INDEX idx_servers_deleted_at;
+ALTER TABLE servers DROP COLUMN deleted_at;
+
+COMMIT;
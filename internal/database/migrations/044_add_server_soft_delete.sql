@@ -0,0 +1,12 @@
+-- Soft-deleted servers
+-- Servers admins delete are hidden from every read rather than deleted, so that a deletion made by
+-- mistake, or to cover a takeover, can be restored. Their name stays reserved until a scheduled job
+-- purges them once the retention window has passed since deleted_at.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_servers_deleted_at ON servers (deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Rolls back 045_add_server_revisions
+
+BEGIN;
+
+ALTER TABLE registry_snapshot_servers DROP COLUMN revision;
+ALTER TABLE servers DROP COLUMN revision;
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- Server revisions
+-- A counter each edit of a server version increases, so that an edit can be made conditional on the
+-- version not having changed since the editor read it rather than silently overwriting another
+-- edit. Snapshots keep the revision the version had when they were taken.
+
+BEGIN;
+
+ALTER TABLE servers ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE registry_snapshot_servers ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,8 @@
+-- Rolls back 046_add_server_signatures, dropping signing keys and signatures
+
+BEGIN;
+
+DROP TABLE server_signatures;
+DROP TABLE server_signing_keys;
+
+COMMIT;
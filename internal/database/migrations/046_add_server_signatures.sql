@@ -0,0 +1,32 @@
+-- Server signatures
+-- Publishers sign the canonical server.json of the versions they publish with minisign or
+-- cosign. Once a server has a signing key, its publisher's publishes must carry a signature that
+-- verifies with it; signatures published before are kept unverified.
+
+BEGIN;
+
+CREATE TABLE server_signing_keys (
+    server_name VARCHAR(255) PRIMARY KEY,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('minisign', 'cosign')),
+    public_key TEXT NOT NULL,
+    key_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE server_signatures (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('minisign', 'cosign')),
+    signature TEXT NOT NULL,
+    -- SHA-256 of the canonical server.json signed, so that edits can drop signatures they break
+    digest VARCHAR(64) NOT NULL,
+    key_id VARCHAR(255),
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version),
+    -- Follows the version when its server is renamed
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMIT;
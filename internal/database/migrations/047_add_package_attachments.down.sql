@@ -0,0 +1,7 @@
+-- Rolls back 047_add_package_attachments, dropping the attached documents
+
+BEGIN;
+
+DROP TABLE package_attachments;
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- Package attachments
+-- Supply-chain documents publishers attach to the OCI packages of a server version: an SBOM in
+-- SPDX or CycloneDX, and SLSA provenance. Each is stored as published, with its digest, and
+-- follows its version when the server is renamed or deleted.
+
+BEGIN;
+
+CREATE TABLE package_attachments (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    package_index INTEGER NOT NULL CHECK (package_index >= 0),
+    -- Identifier of the package when the document was attached, so that edits replacing the
+    -- package can drop it
+    package_identifier TEXT NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('sbom', 'provenance')),
+    format VARCHAR(20) NOT NULL,
+    media_type VARCHAR(255) NOT NULL,
+    digest VARCHAR(80) NOT NULL,
+    content BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version, package_index, kind),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMIT;
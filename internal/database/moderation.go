@@ -0,0 +1,251 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// quarantinedExpression tells whether a server is quarantined
+const quarantinedExpression = `EXISTS (SELECT 1 FROM moderation_queue mq WHERE mq.server_name = servers.server_name AND mq.status = 'quarantined')`
+
+// quarantineExpression reads why and when a server was quarantined, or NULL when it isn't
+const quarantineExpression = `(SELECT jsonb_build_object('reason', mq.reason, 'quarantinedAt', mq.created_at)
+	FROM moderation_queue mq
+	WHERE mq.server_name = servers.server_name AND mq.status = 'quarantined')`
+
+const moderationCaseColumns = `
+	id::text, server_name, status, reason, moderator_auth_method, moderator_subject, created_at,
+	resolution, resolved_by_auth_method, resolved_by_subject, resolved_at
+`
+
+// CreateModerationCase quarantines a server. A server is quarantined at most once at a time, so
+// quarantining it again returns ErrAlreadyExists.
+func (db *PostgreSQL) CreateModerationCase(ctx context.Context, tx pgx.Tx, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO moderation_queue (server_name, reason, moderator_auth_method, moderator_subject)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (server_name) WHERE status = 'quarantined' DO NOTHING
+		RETURNING ` + moderationCaseColumns
+
+	created, err := scanModerationCase(db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, reason, moderator.AuthMethod, moderator.Subject))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to insert moderation case: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetOpenModerationCase retrieves the case a server is quarantined by
+func (db *PostgreSQL) GetOpenModerationCase(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ModerationCase, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + moderationCaseColumns + ` FROM moderation_queue WHERE server_name = $1 AND status = 'quarantined'`
+
+	moderationCase, err := scanModerationCase(db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get moderation case: %w", err)
+	}
+
+	return moderationCase, nil
+}
+
+// ResolveModerationCase closes an open case with the server reinstated or deleted
+func (db *PostgreSQL) ResolveModerationCase(ctx context.Context, tx pgx.Tx, id, status, resolution string, resolvedBy apiv0.Principal) (*apiv0.ModerationCase, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE moderation_queue
+		SET status = $2, resolution = $3, resolved_by_auth_method = $4, resolved_by_subject = $5, resolved_at = NOW()
+		WHERE id = $1 AND status = 'quarantined'
+		RETURNING ` + moderationCaseColumns
+
+	resolved, err := scanModerationCase(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, status, resolution, resolvedBy.AuthMethod, resolvedBy.Subject))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve moderation case: %w", err)
+	}
+
+	return resolved, nil
+}
+
+// ListModerationCases lists the moderation cases with a status, or every case when status is
+// empty, most recent first
+func (db *PostgreSQL) ListModerationCases(ctx context.Context, tx pgx.Tx, status string, limit int) ([]apiv0.ModerationCase, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + moderationCaseColumns + `
+		FROM moderation_queue
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query moderation cases: %w", err)
+	}
+	defer rows.Close()
+
+	cases := []apiv0.ModerationCase{}
+	for rows.Next() {
+		moderationCase, err := scanModerationCase(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan moderation case: %w", err)
+		}
+		cases = append(cases, *moderationCase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating moderation cases: %w", err)
+	}
+
+	return cases, nil
+}
+
+// PurgeServer permanently deletes every version of a server along with its reviews, advisories,
+// update proposals, signing key, publisher and the redirects to and from it, whether or not it was soft-deleted
+func (db *PostgreSQL) PurgeServer(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+	for _, query := range []string{
+		`DELETE FROM server_reviews WHERE server_name = $1`,
+		`DELETE FROM server_advisories WHERE server_name = $1`,
+		`DELETE FROM server_update_proposals WHERE server_name = $1`,
+		`DELETE FROM server_update_policies WHERE server_name = $1`,
+		`DELETE FROM server_signing_keys WHERE server_name = $1`,
+		`DELETE FROM server_redirects WHERE from_name = $1 OR to_name = $1`,
+		`DELETE FROM server_publishers WHERE server_name = $1`,
+	} {
+		if _, err := executor.Exec(ctx, query, serverName); err != nil {
+			return fmt.Errorf("failed to delete server data: %w", err)
+		}
+	}
+
+	// Channels, scans, images, capabilities, status details, signatures and attachments go with the versions
+	result, err := executor.Exec(ctx, `DELETE FROM servers WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SoftDeleteServer hides every version of a server from reads until it is restored or purged
+func (db *PostgreSQL) SoftDeleteServer(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx,
+		`UPDATE servers SET deleted_at = NOW() WHERE server_name = $1 AND deleted_at IS NULL`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RestoreDeletedServer brings back the versions of a soft-deleted server
+func (db *PostgreSQL) RestoreDeletedServer(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx,
+		`UPDATE servers SET deleted_at = NULL WHERE server_name = $1 AND deleted_at IS NOT NULL`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to restore server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetServerDeletedAt retrieves when a server was soft-deleted, or ErrNotFound when it wasn't
+func (db *PostgreSQL) GetServerDeletedAt(ctx context.Context, tx pgx.Tx, serverName string) (time.Time, error) {
+	if ctx.Err() != nil {
+		return time.Time{}, ctx.Err()
+	}
+
+	var deletedAt *time.Time
+	err := db.getExecutor(ctx, tx).QueryRow(ctx,
+		`SELECT MAX(deleted_at) FROM servers WHERE server_name = $1`, serverName).Scan(&deletedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get server deletion: %w", err)
+	}
+	if deletedAt == nil {
+		return time.Time{}, ErrNotFound
+	}
+	return *deletedAt, nil
+}
+
+// ListDeletedServers lists the names of the servers soft-deleted before a time, earliest first
+func (db *PostgreSQL) ListDeletedServers(ctx context.Context, tx pgx.Tx, deletedBefore time.Time) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, `
+		SELECT server_name FROM servers
+		WHERE deleted_at < $1
+		GROUP BY server_name
+		ORDER BY MIN(deleted_at), server_name`, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted servers: %w", err)
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan deleted server: %w", err)
+	}
+	return names, nil
+}
+
+func scanModerationCase(row pgx.Row) (*apiv0.ModerationCase, error) {
+	var moderationCase apiv0.ModerationCase
+	var resolvedByMethod, resolvedBySubject *string
+	err := row.Scan(&moderationCase.ID, &moderationCase.ServerName, &moderationCase.Status, &moderationCase.Reason,
+		&moderationCase.Moderator.AuthMethod, &moderationCase.Moderator.Subject, &moderationCase.CreatedAt,
+		&moderationCase.Resolution, &resolvedByMethod, &resolvedBySubject, &moderationCase.ResolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedByMethod != nil && resolvedBySubject != nil {
+		moderationCase.ResolvedBy = &apiv0.Principal{AuthMethod: *resolvedByMethod, Subject: *resolvedBySubject}
+	}
+	return &moderationCase, nil
+}
@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const namespaceClaimColumns = `
+	id::text, namespace, method, auth_method, subject, token, status, failed_checks, last_error,
+	created_at, verified_at, expires_at, next_check_at
+`
+
+// NamespaceClaimCheck is a namespace claim with how many of its checks failed in a row
+type NamespaceClaimCheck struct {
+	apiv0.NamespaceClaim
+	FailedChecks int
+}
+
+// SetNamespaceClaim stores a principal's claim on a namespace, replacing the state of their
+// previous claim on it. The challenge token of a new claim is kept when the claim is replaced.
+func (db *PostgreSQL) SetNamespaceClaim(ctx context.Context, tx pgx.Tx, claim *NamespaceClaimCheck) (*NamespaceClaimCheck, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	token := ""
+	if claim.Challenge != nil {
+		token = claim.Challenge.Token
+	}
+
+	query := `
+		INSERT INTO namespace_claims (namespace, method, auth_method, subject, token, status, failed_checks, last_error, verified_at, expires_at, next_check_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (namespace, auth_method, subject) DO UPDATE
+		SET method = EXCLUDED.method, status = EXCLUDED.status, failed_checks = EXCLUDED.failed_checks,
+			last_error = EXCLUDED.last_error, verified_at = EXCLUDED.verified_at, expires_at = EXCLUDED.expires_at,
+			next_check_at = EXCLUDED.next_check_at
+		RETURNING ` + namespaceClaimColumns
+
+	stored, err := scanNamespaceClaim(db.getExecutor(ctx, tx).QueryRow(ctx, query, claim.Namespace, claim.Method, claim.Claimant.AuthMethod,
+		claim.Claimant.Subject, token, claim.Status, claim.FailedChecks, claim.LastError, claim.VerifiedAt, claim.ExpiresAt, claim.NextCheckAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set namespace claim: %w", err)
+	}
+
+	return stored, nil
+}
+
+// GetNamespaceClaim retrieves a claim on a namespace by ID
+func (db *PostgreSQL) GetNamespaceClaim(ctx context.Context, tx pgx.Tx, namespace, id string) (*NamespaceClaimCheck, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceClaimColumns + ` FROM namespace_claims WHERE id = $1 AND namespace = $2`
+
+	claim, err := scanNamespaceClaim(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, namespace))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+// ListNamespaceClaims lists the claims on a namespace, or only a claimant's when claimant isn't
+// nil, most recent first
+func (db *PostgreSQL) ListNamespaceClaims(ctx context.Context, tx pgx.Tx, namespace string, claimant *apiv0.Principal) ([]NamespaceClaimCheck, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var authMethod, subject string
+	if claimant != nil {
+		authMethod, subject = claimant.AuthMethod, claimant.Subject
+	}
+
+	query := `
+		SELECT ` + namespaceClaimColumns + `
+		FROM namespace_claims
+		WHERE namespace = $1 AND ($2 = '' OR (auth_method = $2 AND subject = $3))
+		ORDER BY created_at DESC, id
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, namespace, authMethod, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query namespace claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []NamespaceClaimCheck{}
+	for rows.Next() {
+		claim, err := scanNamespaceClaim(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan namespace claim: %w", err)
+		}
+		claims = append(claims, *claim)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating namespace claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+// ListNamespaceClaimants lists the principals with a verified claim on a namespace that hasn't expired
+func (db *PostgreSQL) ListNamespaceClaimants(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.Principal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT auth_method, subject
+		FROM namespace_claims
+		WHERE namespace = $1 AND status = 'verified' AND expires_at > NOW()
+		ORDER BY auth_method, subject
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query namespace claimants: %w", err)
+	}
+	defer rows.Close()
+
+	claimants := []apiv0.Principal{}
+	for rows.Next() {
+		var claimant apiv0.Principal
+		if err := rows.Scan(&claimant.AuthMethod, &claimant.Subject); err != nil {
+			return nil, fmt.Errorf("failed to scan namespace claimant: %w", err)
+		}
+		claimants = append(claimants, claimant)
+	}
+
+	return claimants, rows.Err()
+}
+
+// DeleteNamespaceClaim withdraws a claim on a namespace
+func (db *PostgreSQL) DeleteNamespaceClaim(ctx context.Context, tx pgx.Tx, namespace, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM namespace_claims WHERE id = $1 AND namespace = $2`, id, namespace)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete namespace claim: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// LeaseDueNamespaceClaim hands the claim that has been due for a check longest to a verifier,
+// postponing its next check to leaseUntil so that other verifiers skip it meanwhile. It returns
+// ErrNotFound when no claim is due.
+func (db *PostgreSQL) LeaseDueNamespaceClaim(ctx context.Context, tx pgx.Tx, leaseUntil time.Time) (*NamespaceClaimCheck, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE namespace_claims SET next_check_at = $1
+		WHERE id = (
+			SELECT id FROM namespace_claims
+			WHERE next_check_at <= NOW()
+			ORDER BY next_check_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + namespaceClaimColumns
+
+	claim, err := scanNamespaceClaim(db.getExecutor(ctx, tx).QueryRow(ctx, query, leaseUntil))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lease namespace claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+func scanNamespaceClaim(row pgx.Row) (*NamespaceClaimCheck, error) {
+	var claim NamespaceClaimCheck
+	var token string
+	err := row.Scan(&claim.ID, &claim.Namespace, &claim.Method, &claim.Claimant.AuthMethod, &claim.Claimant.Subject, &token,
+		&claim.Status, &claim.FailedChecks, &claim.LastError, &claim.CreatedAt, &claim.VerifiedAt, &claim.ExpiresAt, &claim.NextCheckAt)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		claim.Challenge = &apiv0.ClaimChallenge{Token: token}
+	}
+	return &claim, nil
+}
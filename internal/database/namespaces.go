@@ -0,0 +1,337 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// pgInvalidTextRepresentation is returned by PostgreSQL when a request ID is not a valid UUID
+const pgInvalidTextRepresentation = "22P02"
+
+const namespaceRequestColumns = `
+	id::text, kind, namespace, from_auth_method, from_subject, to_auth_method, to_subject,
+	status, created_at, expires_at, resolved_at
+`
+
+// GetNamespaceOwner retrieves the recorded owner of a namespace
+func (db *PostgreSQL) GetNamespaceOwner(ctx context.Context, tx pgx.Tx, namespace string) (*apiv0.Principal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT auth_method, subject FROM namespace_owners WHERE namespace = $1`
+
+	var owner apiv0.Principal
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, namespace).Scan(&owner.AuthMethod, &owner.Subject)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace owner: %w", err)
+	}
+
+	return &owner, nil
+}
+
+// SetNamespaceOwner records the owner of a namespace, replacing any previous owner
+func (db *PostgreSQL) SetNamespaceOwner(ctx context.Context, tx pgx.Tx, namespace string, owner apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_owners (namespace, auth_method, subject, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (namespace) DO UPDATE
+		SET auth_method = EXCLUDED.auth_method, subject = EXCLUDED.subject, updated_at = NOW()
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, namespace, owner.AuthMethod, owner.Subject); err != nil {
+		return fmt.Errorf("failed to set namespace owner: %w", err)
+	}
+
+	return nil
+}
+
+// ListNamespaceDelegates lists the principals with delegated publish rights to a namespace
+func (db *PostgreSQL) ListNamespaceDelegates(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.Principal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT auth_method, subject
+		FROM namespace_delegates
+		WHERE namespace = $1
+		ORDER BY created_at, auth_method, subject
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query namespace delegates: %w", err)
+	}
+	defer rows.Close()
+
+	delegates := []apiv0.Principal{}
+	for rows.Next() {
+		var delegate apiv0.Principal
+		if err := rows.Scan(&delegate.AuthMethod, &delegate.Subject); err != nil {
+			return nil, fmt.Errorf("failed to scan namespace delegate: %w", err)
+		}
+		delegates = append(delegates, delegate)
+	}
+
+	return delegates, rows.Err()
+}
+
+// AddNamespaceDelegate grants a principal publish rights to a namespace
+func (db *PostgreSQL) AddNamespaceDelegate(ctx context.Context, tx pgx.Tx, namespace string, delegate apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_delegates (namespace, auth_method, subject)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, namespace, delegate.AuthMethod, delegate.Subject); err != nil {
+		return fmt.Errorf("failed to add namespace delegate: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveNamespaceDelegate revokes a principal's delegated publish rights to a namespace
+func (db *PostgreSQL) RemoveNamespaceDelegate(ctx context.Context, tx pgx.Tx, namespace string, delegate apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM namespace_delegates WHERE namespace = $1 AND auth_method = $2 AND subject = $3`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, namespace, delegate.AuthMethod, delegate.Subject)
+	if err != nil {
+		return fmt.Errorf("failed to remove namespace delegate: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateNamespaceRequest stores a new pending transfer or delegation request
+func (db *PostgreSQL) CreateNamespaceRequest(ctx context.Context, tx pgx.Tx, req *apiv0.NamespaceRequest) (*apiv0.NamespaceRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_requests (kind, namespace, from_auth_method, from_subject, to_auth_method, to_subject, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + namespaceRequestColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query,
+		req.Kind,
+		req.Namespace,
+		req.From.AuthMethod,
+		req.From.Subject,
+		req.To.AuthMethod,
+		req.To.Subject,
+		req.ExpiresAt,
+	)
+	created, err := scanNamespaceRequest(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert namespace request: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetNamespaceRequest retrieves a transfer or delegation request by ID
+func (db *PostgreSQL) GetNamespaceRequest(ctx context.Context, tx pgx.Tx, id string) (*apiv0.NamespaceRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceRequestColumns + ` FROM namespace_requests WHERE id = $1`
+
+	req, err := scanNamespaceRequest(db.getExecutor(ctx, tx).QueryRow(ctx, query, id))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace request: %w", err)
+	}
+
+	return req, nil
+}
+
+// ListPendingNamespaceRequests lists the pending requests made by or addressed to a principal
+func (db *PostgreSQL) ListPendingNamespaceRequests(ctx context.Context, tx pgx.Tx, principal apiv0.Principal) ([]apiv0.NamespaceRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceRequestColumns + `
+		FROM namespace_requests
+		WHERE status = 'pending'
+		  AND ((from_auth_method = $1 AND from_subject = $2) OR (to_auth_method = $1 AND to_subject = $2))
+		ORDER BY created_at DESC
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, principal.AuthMethod, principal.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query namespace requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []apiv0.NamespaceRequest{}
+	for rows.Next() {
+		req, err := scanNamespaceRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan namespace request: %w", err)
+		}
+		requests = append(requests, *req)
+	}
+
+	return requests, rows.Err()
+}
+
+// ResolveNamespaceRequest moves a pending request to its final status
+func (db *PostgreSQL) ResolveNamespaceRequest(ctx context.Context, tx pgx.Tx, id, status string) (*apiv0.NamespaceRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE namespace_requests
+		SET status = $1, resolved_at = NOW()
+		WHERE id = $2 AND status = 'pending'
+		RETURNING ` + namespaceRequestColumns
+
+	req, err := scanNamespaceRequest(db.getExecutor(ctx, tx).QueryRow(ctx, query, status, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve namespace request: %w", err)
+	}
+
+	return req, nil
+}
+
+// AddNamespaceAuditEntry appends an entry to the namespace audit log
+func (db *PostgreSQL) AddNamespaceAuditEntry(ctx context.Context, tx pgx.Tx, entry *apiv0.NamespaceAuditEntry) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var targetMethod, targetSubject, requestID, organization *string
+	if entry.Target != nil {
+		targetMethod, targetSubject = &entry.Target.AuthMethod, &entry.Target.Subject
+	}
+	if entry.RequestID != "" {
+		requestID = &entry.RequestID
+	}
+	if entry.Organization != "" {
+		organization = &entry.Organization
+	}
+
+	query := `
+		INSERT INTO namespace_audit_log (namespace, action, actor_auth_method, actor_subject, target_auth_method, target_subject, request_id, organization)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := db.getExecutor(ctx, tx).Exec(ctx, query,
+		entry.Namespace,
+		entry.Action,
+		entry.Actor.AuthMethod,
+		entry.Actor.Subject,
+		targetMethod,
+		targetSubject,
+		requestID,
+		organization,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert namespace audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListNamespaceAuditEntries lists the audit log of a namespace, newest first
+func (db *PostgreSQL) ListNamespaceAuditEntries(ctx context.Context, tx pgx.Tx, namespace string, limit int) ([]apiv0.NamespaceAuditEntry, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, namespace, action, actor_auth_method, actor_subject, target_auth_method, target_subject, request_id::text, organization, created_at
+		FROM namespace_audit_log
+		WHERE namespace = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, namespace, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query namespace audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []apiv0.NamespaceAuditEntry{}
+	for rows.Next() {
+		var entry apiv0.NamespaceAuditEntry
+		var targetMethod, targetSubject, requestID, organization *string
+		if err := rows.Scan(&entry.ID, &entry.Namespace, &entry.Action, &entry.Actor.AuthMethod, &entry.Actor.Subject,
+			&targetMethod, &targetSubject, &requestID, &organization, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan namespace audit entry: %w", err)
+		}
+		if targetMethod != nil && targetSubject != nil {
+			entry.Target = &apiv0.Principal{AuthMethod: *targetMethod, Subject: *targetSubject}
+		}
+		if requestID != nil {
+			entry.RequestID = *requestID
+		}
+		if organization != nil {
+			entry.Organization = *organization
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func scanNamespaceRequest(row pgx.Row) (*apiv0.NamespaceRequest, error) {
+	var req apiv0.NamespaceRequest
+	var resolvedAt *time.Time
+	err := row.Scan(
+		&req.ID,
+		&req.Kind,
+		&req.Namespace,
+		&req.From.AuthMethod,
+		&req.From.Subject,
+		&req.To.AuthMethod,
+		&req.To.Subject,
+		&req.Status,
+		&req.CreatedAt,
+		&req.ExpiresAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.ResolvedAt = resolvedAt
+	return &req, nil
+}
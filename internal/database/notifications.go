@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const notificationSubscriptionColumns = `
+	id::text, namespace, auth_method, subject, channel, target, COALESCE(secret, ''), events, created_at
+`
+
+// CreateNotificationSubscription stores a new notification subscription
+func (db *PostgreSQL) CreateNotificationSubscription(ctx context.Context, tx pgx.Tx, sub *apiv0.NotificationSubscription) (*apiv0.NotificationSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO notification_subscriptions (namespace, auth_method, subject, channel, target, secret, events)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		RETURNING ` + notificationSubscriptionColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, sub.Namespace, sub.Owner.AuthMethod, sub.Owner.Subject,
+		sub.Channel, sub.Target, sub.Secret, sub.Events)
+	created, err := scanNotificationSubscription(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert notification subscription: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListNotificationSubscriptions lists every subscription for a namespace, oldest first
+func (db *PostgreSQL) ListNotificationSubscriptions(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.NotificationSubscription, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + notificationSubscriptionColumns + `
+		FROM notification_subscriptions
+		WHERE namespace = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []apiv0.NotificationSubscription{}
+	for rows.Next() {
+		sub, err := scanNotificationSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, *sub)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// DeleteNotificationSubscription deletes one of an owner's subscriptions for a namespace
+func (db *PostgreSQL) DeleteNotificationSubscription(ctx context.Context, tx pgx.Tx, namespace, id string, owner apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM notification_subscriptions WHERE id = $1 AND namespace = $2 AND auth_method = $3 AND subject = $4`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, id, namespace, owner.AuthMethod, owner.Subject)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete notification subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteOwnerNotificationSubscriptions deletes all of a principal's subscriptions for a namespace
+func (db *PostgreSQL) DeleteOwnerNotificationSubscriptions(ctx context.Context, tx pgx.Tx, namespace string, owner apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM notification_subscriptions WHERE namespace = $1 AND auth_method = $2 AND subject = $3`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, namespace, owner.AuthMethod, owner.Subject); err != nil {
+		return fmt.Errorf("failed to delete notification subscriptions: %w", err)
+	}
+
+	return nil
+}
+
+func scanNotificationSubscription(row pgx.Row) (*apiv0.NotificationSubscription, error) {
+	var sub apiv0.NotificationSubscription
+	if err := row.Scan(&sub.ID, &sub.Namespace, &sub.Owner.AuthMethod, &sub.Owner.Subject, &sub.Channel,
+		&sub.Target, &sub.Secret, &sub.Events, &sub.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
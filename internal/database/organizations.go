@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// pgUniqueViolation is returned by PostgreSQL when an insert conflicts with an existing row
+const pgUniqueViolation = "23505"
+
+// CreateOrganization creates an organization without members
+func (db *PostgreSQL) CreateOrganization(ctx context.Context, tx pgx.Tx, name string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `INSERT INTO organizations (name) VALUES ($1)`, name); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganization retrieves an organization with its members and namespaces
+func (db *PostgreSQL) GetOrganization(ctx context.Context, tx pgx.Tx, name string) (*apiv0.Organization, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+
+	org := &apiv0.Organization{Members: []apiv0.OrganizationMember{}, Namespaces: []string{}}
+	err := executor.QueryRow(ctx, `SELECT name, created_at FROM organizations WHERE name = $1`, name).Scan(&org.Name, &org.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	memberQuery := `
+		SELECT auth_method, subject, role
+		FROM organization_members
+		WHERE organization = $1
+		ORDER BY created_at, auth_method, subject
+	`
+	rows, err := executor.Query(ctx, memberQuery, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organization members: %w", err)
+	}
+	for rows.Next() {
+		var member apiv0.OrganizationMember
+		if err := rows.Scan(&member.AuthMethod, &member.Subject, &member.Role); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		org.Members = append(org.Members, member)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read organization members: %w", err)
+	}
+
+	rows, err = executor.Query(ctx, `SELECT namespace FROM organization_namespaces WHERE organization = $1 ORDER BY namespace`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organization namespaces: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var namespace string
+		if err := rows.Scan(&namespace); err != nil {
+			return nil, fmt.Errorf("failed to scan organization namespace: %w", err)
+		}
+		org.Namespaces = append(org.Namespaces, namespace)
+	}
+
+	return org, rows.Err()
+}
+
+// SetOrganizationMember adds a member to an organization or changes their role
+func (db *PostgreSQL) SetOrganizationMember(ctx context.Context, tx pgx.Tx, organization string, member apiv0.OrganizationMember) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO organization_members (organization, auth_method, subject, role)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization, auth_method, subject) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, organization, member.AuthMethod, member.Subject, member.Role); err != nil {
+		return fmt.Errorf("failed to set organization member: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveOrganizationMember removes a member from an organization
+func (db *PostgreSQL) RemoveOrganizationMember(ctx context.Context, tx pgx.Tx, organization string, member apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM organization_members WHERE organization = $1 AND auth_method = $2 AND subject = $3`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, organization, member.AuthMethod, member.Subject)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetNamespaceOrganization retrieves the organization a namespace belongs to
+func (db *PostgreSQL) GetNamespaceOrganization(ctx context.Context, tx pgx.Tx, namespace string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var organization string
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, `SELECT organization FROM organization_namespaces WHERE namespace = $1`, namespace).Scan(&organization)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get namespace organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+// AddOrganizationNamespace assigns a namespace to an organization
+func (db *PostgreSQL) AddOrganizationNamespace(ctx context.Context, tx pgx.Tx, organization, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `INSERT INTO organization_namespaces (namespace, organization) VALUES ($1, $2)`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, namespace, organization); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to add organization namespace: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveOrganizationNamespace releases a namespace from an organization
+func (db *PostgreSQL) RemoveOrganizationNamespace(ctx context.Context, tx pgx.Tx, organization, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM organization_namespaces WHERE namespace = $1 AND organization = $2`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, namespace, organization)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization namespace: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
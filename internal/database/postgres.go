@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,9 +20,23 @@ import (
 
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
 type PostgreSQL struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	replicas *replicaSet
 }
 
+// serverFlagColumns reads what the registry knows about a server version beyond its own
+// columns: whether its namespace is verified, the severity of advisories affecting it, the
+// vulnerabilities found in its images, what was recorded about its images when it was published,
+// the capabilities it listed when run in a sandbox, why its status last changed, whether it is
+// quarantined, and how much it was used recently
+const serverFlagColumns = verifiedExpression + ", " + advisoryExpression + ", " + vulnerabilitiesExpression + ", " + imagesExpression +
+	", " + capabilitiesExpression + ", " + statusDetailsExpression + ", " + quarantineExpression +
+	", " + usageExpression
+
+// noServerFlagColumns stands in for serverFlagColumns when listing servers without their _meta,
+// which skips the subqueries
+const noServerFlagColumns = "false, '', NULL::jsonb, NULL::jsonb, NULL::jsonb, NULL::jsonb, NULL::jsonb, NULL::jsonb"
+
 // Executor is an interface for executing queries (satisfied by both pgx.Tx and pgxpool.Pool)
 type Executor interface {
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
@@ -29,16 +44,34 @@ type Executor interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
-// getExecutor returns the appropriate executor (transaction or pool)
-func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
+// getExecutor returns the appropriate executor (transaction, read replica or primary pool)
+func (db *PostgreSQL) getExecutor(ctx context.Context, tx pgx.Tx) Executor {
 	if tx != nil {
 		return tx
 	}
+	if db.replicas != nil && ReadsFromReplica(ctx) {
+		if pool := db.replicas.reader(); pool != nil {
+			return pool
+		}
+	}
 	return db.pool
 }
 
-// NewPostgreSQL creates a new instance of the PostgreSQL database
+// NewPostgreSQL creates a new instance of the PostgreSQL database, applying pending migrations
 func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
+	db, err := ConnectPostgreSQL(ctx, connectionURI)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Migrate(ctx); err != nil {
+		db.pool.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// ConnectPostgreSQL creates a new instance of the PostgreSQL database without migrating it
+func ConnectPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
 	// Parse connection config for pool settings
 	config, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
@@ -59,21 +92,10 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 
 	// Test the connection
 	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
-	// Run migrations using a single connection from the pool
-	conn, err := pool.Acquire(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
-	}
-	defer conn.Release()
-
-	migrator := NewMigrator(conn.Conn())
-	if err := migrator.Migrate(ctx); err != nil {
-		return nil, fmt.Errorf("failed to run database migrations: %w", err)
-	}
-
 	return &PostgreSQL{
 		pool: pool,
 	}, nil
@@ -95,60 +117,28 @@ func (db *PostgreSQL) ListServers(
 	}
 
 	// Build WHERE clause for filtering using dedicated columns
-	var whereConditions []string
-	args := []any{}
-	argIndex := 1
+	whereConditions, args, orderBy, ranked := serverFilterConditions(filter)
+	argIndex := len(args) + 1
+	offset := 0
 
-	// Add filters using dedicated columns for better performance
-	if filter != nil {
-		if filter.Name != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
-			args = append(args, *filter.Name)
-			argIndex++
-		}
-		if filter.RemoteURL != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
-			args = append(args, *filter.RemoteURL)
-			argIndex++
-		}
-		if filter.UpdatedSince != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
-			args = append(args, *filter.UpdatedSince)
-			argIndex++
-		}
-		if filter.SubstringName != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
-			args = append(args, "%"+*filter.SubstringName+"%")
-			argIndex++
-		}
-		if filter.Version != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
-			args = append(args, *filter.Version)
-			argIndex++
-		}
-		if filter.IsLatest != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
-			args = append(args, *filter.IsLatest)
-			argIndex++
-		}
-	}
-
-	// Add cursor pagination using compound serverName:version cursor
+	// Add keyset pagination after the cursor's server name and version, or an offset for ranked results
 	if cursor != "" {
-		// Parse cursor format: "serverName:version"
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorServerName := parts[0]
-			cursorVersion := parts[1]
-
+		position, err := decodeListCursor(cursor, ranked)
+		if err != nil {
+			return nil, "", err
+		}
+		switch {
+		case ranked:
+			offset = position.Offset
+		case position.Version != "":
 			// Use compound condition: (server_name > cursor_name) OR (server_name = cursor_name AND version > cursor_version)
 			whereConditions = append(whereConditions, fmt.Sprintf("(server_name > $%d OR (server_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorServerName, cursorServerName, cursorVersion)
+			args = append(args, position.Name, position.Name, position.Version)
 			argIndex += 3
-		} else {
-			// Fallback for malformed cursor - treat as server name only for backwards compatibility
+		default:
+			// Fallback for cursors with a server name only, for backwards compatibility
 			whereConditions = append(whereConditions, fmt.Sprintf("server_name > $%d", argIndex))
-			args = append(args, cursor)
+			args = append(args, position.Name)
 			argIndex++
 		}
 	}
@@ -159,30 +149,52 @@ func (db *PostgreSQL) ListServers(
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	// Read only the selected fields of each server.json, and the flags only when _meta is selected
+	valueColumn, flagColumns := "value", serverFlagColumns
+	if filter != nil && len(filter.Fields) > 0 {
+		valueColumn = fmt.Sprintf("COALESCE((SELECT jsonb_object_agg(field.key, field.value) FROM jsonb_each(servers.value) AS field WHERE field.key = ANY($%d)), '{}'::jsonb)", argIndex)
+		args = append(args, filter.Fields)
+		argIndex++
+		if !slices.Contains(filter.Fields, "_meta") {
+			flagColumns = noServerFlagColumns
+		}
+	}
+
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
-        FROM servers
+        SELECT server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, %s, %s
+        FROM %s
         %s
-        ORDER BY server_name, version
-        LIMIT $%d
-    `, whereClause, argIndex)
-	args = append(args, limit)
+        ORDER BY %s
+        LIMIT $%d OFFSET $%d
+    `, valueColumn, flagColumns, serversTable(filter), whereClause, orderBy, argIndex, argIndex+1)
+	args = append(args, limit, offset)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query servers: %w", err)
 	}
 	defer rows.Close()
 
 	var results []*apiv0.ServerResponse
+	var lastName, lastVersion string
 	for rows.Next() {
 		var serverName, version, status string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
+		var yankedAt *time.Time
+		var revision int
 		var valueJSON []byte
-
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		var verified bool
+		var advisory string
+		var vulnerabilities *apiv0.VulnerabilityScan
+		var images []apiv0.OCIImage
+		var capabilities *apiv0.Capabilities
+		var statusDetails *apiv0.StatusDetails
+		var quarantine *apiv0.Quarantine
+		var usage *apiv0.Usage
+
+		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &valueJSON, &verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -198,31 +210,292 @@ func (db *PostgreSQL) ListServers(
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
+					Status:          model.Status(status),
+					PublishedAt:     publishedAt,
+					UpdatedAt:       updatedAt,
+					Revision:        revision,
+					IsLatest:        isLatest,
+					YankedAt:        yankedAt,
+					Verified:        verified,
+					Advisory:        advisory,
+					Vulnerabilities: vulnerabilities,
+					Images:          images,
+					Capabilities:    capabilities,
+					StatusDetails:   statusDetails,
+					Quarantine:      quarantine,
+					Usage:           usage,
 				},
 			},
 		}
 
 		results = append(results, serverResponse)
+		lastName, lastVersion = serverName, version
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, "", fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Determine next cursor using compound serverName:version format
+	// Determine the next cursor from the last server, or the offset of ranked results
 	nextCursor := ""
 	if len(results) > 0 && len(results) >= limit {
-		lastResult := results[len(results)-1]
-		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+		if ranked {
+			nextCursor = listCursor{Offset: offset + len(results)}.encode()
+		} else {
+			// From the columns, since the name and version may not be among the selected fields
+			nextCursor = listCursor{Name: lastName, Version: lastVersion}.encode()
+		}
 	}
 
 	return results, nextCursor, nil
 }
 
+// maxExactServerCount is how many matching servers CountServers counts before estimating instead
+const maxExactServerCount = 10000
+
+// CountServers counts the server entries matching a filter. Up to maxExactServerCount the count
+// is exact; beyond that it is the query planner's estimate, which avoids scanning every match.
+func (db *PostgreSQL) CountServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter) (int, bool, error) {
+	if ctx.Err() != nil {
+		return 0, false, ctx.Err()
+	}
+
+	whereConditions, args, _, _ := serverFilterConditions(filter)
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT 1 FROM %s %s LIMIT %d) AS matches`, serversTable(filter), whereClause, maxExactServerCount+1)
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, false, fmt.Errorf("failed to count servers: %w", err)
+	}
+	if count <= maxExactServerCount {
+		return count, false, nil
+	}
+
+	var plan []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	var planJSON []byte
+	query = fmt.Sprintf(`EXPLAIN (FORMAT JSON) SELECT 1 FROM %s %s`, serversTable(filter), whereClause)
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, args...).Scan(&planJSON); err != nil {
+		return 0, false, fmt.Errorf("failed to estimate server count: %w", err)
+	}
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return 0, false, fmt.Errorf("failed to parse query plan: %w", err)
+	}
+	if len(plan) == 0 {
+		return 0, false, errors.New("failed to parse query plan: empty plan")
+	}
+
+	// The estimate can be stale, but there are at least as many matches as were counted
+	return max(count, int(plan[0].Plan.Rows)), true, nil
+}
+
+// serverFilterConditions builds the WHERE conditions and their arguments for a filter, with the
+// order to list matching servers in and whether it ranks them by relevance
+func serverFilterConditions(filter *ServerFilter) ([]string, []any, string, bool) {
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	// Results are ordered by name, or by rating, size, recency or relevance first, in which case they page by offset
+	orderBy := "server_name, version"
+	ranked := false
+
+	// Deleted servers are hidden until they're restored or purged; snapshots copy none of them
+	if filter == nil || filter.Snapshot == nil {
+		whereConditions = append(whereConditions, "deleted_at IS NULL")
+	}
+
+	// Add filters using dedicated columns for better performance
+	if filter != nil {
+		if filter.Snapshot != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("snapshot_id = $%d", argIndex))
+			args = append(args, *filter.Snapshot)
+			argIndex++
+		}
+		if filter.Name != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
+			args = append(args, *filter.Name)
+			argIndex++
+		}
+		if filter.RemoteURL != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
+			args = append(args, *filter.RemoteURL)
+			argIndex++
+		}
+		if filter.UpdatedSince != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
+			args = append(args, *filter.UpdatedSince)
+			argIndex++
+		}
+		if filter.SubstringName != nil && filter.Ranking != nil {
+			condition, score, searchArgs := rankedSearch(*filter.SubstringName, filter.Ranking, argIndex)
+			whereConditions = append(whereConditions, condition)
+			orderBy = score + " DESC, " + orderBy
+			args = append(args, searchArgs...)
+			argIndex += len(searchArgs)
+			ranked = true
+		} else if filter.SubstringName != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
+			args = append(args, "%"+*filter.SubstringName+"%")
+			argIndex++
+		}
+		if filter.Version != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
+			args = append(args, *filter.Version)
+			argIndex++
+		}
+		if filter.IsLatest != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
+			args = append(args, *filter.IsLatest)
+			argIndex++
+		}
+		if filter.Yanked != nil {
+			if *filter.Yanked {
+				whereConditions = append(whereConditions, "yanked_at IS NOT NULL")
+			} else {
+				whereConditions = append(whereConditions, "yanked_at IS NULL")
+			}
+		}
+		if filter.Quarantined != nil {
+			if *filter.Quarantined {
+				whereConditions = append(whereConditions, quarantinedExpression)
+			} else {
+				whereConditions = append(whereConditions, "NOT "+quarantinedExpression)
+			}
+		}
+		if len(filter.Statuses) > 0 {
+			whereConditions = append(whereConditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+			args = append(args, filter.Statuses)
+			argIndex++
+		}
+		if filter.Channel != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM server_channels c WHERE c.server_name = servers.server_name AND c.version = servers.version AND c.channel = $%d)", argIndex))
+			args = append(args, *filter.Channel)
+			argIndex++
+		}
+		if len(filter.Supports) > 0 {
+			condition, transports := supportsCondition(filter.Supports, argIndex)
+			whereConditions = append(whereConditions, condition)
+			args = append(args, transports)
+			argIndex++
+		}
+		if filter.RegistryType != nil || filter.Transport != nil {
+			condition, patterns := runtimeCondition(filter.RegistryType, filter.Transport, argIndex)
+			whereConditions = append(whereConditions, condition)
+			args = append(args, patterns...)
+			argIndex += len(patterns)
+		}
+		if filter.Category != nil {
+			whereConditions = append(whereConditions, taxonomyCondition("server_version_categories", "category", argIndex))
+			args = append(args, NormalizeTaxonomyTerm(*filter.Category))
+			argIndex++
+		}
+		if filter.Tag != nil {
+			whereConditions = append(whereConditions, taxonomyCondition("server_version_tags", "tag", argIndex))
+			args = append(args, NormalizeTaxonomyTerm(*filter.Tag))
+			argIndex++
+		}
+		if filter.Verified != nil {
+			if *filter.Verified {
+				whereConditions = append(whereConditions, verifiedExpression)
+			} else {
+				whereConditions = append(whereConditions, "NOT "+verifiedExpression)
+			}
+		}
+		if filter.MaxSeverity != nil {
+			if condition := maxSeverityCondition(*filter.MaxSeverity); condition != "" {
+				whereConditions = append(whereConditions, condition)
+			}
+		}
+		if filter.Platform != nil {
+			whereConditions = append(whereConditions, platformCondition(argIndex))
+			args = append(args, *filter.Platform)
+			argIndex++
+		}
+		if filter.MaxImageSize != nil {
+			whereConditions = append(whereConditions, maxImageSizeCondition(argIndex))
+			args = append(args, *filter.MaxImageSize)
+		}
+		if filter.SortByRating {
+			orderBy = ratingScoreExpression + " DESC, " + orderBy
+			ranked = true
+		}
+		if filter.SortBySize {
+			orderBy = imageSizeExpression + " ASC NULLS LAST, " + orderBy
+			ranked = true
+		}
+		if filter.SortByUpdated {
+			orderBy = "updated_at DESC, " + orderBy
+			ranked = true
+		}
+	}
+
+	return whereConditions, args, orderBy, ranked
+}
+
+// runtimeCondition builds the condition keeping servers with a package from a registry type, a
+// package or remote using a transport, or, when both are given, a package from the registry type
+// using the transport. It returns the JSON patterns to bind to its placeholders, which are matched
+// by containment so that the indexes on packages and remotes are used.
+func runtimeCondition(registryType, transport *string, argIndex int) (string, []any) {
+	pkg := map[string]any{}
+	if registryType != nil {
+		pkg["registryType"] = *registryType
+	}
+	if transport != nil {
+		pkg["transport"] = map[string]string{"type": *transport}
+	}
+	packagePattern, _ := json.Marshal([]any{pkg})
+	condition := fmt.Sprintf("value->'packages' @> $%d::jsonb", argIndex)
+	if registryType != nil {
+		return condition, []any{string(packagePattern)}
+	}
+
+	remotePattern, _ := json.Marshal([]any{map[string]string{"type": *transport}})
+	return fmt.Sprintf("(%s OR value->'remotes' @> $%d::jsonb)", condition, argIndex+1),
+		[]any{string(packagePattern), string(remotePattern)}
+}
+
+// supportsCondition builds the condition keeping servers with a package or remote a client can
+// use, returning it with the transport types to bind to its placeholder. A remote that declares
+// required headers needs a client that can send them; any other remote is assumed to use MCP
+// authorization (OAuth) if it needs credentials. Auth is only checked when the client declares
+// at least one auth method.
+func supportsCondition(supports []string, argIndex int) (string, []string) {
+	transports := []string{}
+	var oauth, headers bool
+	for _, capability := range supports {
+		switch capability {
+		case model.ClientCapabilityOAuth:
+			oauth = true
+		case model.ClientCapabilityHeaders:
+			headers = true
+		default:
+			transports = append(transports, capability)
+		}
+	}
+
+	requiredHeader := "EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE(remote->'headers', '[]'::jsonb)) AS header WHERE (header->>'isRequired')::boolean)"
+	remoteAuth := ""
+	switch {
+	case oauth && !headers:
+		remoteAuth = " AND NOT " + requiredHeader
+	case headers && !oauth:
+		remoteAuth = " AND " + requiredHeader
+	}
+
+	return fmt.Sprintf(`(EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE(value->'packages', '[]'::jsonb)) AS package WHERE package->'transport'->>'type' = ANY($%[1]d::text[]))
+			OR EXISTS (SELECT 1 FROM jsonb_array_elements(COALESCE(value->'remotes', '[]'::jsonb)) AS remote WHERE remote->>'type' = ANY($%[1]d::text[])%[2]s))`,
+		argIndex, remoteAuth), transports
+}
+
 // GetServerByName retrieves the latest version of a server by server name
 func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -230,9 +503,9 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value, ` + serverFlagColumns + `
 		FROM servers
-		WHERE server_name = $1 AND is_latest = true
+		WHERE server_name = $1 AND is_latest = true AND deleted_at IS NULL
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
@@ -240,9 +513,19 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	var name, version, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
+	var yankedAt *time.Time
+	var revision int
 	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	var verified bool
+	var advisory string
+	var vulnerabilities *apiv0.VulnerabilityScan
+	var images []apiv0.OCIImage
+	var capabilities *apiv0.Capabilities
+	var statusDetails *apiv0.StatusDetails
+	var quarantine *apiv0.Quarantine
+	var usage *apiv0.Usage
+
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &valueJSON, &verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -261,10 +544,20 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				Revision:        revision,
+				IsLatest:        isLatest,
+				YankedAt:        yankedAt,
+				Verified:        verified,
+				Advisory:        advisory,
+				Vulnerabilities: vulnerabilities,
+				Images:          images,
+				Capabilities:    capabilities,
+				StatusDetails:   statusDetails,
+				Quarantine:      quarantine,
+				Usage:           usage,
 			},
 		},
 	}
@@ -279,18 +572,28 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value, ` + serverFlagColumns + `
 		FROM servers
-		WHERE server_name = $1 AND version = $2
+		WHERE server_name = $1 AND version = $2 AND deleted_at IS NULL
 		LIMIT 1
 	`
 
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
+	var yankedAt *time.Time
+	var revision int
 	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	var verified bool
+	var advisory string
+	var vulnerabilities *apiv0.VulnerabilityScan
+	var images []apiv0.OCIImage
+	var capabilities *apiv0.Capabilities
+	var statusDetails *apiv0.StatusDetails
+	var quarantine *apiv0.Quarantine
+	var usage *apiv0.Usage
+
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &valueJSON, &verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -309,10 +612,20 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				Revision:        revision,
+				IsLatest:        isLatest,
+				YankedAt:        yankedAt,
+				Verified:        verified,
+				Advisory:        advisory,
+				Vulnerabilities: vulnerabilities,
+				Images:          images,
+				Capabilities:    capabilities,
+				StatusDetails:   statusDetails,
+				Quarantine:      quarantine,
+				Usage:           usage,
 			},
 		},
 	}
@@ -327,13 +640,13 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value, ` + serverFlagColumns + `
 		FROM servers
-		WHERE server_name = $1
+		WHERE server_name = $1 AND deleted_at IS NULL
 		ORDER BY published_at DESC
 	`
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query server versions: %w", err)
 	}
@@ -344,9 +657,19 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 		var name, version, status string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
+		var yankedAt *time.Time
+		var revision int
 		var valueJSON []byte
-
-		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		var verified bool
+		var advisory string
+		var vulnerabilities *apiv0.VulnerabilityScan
+		var images []apiv0.OCIImage
+		var capabilities *apiv0.Capabilities
+		var statusDetails *apiv0.StatusDetails
+		var quarantine *apiv0.Quarantine
+		var usage *apiv0.Usage
+
+		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &valueJSON, &verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -362,10 +685,20 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
+					Status:          model.Status(status),
+					PublishedAt:     publishedAt,
+					UpdatedAt:       updatedAt,
+					Revision:        revision,
+					IsLatest:        isLatest,
+					YankedAt:        yankedAt,
+					Verified:        verified,
+					Advisory:        advisory,
+					Vulnerabilities: vulnerabilities,
+					Images:          images,
+					Capabilities:    capabilities,
+					StatusDetails:   statusDetails,
+					Quarantine:      quarantine,
+					Usage:           usage,
 				},
 			},
 		}
@@ -409,9 +742,9 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 	insertQuery := `
 		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`
+		RETURNING revision, ` + serverFlagColumns
 
-	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
+	err = db.getExecutor(ctx, tx).QueryRow(ctx, insertQuery,
 		serverJSON.Name,
 		serverJSON.Version,
 		string(officialMeta.Status),
@@ -419,7 +752,7 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
 		valueJSON,
-	)
+	).Scan(&officialMeta.Revision, &officialMeta.Verified, &officialMeta.Advisory, &officialMeta.Vulnerabilities, &officialMeta.Images, &officialMeta.Capabilities, &officialMeta.StatusDetails, &officialMeta.Quarantine, &officialMeta.Usage)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert server: %w", err)
@@ -461,16 +794,26 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 	// Update only the JSON data (keep existing metadata columns)
 	query := `
 		UPDATE servers
-		SET value = $1, updated_at = NOW()
+		SET value = $1, updated_at = NOW(), revision = revision + 1
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, ` + serverFlagColumns + `
 	`
 
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
-
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest)
+	var yankedAt *time.Time
+	var revision int
+	var verified bool
+	var advisory string
+	var vulnerabilities *apiv0.VulnerabilityScan
+	var images []apiv0.OCIImage
+	var capabilities *apiv0.Capabilities
+	var statusDetails *apiv0.StatusDetails
+	var quarantine *apiv0.Quarantine
+	var usage *apiv0.Usage
+
+	err = db.getExecutor(ctx, tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -483,10 +826,20 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		Server: *serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				Revision:        revision,
+				IsLatest:        isLatest,
+				YankedAt:        yankedAt,
+				Verified:        verified,
+				Advisory:        advisory,
+				Vulnerabilities: vulnerabilities,
+				Images:          images,
+				Capabilities:    capabilities,
+				StatusDetails:   statusDetails,
+				Quarantine:      quarantine,
+				Usage:           usage,
 			},
 		},
 	}
@@ -503,17 +856,27 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 	// Update the status column
 	query := `
 		UPDATE servers
-		SET status = $1, updated_at = NOW()
+		SET status = $1, updated_at = NOW(), revision = revision + 1
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, yanked_at, revision, ` + serverFlagColumns + `
 	`
 
 	var name, vers, currentStatus string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
+	var yankedAt *time.Time
+	var revision int
 	var valueJSON []byte
-
-	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest)
+	var verified bool
+	var advisory string
+	var vulnerabilities *apiv0.VulnerabilityScan
+	var images []apiv0.OCIImage
+	var capabilities *apiv0.Capabilities
+	var statusDetails *apiv0.StatusDetails
+	var quarantine *apiv0.Quarantine
+	var usage *apiv0.Usage
+
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -532,10 +895,20 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(currentStatus),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(currentStatus),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				Revision:        revision,
+				IsLatest:        isLatest,
+				YankedAt:        yankedAt,
+				Verified:        verified,
+				Advisory:        advisory,
+				Vulnerabilities: vulnerabilities,
+				Images:          images,
+				Capabilities:    capabilities,
+				StatusDetails:   statusDetails,
+				Quarantine:      quarantine,
+				Usage:           usage,
 			},
 		},
 	}
@@ -583,7 +956,7 @@ func (db *PostgreSQL) AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverN
 
 	lockID := hashServerName(serverName)
 
-	if _, err := db.getExecutor(tx).Exec(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
 		return fmt.Errorf("failed to acquire publish lock: %w", err)
 	}
 
@@ -612,7 +985,7 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		return nil, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getExecutor(ctx, tx)
 
 	query := `
 		SELECT server_name, version, status, value, published_at, updated_at, is_latest
@@ -662,7 +1035,7 @@ func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx pgx.Tx, server
 		return 0, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getExecutor(ctx, tx)
 
 	query := `SELECT COUNT(*) FROM servers WHERE server_name = $1`
 
@@ -681,7 +1054,7 @@ func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverN
 		return false, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getExecutor(ctx, tx)
 
 	query := `SELECT EXISTS(SELECT 1 FROM servers WHERE server_name = $1 AND version = $2)`
 
@@ -700,7 +1073,7 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 		return ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getExecutor(ctx, tx)
 
 	query := `UPDATE servers SET is_latest = false WHERE server_name = $1 AND is_latest = true`
 
@@ -712,8 +1085,58 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
+// MarkAsLatest marks a version of a server as its latest. Unmark the current latest version first.
+func (db *PostgreSQL) MarkAsLatest(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE servers SET is_latest = true WHERE server_name = $1 AND version = $2`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version)
+	if err != nil {
+		return fmt.Errorf("failed to mark latest version: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetServerYanked yanks a version of a server, or restores a yanked one
+func (db *PostgreSQL) SetServerYanked(ctx context.Context, tx pgx.Tx, serverName, version string, yanked bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE servers
+		SET yanked_at = CASE WHEN $3::boolean THEN COALESCE(yanked_at, NOW()) END, updated_at = NOW(), revision = revision + 1
+		WHERE server_name = $1 AND version = $2
+	`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version, yanked)
+	if err != nil {
+		return fmt.Errorf("failed to set server yanked: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Ping checks the database is reachable
+func (db *PostgreSQL) Ping(ctx context.Context) error {
+	return db.pool.Ping(ctx)
+}
+
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
+	if db.replicas != nil {
+		db.replicas.close()
+	}
 	db.pool.Close()
 	return nil
 }
@@ -955,6 +955,204 @@ func TestPostgreSQL_PerformanceScenarios(t *testing.T) {
 	})
 }
 
+func TestPostgreSQL_PackageImages(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	meta := func() *apiv0.RegistryExtensions {
+		return &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: time.Now(), UpdatedAt: time.Now(), IsLatest: true}
+	}
+	for _, name := range []string{"com.example/multi-arch", "com.example/amd64-only", "com.example/unknown"} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{Name: name, Description: "Image server", Version: "1.0.0"}, meta())
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, db.SetPackageImage(ctx, nil, "com.example/multi-arch", "1.0.0", &apiv0.OCIImage{
+		Identifier: "docker.io/example/multi-arch:1.0.0",
+		Platforms:  []string{"linux/amd64", "linux/arm64/v8"},
+		Size:       300 << 20,
+		Memory:     "512Mi",
+	}))
+	require.NoError(t, db.SetPackageImage(ctx, nil, "com.example/amd64-only", "1.0.0", &apiv0.OCIImage{
+		Identifier: "docker.io/example/amd64-only:1.0.0",
+		Platforms:  []string{"linux/amd64"},
+		Size:       20 << 20,
+	}))
+	assert.ErrorIs(t, db.SetPackageImage(ctx, nil, "com.example/missing", "1.0.0", &apiv0.OCIImage{
+		Identifier: "docker.io/example/missing:1.0.0",
+	}), database.ErrNotFound)
+
+	server, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/multi-arch", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, server.Meta.Official.Images, 1)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64/v8"}, server.Meta.Official.Images[0].Platforms)
+	assert.Equal(t, int64(300<<20), server.Meta.Official.Images[0].Size)
+	assert.Equal(t, "512Mi", server.Meta.Official.Images[0].Memory)
+	assert.Empty(t, server.Meta.Official.Images[0].CPUs)
+
+	// A platform without a variant matches every variant, and unknown platforms are kept
+	results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Platform: stringPtr("linux/arm64")}, "", 10)
+	require.NoError(t, err)
+	var names []string
+	for _, result := range results {
+		names = append(names, result.Server.Name)
+	}
+	assert.ElementsMatch(t, []string{"com.example/multi-arch", "com.example/unknown"}, names)
+
+	// Smaller images sort first, and unknown sizes last and past any size limit
+	maxSize := int64(100 << 20)
+	results, _, err = db.ListServers(ctx, nil, &database.ServerFilter{SubstringName: stringPtr("com.example/"), SortBySize: true}, "", 10)
+	require.NoError(t, err)
+	names = nil
+	for _, result := range results {
+		names = append(names, result.Server.Name)
+	}
+	assert.Equal(t, []string{"com.example/amd64-only", "com.example/multi-arch", "com.example/unknown"}, names)
+
+	results, _, err = db.ListServers(ctx, nil, &database.ServerFilter{MaxImageSize: &maxSize}, "", 10)
+	require.NoError(t, err)
+	names = nil
+	for _, result := range results {
+		names = append(names, result.Server.Name)
+	}
+	assert.ElementsMatch(t, []string{"com.example/amd64-only", "com.example/unknown"}, names)
+}
+
+func TestPostgreSQL_Snapshots(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	meta := func() *apiv0.RegistryExtensions {
+		return &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: time.Now(), UpdatedAt: time.Now(), IsLatest: true}
+	}
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{Name: "com.example/before", Description: "Snapshot server", Version: "1.0.0"}, meta())
+	require.NoError(t, err)
+
+	snapshot, err := db.CreateSnapshot(ctx, nil, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	// Servers published after the snapshot don't show up in it
+	_, err = db.CreateServer(ctx, nil, &apiv0.ServerJSON{Name: "com.example/after", Description: "Snapshot server", Version: "1.0.0"}, meta())
+	require.NoError(t, err)
+
+	results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Snapshot: &snapshot.ID}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "com.example/before", results[0].Server.Name)
+
+	total, _, err := db.CountServers(ctx, nil, &database.ServerFilter{Snapshot: &snapshot.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	got, err := db.GetSnapshot(ctx, nil, snapshot.ID)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.ID, got.ID)
+	newest, err := db.GetNewestSnapshot(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.ID, newest.ID)
+
+	_, err = db.GetSnapshot(ctx, nil, "not-a-uuid")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestPostgreSQL_SearchServers(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	meta := func(isLatest bool) *apiv0.RegistryExtensions {
+		return &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: time.Now(), UpdatedAt: time.Now(), IsLatest: isLatest}
+	}
+	servers := []*apiv0.ServerJSON{
+		{Name: "com.example/weather", Title: "Weather", Description: "Forecasts & <alerts> for any city", Version: "1.0.0"},
+		{Name: "com.example/maps", Description: "Maps and directions", Version: "1.0.0", Meta: &apiv0.ServerMeta{
+			PublisherProvided: map[string]any{"tags": []any{"geo"}, "tools": []any{map[string]any{"name": "get_weather_overlay"}}},
+		}},
+		{Name: "com.example/calendar", Description: "Schedules meetings", Version: "1.0.0"},
+	}
+	for _, server := range servers {
+		_, err := db.CreateServer(ctx, nil, server, meta(true))
+		require.NoError(t, err)
+	}
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{Name: "com.example/old", Description: "Weather archive", Version: "0.1.0"}, meta(false))
+	require.NoError(t, err)
+
+	// Names and titles outrank tools, and versions that aren't latest are left out
+	results, nextCursor, err := db.SearchServers(ctx, nil, "weather", "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "com.example/weather", results[0].Server.Name)
+	assert.Equal(t, "com.example/maps", results[1].Server.Name)
+	assert.Greater(t, results[0].Rank, results[1].Rank)
+	assert.Empty(t, nextCursor)
+
+	// Stemmed words match, and highlights are HTML-escaped
+	results, _, err = db.SearchServers(ctx, nil, "forecast", "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "<mark>Forecasts</mark> &amp; &lt;alerts&gt; for any city", results[0].Highlight)
+
+	results, _, err = db.SearchServers(ctx, nil, "geo", "", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "com.example/maps", results[0].Server.Name)
+
+	// Results page by offset
+	results, nextCursor, err = db.SearchServers(ctx, nil, "example", "", 2)
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "2", nextCursor)
+	results, _, err = db.SearchServers(ctx, nil, "example", nextCursor, 2)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	_, _, err = db.SearchServers(ctx, nil, "weather", "not-an-offset", 10)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
+func TestPostgreSQL_RegistryTypeAndTransportFilters(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	meta := &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: time.Now(), UpdatedAt: time.Now(), IsLatest: true}
+	servers := []*apiv0.ServerJSON{
+		{Name: "com.example/container", Description: "Local container", Version: "1.0.0", Packages: []model.Package{
+			{RegistryType: model.RegistryTypeOCI, Identifier: "docker.io/example/container:1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+		}},
+		{Name: "com.example/mixed", Description: "HTTP container and stdio npm package", Version: "1.0.0", Packages: []model.Package{
+			{RegistryType: model.RegistryTypeOCI, Identifier: "docker.io/example/mixed:1.0.0", Transport: model.Transport{Type: model.TransportTypeStreamableHTTP}},
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@example/mixed", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+		}},
+		{Name: "com.example/remote", Description: "Remote only", Version: "1.0.0", Remotes: []model.Transport{
+			{Type: model.TransportTypeSSE, URL: "https://example.com/sse"},
+		}},
+	}
+	for _, server := range servers {
+		_, err := db.CreateServer(ctx, nil, server, meta)
+		require.NoError(t, err)
+	}
+
+	names := func(filter *database.ServerFilter) []string {
+		results, _, err := db.ListServers(ctx, nil, filter, "", 10)
+		require.NoError(t, err)
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Server.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"com.example/container", "com.example/mixed"}, names(&database.ServerFilter{RegistryType: stringPtr(model.RegistryTypeOCI)}))
+	assert.Equal(t, []string{"com.example/container", "com.example/mixed"}, names(&database.ServerFilter{Transport: stringPtr(model.TransportTypeStdio)}))
+	assert.Equal(t, []string{"com.example/remote"}, names(&database.ServerFilter{Transport: stringPtr(model.TransportTypeSSE)}))
+
+	// Together, both must match the same package
+	assert.Equal(t, []string{"com.example/container"}, names(&database.ServerFilter{
+		RegistryType: stringPtr(model.RegistryTypeOCI),
+		Transport:    stringPtr(model.TransportTypeStdio),
+	}))
+	assert.Empty(t, names(&database.ServerFilter{RegistryType: stringPtr(model.RegistryTypePyPI)}))
+}
+
 // Helper functions for creating pointers to basic types
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const updateProposalColumns = `
+	id::text, server_name, version, current_version, release_tag, release_url, value, status, error, created_at, resolved_at
+`
+
+// CreateUpdateProposal stores a proposal to publish a new version of a server. A version is only
+// ever proposed once, so proposing it again returns ErrAlreadyExists.
+func (db *PostgreSQL) CreateUpdateProposal(ctx context.Context, tx pgx.Tx, proposal *apiv0.UpdateProposal) (*apiv0.UpdateProposal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	valueJSON, err := json.Marshal(proposal.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proposed server JSON: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_update_proposals (server_name, version, current_version, release_tag, release_url, value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (server_name, version) DO NOTHING
+		RETURNING ` + updateProposalColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, proposal.ServerName, proposal.Version, proposal.CurrentVersion,
+		proposal.ReleaseTag, proposal.ReleaseURL, valueJSON)
+	created, err := scanUpdateProposal(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to insert update proposal: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetUpdateProposal retrieves an update proposal of a server
+func (db *PostgreSQL) GetUpdateProposal(ctx context.Context, tx pgx.Tx, serverName, id string) (*apiv0.UpdateProposal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + updateProposalColumns + ` FROM server_update_proposals WHERE id = $1 AND server_name = $2`
+
+	proposal, err := scanUpdateProposal(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, serverName))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get update proposal: %w", err)
+	}
+
+	return proposal, nil
+}
+
+// ListUpdateProposals lists the update proposals of a server, or of every server when serverName
+// is empty, most recent first. An empty status lists proposals of any status.
+func (db *PostgreSQL) ListUpdateProposals(ctx context.Context, tx pgx.Tx, serverName, status string, limit int) ([]apiv0.UpdateProposal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + updateProposalColumns + `
+		FROM server_update_proposals
+		WHERE ($1 = '' OR server_name = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id
+		LIMIT $3
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query update proposals: %w", err)
+	}
+	defer rows.Close()
+
+	proposals := []apiv0.UpdateProposal{}
+	for rows.Next() {
+		proposal, err := scanUpdateProposal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan update proposal: %w", err)
+		}
+		proposals = append(proposals, *proposal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating update proposals: %w", err)
+	}
+
+	return proposals, nil
+}
+
+// SetUpdateProposalStatus records the status of an update proposal and why publishing it last
+// failed, if it did. Leaving the pending status marks the proposal resolved.
+func (db *PostgreSQL) SetUpdateProposalStatus(ctx context.Context, tx pgx.Tx, id, status, errorMessage string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE server_update_proposals
+		SET status = $2, error = $3, resolved_at = CASE WHEN $2 = 'pending' THEN NULL ELSE NOW() END
+		WHERE id = $1
+	`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, id, status, errorMessage)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set update proposal status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUpdatePolicy retrieves how new upstream releases of a server are handled. Servers without a
+// policy have their releases proposed.
+func (db *PostgreSQL) GetUpdatePolicy(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.UpdatePolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var policy apiv0.UpdatePolicy
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, `SELECT auto_publish FROM server_update_policies WHERE server_name = $1`, serverName).
+		Scan(&policy.AutoPublish)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get update policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// SetUpdatePolicy sets how new upstream releases of a server are handled
+func (db *PostgreSQL) SetUpdatePolicy(ctx context.Context, tx pgx.Tx, serverName string, policy *apiv0.UpdatePolicy) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_update_policies (server_name, auto_publish)
+		VALUES ($1, $2)
+		ON CONFLICT (server_name) DO UPDATE SET auto_publish = $2, updated_at = NOW()
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, policy.AutoPublish); err != nil {
+		return fmt.Errorf("failed to set update policy: %w", err)
+	}
+
+	return nil
+}
+
+func scanUpdateProposal(row pgx.Row) (*apiv0.UpdateProposal, error) {
+	var proposal apiv0.UpdateProposal
+	var valueJSON []byte
+	err := row.Scan(&proposal.ID, &proposal.ServerName, &proposal.Version, &proposal.CurrentVersion, &proposal.ReleaseTag,
+		&proposal.ReleaseURL, &valueJSON, &proposal.Status, &proposal.Error, &proposal.CreatedAt, &proposal.ResolvedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(valueJSON, &proposal.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal proposed server JSON: %w", err)
+	}
+	return &proposal, nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetServerPublisher retrieves the identity that first published a server
+func (db *PostgreSQL) GetServerPublisher(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.Principal, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT auth_method, subject FROM server_publishers WHERE server_name = $1`
+
+	var publisher apiv0.Principal
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName).Scan(&publisher.AuthMethod, &publisher.Subject)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server publisher: %w", err)
+	}
+
+	return &publisher, nil
+}
+
+// SetServerPublisher records the identity that first published a server. A publisher already
+// recorded is kept.
+func (db *PostgreSQL) SetServerPublisher(ctx context.Context, tx pgx.Tx, serverName string, publisher apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_publishers (server_name, auth_method, subject)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (server_name) DO NOTHING
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, publisher.AuthMethod, publisher.Subject); err != nil {
+		return fmt.Errorf("failed to set server publisher: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// pullsExpression reads the publisher-reported pull count of a server version, or 0
+const pullsExpression = `COALESCE(CASE WHEN jsonb_typeof(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'pulls') = 'number'
+	THEN GREATEST((value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->>'pulls')::float8, 0) END, 0)`
+
+// GetSearchRanking retrieves the search ranking an admin set, overriding the configured one
+func (db *PostgreSQL) GetSearchRanking(ctx context.Context, tx pgx.Tx) (*apiv0.SearchRanking, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var settings []byte
+	var ranking apiv0.SearchRanking
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, `SELECT settings, updated_at FROM search_ranking`).Scan(&settings, &ranking.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get search ranking: %w", err)
+	}
+	if err := json.Unmarshal(settings, &ranking); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search ranking: %w", err)
+	}
+
+	return &ranking, nil
+}
+
+// SetSearchRanking stores the search ranking, overriding the configured one
+func (db *PostgreSQL) SetSearchRanking(ctx context.Context, tx pgx.Tx, ranking *apiv0.SearchRanking) (*apiv0.SearchRanking, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	stored := *ranking
+	stored.UpdatedAt = nil
+	settings, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search ranking: %w", err)
+	}
+
+	query := `
+		INSERT INTO search_ranking (settings) VALUES ($1)
+		ON CONFLICT (id) DO UPDATE SET settings = EXCLUDED.settings, updated_at = NOW()
+		RETURNING updated_at
+	`
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, settings).Scan(&stored.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to set search ranking: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// DeleteSearchRanking removes the search ranking an admin set, restoring the configured one
+func (db *PostgreSQL) DeleteSearchRanking(ctx context.Context, tx pgx.Tx) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM search_ranking`); err != nil {
+		return fmt.Errorf("failed to delete search ranking: %w", err)
+	}
+
+	return nil
+}
+
+// rankedSearch builds the condition matching a search query against the fields the ranking
+// gives weight to, and the score to order matches by, with the arguments to bind starting at
+// argIndex
+func rankedSearch(search string, ranking *apiv0.SearchRanking, argIndex int) (condition, score string, args []any) {
+	args = []any{"%" + search + "%"}
+	pattern := argIndex
+	param := func(value float64) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d::float8", argIndex+len(args)-1)
+	}
+
+	var matches, scores []string
+	for _, field := range []struct {
+		column string
+		weight float64
+	}{
+		{"server_name", ranking.NameWeight},
+		{"value->>'title'", ranking.TitleWeight},
+		{"value->>'description'", ranking.DescriptionWeight},
+	} {
+		if field.weight <= 0 {
+			continue
+		}
+		matches = append(matches, fmt.Sprintf("%s ILIKE $%d", field.column, pattern))
+		scores = append(scores, fmt.Sprintf("CASE WHEN %s ILIKE $%d THEN %s ELSE 0 END", field.column, pattern, param(field.weight)))
+	}
+	if len(matches) == 0 {
+		// Nothing is searched without a weighted field
+		return "FALSE", "0", args
+	}
+
+	if ranking.FreshnessBoost > 0 && ranking.FreshnessHalfLifeDays > 0 {
+		scores = append(scores, fmt.Sprintf("%s * power(0.5, EXTRACT(EPOCH FROM (NOW() - published_at)) / 86400 / %s)",
+			param(ranking.FreshnessBoost), param(ranking.FreshnessHalfLifeDays)))
+	}
+	if ranking.PopularityBoost > 0 {
+		// A million pulls earns the full boost
+		scores = append(scores, fmt.Sprintf("%s * LEAST(1, ln(1 + %s) / ln(1000001))", param(ranking.PopularityBoost), pullsExpression))
+	}
+	if ranking.VerifiedBoost > 0 {
+		scores = append(scores, fmt.Sprintf("CASE WHEN %s THEN %s ELSE 0 END", verifiedExpression, param(ranking.VerifiedBoost)))
+	}
+
+	return "(" + strings.Join(matches, " OR ") + ")", strings.Join(scores, " + "), args
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RenameServer moves every version of a server to a new name
+func (db *PostgreSQL) RenameServer(ctx context.Context, tx pgx.Tx, oldName, newName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE servers
+		SET server_name = $2, value = jsonb_set(value, '{name}', to_jsonb($2::text)), updated_at = NOW()
+		WHERE server_name = $1
+	`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, oldName, newName)
+	if err != nil {
+		return fmt.Errorf("failed to rename server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	// Reviews, advisories, update proposals, the signing key and the publisher are of the server rather than a
+	// version, so they can't cascade like channels do
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE server_reviews SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to move server reviews: %w", err)
+	}
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE server_advisories SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to move server advisories: %w", err)
+	}
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE server_update_proposals SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to move update proposals: %w", err)
+	}
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE server_update_policies SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to move update policy: %w", err)
+	}
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE server_signing_keys SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to move signing key: %w", err)
+	}
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE server_publishers SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to move server publisher: %w", err)
+	}
+
+	return nil
+}
+
+// SetServerRedirect records that a server moved, pointing earlier redirects to the server at
+// its new name too so that redirects never chain. Any redirect away from the new name is
+// dropped, since the name is taken again.
+func (db *PostgreSQL) SetServerRedirect(ctx context.Context, tx pgx.Tx, redirect *apiv0.ServerRedirect) (*apiv0.ServerRedirect, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+
+	if _, err := executor.Exec(ctx, `DELETE FROM server_redirects WHERE from_name = $1`, redirect.To); err != nil {
+		return nil, fmt.Errorf("failed to delete server redirect: %w", err)
+	}
+	if _, err := executor.Exec(ctx, `UPDATE server_redirects SET to_name = $2 WHERE to_name = $1`, redirect.From, redirect.To); err != nil {
+		return nil, fmt.Errorf("failed to update server redirects: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_redirects (from_name, to_name, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (from_name) DO UPDATE SET to_name = EXCLUDED.to_name, reason = EXCLUDED.reason, created_at = NOW()
+		RETURNING from_name, to_name, reason, created_at
+	`
+
+	var stored apiv0.ServerRedirect
+	err := executor.QueryRow(ctx, query, redirect.From, redirect.To, redirect.Reason).Scan(&stored.From, &stored.To, &stored.Reason, &stored.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set server redirect: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// GetServerRedirect retrieves where a server that moved can now be found
+func (db *PostgreSQL) GetServerRedirect(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerRedirect, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT from_name, to_name, reason, created_at FROM server_redirects WHERE from_name = $1`
+
+	var redirect apiv0.ServerRedirect
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName).Scan(&redirect.From, &redirect.To, &redirect.Reason, &redirect.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server redirect: %w", err)
+	}
+
+	return &redirect, nil
+}
+
+// ListServerRedirects lists the redirects pointing at a server
+func (db *PostgreSQL) ListServerRedirects(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.ServerRedirect, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT from_name, to_name, reason, created_at FROM server_redirects WHERE to_name = $1 ORDER BY created_at`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server redirects: %w", err)
+	}
+	defer rows.Close()
+
+	redirects := []apiv0.ServerRedirect{}
+	for rows.Next() {
+		var redirect apiv0.ServerRedirect
+		if err := rows.Scan(&redirect.From, &redirect.To, &redirect.Reason, &redirect.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server redirect: %w", err)
+		}
+		redirects = append(redirects, redirect)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return redirects, nil
+}
+
+// DeleteServerRedirect removes a redirect to a server
+func (db *PostgreSQL) DeleteServerRedirect(ctx context.Context, tx pgx.Tx, fromName, toName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM server_redirects WHERE from_name = $1 AND to_name = $2`, fromName, toName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server redirect: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ListRelationshipsTo lists the relationships the latest versions of other servers declare to a server
+func (db *PostgreSQL) ListRelationshipsTo(ctx context.Context, tx pgx.Tx, serverName string) ([]apiv0.ServerRelationshipEdge, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT s.server_name, rel->>'type'
+		FROM servers s, jsonb_array_elements(s.value->'relationships') rel
+		WHERE s.is_latest = true
+		  AND s.deleted_at IS NULL
+		  AND s.status <> $2
+		  AND s.value->'relationships' @> jsonb_build_array(jsonb_build_object('name', $1::text))
+		  AND rel->>'name' = $1
+		ORDER BY s.server_name, rel->>'type'
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName, string(model.StatusDeleted))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server relationships: %w", err)
+	}
+	defer rows.Close()
+
+	edges := []apiv0.ServerRelationshipEdge{}
+	for rows.Next() {
+		edge := apiv0.ServerRelationshipEdge{To: serverName}
+		if err := rows.Scan(&edge.From, &edge.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan server relationship: %w", err)
+		}
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return edges, nil
+}
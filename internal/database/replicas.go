@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaCheckTimeout bounds how long a health check waits for a replica to answer
+const replicaCheckTimeout = 2 * time.Second
+
+type (
+	replicaReadsKey struct{}
+	primaryReadsKey struct{}
+)
+
+// ReadFromReplica marks the reads made with a context outside transactions as safe to serve from
+// a read replica, for reads that can tolerate the lag of replicas behind the primary
+func ReadFromReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsKey{}, true)
+}
+
+// ReadFromPrimary makes the reads made with a context go to the primary even when they are also
+// marked with ReadFromReplica, for reads that must see a change that was just made
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// ReadsFromReplica reports whether reads made with a context may be served from a read replica
+func ReadsFromReplica(ctx context.Context) bool {
+	if primary, _ := ctx.Value(primaryReadsKey{}).(bool); primary {
+		return false
+	}
+	ok, _ := ctx.Value(replicaReadsKey{}).(bool)
+	return ok
+}
+
+// replica is a read replica's pool, and whether it answered its last health check
+type replica struct {
+	host    string
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// replicaSet spreads reads across the healthy read replicas in turn
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+// AddReadReplicas connects to read replicas that reads marked with ReadFromReplica are spread
+// across, checking every interval that they answer. Reads fall back to the primary while no replica
+// answers. Replicas that can't be reached yet are added anyway, to be used once they answer.
+func (db *PostgreSQL) AddReadReplicas(ctx context.Context, connectionURIs []string, checkInterval time.Duration) error {
+	if db.replicas != nil {
+		return fmt.Errorf("read replicas are already configured")
+	}
+	if checkInterval <= 0 {
+		return fmt.Errorf("invalid replica health check interval %s", checkInterval)
+	}
+
+	set := &replicaSet{stop: make(chan struct{})}
+	for _, uri := range connectionURIs {
+		config, err := pgxpool.ParseConfig(uri)
+		if err != nil {
+			set.close()
+			return fmt.Errorf("failed to parse read replica config: %w", err)
+		}
+		config.MaxConns = 30
+		config.MaxConnIdleTime = 30 * time.Minute
+		config.MaxConnLifetime = 2 * time.Hour
+
+		pool, err := pgxpool.NewWithConfig(ctx, config)
+		if err != nil {
+			set.close()
+			return fmt.Errorf("failed to create read replica pool for %s: %w", config.ConnConfig.Host, err)
+		}
+		set.replicas = append(set.replicas, &replica{host: config.ConnConfig.Host, pool: pool})
+	}
+	set.check(ctx)
+
+	set.done.Add(1)
+	go set.run(checkInterval)
+	db.replicas = set
+	return nil
+}
+
+// reader returns the pool of the next healthy read replica, or nil when none is healthy
+func (s *replicaSet) reader() *pgxpool.Pool {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := range n {
+		if r := s.replicas[(start+i)%n]; r.healthy.Load() {
+			return r.pool
+		}
+	}
+	return nil
+}
+
+// run checks the replicas every interval until the set is closed
+func (s *replicaSet) run(interval time.Duration) {
+	defer s.done.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.check(context.Background())
+		}
+	}
+}
+
+// check pings every replica, logging the ones that stopped or started answering
+func (s *replicaSet) check(ctx context.Context) {
+	for _, r := range s.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+		err := r.pool.Ping(pingCtx)
+		cancel()
+
+		wasHealthy := r.healthy.Swap(err == nil)
+		switch {
+		case err != nil && wasHealthy:
+			log.Printf("Read replica %s is unavailable, reading from the primary instead: %v", r.host, err)
+		case err == nil && !wasHealthy:
+			log.Printf("Read replica %s is available", r.host)
+		}
+	}
+}
+
+// close stops the health checks and closes the replicas' pools
+func (s *replicaSet) close() {
+	close(s.stop)
+	s.done.Wait()
+	for _, r := range s.replicas {
+		r.pool.Close()
+	}
+}
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const serverReviewColumns = `
+	id::text, server_name, auth_method, subject, rating, review, hidden, created_at, updated_at
+`
+
+// ratingScoreExpression orders servers by rating. It is a Bayesian average that starts every
+// server off with five ratings of 3, so that a single 5-star rating doesn't outrank many good
+// ones. Hidden reviews don't count.
+const ratingScoreExpression = `(SELECT (COALESCE(SUM(r.rating), 0) + 15)::float8 / (COUNT(*) + 5)
+	FROM server_reviews r WHERE r.server_name = servers.server_name AND NOT r.hidden)`
+
+// UpsertServerReview stores a principal's review of a server, replacing their earlier one. A
+// review an admin hid stays hidden.
+func (db *PostgreSQL) UpsertServerReview(ctx context.Context, tx pgx.Tx, review *apiv0.ServerReview) (*apiv0.ServerReview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_reviews (server_name, auth_method, subject, rating, review)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (server_name, auth_method, subject)
+		DO UPDATE SET rating = EXCLUDED.rating, review = EXCLUDED.review, updated_at = NOW()
+		RETURNING ` + serverReviewColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, review.ServerName, review.Reviewer.AuthMethod, review.Reviewer.Subject,
+		review.Rating, review.Review)
+	stored, err := scanServerReview(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert server review: %w", err)
+	}
+
+	return stored, nil
+}
+
+// GetServerReview retrieves a principal's review of a server, even if hidden
+func (db *PostgreSQL) GetServerReview(ctx context.Context, tx pgx.Tx, serverName string, reviewer apiv0.Principal) (*apiv0.ServerReview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + serverReviewColumns + ` FROM server_reviews WHERE server_name = $1 AND auth_method = $2 AND subject = $3`
+
+	review, err := scanServerReview(db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, reviewer.AuthMethod, reviewer.Subject))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server review: %w", err)
+	}
+
+	return review, nil
+}
+
+// ListServerReviews lists the reviews of a server that aren't hidden, most recently updated first
+func (db *PostgreSQL) ListServerReviews(ctx context.Context, tx pgx.Tx, serverName string, offset, limit int) ([]apiv0.ServerReview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + serverReviewColumns + `
+		FROM server_reviews
+		WHERE server_name = $1 AND NOT hidden
+		ORDER BY updated_at DESC, id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, serverName, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []apiv0.ServerReview{}
+	for rows.Next() {
+		review, err := scanServerReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server review: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// GetServerRating aggregates the ratings of a server that aren't hidden
+func (db *PostgreSQL) GetServerRating(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerRating, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT COALESCE(AVG(rating), 0)::float8, COUNT(*) FROM server_reviews WHERE server_name = $1 AND NOT hidden`
+
+	var rating apiv0.ServerRating
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName).Scan(&rating.Average, &rating.Count); err != nil {
+		return nil, fmt.Errorf("failed to get server rating: %w", err)
+	}
+
+	return &rating, nil
+}
+
+// CountServerReviewsSince counts the reviews a principal left since a time, not counting updates
+// to earlier reviews
+func (db *PostgreSQL) CountServerReviewsSince(ctx context.Context, tx pgx.Tx, reviewer apiv0.Principal, since time.Time) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	query := `SELECT COUNT(*) FROM server_reviews WHERE auth_method = $1 AND subject = $2 AND created_at > $3`
+
+	var count int
+	if err := db.getExecutor(ctx, tx).QueryRow(ctx, query, reviewer.AuthMethod, reviewer.Subject, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count server reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteServerReview deletes a principal's review of a server
+func (db *PostgreSQL) DeleteServerReview(ctx context.Context, tx pgx.Tx, serverName string, reviewer apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM server_reviews WHERE server_name = $1 AND auth_method = $2 AND subject = $3`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, reviewer.AuthMethod, reviewer.Subject)
+	if err != nil {
+		return fmt.Errorf("failed to delete server review: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetServerReviewHidden hides a review, or shows a hidden one again
+func (db *PostgreSQL) SetServerReviewHidden(ctx context.Context, tx pgx.Tx, id string, hidden bool) (*apiv0.ServerReview, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `UPDATE server_reviews SET hidden = $2 WHERE id = $1 RETURNING ` + serverReviewColumns
+
+	review, err := scanServerReview(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, hidden))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set server review hidden: %w", err)
+	}
+
+	return review, nil
+}
+
+func scanServerReview(row pgx.Row) (*apiv0.ServerReview, error) {
+	var review apiv0.ServerReview
+	if err := row.Scan(&review.ID, &review.ServerName, &review.Reviewer.AuthMethod, &review.Reviewer.Subject, &review.Rating,
+		&review.Review, &review.Hidden, &review.CreatedAt, &review.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// vulnerabilitySeverities lists the severity columns of vulnerability scans, least severe first
+var vulnerabilitySeverities = []string{"low", "moderate", "high", "critical"}
+
+// vulnerabilitiesExpression sums the vulnerability scans of the OCI images of a server version
+// into a JSON summary, or NULL when none has been scanned
+const vulnerabilitiesExpression = `(SELECT jsonb_build_object(
+		'critical', SUM(vs.critical), 'high', SUM(vs.high), 'moderate', SUM(vs.moderate), 'low', SUM(vs.low),
+		'scannedAt', MIN(vs.scanned_at))
+	FROM server_version_scans vs
+	WHERE vs.server_name = servers.server_name AND vs.version = servers.version
+	HAVING COUNT(*) > 0)`
+
+// SetPackageScan records the vulnerabilities found by scanning an OCI image of a server version,
+// replacing the image's earlier scan
+func (db *PostgreSQL) SetPackageScan(ctx context.Context, tx pgx.Tx, serverName, version, identifier string, scan *apiv0.VulnerabilityScan) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_version_scans (server_name, version, package_identifier, critical, high, moderate, low, scanned_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (server_name, version, package_identifier)
+		DO UPDATE SET critical = $4, high = $5, moderate = $6, low = $7, scanned_at = $8`
+
+	_, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, version, identifier,
+		scan.Critical, scan.High, scan.Moderate, scan.Low, scan.ScannedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set package scan: %w", err)
+	}
+
+	return nil
+}
+
+// maxSeverityCondition builds the condition keeping server versions none of whose scanned images
+// have vulnerabilities more severe than the given one, or an empty string when every version
+// qualifies. Versions that haven't been scanned are kept.
+func maxSeverityCondition(severity string) string {
+	var found []string
+	for _, column := range vulnerabilitySeverities[slices.Index(vulnerabilitySeverities, severity)+1:] {
+		found = append(found, "vs."+column+" > 0")
+	}
+	if len(found) == 0 {
+		return ""
+	}
+	return `NOT EXISTS (SELECT 1 FROM server_version_scans vs
+		WHERE vs.server_name = servers.server_name AND vs.version = servers.version AND (` + strings.Join(found, " OR ") + `))`
+}
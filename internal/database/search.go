@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// escapedDescriptionExpression HTML-escapes a server's description, so that its highlight can be
+// rendered as HTML with only the <mark> tags ts_headline adds
+const escapedDescriptionExpression = `replace(replace(replace(COALESCE(value->>'description', ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;')`
+
+// SearchServers matches a web-search style query ("quoted phrases", -exclusions, or) against the
+// search vector of the latest version of every server that isn't yanked or quarantined, most
+// relevant first. Results page by offset, like other ranked lists.
+func (db *PostgreSQL) SearchServers(ctx context.Context, tx pgx.Tx, query, cursor string, limit int) ([]apiv0.ServerSearchResult, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	offset := 0
+	if cursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(cursor); err != nil || offset < 0 {
+			return nil, "", fmt.Errorf("%w: invalid cursor for a search", ErrInvalidInput)
+		}
+	}
+
+	// Normalization 32 maps ranks into [0, 1) as rank / (rank + 1)
+	sqlQuery := `
+		SELECT server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value, ` + serverFlagColumns + `,
+			ts_rank_cd(search_vector, q, 32) AS search_rank,
+			ts_headline('english', ` + escapedDescriptionExpression + `, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')
+		FROM servers, websearch_to_tsquery('english', $1) AS q
+		WHERE search_vector @@ q AND is_latest AND yanked_at IS NULL AND deleted_at IS NULL AND NOT ` + quarantinedExpression + `
+		ORDER BY search_rank DESC, server_name, version
+		LIMIT $2 OFFSET $3`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, sqlQuery, query, limit, offset)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to search servers: %w", err)
+	}
+	defer rows.Close()
+
+	results := []apiv0.ServerSearchResult{}
+	for rows.Next() {
+		var serverName, version, status string
+		var publishedAt, updatedAt time.Time
+		var isLatest bool
+		var yankedAt *time.Time
+		var revision int
+		var valueJSON []byte
+		var verified bool
+		var advisory string
+		var vulnerabilities *apiv0.VulnerabilityScan
+		var images []apiv0.OCIImage
+		var capabilities *apiv0.Capabilities
+		var statusDetails *apiv0.StatusDetails
+		var quarantine *apiv0.Quarantine
+		var usage *apiv0.Usage
+		var result apiv0.ServerSearchResult
+
+		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &yankedAt, &revision, &valueJSON,
+			&verified, &advisory, &vulnerabilities, &images, &capabilities, &statusDetails, &quarantine, &usage, &result.Rank, &result.Highlight)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if err := json.Unmarshal(valueJSON, &result.Server); err != nil {
+			return nil, "", fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		result.Meta = apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				Revision:        revision,
+				IsLatest:        isLatest,
+				YankedAt:        yankedAt,
+				Verified:        verified,
+				Advisory:        advisory,
+				Vulnerabilities: vulnerabilities,
+				Images:          images,
+				Capabilities:    capabilities,
+				StatusDetails:   statusDetails,
+				Quarantine:      quarantine,
+				Usage:           usage,
+			},
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	nextCursor := ""
+	if len(results) >= limit {
+		nextCursor = strconv.Itoa(offset + len(results))
+	}
+	return results, nextCursor, nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetSigningKey retrieves the public key a server's versions must be signed with
+func (db *PostgreSQL) GetSigningKey(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.SigningKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var key apiv0.SigningKey
+	err := db.getExecutor(ctx, tx).QueryRow(ctx,
+		`SELECT format, public_key, key_id, updated_at FROM server_signing_keys WHERE server_name = $1`, serverName).
+		Scan(&key.Format, &key.PublicKey, &key.KeyID, &key.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// SetSigningKey sets the public key a server's versions must be signed with, replacing any other
+func (db *PostgreSQL) SetSigningKey(ctx context.Context, tx pgx.Tx, serverName string, key *apiv0.SigningKey) (*apiv0.SigningKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_signing_keys (server_name, format, public_key, key_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (server_name) DO UPDATE SET format = $2, public_key = $3, key_id = $4, updated_at = NOW()
+		RETURNING format, public_key, key_id, updated_at
+	`
+
+	var stored apiv0.SigningKey
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, key.Format, key.PublicKey, key.KeyID).
+		Scan(&stored.Format, &stored.PublicKey, &stored.KeyID, &stored.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set signing key: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// DeleteSigningKey deletes a server's signing key, leaving the signatures it verified
+func (db *PostgreSQL) DeleteSigningKey(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM server_signing_keys WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete signing key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SetServerSignature stores the signature of a server version, replacing any other
+func (db *PostgreSQL) SetServerSignature(ctx context.Context, tx pgx.Tx, signature *apiv0.ServerSignature) (*apiv0.ServerSignature, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_signatures (server_name, version, format, signature, digest, key_id, verified)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		ON CONFLICT (server_name, version) DO UPDATE
+		SET format = $3, signature = $4, digest = $5, key_id = NULLIF($6, ''), verified = $7, created_at = NOW()
+		RETURNING ` + serverSignatureColumns
+
+	stored, err := scanServerSignature(db.getExecutor(ctx, tx).QueryRow(ctx, query, signature.ServerName, signature.Version,
+		signature.Format, signature.Signature, signature.Digest, signature.KeyID, signature.Verified))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set server signature: %w", err)
+	}
+
+	return stored, nil
+}
+
+// GetServerSignature retrieves the signature of a server version
+func (db *PostgreSQL) GetServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.ServerSignature, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + serverSignatureColumns + ` FROM server_signatures WHERE server_name = $1 AND version = $2`
+	signature, err := scanServerSignature(db.getExecutor(ctx, tx).QueryRow(ctx, query, serverName, version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server signature: %w", err)
+	}
+
+	return signature, nil
+}
+
+// DeleteServerSignature deletes the signature of a server version, if it has one
+func (db *PostgreSQL) DeleteServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx,
+		`DELETE FROM server_signatures WHERE server_name = $1 AND version = $2`, serverName, version); err != nil {
+		return fmt.Errorf("failed to delete server signature: %w", err)
+	}
+
+	return nil
+}
+
+const serverSignatureColumns = `server_name, version, format, signature, digest, COALESCE(key_id, ''), verified, created_at`
+
+func scanServerSignature(row pgx.Row) (*apiv0.ServerSignature, error) {
+	var signature apiv0.ServerSignature
+	err := row.Scan(&signature.ServerName, &signature.Version, &signature.Format, &signature.Signature,
+		&signature.Digest, &signature.KeyID, &signature.Verified, &signature.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &signature, nil
+}
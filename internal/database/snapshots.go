@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Snapshot is a copy of every server version at a point in time, which list reads can page
+// through instead of the live table
+type Snapshot struct {
+	ID        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// serversTable returns the table list reads select from: the servers table, or a snapshot of it
+// aliased as servers, so that conditions and expressions referring to servers work on both
+func serversTable(filter *ServerFilter) string {
+	if filter != nil && filter.Snapshot != nil {
+		return "registry_snapshot_servers AS servers"
+	}
+	return "servers"
+}
+
+// CreateSnapshot copies every server version into a new snapshot, deleting expired snapshots
+func (db *PostgreSQL) CreateSnapshot(ctx context.Context, tx pgx.Tx, expiresAt time.Time) (*Snapshot, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+	if _, err := executor.Exec(ctx, `DELETE FROM registry_snapshots WHERE expires_at <= NOW()`); err != nil {
+		return nil, fmt.Errorf("failed to delete expired snapshots: %w", err)
+	}
+
+	var snapshot Snapshot
+	err := executor.QueryRow(ctx, `
+		INSERT INTO registry_snapshots (expires_at) VALUES ($1)
+		RETURNING id, created_at, expires_at`, expiresAt,
+	).Scan(&snapshot.ID, &snapshot.CreatedAt, &snapshot.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	_, err = executor.Exec(ctx, `
+		INSERT INTO registry_snapshot_servers (snapshot_id, server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value)
+		SELECT $1, server_name, version, status, published_at, updated_at, is_latest, yanked_at, revision, value
+		FROM servers
+		WHERE deleted_at IS NULL`, snapshot.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy servers into snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetSnapshot retrieves a snapshot by ID
+func (db *PostgreSQL) GetSnapshot(ctx context.Context, tx pgx.Tx, id string) (*Snapshot, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var snapshot Snapshot
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, `
+		SELECT id, created_at, expires_at FROM registry_snapshots WHERE id = $1`, id,
+	).Scan(&snapshot.ID, &snapshot.CreatedAt, &snapshot.ExpiresAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetNewestSnapshot retrieves the most recently created snapshot
+func (db *PostgreSQL) GetNewestSnapshot(ctx context.Context, tx pgx.Tx) (*Snapshot, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var snapshot Snapshot
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, `
+		SELECT id, created_at, expires_at FROM registry_snapshots ORDER BY created_at DESC LIMIT 1`,
+	).Scan(&snapshot.ID, &snapshot.CreatedAt, &snapshot.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get newest snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// utcToday is the current day in UTC, which daily statistics are kept by
+const utcToday = `(NOW() AT TIME ZONE 'UTC')::date`
+
+// listedServerCondition keeps the latest versions that listings show: not yanked, deleted (by status
+// or by an admin) or quarantined
+const listedServerCondition = `is_latest AND yanked_at IS NULL AND status <> 'deleted' AND deleted_at IS NULL AND NOT ` + quarantinedExpression
+
+// usageExpression reads how much a server was used over the last 30 complete days, or NULL when it
+// wasn't. Today is left out so that the usage, and the ETags of responses showing it, only change
+// once a day.
+const usageExpression = `(SELECT jsonb_build_object('requests', SUM(sds.requests), 'installs', SUM(sds.installs))
+	FROM server_daily_stats sds
+	WHERE sds.server_name = servers.server_name AND sds.day < ` + utcToday + ` AND sds.day >= ` + utcToday + ` - 30
+	HAVING SUM(sds.requests) + SUM(sds.installs) > 0)`
+
+// ServerUsage is how much a server was used over a period: the requests for its details, and
+// how much the pull count its publisher reports grew
+type ServerUsage struct {
+	ServerName string
+	Requests   int64
+	Pulls      int64
+}
+
+// RecordServerUsage counts requests for a server's details and installs of it towards today's statistics
+func (db *PostgreSQL) RecordServerUsage(ctx context.Context, tx pgx.Tx, serverName string, requests, installs int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_daily_stats (server_name, day, requests, installs)
+		VALUES ($1, ` + utcToday + `, $2, $3)
+		ON CONFLICT (server_name, day) DO UPDATE SET
+			requests = server_daily_stats.requests + EXCLUDED.requests,
+			installs = server_daily_stats.installs + EXCLUDED.installs
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName, requests, installs); err != nil {
+		return fmt.Errorf("failed to record server usage: %w", err)
+	}
+
+	return nil
+}
+
+// RecordServerPulls snapshots the pull count the latest version of a server reports into today's
+// statistics. Servers that don't report one are left alone.
+func (db *PostgreSQL) RecordServerPulls(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_daily_stats (server_name, day, first_pulls, pulls)
+		SELECT server_name, ` + utcToday + `, (` + pullsExpression + `)::BIGINT, (` + pullsExpression + `)::BIGINT
+		FROM servers
+		WHERE server_name = $1 AND is_latest
+			AND jsonb_typeof(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'pulls') = 'number'
+		ON CONFLICT (server_name, day) DO UPDATE SET
+			first_pulls = COALESCE(server_daily_stats.first_pulls, EXCLUDED.first_pulls),
+			pulls = EXCLUDED.pulls
+	`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, serverName); err != nil {
+		return fmt.Errorf("failed to record server pulls: %w", err)
+	}
+
+	return nil
+}
+
+// GetRegistryStats counts the servers listings show, by the registries of their packages and the
+// transports of their packages and remotes, and the versions published on each of the last days
+func (db *PostgreSQL) GetRegistryStats(ctx context.Context, tx pgx.Tx, days int) (*apiv0.RegistryStats, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+	stats := &apiv0.RegistryStats{
+		ByRegistryType:  map[string]int{},
+		ByTransport:     map[string]int{},
+		PublishesPerDay: []apiv0.DailyCount{},
+	}
+
+	if err := executor.QueryRow(ctx, `SELECT COUNT(*) FROM servers WHERE `+listedServerCondition).Scan(&stats.TotalServers); err != nil {
+		return nil, fmt.Errorf("failed to count servers: %w", err)
+	}
+
+	registryTypeQuery := `
+		SELECT pkg->>'registryType', COUNT(DISTINCT server_name)
+		FROM servers, jsonb_array_elements(COALESCE(value->'packages', '[]'::jsonb)) AS pkg
+		WHERE ` + listedServerCondition + ` AND pkg->>'registryType' IS NOT NULL
+		GROUP BY 1
+	`
+	if err := scanCounts(ctx, executor, registryTypeQuery, stats.ByRegistryType); err != nil {
+		return nil, fmt.Errorf("failed to count servers by registry type: %w", err)
+	}
+
+	transportQuery := `
+		SELECT transport, COUNT(DISTINCT server_name)
+		FROM (
+			SELECT server_name, pkg->'transport'->>'type' AS transport
+			FROM servers, jsonb_array_elements(COALESCE(value->'packages', '[]'::jsonb)) AS pkg
+			WHERE ` + listedServerCondition + `
+			UNION ALL
+			SELECT server_name, remote->>'type'
+			FROM servers, jsonb_array_elements(COALESCE(value->'remotes', '[]'::jsonb)) AS remote
+			WHERE ` + listedServerCondition + `
+		) AS transports
+		WHERE transport IS NOT NULL
+		GROUP BY transport
+	`
+	if err := scanCounts(ctx, executor, transportQuery, stats.ByTransport); err != nil {
+		return nil, fmt.Errorf("failed to count servers by transport: %w", err)
+	}
+
+	publishesQuery := `
+		SELECT day::date::text, COUNT(servers.server_name)
+		FROM generate_series(` + utcToday + ` - ($1::int - 1), ` + utcToday + `, interval '1 day') AS day
+		LEFT JOIN servers ON (published_at AT TIME ZONE 'UTC')::date = day::date
+		GROUP BY day
+		ORDER BY day
+	`
+	rows, err := executor.Query(ctx, publishesQuery, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count publishes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count apiv0.DailyCount
+		if err := rows.Scan(&count.Date, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan publish count: %w", err)
+		}
+		stats.PublishesPerDay = append(stats.PublishesPerDay, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating publish counts: %w", err)
+	}
+
+	return stats, nil
+}
+
+// scanCounts reads the rows of a query grouping counts by a key into counts
+func scanCounts(ctx context.Context, executor Executor, query string, counts map[string]int) error {
+	rows, err := executor.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		counts[key] = count
+	}
+	return rows.Err()
+}
+
+// ListTrendingServers ranks the active servers listings show by their usage over the last days,
+// most used first. Pull growth is counted from the pulls reported at the end of the latest day
+// before the period, or from the first pulls reported for servers that started reporting during it.
+func (db *PostgreSQL) ListTrendingServers(ctx context.Context, tx pgx.Tx, days, limit int) ([]ServerUsage, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		WITH requests AS (
+			SELECT server_name, SUM(requests) AS requests
+			FROM server_daily_stats
+			WHERE day > ` + utcToday + ` - $1::int
+			GROUP BY server_name
+		), baselines AS (
+			SELECT DISTINCT ON (server_name) server_name,
+				CASE WHEN day <= ` + utcToday + ` - $1::int THEN pulls ELSE first_pulls END AS pulls
+			FROM server_daily_stats
+			WHERE pulls IS NOT NULL
+			ORDER BY server_name, day <= ` + utcToday + ` - $1::int DESC,
+				CASE WHEN day <= ` + utcToday + ` - $1::int THEN day END DESC, day
+		), usage AS (
+			SELECT servers.server_name, COALESCE(requests.requests, 0)::BIGINT AS requests,
+				GREATEST(` + pullsExpression + ` - COALESCE(baselines.pulls, ` + pullsExpression + `), 0)::BIGINT AS pulls
+			FROM servers
+			LEFT JOIN requests ON requests.server_name = servers.server_name
+			LEFT JOIN baselines ON baselines.server_name = servers.server_name
+			WHERE ` + listedServerCondition + ` AND status = 'active'
+		)
+		SELECT server_name, requests, pulls
+		FROM usage
+		WHERE requests + pulls > 0
+		ORDER BY requests + pulls DESC, server_name
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, days, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trending servers: %w", err)
+	}
+	defer rows.Close()
+
+	usages := []ServerUsage{}
+	for rows.Next() {
+		var usage ServerUsage
+		if err := rows.Scan(&usage.ServerName, &usage.Requests, &usage.Pulls); err != nil {
+			return nil, fmt.Errorf("failed to scan server usage: %w", err)
+		}
+		usages = append(usages, usage)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server usage: %w", err)
+	}
+
+	return usages, nil
+}
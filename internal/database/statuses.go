@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// statusDetailsExpression reads why a server version's status last changed, or NULL when its
+// publisher didn't say
+const statusDetailsExpression = `(SELECT jsonb_strip_nulls(jsonb_build_object(
+		'reason', sd.reason, 'replacedBy', sd.replaced_by, 'changedAt', sd.changed_at))
+	FROM server_status_details sd
+	WHERE sd.server_name = servers.server_name AND sd.version = servers.version)`
+
+// SetServerStatusDetails records why a server version's status changed, replacing the details of
+// its previous change. Nil details remove them.
+func (db *PostgreSQL) SetServerStatusDetails(ctx context.Context, tx pgx.Tx, serverName, version string, details *apiv0.StatusDetails) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+	if details == nil {
+		if _, err := executor.Exec(ctx, `DELETE FROM server_status_details WHERE server_name = $1 AND version = $2`, serverName, version); err != nil {
+			return fmt.Errorf("failed to delete server status details: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO server_status_details (server_name, version, reason, replaced_by)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+		ON CONFLICT (server_name, version) DO UPDATE
+		SET reason = EXCLUDED.reason, replaced_by = EXCLUDED.replaced_by, changed_at = NOW()`
+
+	if _, err := executor.Exec(ctx, query, serverName, version, details.Reason, details.ReplacedBy); err != nil {
+		return fmt.Errorf("failed to set server status details: %w", err)
+	}
+
+	return nil
+}
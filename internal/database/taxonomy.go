@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// taxonomies are the join tables of server versions' categories and tags, with their term column
+// and the publisher-provided key they are read from
+var taxonomies = []struct {
+	table, column, key string
+}{
+	{table: "server_version_categories", column: "category", key: "categories"},
+	{table: "server_version_tags", column: "tag", key: "tags"},
+}
+
+// NormalizeTaxonomyTerm returns the form categories and tags are stored and matched in
+func NormalizeTaxonomyTerm(term string) string {
+	return strings.ToLower(strings.TrimSpace(term))
+}
+
+// taxonomyCondition builds the condition keeping server versions with a category or tag
+func taxonomyCondition(table, column string, argIndex int) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s t WHERE t.server_name = servers.server_name AND t.version = servers.version AND t.%s = $%d)", table, column, argIndex)
+}
+
+// SetServerTaxonomy replaces the categories and tags recorded for a server version with those its
+// publisher-provided metadata lists
+func (db *PostgreSQL) SetServerTaxonomy(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(ctx, tx)
+	for _, taxonomy := range taxonomies {
+		deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE server_name = $1 AND version = $2`, taxonomy.table)
+		if _, err := executor.Exec(ctx, deleteQuery, serverName, version); err != nil {
+			return fmt.Errorf("failed to clear server %s: %w", taxonomy.key, err)
+		}
+
+		insertQuery := fmt.Sprintf(`
+			INSERT INTO %s (server_name, version, %s)
+			SELECT DISTINCT server_name, version, lower(btrim(term #>> '{}'))
+			FROM servers, jsonb_array_elements(CASE
+				WHEN jsonb_typeof(value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'%s') = 'array'
+				THEN value->'_meta'->'io.modelcontextprotocol.registry/publisher-provided'->'%s' ELSE '[]'::jsonb END) AS term
+			WHERE server_name = $1 AND version = $2
+				AND jsonb_typeof(term) = 'string' AND length(btrim(term #>> '{}')) BETWEEN 1 AND 100
+		`, taxonomy.table, taxonomy.column, taxonomy.key, taxonomy.key)
+		if _, err := executor.Exec(ctx, insertQuery, serverName, version); err != nil {
+			return fmt.Errorf("failed to record server %s: %w", taxonomy.key, err)
+		}
+	}
+
+	return nil
+}
+
+// ListCategories lists the categories of the servers listings show, with how many servers have each
+func (db *PostgreSQL) ListCategories(ctx context.Context, tx pgx.Tx) ([]apiv0.TaxonomyTerm, error) {
+	return db.listTaxonomyTerms(ctx, tx, "server_version_categories", "category")
+}
+
+// ListTags lists the tags of the servers listings show, with how many servers have each
+func (db *PostgreSQL) ListTags(ctx context.Context, tx pgx.Tx) ([]apiv0.TaxonomyTerm, error) {
+	return db.listTaxonomyTerms(ctx, tx, "server_version_tags", "tag")
+}
+
+// listTaxonomyTerms counts the servers whose latest listed version has each term of a taxonomy,
+// most common first
+func (db *PostgreSQL) listTaxonomyTerms(ctx context.Context, tx pgx.Tx, table, column string) ([]apiv0.TaxonomyTerm, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.%s, COUNT(*)
+		FROM %s t
+		JOIN servers ON servers.server_name = t.server_name AND servers.version = t.version
+		WHERE `+listedServerCondition+`
+		GROUP BY t.%s
+		ORDER BY COUNT(*) DESC, t.%s
+	`, column, table, column, column)
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count servers by %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	terms := []apiv0.TaxonomyTerm{}
+	for rows.Next() {
+		var term apiv0.TaxonomyTerm
+		if err := rows.Scan(&term.Name, &term.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", column, err)
+		}
+		terms = append(terms, term)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return terms, nil
+}
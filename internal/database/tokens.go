@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const personalAccessTokenColumns = `
+	id::text, name, token_prefix, auth_method, subject, permissions,
+	created_at, expires_at, last_used_at, revoked_at, rotated_at
+`
+
+// CreatePersonalAccessToken stores a new personal access token under the hash of its secret
+func (db *PostgreSQL) CreatePersonalAccessToken(ctx context.Context, tx pgx.Tx, token *apiv0.PersonalAccessToken, tokenHash string) (*apiv0.PersonalAccessToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	permissionsJSON, err := json.Marshal(token.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token permissions: %w", err)
+	}
+
+	query := `
+		INSERT INTO personal_access_tokens (name, token_prefix, token_hash, auth_method, subject, permissions, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + personalAccessTokenColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, token.Name, token.Prefix, tokenHash,
+		token.Owner.AuthMethod, token.Owner.Subject, permissionsJSON, token.ExpiresAt)
+	created, err := scanPersonalAccessToken(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert personal access token: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetPersonalAccessTokenByHash retrieves a personal access token by the hash of its secret
+func (db *PostgreSQL) GetPersonalAccessTokenByHash(ctx context.Context, tx pgx.Tx, tokenHash string) (*apiv0.PersonalAccessToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE token_hash = $1`
+
+	token, err := scanPersonalAccessToken(db.getExecutor(ctx, tx).QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetPersonalAccessToken retrieves one of a principal's personal access tokens by ID
+func (db *PostgreSQL) GetPersonalAccessToken(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + personalAccessTokenColumns + ` FROM personal_access_tokens WHERE id = $1 AND auth_method = $2 AND subject = $3`
+
+	token, err := scanPersonalAccessToken(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, owner.AuthMethod, owner.Subject))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ListPersonalAccessTokens lists the personal access tokens of a principal, newest first
+func (db *PostgreSQL) ListPersonalAccessTokens(ctx context.Context, tx pgx.Tx, owner apiv0.Principal) ([]apiv0.PersonalAccessToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + personalAccessTokenColumns + `
+		FROM personal_access_tokens
+		WHERE auth_method = $1 AND subject = $2
+		ORDER BY created_at DESC, id
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, owner.AuthMethod, owner.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query personal access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []apiv0.PersonalAccessToken{}
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan personal access token: %w", err)
+		}
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// RevokePersonalAccessToken revokes one of a principal's tokens. Revoking a token twice keeps
+// the time of the first revocation.
+func (db *PostgreSQL) RevokePersonalAccessToken(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE personal_access_tokens
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1 AND auth_method = $2 AND subject = $3
+		RETURNING ` + personalAccessTokenColumns
+
+	token, err := scanPersonalAccessToken(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, owner.AuthMethod, owner.Subject))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// RotatePersonalAccessToken replaces the secret of one of a principal's tokens that hasn't been
+// revoked, setting its new expiry. It returns ErrNotFound for unknown and revoked tokens.
+func (db *PostgreSQL) RotatePersonalAccessToken(
+	ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal, prefix, tokenHash string, expiresAt time.Time,
+) (*apiv0.PersonalAccessToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE personal_access_tokens
+		SET token_prefix = $4, token_hash = $5, expires_at = $6, rotated_at = NOW(), last_used_at = NULL
+		WHERE id = $1 AND auth_method = $2 AND subject = $3 AND revoked_at IS NULL
+		RETURNING ` + personalAccessTokenColumns
+
+	token, err := scanPersonalAccessToken(db.getExecutor(ctx, tx).QueryRow(ctx, query, id, owner.AuthMethod, owner.Subject, prefix, tokenHash, expiresAt))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rotate personal access token: %w", err)
+	}
+
+	return token, nil
+}
+
+// TouchPersonalAccessToken records that a personal access token was just used
+func (db *PostgreSQL) TouchPersonalAccessToken(ctx context.Context, tx pgx.Tx, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, `UPDATE personal_access_tokens SET last_used_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to update personal access token: %w", err)
+	}
+
+	return nil
+}
+
+func scanPersonalAccessToken(row pgx.Row) (*apiv0.PersonalAccessToken, error) {
+	var token apiv0.PersonalAccessToken
+	var permissionsJSON []byte
+	if err := row.Scan(&token.ID, &token.Name, &token.Prefix, &token.Owner.AuthMethod, &token.Owner.Subject, &permissionsJSON,
+		&token.CreatedAt, &token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.RotatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(permissionsJSON, &token.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token permissions: %w", err)
+	}
+	return &token, nil
+}
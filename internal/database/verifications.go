@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// verifiedExpression tells whether the namespace of a server is verified
+const verifiedExpression = `EXISTS (SELECT 1 FROM namespace_verifications nv WHERE nv.namespace = split_part(servers.server_name, '/', 1))`
+
+// GetNamespaceVerification retrieves how a namespace was verified
+func (db *PostgreSQL) GetNamespaceVerification(ctx context.Context, tx pgx.Tx, namespace string) (*apiv0.NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT namespace, method, auth_method, subject, verified_at FROM namespace_verifications WHERE namespace = $1`
+
+	var verification apiv0.NamespaceVerification
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, namespace).Scan(&verification.Namespace, &verification.Method,
+		&verification.VerifiedBy.AuthMethod, &verification.VerifiedBy.Subject, &verification.VerifiedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace verification: %w", err)
+	}
+
+	return &verification, nil
+}
+
+// SetNamespaceVerification records that a namespace is verified, replacing an earlier verification
+func (db *PostgreSQL) SetNamespaceVerification(ctx context.Context, tx pgx.Tx, verification *apiv0.NamespaceVerification) (*apiv0.NamespaceVerification, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_verifications (namespace, method, auth_method, subject)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (namespace) DO UPDATE SET method = EXCLUDED.method, auth_method = EXCLUDED.auth_method,
+			subject = EXCLUDED.subject, verified_at = NOW()
+		RETURNING verified_at
+	`
+
+	stored := *verification
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, verification.Namespace, verification.Method,
+		verification.VerifiedBy.AuthMethod, verification.VerifiedBy.Subject).Scan(&stored.VerifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set namespace verification: %w", err)
+	}
+
+	return &stored, nil
+}
+
+// DeleteNamespaceVerification revokes the verification of a namespace
+func (db *PostgreSQL) DeleteNamespaceVerification(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, `DELETE FROM namespace_verifications WHERE namespace = $1`, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace verification: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const webhookColumns = `
+	id::text, auth_method, subject, url, secret, events, created_at, consecutive_failures, last_delivery_at, COALESCE(last_error, '')
+`
+
+const webhookDeliveryColumns = `
+	id::text, webhook_id::text, event, status, attempts, COALESCE(response_status, 0), COALESCE(error, ''),
+	created_at, CASE WHEN status = 'pending' THEN next_attempt_at END, delivered_at
+`
+
+// WebhookDeliveryAttempt is a delivery claimed for an attempt, with what is needed to make it
+type WebhookDeliveryAttempt struct {
+	ID        string
+	WebhookID string
+	Event     string
+	Payload   []byte
+	// Attempt counts the attempts made so far, including this one
+	Attempt int
+	URL     string
+	Secret  string
+}
+
+// CreateWebhook stores a new webhook
+func (db *PostgreSQL) CreateWebhook(ctx context.Context, tx pgx.Tx, webhook *apiv0.Webhook) (*apiv0.Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO webhooks (auth_method, subject, url, secret, events)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + webhookColumns
+
+	row := db.getExecutor(ctx, tx).QueryRow(ctx, query, webhook.Owner.AuthMethod, webhook.Owner.Subject, webhook.URL, webhook.Secret, webhook.Events)
+	created, err := scanWebhook(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert webhook: %w", err)
+	}
+
+	return created, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (db *PostgreSQL) GetWebhook(ctx context.Context, tx pgx.Tx, id string) (*apiv0.Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	webhook, err := scanWebhook(db.getExecutor(ctx, tx).QueryRow(ctx, query, id))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks lists a principal's webhooks, oldest first
+func (db *PostgreSQL) ListWebhooks(ctx context.Context, tx pgx.Tx, owner apiv0.Principal) ([]apiv0.Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE auth_method = $1 AND subject = $2
+		ORDER BY created_at, id
+	`
+
+	return db.queryWebhooks(ctx, tx, query, owner.AuthMethod, owner.Subject)
+}
+
+// ListFailingWebhooks lists the webhooks whose last delivery attempt failed, those failing the
+// longest first
+func (db *PostgreSQL) ListFailingWebhooks(ctx context.Context, tx pgx.Tx, limit int) ([]apiv0.Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE consecutive_failures > 0
+		ORDER BY consecutive_failures DESC, created_at, id
+		LIMIT $1
+	`
+
+	return db.queryWebhooks(ctx, tx, query, limit)
+}
+
+func (db *PostgreSQL) queryWebhooks(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]apiv0.Webhook, error) {
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []apiv0.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// DeleteWebhook deletes one of a principal's webhooks along with its deliveries
+func (db *PostgreSQL) DeleteWebhook(ctx context.Context, tx pgx.Tx, id string, owner apiv0.Principal) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM webhooks WHERE id = $1 AND auth_method = $2 AND subject = $3`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, id, owner.AuthMethod, owner.Subject)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgInvalidTextRepresentation {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues an event for delivery to every webhook that wants it
+func (db *PostgreSQL) EnqueueWebhookDeliveries(ctx context.Context, tx pgx.Tx, event string, payload []byte) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload)
+		SELECT id, $1::text, $2::jsonb FROM webhooks WHERE $1::text = ANY(events)`
+
+	if _, err := db.getExecutor(ctx, tx).Exec(ctx, query, event, payload); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries lists the most recent deliveries to a webhook, newest first
+func (db *PostgreSQL) ListWebhookDeliveries(ctx context.Context, tx pgx.Tx, webhookID string, limit int) ([]apiv0.WebhookDelivery, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(ctx, tx).Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []apiv0.WebhookDelivery{}
+	for rows.Next() {
+		var delivery apiv0.WebhookDelivery
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Status, &delivery.Attempts,
+			&delivery.ResponseStatus, &delivery.Error, &delivery.CreatedAt, &delivery.NextAttemptAt, &delivery.DeliveredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// ClaimWebhookDelivery counts an attempt of the pending delivery that has been due longest and
+// returns it. It isn't due again until leaseUntil, so that a delivery whose worker died is
+// attempted again rather than left pending.
+func (db *PostgreSQL) ClaimWebhookDelivery(ctx context.Context, tx pgx.Tx, leaseUntil time.Time) (*WebhookDeliveryAttempt, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE webhook_deliveries d SET attempts = d.attempts + 1, next_attempt_at = $1
+		FROM webhooks w
+		WHERE d.id = (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		) AND w.id = d.webhook_id
+		RETURNING d.id::text, d.webhook_id::text, d.event, d.payload, d.attempts, w.url, w.secret`
+
+	var attempt WebhookDeliveryAttempt
+	err := db.getExecutor(ctx, tx).QueryRow(ctx, query, leaseUntil).Scan(&attempt.ID, &attempt.WebhookID, &attempt.Event,
+		&attempt.Payload, &attempt.Attempt, &attempt.URL, &attempt.Secret)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim webhook delivery: %w", err)
+	}
+
+	return &attempt, nil
+}
+
+// RecordWebhookDeliveryAttempt records the outcome of a delivery attempt on the delivery and on
+// its webhook, whose failures are counted until an attempt succeeds
+func (db *PostgreSQL) RecordWebhookDeliveryAttempt(ctx context.Context, tx pgx.Tx, outcome *apiv0.WebhookDelivery) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		WITH delivery AS (
+			UPDATE webhook_deliveries
+			SET status = $2, response_status = NULLIF($3, 0), error = NULLIF($4, ''),
+				next_attempt_at = COALESCE($5, next_attempt_at), delivered_at = $6
+			WHERE id = $1
+			RETURNING webhook_id
+		)
+		UPDATE webhooks SET
+			last_delivery_at = NOW(),
+			consecutive_failures = CASE WHEN $4 = '' THEN 0 ELSE consecutive_failures + 1 END,
+			last_error = CASE WHEN $4 = '' THEN last_error ELSE $4 END
+		FROM delivery
+		WHERE webhooks.id = delivery.webhook_id`
+
+	result, err := db.getExecutor(ctx, tx).Exec(ctx, query, outcome.ID, outcome.Status, outcome.ResponseStatus, outcome.Error,
+		outcome.NextAttemptAt, outcome.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func scanWebhook(row pgx.Row) (*apiv0.Webhook, error) {
+	var webhook apiv0.Webhook
+	if err := row.Scan(&webhook.ID, &webhook.Owner.AuthMethod, &webhook.Owner.Subject, &webhook.URL, &webhook.Secret,
+		&webhook.Events, &webhook.CreatedAt, &webhook.ConsecutiveFailures, &webhook.LastDeliveryAt, &webhook.LastError); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
@@ -0,0 +1,169 @@
+// Package exports renders the whole registry into documents that CDNs, offline mirrors and web
+// crawlers consume: a JSON snapshot of every server version and a sitemap
+package exports
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// pageSize is how many server versions are read at a time
+	pageSize = 1000
+	// maxSitemapURLs is how many URLs a sitemap may hold
+	maxSitemapURLs = 50000
+)
+
+// Store reads a consistent snapshot of the registry and keeps the exports rendered from it
+type Store interface {
+	CreateSnapshot(ctx context.Context) (*database.Snapshot, error)
+	ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	PutExport(ctx context.Context, export *database.Export) error
+}
+
+// Generator renders the exports of a store
+type Generator struct {
+	store Store
+	// publicURL is what sitemap URLs start with, or empty to render no sitemap
+	publicURL string
+}
+
+// NewGenerator creates a generator for the exports of a store, with a sitemap when the
+// registry's public URL is configured
+func NewGenerator(store Store, cfg *config.Config) *Generator {
+	return &Generator{store: store, publicURL: strings.TrimSuffix(cfg.PublicURL, "/")}
+}
+
+// Generate renders every export from a snapshot of the registry and stores them
+func (g *Generator) Generate(ctx context.Context) error {
+	generatedAt := time.Now().UTC()
+	versions, err := g.listVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	export := apiv0.ServerExport{GeneratedAt: generatedAt, Servers: versions, Metadata: apiv0.Metadata{Count: len(versions)}}
+	body, err := json.Marshal(export)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", service.ExportServers, err)
+	}
+	if err := g.put(ctx, service.ExportServers, "application/json", body, generatedAt); err != nil {
+		return err
+	}
+
+	if g.publicURL == "" {
+		return nil
+	}
+	sitemap, err := g.renderSitemap(versions)
+	if err != nil {
+		return err
+	}
+	return g.put(ctx, service.ExportSitemap, "application/xml", sitemap, generatedAt)
+}
+
+// listVersions pages through a snapshot of every server version that isn't quarantined, so that
+// versions published during the read don't leave the export inconsistent
+func (g *Generator) listVersions(ctx context.Context) ([]apiv0.ServerResponse, error) {
+	snapshot, err := g.store.CreateSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	quarantined := false
+	filter := &database.ServerFilter{Snapshot: &snapshot.ID, Quarantined: &quarantined}
+
+	versions := []apiv0.ServerResponse{}
+	cursor := ""
+	for {
+		page, nextCursor, err := g.store.ListServers(ctx, filter, cursor, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, version := range page {
+			versions = append(versions, *version)
+		}
+		if nextCursor == "" {
+			return versions, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// put gzips an export and stores it with a strong ETag of its body
+func (g *Generator) put(ctx context.Context, name, contentType string, body []byte, generatedAt time.Time) error {
+	var gzipped bytes.Buffer
+	writer := gzip.NewWriter(&gzipped)
+	if _, err := writer.Write(body); err != nil {
+		return fmt.Errorf("failed to gzip %s: %w", name, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to gzip %s: %w", name, err)
+	}
+
+	sum := sha256.Sum256(body)
+	err := g.store.PutExport(ctx, &database.Export{
+		Name:        name,
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(sum[:]) + `"`,
+		GeneratedAt: generatedAt,
+		Body:        body,
+		GzipBody:    gzipped.Bytes(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store %s: %w", name, err)
+	}
+	return nil
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// renderSitemap links to the latest version of every server listings show, by name, up to the
+// number of URLs a sitemap may hold
+func (g *Generator) renderSitemap(versions []apiv0.ServerResponse) ([]byte, error) {
+	urlSet := sitemapURLSet{URLs: []sitemapURL{}}
+	for _, version := range versions {
+		official := version.Meta.Official
+		if official == nil || !official.IsLatest || official.YankedAt != nil || official.Status == model.StatusDeleted {
+			continue
+		}
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:     g.publicURL + "/v0/servers/" + url.PathEscape(version.Server.Name) + "/versions/latest",
+			LastMod: official.UpdatedAt.UTC().Format(time.DateOnly),
+		})
+	}
+	sort.Slice(urlSet.URLs, func(i, j int) bool { return urlSet.URLs[i].Loc < urlSet.URLs[j].Loc })
+	if len(urlSet.URLs) > maxSitemapURLs {
+		log.Printf("Sitemap truncated to %d of %d servers", maxSitemapURLs, len(urlSet.URLs))
+		urlSet.URLs = urlSet.URLs[:maxSitemapURLs]
+	}
+
+	body, err := xml.Marshal(urlSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", service.ExportSitemap, err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,111 @@
+package exports_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/exports"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+type fakeStore struct {
+	pages   [][]*apiv0.ServerResponse
+	filters []*database.ServerFilter
+	exports map[string]*database.Export
+}
+
+func (s *fakeStore) CreateSnapshot(_ context.Context) (*database.Snapshot, error) {
+	return &database.Snapshot{ID: "snapshot-1"}, nil
+}
+
+func (s *fakeStore) ListServers(_ context.Context, filter *database.ServerFilter, cursor string, _ int) ([]*apiv0.ServerResponse, string, error) {
+	s.filters = append(s.filters, filter)
+	if cursor == "" {
+		return s.pages[0], "next", nil
+	}
+	return s.pages[1], "", nil
+}
+
+func (s *fakeStore) PutExport(_ context.Context, export *database.Export) error {
+	s.exports[export.Name] = export
+	return nil
+}
+
+func version(name, version string, isLatest bool, yanked bool) *apiv0.ServerResponse {
+	official := &apiv0.RegistryExtensions{
+		Status:    model.StatusActive,
+		IsLatest:  isLatest,
+		UpdatedAt: time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC),
+	}
+	if yanked {
+		official.YankedAt = &official.UpdatedAt
+	}
+	return &apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{Name: name, Version: version, Description: "Export test server"},
+		Meta:   apiv0.ResponseMeta{Official: official},
+	}
+}
+
+func newStore() *fakeStore {
+	return &fakeStore{
+		exports: map[string]*database.Export{},
+		pages: [][]*apiv0.ServerResponse{
+			{version("com.example/weather", "1.0.0", false, false), version("com.example/weather", "1.1.0", true, false)},
+			{version("com.example/yanked", "1.0.0", true, true)},
+		},
+	}
+}
+
+func TestGenerate_ExportsEveryVersion(t *testing.T) {
+	store := newStore()
+	require.NoError(t, exports.NewGenerator(store, &config.Config{}).Generate(context.Background()))
+
+	require.Len(t, store.filters, 2)
+	assert.Equal(t, "snapshot-1", *store.filters[0].Snapshot)
+	assert.False(t, *store.filters[0].Quarantined)
+
+	export := store.exports[service.ExportServers]
+	require.NotNil(t, export)
+	assert.Equal(t, "application/json", export.ContentType)
+	assert.Regexp(t, `^"[0-9a-f]{64}"$`, export.ETag)
+
+	var servers apiv0.ServerExport
+	require.NoError(t, json.Unmarshal(export.Body, &servers))
+	assert.Equal(t, 3, servers.Metadata.Count)
+	require.Len(t, servers.Servers, 3)
+	assert.Equal(t, "com.example/yanked", servers.Servers[2].Server.Name)
+
+	reader, err := gzip.NewReader(bytes.NewReader(export.GzipBody))
+	require.NoError(t, err)
+	unzipped, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, export.Body, unzipped)
+
+	assert.NotContains(t, store.exports, service.ExportSitemap, "no sitemap without a public URL")
+}
+
+func TestGenerate_SitemapLinksLatestVersions(t *testing.T) {
+	store := newStore()
+	generator := exports.NewGenerator(store, &config.Config{PublicURL: "https://registry.example.com/"})
+	require.NoError(t, generator.Generate(context.Background()))
+
+	sitemap := store.exports[service.ExportSitemap]
+	require.NotNil(t, sitemap)
+	assert.Equal(t, "application/xml", sitemap.ContentType)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+		`<url><loc>https://registry.example.com/v0/servers/com.example%2Fweather/versions/latest</loc><lastmod>2026-03-14</lastmod></url>`+
+		`</urlset>`, string(sitemap.Body))
+}
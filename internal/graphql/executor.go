@@ -0,0 +1,677 @@
+// Package graphql executes GraphQL queries against the registry's Go types.
+//
+// It implements the query subset of GraphQL that clients use to select fields: operations,
+// variables, aliases, arguments, named and inline fragments. Types aren't declared in a schema
+// but come from the Go values resolvers return: struct fields are addressed by their JSON names
+// (with characters GraphQL doesn't allow in names dropped, and a path like
+// "io.modelcontextprotocol.registry/official" shortened to its last segment), and schemas can add
+// fields to a type with resolvers of their own. Maps and other values that aren't structs are
+// returned as JSON, or narrowed to the keys selected from them.
+//
+// Arguments passed to a list field that has no resolver filter its items: an item is kept when
+// each argument equals the item's field of that name. An object argument matches the fields it
+// names in a nested object, so a filter can reach into the items' nested fields. Directives,
+// mutations, subscriptions and introspection aren't supported.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Resolver resolves a field. The parent is a pointer to the object the field is selected on,
+// or nil for fields of the query root.
+type Resolver func(ctx context.Context, parent any, args Args) (any, error)
+
+// Schema holds the resolvers of the query root's fields and of fields added to Go types
+type Schema struct {
+	Query  map[string]Resolver
+	Fields map[reflect.Type]map[string]Resolver
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string         `json:"query" doc:"GraphQL query document" minLength:"1"`
+	OperationName string         `json:"operationName,omitempty" doc:"Operation to execute, when the document has more than one"`
+	Variables     map[string]any `json:"variables,omitempty" doc:"Values of the operation's variables"`
+}
+
+// Response is the result of executing a request. Data is left out when the request couldn't be executed.
+type Response struct {
+	Data   any     `json:"data,omitempty" doc:"Selected fields, in the order they were selected"`
+	Errors []Error `json:"errors,omitempty" doc:"Errors parsing the query or resolving fields"`
+}
+
+// Error is an error parsing a query or resolving a field, with the path to the field that failed
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Args are a field's arguments. Numbers are float64s, as they are in JSON variables.
+type Args map[string]any
+
+// String returns a string argument, reporting whether it was passed
+func (a Args) String(name string) (string, bool, error) {
+	raw, ok := a[name]
+	if !ok || raw == nil {
+		return "", false, nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return "", false, fmt.Errorf("argument %q must be a string", name)
+	}
+	return s, true, nil
+}
+
+// Int returns an integer argument, reporting whether it was passed
+func (a Args) Int(name string) (int, bool, error) {
+	raw, ok := a[name]
+	if !ok || raw == nil {
+		return 0, false, nil
+	}
+	f, ok := raw.(float64)
+	if !ok || f != math.Trunc(f) || math.Abs(f) > math.MaxInt32 {
+		return 0, false, fmt.Errorf("argument %q must be an integer", name)
+	}
+	return int(f), true, nil
+}
+
+// Bool returns a boolean argument, reporting whether it was passed
+func (a Args) Bool(name string) (bool, bool, error) {
+	raw, ok := a[name]
+	if !ok || raw == nil {
+		return false, false, nil
+	}
+	b, ok := raw.(bool)
+	if !ok {
+		return false, false, fmt.Errorf("argument %q must be a boolean", name)
+	}
+	return b, true, nil
+}
+
+// Execute parses and executes a query. Fields that fail to resolve are null, with an error for each.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	variables, err := op.resolveVariables(req.Variables)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+
+	e := &execution{schema: s, doc: doc, variables: variables}
+	fields, err := e.collectFields(op.selections)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+
+	data := &object{}
+	for _, f := range fields {
+		path := []any{f.key}
+		if f.name == "__typename" {
+			data.set(f.key, "Query")
+			continue
+		}
+		resolver, ok := s.Query[f.name]
+		if !ok {
+			e.fail(path, fmt.Errorf("cannot query field %q on type Query", f.name))
+			data.set(f.key, nil)
+			continue
+		}
+		data.set(f.key, e.resolveField(ctx, resolver, nil, f, path))
+	}
+	return &Response{Data: data, Errors: e.errors}
+}
+
+// operation returns the operation to execute
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, errors.New("operationName is required when the document has more than one operation")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// resolveVariables applies defaults to the values passed for the operation's variables
+func (o *operation) resolveVariables(values map[string]any) (map[string]any, error) {
+	resolved := map[string]any{}
+	for _, definition := range o.variables {
+		if v, ok := values[definition.name]; ok {
+			resolved[definition.name] = v
+		} else if definition.defaultValue != nil {
+			v, err := definition.defaultValue.resolve(nil)
+			if err != nil {
+				return nil, err
+			}
+			resolved[definition.name] = v
+		}
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return nil, fmt.Errorf("variable $%s isn't declared by the operation", name)
+		}
+	}
+	return resolved, nil
+}
+
+// resolve converts a value to its Go form, substituting variables
+func (v *value) resolve(variables map[string]any) (any, error) {
+	switch {
+	case v.variable != "":
+		// Variables that weren't passed and have no default are null
+		return variables[v.variable], nil
+	case v.isList:
+		list := make([]any, 0, len(v.list))
+		for _, item := range v.list {
+			resolved, err := item.resolve(variables)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, resolved)
+		}
+		return list, nil
+	case v.isObject:
+		obj := make(map[string]any, len(v.object))
+		for name, item := range v.object {
+			resolved, err := item.resolve(variables)
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = resolved
+		}
+		return obj, nil
+	default:
+		return v.literal, nil
+	}
+}
+
+// execution is the state of executing one operation
+type execution struct {
+	schema    *Schema
+	doc       *document
+	variables map[string]any
+	errors    []Error
+}
+
+func (e *execution) fail(path []any, err error) {
+	e.errors = append(e.errors, Error{Message: err.Error(), Path: append([]any(nil), path...)})
+}
+
+// field is a field to resolve, merged from every selection of its response key
+type field struct {
+	key        string
+	name       string
+	arguments  []argument
+	selections []*selection
+}
+
+// collectFields flattens fragments into the fields they select, merging selections of the same
+// response key. Type conditions aren't checked, since every selection applies to a concrete type.
+// Each fragment is spread once per selection set, as the spec has it, so repeated spreads of the
+// same fragment can't multiply the work.
+func (e *execution) collectFields(selections []*selection) ([]*field, error) {
+	var fields []*field
+	byKey := map[string]*field{}
+	spread := map[string]bool{}
+
+	var collect func(selections []*selection) error
+	collect = func(selections []*selection) error {
+		for _, sel := range selections {
+			switch {
+			case sel.spread != "":
+				if spread[sel.spread] {
+					continue
+				}
+				spread[sel.spread] = true
+				frag, ok := e.doc.fragments[sel.spread]
+				if !ok {
+					return fmt.Errorf("unknown fragment %q", sel.spread)
+				}
+				if err := collect(frag.selections); err != nil {
+					return err
+				}
+			case sel.inline:
+				if err := collect(sel.selections); err != nil {
+					return err
+				}
+			default:
+				f, ok := byKey[sel.key()]
+				if !ok {
+					f = &field{key: sel.key(), name: sel.name, arguments: sel.arguments}
+					byKey[f.key] = f
+					fields = append(fields, f)
+				} else if f.name != sel.name {
+					return fmt.Errorf("fields %q and %q can't both be selected as %q", f.name, sel.name, f.key)
+				}
+				f.selections = append(f.selections, sel.selections...)
+			}
+		}
+		return nil
+	}
+	if err := collect(selections); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// arguments resolves a field's arguments against the operation's variables
+func (e *execution) arguments(f *field) (Args, error) {
+	args := Args{}
+	for _, arg := range f.arguments {
+		v, err := arg.value.resolve(e.variables)
+		if err != nil {
+			return nil, err
+		}
+		args[arg.name] = v
+	}
+	return args, nil
+}
+
+// resolveField calls a field's resolver and selects from the value it returns
+func (e *execution) resolveField(ctx context.Context, resolver Resolver, parent any, f *field, path []any) any {
+	args, err := e.arguments(f)
+	if err != nil {
+		e.fail(path, err)
+		return nil
+	}
+	resolved, err := resolver(ctx, parent, args)
+	if err != nil {
+		e.fail(path, err)
+		return nil
+	}
+	return e.complete(ctx, reflect.ValueOf(resolved), f, path)
+}
+
+// complete selects a field's subfields from its value
+func (e *execution) complete(ctx context.Context, v reflect.Value, f *field, path []any) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		if len(f.selections) == 0 && isScalar(v.Type()) {
+			break
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if len(f.selections) == 0 {
+		// Values without a selection, including objects, are returned whole
+		return v.Interface()
+	}
+
+	switch {
+	case isScalar(v.Type()):
+		e.fail(path, fmt.Errorf("field %q has no subfields to select", f.name))
+		return nil
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		list := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			list = append(list, e.complete(ctx, v.Index(i), f, append(path[:len(path):len(path)], i)))
+		}
+		return list
+	case v.Kind() == reflect.Struct:
+		return e.selectObject(ctx, v, f.selections, path)
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		return e.selectMap(ctx, v, f.selections, path)
+	default:
+		e.fail(path, fmt.Errorf("field %q has no subfields to select", f.name))
+		return nil
+	}
+}
+
+// selectObject resolves the fields selected from a struct
+func (e *execution) selectObject(ctx context.Context, v reflect.Value, selections []*selection, path []any) any {
+	fields, err := e.collectFields(selections)
+	if err != nil {
+		e.fail(path, err)
+		return nil
+	}
+
+	// Resolvers get a pointer to the object, copying it if it isn't addressable
+	parent := v
+	if v.CanAddr() {
+		parent = v.Addr()
+	} else {
+		parent = reflect.New(v.Type())
+		parent.Elem().Set(v)
+	}
+
+	t := v.Type()
+	index := fieldIndex(t)
+	result := &object{}
+	for _, f := range fields {
+		fieldPath := append(path[:len(path):len(path)], f.key)
+		if f.name == "__typename" {
+			result.set(f.key, t.Name())
+			continue
+		}
+		if resolver, ok := e.schema.Fields[t][f.name]; ok {
+			result.set(f.key, e.resolveField(ctx, resolver, parent.Interface(), f, fieldPath))
+			continue
+		}
+		structField, ok := index[f.name]
+		if !ok {
+			e.fail(fieldPath, fmt.Errorf("cannot query field %q on type %s", f.name, t.Name()))
+			result.set(f.key, nil)
+			continue
+		}
+		fieldValue, ok := fieldByIndex(v, structField)
+		if !ok {
+			result.set(f.key, nil)
+			continue
+		}
+		if len(f.arguments) > 0 {
+			if fieldValue, err = e.filter(fieldValue, f); err != nil {
+				e.fail(fieldPath, err)
+				result.set(f.key, nil)
+				continue
+			}
+		}
+		result.set(f.key, e.complete(ctx, fieldValue, f, fieldPath))
+	}
+	return result
+}
+
+// selectMap resolves the keys selected from a map, matching them by their GraphQL names
+func (e *execution) selectMap(ctx context.Context, v reflect.Value, selections []*selection, path []any) any {
+	fields, err := e.collectFields(selections)
+	if err != nil {
+		e.fail(path, err)
+		return nil
+	}
+	keys := map[string]reflect.Value{}
+	for _, key := range v.MapKeys() {
+		keys[fieldName(key.String())] = key
+	}
+
+	result := &object{}
+	for _, f := range fields {
+		fieldPath := append(path[:len(path):len(path)], f.key)
+		if f.name == "__typename" {
+			result.set(f.key, "JSON")
+			continue
+		}
+		if len(f.arguments) > 0 {
+			e.fail(fieldPath, fmt.Errorf("field %q takes no arguments", f.name))
+			result.set(f.key, nil)
+			continue
+		}
+		key, ok := keys[f.name]
+		if !ok {
+			result.set(f.key, nil)
+			continue
+		}
+		result.set(f.key, e.complete(ctx, v.MapIndex(key), f, fieldPath))
+	}
+	return result
+}
+
+// filter keeps the items of a list that match the field's arguments
+func (e *execution) filter(v reflect.Value, f *field) (reflect.Value, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return v, fmt.Errorf("field %q takes no arguments", f.name)
+	}
+	args, err := e.arguments(f)
+	if err != nil {
+		return v, err
+	}
+	want, err := normalize(map[string]any(args))
+	if err != nil {
+		return v, err
+	}
+
+	filtered := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item, err := normalize(v.Index(i).Interface())
+		if err != nil {
+			return v, err
+		}
+		if matches(item, want) {
+			filtered = reflect.Append(filtered, v.Index(i))
+		}
+	}
+	return filtered, nil
+}
+
+// normalize converts a value to its JSON form, with object keys renamed to their GraphQL names
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return rename(decoded), nil
+}
+
+func rename(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		renamed := make(map[string]any, len(v))
+		for key, item := range v {
+			renamed[fieldName(key)] = rename(item)
+		}
+		return renamed
+	case []any:
+		for i, item := range v {
+			v[i] = rename(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// matches reports whether an item has the values of a filter. Objects in the filter match the
+// fields they name, and lists match a list holding an item that matches each of theirs.
+func matches(item, want any) bool {
+	switch want := want.(type) {
+	case map[string]any:
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range want {
+			if !matches(obj[key], value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		list, ok := item.([]any)
+		if !ok {
+			return false
+		}
+		for _, value := range want {
+			found := false
+			for _, candidate := range list {
+				if matches(candidate, value) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		return item == want
+	}
+}
+
+var (
+	jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+	timeType      = reflect.TypeFor[time.Time]()
+)
+
+// isScalar reports whether values of a type are returned as JSON rather than selected from
+func isScalar(t reflect.Type) bool {
+	if t == timeType || t.Implements(jsonMarshaler) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Struct, reflect.Map, reflect.Pointer, reflect.Interface:
+		return false
+	default:
+		return true
+	}
+}
+
+var fieldIndexes sync.Map // reflect.Type -> map[string][]int
+
+// fieldIndex maps the GraphQL names of a struct's fields to their indexes, flattening embedded
+// structs the way encoding/json does
+func fieldIndex(t reflect.Type) map[string][]int {
+	if index, ok := fieldIndexes.Load(t); ok {
+		return index.(map[string][]int)
+	}
+	index := map[string][]int{}
+	addFields(t, nil, index)
+	fieldIndexes.Store(t, index)
+	return index
+}
+
+func addFields(t reflect.Type, parent []int, index map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		position := append(parent[:len(parent):len(parent)], i)
+
+		fieldType := sf.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		// Unexported embedded structs are left out, since their fields can't be read through reflection
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			addFields(fieldType, position, index)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		// Fields of the outer struct take precedence over embedded ones
+		graphqlName := fieldName(name)
+		if existing, ok := index[graphqlName]; !ok || len(existing) > len(position) {
+			index[graphqlName] = position
+		}
+	}
+}
+
+// fieldByIndex returns a nested field, reporting false when an embedded pointer on the way is nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, position := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(position)
+	}
+	return v, true
+}
+
+// fieldName converts a JSON name to a GraphQL name: the last segment of a path, in camel case,
+// with characters GraphQL doesn't allow dropped
+func fieldName(jsonName string) string {
+	if i := strings.LastIndex(jsonName, "/"); i >= 0 && i < len(jsonName)-1 {
+		jsonName = jsonName[i+1:]
+	}
+	var b strings.Builder
+	upper := false
+	for _, r := range jsonName {
+		switch {
+		case r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))):
+			if upper && b.Len() > 0 {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// object is a JSON object that keeps its fields in the order they were selected
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *object) set(key string, value any) {
+	if o.values == nil {
+		o.values = map[string]any{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON encodes the object's fields in order
+func (o *object) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, key := range o.keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, name...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
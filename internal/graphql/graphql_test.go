@@ -0,0 +1,181 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/graphql"
+)
+
+type testPackage struct {
+	RegistryType string         `json:"registryType"`
+	Identifier   string         `json:"identifier"`
+	Transport    map[string]any `json:"transport"`
+}
+
+type TestBase struct {
+	Name string `json:"name"`
+}
+
+type testServer struct {
+	TestBase `json:",inline"`
+	Schema   string         `json:"$schema"`
+	Version  string         `json:"version"`
+	Packages []testPackage  `json:"packages,omitempty"`
+	Meta     map[string]any `json:"_meta,omitempty"`
+	internal string
+}
+
+func testSchema() *graphql.Schema {
+	servers := []testServer{
+		{
+			TestBase: TestBase{Name: "com.example/weather"},
+			Schema:   "https://example.com/server.schema.json",
+			Version:  "1.0.0",
+			Packages: []testPackage{
+				{RegistryType: "npm", Identifier: "@example/weather", Transport: map[string]any{"type": "stdio"}},
+				{RegistryType: "oci", Identifier: "example/weather", Transport: map[string]any{"type": "streamable-http"}},
+			},
+			Meta: map[string]any{"io.modelcontextprotocol.registry/publisher-provided": map[string]any{"tier": "free", "size": 3}},
+		},
+		{TestBase: TestBase{Name: "com.example/maps"}, Version: "2.0.0"},
+	}
+	return &graphql.Schema{
+		Query: map[string]graphql.Resolver{
+			"servers": func(_ context.Context, _ any, args graphql.Args) (any, error) {
+				limit, ok, err := args.Int("limit")
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					return servers[:limit], nil
+				}
+				return servers, nil
+			},
+			"server": func(_ context.Context, _ any, args graphql.Args) (any, error) {
+				name, _, err := args.String("name")
+				if err != nil {
+					return nil, err
+				}
+				for i := range servers {
+					if servers[i].Name == name {
+						return &servers[i], nil
+					}
+				}
+				return nil, nil
+			},
+			"broken": func(_ context.Context, _ any, _ graphql.Args) (any, error) {
+				return nil, errors.New("resolver failed")
+			},
+		},
+		Fields: map[reflect.Type]map[string]graphql.Resolver{
+			reflect.TypeFor[testServer](): {
+				"title": func(_ context.Context, parent any, _ graphql.Args) (any, error) {
+					return parent.(*testServer).Name + " " + parent.(*testServer).Version, nil
+				},
+			},
+		},
+	}
+}
+
+func execute(t *testing.T, req graphql.Request) (string, []graphql.Error) {
+	t.Helper()
+	resp := testSchema().Execute(context.Background(), req)
+	data, err := json.Marshal(resp.Data)
+	require.NoError(t, err)
+	return string(data), resp.Errors
+}
+
+func TestExecute_SelectsFieldsInOrder(t *testing.T) {
+	data, errs := execute(t, graphql.Request{Query: `{
+		servers {
+			version
+			name # embedded fields are flattened
+			schema
+			alias: title
+			__typename
+		}
+	}`})
+	assert.Empty(t, errs)
+	assert.JSONEq(t, `{"servers":[
+		{"version":"1.0.0","name":"com.example/weather","schema":"https://example.com/server.schema.json","alias":"com.example/weather 1.0.0","__typename":"testServer"},
+		{"version":"2.0.0","name":"com.example/maps","schema":"","alias":"com.example/maps 2.0.0","__typename":"testServer"}
+	]}`, data)
+	assert.Regexp(t, `^\{"servers":\[\{"version":.*"name":.*"schema":.*"alias":`, data, "fields keep the order they were selected in")
+}
+
+func TestExecute_FiltersListsByArguments(t *testing.T) {
+	data, errs := execute(t, graphql.Request{Query: `{
+		npm: server(name: "com.example/weather") { packages(registryType: npm) { identifier } }
+		http: server(name: "com.example/weather") { packages(transport: {type: "streamable-http"}) { identifier } }
+		none: server(name: "com.example/weather") { packages(registryType: "pypi") { identifier } }
+	}`})
+	assert.Empty(t, errs)
+	assert.JSONEq(t, `{
+		"npm":{"packages":[{"identifier":"@example/weather"}]},
+		"http":{"packages":[{"identifier":"example/weather"}]},
+		"none":{"packages":[]}
+	}`, data)
+}
+
+func TestExecute_VariablesAndFragments(t *testing.T) {
+	data, errs := execute(t, graphql.Request{
+		Query: `
+			query Lookup($name: String!, $limit: Int = 1) {
+				server(name: $name) { ...identity meta: _meta { publisherProvided { tier } } }
+				servers(limit: $limit) { ... on testServer { name } }
+			}
+			fragment identity on testServer { name version }
+			query Other { servers { name } }`,
+		OperationName: "Lookup",
+		Variables:     map[string]any{"name": "com.example/weather"},
+	})
+	assert.Empty(t, errs)
+	assert.JSONEq(t, `{
+		"server":{"name":"com.example/weather","version":"1.0.0","meta":{"publisherProvided":{"tier":"free"}}},
+		"servers":[{"name":"com.example/weather"}]
+	}`, data)
+}
+
+func TestExecute_ReturnsUnselectedObjectsWhole(t *testing.T) {
+	data, errs := execute(t, graphql.Request{Query: `{ server(name: "com.example/weather") { _meta } missing: server(name: "com.example/none") { name } }`})
+	assert.Empty(t, errs)
+	assert.JSONEq(t, `{
+		"server":{"_meta":{"io.modelcontextprotocol.registry/publisher-provided":{"tier":"free","size":3}}},
+		"missing":null
+	}`, data)
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	data, errs := execute(t, graphql.Request{Query: `{ broken servers { internal name } unknown }`})
+	assert.JSONEq(t, `{"broken":null,"servers":[{"internal":null,"name":"com.example/weather"},{"internal":null,"name":"com.example/maps"}],"unknown":null}`, data)
+	require.Len(t, errs, 4)
+	assert.Equal(t, graphql.Error{Message: "resolver failed", Path: []any{"broken"}}, errs[0])
+	assert.Equal(t, []any{"servers", 0, "internal"}, errs[1].Path)
+	assert.Equal(t, []any{"unknown"}, errs[3].Path)
+}
+
+func TestExecute_RejectsInvalidDocuments(t *testing.T) {
+	for name, req := range map[string]graphql.Request{
+		"syntax error":       {Query: `{ servers { name }`},
+		"mutation":           {Query: `mutation { servers { name } }`},
+		"directive":          {Query: `{ servers @include(if: true) { name } }`},
+		"fragment cycle":     {Query: `{ servers { ...a } } fragment a on testServer { ...b } fragment b on testServer { ...a }`},
+		"ambiguous":          {Query: `query A { servers { name } } query B { servers { name } }`},
+		"undeclared":         {Query: `{ servers { name } }`, Variables: map[string]any{"limit": 1}},
+		"too deep":           {Query: `{ a { b { c { d { e { f { g { h { i { j { k { l { m { n { o { p { q { r { s { t { u { v { w } } } } } } } } } } } } } } } } } } } } } }`},
+		"conflicting fields": {Query: `{ servers: server(name: "com.example/maps") { name } servers { name } }`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := testSchema().Execute(context.Background(), req)
+			assert.Nil(t, resp.Data)
+			assert.Len(t, resp.Errors, 1)
+		})
+	}
+}
@@ -0,0 +1,611 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDepth bounds how deeply selections nest, which no query of the registry's types comes near
+const maxDepth = 20
+
+// document is a parsed query document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// operation is a query with its variables
+type operation struct {
+	name       string
+	variables  []variableDefinition
+	selections []*selection
+}
+
+type variableDefinition struct {
+	name         string
+	defaultValue *value
+}
+
+type fragment struct {
+	name       string
+	selections []*selection
+}
+
+// selection is a field, a fragment spread (spread is set) or an inline fragment (inline is set)
+type selection struct {
+	alias      string
+	name       string
+	arguments  []argument
+	selections []*selection
+
+	spread string
+	inline bool
+}
+
+// key is the name of the selection's field in the response
+func (s *selection) key() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type argument struct {
+	name  string
+	value *value
+}
+
+// value is a literal or a variable, resolved against the request's variables when executed
+type value struct {
+	variable string
+	literal  any
+	list     []*value
+	object   map[string]*value
+	isList   bool
+	isObject bool
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// parser is a recursive descent parser of the executable parts of the GraphQL grammar
+type parser struct {
+	source string
+	pos    int
+	token  token
+}
+
+// parse parses a query document
+func parse(source string) (*document, error) {
+	p := &parser{source: source}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.token.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"):
+			selections, err := p.parseSelectionSet(0)
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{selections: selections})
+		case p.peek(tokenName, "query"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peek(tokenName, "mutation"), p.peek(tokenName, "subscription"):
+			return nil, fmt.Errorf("%ss aren't supported, only queries", p.token.text)
+		case p.peek(tokenName, "fragment"):
+			frag, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[frag.name]; ok {
+				return nil, fmt.Errorf("fragment %q is defined more than once", frag.name)
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("the document has no query")
+	}
+	checked := map[string]bool{}
+	for _, op := range doc.operations {
+		if err := doc.checkSpreads(op.selections, nil, checked); err != nil {
+			return nil, err
+		}
+	}
+	for _, frag := range doc.fragments {
+		if err := doc.checkSpreads(frag.selections, []string{frag.name}, checked); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// checkSpreads fails if selections spread an unknown fragment or one of the fragments they are
+// in, skipping fragments already checked
+func (d *document) checkSpreads(selections []*selection, within []string, checked map[string]bool) error {
+	for _, sel := range selections {
+		if sel.spread != "" {
+			frag, ok := d.fragments[sel.spread]
+			if !ok {
+				return fmt.Errorf("unknown fragment %q", sel.spread)
+			}
+			for _, name := range within {
+				if name == sel.spread {
+					return fmt.Errorf("fragment %q spreads itself", sel.spread)
+				}
+			}
+			if !checked[sel.spread] {
+				if err := d.checkSpreads(frag.selections, append(within[:len(within):len(within)], sel.spread), checked); err != nil {
+					return err
+				}
+				checked[sel.spread] = true
+			}
+		}
+		if err := d.checkSpreads(sel.selections, within, checked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	if err := p.next(); err != nil { // query
+		return nil, err
+	}
+	op := &operation{}
+	if p.token.kind == tokenName {
+		op.name = p.token.text
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek(tokenPunctuator, "(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.peek(tokenPunctuator, ")") {
+			definition, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, definition)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.rejectDirectives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet(0)
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinition() (variableDefinition, error) {
+	if err := p.expect(tokenPunctuator, "$"); err != nil {
+		return variableDefinition{}, err
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return variableDefinition{}, err
+	}
+	if err := p.expect(tokenPunctuator, ":"); err != nil {
+		return variableDefinition{}, err
+	}
+	// Types are only parsed: values are checked by the fields they are passed to
+	if err := p.parseType(); err != nil {
+		return variableDefinition{}, err
+	}
+	definition := variableDefinition{name: name}
+	if p.peek(tokenPunctuator, "=") {
+		if err := p.next(); err != nil {
+			return variableDefinition{}, err
+		}
+		if definition.defaultValue, err = p.parseValue(true); err != nil {
+			return variableDefinition{}, err
+		}
+	}
+	return definition, nil
+}
+
+func (p *parser) parseType() error {
+	if p.peek(tokenPunctuator, "[") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		if err := p.parseType(); err != nil {
+			return err
+		}
+		if err := p.expect(tokenPunctuator, "]"); err != nil {
+			return err
+		}
+	} else if _, err := p.parseName(); err != nil {
+		return err
+	}
+	if p.peek(tokenPunctuator, "!") {
+		return p.next()
+	}
+	return nil
+}
+
+func (p *parser) parseFragment() (*fragment, error) {
+	if err := p.next(); err != nil { // fragment
+		return nil, err
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, fmt.Errorf("a fragment can't be named \"on\"")
+	}
+	if err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+	if _, err := p.parseName(); err != nil {
+		return nil, err
+	}
+	if err := p.rejectDirectives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet(0)
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, selections: selections}, nil
+}
+
+func (p *parser) parseSelectionSet(depth int) ([]*selection, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("selections nest deeper than %d levels", maxDepth)
+	}
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+	var selections []*selection
+	for !p.peek(tokenPunctuator, "}") {
+		sel, err := p.parseSelection(depth)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection at position %d", p.token.pos)
+	}
+	return selections, p.next()
+}
+
+func (p *parser) parseSelection(depth int) (*selection, error) {
+	if p.peek(tokenPunctuator, "...") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		// A spread of a named fragment, or an inline fragment with an optional type condition
+		if p.token.kind == tokenName && p.token.text != "on" {
+			name := p.token.text
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			return &selection{spread: name}, p.rejectDirectives()
+		}
+		if p.peek(tokenName, "on") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if _, err := p.parseName(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.rejectDirectives(); err != nil {
+			return nil, err
+		}
+		selections, err := p.parseSelectionSet(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &selection{inline: true, selections: selections}, nil
+	}
+
+	sel := &selection{}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunctuator, ":") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		sel.alias = name
+		if name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	sel.name = name
+
+	if p.peek(tokenPunctuator, "(") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for !p.peek(tokenPunctuator, ")") {
+			argName, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokenPunctuator, ":"); err != nil {
+				return nil, err
+			}
+			argValue, err := p.parseValue(false)
+			if err != nil {
+				return nil, err
+			}
+			sel.arguments = append(sel.arguments, argument{name: argName, value: argValue})
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.rejectDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunctuator, "{") {
+		if sel.selections, err = p.parseSelectionSet(depth + 1); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+// parseValue parses a value, which may only be a variable outside of default values
+func (p *parser) parseValue(constant bool) (*value, error) {
+	tok := p.token
+	switch {
+	case tok.kind == tokenPunctuator && tok.text == "$" && !constant:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		return &value{variable: name}, nil
+	case tok.kind == tokenPunctuator && tok.text == "[":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		list := &value{isList: true}
+		for !p.peek(tokenPunctuator, "]") {
+			item, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			list.list = append(list.list, item)
+		}
+		return list, p.next()
+	case tok.kind == tokenPunctuator && tok.text == "{":
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		object := &value{isObject: true, object: map[string]*value{}}
+		for !p.peek(tokenPunctuator, "}") {
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokenPunctuator, ":"); err != nil {
+				return nil, err
+			}
+			if object.object[name], err = p.parseValue(constant); err != nil {
+				return nil, err
+			}
+		}
+		return object, p.next()
+	case tok.kind == tokenInt, tok.kind == tokenFloat:
+		number, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at position %d", tok.text, tok.pos)
+		}
+		return &value{literal: number}, p.next()
+	case tok.kind == tokenString:
+		return &value{literal: tok.text}, p.next()
+	case tok.kind == tokenName:
+		// true, false, null, or an enum value, which is taken as its name
+		var literal any = tok.text
+		switch tok.text {
+		case "true":
+			literal = true
+		case "false":
+			literal = false
+		case "null":
+			literal = nil
+		}
+		return &value{literal: literal}, p.next()
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+func (p *parser) rejectDirectives() error {
+	if p.peek(tokenPunctuator, "@") {
+		return fmt.Errorf("directives aren't supported (position %d)", p.token.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.token.text
+	return name, p.next()
+}
+
+func (p *parser) peek(kind tokenKind, text string) bool {
+	return p.token.kind == kind && p.token.text == text
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if !p.peek(kind, text) {
+		return fmt.Errorf("expected %q at position %d, found %s", text, p.token.pos, p.describe())
+	}
+	return p.next()
+}
+
+func (p *parser) unexpected() error {
+	return fmt.Errorf("unexpected %s at position %d", p.describe(), p.token.pos)
+}
+
+func (p *parser) describe() string {
+	if p.token.kind == tokenEOF {
+		return "end of document"
+	}
+	return strconv.Quote(p.token.text)
+}
+
+// next reads the next token, skipping whitespace, commas and comments
+func (p *parser) next() error {
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+		} else if c == '#' {
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' && p.source[p.pos] != '\r' {
+				p.pos++
+			}
+		} else if strings.HasPrefix(p.source[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+		} else {
+			break
+		}
+	}
+
+	start := p.pos
+	if p.pos >= len(p.source) {
+		p.token = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	c := p.source[p.pos]
+	switch {
+	case strings.HasPrefix(p.source[p.pos:], "..."):
+		p.pos += 3
+		p.token = token{kind: tokenPunctuator, text: "...", pos: start}
+	case strings.ContainsRune("!$():=@[]{|}&", rune(c)):
+		p.pos++
+		p.token = token{kind: tokenPunctuator, text: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || isLetter(p.source[p.pos]) || isDigit(p.source[p.pos])) {
+			p.pos++
+		}
+		p.token = token{kind: tokenName, text: p.source[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		return p.readNumber()
+	case c == '"':
+		return p.readString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.source[p.pos:])
+		return fmt.Errorf("unexpected character %q at position %d", r, start)
+	}
+	return nil
+}
+
+func (p *parser) readNumber() error {
+	start := p.pos
+	kind := tokenInt
+	if p.source[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		for p.pos < len(p.source) && isDigit(p.source[p.pos]) {
+			p.pos++
+		}
+	}
+	digits()
+	if p.pos < len(p.source) && p.source[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+		digits()
+	}
+	if p.pos < len(p.source) && (p.source[p.pos] == 'e' || p.source[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+		if p.pos < len(p.source) && (p.source[p.pos] == '+' || p.source[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	p.token = token{kind: kind, text: p.source[start:p.pos], pos: start}
+	return nil
+}
+
+func (p *parser) readString() error {
+	start := p.pos
+	if strings.HasPrefix(p.source[p.pos:], `"""`) {
+		end := strings.Index(p.source[p.pos+3:], `"""`)
+		if end < 0 {
+			return fmt.Errorf("unterminated string at position %d", start)
+		}
+		p.token = token{kind: tokenString, text: p.source[p.pos+3 : p.pos+3+end], pos: start}
+		p.pos += 3 + end + 3
+		return nil
+	}
+
+	p.pos++
+	for p.pos < len(p.source) {
+		switch p.source[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '\n', '\r':
+			return fmt.Errorf("unterminated string at position %d", start)
+		case '"':
+			p.pos++
+			// GraphQL string escapes are a subset of JSON's
+			text, err := strconv.Unquote(p.source[start:p.pos])
+			if err != nil {
+				return fmt.Errorf("invalid string at position %d", start)
+			}
+			p.token = token{kind: tokenString, text: text, pos: start}
+			return nil
+		default:
+			p.pos++
+		}
+	}
+	return fmt.Errorf("unterminated string at position %d", start)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -29,8 +29,11 @@ func NewService(registry service.RegistryService) *Service {
 // 1. Local file paths (*.json files) - expects ServerJSON array format
 // 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
 // 3. Registry root URLs (automatically appends /v0/servers and paginates)
+//
+// Seed files may also hold server responses as the list endpoint returns them, whose official
+// metadata sets the initial status of deprecated and deleted versions.
 func (s *Service) ImportFromPath(ctx context.Context, path string) error {
-	servers, err := readSeedFile(ctx, path)
+	records, err := readSeedFile(ctx, path)
 	if err != nil {
 		return fmt.Errorf("failed to read seed data: %w", err)
 	}
@@ -38,14 +41,28 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 	// Import each server using registry service CreateServer
 	var successfullyCreated []string
 	var failedCreations []string
+	var statusChanges []*apiv0.ServerResponse
 
-	for _, server := range servers {
+	for _, record := range records {
+		server := &record.Server
 		_, err := s.registry.CreateServer(ctx, server)
 		if err != nil {
 			failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
 			log.Printf("Failed to create server %s: %v", server.Name, err)
 		} else {
 			successfullyCreated = append(successfullyCreated, server.Name)
+			if service.SeedStatusUpdate(record) != nil {
+				statusChanges = append(statusChanges, record)
+			}
+		}
+	}
+
+	// Set statuses once every server is created, so that replacements later in the seed exist
+	for _, record := range statusChanges {
+		update := service.SeedStatusUpdate(record)
+		if _, err := s.registry.SetServerStatus(ctx, record.Server.Name, record.Server.Version, update); err != nil {
+			failedCreations = append(failedCreations, fmt.Sprintf("%s: failed to set status %s: %v", record.Server.Name, update.Status, err))
+			log.Printf("Failed to set status of server %s: %v", record.Server.Name, err)
 		}
 	}
 
@@ -62,7 +79,7 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 }
 
 // readSeedFile reads seed data from various sources
-func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error) {
+func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerResponse, error) {
 	var data []byte
 	var err error
 
@@ -83,23 +100,29 @@ func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error)
 		return nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
 	}
 
-	// Parse ServerJSON array format
-	var serverResponses []apiv0.ServerJSON
-	if err := json.Unmarshal(data, &serverResponses); err != nil {
+	// Parse ServerJSON array format, or server responses carrying official metadata
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
 		return nil, fmt.Errorf("failed to parse seed data as ServerJSON array format: %w", err)
 	}
 
-	if len(serverResponses) == 0 {
-		return []*apiv0.ServerJSON{}, nil
+	if len(entries) == 0 {
+		return []*apiv0.ServerResponse{}, nil
 	}
 
 	// Validate servers and collect warnings instead of failing the whole batch
-	var validRecords []*apiv0.ServerJSON
+	var validRecords []*apiv0.ServerResponse
 	var invalidServers []string
 	var validationFailures []string
 
-	for _, response := range serverResponses {
-		if err := validators.ValidateServerJSON(&response); err != nil {
+	for i, entry := range entries {
+		record, err := ParseSeedEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse seed entry %d: %w", i, err)
+		}
+		response := &record.Server
+
+		if err := validators.ValidateServerJSON(response); err != nil {
 			// Log warning and track invalid server instead of failing
 			invalidServers = append(invalidServers, response.Name)
 			validationFailures = append(validationFailures, fmt.Sprintf("Server '%s': %v", response.Name, err))
@@ -108,7 +131,7 @@ func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error)
 		}
 
 		// Add valid ServerJSON to records
-		validRecords = append(validRecords, &response)
+		validRecords = append(validRecords, record)
 	}
 
 	// Print summary of validation results
@@ -125,6 +148,30 @@ func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error)
 	return validRecords, nil
 }
 
+// ParseSeedEntry reads a seed entry: a server.json, or a server response as the list endpoint
+// returns it, whose official metadata may give the version a status other than active
+func ParseSeedEntry(entry json.RawMessage) (*apiv0.ServerResponse, error) {
+	var probe struct {
+		Server json.RawMessage `json:"server"`
+	}
+	if err := json.Unmarshal(entry, &probe); err != nil {
+		return nil, err
+	}
+
+	var response apiv0.ServerResponse
+	if probe.Server == nil {
+		if err := json.Unmarshal(entry, &response.Server); err != nil {
+			return nil, err
+		}
+		return &response, nil
+	}
+
+	if err := json.Unmarshal(entry, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
 func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -144,18 +191,22 @@ func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJSON, error) {
-	var allRecords []*apiv0.ServerJSON
+func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerResponse, error) {
+	var allRecords []*apiv0.ServerResponse
 	cursor := ""
+	// Page through a snapshot, so servers published or changed mid-import aren't skipped or seen
+	// twice. Registries that don't support snapshots ignore the parameter.
+	snapshot := "new"
 
 	for {
 		url := baseURL
+		if strings.Contains(url, "?") {
+			url += "&snapshot=" + snapshot
+		} else {
+			url += "?snapshot=" + snapshot
+		}
 		if cursor != "" {
-			if strings.Contains(url, "?") {
-				url += "&cursor=" + cursor
-			} else {
-				url += "?cursor=" + cursor
-			}
+			url += "&cursor=" + cursor
 		}
 
 		data, err := fetchFromHTTP(ctx, url)
@@ -167,6 +218,7 @@ func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJ
 			Servers  []apiv0.ServerResponse `json:"servers"`
 			Metadata *struct {
 				NextCursor string `json:"nextCursor,omitempty"`
+				Snapshot   string `json:"snapshot,omitempty"`
 			} `json:"metadata,omitempty"`
 		}
 
@@ -174,9 +226,9 @@ func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJ
 			return nil, fmt.Errorf("failed to parse registry API response: %w", err)
 		}
 
-		// Extract ServerJSON from each ServerResponse
+		// Extract ServerJSON and status from each ServerResponse
 		for _, serverResponse := range response.Servers {
-			allRecords = append(allRecords, &serverResponse.Server)
+			allRecords = append(allRecords, &serverResponse)
 		}
 
 		// Check if there's a next page
@@ -184,6 +236,9 @@ func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*apiv0.ServerJ
 			break
 		}
 		cursor = response.Metadata.NextCursor
+		if response.Metadata.Snapshot != "" {
+			snapshot = response.Metadata.Snapshot
+		}
 	}
 
 	return allRecords, nil
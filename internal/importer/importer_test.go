@@ -180,6 +180,48 @@ func TestImportService_RegistryPagination(t *testing.T) {
 	assert.Contains(t, serverNames, "com.source/server-2")
 }
 
+func TestImportService_InitialStatus(t *testing.T) {
+	// Seeds may hold server responses, whose official metadata sets a status
+	seedData := []any{
+		apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.test/weather-v2",
+			Description: "Weather server",
+			Version:     "2.0.0",
+		},
+		apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.test/weather",
+				Description: "Weather server",
+				Version:     "1.0.0",
+			},
+			Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{
+				Status:        model.StatusDeprecated,
+				StatusDetails: &apiv0.StatusDetails{Reason: "Moved to v2", ReplacedBy: "io.github.test/weather-v2"},
+			}},
+		},
+	}
+
+	jsonData, err := json.Marshal(seedData)
+	require.NoError(t, err)
+	tempFile := t.TempDir() + "/seed.json"
+	require.NoError(t, os.WriteFile(tempFile, jsonData, 0600))
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+	require.NoError(t, importer.NewService(registryService).ImportFromPath(context.Background(), tempFile))
+
+	server, err := registryService.GetServerByNameAndVersion(context.Background(), "io.github.test/weather", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+	require.NotNil(t, server.Meta.Official.StatusDetails)
+	assert.Equal(t, "io.github.test/weather-v2", server.Meta.Official.StatusDetails.ReplacedBy)
+
+	replacement, err := registryService.GetServerByNameAndVersion(context.Background(), "io.github.test/weather-v2", "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusActive, replacement.Meta.Official.Status)
+}
+
 func TestImportService_ErrorHandling(t *testing.T) {
 	// Create registry service
 	testDB := database.NewTestDB(t)
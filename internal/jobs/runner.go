@@ -0,0 +1,265 @@
+// Package jobs runs asynchronous registry tasks from the database-backed job queue: a pool of
+// workers claims due jobs, retries failed ones with backoff, and leaves those that run out of
+// attempts dead for operators to look into. Recurring tasks are enqueued once per interval
+// however many instances schedule them.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// defaultTimeout bounds a job's run unless its kind is registered with another
+	defaultTimeout = 10 * time.Minute
+	// leaseMargin is how much longer than its timeout a job stays leased to its worker, so that it
+	// isn't claimed again while its outcome is recorded
+	leaseMargin = time.Minute
+	// retryDelay is how long a job waits after its first failed attempt, doubling with each one
+	retryDelay = 30 * time.Second
+	// maxRetryDelay caps the wait between attempts
+	maxRetryDelay = time.Hour
+)
+
+// Queue hands out due jobs and records how their attempts went
+type Queue interface {
+	EnqueueJob(ctx context.Context, job *apiv0.Job) (bool, error)
+	ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*apiv0.Job, error)
+	FinishJob(ctx context.Context, job *apiv0.Job, jobErr error, retryAt *time.Time) error
+}
+
+// Handler runs a job. Returning an error fails the attempt, which is retried unless the error is
+// Permanent or the job ran out of attempts.
+type Handler func(ctx context.Context, job *apiv0.Job) error
+
+// Options configure how the jobs of a kind run
+type Options struct {
+	// MaxAttempts is how many attempts a job gets before it is dead, service.DefaultJobMaxAttempts when 0
+	MaxAttempts int
+	// Timeout bounds each attempt, 10 minutes when 0
+	Timeout time.Duration
+}
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error retrying won't fix, so that the job is dead at once
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// RetryDelay returns how long to wait before retrying a job after its nth failed attempt
+func RetryDelay(attempt int) time.Duration {
+	delay := retryDelay << (min(max(attempt, 1), 16) - 1)
+	return min(delay, maxRetryDelay)
+}
+
+type kind struct {
+	handler Handler
+	options Options
+}
+
+type schedule struct {
+	kind     string
+	interval time.Duration
+}
+
+// Runner runs the jobs of the kinds it has handlers for
+type Runner struct {
+	queue     Queue
+	metrics   *telemetry.Metrics
+	kinds     map[string]kind
+	schedules []schedule
+}
+
+// NewRunner creates a runner for the jobs of a queue, counting the jobs it runs in metrics when
+// they're not nil
+func NewRunner(queue Queue, metrics *telemetry.Metrics) *Runner {
+	return &Runner{queue: queue, metrics: metrics, kinds: map[string]kind{}}
+}
+
+// Handle registers the handler of a kind of job. Kinds are registered before the runner runs.
+func (r *Runner) Handle(name string, handler Handler, options Options) {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = service.DefaultJobMaxAttempts
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultTimeout
+	}
+	r.kinds[name] = kind{handler: handler, options: options}
+}
+
+// Every schedules a job of a kind every interval. Each interval's job is enqueued once, with a
+// dedupe key, so that instances scheduling the same kind share its runs.
+func (r *Runner) Every(name string, interval time.Duration) {
+	r.schedules = append(r.schedules, schedule{kind: name, interval: interval})
+}
+
+// Enqueue queues a job of a registered kind with a payload, once per dedupe key when it isn't empty
+func (r *Runner) Enqueue(ctx context.Context, name string, payload any, dedupeKey string) (bool, error) {
+	k, ok := r.kinds[name]
+	if !ok {
+		return false, fmt.Errorf("unknown job kind %q", name)
+	}
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+	return r.queue.EnqueueJob(ctx, &apiv0.Job{Kind: name, Payload: encoded, DedupeKey: dedupeKey, MaxAttempts: k.options.MaxAttempts})
+}
+
+// Run enqueues scheduled jobs and runs due ones with a number of workers, each polling every
+// interval, until the context is cancelled
+func (r *Runner) Run(ctx context.Context, workers int, interval time.Duration) {
+	var wg sync.WaitGroup
+	if len(r.schedules) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.every(ctx, interval, r.schedule)
+		}()
+	}
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.every(ctx, interval, r.Poll)
+		}()
+	}
+	wg.Wait()
+}
+
+// every calls fn every interval until the context is cancelled, logging failures
+func (r *Runner) every(ctx context.Context, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to run jobs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// schedule enqueues the job of the current interval of each schedule
+func (r *Runner) schedule(ctx context.Context) error {
+	now := time.Now()
+	var errs []error
+	for _, s := range r.schedules {
+		slot := now.Truncate(s.interval)
+		dedupeKey := s.kind + "@" + strconv.FormatInt(slot.Unix(), 10)
+		if _, err := r.Enqueue(ctx, s.kind, struct{}{}, dedupeKey); err != nil {
+			errs = append(errs, fmt.Errorf("failed to schedule %s: %w", s.kind, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Poll runs every due job of the registered kinds
+func (r *Runner) Poll(ctx context.Context) error {
+	names := make([]string, 0, len(r.kinds))
+	for name := range r.kinds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for ctx.Err() == nil {
+		job, err := r.queue.ClaimJob(ctx, names, r.lease())
+		if errors.Is(err, database.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim a job: %w", err)
+		}
+		if err := r.run(ctx, job); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// lease is how long a claimed job stays with its worker: longer than any kind's timeout, as the
+// kind isn't known before the job is claimed
+func (r *Runner) lease() time.Duration {
+	lease := defaultTimeout
+	for _, k := range r.kinds {
+		lease = max(lease, k.options.Timeout)
+	}
+	return lease + leaseMargin
+}
+
+// run runs a claimed job and records the outcome
+func (r *Runner) run(ctx context.Context, job *apiv0.Job) error {
+	k := r.kinds[job.Kind]
+	started := time.Now()
+
+	runCtx, cancel := context.WithTimeout(ctx, k.options.Timeout)
+	jobErr := runHandler(runCtx, k.handler, job)
+	cancel()
+
+	result := service.JobSucceeded
+	var retryAt *time.Time
+	if jobErr != nil {
+		var permanent permanentError
+		if job.Attempts < job.MaxAttempts && !errors.As(jobErr, &permanent) {
+			next := time.Now().Add(RetryDelay(job.Attempts))
+			retryAt = &next
+			result = "retried"
+			log.Printf("Job %s (%s) failed attempt %d, retrying at %s: %v", job.ID, job.Kind, job.Attempts, next.Format(time.RFC3339), jobErr)
+		} else {
+			result = service.JobDead
+			log.Printf("Job %s (%s) failed attempt %d and is dead: %v", job.ID, job.Kind, job.Attempts, jobErr)
+		}
+	}
+	r.record(ctx, job.Kind, result, time.Since(started))
+
+	// Record the outcome even when ctx was cancelled mid-run, so the job isn't left leased until it
+	// goes stale
+	if err := r.queue.FinishJob(context.WithoutCancel(ctx), job, jobErr, retryAt); err != nil {
+		return fmt.Errorf("failed to record job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// runHandler runs a handler, failing the attempt rather than the worker when it panics
+func runHandler(ctx context.Context, handler Handler, job *apiv0.Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// record counts a job's attempt and how long it took
+func (r *Runner) record(ctx context.Context, kind, result string, duration time.Duration) {
+	if r.metrics == nil {
+		return
+	}
+	attributes := metric.WithAttributes(attribute.String("kind", kind), attribute.String("result", result))
+	r.metrics.JobRuns.Add(ctx, 1, attributes)
+	r.metrics.JobDuration.Record(ctx, duration.Seconds(), attributes)
+}
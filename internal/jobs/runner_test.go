@@ -0,0 +1,145 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/jobs"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+type finishedJob struct {
+	id      string
+	err     error
+	retryAt *time.Time
+}
+
+type fakeQueue struct {
+	due      []*apiv0.Job
+	enqueued []*apiv0.Job
+	finished []finishedJob
+}
+
+func (q *fakeQueue) EnqueueJob(_ context.Context, job *apiv0.Job) (bool, error) {
+	for _, queued := range q.enqueued {
+		if job.DedupeKey != "" && queued.DedupeKey == job.DedupeKey {
+			return false, nil
+		}
+	}
+	q.enqueued = append(q.enqueued, job)
+	return true, nil
+}
+
+func (q *fakeQueue) ClaimJob(_ context.Context, kinds []string, _ time.Duration) (*apiv0.Job, error) {
+	for i, job := range q.due {
+		if slices.Contains(kinds, job.Kind) {
+			q.due = slices.Delete(q.due, i, i+1)
+			job.Attempts++
+			return job, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (q *fakeQueue) FinishJob(_ context.Context, job *apiv0.Job, jobErr error, retryAt *time.Time) error {
+	q.finished = append(q.finished, finishedJob{id: job.ID, err: jobErr, retryAt: retryAt})
+	return nil
+}
+
+func TestRunner_Poll(t *testing.T) {
+	ctx := context.Background()
+	queue := &fakeQueue{due: []*apiv0.Job{
+		{ID: "ok", Kind: "test.ok", MaxAttempts: 3},
+		{ID: "flaky", Kind: "test.fail", MaxAttempts: 3},
+		{ID: "exhausted", Kind: "test.fail", Attempts: 2, MaxAttempts: 3},
+		{ID: "permanent", Kind: "test.permanent", MaxAttempts: 3},
+		{ID: "panic", Kind: "test.panic", MaxAttempts: 1},
+		{ID: "unknown", Kind: "test.unknown", MaxAttempts: 3},
+	}}
+
+	runner := jobs.NewRunner(queue, nil)
+	var ran []string
+	runner.Handle("test.ok", func(_ context.Context, job *apiv0.Job) error {
+		ran = append(ran, job.ID)
+		return nil
+	}, jobs.Options{})
+	runner.Handle("test.fail", func(_ context.Context, _ *apiv0.Job) error {
+		return errors.New("unavailable")
+	}, jobs.Options{})
+	runner.Handle("test.permanent", func(_ context.Context, _ *apiv0.Job) error {
+		return jobs.Permanent(errors.New("invalid payload"))
+	}, jobs.Options{})
+	runner.Handle("test.panic", func(_ context.Context, _ *apiv0.Job) error {
+		panic("boom")
+	}, jobs.Options{})
+
+	require.NoError(t, runner.Poll(ctx))
+	assert.Equal(t, []string{"ok"}, ran)
+	require.Len(t, queue.finished, 5)
+	assert.Len(t, queue.due, 1, "jobs of kinds without a handler are left queued")
+
+	outcomes := map[string]finishedJob{}
+	for _, finished := range queue.finished {
+		outcomes[finished.id] = finished
+	}
+	assert.NoError(t, outcomes["ok"].err)
+	assert.Nil(t, outcomes["ok"].retryAt)
+
+	require.Error(t, outcomes["flaky"].err)
+	require.NotNil(t, outcomes["flaky"].retryAt, "a failed attempt is retried")
+	assert.WithinDuration(t, time.Now().Add(jobs.RetryDelay(1)), *outcomes["flaky"].retryAt, 5*time.Second)
+
+	for _, id := range []string{"exhausted", "permanent", "panic"} {
+		assert.Error(t, outcomes[id].err, id)
+		assert.Nil(t, outcomes[id].retryAt, "%s is dead", id)
+	}
+	assert.ErrorContains(t, outcomes["panic"].err, "boom")
+}
+
+func TestRunner_Enqueue(t *testing.T) {
+	ctx := context.Background()
+	queue := &fakeQueue{}
+	runner := jobs.NewRunner(queue, nil)
+	runner.Handle("test.ok", func(_ context.Context, _ *apiv0.Job) error { return nil }, jobs.Options{MaxAttempts: 2})
+
+	enqueued, err := runner.Enqueue(ctx, "test.ok", map[string]string{"server": "com.example/weather"}, "weather")
+	require.NoError(t, err)
+	assert.True(t, enqueued)
+	enqueued, err = runner.Enqueue(ctx, "test.ok", nil, "weather")
+	require.NoError(t, err)
+	assert.False(t, enqueued, "a dedupe key is enqueued once")
+
+	require.Len(t, queue.enqueued, 1)
+	assert.JSONEq(t, `{"server":"com.example/weather"}`, string(queue.enqueued[0].Payload))
+	assert.Equal(t, 2, queue.enqueued[0].MaxAttempts)
+
+	_, err = runner.Enqueue(ctx, "test.unknown", nil, "")
+	assert.Error(t, err)
+}
+
+func TestRunner_Run(t *testing.T) {
+	queue := &fakeQueue{}
+	runner := jobs.NewRunner(queue, nil)
+	runner.Handle("test.recurring", func(_ context.Context, _ *apiv0.Job) error { return nil }, jobs.Options{})
+	runner.Every("test.recurring", time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	runner.Run(ctx, 2, 10*time.Millisecond)
+
+	require.Len(t, queue.enqueued, 1, "a recurring job is enqueued once per interval")
+	assert.Equal(t, "test.recurring", queue.enqueued[0].Kind)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, jobs.RetryDelay(1))
+	assert.Equal(t, time.Minute, jobs.RetryDelay(2))
+	assert.Equal(t, time.Hour, jobs.RetryDelay(20))
+}
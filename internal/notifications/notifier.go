@@ -0,0 +1,211 @@
+// Package notifications delivers events about namespaces to their owners by email and webhook
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/smtp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Types of event owners can subscribe to
+const (
+	EventVersionPublished        = "version-published"
+	EventRevalidationFailed      = "revalidation-failed"
+	EventServerReported          = "server-reported"
+	EventOwnershipClaimAttempted = "ownership-claim-attempted"
+	EventUpdateAvailable         = "update-available"
+	EventServerModerated         = "server-moderated"
+)
+
+// EventTypes lists every type of event, in the order they are documented
+var EventTypes = []string{EventVersionPublished, EventRevalidationFailed, EventServerReported, EventOwnershipClaimAttempted, EventUpdateAvailable, EventServerModerated}
+
+// Delivery channels
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Webhook request headers
+const (
+	EventHeader     = "X-MCP-Registry-Event"
+	SignatureHeader = "X-MCP-Registry-Signature"
+)
+
+// deliveryTimeout bounds how long delivering one event to all subscribers may take
+const deliveryTimeout = 30 * time.Second
+
+// Event is something that happened in a namespace. It is the JSON body of webhook deliveries.
+type Event struct {
+	Type       string    `json:"type"`
+	Namespace  string    `json:"namespace"`
+	ServerName string    `json:"serverName,omitempty"`
+	Version    string    `json:"version,omitempty"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// SubscriptionStore provides the subscriptions of a namespace
+type SubscriptionStore interface {
+	ListNotificationSubscriptions(ctx context.Context, tx pgx.Tx, namespace string) ([]apiv0.NotificationSubscription, error)
+}
+
+// Notifier delivers events to the subscriptions of their namespace
+type Notifier struct {
+	store      SubscriptionStore
+	httpClient *http.Client
+	smtpAddr   string
+	smtpFrom   string
+	smtpAuth   smtp.Auth
+}
+
+// NewNotifier creates a notifier. Email delivery is only enabled when SMTP is configured, and
+// webhooks are only posted to public addresses over https, redirects included.
+func NewNotifier(store SubscriptionStore, cfg *config.Config) *Notifier {
+	return NewNotifierWithClient(store, cfg, safehttp.NewClient(10*time.Second, "https"))
+}
+
+// NewNotifierWithClient creates a notifier that posts webhooks with the given client
+func NewNotifierWithClient(store SubscriptionStore, cfg *config.Config, httpClient *http.Client) *Notifier {
+	n := &Notifier{
+		store:      store,
+		httpClient: httpClient,
+	}
+	if EmailEnabled(cfg) {
+		n.smtpAddr = cfg.SMTPAddress
+		n.smtpFrom = cfg.SMTPFrom
+		if cfg.SMTPUsername != "" {
+			host, _, _ := strings.Cut(cfg.SMTPAddress, ":")
+			n.smtpAuth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, host)
+		}
+	}
+	return n
+}
+
+// EmailEnabled reports whether the registry is configured to send email notifications
+func EmailEnabled(cfg *config.Config) bool {
+	return cfg.SMTPAddress != "" && cfg.SMTPFrom != ""
+}
+
+// Notify delivers an event in the background, logging failures, so that whatever triggered it
+// doesn't wait on subscribers
+func (n *Notifier) Notify(event Event) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		defer cancel()
+		if err := n.Deliver(ctx, event); err != nil {
+			log.Printf("Failed to deliver %s notification for %s: %v", event.Type, event.Namespace, err)
+		}
+	}()
+}
+
+// Deliver sends an event to every subscription of its namespace that wants it, returning the
+// errors of failed deliveries
+func (n *Notifier) Deliver(ctx context.Context, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	subscriptions, err := n.store.ListNotificationSubscriptions(ctx, nil, event.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	var errs []error
+	for _, sub := range subscriptions {
+		if !slices.Contains(sub.Events, event.Type) {
+			continue
+		}
+
+		var err error
+		switch sub.Channel {
+		case ChannelWebhook:
+			err = n.sendWebhook(ctx, sub, event)
+		case ChannelEmail:
+			err = n.sendEmail(sub, event)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", sub.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) sendWebhook(ctx context.Context, sub apiv0.NotificationSubscription, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mcp-registry")
+	req.Header.Set(EventHeader, event.Type)
+	if sub.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (n *Notifier) sendEmail(sub apiv0.NotificationSubscription, event Event) error {
+	if n.smtpAddr == "" {
+		// Subscriptions made while email was configured outlive the configuration
+		return nil
+	}
+
+	subject := "[MCP Registry] " + event.Message
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n", n.smtpFrom, sub.Target, subject)
+	fmt.Fprintf(&msg, "%s\r\n\r\nNamespace: %s\r\n", event.Message, event.Namespace)
+	if event.ServerName != "" {
+		fmt.Fprintf(&msg, "Server: %s\r\n", event.ServerName)
+	}
+	if event.Version != "" {
+		fmt.Fprintf(&msg, "Version: %s\r\n", event.Version)
+	}
+	fmt.Fprintf(&msg, "\r\nYou receive this because you subscribed to %s notifications for %s.\r\n", event.Type, event.Namespace)
+
+	if err := smtp.SendMail(n.smtpAddr, n.smtpAuth, n.smtpFrom, []string{sub.Target}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// Sign returns the signature header value of a webhook body: its HMAC-SHA256 under the
+// subscription's secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
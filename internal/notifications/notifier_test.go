@@ -0,0 +1,81 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+type fakeStore []apiv0.NotificationSubscription
+
+func (s fakeStore) ListNotificationSubscriptions(_ context.Context, _ pgx.Tx, namespace string) ([]apiv0.NotificationSubscription, error) {
+	var subscriptions []apiv0.NotificationSubscription
+	for _, sub := range s {
+		if sub.Namespace == namespace {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	return subscriptions, nil
+}
+
+func TestDeliver_SignsWebhooksForSubscribedEvents(t *testing.T) {
+	var received []notifications.Event
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, notifications.Sign("s3cret", body), r.Header.Get(notifications.SignatureHeader))
+
+		var event notifications.Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, event.Type, r.Header.Get(notifications.EventHeader))
+		received = append(received, event)
+	}))
+	t.Cleanup(webhook.Close)
+
+	notifier := notifications.NewNotifierWithClient(fakeStore{
+		{ID: "1", Namespace: "io.github.alice", Channel: notifications.ChannelWebhook, Target: webhook.URL, Secret: "s3cret",
+			Events: []string{notifications.EventVersionPublished}},
+		{ID: "2", Namespace: "io.github.bob", Channel: notifications.ChannelWebhook, Target: webhook.URL,
+			Events: notifications.EventTypes},
+	}, &config.Config{}, webhook.Client())
+
+	require.NoError(t, notifier.Deliver(context.Background(), notifications.Event{
+		Type: notifications.EventVersionPublished, Namespace: "io.github.alice", ServerName: "io.github.alice/weather", Version: "1.0.0",
+	}))
+	require.NoError(t, notifier.Deliver(context.Background(), notifications.Event{
+		Type: notifications.EventServerReported, Namespace: "io.github.alice", ServerName: "io.github.alice/weather",
+	}))
+
+	require.Len(t, received, 1)
+	assert.Equal(t, "io.github.alice/weather", received[0].ServerName)
+	assert.False(t, received[0].OccurredAt.IsZero())
+}
+
+func TestDeliver_ReportsFailedWebhooks(t *testing.T) {
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(webhook.Close)
+
+	notifier := notifications.NewNotifierWithClient(fakeStore{
+		{ID: "1", Namespace: "io.github.alice", Channel: notifications.ChannelWebhook, Target: webhook.URL, Events: notifications.EventTypes},
+		// Email subscriptions are skipped while SMTP isn't configured
+		{ID: "2", Namespace: "io.github.alice", Channel: notifications.ChannelEmail, Target: "alice@example.com", Events: notifications.EventTypes},
+	}, &config.Config{}, webhook.Client())
+
+	err := notifier.Deliver(context.Background(), notifications.Event{Type: notifications.EventVersionPublished, Namespace: "io.github.alice"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subscription 1: webhook returned status 500")
+	assert.NotContains(t, err.Error(), "subscription 2")
+}
@@ -0,0 +1,144 @@
+// Package ownership checks the proof of namespace claims: the DNS TXT records and HTTPS
+// well-known files claimants publish their challenge token in
+package ownership
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// maxChallengeSize is how much of a well-known file is read looking for the token
+const maxChallengeSize = 4096
+
+// ClaimQueue hands out the claims that are due for a check and records whether their proof was found
+type ClaimQueue interface {
+	LeaseNamespaceClaimCheck(ctx context.Context) (*database.NamespaceClaimCheck, error)
+	RecordNamespaceClaimCheck(ctx context.Context, claim *database.NamespaceClaimCheck, checkErr error) error
+}
+
+// TXTResolver looks up the TXT records of a domain name
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Verifier checks due claims, one at a time
+type Verifier struct {
+	queue      ClaimQueue
+	resolver   TXTResolver
+	httpClient *http.Client
+}
+
+// NewVerifier creates a verifier for the claims of a queue
+func NewVerifier(queue ClaimQueue) *Verifier {
+	// Claimants choose the domain, so its well-known file is fetched like other URLs they supply
+	httpClient := safehttp.NewClient(10*time.Second, "https")
+	// The well-known file must be served by the domain itself
+	httpClient.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return NewVerifierWithClients(queue, &net.Resolver{}, httpClient)
+}
+
+// NewVerifierWithClients creates a verifier that looks up TXT records and fetches well-known
+// files with the given clients
+func NewVerifierWithClients(queue ClaimQueue, resolver TXTResolver, httpClient *http.Client) *Verifier {
+	return &Verifier{
+		queue:      queue,
+		resolver:   resolver,
+		httpClient: httpClient,
+	}
+}
+
+// Poll checks every claim that is due. A claim whose proof isn't found fails the check, which the
+// queue schedules to be retried or expires the claim for.
+func (v *Verifier) Poll(ctx context.Context) error {
+	for ctx.Err() == nil {
+		claim, err := v.queue.LeaseNamespaceClaimCheck(ctx)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lease a claim: %w", err)
+		}
+
+		err = v.check(ctx, claim)
+		if err != nil {
+			log.Printf("Proof of claim %s on namespace %s not found: %v", claim.ID, claim.Namespace, err)
+		}
+
+		// Record the outcome even when ctx was cancelled mid-check, so the claim isn't left leased
+		// until it goes stale
+		if err := v.queue.RecordNamespaceClaimCheck(context.WithoutCancel(ctx), claim, err); err != nil {
+			return fmt.Errorf("failed to record check of claim %s: %w", claim.ID, err)
+		}
+	}
+	return ctx.Err()
+}
+
+// check looks for the proof of a claim, returning why it wasn't found
+func (v *Verifier) check(ctx context.Context, claim *database.NamespaceClaimCheck) error {
+	if claim.Challenge == nil {
+		// GitHub identities can only be proven by claiming again
+		return fmt.Errorf("%s claims are renewed by claiming the namespace again", claim.Method)
+	}
+
+	switch claim.Method {
+	case service.NamespaceClaimDNS:
+		return v.checkDNS(ctx, claim.Challenge.RecordName, claim.Challenge.RecordValue)
+	case service.NamespaceClaimHTTP:
+		return v.checkHTTP(ctx, claim.Challenge.URL, claim.Challenge.Token)
+	default:
+		return fmt.Errorf("unknown claim method %q", claim.Method)
+	}
+}
+
+// checkDNS looks for a TXT record with the challenge
+func (v *Verifier) checkDNS(ctx context.Context, name, value string) error {
+	records, err := v.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to look up TXT records of %s: %w", name, err)
+	}
+	if !slices.Contains(records, value) {
+		return fmt.Errorf("no TXT record of %s contains the challenge", name)
+	}
+	return nil
+}
+
+// checkHTTP fetches the well-known file, which must contain only the token
+func (v *Verifier) checkHTTP(ctx context.Context, url, token string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "mcp-registry/1.0")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxChallengeSize))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if strings.TrimSpace(string(body)) != token {
+		return fmt.Errorf("%s doesn't contain the challenge token", url)
+	}
+	return nil
+}
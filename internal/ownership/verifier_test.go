@@ -0,0 +1,135 @@
+package ownership_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/ownership"
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+type fakeQueue struct {
+	due      []*database.NamespaceClaimCheck
+	recorded map[string]error
+}
+
+func (q *fakeQueue) LeaseNamespaceClaimCheck(_ context.Context) (*database.NamespaceClaimCheck, error) {
+	if len(q.due) == 0 {
+		return nil, database.ErrNotFound
+	}
+	claim := q.due[0]
+	q.due = q.due[1:]
+	return claim, nil
+}
+
+func (q *fakeQueue) RecordNamespaceClaimCheck(_ context.Context, claim *database.NamespaceClaimCheck, checkErr error) error {
+	q.recorded[claim.ID] = checkErr
+	return nil
+}
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host %s", name)
+	}
+	return records, nil
+}
+
+func dueClaim(id, method string, challenge apiv0.ClaimChallenge) *database.NamespaceClaimCheck {
+	return &database.NamespaceClaimCheck{NamespaceClaim: apiv0.NamespaceClaim{
+		ID:        id,
+		Namespace: "com.example",
+		Method:    method,
+		Challenge: &challenge,
+	}}
+}
+
+func TestPoll_ChecksDNSRecords(t *testing.T) {
+	resolver := fakeResolver{
+		"_mcp-registry-challenge.example.com": {"v=spf1 -all", "mcp-registry-challenge=abc123"},
+		"_mcp-registry-challenge.example.org": {"mcp-registry-challenge=stale"},
+	}
+	queue := &fakeQueue{recorded: map[string]error{}, due: []*database.NamespaceClaimCheck{
+		dueClaim("found", service.NamespaceClaimDNS, apiv0.ClaimChallenge{
+			Token: "abc123", RecordName: "_mcp-registry-challenge.example.com", RecordValue: "mcp-registry-challenge=abc123",
+		}),
+		dueClaim("mismatch", service.NamespaceClaimDNS, apiv0.ClaimChallenge{
+			Token: "abc123", RecordName: "_mcp-registry-challenge.example.org", RecordValue: "mcp-registry-challenge=abc123",
+		}),
+		dueClaim("missing", service.NamespaceClaimDNS, apiv0.ClaimChallenge{
+			Token: "abc123", RecordName: "_mcp-registry-challenge.example.net", RecordValue: "mcp-registry-challenge=abc123",
+		}),
+	}}
+
+	require.NoError(t, ownership.NewVerifierWithClients(queue, resolver, http.DefaultClient).Poll(context.Background()))
+
+	require.Len(t, queue.recorded, 3)
+	assert.NoError(t, queue.recorded["found"])
+	assert.ErrorContains(t, queue.recorded["mismatch"], "no TXT record")
+	assert.ErrorContains(t, queue.recorded["missing"], "no such host")
+}
+
+func TestPoll_ChecksWellKnownFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "mcp-registry/1.0", r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/valid":
+			_, _ = w.Write([]byte("abc123\n"))
+		case "/wrong":
+			_, _ = w.Write([]byte("something else"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	queue := &fakeQueue{recorded: map[string]error{}, due: []*database.NamespaceClaimCheck{
+		dueClaim("valid", service.NamespaceClaimHTTP, apiv0.ClaimChallenge{Token: "abc123", URL: server.URL + "/valid"}),
+		dueClaim("wrong", service.NamespaceClaimHTTP, apiv0.ClaimChallenge{Token: "abc123", URL: server.URL + "/wrong"}),
+		dueClaim("gone", service.NamespaceClaimHTTP, apiv0.ClaimChallenge{Token: "abc123", URL: server.URL + "/gone"}),
+	}}
+
+	require.NoError(t, ownership.NewVerifierWithClients(queue, &net.Resolver{}, server.Client()).Poll(context.Background()))
+
+	require.Len(t, queue.recorded, 3)
+	assert.NoError(t, queue.recorded["valid"])
+	assert.ErrorContains(t, queue.recorded["wrong"], "doesn't contain the challenge token")
+	assert.ErrorContains(t, queue.recorded["gone"], "status 404")
+}
+
+func TestPoll_RefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("abc123"))
+	}))
+	t.Cleanup(server.Close)
+
+	queue := &fakeQueue{recorded: map[string]error{}, due: []*database.NamespaceClaimCheck{
+		dueClaim("loopback", service.NamespaceClaimHTTP, apiv0.ClaimChallenge{Token: "abc123", URL: server.URL + "/valid"}),
+	}}
+
+	require.NoError(t, ownership.NewVerifier(queue).Poll(context.Background()))
+
+	assert.ErrorIs(t, queue.recorded["loopback"], safehttp.ErrForbiddenAddress)
+}
+
+func TestPoll_ExpiresGitHubClaims(t *testing.T) {
+	claim := dueClaim("github", service.NamespaceClaimGitHub, apiv0.ClaimChallenge{})
+	claim.Challenge = nil
+	queue := &fakeQueue{recorded: map[string]error{}, due: []*database.NamespaceClaimCheck{claim}}
+
+	require.NoError(t, ownership.NewVerifier(queue).Poll(context.Background()))
+
+	assert.ErrorContains(t, queue.recorded["github"], "claiming the namespace again")
+}
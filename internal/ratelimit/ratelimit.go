@@ -0,0 +1,126 @@
+// Package ratelimit keeps the token buckets the API rate limits requests with, in memory or in
+// Redis when several replicas share the limits
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// Limit is a token bucket: up to Burst requests at once, refilled at PerMinute requests a minute
+type Limit struct {
+	PerMinute int
+	Burst     int
+}
+
+// Enabled reports whether the limit allows a finite rate of requests
+func (l Limit) Enabled() bool {
+	return l.PerMinute > 0 && l.Burst > 0
+}
+
+// perSecond is the rate the bucket refills at
+func (l Limit) perSecond() float64 {
+	return float64(l.PerMinute) / 60
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until a token is available, when the request wasn't allowed
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets by key
+type Store interface {
+	// Take takes a token from the bucket of a key, if it has one
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// NewStore creates the store the configuration asks for: Redis when a URL is set, memory otherwise
+func NewStore(cfg *config.Config) (Store, error) {
+	if cfg.RateLimitRedisURL != "" {
+		return NewRedisStore(cfg.RateLimitRedisURL)
+	}
+	return NewMemoryStore(), nil
+}
+
+// result describes a bucket holding tokens after a request was or wasn't allowed
+func result(limit Limit, tokens float64, allowed bool) Result {
+	rate := limit.perSecond()
+	r := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     secondsDuration((float64(limit.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = secondsDuration((1 - tokens) / rate)
+	}
+	return r
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
+
+// sweepInterval is how often the memory store drops buckets that have refilled
+const sweepInterval = time.Minute
+
+// MemoryStore keeps token buckets in memory, so each replica limits requests on its own
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), lastSweep: time.Now()}
+}
+
+// Take takes a token from the bucket of a key, if it has one
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A refilled bucket is the same as a new one, so buckets of clients that went away are dropped
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for key, b := range s.buckets {
+			if !now.Before(b.fullAt) {
+				delete(s.buckets, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updatedAt).Seconds()*limit.perSecond())
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	r := result(limit, b.tokens, allowed)
+	b.fullAt = now.Add(r.Reset)
+	return r, nil
+}
@@ -0,0 +1,165 @@
+package ratelimit_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	limit := ratelimit.Limit{PerMinute: 60, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(context.Background(), "caller", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(context.Background(), "caller", limit)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed, "the burst is used up")
+	assert.Equal(t, 0, result.Remaining)
+	assert.Greater(t, result.RetryAfter, time.Duration(0))
+	assert.LessOrEqual(t, result.RetryAfter, time.Second)
+	assert.LessOrEqual(t, result.Reset, 3*time.Second)
+
+	result, err = store.Take(context.Background(), "other-caller", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "callers have their own buckets")
+}
+
+func TestMemoryStoreRefills(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	limit := ratelimit.Limit{PerMinute: 6000, Burst: 1}
+
+	result, err := store.Take(context.Background(), "caller", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	// 6000 a minute refills a token every 10ms
+	time.Sleep(20 * time.Millisecond)
+	result, err = store.Take(context.Background(), "caller", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+// fakeRedis answers commands from a connection with canned replies, recording the commands
+type fakeRedis struct {
+	mu       sync.Mutex
+	commands [][]string
+}
+
+func (f *fakeRedis) serve(t *testing.T, listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			reader := bufio.NewReader(conn)
+			for {
+				command, err := readCommand(reader)
+				if err != nil {
+					return
+				}
+				f.mu.Lock()
+				f.commands = append(f.commands, command)
+				f.mu.Unlock()
+
+				var reply string
+				switch command[0] {
+				case "AUTH", "SELECT":
+					reply = "+OK\r\n"
+				case "EVAL":
+					reply = "*2\r\n:1\r\n$3\r\n4.5\r\n"
+				default:
+					reply = "-ERR unknown command\r\n"
+				}
+				if _, err := conn.Write([]byte(reply)); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	command := make([]string, count)
+	for i := range command {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		command[i] = string(arg[:length])
+	}
+	return command, nil
+}
+
+func TestRedisStore(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	redis := &fakeRedis{}
+	go redis.serve(t, listener)
+
+	store, err := ratelimit.NewRedisStore(fmt.Sprintf("redis://:secret@%s/2", listener.Addr()))
+	require.NoError(t, err)
+
+	limit := ratelimit.Limit{PerMinute: 60, Burst: 10}
+	for range 2 {
+		result, err := store.Take(context.Background(), "read:ip:192.0.2.1", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 10, result.Limit)
+		assert.Equal(t, 4, result.Remaining)
+		assert.Equal(t, 5500*time.Millisecond, result.Reset)
+	}
+
+	redis.mu.Lock()
+	defer redis.mu.Unlock()
+	require.Len(t, redis.commands, 4, "the connection is reused")
+	assert.Equal(t, []string{"AUTH", "secret"}, redis.commands[0])
+	assert.Equal(t, []string{"SELECT", "2"}, redis.commands[1])
+	assert.Equal(t, "EVAL", redis.commands[2][0])
+	assert.Equal(t, []string{"mcp-registry:ratelimit:read:ip:192.0.2.1", "1", "10"}, redis.commands[2][3:])
+}
+
+func TestNewRedisStoreInvalidURL(t *testing.T) {
+	for _, url := range []string{"http://redis:6379", "redis://redis:6379/db", "::"} {
+		_, err := ratelimit.NewRedisStore(url)
+		assert.Error(t, err, url)
+	}
+}
@@ -0,0 +1,255 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// redisTimeout bounds a round trip to Redis, so that a slow Redis doesn't hold up requests
+	redisTimeout = time.Second
+	// maxIdleRedisConns is how many connections are kept open between requests
+	maxIdleRedisConns = 16
+)
+
+// takeScript takes a token from a bucket kept as a hash of its tokens and when they were counted,
+// timed by the Redis clock so that replicas with skewed clocks agree. The hash expires once the
+// bucket would be full again. Tokens are returned as a string, since Lua numbers are returned
+// truncated to integers.
+const takeScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+// redisKeyPrefix namespaces the buckets in a Redis shared with other applications
+const redisKeyPrefix = "mcp-registry:ratelimit:"
+
+// RedisStore keeps token buckets in Redis, so that replicas share them. It speaks just enough of
+// the Redis protocol to run the script that takes tokens.
+type RedisStore struct {
+	address  string
+	useTLS   bool
+	username string
+	password string
+	database int
+	idle     chan *redisConn
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisStore creates a store using the Redis at a redis:// or rediss:// URL, such as
+// redis://:password@redis:6379/0
+func NewRedisStore(redisURL string) (*RedisStore, error) {
+	parsed, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	if parsed.Scheme != "redis" && parsed.Scheme != "rediss" {
+		return nil, fmt.Errorf("invalid Redis URL: scheme must be redis or rediss, got %q", parsed.Scheme)
+	}
+
+	store := &RedisStore{
+		address: parsed.Host,
+		useTLS:  parsed.Scheme == "rediss",
+		idle:    make(chan *redisConn, maxIdleRedisConns),
+	}
+	if parsed.Port() == "" {
+		store.address = net.JoinHostPort(parsed.Hostname(), "6379")
+	}
+	if parsed.User != nil {
+		store.username = parsed.User.Username()
+		store.password, _ = parsed.User.Password()
+	}
+	if db := strings.TrimPrefix(parsed.Path, "/"); db != "" {
+		if store.database, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid Redis database %q: %w", db, err)
+		}
+	}
+	return store, nil
+}
+
+// Take takes a token from the bucket of a key, if it has one
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := s.do(ctx, "EVAL", takeScript, "1", redisKeyPrefix+key,
+		strconv.FormatFloat(limit.perSecond(), 'f', -1, 64), strconv.Itoa(limit.Burst))
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := reply.([]any)
+	if !ok || len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected Redis reply: %v", reply)
+	}
+	allowed, _ := values[0].(int64)
+	tokensText, _ := values[1].(string)
+	tokens, err := strconv.ParseFloat(tokensText, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected Redis reply: %v", reply)
+	}
+	return result(limit, tokens, allowed == 1), nil
+}
+
+// do runs a command on an idle connection, or a new one, and returns its reply
+func (s *RedisStore) do(ctx context.Context, args ...string) (any, error) {
+	var conn *redisConn
+	select {
+	case conn = <-s.idle:
+	default:
+		var err error
+		if conn, err = s.dial(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := conn.do(ctx, args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection may be half way through a reply, so it can't be reused
+		conn.conn.Close()
+		return nil, err
+	}
+	select {
+	case s.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+	return reply, err
+}
+
+// dial opens a connection, authenticating and selecting the database as configured
+func (s *RedisStore) dial(ctx context.Context) (*redisConn, error) {
+	dialer := &net.Dialer{Timeout: redisTimeout}
+	var conn net.Conn
+	var err error
+	if s.useTLS {
+		host, _, _ := net.SplitHostPort(s.address)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}).DialContext(ctx, "tcp", s.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	if s.password != "" {
+		args := []string{"AUTH", s.password}
+		if s.username != "" {
+			args = []string{"AUTH", s.username, s.password}
+		}
+		if _, err := c.do(ctx, args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to authenticate with Redis: %w", err)
+		}
+	}
+	if s.database != 0 {
+		if _, err := c.do(ctx, "SELECT", strconv.Itoa(s.database)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select Redis database: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// redisError is an error reply, after which the connection is still usable
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// do sends a command as an array of bulk strings and reads its reply
+func (c *redisConn) do(ctx context.Context, args ...string) (any, error) {
+	deadline := time.Now().Add(redisTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := c.conn.Write([]byte(command.String())); err != nil {
+		return nil, fmt.Errorf("failed to send Redis command: %w", err)
+	}
+	return c.readReply()
+}
+
+// readReply reads a reply: a simple string, error, integer, bulk string or array of replies
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Redis reply: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty Redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, fmt.Errorf("failed to read Redis reply: %w", err)
+		}
+		return string(data[:length]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		values := make([]any, count)
+		for i := range values {
+			// Errors inside arrays are values rather than failures of the command
+			value, err := c.readReply()
+			var redisErr redisError
+			if err != nil && !errors.As(err, &redisErr) {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unexpected Redis reply: %q", line)
+	}
+}
@@ -0,0 +1,131 @@
+// Package readme fetches the READMEs servers link to in their publisher-provided metadata, so the
+// registry can serve them sanitized and rendered rather than leaving clients to fetch them
+// cross-origin
+package readme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PublisherProvidedKey is the key of the README URL in a server's publisher-provided metadata
+const PublisherProvidedKey = "readme"
+
+const (
+	// maxReadmeBytes bounds the size of a README, which is far more than any real one needs
+	maxReadmeBytes = 1 << 20
+	// maxCachedReadmes bounds the memory the cache takes
+	maxCachedReadmes = 1000
+)
+
+// ErrNoReadme is returned for servers whose metadata doesn't link to a README over https
+var ErrNoReadme = errors.New("server has no README")
+
+// URL returns the README URL of a server, which must be an https URL
+func URL(server *apiv0.ServerJSON) (string, error) {
+	if server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return "", ErrNoReadme
+	}
+	readmeURL, ok := server.Meta.PublisherProvided[PublisherProvidedKey].(string)
+	if !ok {
+		return "", ErrNoReadme
+	}
+	parsed, err := url.Parse(readmeURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return "", ErrNoReadme
+	}
+	return readmeURL, nil
+}
+
+// Fetcher fetches READMEs, caching them so that popular servers don't hit their hosts on every read
+type Fetcher struct {
+	client *http.Client
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedReadme
+}
+
+type cachedReadme struct {
+	markdown  string
+	fetchedAt time.Time
+}
+
+// NewFetcher creates a fetcher that caches READMEs for the configured time. READMEs are only
+// fetched from public addresses over https, redirects included, since publishers choose the URL
+// and the registry serves back what it gets.
+func NewFetcher(cfg *config.Config) *Fetcher {
+	return NewFetcherWithClient(safehttp.NewClient(10*time.Second, "https"), cfg.ReadmeCacheTTL)
+}
+
+// NewFetcherWithClient creates a fetcher with a custom HTTP client
+func NewFetcherWithClient(client *http.Client, ttl time.Duration) *Fetcher {
+	return &Fetcher{client: client, ttl: ttl, entries: make(map[string]cachedReadme)}
+}
+
+// Fetch returns the sanitized markdown of the README at a URL, and when it was fetched
+func (f *Fetcher) Fetch(ctx context.Context, readmeURL string) (string, time.Time, error) {
+	f.mu.Lock()
+	cached, ok := f.entries[readmeURL]
+	f.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < f.ttl {
+		return cached.markdown, cached.fetchedAt, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readmeURL, nil)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create README request: %w", err)
+	}
+	req.Header.Set("Accept", "text/markdown, text/plain;q=0.9, */*;q=0.1")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to fetch README: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("README request failed with status: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxReadmeBytes+1))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read README: %w", err)
+	}
+	if len(body) > maxReadmeBytes {
+		return "", time.Time{}, fmt.Errorf("README exceeds %d bytes", maxReadmeBytes)
+	}
+
+	fetched := cachedReadme{markdown: Sanitize(string(body)), fetchedAt: time.Now()}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.entries) >= maxCachedReadmes {
+		f.evictLocked()
+	}
+	f.entries[readmeURL] = fetched
+	return fetched.markdown, fetched.fetchedAt, nil
+}
+
+// evictLocked drops expired READMEs, or the oldest one when none have expired
+func (f *Fetcher) evictLocked() {
+	var oldest string
+	for key, entry := range f.entries {
+		if time.Since(entry.fetchedAt) >= f.ttl {
+			delete(f.entries, key)
+			continue
+		}
+		if oldest == "" || entry.fetchedAt.Before(f.entries[oldest].fetchedAt) {
+			oldest = key
+		}
+	}
+	if len(f.entries) >= maxCachedReadmes {
+		delete(f.entries, oldest)
+	}
+}
@@ -0,0 +1,110 @@
+package readme_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/readme"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL(t *testing.T) {
+	withReadme := func(value any) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{Meta: &apiv0.ServerMeta{PublisherProvided: map[string]any{"readme": value}}}
+	}
+
+	readmeURL, err := readme.URL(withReadme("https://example.com/README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/README.md", readmeURL)
+
+	for _, server := range []*apiv0.ServerJSON{{}, withReadme("http://example.com/README.md"), withReadme("/README.md"), withReadme(42)} {
+		_, err := readme.URL(server)
+		assert.ErrorIs(t, err, readme.ErrNoReadme)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	sanitized := readme.Sanitize("# Weather\n<script>alert(1)</script>\n<img src=x onerror=\"alert(1)\">\n<a href=\"javascript:alert(1)\">x</a>\n<SCRIPT src=\"https://evil.example\"></SCRIPT>")
+	assert.NotContains(t, sanitized, "alert(1)</script>")
+	assert.NotContains(t, sanitized, "onerror")
+	assert.NotContains(t, sanitized, "javascript:")
+	assert.NotContains(t, sanitized, "evil.example")
+	assert.Contains(t, sanitized, "# Weather")
+
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"handlers without whitespace", "<svg/onload=alert(1)>Weather", "Weather"},
+		{"form actions", "<form><button formaction=javascript:alert(1)>Go</button></form>", "Go"},
+		{"xlink", `<svg><a xlink:href="javascript:alert(1)"><text>x</text></a></svg>`, "x"},
+		{"comments", "<!-- <img src=x onerror=alert(1)> -->Weather", "Weather"},
+		{"markdown script links", "[x](javascript:alert(1))", "[x](#)"},
+		{"escaped script links", "[x](java&#115;cript&#58;alert(1)) [y](javascript\\:alert(1))", "[x](#) [y](#)"},
+		{"data links", "![x](data:text/html;base64,PHNjcmlwdD4=)", "![x](#)"},
+		{"reference definitions", "[x]: vbscript:msgbox", "[x]: #"},
+		{"safe links", "[docs](docs/usage.md) [home](https://example.com)", "[docs](docs/usage.md) [home](https://example.com)"},
+		{"autolinks", "<https://example.com> <alice@example.com> <javascript:alert(1)>", "<https://example.com> <alice@example.com> "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, readme.Sanitize(tt.markdown))
+		})
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"heading", "## Install", "<h2>Install</h2>\n"},
+		{"paragraph with emphasis", "Fast **and** *simple*\nweather", "<p>Fast <strong>and</strong> <em>simple</em> weather</p>\n"},
+		{"fenced code is escaped", "```bash\necho '<b>'\n```", "<pre><code class=\"language-bash\">echo &#39;&lt;b&gt;&#39;</code></pre>\n"},
+		{"list", "- one\n- `two`", "<ul>\n<li>one</li>\n<li><code>two</code></li>\n</ul>\n"},
+		{"relative link", "[docs](docs/usage.md)", "<p><a href=\"https://example.com/repo/docs/usage.md\" rel=\"nofollow\">docs</a></p>\n"},
+		{"linked badge", "[![build](https://img.example/b_a_d.svg)](https://ci.example)",
+			"<p><a href=\"https://ci.example\" rel=\"nofollow\"><img src=\"https://img.example/b_a_d.svg\" alt=\"build\"></a></p>\n"},
+		{"script URLs are dropped", "[click](javascript:void)", "<p>click</p>\n"},
+		{"raw HTML is left out", "<div align=\"center\">Weather</div>", "<p>Weather</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, readme.RenderHTML(tt.markdown, "https://example.com/repo/README.md"))
+		})
+	}
+}
+
+func TestFetcher(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("# Weather\n<script>alert(1)</script>"))
+	}))
+	defer server.Close()
+
+	fetcher := readme.NewFetcherWithClient(server.Client(), time.Hour)
+	markdown, _, err := fetcher.Fetch(context.Background(), server.URL+"/README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Weather\n", markdown)
+
+	_, _, err = fetcher.Fetch(context.Background(), server.URL+"/README.md")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), requests.Load(), "READMEs are cached")
+
+	_, _, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	assert.Error(t, err)
+}
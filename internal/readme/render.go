@@ -0,0 +1,218 @@
+package readme
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// dangerousElements matches elements that run code or embed other documents, with their content
+	dangerousElements = regexp.MustCompile(`(?is)<(script|style|iframe|object|embed)\b.*?(</\s*(script|style|iframe|object|embed)\s*>|\z)`)
+	// rawHTML matches HTML comments, declarations, processing instructions and tags
+	rawHTML = regexp.MustCompile(`(?s)<!--.*?(-->|\z)|<[!?][^>]*>|</?[a-zA-Z][^>]*>`)
+	// autolink matches the markdown autolinks raw HTML stripping keeps: URLs and email addresses
+	autolink = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>"']*|[^\s<>"'/@]+@[^\s<>"'/@]+)>$`)
+	// linkDestination matches the destination of an inline link or image, which may hold balanced
+	// parentheses, or of a link reference definition
+	linkDestination = regexp.MustCompile(`(?m)(\]\(\s*<?|^ {0,3}\[[^\]]+\]:[ \t]*<?)([^\s()>]*(?:\([^\s()]*\)[^\s()>]*)*)`)
+	// markdownEscape matches backslash escapes, which markdown resolves in link destinations
+	markdownEscape = regexp.MustCompile(`\\([!-/:-@\[-` + "`" + `{-~])`)
+)
+
+// Sanitize strips what could run in a reader's browser from a README's markdown: all raw HTML,
+// along with the content of script, style, iframe, object and embed elements, and link
+// destinations with schemes other than http, https and mailto. Autolinks are kept, as long as they
+// are links the renderer would write.
+func Sanitize(markdown string) string {
+	markdown = dangerousElements.ReplaceAllString(markdown, "")
+	markdown = rawHTML.ReplaceAllStringFunc(markdown, func(tag string) string {
+		if autolink.MatchString(tag) && safeDestination(strings.Trim(tag, "<>")) {
+			return tag
+		}
+		return ""
+	})
+	return linkDestination.ReplaceAllStringFunc(markdown, func(match string) string {
+		parts := linkDestination.FindStringSubmatch(match)
+		if safeDestination(parts[2]) {
+			return match
+		}
+		return parts[1] + "#"
+	})
+}
+
+// safeDestination reports whether a link destination, once markdown resolves its escapes and
+// entities, is relative or uses an allowed scheme
+func safeDestination(destination string) bool {
+	destination = html.UnescapeString(markdownEscape.ReplaceAllString(destination, "$1"))
+	destination = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, destination)
+	target, err := url.Parse(destination)
+	return err == nil && allowedSchemes[strings.ToLower(target.Scheme)]
+}
+
+var (
+	headingLine     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	fenceLine       = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+-]*)")
+	ruleLine        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	unorderedItem   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	orderedItem     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	quoteLine       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	htmlTag         = regexp.MustCompile(`</?[a-zA-Z][^>]*>|<!--.*?-->`)
+	codeSpan        = regexp.MustCompile("`+[^`]+`+")
+	inlineImage     = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&quot;[^)]*&quot;)?\)`)
+	inlineLink      = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&quot;[^)]*&quot;)?\)`)
+	strongEmphasis  = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	emphasis        = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_]+)_\b`)
+	strikethrough   = regexp.MustCompile(`~~([^~]+)~~`)
+	allowedSchemes  = map[string]bool{"": true, "http": true, "https": true, "mailto": true}
+	blockSeparators = []*regexp.Regexp{headingLine, fenceLine, ruleLine, unorderedItem, orderedItem, quoteLine}
+)
+
+// RenderHTML renders a README's markdown as an HTML fragment. It covers the markdown READMEs
+// commonly use: headings, paragraphs, fenced code, lists, block quotes, rules, links, images and
+// emphasis. Raw HTML is left out, so only the elements the renderer writes reach the page, and
+// relative links and images are resolved against the README's URL.
+func RenderHTML(markdown, baseURL string) string {
+	base, _ := url.Parse(baseURL)
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fenceLine.MatchString(line):
+			match := fenceLine.FindStringSubmatch(line)
+			i++
+			var code []string
+			for ; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			if match[2] != "" {
+				b.WriteString(`<pre><code class="language-` + html.EscapeString(match[2]) + `">`)
+			} else {
+				b.WriteString("<pre><code>")
+			}
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+		case headingLine.MatchString(line):
+			match := headingLine.FindStringSubmatch(line)
+			level := string(rune('0' + len(match[1])))
+			b.WriteString("<h" + level + ">" + renderInline(match[2], base) + "</h" + level + ">\n")
+			i++
+		case ruleLine.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+		case quoteLine.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quoteLine.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quoteLine.FindStringSubmatch(lines[i])[1])
+			}
+			b.WriteString("<blockquote>\n" + RenderHTML(strings.Join(quoted, "\n"), baseURL) + "</blockquote>\n")
+		case unorderedItem.MatchString(line), orderedItem.MatchString(line):
+			item, tag := unorderedItem, "ul"
+			if !unorderedItem.MatchString(line) {
+				item, tag = orderedItem, "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && item.MatchString(lines[i]); i++ {
+				b.WriteString("<li>" + renderInline(item.FindStringSubmatch(lines[i])[1], base) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+		default:
+			var paragraph []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(paragraph) == 0 || !startsBlock(lines[i])); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			text := renderInline(strings.Join(paragraph, "\n"), base)
+			if strings.TrimSpace(text) != "" {
+				b.WriteString("<p>" + text + "</p>\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// startsBlock reports whether a line starts a block other than a paragraph
+func startsBlock(line string) bool {
+	for _, separator := range blockSeparators {
+		if separator.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderInline renders the inline markdown of a block: code spans, images, links and emphasis
+func renderInline(text string, base *url.URL) string {
+	var b strings.Builder
+	last := 0
+	for _, span := range codeSpan.FindAllStringIndex(text, -1) {
+		b.WriteString(renderText(text[last:span[0]], base))
+		code := strings.Trim(text[span[0]:span[1]], "`")
+		b.WriteString("<code>" + html.EscapeString(strings.TrimSpace(code)) + "</code>")
+		last = span[1]
+	}
+	b.WriteString(renderText(text[last:], base))
+	return b.String()
+}
+
+// renderText renders inline markdown outside code spans. Images and links are set aside while
+// emphasis is rendered, so that emphasis markers in their URLs are left alone; setting images
+// aside first lets them be linked, as README badges are.
+func renderText(text string, base *url.URL) string {
+	text = html.EscapeString(htmlTag.ReplaceAllString(strings.ReplaceAll(text, "\x00", ""), ""))
+
+	var rendered []string
+	setAside := func(element string) string {
+		rendered = append(rendered, element)
+		return "\x00" + strconv.Itoa(len(rendered)-1) + "\x00"
+	}
+	text = inlineImage.ReplaceAllStringFunc(text, func(match string) string {
+		parts := inlineImage.FindStringSubmatch(match)
+		src, ok := resolveURL(parts[2], base)
+		if !ok {
+			return parts[1]
+		}
+		return setAside(`<img src="` + src + `" alt="` + parts[1] + `">`)
+	})
+	text = inlineLink.ReplaceAllStringFunc(text, func(match string) string {
+		parts := inlineLink.FindStringSubmatch(match)
+		href, ok := resolveURL(parts[2], base)
+		if !ok {
+			return parts[1]
+		}
+		return setAside(`<a href="` + href + `" rel="nofollow">` + parts[1] + `</a>`)
+	})
+	text = strongEmphasis.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = emphasis.ReplaceAllString(text, "<em>$1$2</em>")
+	text = strikethrough.ReplaceAllString(text, "<del>$1</del>")
+
+	// Links hold the images set aside in them, so put elements back last to first
+	for i := len(rendered) - 1; i >= 0; i-- {
+		text = strings.ReplaceAll(text, "\x00"+strconv.Itoa(i)+"\x00", rendered[i])
+	}
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// resolveURL resolves an escaped link target against the README's URL, returning it escaped for an
+// attribute, and reports false for schemes other than http, https and mailto
+func resolveURL(escaped string, base *url.URL) (string, bool) {
+	target, err := url.Parse(html.UnescapeString(escaped))
+	if err != nil || !allowedSchemes[strings.ToLower(target.Scheme)] {
+		return "", false
+	}
+	if base != nil && !strings.HasPrefix(escaped, "#") {
+		target = base.ResolveReference(target)
+	}
+	return html.EscapeString(target.String()), true
+}
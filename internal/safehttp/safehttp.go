@@ -0,0 +1,101 @@
+// Package safehttp makes HTTP clients for URLs that publishers and users supply, which must not be
+// able to reach the registry's own network: they only connect to public addresses, whatever a
+// name resolves to and wherever a redirect points
+package safehttp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// maxRedirects is the number of redirects followed, as many as Go's default client follows
+const maxRedirects = 10
+
+// ErrForbiddenAddress is returned for requests to loopback, private, link-local and other addresses
+// that aren't reachable from the internet
+var ErrForbiddenAddress = errors.New("address is not public")
+
+// reservedPrefixes are the non-public ranges netip doesn't classify: "this network", carrier-grade
+// NAT, IETF protocol assignments, benchmarking, the reserved class E range, and NAT64, which
+// embeds IPv4 addresses
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// NewClient creates a client that only connects to public addresses over the given URL schemes,
+// checking every redirect again
+func NewClient(timeout time.Duration, schemes ...string) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialPublic}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would make the connection to the proxy the only one checked
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return CheckURL(req, schemes...)
+		},
+	}
+}
+
+// CheckURL rejects requests over schemes other than the given ones, and to hosts that are non-public
+// addresses. Names are checked once they resolve, when the client connects.
+func CheckURL(req *http.Request, schemes ...string) error {
+	if !slices.Contains(schemes, req.URL.Scheme) {
+		return fmt.Errorf("%w: scheme %q isn't allowed", ErrForbiddenAddress, req.URL.Scheme)
+	}
+	host := req.URL.Hostname()
+	if host == "localhost" {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublic(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// IsPublic reports whether an address is reachable from the internet
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// dialPublic refuses connections to non-public addresses, once names have been resolved
+func dialPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	if !IsPublic(addr) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	return nil
+}
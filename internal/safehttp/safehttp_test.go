@@ -0,0 +1,66 @@
+package safehttp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublic(t *testing.T) {
+	for _, addr := range []string{"8.8.8.8", "1.1.1.1", "2606:4700:4700::1111"} {
+		assert.True(t, safehttp.IsPublic(netip.MustParseAddr(addr)), addr)
+	}
+	for _, addr := range []string{
+		"127.0.0.1", "10.0.0.1", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1",
+		"255.255.255.255", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1", "::ffff:169.254.169.254", "64:ff9b::a9fe:a9fe",
+	} {
+		assert.False(t, safehttp.IsPublic(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("metadata"))
+	}))
+	defer server.Close()
+
+	client := safehttp.NewClient(time.Second, "http", "https")
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, safehttp.ErrForbiddenAddress, "loopback servers can't be reached")
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		allowed bool
+	}{
+		{"https://example.com/README.md", true},
+		{"https://93.184.216.34/README.md", true},
+		{"http://example.com/README.md", false},
+		{"file:///etc/passwd", false},
+		{"https://localhost/", false},
+		{"https://169.254.169.254/latest/meta-data/", false},
+		{"https://[::1]:8080/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			if tt.allowed {
+				assert.NoError(t, safehttp.CheckURL(req, "https"))
+			} else {
+				assert.ErrorIs(t, safehttp.CheckURL(req, "https"), safehttp.ErrForbiddenAddress)
+			}
+		})
+	}
+}
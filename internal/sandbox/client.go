@@ -0,0 +1,155 @@
+package sandbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// mcpProtocolVersion is the MCP protocol version offered in the initialize request
+const mcpProtocolVersion = "2025-06-18"
+
+// maxListPages bounds how many pages of each list are followed, in case a server keeps
+// returning cursors
+const maxListPages = 50
+
+// jsonRPCMessage is any JSON-RPC 2.0 message exchanged with the server
+type jsonRPCMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int            `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// session is a minimal MCP client speaking newline-delimited JSON-RPC over a server's stdio
+type session struct {
+	stdin  io.Writer
+	stdout *bufio.Scanner
+	nextID int
+}
+
+// ListCapabilities initializes an MCP session with a server over its stdout and stdin, and
+// lists the tools, resources and prompts it declares support for, following pagination.
+// It blocks until the server responds, so callers bound it by stopping the server.
+func ListCapabilities(stdout io.Reader, stdin io.Writer) (*apiv0.Capabilities, error) {
+	s := &session{stdin: stdin, stdout: bufio.NewScanner(stdout)}
+	s.stdout.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var initResult struct {
+		Capabilities struct {
+			Tools     *struct{} `json:"tools"`
+			Resources *struct{} `json:"resources"`
+			Prompts   *struct{} `json:"prompts"`
+		} `json:"capabilities"`
+	}
+	err := s.request("initialize", map[string]any{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": "mcp-registry", "version": "sandbox"},
+	}, &initResult)
+	if err != nil {
+		return nil, fmt.Errorf("initialize failed: %w", err)
+	}
+	if err := s.send(jsonRPCMessage{Method: "notifications/initialized"}); err != nil {
+		return nil, fmt.Errorf("initialized notification failed: %w", err)
+	}
+
+	capabilities := &apiv0.Capabilities{}
+	if initResult.Capabilities.Tools != nil {
+		if err := list(s, "tools/list", "tools", &capabilities.Tools); err != nil {
+			return nil, err
+		}
+	}
+	if initResult.Capabilities.Resources != nil {
+		if err := list(s, "resources/list", "resources", &capabilities.Resources); err != nil {
+			return nil, err
+		}
+	}
+	if initResult.Capabilities.Prompts != nil {
+		if err := list(s, "prompts/list", "prompts", &capabilities.Prompts); err != nil {
+			return nil, err
+		}
+	}
+	return capabilities, nil
+}
+
+// list collects every page of a list method into items, reading each page's entries from field
+func list[T any](s *session, method, field string, items *[]T) error {
+	params := map[string]any{}
+	for range maxListPages {
+		var page map[string]json.RawMessage
+		if err := s.request(method, params, &page); err != nil {
+			return fmt.Errorf("%s failed: %w", method, err)
+		}
+		if entries, ok := page[field]; ok {
+			var pageItems []T
+			if err := json.Unmarshal(entries, &pageItems); err != nil {
+				return fmt.Errorf("%s returned invalid %s: %w", method, field, err)
+			}
+			*items = append(*items, pageItems...)
+		}
+
+		var cursor string
+		if raw, ok := page["nextCursor"]; ok {
+			_ = json.Unmarshal(raw, &cursor)
+		}
+		if cursor == "" {
+			return nil
+		}
+		params = map[string]any{"cursor": cursor}
+	}
+	return fmt.Errorf("%s returned more than %d pages", method, maxListPages)
+}
+
+func (s *session) send(message jsonRPCMessage) error {
+	message.JSONRPC = "2.0"
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = s.stdin.Write(append(data, '\n'))
+	return err
+}
+
+// request sends a request and waits for its response, answering any requests the server makes
+// in the meantime with empty results and skipping notifications and non-JSON log lines
+func (s *session) request(method string, params any, result any) error {
+	s.nextID++
+	id := s.nextID
+	if err := s.send(jsonRPCMessage{ID: &id, Method: method, Params: params}); err != nil {
+		return err
+	}
+
+	for s.stdout.Scan() {
+		var message jsonRPCMessage
+		if err := json.Unmarshal(s.stdout.Bytes(), &message); err != nil {
+			continue
+		}
+		if message.Method != "" {
+			if message.ID != nil {
+				_ = s.send(jsonRPCMessage{ID: message.ID, Result: json.RawMessage(`{}`)})
+			}
+			continue
+		}
+		if message.ID == nil || *message.ID != id {
+			continue
+		}
+		if message.Error != nil {
+			return fmt.Errorf("server returned error %d: %s", message.Error.Code, message.Error.Message)
+		}
+		return json.Unmarshal(message.Result, result)
+	}
+	if err := s.stdout.Err(); err != nil {
+		return err
+	}
+	return errors.New("server closed its output without responding")
+}
@@ -0,0 +1,187 @@
+// Package sandbox runs published servers in isolated containers to extract the tools, resources
+// and prompts they list, so that the registry records what servers offer rather than what their
+// publishers say they offer
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// placeholderValue is passed for required inputs without a value or default, such as API keys,
+// so that servers that check their configuration at startup still start far enough to list
+const placeholderValue = "sandbox-placeholder"
+
+// Options configure the containers servers run in
+type Options struct {
+	// Runtime is the Docker-compatible CLI that runs containers, e.g. docker or podman
+	Runtime string
+	// Network is the network npm and PyPI packages run with, since they are downloaded when the
+	// container starts. OCI images are pulled beforehand and run without a network.
+	Network string
+	// Memory and CPUs limit each container, in the runtime's format, e.g. 512m and 1
+	Memory string
+	CPUs   string
+	// NodeImage and PythonImage run npm packages with npx and PyPI packages with uvx
+	NodeImage   string
+	PythonImage string
+	// Timeout bounds how long a server has to start and list its capabilities
+	Timeout time.Duration
+}
+
+// DefaultOptions are the options the extract-capabilities command starts from
+var DefaultOptions = Options{
+	Runtime:     "docker",
+	Network:     "bridge",
+	Memory:      "512m",
+	CPUs:        "1",
+	NodeImage:   "node:22-alpine",
+	PythonImage: "ghcr.io/astral-sh/uv:python3.12-bookworm-slim",
+	Timeout:     3 * time.Minute,
+}
+
+// Command builds the container runtime arguments that run the first stdio package of a server
+// that can run in a sandbox. The container gets a read-only filesystem apart from /tmp, no
+// capabilities and limited resources, and the package's runtime arguments are ignored, since
+// they could mount host paths or loosen the sandbox.
+func Command(server *apiv0.ServerJSON, name string, options Options) ([]string, error) {
+	for i := range server.Packages {
+		pkg := &server.Packages[i]
+		if pkg.Transport.Type != model.TransportTypeStdio {
+			continue
+		}
+		switch pkg.RegistryType {
+		case model.RegistryTypeOCI, model.RegistryTypeNPM, model.RegistryTypePyPI:
+		default:
+			continue
+		}
+
+		args := []string{
+			"run", "--rm", "-i", "--name", name,
+			"--read-only", "--tmpfs", "/tmp:rw,exec,size=512m",
+			"--cap-drop", "ALL", "--security-opt", "no-new-privileges", "--pids-limit", "256",
+			"--memory", options.Memory, "--cpus", options.CPUs,
+			"-e", "HOME=/tmp",
+		}
+		for _, variable := range pkg.EnvironmentVariables {
+			if value := sandboxValue(&variable.Input); value != "" {
+				args = append(args, "-e", variable.Name+"="+value)
+			}
+		}
+
+		switch pkg.RegistryType {
+		case model.RegistryTypeOCI:
+			args = append(args, "--network", "none", pkg.Identifier)
+		case model.RegistryTypeNPM:
+			args = append(args, "--network", options.Network, "-e", "npm_config_cache=/tmp/.npm",
+				options.NodeImage, "npx", "-y", pkg.Identifier+"@"+pkg.Version)
+		case model.RegistryTypePyPI:
+			args = append(args, "--network", options.Network, "-e", "UV_CACHE_DIR=/tmp/.uv",
+				options.PythonImage, "uvx", pkg.Identifier+"=="+pkg.Version)
+		}
+		return append(args, argumentValues(pkg.PackageArguments)...), nil
+	}
+	return nil, errors.New("the server has no stdio npm, PyPI or OCI package that can run in a sandbox")
+}
+
+// argumentValues resolves declared package arguments to command line words, leaving out
+// optional arguments without a value
+func argumentValues(arguments []model.Argument) []string {
+	var values []string
+	for _, argument := range arguments {
+		switch {
+		case argument.Type != model.ArgumentTypeNamed:
+			if value := sandboxValue(&argument.Input); value != "" {
+				values = append(values, value)
+			}
+		case declaredValue(&argument.Input) != "":
+			values = append(values, argument.Name, declaredValue(&argument.Input))
+		case argument.IsRequired:
+			// A required flag without a value is a switch, e.g. --stdio
+			values = append(values, argument.Name)
+		}
+	}
+	return values
+}
+
+// sandboxValue is the value to run an input with: its declared value, or a placeholder when it
+// is required
+func sandboxValue(input *model.Input) string {
+	value := declaredValue(input)
+	if value == "" && input.IsRequired && input.Format != model.FormatBoolean {
+		return placeholderValue
+	}
+	return value
+}
+
+// declaredValue is an input's value, or its default when the value is missing or templated
+func declaredValue(input *model.Input) string {
+	if input.Value == "" || strings.Contains(input.Value, "{") {
+		return input.Default
+	}
+	return input.Value
+}
+
+// Extract runs a server in a sandbox and lists its capabilities
+func Extract(ctx context.Context, server *apiv0.ServerJSON, options Options) (*apiv0.Capabilities, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	name := "mcp-sandbox-" + hex.EncodeToString(suffix)
+
+	args, err := Command(server, name, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, options.Runtime, args...) //nolint:gosec // The runtime is configured by the operator
+	cmd.WaitDelay = 10 * time.Second
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", options.Runtime, err)
+	}
+
+	capabilities, err := ListCapabilities(stdout, stdin)
+	_ = stdin.Close()
+	// Killing the runtime's CLI doesn't always stop the container it started
+	_ = exec.Command(options.Runtime, "rm", "-f", name).Run() //nolint:gosec,noctx // Cleanup must run after ctx is done
+	_ = cmd.Wait()
+
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", options.Timeout)
+		}
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			const maxOutput = 1000
+			if len(output) > maxOutput {
+				output = "..." + output[len(output)-maxOutput:]
+			}
+			return nil, fmt.Errorf("%w\n\nServer output:\n%s", err, output)
+		}
+		return nil, err
+	}
+	return capabilities, nil
+}
@@ -0,0 +1,120 @@
+package sandbox_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/sandbox"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	apiKey := model.KeyValueInput{Name: "API_KEY"}
+	apiKey.IsRequired = true
+	region := model.KeyValueInput{Name: "REGION"}
+	region.Default = "eu"
+	port := model.Argument{Type: model.ArgumentTypeNamed, Name: "--port"}
+	port.Value = "{port}"
+	port.Default = "8080"
+	stdio := model.Argument{Type: model.ArgumentTypeNamed, Name: "--stdio"}
+	stdio.IsRequired = true
+	mount := model.Argument{Type: model.ArgumentTypeNamed, Name: "-v"}
+	mount.Value = "/:/host"
+
+	server := &apiv0.ServerJSON{
+		Name:    "com.example/weather",
+		Version: "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNuGet, Identifier: "Example.Weather", Version: "1.0.0", Transport: model.Transport{Type: model.TransportTypeStdio}},
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@example/weather", Version: "1.0.0", Transport: model.Transport{Type: "streamable-http"}},
+			{
+				RegistryType:         model.RegistryTypeOCI,
+				Identifier:           "docker.io/example/weather:1.0.0",
+				Transport:            model.Transport{Type: model.TransportTypeStdio},
+				RuntimeArguments:     []model.Argument{mount},
+				PackageArguments:     []model.Argument{port, stdio},
+				EnvironmentVariables: []model.KeyValueInput{apiKey, region},
+			},
+		},
+	}
+
+	args, err := sandbox.Command(server, "mcp-sandbox-test", sandbox.DefaultOptions)
+	require.NoError(t, err)
+	assert.Contains(t, args, "--read-only")
+	assert.Contains(t, args, "API_KEY=sandbox-placeholder")
+	assert.Contains(t, args, "REGION=eu")
+	assert.NotContains(t, args, "/:/host", "runtime arguments must not loosen the sandbox")
+	assert.Equal(t, []string{"--network", "none", "docker.io/example/weather:1.0.0", "--port", "8080", "--stdio"}, args[len(args)-6:])
+
+	server.Packages = server.Packages[:2]
+	_, err = sandbox.Command(server, "mcp-sandbox-test", sandbox.DefaultOptions)
+	assert.Error(t, err, "servers without a stdio npm, PyPI or OCI package can't be run")
+}
+
+func TestListCapabilities(t *testing.T) {
+	clientToServer, clientWriter := io.Pipe()
+	serverReader, serverWriter := io.Pipe()
+
+	// A fake server that logs to stdout, asks the client for its roots, and pages its tools
+	output := make(chan string, 10)
+	go func() {
+		defer serverWriter.Close()
+		for line := range output {
+			_, _ = io.WriteString(serverWriter, line+"\n")
+		}
+	}()
+	go func() {
+		defer close(output)
+		requests := bufio.NewScanner(clientToServer)
+		write := func(line string) { output <- line }
+		for requests.Scan() {
+			var request struct {
+				ID     *int            `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if json.Unmarshal(requests.Bytes(), &request) != nil || request.ID == nil {
+				continue
+			}
+			id, _ := json.Marshal(request.ID)
+			respond := func(result string) { write(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":` + result + `}`) }
+			switch request.Method {
+			case "initialize":
+				write("Weather server starting")
+				respond(`{"protocolVersion":"2025-06-18","capabilities":{"tools":{},"prompts":{}},"serverInfo":{"name":"weather","version":"1.0.0"}}`)
+			case "tools/list":
+				if string(request.Params) == "{}" {
+					write(`{"jsonrpc":"2.0","id":100,"method":"roots/list"}`)
+					respond(`{"tools":[{"name":"get_forecast","description":"Get the forecast","inputSchema":{"type":"object"}}],"nextCursor":"2"}`)
+				} else {
+					respond(`{"tools":[{"name":"get_alerts","title":"Alerts"}]}`)
+				}
+			case "prompts/list":
+				respond(`{"prompts":[{"name":"summarize_forecast"}]}`)
+			case "resources/list":
+				t.Error("resources/list must not be called for servers without resources")
+			}
+		}
+	}()
+
+	capabilities, err := sandbox.ListCapabilities(serverReader, clientWriter)
+	require.NoError(t, err)
+	assert.Equal(t, []apiv0.CapabilityTool{
+		{Name: "get_forecast", Description: "Get the forecast"},
+		{Name: "get_alerts", Title: "Alerts"},
+	}, capabilities.Tools)
+	assert.Empty(t, capabilities.Resources)
+	assert.Equal(t, []apiv0.CapabilityPrompt{{Name: "summarize_forecast"}}, capabilities.Prompts)
+	_ = clientWriter.Close()
+
+	// A server that exits without answering fails the extraction
+	reader, writer := io.Pipe()
+	_ = writer.Close()
+	_, err = sandbox.ListCapabilities(reader, io.Discard)
+	assert.ErrorContains(t, err, "initialize failed")
+}
@@ -0,0 +1,75 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Worker extracts the capabilities of the server versions publishers and admins queue for
+// extraction, one at a time
+type Worker struct {
+	registry service.RegistryService
+	options  Options
+}
+
+// NewWorker creates a worker that runs servers with the given options
+func NewWorker(registry service.RegistryService, options Options) *Worker {
+	return &Worker{registry: registry, options: options}
+}
+
+// Run extracts queued capabilities every interval until the context is cancelled, logging failures
+func (w *Worker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(ctx); err != nil {
+			log.Printf("Failed to extract capabilities: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll extracts the capabilities of every queued server version, recording why a server
+// couldn't be run as a failed extraction
+func (w *Worker) Poll(ctx context.Context) error {
+	for ctx.Err() == nil {
+		server, err := w.registry.ClaimCapabilityExtraction(ctx)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim an extraction: %w", err)
+		}
+
+		name, version := server.Server.Name, server.Server.Version
+		capabilities, err := Extract(ctx, &server.Server, w.options)
+		if err != nil {
+			log.Printf("Failed to extract the capabilities of %s %s: %v", name, version, err)
+			capabilities = &apiv0.Capabilities{Status: service.CapabilitiesFailed, Error: err.Error()}
+		} else {
+			log.Printf("Extracted %d tools, %d resources and %d prompts from %s %s",
+				len(capabilities.Tools), len(capabilities.Resources), len(capabilities.Prompts), name, version)
+			capabilities.Status = service.CapabilitiesSucceeded
+		}
+
+		// Record the outcome even when ctx was cancelled mid-extraction, so the version isn't
+		// left running until it goes stale
+		if err := w.registry.RecordCapabilities(context.WithoutCancel(ctx), name, version, capabilities); err != nil {
+			return fmt.Errorf("failed to record the capabilities of %s %s: %w", name, version, err)
+		}
+	}
+	return ctx.Err()
+}
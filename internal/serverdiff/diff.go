@@ -0,0 +1,233 @@
+// Package serverdiff compares two versions of a server.json, for the registry's diff endpoint and
+// the publisher's diff and release commands alike
+package serverdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Diff lists what changed between two versions of a server: the packages, remotes, and their
+// environment variables, arguments and headers that were added, removed or changed, and the other
+// fields of the server.json that changed
+func Diff(from, to *apiv0.ServerJSON) *apiv0.ServerDiff {
+	diff := &apiv0.ServerDiff{
+		Name:     to.Name,
+		From:     from.Version,
+		To:       to.Version,
+		Packages: []apiv0.PackageDiff{},
+		Remotes:  []apiv0.RemoteDiff{},
+		Metadata: diffFields("", jsonObject(from, "name", "version", "packages", "remotes"), jsonObject(to, "name", "version", "packages", "remotes")),
+	}
+
+	for _, p := range pairItems(from.Packages, to.Packages, packageKey) {
+		current := p.current()
+		pkg := apiv0.PackageDiff{
+			Change:       p.change(),
+			RegistryType: current.RegistryType,
+			Identifier:   current.Identifier,
+			Fields: diffFields("", jsonObject(p.from, "environmentVariables", "runtimeArguments", "packageArguments"),
+				jsonObject(p.to, "environmentVariables", "runtimeArguments", "packageArguments")),
+		}
+		var fromPkg, toPkg model.Package
+		if p.from != nil {
+			fromPkg = *p.from
+		}
+		if p.to != nil {
+			toPkg = *p.to
+		}
+		pkg.EnvironmentVariables = diffInputs(fromPkg.EnvironmentVariables, toPkg.EnvironmentVariables, keyValueInputKey)
+		pkg.RuntimeArguments = diffInputs(fromPkg.RuntimeArguments, toPkg.RuntimeArguments, argumentKey)
+		pkg.PackageArguments = diffInputs(fromPkg.PackageArguments, toPkg.PackageArguments, argumentKey)
+		if pkg.Change == apiv0.ChangeChanged && len(pkg.Fields) == 0 && len(pkg.EnvironmentVariables) == 0 &&
+			len(pkg.RuntimeArguments) == 0 && len(pkg.PackageArguments) == 0 {
+			continue
+		}
+		diff.Packages = append(diff.Packages, pkg)
+	}
+
+	for _, p := range pairItems(from.Remotes, to.Remotes, remoteKey) {
+		current := p.current()
+		remote := apiv0.RemoteDiff{
+			Change: p.change(),
+			Type:   current.Type,
+			URL:    current.URL,
+			Fields: diffFields("", jsonObject(p.from, "headers"), jsonObject(p.to, "headers")),
+		}
+		var fromHeaders, toHeaders []model.KeyValueInput
+		if p.from != nil {
+			fromHeaders = p.from.Headers
+		}
+		if p.to != nil {
+			toHeaders = p.to.Headers
+		}
+		remote.Headers = diffInputs(fromHeaders, toHeaders, keyValueInputKey)
+		if remote.Change == apiv0.ChangeChanged && len(remote.Fields) == 0 && len(remote.Headers) == 0 {
+			continue
+		}
+		diff.Remotes = append(diff.Remotes, remote)
+	}
+
+	return diff
+}
+
+// itemPair is an item of a list in two versions of a server, nil in the version without it
+type itemPair[T any] struct {
+	key      string
+	from, to *T
+}
+
+func (p itemPair[T]) change() string {
+	switch {
+	case p.from == nil:
+		return apiv0.ChangeAdded
+	case p.to == nil:
+		return apiv0.ChangeRemoved
+	default:
+		return apiv0.ChangeChanged
+	}
+}
+
+// current returns the item in the newer version, or the older one if it was removed
+func (p itemPair[T]) current() *T {
+	if p.to != nil {
+		return p.to
+	}
+	return p.from
+}
+
+// pairItems matches the items of a list in two versions by their key, in the order of the newer
+// version followed by the removed items. Items sharing a key are matched in order.
+func pairItems[T any](from, to []T, key func(int, T) string) []itemPair[T] {
+	keys := func(items []T) []string {
+		seen := map[string]int{}
+		keys := make([]string, len(items))
+		for i, item := range items {
+			k := key(i, item)
+			if seen[k]++; seen[k] > 1 {
+				k = fmt.Sprintf("%s#%d", k, seen[k])
+			}
+			keys[i] = k
+		}
+		return keys
+	}
+	fromKeys, toKeys := keys(from), keys(to)
+
+	var pairs []itemPair[T]
+	for i, k := range toKeys {
+		p := itemPair[T]{key: k, to: &to[i]}
+		if j := slices.Index(fromKeys, k); j >= 0 {
+			p.from = &from[j]
+		}
+		pairs = append(pairs, p)
+	}
+	for i, k := range fromKeys {
+		if !slices.Contains(toKeys, k) {
+			pairs = append(pairs, itemPair[T]{key: k, from: &from[i]})
+		}
+	}
+	return pairs
+}
+
+// diffInputs lists the inputs added, removed or changed between two versions
+func diffInputs[T any](from, to []T, key func(int, T) string) []apiv0.InputDiff {
+	var diffs []apiv0.InputDiff
+	for _, p := range pairItems(from, to, key) {
+		input := apiv0.InputDiff{Change: p.change(), Name: p.key, Fields: diffFields("", jsonObject(p.from), jsonObject(p.to))}
+		if input.Change == apiv0.ChangeChanged && len(input.Fields) == 0 {
+			continue
+		}
+		diffs = append(diffs, input)
+	}
+	return diffs
+}
+
+// packageKey matches packages by their registry type and identifier, without the tag or digest of
+// OCI images so that an image bumped to a new tag is a changed package
+func packageKey(_ int, pkg model.Package) string {
+	identifier := pkg.Identifier
+	if pkg.RegistryType == model.RegistryTypeOCI {
+		if i := strings.LastIndex(identifier, "@"); i >= 0 {
+			identifier = identifier[:i]
+		}
+		if i := strings.LastIndex(identifier, ":"); i > strings.LastIndex(identifier, "/") {
+			identifier = identifier[:i]
+		}
+	}
+	return pkg.RegistryType + ":" + identifier
+}
+
+func remoteKey(_ int, remote model.Transport) string {
+	return remote.URL
+}
+
+func keyValueInputKey(_ int, input model.KeyValueInput) string {
+	return input.Name
+}
+
+// argumentKey matches named arguments by name, and positional ones by value hint or position
+func argumentKey(i int, argument model.Argument) string {
+	switch {
+	case argument.Type == model.ArgumentTypeNamed && argument.Name != "":
+		return argument.Name
+	case argument.ValueHint != "":
+		return argument.ValueHint
+	default:
+		return fmt.Sprintf("#%d", i+1)
+	}
+}
+
+// jsonObject returns the JSON encoding of a value as an object, without some of its keys, or nil
+// for a nil pointer
+func jsonObject(v any, skip ...string) map[string]any {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil
+	}
+	for _, key := range skip {
+		delete(object, key)
+	}
+	return object
+}
+
+// diffFields lists the fields whose values differ between two JSON objects, descending into nested
+// objects. Lists are compared as a whole.
+func diffFields(prefix string, from, to map[string]any) []apiv0.FieldChange {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	changes := []apiv0.FieldChange{}
+	for _, key := range keys {
+		fromValue, toValue := from[key], to[key]
+		fromObject, fromIsObject := fromValue.(map[string]any)
+		toObject, toIsObject := toValue.(map[string]any)
+		switch {
+		case fromIsObject && toIsObject, fromIsObject && toValue == nil, fromValue == nil && toIsObject:
+			changes = append(changes, diffFields(prefix+key+".", fromObject, toObject)...)
+		case !reflect.DeepEqual(fromValue, toValue):
+			changes = append(changes, apiv0.FieldChange{Field: prefix + key, From: fromValue, To: toValue})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,124 @@
+package serverdiff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/serverdiff"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestDiff(t *testing.T) {
+	from := &apiv0.ServerJSON{
+		Name:        "com.example/weather",
+		Description: "Weather forecasts",
+		Version:     "1.2.0",
+		Packages: []model.Package{
+			{
+				RegistryType: "oci",
+				Identifier:   "ghcr.io/example/weather:1.2.0",
+				Transport:    model.Transport{Type: "stdio"},
+				EnvironmentVariables: []model.KeyValueInput{
+					{Name: "API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+					{Name: "UNITS", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "metric"}}},
+				},
+				PackageArguments: []model.Argument{{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "serve"}}}},
+			},
+			{RegistryType: "npm", Identifier: "@example/weather", Version: "1.2.0", Transport: model.Transport{Type: "stdio"}},
+		},
+		Remotes: []model.Transport{{Type: "sse", URL: "https://mcp.example.com/sse"}},
+		Meta:    &apiv0.ServerMeta{PublisherProvided: map[string]any{"tier": "free", "tools": []any{"forecast"}}},
+	}
+	to := &apiv0.ServerJSON{
+		Name:        "com.example/weather",
+		Description: "Weather forecasts and alerts",
+		Version:     "1.3.0",
+		Packages: []model.Package{
+			{
+				RegistryType: "oci",
+				Identifier:   "ghcr.io/example/weather:1.3.0",
+				Transport:    model.Transport{Type: "stdio"},
+				EnvironmentVariables: []model.KeyValueInput{
+					{Name: "API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+					{Name: "ENDPOINT", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "https://api.example.com"}}},
+				},
+				PackageArguments: []model.Argument{{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "serve"}}}},
+			},
+			{RegistryType: "pypi", Identifier: "example-weather", Version: "1.3.0", Transport: model.Transport{Type: "stdio"}},
+		},
+		Remotes: []model.Transport{
+			{Type: "sse", URL: "https://mcp.example.com/sse"},
+			{Type: "streamable-http", URL: "https://mcp.example.com/mcp", Headers: []model.KeyValueInput{{Name: "Authorization", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true}}}}},
+		},
+		Meta: &apiv0.ServerMeta{PublisherProvided: map[string]any{"tier": "free", "tools": []any{"forecast", "alerts"}}},
+	}
+
+	diff := serverdiff.Diff(from, to)
+	assert.Equal(t, "com.example/weather", diff.Name)
+	assert.Equal(t, "1.2.0", diff.From)
+	assert.Equal(t, "1.3.0", diff.To)
+
+	assert.Equal(t, []apiv0.PackageDiff{
+		{
+			Change:       apiv0.ChangeChanged,
+			RegistryType: "oci",
+			Identifier:   "ghcr.io/example/weather:1.3.0",
+			Fields:       []apiv0.FieldChange{{Field: "identifier", From: "ghcr.io/example/weather:1.2.0", To: "ghcr.io/example/weather:1.3.0"}},
+			EnvironmentVariables: []apiv0.InputDiff{
+				{Change: apiv0.ChangeAdded, Name: "ENDPOINT", Fields: []apiv0.FieldChange{{Field: "default", To: "https://api.example.com"}, {Field: "name", To: "ENDPOINT"}}},
+				{Change: apiv0.ChangeRemoved, Name: "UNITS", Fields: []apiv0.FieldChange{{Field: "default", From: "metric"}, {Field: "name", From: "UNITS"}}},
+			},
+		},
+		{
+			Change:       apiv0.ChangeAdded,
+			RegistryType: "pypi",
+			Identifier:   "example-weather",
+			Fields: []apiv0.FieldChange{
+				{Field: "identifier", To: "example-weather"},
+				{Field: "registryType", To: "pypi"},
+				{Field: "transport.type", To: "stdio"},
+				{Field: "version", To: "1.3.0"},
+			},
+		},
+		{
+			Change:       apiv0.ChangeRemoved,
+			RegistryType: "npm",
+			Identifier:   "@example/weather",
+			Fields: []apiv0.FieldChange{
+				{Field: "identifier", From: "@example/weather"},
+				{Field: "registryType", From: "npm"},
+				{Field: "transport.type", From: "stdio"},
+				{Field: "version", From: "1.2.0"},
+			},
+		},
+	}, diff.Packages)
+
+	assert.Equal(t, []apiv0.RemoteDiff{{
+		Change: apiv0.ChangeAdded,
+		Type:   "streamable-http",
+		URL:    "https://mcp.example.com/mcp",
+		Fields: []apiv0.FieldChange{{Field: "type", To: "streamable-http"}, {Field: "url", To: "https://mcp.example.com/mcp"}},
+		Headers: []apiv0.InputDiff{{Change: apiv0.ChangeAdded, Name: "Authorization", Fields: []apiv0.FieldChange{
+			{Field: "isRequired", To: true}, {Field: "name", To: "Authorization"},
+		}}},
+	}}, diff.Remotes)
+
+	assert.Equal(t, []apiv0.FieldChange{
+		{Field: "_meta.io.modelcontextprotocol.registry/publisher-provided.tools", From: []any{"forecast"}, To: []any{"forecast", "alerts"}},
+		{Field: "description", From: "Weather forecasts", To: "Weather forecasts and alerts"},
+	}, diff.Metadata)
+}
+
+func TestDiff_SameVersion(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Name:     "com.example/weather",
+		Version:  "1.0.0",
+		Packages: []model.Package{{RegistryType: "npm", Identifier: "@example/weather", Version: "1.0.0"}},
+	}
+	diff := serverdiff.Diff(server, server)
+	assert.Empty(t, diff.Packages)
+	assert.Empty(t, diff.Remotes)
+	assert.Empty(t, diff.Metadata)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServerAdvisories lists the security advisories of a server, most recently published first
+func (s *registryServiceImpl) ListServerAdvisories(ctx context.Context, serverName string) ([]apiv0.SecurityAdvisory, error) {
+	ctx = database.ReadFromReplica(ctx)
+	if _, err := s.db.GetServerByName(ctx, nil, serverName); err != nil {
+		return nil, err
+	}
+	return s.db.ListServerAdvisories(ctx, nil, serverName)
+}
+
+// PublishServerAdvisory attaches a security advisory to the versions of a server it affects. The
+// caller is responsible for checking that the publisher may publish advisories for the server.
+func (s *registryServiceImpl) PublishServerAdvisory(ctx context.Context, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.SecurityAdvisory, error) {
+		if err := s.checkAffectedVersions(ctx, tx, advisory); err != nil {
+			return nil, err
+		}
+		return s.db.CreateServerAdvisory(ctx, tx, advisory)
+	})
+}
+
+// UpdateServerAdvisory replaces the contents of a security advisory, e.g. once a fix is released
+func (s *registryServiceImpl) UpdateServerAdvisory(ctx context.Context, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.SecurityAdvisory, error) {
+		if err := s.checkAffectedVersions(ctx, tx, advisory); err != nil {
+			return nil, err
+		}
+		return s.db.UpdateServerAdvisory(ctx, tx, advisory)
+	})
+}
+
+// WithdrawServerAdvisory deletes a security advisory published in error
+func (s *registryServiceImpl) WithdrawServerAdvisory(ctx context.Context, serverName, id string) error {
+	return s.db.DeleteServerAdvisory(ctx, nil, serverName, id)
+}
+
+// ListAdvisoryFeed lists the security advisories of every server published or updated after a
+// time, oldest first, with the cursor to continue from. A cursor takes precedence over the time.
+func (s *registryServiceImpl) ListAdvisoryFeed(ctx context.Context, since time.Time, cursor string, limit int) ([]apiv0.SecurityAdvisory, string, error) {
+	ctx = database.ReadFromReplica(ctx)
+	afterID := ""
+	if cursor != "" {
+		timestamp, id, found := strings.Cut(cursor, "_")
+		parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+		if !found || err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor", database.ErrInvalidInput)
+		}
+		since, afterID = parsed, id
+	}
+
+	advisories, err := s.db.ListAdvisoriesUpdatedSince(ctx, nil, since, afterID, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(advisories) > 0 && len(advisories) >= limit {
+		last := advisories[len(advisories)-1]
+		nextCursor = last.UpdatedAt.UTC().Format(time.RFC3339Nano) + "_" + last.ID
+	}
+	return advisories, nextCursor, nil
+}
+
+// checkAffectedVersions checks that an advisory only names published versions of its server,
+// dropping duplicates
+func (s *registryServiceImpl) checkAffectedVersions(ctx context.Context, tx pgx.Tx, advisory *apiv0.SecurityAdvisory) error {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, advisory.ServerName)
+	if err != nil {
+		return err
+	}
+
+	published := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		published[version.Server.Version] = true
+	}
+	for _, version := range advisory.AffectedVersions {
+		if !published[version] {
+			return fmt.Errorf("%w: version %s of %s isn't published", database.ErrInvalidInput, version, advisory.ServerName)
+		}
+	}
+
+	slices.Sort(advisory.AffectedVersions)
+	advisory.AffectedVersions = slices.Compact(advisory.AffectedVersions)
+	return nil
+}
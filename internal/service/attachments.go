@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Kinds of documents attached to packages
+const (
+	AttachmentSBOM       = "sbom"
+	AttachmentProvenance = "provenance"
+)
+
+// Formats of documents attached to packages
+const (
+	AttachmentFormatSPDX      = "spdx"
+	AttachmentFormatCycloneDX = "cyclonedx"
+	AttachmentFormatSLSA      = "slsa"
+)
+
+// attachmentMediaTypes are the media types attached documents are served as, by format
+var attachmentMediaTypes = map[string]string{
+	AttachmentFormatSPDX:      "application/spdx+json",
+	AttachmentFormatCycloneDX: "application/vnd.cyclonedx+json",
+	AttachmentFormatSLSA:      "application/vnd.in-toto+json",
+}
+
+// ErrInvalidAttachment is returned when a document attached to a package isn't an SBOM or
+// provenance in a supported format, or the package isn't an OCI image
+var ErrInvalidAttachment = errors.New("invalid package attachment")
+
+// AttachPackageDocument attaches an SBOM or SLSA provenance document to an OCI package of a server
+// version, replacing the one of the same kind. With an editor, only the server's original
+// publisher may attach documents.
+func (s *registryServiceImpl) AttachPackageDocument(
+	ctx context.Context, serverName, version string, packageIndex int, kind string, content []byte, editor *Publisher,
+) (*apiv0.PackageAttachment, error) {
+	format, err := attachmentFormat(kind, content)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.PackageAttachment, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if err := s.checkServerPublisher(ctx, tx, serverName, editor); err != nil {
+			return nil, err
+		}
+
+		server, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		pkg, err := attachmentPackage(&server.Server, packageIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.db.SetPackageAttachment(ctx, tx, serverName, version, &database.PackageDocument{
+			Attachment: apiv0.PackageAttachment{
+				PackageIndex: packageIndex,
+				Identifier:   pkg.Identifier,
+				Kind:         kind,
+				Format:       format,
+				MediaType:    attachmentMediaTypes[format],
+				Digest:       "sha256:" + hex.EncodeToString(sum[:]),
+			},
+			Content: content,
+		})
+	})
+}
+
+// GetPackageAttachment returns a document attached to a package of a server version, of its
+// latest version when version is empty or 'latest'. Packages without one return
+// database.ErrNotFound.
+func (s *registryServiceImpl) GetPackageAttachment(ctx context.Context, serverName, version string, packageIndex int, kind string) (*database.PackageDocument, error) {
+	ctx = database.ReadFromReplica(ctx)
+	version, err := s.resolveVersion(ctx, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.GetPackageAttachment(ctx, nil, serverName, version, packageIndex, kind)
+}
+
+// ListPackageAttachments lists the documents attached to the packages of a server version
+func (s *registryServiceImpl) ListPackageAttachments(ctx context.Context, serverName, version string) ([]apiv0.PackageAttachment, error) {
+	ctx = database.ReadFromReplica(ctx)
+	if _, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version); err != nil {
+		return nil, err
+	}
+	return s.db.ListPackageAttachments(ctx, nil, serverName, version)
+}
+
+// DeletePackageAttachment deletes a document attached to a package of a server version. With an
+// editor, only the server's original publisher may delete documents.
+func (s *registryServiceImpl) DeletePackageAttachment(ctx context.Context, serverName, version string, packageIndex int, kind string, editor *Publisher) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return err
+		}
+		if err := s.checkServerPublisher(ctx, tx, serverName, editor); err != nil {
+			return err
+		}
+		return s.db.DeletePackageAttachment(ctx, tx, serverName, version, packageIndex, kind)
+	})
+}
+
+// dropStaleAttachments deletes the documents attached to packages that an edit replaced or removed
+func (s *registryServiceImpl) dropStaleAttachments(ctx context.Context, tx pgx.Tx, server *apiv0.ServerJSON) error {
+	identifiers := make([]string, len(server.Packages))
+	for i, pkg := range server.Packages {
+		identifiers[i] = pkg.Identifier
+	}
+	return s.db.DeleteStalePackageAttachments(ctx, tx, server.Name, server.Version, identifiers)
+}
+
+// attachmentPackage returns the package of a server version documents are attached to, which
+// must be an OCI image
+func attachmentPackage(server *apiv0.ServerJSON, packageIndex int) (*model.Package, error) {
+	if packageIndex < 0 || packageIndex >= len(server.Packages) {
+		return nil, fmt.Errorf("%w: %s %s has %d packages", database.ErrNotFound, server.Name, server.Version, len(server.Packages))
+	}
+	pkg := &server.Packages[packageIndex]
+	if pkg.RegistryType != model.RegistryTypeOCI {
+		return nil, fmt.Errorf("%w: documents can only be attached to OCI packages, and package %d is %s", ErrInvalidAttachment, packageIndex, pkg.RegistryType)
+	}
+	return pkg, nil
+}
+
+// attachmentFormat works out the format of a document from its contents: SPDX or CycloneDX JSON
+// for SBOMs, and an in-toto statement with a SLSA provenance predicate, bare or in a DSSE
+// envelope, for provenance
+func attachmentFormat(kind string, content []byte) (string, error) {
+	var document struct {
+		SPDXVersion   string `json:"spdxVersion"`
+		BOMFormat     string `json:"bomFormat"`
+		Type          string `json:"_type"`
+		PredicateType string `json:"predicateType"`
+		PayloadType   string `json:"payloadType"`
+		Payload       string `json:"payload"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return "", fmt.Errorf("%w: documents must be JSON objects: %w", ErrInvalidAttachment, err)
+	}
+
+	switch kind {
+	case AttachmentSBOM:
+		switch {
+		case strings.HasPrefix(document.SPDXVersion, "SPDX-"):
+			return AttachmentFormatSPDX, nil
+		case document.BOMFormat == "CycloneDX":
+			return AttachmentFormatCycloneDX, nil
+		}
+		return "", fmt.Errorf("%w: SBOMs must be SPDX or CycloneDX JSON", ErrInvalidAttachment)
+	case AttachmentProvenance:
+		if document.PayloadType == "application/vnd.in-toto+json" {
+			payload, err := base64.StdEncoding.DecodeString(document.Payload)
+			if err != nil {
+				return "", fmt.Errorf("%w: DSSE payload isn't base64", ErrInvalidAttachment)
+			}
+			if err := json.Unmarshal(payload, &document); err != nil {
+				return "", fmt.Errorf("%w: DSSE payload isn't an in-toto statement: %w", ErrInvalidAttachment, err)
+			}
+		}
+		if strings.HasPrefix(document.Type, "https://in-toto.io/Statement/") &&
+			strings.HasPrefix(document.PredicateType, "https://slsa.dev/provenance/") {
+			return AttachmentFormatSLSA, nil
+		}
+		return "", fmt.Errorf("%w: provenance must be an in-toto statement with a SLSA provenance predicate", ErrInvalidAttachment)
+	default:
+		return "", fmt.Errorf("%w: unknown kind %q, expected %s or %s", ErrInvalidAttachment, kind, AttachmentSBOM, AttachmentProvenance)
+	}
+}
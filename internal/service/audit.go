@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RecordAuditEvent appends an event to the audit log
+func (s *registryServiceImpl) RecordAuditEvent(ctx context.Context, event *apiv0.AuditEvent) error {
+	return s.db.AddAuditEvent(ctx, nil, event)
+}
+
+// ListAuditEvents lists the audit events matching a filter, newest first, with the cursor to
+// continue from
+func (s *registryServiceImpl) ListAuditEvents(ctx context.Context, filter *database.AuditFilter, cursor string, limit int) ([]apiv0.AuditEvent, string, error) {
+	if cursor != "" {
+		beforeID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return nil, "", fmt.Errorf("%w: invalid cursor", database.ErrInvalidInput)
+		}
+		filter.BeforeID = beforeID
+	}
+
+	events, err := s.db.ListAuditEvents(ctx, nil, filter, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(events) == limit {
+		nextCursor = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+	return events, nextCursor, nil
+}
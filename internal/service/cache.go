@@ -0,0 +1,354 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// lruCache keeps up to a number of values for a time, evicting the least recently used first.
+// Values are shared between callers, which must not modify them.
+type lruCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // of *lruEntry[V], most recently used first
+	entries map[string]*list.Element
+	// fills are the loads in progress, which removing their group makes stale
+	fills map[*lruFill]struct{}
+}
+
+// lruFill is a value being loaded for the cache. A stale fill's value may predate a change that
+// removed its group, and isn't cached.
+type lruFill struct {
+	group string
+	stale bool
+}
+
+type lruEntry[V any] struct {
+	key     string
+	group   string
+	value   V
+	expires time.Time
+}
+
+func newLRUCache[V any](size int, ttl time.Duration) *lruCache[V] {
+	return &lruCache[V]{size: size, ttl: ttl, order: list.New(), entries: make(map[string]*list.Element), fills: make(map[*lruFill]struct{})}
+}
+
+// get returns the value cached for a key, unless it expired
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zero V
+	element, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := element.Value.(*lruEntry[V])
+	if time.Now().After(entry.expires) {
+		c.removeLocked(element)
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// fill loads a value and caches it for a key, in a group that can be removed together. The value
+// is returned but not cached when its group is removed while it loads.
+func (c *lruCache[V]) fill(key, group string, load func() (V, error)) (V, error) {
+	pending := &lruFill{group: group}
+	c.mu.Lock()
+	c.fills[pending] = struct{}{}
+	c.mu.Unlock()
+
+	value, err := load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.fills, pending)
+	if err == nil && !pending.stale {
+		c.addLocked(key, group, value)
+	}
+	return value, err
+}
+
+// add caches a value for a key, in a group that can be removed together
+func (c *lruCache[V]) add(key, group string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(key, group, value)
+}
+
+func (c *lruCache[V]) addLocked(key, group string, value V) {
+	if element, ok := c.entries[key]; ok {
+		c.removeLocked(element)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, group: group, value: value, expires: time.Now().Add(c.ttl)})
+	for c.order.Len() > c.size {
+		c.removeLocked(c.order.Back())
+	}
+}
+
+// removeGroup removes the values cached in a group
+func (c *lruCache[V]) removeGroup(group string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for element := c.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*lruEntry[V]).group == group {
+			c.removeLocked(element)
+		}
+		element = next
+	}
+	for pending := range c.fills {
+		if pending.group == group {
+			pending.stale = true
+		}
+	}
+}
+
+// purge removes every cached value
+func (c *lruCache[V]) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+	for pending := range c.fills {
+		pending.stale = true
+	}
+}
+
+func (c *lruCache[V]) removeLocked(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[V]).key)
+}
+
+// serverPage is a cached first page of a server listing
+type serverPage struct {
+	servers    []*apiv0.ServerResponse
+	nextCursor string
+}
+
+// cachedRegistryService serves the most requested server versions and the first pages of server
+// listings from memory. Publishes and edits made through it drop what they change at once, and
+// what they changed is read again from the primary database for as long as entries are cached,
+// so that a read replica lagging behind the change isn't cached in its place. Changes made by
+// other instances, or to what the registry records about servers (scans, advisories, usage and
+// the like), show once the cached entries expire.
+type cachedRegistryService struct {
+	RegistryService
+	servers *lruCache[*apiv0.ServerResponse]
+	pages   *lruCache[serverPage]
+	// written are the servers changed through the cache, keyed by name, and the listings under
+	// an empty name
+	written *lruCache[struct{}]
+	metrics *telemetry.Metrics
+}
+
+// NewCachedRegistryService caches the server versions and first pages of server listings a registry
+// service returns, for the configured time and up to the configured number of each. It returns the
+// service itself when caching is turned off.
+func NewCachedRegistryService(registry RegistryService, cfg *config.Config, metrics *telemetry.Metrics) RegistryService {
+	if cfg.ServerCacheSize <= 0 || cfg.ServerCacheTTL <= 0 {
+		return registry
+	}
+	return &cachedRegistryService{
+		RegistryService: registry,
+		servers:         newLRUCache[*apiv0.ServerResponse](cfg.ServerCacheSize, cfg.ServerCacheTTL),
+		pages:           newLRUCache[serverPage](cfg.ServerCacheSize, cfg.ServerCacheTTL),
+		written:         newLRUCache[struct{}](cfg.ServerCacheSize, cfg.ServerCacheTTL),
+		metrics:         metrics,
+	}
+}
+
+// record counts a lookup in one of the caches
+func (s *cachedRegistryService) record(ctx context.Context, cache string, hit bool) {
+	if s.metrics == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	s.metrics.CacheRequests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cache", cache),
+		attribute.String("result", result),
+	))
+}
+
+func (s *cachedRegistryService) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
+	return s.getServer(ctx, serverName, "", func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.RegistryService.GetServerByName(ctx, serverName)
+	})
+}
+
+func (s *cachedRegistryService) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error) {
+	return s.getServer(ctx, serverName, version, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.RegistryService.GetServerByNameAndVersion(ctx, serverName, version)
+	})
+}
+
+// getServer returns a cached server version, or caches the one get returns. An empty version is
+// the latest one.
+func (s *cachedRegistryService) getServer(ctx context.Context, serverName, version string, get func(context.Context) (*apiv0.ServerResponse, error)) (*apiv0.ServerResponse, error) {
+	key := serverName + "\x00" + version
+	if server, ok := s.servers.get(key); ok {
+		s.record(ctx, "server", true)
+		return server, nil
+	}
+	s.record(ctx, "server", false)
+
+	return s.servers.fill(key, serverName, func() (*apiv0.ServerResponse, error) {
+		return get(s.readContext(ctx, serverName))
+	})
+}
+
+// ListServers caches the first page of each listing
+func (s *cachedRegistryService) ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	if cursor != "" {
+		return s.RegistryService.ListServers(ctx, filter, cursor, limit)
+	}
+	encodedFilter, err := json.Marshal(filter)
+	if err != nil {
+		return s.RegistryService.ListServers(ctx, filter, cursor, limit)
+	}
+	key := strconv.Itoa(limit) + "\x00" + string(encodedFilter)
+	if page, ok := s.pages.get(key); ok {
+		s.record(ctx, "server_list", true)
+		return page.servers, page.nextCursor, nil
+	}
+	s.record(ctx, "server_list", false)
+
+	page, err := s.pages.fill(key, "", func() (serverPage, error) {
+		servers, nextCursor, err := s.RegistryService.ListServers(s.readContext(ctx, ""), filter, cursor, limit)
+		return serverPage{servers: servers, nextCursor: nextCursor}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return page.servers, page.nextCursor, nil
+}
+
+// readContext reads a server, or the listings for an empty name, from the primary database if it
+// was changed through the cache recently
+func (s *cachedRegistryService) readContext(ctx context.Context, serverName string) context.Context {
+	if _, ok := s.written.get(serverName); ok {
+		return database.ReadFromPrimary(ctx)
+	}
+	return ctx
+}
+
+// invalidate drops the cached versions of servers, and every cached listing
+func (s *cachedRegistryService) invalidate(serverNames ...string) {
+	for _, name := range serverNames {
+		s.servers.removeGroup(name)
+		s.written.add(name, "", struct{}{})
+	}
+	s.pages.purge()
+	s.written.add("", "", struct{}{})
+}
+
+func (s *cachedRegistryService) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	defer s.invalidate(req.Name)
+	return s.RegistryService.CreateServer(ctx, req)
+}
+
+func (s *cachedRegistryService) PublishServer(ctx context.Context, req *apiv0.ServerJSON, idempotencyKey string, signature *Signature, publisher *Publisher) (*apiv0.ServerResponse, bool, error) {
+	defer s.invalidate(req.Name)
+	return s.RegistryService.PublishServer(ctx, req, idempotencyKey, signature, publisher)
+}
+
+func (s *cachedRegistryService) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editor *Publisher, revision int) (*apiv0.ServerResponse, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.UpdateServer(ctx, serverName, version, req, newStatus, editor, revision)
+}
+
+func (s *cachedRegistryService) SetServerStatus(ctx context.Context, serverName, version string, update *apiv0.ServerStatusUpdate) ([]*apiv0.ServerResponse, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.SetServerStatus(ctx, serverName, version, update)
+}
+
+func (s *cachedRegistryService) ImportServers(ctx context.Context, entries []*apiv0.ServerResponse, dryRun bool) (*apiv0.ImportReport, error) {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry != nil {
+			names = append(names, entry.Server.Name)
+		}
+	}
+	defer s.invalidate(names...)
+	return s.RegistryService.ImportServers(ctx, entries, dryRun)
+}
+
+func (s *cachedRegistryService) ClaimServer(ctx context.Context, serverName string, claimant apiv0.Principal, verify ClaimVerifier) (*apiv0.ServerRedirect, error) {
+	redirect, err := s.RegistryService.ClaimServer(ctx, serverName, claimant, verify)
+	if redirect != nil {
+		s.invalidate(serverName, redirect.To)
+	}
+	return redirect, err
+}
+
+func (s *cachedRegistryService) RenameServer(ctx context.Context, serverName, newName string) (*apiv0.ServerRedirect, error) {
+	defer s.invalidate(serverName, newName)
+	return s.RegistryService.RenameServer(ctx, serverName, newName)
+}
+
+func (s *cachedRegistryService) YankServerVersion(ctx context.Context, serverName, version string, yanked bool) (*apiv0.ServerResponse, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.YankServerVersion(ctx, serverName, version, yanked)
+}
+
+func (s *cachedRegistryService) PublishUpdateProposal(ctx context.Context, serverName, id string) (*apiv0.ServerResponse, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.PublishUpdateProposal(ctx, serverName, id)
+}
+
+func (s *cachedRegistryService) QuarantineServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.QuarantineServer(ctx, serverName, reason, moderator)
+}
+
+func (s *cachedRegistryService) ReinstateServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.ReinstateServer(ctx, serverName, reason, moderator)
+}
+
+func (s *cachedRegistryService) DeleteQuarantinedServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.DeleteQuarantinedServer(ctx, serverName, reason, moderator)
+}
+
+func (s *cachedRegistryService) RestoreServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.RestoreServer(ctx, serverName, reason, moderator)
+}
+
+func (s *cachedRegistryService) AddServerAlias(ctx context.Context, serverName, alias string) (*apiv0.ServerRedirect, error) {
+	defer s.invalidate(serverName, alias)
+	return s.RegistryService.AddServerAlias(ctx, serverName, alias)
+}
+
+func (s *cachedRegistryService) SetServerChannel(ctx context.Context, serverName, channel, version string) (*apiv0.ServerChannel, error) {
+	defer s.invalidate(serverName)
+	return s.RegistryService.SetServerChannel(ctx, serverName, channel, version)
+}
+
+func (s *cachedRegistryService) DeleteServerChannel(ctx context.Context, serverName, channel string) error {
+	defer s.invalidate(serverName)
+	return s.RegistryService.DeleteServerChannel(ctx, serverName, channel)
+}
+
+func (s *cachedRegistryService) DeleteServerRedirect(ctx context.Context, serverName, from string) error {
+	defer s.invalidate(serverName, from)
+	return s.RegistryService.DeleteServerRedirect(ctx, serverName, from)
+}
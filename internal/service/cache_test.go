@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// countingRegistry serves the latest version of any server, counting the reads that reach it and
+// those of them that the registry service would send to the primary database
+type countingRegistry struct {
+	service.RegistryService
+	version      string
+	reads        int
+	primaryReads int
+	// during runs once in the middle of the next read, which returns what was there before
+	during func()
+}
+
+func (r *countingRegistry) read(ctx context.Context) {
+	r.reads++
+	if during := r.during; during != nil {
+		r.during = nil
+		during()
+	}
+	if !database.ReadsFromReplica(database.ReadFromReplica(ctx)) {
+		r.primaryReads++
+	}
+}
+
+func (r *countingRegistry) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
+	version := r.version
+	r.read(ctx)
+	return &apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: serverName, Version: version}}, nil
+}
+
+func (r *countingRegistry) ListServers(ctx context.Context, _ *database.ServerFilter, cursor string, _ int) ([]*apiv0.ServerResponse, string, error) {
+	version := r.version
+	r.read(ctx)
+	return []*apiv0.ServerResponse{{Server: apiv0.ServerJSON{Name: "com.example/weather", Version: version}}}, cursor + "next", nil
+}
+
+func (r *countingRegistry) UpdateServer(_ context.Context, _, version string, _ *apiv0.ServerJSON, _ *string, _ *service.Publisher, _ int) (*apiv0.ServerResponse, error) {
+	r.version = version
+	return nil, nil
+}
+
+func (r *countingRegistry) SetServerChannel(_ context.Context, _, channel, version string) (*apiv0.ServerChannel, error) {
+	return &apiv0.ServerChannel{Channel: channel, Version: version}, nil
+}
+
+func (r *countingRegistry) DeleteServerChannel(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func TestCachedRegistryService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves repeated reads from memory until an edit", func(t *testing.T) {
+		inner := &countingRegistry{version: "1.0.0"}
+		registry := service.NewCachedRegistryService(inner, &config.Config{ServerCacheSize: 10, ServerCacheTTL: time.Minute}, nil)
+
+		for range 3 {
+			server, err := registry.GetServerByName(ctx, "com.example/weather")
+			require.NoError(t, err)
+			assert.Equal(t, "1.0.0", server.Server.Version)
+			_, next, err := registry.ListServers(ctx, &database.ServerFilter{}, "", 10)
+			require.NoError(t, err)
+			assert.Equal(t, "next", next)
+		}
+		assert.Equal(t, 2, inner.reads)
+		assert.Zero(t, inner.primaryReads)
+
+		_, _, err := registry.ListServers(ctx, &database.ServerFilter{}, "next", 10)
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.reads, "only first pages are cached")
+
+		_, err = registry.UpdateServer(ctx, "com.example/weather", "1.0.1", nil, nil, nil, 0)
+		require.NoError(t, err)
+		server, err := registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.1", server.Server.Version)
+		page, _, err := registry.ListServers(ctx, &database.ServerFilter{}, "", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.1", page[0].Server.Version)
+		assert.Equal(t, 2, inner.primaryReads, "what an edit changed is read again from the primary")
+	})
+
+	t.Run("drops servers tagged into a channel", func(t *testing.T) {
+		inner := &countingRegistry{version: "1.0.0"}
+		registry := service.NewCachedRegistryService(inner, &config.Config{ServerCacheSize: 10, ServerCacheTTL: time.Minute}, nil)
+
+		_, err := registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		_, err = registry.SetServerChannel(ctx, "com.example/weather", "stable", "1.0.0")
+		require.NoError(t, err)
+		_, err = registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.reads)
+		assert.Equal(t, 1, inner.primaryReads)
+
+		require.NoError(t, registry.DeleteServerChannel(ctx, "com.example/weather", "stable"))
+		_, err = registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.reads)
+	})
+
+	t.Run("doesn't cache reads an edit overtook", func(t *testing.T) {
+		inner := &countingRegistry{version: "1.0.0"}
+		registry := service.NewCachedRegistryService(inner, &config.Config{ServerCacheSize: 10, ServerCacheTTL: time.Minute}, nil)
+		edit := func() {
+			_, err := registry.UpdateServer(ctx, "com.example/weather", "1.0.1", nil, nil, nil, 0)
+			require.NoError(t, err)
+		}
+
+		inner.during = edit
+		server, err := registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", server.Server.Version, "the read started before the edit")
+		server, err = registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.1", server.Server.Version)
+
+		inner.during = edit
+		_, _, err = registry.ListServers(ctx, &database.ServerFilter{}, "", 10)
+		require.NoError(t, err)
+		page, _, err := registry.ListServers(ctx, &database.ServerFilter{}, "", 10)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.1", page[0].Server.Version)
+		assert.Equal(t, 4, inner.reads)
+	})
+
+	t.Run("evicts the least recently used servers", func(t *testing.T) {
+		inner := &countingRegistry{version: "1.0.0"}
+		registry := service.NewCachedRegistryService(inner, &config.Config{ServerCacheSize: 2, ServerCacheTTL: time.Minute}, nil)
+
+		for _, name := range []string{"com.example/a", "com.example/b", "com.example/a", "com.example/c", "com.example/a", "com.example/b"} {
+			_, err := registry.GetServerByName(ctx, name)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 4, inner.reads, "b is evicted by c, and read again")
+	})
+
+	t.Run("expires servers", func(t *testing.T) {
+		inner := &countingRegistry{version: "1.0.0"}
+		registry := service.NewCachedRegistryService(inner, &config.Config{ServerCacheSize: 10, ServerCacheTTL: time.Millisecond}, nil)
+
+		_, err := registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+		_, err = registry.GetServerByName(ctx, "com.example/weather")
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.reads)
+	})
+
+	t.Run("is turned off without a size", func(t *testing.T) {
+		inner := &countingRegistry{}
+		assert.Same(t, service.RegistryService(inner), service.NewCachedRegistryService(inner, &config.Config{ServerCacheTTL: time.Minute}, nil))
+	})
+}
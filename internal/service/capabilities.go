@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Statuses of capability extractions
+const (
+	CapabilitiesPending   = "pending"
+	CapabilitiesRunning   = "running"
+	CapabilitiesSucceeded = "succeeded"
+	CapabilitiesFailed    = "failed"
+)
+
+// StaleCapabilityExtraction is how long an extraction can run before it is assumed to have been
+// abandoned by its worker and is handed to another. Workers must give up on servers well before.
+const StaleCapabilityExtraction = 30 * time.Minute
+
+// RequestCapabilityExtraction queues the extraction of the capabilities of a server version by
+// a sandbox worker and returns the version with its pending extraction
+func (s *registryServiceImpl) RequestCapabilityExtraction(ctx context.Context, serverName, version string) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		if err := s.db.RequestCapabilityExtraction(ctx, tx, serverName, version); err != nil {
+			return nil, err
+		}
+		return s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	})
+}
+
+// ClaimCapabilityExtraction hands the server version that has waited longest for its capabilities
+// to be extracted to a sandbox worker, or returns database.ErrNotFound when none is waiting
+func (s *registryServiceImpl) ClaimCapabilityExtraction(ctx context.Context) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		serverName, version, err := s.db.ClaimCapabilityExtraction(ctx, tx, time.Now().Add(-StaleCapabilityExtraction))
+		if err != nil {
+			return nil, err
+		}
+		return s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	})
+}
+
+// RecordCapabilities records what a sandbox worker extracted from a server version it claimed,
+// or why it failed to
+func (s *registryServiceImpl) RecordCapabilities(ctx context.Context, serverName, version string, capabilities *apiv0.Capabilities) error {
+	if capabilities.Status != CapabilitiesFailed && capabilities.ExtractedAt == nil {
+		now := time.Now()
+		capabilities.ExtractedAt = &now
+	}
+	return s.db.SetCapabilities(ctx, nil, serverName, version, capabilities)
+}
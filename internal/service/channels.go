@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Release channels. latest always follows the latest version; publishers tag versions into the others.
+const (
+	ReleaseChannelLatest = "latest"
+	ReleaseChannelStable = "stable"
+	ReleaseChannelBeta   = "beta"
+)
+
+// TaggableReleaseChannels lists the channels publishers can tag versions into
+var TaggableReleaseChannels = []string{ReleaseChannelStable, ReleaseChannelBeta}
+
+// Errors returned when a release channel can't be set
+var (
+	ErrUnknownReleaseChannel = errors.New("unknown release channel: channels are latest, stable and beta")
+	ErrLatestChannelReadOnly = errors.New("the latest channel always follows the latest version and can't be set")
+)
+
+// SetServerChannel tags a version of a server into a release channel, moving the channel off
+// whichever version it pointed at before
+func (s *registryServiceImpl) SetServerChannel(ctx context.Context, serverName, channel, version string) (*apiv0.ServerChannel, error) {
+	if err := validateTaggableChannel(channel); err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerChannel, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		server, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDeleted {
+			return nil, fmt.Errorf("%w: %s %s is deleted", database.ErrInvalidInput, serverName, version)
+		}
+		if server.Meta.Official != nil && server.Meta.Official.YankedAt != nil {
+			return nil, fmt.Errorf("%w: %s %s is yanked", database.ErrInvalidInput, serverName, version)
+		}
+
+		return s.db.SetServerChannel(ctx, tx, serverName, channel, version)
+	})
+}
+
+// ListServerChannels lists the release channels of a server, starting with latest
+func (s *registryServiceImpl) ListServerChannels(ctx context.Context, serverName string) ([]apiv0.ServerChannel, error) {
+	ctx = database.ReadFromReplica(ctx)
+	latest, err := s.db.GetServerByName(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+	tagged, err := s.db.ListServerChannels(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := []apiv0.ServerChannel{{Channel: ReleaseChannelLatest, Version: latest.Server.Version}}
+	if latest.Meta.Official != nil {
+		channels[0].UpdatedAt = latest.Meta.Official.PublishedAt
+	}
+	return append(channels, tagged...), nil
+}
+
+// DeleteServerChannel removes a release channel from a server
+func (s *registryServiceImpl) DeleteServerChannel(ctx context.Context, serverName, channel string) error {
+	if err := validateTaggableChannel(channel); err != nil {
+		return err
+	}
+	return s.db.DeleteServerChannel(ctx, nil, serverName, channel)
+}
+
+// GetServerByChannel retrieves the version of a server a release channel points at
+func (s *registryServiceImpl) GetServerByChannel(ctx context.Context, serverName, channel string) (*apiv0.ServerResponse, error) {
+	ctx = database.ReadFromReplica(ctx)
+	if channel == ReleaseChannelLatest {
+		return s.db.GetServerByName(ctx, nil, serverName)
+	}
+	if !slices.Contains(TaggableReleaseChannels, channel) {
+		return nil, ErrUnknownReleaseChannel
+	}
+
+	tagged, err := s.db.GetServerChannel(ctx, nil, serverName, channel)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.GetServerByNameAndVersion(ctx, nil, serverName, tagged.Version)
+}
+
+func validateTaggableChannel(channel string) error {
+	if channel == ReleaseChannelLatest {
+		return ErrLatestChannelReadOnly
+	}
+	if !slices.Contains(TaggableReleaseChannels, channel) {
+		return ErrUnknownReleaseChannel
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// SeededNamespace holds the servers seeded into the registry on behalf of their upstream
+// projects, which their maintainers can claim
+const SeededNamespace = "com.docker.mcp"
+
+// ErrNotSeededServer is returned when claiming a server that wasn't seeded
+var ErrNotSeededServer = errors.New("only servers seeded into the " + SeededNamespace + " namespace can be claimed")
+
+// ClaimVerifier checks that the claimant maintains the repository a seeded server declares,
+// and returns the name to move the server to
+type ClaimVerifier func(server *apiv0.ServerJSON) (string, error)
+
+// ClaimServer moves every version of a seeded server to the name chosen by verify, leaving a
+// redirect from the old name. Owners of the seeded namespace are notified of every attempt
+// that gets as far as verification.
+func (s *registryServiceImpl) ClaimServer(ctx context.Context, serverName string, claimant apiv0.Principal, verify ClaimVerifier) (*apiv0.ServerRedirect, error) {
+	if !strings.HasPrefix(serverName, SeededNamespace+"/") {
+		return nil, ErrNotSeededServer
+	}
+
+	latest, err := s.db.GetServerByName(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect, err := s.claimServer(ctx, latest, verify)
+
+	message := fmt.Sprintf("%s:%s tried to claim %s", claimant.AuthMethod, claimant.Subject, serverName)
+	if err == nil {
+		message = fmt.Sprintf("%s:%s claimed %s, which moved to %s", claimant.AuthMethod, claimant.Subject, serverName, redirect.To)
+	}
+	s.notifier.Notify(notifications.Event{
+		Type:       notifications.EventOwnershipClaimAttempted,
+		Namespace:  SeededNamespace,
+		ServerName: serverName,
+		Message:    message,
+	})
+
+	return redirect, err
+}
+
+func (s *registryServiceImpl) claimServer(ctx context.Context, latest *apiv0.ServerResponse, verify ClaimVerifier) (*apiv0.ServerRedirect, error) {
+	newName, err := verify(&latest.Server)
+	if err != nil {
+		return nil, err
+	}
+	return s.moveServer(ctx, latest.Server.Name, newName, ServerRedirectClaim)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Names of the exports the registry renders
+const (
+	ExportServers = "servers.json"
+	ExportSitemap = "sitemap.xml"
+)
+
+// PutExport stores a document rendered from the whole registry, replacing the previous rendering
+func (s *registryServiceImpl) PutExport(ctx context.Context, export *database.Export) error {
+	return s.db.PutExport(ctx, nil, export)
+}
+
+// GetExport returns the latest rendering of an export, with its body gzip-encoded or not
+func (s *registryServiceImpl) GetExport(ctx context.Context, name string, gzipped bool) (*database.Export, error) {
+	return s.db.GetExport(ctx, nil, name, gzipped)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServerActivity is a server version as last published or updated, with the identity recorded as
+// publishing the server, if any
+type ServerActivity struct {
+	Server    *apiv0.ServerResponse
+	Publisher *apiv0.Principal
+}
+
+// ListServerActivity returns the server versions published or updated most recently, most recent
+// first, leaving out quarantined servers
+func (s *registryServiceImpl) ListServerActivity(ctx context.Context, limit int) ([]ServerActivity, error) {
+	ctx = database.ReadFromReplica(ctx)
+	quarantined := false
+	servers, _, err := s.db.ListServers(ctx, nil, &database.ServerFilter{SortByUpdated: true, Quarantined: &quarantined}, "", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := make([]ServerActivity, 0, len(servers))
+	publishers := map[string]*apiv0.Principal{}
+	for _, server := range servers {
+		name := server.Server.Name
+		publisher, ok := publishers[name]
+		if !ok {
+			publisher, err = s.db.GetServerPublisher(ctx, nil, name)
+			if err != nil && !errors.Is(err, database.ErrNotFound) {
+				return nil, err
+			}
+			publishers[name] = publisher
+		}
+		activity = append(activity, ServerActivity{Server: server, Publisher: publisher})
+	}
+	return activity, nil
+}
@@ -0,0 +1,13 @@
+package service
+
+import "context"
+
+// PingDatabase checks the database is reachable
+func (s *registryServiceImpl) PingDatabase(ctx context.Context) error {
+	return s.db.Ping(ctx)
+}
+
+// PendingMigrations lists the migrations this build has that the database hasn't applied
+func (s *registryServiceImpl) PendingMigrations(ctx context.Context) ([]string, error) {
+	return s.db.PendingMigrations(ctx, nil)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different server.json
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used to publish a different server.json")
+
+// PublishServer creates a new server version like CreateServer, provided the publisher published
+// the server first or may override that. With an idempotency key, the key is stored with the
+// version, and a retry with the same key and server.json returns that version rather than failing
+// as a duplicate. The second return value reports whether it was such a retry. The signature,
+// which may be nil, is stored with the version once it's verified with the server's signing key.
+func (s *registryServiceImpl) PublishServer(ctx context.Context, req *apiv0.ServerJSON, idempotencyKey string, signature *Signature, publisher *Publisher) (*apiv0.ServerResponse, bool, error) {
+	if idempotencyKey == "" {
+		published, err := s.createServer(ctx, req, signature, publisher)
+		return published, false, err
+	}
+
+	replayed := false
+	published, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		// Hold the publish lock before looking the key up, so that concurrent retries wait for the first
+		if err := s.db.AcquirePublishLock(ctx, tx, req.Name); err != nil {
+			return nil, err
+		}
+
+		// Replays are checked too, so a stranger's retry doesn't return the version
+		if err := s.checkServerPublisher(ctx, tx, req.Name, publisher); err != nil {
+			return nil, err
+		}
+
+		version, err := s.db.GetServerVersionByIdempotencyKey(ctx, tx, req.Name, idempotencyKey)
+		if err == nil {
+			existing, err := s.db.GetServerByNameAndVersion(ctx, tx, req.Name, version)
+			if err != nil {
+				return nil, err
+			}
+			if !sameServerJSON(&existing.Server, req) {
+				return nil, ErrIdempotencyKeyReused
+			}
+			replayed = true
+			return existing, nil
+		}
+		if !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+
+		published, err := s.createServerInTransaction(ctx, tx, req, signature, publisher)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.SetServerIdempotencyKey(ctx, tx, req.Name, req.Version, idempotencyKey); err != nil {
+			return nil, err
+		}
+		return published, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !replayed {
+		s.notifyPublished(published)
+	}
+	return published, replayed, nil
+}
+
+// sameServerJSON reports whether two server.json documents encode the same
+func sameServerJSON(a, b *apiv0.ServerJSON) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
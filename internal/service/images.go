@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// recordPackageImages looks up the platforms, sizes and declared resources of the OCI images of a
+// newly published version and records them on the version. Like registry validation, it only runs when that is
+// enabled, and it never fails a publish: images that can't be inspected are logged and skipped.
+func (s *registryServiceImpl) recordPackageImages(ctx context.Context, tx pgx.Tx, published *apiv0.ServerResponse) error {
+	if !s.cfg.EnableRegistryValidation {
+		return nil
+	}
+
+	for _, pkg := range published.Server.Packages {
+		if pkg.RegistryType != model.RegistryTypeOCI {
+			continue
+		}
+		info, err := registries.InspectOCIImage(ctx, pkg.Identifier)
+		if err != nil {
+			log.Printf("Skipping image metadata of %s for %s %s: %v", pkg.Identifier, published.Server.Name, published.Server.Version, err)
+			continue
+		}
+
+		image := apiv0.OCIImage{
+			Identifier: pkg.Identifier,
+			Platforms:  info.Platforms,
+			Size:       info.Size,
+			Memory:     info.Memory,
+			CPUs:       info.CPUs,
+		}
+		if image.Platforms == nil {
+			image.Platforms = []string{}
+		}
+		if err := s.db.SetPackageImage(ctx, tx, published.Server.Name, published.Server.Version, &image); err != nil {
+			return err
+		}
+		published.Meta.Official.Images = append(published.Meta.Official.Images, image)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Results of the entries of an import
+const (
+	ImportResultOK     = "ok"
+	ImportResultFailed = "failed"
+)
+
+// errImportNotApplied rolls back the transaction of an import that failed or is a dry run
+var errImportNotApplied = errors.New("import not applied")
+
+// ImportServers publishes a batch of seed entries in a single transaction. Every entry is
+// published as CreateServer would, then given the status its official metadata sets. The batch
+// is saved only when every entry succeeds and it isn't a dry run; either way the report says how
+// each entry fared.
+func (s *registryServiceImpl) ImportServers(ctx context.Context, entries []*apiv0.ServerResponse, dryRun bool) (*apiv0.ImportReport, error) {
+	report := &apiv0.ImportReport{Results: make([]apiv0.ImportResult, len(entries))}
+	published := make([]*apiv0.ServerResponse, len(entries))
+
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		for i, entry := range entries {
+			report.Results[i] = apiv0.ImportResult{Index: i, Name: entry.Server.Name, Version: entry.Server.Version, Status: ImportResultOK}
+			err := importEntry(ctx, tx, &report.Results[i], func(tx pgx.Tx) error {
+				var err error
+				published[i], err = s.createServerInTransaction(ctx, tx, &entry.Server, nil, nil)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Set statuses once every server is created, so that replacements later in the batch exist
+		for i, entry := range entries {
+			update := SeedStatusUpdate(entry)
+			if published[i] == nil || update == nil {
+				continue
+			}
+			err := importEntry(ctx, tx, &report.Results[i], func(tx pgx.Tx) error {
+				if _, err := s.setServerStatusInTransaction(ctx, tx, entry.Server.Name, entry.Server.Version, update); err != nil {
+					return fmt.Errorf("failed to set status %s: %w", update.Status, err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, result := range report.Results {
+			if result.Status == ImportResultOK {
+				report.Created++
+			} else {
+				report.Failed++
+			}
+		}
+		if report.Failed > 0 || dryRun {
+			return errImportNotApplied
+		}
+		return nil
+	})
+	if errors.Is(err, errImportNotApplied) {
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report.Applied = true
+	for _, server := range published {
+		s.notifyPublished(server)
+	}
+	return report, nil
+}
+
+// SeedStatusUpdate returns the status change a seed entry's official metadata asks for, or nil
+// when the entry is a plain server.json or its version is active
+func SeedStatusUpdate(entry *apiv0.ServerResponse) *apiv0.ServerStatusUpdate {
+	official := entry.Meta.Official
+	if official == nil || official.Status == "" || official.Status == model.StatusActive {
+		return nil
+	}
+
+	update := &apiv0.ServerStatusUpdate{Status: official.Status}
+	if official.StatusDetails != nil {
+		update.Reason = official.StatusDetails.Reason
+		update.ReplacedBy = official.StatusDetails.ReplacedBy
+	}
+	return update
+}
+
+// importEntry runs a step of importing an entry in a savepoint, so that the entry failing leaves
+// the transaction usable, and records the failure in the entry's result. Only errors with the
+// savepoint itself are returned.
+func importEntry(ctx context.Context, tx pgx.Tx, result *apiv0.ImportResult, fn func(tx pgx.Tx) error) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(savepoint); err != nil {
+		result.Status = ImportResultFailed
+		result.Error = err.Error()
+		return savepoint.Rollback(ctx)
+	}
+	return savepoint.Commit(ctx)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Job statuses
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobDead      = "dead"
+)
+
+// DefaultJobMaxAttempts is how many attempts a job gets unless it's enqueued with another number
+const DefaultJobMaxAttempts = 5
+
+// EnqueueJob queues a job, reporting false when a job with the same dedupe key was already queued
+func (s *registryServiceImpl) EnqueueJob(ctx context.Context, job *apiv0.Job) (bool, error) {
+	if job.Kind == "" {
+		return false, errors.New("job kind is required")
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = DefaultJobMaxAttempts
+	}
+	return s.db.EnqueueJob(ctx, nil, job)
+}
+
+// ClaimJob hands the due job of one of the given kinds that has been due longest to a worker,
+// which has it for the lease before it is due again
+func (s *registryServiceImpl) ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*apiv0.Job, error) {
+	return s.db.ClaimJob(ctx, nil, kinds, time.Now().Add(lease))
+}
+
+// FinishJob records how a job's attempt went. A failed attempt is retried at retryAt, or the job
+// is dead when retryAt is nil.
+func (s *registryServiceImpl) FinishJob(ctx context.Context, job *apiv0.Job, jobErr error, retryAt *time.Time) error {
+	message := ""
+	if jobErr != nil {
+		message = jobErr.Error()
+		if message == "" {
+			message = "job failed"
+		}
+	} else {
+		retryAt = nil
+	}
+	return s.db.FinishJob(ctx, nil, job.ID, message, retryAt)
+}
+
+// ListJobs lists the jobs with a status, most recently created first
+func (s *registryServiceImpl) ListJobs(ctx context.Context, status string, limit int) ([]apiv0.Job, error) {
+	return s.db.ListJobs(ctx, nil, status, limit)
+}
+
+// RetryJob queues a dead job again, with its attempts reset
+func (s *registryServiceImpl) RetryJob(ctx context.Context, id string) (*apiv0.Job, error) {
+	return s.db.RetryJob(ctx, nil, id)
+}
+
+// PruneJobs deletes the jobs that succeeded before a time, and the dead ones that died before
+// another, returning how many
+func (s *registryServiceImpl) PruneJobs(ctx context.Context, succeededBefore, deadBefore time.Time) (int, error) {
+	succeeded, err := s.db.DeleteFinishedJobs(ctx, nil, JobSucceeded, succeededBefore)
+	if err != nil {
+		return 0, err
+	}
+	dead, err := s.db.DeleteFinishedJobs(ctx, nil, JobDead, deadBefore)
+	return succeeded + dead, err
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Statuses of moderation cases
+const (
+	ModerationQuarantined = "quarantined"
+	ModerationReinstated  = "reinstated"
+	ModerationDeleted     = "deleted"
+)
+
+// ErrNotQuarantined is returned when reinstating or deleting a server that isn't quarantined
+var ErrNotQuarantined = errors.New("server is not quarantined")
+
+// ErrServerAwaitingPurge is returned when publishing a server an admin deleted, whose name stays
+// reserved while it can be restored
+var ErrServerAwaitingPurge = errors.New("server was deleted by the registry's moderators and its name can't be published until it is purged")
+
+// QuarantineServer hides a server from listings until an admin reinstates or deletes it. Its
+// versions can still be fetched by name, with the quarantine in their official metadata. The
+// namespace's subscribers are notified with the reason.
+func (s *registryServiceImpl) QuarantineServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	if _, err := s.db.GetServerByName(ctx, nil, serverName); err != nil {
+		return nil, err
+	}
+
+	moderationCase, err := s.db.CreateModerationCase(ctx, nil, serverName, reason, moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyModerated(serverName, fmt.Sprintf("%s was quarantined by the registry's moderators and is hidden from listings: %s", serverName, reason))
+	return moderationCase, nil
+}
+
+// ReinstateServer lifts the quarantine of a server, listing it again
+func (s *registryServiceImpl) ReinstateServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	moderationCase, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ModerationCase, error) {
+		open, err := s.openModerationCase(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+		return s.db.ResolveModerationCase(ctx, tx, open.ID, ModerationReinstated, reason, moderator)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyModerated(serverName, fmt.Sprintf("%s was reinstated by the registry's moderators and is listed again: %s", serverName, reason))
+	return moderationCase, nil
+}
+
+// DeleteQuarantinedServer deletes a quarantined server and every version of it. The server is
+// hidden from every read, with its name reserved, until PurgeDeletedServers purges it, and can be
+// brought back with RestoreServer until then. Webhooks get a deletion event for each version, so
+// that mirrors drop them too.
+func (s *registryServiceImpl) DeleteQuarantinedServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	moderationCase, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ModerationCase, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		open, err := s.openModerationCase(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+
+		versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		for _, version := range versions {
+			if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventServerDeleted, version); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.db.SoftDeleteServer(ctx, tx, serverName); err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+
+		return s.db.ResolveModerationCase(ctx, tx, open.ID, ModerationDeleted, reason, moderator)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyModerated(serverName, fmt.Sprintf("%s was deleted by the registry's moderators: %s", serverName, reason))
+	return moderationCase, nil
+}
+
+// RestoreServer brings back a deleted server that wasn't purged yet. It comes back quarantined, as
+// it was when it was deleted, with a new case for the reason it was restored, so that admins
+// reinstate it once they've checked it.
+func (s *registryServiceImpl) RestoreServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error) {
+	moderationCase, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ModerationCase, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if err := s.db.RestoreDeletedServer(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		return s.db.CreateModerationCase(ctx, tx, serverName, reason, moderator)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyModerated(serverName, fmt.Sprintf("%s was restored by the registry's moderators and stays quarantined until it is reinstated: %s", serverName, reason))
+	return moderationCase, nil
+}
+
+// PurgeDeletedServers permanently deletes the servers deleted before a time, with their reviews,
+// advisories and redirects, freeing their names. It returns how many servers it purged.
+func (s *registryServiceImpl) PurgeDeletedServers(ctx context.Context, deletedBefore time.Time) (int, error) {
+	names, err := s.db.ListDeletedServers(ctx, nil, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, name := range names {
+		skipped := false
+		err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			if err := s.db.AcquirePublishLock(ctx, tx, name); err != nil {
+				return err
+			}
+			// Skip servers restored since they were listed, and those deleted again since, whose
+			// retention window starts over
+			deletedAt, err := s.db.GetServerDeletedAt(ctx, tx, name)
+			if err != nil {
+				return err
+			}
+			if !deletedAt.Before(deletedBefore) {
+				skipped = true
+				return nil
+			}
+			return s.db.PurgeServer(ctx, tx, name)
+		})
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			continue
+		case err != nil:
+			return purged, fmt.Errorf("failed to purge %s: %w", name, err)
+		}
+		if !skipped {
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// ListModerationCases lists the moderation cases with a status, or every case when status is
+// empty, most recent first
+func (s *registryServiceImpl) ListModerationCases(ctx context.Context, status string, limit int) ([]apiv0.ModerationCase, error) {
+	return s.db.ListModerationCases(ctx, nil, status, limit)
+}
+
+// openModerationCase returns the case a server is quarantined by, or ErrNotQuarantined
+func (s *registryServiceImpl) openModerationCase(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ModerationCase, error) {
+	open, err := s.db.GetOpenModerationCase(ctx, tx, serverName)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, ErrNotQuarantined
+	}
+	return open, err
+}
+
+// notifyModerated notifies the subscribers of a namespace that a moderator acted on one of its servers
+func (s *registryServiceImpl) notifyModerated(serverName, message string) {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	s.notifier.Notify(notifications.Event{
+		Type:       notifications.EventServerModerated,
+		Namespace:  namespace,
+		ServerName: serverName,
+		Message:    message,
+	})
+}
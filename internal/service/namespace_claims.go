@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// How a namespace claim proves control of the namespace
+const (
+	NamespaceClaimDNS    = "dns"
+	NamespaceClaimHTTP   = "http"
+	NamespaceClaimGitHub = "github"
+)
+
+// Statuses of namespace claims
+const (
+	NamespaceClaimPending  = "pending"
+	NamespaceClaimVerified = "verified"
+	NamespaceClaimExpired  = "expired"
+	NamespaceClaimFailed   = "failed"
+)
+
+// Where domain namespace claimants publish their challenge token
+const (
+	NamespaceClaimRecordPrefix = "_mcp-registry-challenge."
+	NamespaceClaimRecordValue  = "mcp-registry-challenge="
+	NamespaceClaimWellKnown    = "/.well-known/mcp-registry-challenge"
+)
+
+const (
+	// NamespaceClaimLease is how long a leased claim waits before it is checked again when the
+	// check's outcome was never recorded
+	NamespaceClaimLease = 2 * time.Minute
+	// namespaceClaimRecheckInterval is how often the proof of a verified claim is looked for again
+	namespaceClaimRecheckInterval = 24 * time.Hour
+	// namespaceClaimRetryDelay is the wait after the first failed check of a pending claim,
+	// doubling after each one up to namespaceClaimMaxRetryDelay
+	namespaceClaimRetryDelay    = time.Minute
+	namespaceClaimMaxRetryDelay = time.Hour
+	// namespaceClaimPendingTimeout is how long the proof of a new claim is looked for before the claim fails
+	namespaceClaimPendingTimeout = 7 * 24 * time.Hour
+	// defaultNamespaceClaimValidity is how long a claim grants its namespace after its proof was
+	// last found, when the configuration doesn't say
+	defaultNamespaceClaimValidity = 30 * 24 * time.Hour
+)
+
+// ErrClaimMethodNotAllowed is returned when claiming a namespace with a method that can't prove control of it
+var ErrClaimMethodNotAllowed = errors.New("domain namespaces are claimed with a DNS or HTTP challenge, and io.github namespaces with a GitHub identity")
+
+// NamespaceDomain returns the domain a namespace is named after, e.g. example.com for
+// com.example, or "" for io.github namespaces and namespaces that can't be a domain
+func NamespaceDomain(namespace string) string {
+	if strings.HasPrefix(namespace, "io.github.") {
+		return ""
+	}
+	labels := strings.Split(namespace, ".")
+	if len(labels) < 2 || slices.Contains(labels, "") {
+		return ""
+	}
+	slices.Reverse(labels)
+	return strings.Join(labels, ".")
+}
+
+// ClaimNamespace creates a principal's claim on a namespace, or starts their previous claim over
+// with the same challenge. DNS and HTTP claims are pending until the verifier finds the
+// challenge; GitHub claims are verified straight away, and the caller is responsible for
+// checking that the claimant's GitHub identity proves control of the namespace.
+func (s *registryServiceImpl) ClaimNamespace(ctx context.Context, namespace, method string, claimant apiv0.Principal) (*apiv0.NamespaceClaim, error) {
+	var allowed bool
+	switch {
+	case NamespaceDomain(namespace) != "":
+		allowed = method == NamespaceClaimDNS || method == NamespaceClaimHTTP
+	case strings.HasPrefix(namespace, "io.github."):
+		allowed = method == NamespaceClaimGitHub
+	}
+	if !allowed {
+		return nil, ErrClaimMethodNotAllowed
+	}
+
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate claim token: %w", err)
+	}
+
+	now := time.Now()
+	claim := &database.NamespaceClaimCheck{NamespaceClaim: apiv0.NamespaceClaim{
+		Namespace: namespace,
+		Method:    method,
+		Claimant:  claimant,
+		Challenge: &apiv0.ClaimChallenge{Token: hex.EncodeToString(randomBytes)},
+	}}
+	if method == NamespaceClaimGitHub {
+		// The GitHub identity can't be looked for again, so the claim lasts until it expires
+		// unless it is claimed again
+		expiresAt := now.Add(s.namespaceClaimValidity())
+		claim.Status = NamespaceClaimVerified
+		claim.VerifiedAt = &now
+		claim.ExpiresAt = &expiresAt
+		claim.NextCheckAt = &expiresAt
+	} else {
+		claim.Status = NamespaceClaimPending
+		claim.NextCheckAt = &now
+	}
+
+	stored, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.NamespaceClaimCheck, error) {
+		previous, err := s.db.ListNamespaceClaims(ctx, tx, namespace, &claimant)
+		if err != nil {
+			return nil, err
+		}
+		// Claiming again with the same method keeps a verified claim granting the namespace
+		// while its proof is looked for again
+		if len(previous) > 0 && previous[0].Status == NamespaceClaimVerified && previous[0].Method == method && method != NamespaceClaimGitHub {
+			claim.Status = NamespaceClaimVerified
+			claim.VerifiedAt = previous[0].VerifiedAt
+			claim.ExpiresAt = previous[0].ExpiresAt
+		}
+
+		stored, err := s.db.SetNamespaceClaim(ctx, tx, claim)
+		if err != nil {
+			return nil, err
+		}
+		if claim.Status == NamespaceClaimVerified && (len(previous) == 0 || previous[0].Status != NamespaceClaimVerified) {
+			if err := s.namespaceClaimVerified(ctx, tx, stored); err != nil {
+				return nil, err
+			}
+		}
+		return stored, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return withClaimChallenge(stored), nil
+}
+
+// ListNamespaceClaims lists the claims on a namespace, or only a claimant's when claimant isn't
+// nil, most recent first
+func (s *registryServiceImpl) ListNamespaceClaims(ctx context.Context, namespace string, claimant *apiv0.Principal) ([]apiv0.NamespaceClaim, error) {
+	checks, err := s.db.ListNamespaceClaims(ctx, nil, namespace, claimant)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make([]apiv0.NamespaceClaim, 0, len(checks))
+	for i := range checks {
+		claims = append(claims, *withClaimChallenge(&checks[i]))
+	}
+	return claims, nil
+}
+
+// GetNamespaceClaim retrieves a claim on a namespace by ID
+func (s *registryServiceImpl) GetNamespaceClaim(ctx context.Context, namespace, id string) (*apiv0.NamespaceClaim, error) {
+	claim, err := s.db.GetNamespaceClaim(ctx, nil, namespace, id)
+	if err != nil {
+		return nil, err
+	}
+	return withClaimChallenge(claim), nil
+}
+
+// WithdrawNamespaceClaim deletes a claim on a namespace. Withdrawing a verified claim also drops
+// the namespace's verification when the claim is what verified it.
+func (s *registryServiceImpl) WithdrawNamespaceClaim(ctx context.Context, namespace, id string, actor apiv0.Principal) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		claim, err := s.db.GetNamespaceClaim(ctx, tx, namespace, id)
+		if err != nil {
+			return err
+		}
+		if err := s.db.DeleteNamespaceClaim(ctx, tx, namespace, id); err != nil {
+			return err
+		}
+		if claim.Status != NamespaceClaimVerified {
+			return nil
+		}
+		return s.namespaceClaimLost(ctx, tx, claim, "namespace-claim-withdrawn", actor)
+	})
+}
+
+// LeaseNamespaceClaimCheck hands the claim that has been due for a check longest to a verifier,
+// with the challenge it looks for
+func (s *registryServiceImpl) LeaseNamespaceClaimCheck(ctx context.Context) (*database.NamespaceClaimCheck, error) {
+	claim, err := s.db.LeaseDueNamespaceClaim(ctx, nil, time.Now().Add(NamespaceClaimLease))
+	if err != nil {
+		return nil, err
+	}
+	claim.NamespaceClaim = *withClaimChallenge(claim)
+	return claim, nil
+}
+
+// RecordNamespaceClaimCheck records whether a verifier found a claim's proof. A pending claim is
+// verified once its proof is found, and is checked again with backoff until it fails. A verified
+// claim stays verified while its proof keeps being found, and expires once it hasn't been for
+// the claim validity.
+func (s *registryServiceImpl) RecordNamespaceClaimCheck(ctx context.Context, claim *database.NamespaceClaimCheck, checkErr error) error {
+	now := time.Now()
+	wasVerified := claim.Status == NamespaceClaimVerified
+	switch {
+	case checkErr == nil:
+		expiresAt := now.Add(s.namespaceClaimValidity())
+		next := now.Add(namespaceClaimRecheckInterval)
+		claim.Status = NamespaceClaimVerified
+		claim.FailedChecks = 0
+		claim.LastError = ""
+		claim.VerifiedAt = &now
+		claim.ExpiresAt = &expiresAt
+		claim.NextCheckAt = &next
+	case wasVerified && claim.ExpiresAt != nil && now.Before(*claim.ExpiresAt):
+		next := now.Add(NamespaceClaimRetryDelay(claim.FailedChecks + 1))
+		if next.After(*claim.ExpiresAt) {
+			next = *claim.ExpiresAt
+		}
+		claim.FailedChecks++
+		claim.LastError = checkErr.Error()
+		claim.NextCheckAt = &next
+	case wasVerified:
+		claim.Status = NamespaceClaimExpired
+		claim.FailedChecks++
+		claim.LastError = checkErr.Error()
+		claim.NextCheckAt = nil
+	case now.Sub(claim.CreatedAt) < namespaceClaimPendingTimeout:
+		next := now.Add(NamespaceClaimRetryDelay(claim.FailedChecks + 1))
+		claim.FailedChecks++
+		claim.LastError = checkErr.Error()
+		claim.NextCheckAt = &next
+	default:
+		claim.Status = NamespaceClaimFailed
+		claim.FailedChecks++
+		claim.LastError = checkErr.Error()
+		claim.NextCheckAt = nil
+	}
+
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		stored, err := s.db.SetNamespaceClaim(ctx, tx, claim)
+		if err != nil {
+			return err
+		}
+		switch {
+		case stored.Status == NamespaceClaimVerified && !wasVerified:
+			return s.namespaceClaimVerified(ctx, tx, stored)
+		case stored.Status == NamespaceClaimExpired && wasVerified:
+			return s.namespaceClaimLost(ctx, tx, stored, "namespace-claim-expired", stored.Claimant)
+		}
+		return nil
+	})
+}
+
+// NamespaceClaimRetryDelay returns how long to wait before checking a claim again after its nth failed check
+func NamespaceClaimRetryDelay(failedChecks int) time.Duration {
+	return min(namespaceClaimRetryDelay<<min(max(failedChecks, 1)-1, 16), namespaceClaimMaxRetryDelay)
+}
+
+// namespaceClaimVerified records that a claim on a namespace was verified, verifying the
+// namespace with it unless it already is
+func (s *registryServiceImpl) namespaceClaimVerified(ctx context.Context, tx pgx.Tx, claim *database.NamespaceClaimCheck) error {
+	if err := s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+		Namespace: claim.Namespace,
+		Action:    "namespace-claimed",
+		Actor:     claim.Claimant,
+	}); err != nil {
+		return err
+	}
+
+	// GitHub user namespaces can't be verified, since anyone can create a GitHub account
+	method := NamespaceVerificationDomain
+	if claim.Method == NamespaceClaimGitHub {
+		login := strings.TrimPrefix(claim.Namespace, "io.github.")
+		if strings.EqualFold(login, claim.Claimant.Subject) {
+			return nil
+		}
+		method = NamespaceVerificationGitHubOrg
+	}
+
+	_, err := s.db.GetNamespaceVerification(ctx, tx, claim.Namespace)
+	if !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	if _, err := s.db.SetNamespaceVerification(ctx, tx, &apiv0.NamespaceVerification{
+		Namespace:  claim.Namespace,
+		Method:     method,
+		VerifiedBy: claim.Claimant,
+	}); err != nil {
+		return err
+	}
+	return s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+		Namespace: claim.Namespace,
+		Action:    "namespace-verified",
+		Actor:     claim.Claimant,
+	})
+}
+
+// namespaceClaimLost records that a verified claim no longer grants its namespace, dropping the
+// namespace's verification when the claimant is who verified it
+func (s *registryServiceImpl) namespaceClaimLost(ctx context.Context, tx pgx.Tx, claim *database.NamespaceClaimCheck, action string, actor apiv0.Principal) error {
+	if err := s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+		Namespace: claim.Namespace,
+		Action:    action,
+		Actor:     actor,
+		Target:    &claim.Claimant,
+	}); err != nil {
+		return err
+	}
+
+	verification, err := s.db.GetNamespaceVerification(ctx, tx, claim.Namespace)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if verification.VerifiedBy != claim.Claimant {
+		return nil
+	}
+	if err := s.db.DeleteNamespaceVerification(ctx, tx, claim.Namespace); err != nil {
+		return err
+	}
+	return s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+		Namespace: claim.Namespace,
+		Action:    "namespace-verification-revoked",
+		Actor:     actor,
+	})
+}
+
+// namespaceClaimValidity returns how long a claim grants its namespace after its proof was last found
+func (s *registryServiceImpl) namespaceClaimValidity() time.Duration {
+	if s.cfg.NamespaceClaimValidity <= 0 {
+		return defaultNamespaceClaimValidity
+	}
+	return s.cfg.NamespaceClaimValidity
+}
+
+// withClaimChallenge returns a claim with where its challenge token is to be published, which
+// only DNS and HTTP claims have
+func withClaimChallenge(claim *database.NamespaceClaimCheck) *apiv0.NamespaceClaim {
+	result := claim.NamespaceClaim
+	if claim.Challenge == nil || claim.Method == NamespaceClaimGitHub {
+		result.Challenge = nil
+		return &result
+	}
+
+	challenge := apiv0.ClaimChallenge{Token: claim.Challenge.Token}
+	domain := NamespaceDomain(claim.Namespace)
+	switch claim.Method {
+	case NamespaceClaimDNS:
+		challenge.RecordName = NamespaceClaimRecordPrefix + domain
+		challenge.RecordValue = NamespaceClaimRecordValue + challenge.Token
+	case NamespaceClaimHTTP:
+		challenge.URL = "https://" + domain + NamespaceClaimWellKnown
+	}
+	result.Challenge = &challenge
+	return &result
+}
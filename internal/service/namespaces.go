@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Kinds of namespace request
+const (
+	NamespaceRequestTransfer   = "transfer"
+	NamespaceRequestDelegation = "delegation"
+)
+
+// Statuses of namespace requests
+const (
+	NamespaceRequestPending   = "pending"
+	NamespaceRequestAccepted  = "accepted"
+	NamespaceRequestDeclined  = "declined"
+	NamespaceRequestCancelled = "cancelled"
+)
+
+// namespaceRequestTTL is how long the recipient has to accept a request
+const namespaceRequestTTL = 7 * 24 * time.Hour
+
+// Errors returned when a namespace request can't be made or resolved
+var (
+	ErrSelfNamespaceRequest  = errors.New("cannot transfer or delegate a namespace to yourself")
+	ErrNotRequestRecipient   = errors.New("only the principal the request is addressed to can accept it")
+	ErrNotRequestParty       = errors.New("only the requester or the recipient can decline a request")
+	ErrRequestNotPending     = errors.New("request is no longer pending")
+	ErrRequestExpired        = errors.New("request has expired")
+	ErrNamespaceOwnerChanged = errors.New("namespace has changed owner since the request was made")
+)
+
+// GetNamespace retrieves the recorded owner, organization, delegates and claimants of a namespace
+func (s *registryServiceImpl) GetNamespace(ctx context.Context, namespace string) (*apiv0.NamespaceResponse, error) {
+	owner, err := s.db.GetNamespaceOwner(ctx, nil, namespace)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	organization, err := s.db.GetNamespaceOrganization(ctx, nil, namespace)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	delegates, err := s.db.ListNamespaceDelegates(ctx, nil, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	verification, err := s.getNamespaceVerification(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	claimants, err := s.db.ListNamespaceClaimants(ctx, nil, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.NamespaceResponse{
+		Namespace:     namespace,
+		Owner:         owner,
+		Organization:  organization,
+		Delegates:     delegates,
+		Verification:  verification,
+		Claimants:     claimants,
+		ClaimRequired: s.cfg.RequireNamespaceClaims && owner == nil && organization == "",
+	}, nil
+}
+
+// RequestNamespaceChange proposes transferring a namespace to, or delegating publish rights to
+// it to, another principal. The caller is responsible for checking that from owns the namespace.
+func (s *registryServiceImpl) RequestNamespaceChange(ctx context.Context, kind, namespace string, from, to apiv0.Principal) (*apiv0.NamespaceRequest, error) {
+	if from == to {
+		return nil, ErrSelfNamespaceRequest
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.NamespaceRequest, error) {
+		req, err := s.db.CreateNamespaceRequest(ctx, tx, &apiv0.NamespaceRequest{
+			Kind:      kind,
+			Namespace: namespace,
+			From:      from,
+			To:        to,
+			ExpiresAt: time.Now().Add(namespaceRequestTTL),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.auditNamespaceRequest(ctx, tx, req, "requested", from); err != nil {
+			return nil, err
+		}
+		return req, nil
+	})
+}
+
+// ListNamespaceRequests lists the pending requests made by or addressed to a principal
+func (s *registryServiceImpl) ListNamespaceRequests(ctx context.Context, principal apiv0.Principal) ([]apiv0.NamespaceRequest, error) {
+	return s.db.ListPendingNamespaceRequests(ctx, nil, principal)
+}
+
+// AcceptNamespaceRequest accepts a request on behalf of its recipient, applying the transfer
+// or delegation
+func (s *registryServiceImpl) AcceptNamespaceRequest(ctx context.Context, id string, caller apiv0.Principal) (*apiv0.NamespaceRequest, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.NamespaceRequest, error) {
+		req, err := s.lockPendingNamespaceRequest(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if req.To != caller {
+			return nil, ErrNotRequestRecipient
+		}
+		if time.Now().After(req.ExpiresAt) {
+			return nil, ErrRequestExpired
+		}
+
+		// The requester must still own the namespace: a request made before a transfer
+		// can't be used to undo it
+		if err := s.checkStillOwner(ctx, tx, req.Namespace, req.From); err != nil {
+			return nil, err
+		}
+
+		switch req.Kind {
+		case NamespaceRequestTransfer:
+			err = s.db.SetNamespaceOwner(ctx, tx, req.Namespace, req.To)
+			if err == nil {
+				// The previous owner stops hearing about the namespace
+				err = s.db.DeleteOwnerNotificationSubscriptions(ctx, tx, req.Namespace, req.From)
+			}
+		case NamespaceRequestDelegation:
+			err = s.db.AddNamespaceDelegate(ctx, tx, req.Namespace, req.To)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		resolved, err := s.db.ResolveNamespaceRequest(ctx, tx, req.ID, NamespaceRequestAccepted)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.auditNamespaceRequest(ctx, tx, resolved, "accepted", caller); err != nil {
+			return nil, err
+		}
+		return resolved, nil
+	})
+}
+
+// DeclineNamespaceRequest declines a request on behalf of its recipient, or cancels it on
+// behalf of the requester
+func (s *registryServiceImpl) DeclineNamespaceRequest(ctx context.Context, id string, caller apiv0.Principal) (*apiv0.NamespaceRequest, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.NamespaceRequest, error) {
+		req, err := s.lockPendingNamespaceRequest(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		var status string
+		switch caller {
+		case req.To:
+			status = NamespaceRequestDeclined
+		case req.From:
+			status = NamespaceRequestCancelled
+		default:
+			return nil, ErrNotRequestParty
+		}
+
+		resolved, err := s.db.ResolveNamespaceRequest(ctx, tx, req.ID, status)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.auditNamespaceRequest(ctx, tx, resolved, status, caller); err != nil {
+			return nil, err
+		}
+		return resolved, nil
+	})
+}
+
+// RevokeNamespaceDelegate removes a delegate's publish rights. The caller is responsible for
+// checking that actor owns the namespace or is the delegate.
+func (s *registryServiceImpl) RevokeNamespaceDelegate(ctx context.Context, namespace string, delegate, actor apiv0.Principal) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.RemoveNamespaceDelegate(ctx, tx, namespace, delegate); err != nil {
+			return err
+		}
+		return s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+			Namespace: namespace,
+			Action:    NamespaceRequestDelegation + "-revoked",
+			Actor:     actor,
+			Target:    &delegate,
+		})
+	})
+}
+
+// GetNamespaceAuditLog lists the most recent audit entries of a namespace
+func (s *registryServiceImpl) GetNamespaceAuditLog(ctx context.Context, namespace string, limit int) ([]apiv0.NamespaceAuditEntry, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	return s.db.ListNamespaceAuditEntries(ctx, nil, namespace, limit)
+}
+
+// checkStillOwner checks that a principal owns a namespace, as far as the registry records it:
+// an owner of the organization the namespace belongs to, or else its recorded owner, if any
+func (s *registryServiceImpl) checkStillOwner(ctx context.Context, tx pgx.Tx, namespace string, principal apiv0.Principal) error {
+	organization, err := s.db.GetNamespaceOrganization(ctx, tx, namespace)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	if organization != "" {
+		org, err := s.db.GetOrganization(ctx, tx, organization)
+		if err != nil {
+			return err
+		}
+		if MemberRole(org, principal) != OrganizationRoleOwner {
+			return ErrNamespaceOwnerChanged
+		}
+		return nil
+	}
+
+	owner, err := s.db.GetNamespaceOwner(ctx, tx, namespace)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	if owner != nil && *owner != principal {
+		return ErrNamespaceOwnerChanged
+	}
+	return nil
+}
+
+// lockPendingNamespaceRequest loads a request, holding the namespace lock for the rest of the
+// transaction so concurrent accepts can't both apply
+func (s *registryServiceImpl) lockPendingNamespaceRequest(ctx context.Context, tx pgx.Tx, id string) (*apiv0.NamespaceRequest, error) {
+	req, err := s.db.GetNamespaceRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.AcquirePublishLock(ctx, tx, "namespace:"+req.Namespace); err != nil {
+		return nil, err
+	}
+
+	// Re-read now that we hold the lock, in case it was resolved while we waited
+	req, err = s.db.GetNamespaceRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != NamespaceRequestPending {
+		return nil, ErrRequestNotPending
+	}
+	return req, nil
+}
+
+func (s *registryServiceImpl) auditNamespaceRequest(ctx context.Context, tx pgx.Tx, req *apiv0.NamespaceRequest, verb string, actor apiv0.Principal) error {
+	return s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+		Namespace: req.Namespace,
+		Action:    req.Kind + "-" + verb,
+		Actor:     actor,
+		Target:    &req.To,
+		RequestID: req.ID,
+	})
+}
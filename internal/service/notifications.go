@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListNotificationSubscriptions lists an owner's notification subscriptions for a namespace
+func (s *registryServiceImpl) ListNotificationSubscriptions(ctx context.Context, namespace string, owner apiv0.Principal) ([]apiv0.NotificationSubscription, error) {
+	all, err := s.db.ListNotificationSubscriptions(ctx, nil, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := []apiv0.NotificationSubscription{}
+	for _, sub := range all {
+		if sub.Owner == owner {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	return subscriptions, nil
+}
+
+// CreateNotificationSubscription subscribes an owner to events in a namespace. The caller is
+// responsible for checking that the owner owns the namespace and that the target is valid.
+func (s *registryServiceImpl) CreateNotificationSubscription(ctx context.Context, sub *apiv0.NotificationSubscription) (*apiv0.NotificationSubscription, error) {
+	return s.db.CreateNotificationSubscription(ctx, nil, sub)
+}
+
+// DeleteNotificationSubscription removes one of an owner's notification subscriptions
+func (s *registryServiceImpl) DeleteNotificationSubscription(ctx context.Context, namespace, id string, owner apiv0.Principal) error {
+	return s.db.DeleteNotificationSubscription(ctx, nil, namespace, id, owner)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Organization member roles, from most to least privileged
+const (
+	// OrganizationRoleOwner manages members and namespaces, and may delete servers
+	OrganizationRoleOwner = "owner"
+	// OrganizationRolePublisher may publish and edit servers in the organization's namespaces
+	OrganizationRolePublisher = "publisher"
+	// OrganizationRoleViewer may see the organization but not change anything
+	OrganizationRoleViewer = "viewer"
+)
+
+var organizationRoleRank = map[string]int{
+	OrganizationRoleViewer:    1,
+	OrganizationRolePublisher: 2,
+	OrganizationRoleOwner:     3,
+}
+
+// ErrLastOrganizationOwner is returned when a change would leave an organization without owners
+var ErrLastOrganizationOwner = errors.New("an organization must keep at least one owner")
+
+// MemberRole returns a principal's role in an organization, or "" if they are not a member
+func MemberRole(org *apiv0.Organization, principal apiv0.Principal) string {
+	for _, member := range org.Members {
+		if member.Principal == principal {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+// RoleAtLeast reports whether a role grants everything the minimum role does
+func RoleAtLeast(role, minimum string) bool {
+	return organizationRoleRank[role] >= organizationRoleRank[minimum]
+}
+
+// CreateOrganization creates an organization, making its creator the first owner
+func (s *registryServiceImpl) CreateOrganization(ctx context.Context, name string, owner apiv0.Principal) (*apiv0.Organization, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.Organization, error) {
+		if err := s.db.CreateOrganization(ctx, tx, name); err != nil {
+			return nil, err
+		}
+		member := apiv0.OrganizationMember{Principal: owner, Role: OrganizationRoleOwner}
+		if err := s.db.SetOrganizationMember(ctx, tx, name, member); err != nil {
+			return nil, err
+		}
+		return s.db.GetOrganization(ctx, tx, name)
+	})
+}
+
+// GetOrganization retrieves an organization with its members and namespaces
+func (s *registryServiceImpl) GetOrganization(ctx context.Context, name string) (*apiv0.Organization, error) {
+	return s.db.GetOrganization(ctx, nil, name)
+}
+
+// SetOrganizationMember adds a member or changes their role. The caller is responsible for
+// checking that the actor is an owner of the organization.
+func (s *registryServiceImpl) SetOrganizationMember(ctx context.Context, organization string, member apiv0.OrganizationMember) (*apiv0.Organization, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.Organization, error) {
+		org, err := s.lockOrganization(ctx, tx, organization)
+		if err != nil {
+			return nil, err
+		}
+		if member.Role != OrganizationRoleOwner && isLastOwner(org, member.Principal) {
+			return nil, ErrLastOrganizationOwner
+		}
+
+		if err := s.db.SetOrganizationMember(ctx, tx, organization, member); err != nil {
+			return nil, err
+		}
+		return s.db.GetOrganization(ctx, tx, organization)
+	})
+}
+
+// RemoveOrganizationMember removes a member. The caller is responsible for checking that the
+// actor is an owner of the organization or the member themselves.
+func (s *registryServiceImpl) RemoveOrganizationMember(ctx context.Context, organization string, member apiv0.Principal) (*apiv0.Organization, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.Organization, error) {
+		org, err := s.lockOrganization(ctx, tx, organization)
+		if err != nil {
+			return nil, err
+		}
+		if isLastOwner(org, member) {
+			return nil, ErrLastOrganizationOwner
+		}
+
+		if err := s.db.RemoveOrganizationMember(ctx, tx, organization, member); err != nil {
+			return nil, err
+		}
+		return s.db.GetOrganization(ctx, tx, organization)
+	})
+}
+
+// AddOrganizationNamespace hands a namespace over to an organization. The caller is responsible
+// for checking that the actor owns both the namespace and the organization.
+func (s *registryServiceImpl) AddOrganizationNamespace(ctx context.Context, organization, namespace string, actor apiv0.Principal) (*apiv0.Organization, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.Organization, error) {
+		if err := s.db.AddOrganizationNamespace(ctx, tx, organization, namespace); err != nil {
+			return nil, err
+		}
+		if err := s.auditOrganizationNamespace(ctx, tx, organization, namespace, "added", actor); err != nil {
+			return nil, err
+		}
+		return s.db.GetOrganization(ctx, tx, organization)
+	})
+}
+
+// RemoveOrganizationNamespace releases a namespace from an organization, after which its
+// recorded owner or authentication method decides who owns it again
+func (s *registryServiceImpl) RemoveOrganizationNamespace(ctx context.Context, organization, namespace string, actor apiv0.Principal) (*apiv0.Organization, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.Organization, error) {
+		if err := s.db.RemoveOrganizationNamespace(ctx, tx, organization, namespace); err != nil {
+			return nil, err
+		}
+		if err := s.auditOrganizationNamespace(ctx, tx, organization, namespace, "removed", actor); err != nil {
+			return nil, err
+		}
+		return s.db.GetOrganization(ctx, tx, organization)
+	})
+}
+
+// lockOrganization loads an organization, holding its lock for the rest of the transaction so
+// concurrent membership changes can't both remove the last owner
+func (s *registryServiceImpl) lockOrganization(ctx context.Context, tx pgx.Tx, name string) (*apiv0.Organization, error) {
+	if err := s.db.AcquirePublishLock(ctx, tx, "organization:"+name); err != nil {
+		return nil, err
+	}
+	return s.db.GetOrganization(ctx, tx, name)
+}
+
+func (s *registryServiceImpl) auditOrganizationNamespace(ctx context.Context, tx pgx.Tx, organization, namespace, verb string, actor apiv0.Principal) error {
+	return s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+		Namespace:    namespace,
+		Action:       "organization-" + verb,
+		Actor:        actor,
+		Organization: organization,
+	})
+}
+
+func isLastOwner(org *apiv0.Organization, principal apiv0.Principal) bool {
+	if MemberRole(org, principal) != OrganizationRoleOwner {
+		return false
+	}
+	owners := 0
+	for _, member := range org.Members {
+		if member.Role == OrganizationRoleOwner {
+			owners++
+		}
+	}
+	return owners == 1
+}
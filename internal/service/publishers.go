@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrNotServerPublisher is returned when a server is published to or edited by someone other than
+// the identity that published it first
+var ErrNotServerPublisher = errors.New("server was published by another identity")
+
+// Publisher is who publishes or edits a server. Only the identity that published a server first
+// may publish new versions of it and edit it, unless Override is set: handlers set it for admins
+// and for the owners of the namespace the registry records.
+type Publisher struct {
+	Principal apiv0.Principal
+	Override  bool
+}
+
+// checkServerPublisher fails with ErrNotServerPublisher unless publisher is the identity recorded as
+// publishing a server or may override it. Servers nobody is recorded for, such as those published
+// before publishers were recorded, are left to the namespace permissions the handlers checked, and
+// publishing one records the publisher. A nil publisher is never checked.
+func (s *registryServiceImpl) checkServerPublisher(ctx context.Context, tx pgx.Tx, serverName string, publisher *Publisher) error {
+	if publisher == nil || publisher.Override {
+		return nil
+	}
+
+	recorded, err := s.db.GetServerPublisher(ctx, tx, serverName)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	case *recorded != publisher.Principal:
+		return fmt.Errorf("%w: %s can only be published to and edited by its original publisher %s:%s, a namespace owner or a registry admin",
+			ErrNotServerPublisher, serverName, recorded.AuthMethod, recorded.Subject)
+	}
+	return nil
+}
+
+// GetServerPublisher returns the identity recorded as first publishing a server, or
+// database.ErrNotFound when none is recorded
+func (s *registryServiceImpl) GetServerPublisher(ctx context.Context, serverName string) (*apiv0.Principal, error) {
+	return s.db.GetServerPublisher(ctx, nil, serverName)
+}
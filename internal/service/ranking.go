@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetSearchRanking retrieves the search ranking in effect: the one an admin set, or else the configured one
+func (s *registryServiceImpl) GetSearchRanking(ctx context.Context) (*apiv0.SearchRanking, error) {
+	ranking, err := s.db.GetSearchRanking(ctx, nil)
+	if errors.Is(err, database.ErrNotFound) {
+		return s.configuredSearchRanking(), nil
+	}
+	return ranking, err
+}
+
+// SetSearchRanking overrides the configured search ranking
+func (s *registryServiceImpl) SetSearchRanking(ctx context.Context, ranking *apiv0.SearchRanking) (*apiv0.SearchRanking, error) {
+	if ranking.NameWeight <= 0 && ranking.TitleWeight <= 0 && ranking.DescriptionWeight <= 0 {
+		return nil, fmt.Errorf("%w: at least one of nameWeight, titleWeight and descriptionWeight must be above 0", database.ErrInvalidInput)
+	}
+	return s.db.SetSearchRanking(ctx, nil, ranking)
+}
+
+// ResetSearchRanking drops an admin's search ranking, returning the configured one that applies again
+func (s *registryServiceImpl) ResetSearchRanking(ctx context.Context) (*apiv0.SearchRanking, error) {
+	if err := s.db.DeleteSearchRanking(ctx, nil); err != nil {
+		return nil, err
+	}
+	return s.configuredSearchRanking(), nil
+}
+
+// applySearchRanking sets the ranking in effect on a filter that searches and doesn't have one
+func (s *registryServiceImpl) applySearchRanking(ctx context.Context, filter *database.ServerFilter) error {
+	if filter == nil || filter.SubstringName == nil || filter.Ranking != nil {
+		return nil
+	}
+	ranking, err := s.GetSearchRanking(ctx)
+	if err != nil {
+		return err
+	}
+	filter.Ranking = ranking
+	return nil
+}
+
+// configuredSearchRanking returns the ranking configured for the deployment. One that searches no
+// fields, as a zero config does, searches names.
+func (s *registryServiceImpl) configuredSearchRanking() *apiv0.SearchRanking {
+	ranking := &apiv0.SearchRanking{
+		NameWeight:            s.cfg.SearchNameWeight,
+		TitleWeight:           s.cfg.SearchTitleWeight,
+		DescriptionWeight:     s.cfg.SearchDescriptionWeight,
+		FreshnessBoost:        s.cfg.SearchFreshnessBoost,
+		FreshnessHalfLifeDays: s.cfg.SearchFreshnessHalfLifeDays,
+		PopularityBoost:       s.cfg.SearchPopularityBoost,
+		VerifiedBoost:         s.cfg.SearchVerifiedBoost,
+	}
+	if ranking.NameWeight <= 0 && ranking.TitleWeight <= 0 && ranking.DescriptionWeight <= 0 {
+		ranking.NameWeight = 1
+	}
+	return ranking
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Reasons recorded for server redirects
+const (
+	ServerRedirectClaim  = "claim"
+	ServerRedirectRename = "rename"
+	ServerRedirectAlias  = "alias"
+)
+
+// ErrServerMoved is returned when publishing to a name that redirects to another server
+var ErrServerMoved = errors.New("server has moved to a new name")
+
+// RenameServer moves every version of a server to a new name, leaving a redirect from the old one
+func (s *registryServiceImpl) RenameServer(ctx context.Context, serverName, newName string) (*apiv0.ServerRedirect, error) {
+	if serverName == newName {
+		return nil, fmt.Errorf("%w: the new name is the current name", database.ErrInvalidInput)
+	}
+	return s.moveServer(ctx, serverName, newName, ServerRedirectRename)
+}
+
+// moveServer renames a server and records a redirect from its old name
+func (s *registryServiceImpl) moveServer(ctx context.Context, oldName, newName, reason string) (*apiv0.ServerRedirect, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerRedirect, error) {
+		// Lock both names in a consistent order so crossing moves can't deadlock
+		first, second := oldName, newName
+		if second < first {
+			first, second = second, first
+		}
+		for _, name := range []string{first, second} {
+			if err := s.db.AcquirePublishLock(ctx, tx, name); err != nil {
+				return nil, err
+			}
+		}
+
+		existing, err := s.db.CountServerVersions(ctx, tx, newName)
+		if err != nil {
+			return nil, err
+		}
+		if existing > 0 {
+			return nil, fmt.Errorf("%w: %s is already published", database.ErrAlreadyExists, newName)
+		}
+
+		if err := s.db.RenameServer(ctx, tx, oldName, newName); err != nil {
+			return nil, err
+		}
+		return s.db.SetServerRedirect(ctx, tx, &apiv0.ServerRedirect{
+			From:   oldName,
+			To:     newName,
+			Reason: reason,
+		})
+	})
+}
+
+// AddServerAlias makes an unused name redirect to a server without moving it
+func (s *registryServiceImpl) AddServerAlias(ctx context.Context, serverName, alias string) (*apiv0.ServerRedirect, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerRedirect, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, alias); err != nil {
+			return nil, err
+		}
+
+		if _, err := s.db.GetServerByName(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		existing, err := s.db.CountServerVersions(ctx, tx, alias)
+		if err != nil {
+			return nil, err
+		}
+		if existing > 0 {
+			return nil, fmt.Errorf("%w: %s is already published", database.ErrAlreadyExists, alias)
+		}
+		redirect, err := s.db.GetServerRedirect(ctx, tx, alias)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		if redirect != nil && redirect.To != serverName {
+			return nil, fmt.Errorf("%w: %s already redirects to %s", database.ErrAlreadyExists, alias, redirect.To)
+		}
+
+		return s.db.SetServerRedirect(ctx, tx, &apiv0.ServerRedirect{
+			From:   alias,
+			To:     serverName,
+			Reason: ServerRedirectAlias,
+		})
+	})
+}
+
+// ListServerRedirects lists the names that redirect to a server: its aliases and former names
+func (s *registryServiceImpl) ListServerRedirects(ctx context.Context, serverName string) ([]apiv0.ServerRedirect, error) {
+	ctx = database.ReadFromReplica(ctx)
+	return s.db.ListServerRedirects(ctx, nil, serverName)
+}
+
+// DeleteServerRedirect stops a name redirecting to a server, freeing it to be published again
+func (s *registryServiceImpl) DeleteServerRedirect(ctx context.Context, serverName, from string) error {
+	return s.db.DeleteServerRedirect(ctx, nil, from, serverName)
+}
+
+// GetServerRedirect retrieves where a server that moved can now be found
+func (s *registryServiceImpl) GetServerRedirect(ctx context.Context, serverName string) (*apiv0.ServerRedirect, error) {
+	ctx = database.ReadFromReplica(ctx)
+	return s.db.GetServerRedirect(ctx, nil, serverName)
+}
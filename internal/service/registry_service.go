@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -16,27 +18,46 @@ import (
 
 const maxServerVersionsPerServer = 10000
 
-// registryServiceImpl implements the RegistryService interface using our Database
+// ErrRevisionMismatch is returned when editing a server version that was changed since the editor
+// read the revision they sent, so that the edit doesn't silently overwrite the other change
+var ErrRevisionMismatch = errors.New("server version was changed since it was read")
+
+// registryServiceImpl implements the RegistryService interface using our Database. Public reads of
+// servers mark their context with database.ReadFromReplica, as a listing lagging a publish by a
+// moment is fine; reads that authorize requests stay on the primary so that revocations and
+// transfers apply at once.
 type registryServiceImpl struct {
-	db  database.Database
-	cfg *config.Config
+	db       database.Database
+	cfg      *config.Config
+	notifier *notifications.Notifier
 }
 
 // NewRegistryService creates a new registry service with the provided database
 func NewRegistryService(db database.Database, cfg *config.Config) RegistryService {
 	return &registryServiceImpl{
-		db:  db,
-		cfg: cfg,
+		db:       db,
+		cfg:      cfg,
+		notifier: notifications.NewNotifier(db, cfg),
 	}
 }
 
 // ListServers returns registry entries with cursor-based pagination and optional filtering
 func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	ctx = database.ReadFromReplica(ctx)
 	// If limit is not set or negative, use a default limit
 	if limit <= 0 {
 		limit = 30
 	}
 
+	if err := s.checkSnapshot(ctx, filter); err != nil {
+		return nil, "", err
+	}
+
+	// Rank searches by relevance
+	if err := s.applySearchRanking(ctx, filter); err != nil {
+		return nil, "", err
+	}
+
 	// Use the database's ListServers method with pagination and filtering
 	serverRecords, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, limit)
 	if err != nil {
@@ -46,8 +67,33 @@ func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.
 	return serverRecords, nextCursor, nil
 }
 
+// SearchServers matches a full-text query against the latest version of every server, most
+// relevant first
+func (s *registryServiceImpl) SearchServers(ctx context.Context, query, cursor string, limit int) ([]apiv0.ServerSearchResult, string, error) {
+	ctx = database.ReadFromReplica(ctx)
+	if limit <= 0 {
+		limit = 30
+	}
+	return s.db.SearchServers(ctx, nil, query, cursor, limit)
+}
+
+// CountServers counts the server entries matching a filter, reporting whether the count is an estimate
+func (s *registryServiceImpl) CountServers(ctx context.Context, filter *database.ServerFilter) (int, bool, error) {
+	ctx = database.ReadFromReplica(ctx)
+	if err := s.checkSnapshot(ctx, filter); err != nil {
+		return 0, false, err
+	}
+
+	// Searches match the fields the ranking weights
+	if err := s.applySearchRanking(ctx, filter); err != nil {
+		return 0, false, err
+	}
+	return s.db.CountServers(ctx, nil, filter)
+}
+
 // GetServerByName retrieves the latest version of a server by its server name
 func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
+	ctx = database.ReadFromReplica(ctx)
 	serverRecord, err := s.db.GetServerByName(ctx, nil, serverName)
 	if err != nil {
 		return nil, err
@@ -58,6 +104,7 @@ func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName st
 
 // GetServerByNameAndVersion retrieves a specific version of a server by server name and version
 func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error) {
+	ctx = database.ReadFromReplica(ctx)
 	serverRecord, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
 	if err != nil {
 		return nil, err
@@ -66,8 +113,35 @@ func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, ser
 	return serverRecord, nil
 }
 
+// resolveVersion returns the latest version of a server for an empty version or 'latest', and
+// the version otherwise
+func (s *registryServiceImpl) resolveVersion(ctx context.Context, serverName, version string) (string, error) {
+	if version != "" && version != "latest" {
+		return version, nil
+	}
+	latest, err := s.db.GetServerByName(ctx, nil, serverName)
+	if err != nil {
+		return "", err
+	}
+	return latest.Server.Version, nil
+}
+
+// GetServerBatch retrieves many server versions in one query. An empty version or 'latest' gets
+// the latest version of the server at the same index.
+func (s *registryServiceImpl) GetServerBatch(ctx context.Context, names, versions []string) ([]*apiv0.ServerResponse, error) {
+	ctx = database.ReadFromReplica(ctx)
+	exactVersions := make([]string, len(versions))
+	for i, version := range versions {
+		if version != "latest" {
+			exactVersions[i] = version
+		}
+	}
+	return s.db.GetServerBatch(ctx, nil, names, exactVersions)
+}
+
 // GetAllVersionsByServerName retrieves all versions of a server by server name
 func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error) {
+	ctx = database.ReadFromReplica(ctx)
 	serverRecords, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName)
 	if err != nil {
 		return nil, err
@@ -78,14 +152,40 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	return s.createServer(ctx, req, nil, nil)
+}
+
+// createServer creates a new server version, as its original publisher when publisher isn't nil
+func (s *registryServiceImpl) createServer(ctx context.Context, req *apiv0.ServerJSON, signature *Signature, publisher *Publisher) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+	published, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		return s.createServerInTransaction(ctx, tx, req, signature, publisher)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyPublished(published)
+	return published, nil
+}
+
+// notifyPublished notifies the subscribers of a namespace that a version was published in it
+func (s *registryServiceImpl) notifyPublished(published *apiv0.ServerResponse) {
+	namespace, _, _ := strings.Cut(published.Server.Name, "/")
+	s.notifier.Notify(notifications.Event{
+		Type:       notifications.EventVersionPublished,
+		Namespace:  namespace,
+		ServerName: published.Server.Name,
+		Version:    published.Server.Version,
+		Message:    fmt.Sprintf("%s %s was published", published.Server.Name, published.Server.Version),
 	})
 }
 
-// createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+// createServerInTransaction contains the actual CreateServer logic within a transaction. With a
+// publisher, only the server's original publisher may publish it, and is recorded as such. The
+// signature is checked against the server's signing key, which every version must be signed with
+// once one is registered.
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON, signature *Signature, publisher *Publisher) (*apiv0.ServerResponse, error) {
 	// Validate the request
 	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
 		return nil, err
@@ -99,11 +199,43 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	if err := s.checkServerPublisher(ctx, tx, serverJSON.Name, publisher); err != nil {
+		return nil, err
+	}
+
+	// Versions the registry creates itself, from update proposals and imports, have no signature,
+	// so they can't be published to servers with a signing key
+	verifiedSignature, err := s.verifyServerSignature(ctx, tx, &serverJSON, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Names that moved stay reserved for their redirect, so seeding can't bring claimed servers back
+	redirect, err := s.db.GetServerRedirect(ctx, tx, serverJSON.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	if redirect != nil {
+		return nil, fmt.Errorf("%w: %s is now published as %s", ErrServerMoved, serverJSON.Name, redirect.To)
+	}
+
+	// Deleted names stay reserved until they're purged, so that a restore can't be beaten to them
+	if _, err := s.db.GetServerDeletedAt(ctx, tx, serverJSON.Name); err == nil {
+		return nil, ErrServerAwaitingPurge
+	} else if !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
 	// Check for duplicate remote URLs
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, serverJSON); err != nil {
 		return nil, err
 	}
 
+	// Check the servers it declares relationships to are published
+	if err := s.validateRelationshipTargets(ctx, tx, serverJSON); err != nil {
+		return nil, err
+	}
+
 	// Check we haven't exceeded the maximum versions allowed for a server
 	versionCount, err := s.db.CountServerVersions(ctx, tx, serverJSON.Name)
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
@@ -159,7 +291,34 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	published, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordPackageImages(ctx, tx, published); err != nil {
+		return nil, err
+	}
+	if publisher != nil {
+		if err := s.db.SetServerPublisher(ctx, tx, serverJSON.Name, publisher.Principal); err != nil {
+			return nil, err
+		}
+	}
+	if verifiedSignature != nil {
+		if _, err := s.db.SetServerSignature(ctx, tx, verifiedSignature); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.db.SetServerTaxonomy(ctx, tx, serverJSON.Name, serverJSON.Version); err != nil {
+		return nil, err
+	}
+	if err := s.db.RecordServerPulls(ctx, tx, serverJSON.Name); err != nil {
+		return nil, err
+	}
+	if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventServerPublished, published); err != nil {
+		return nil, err
+	}
+	return published, nil
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
@@ -185,16 +344,18 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 	return nil
 }
 
-// UpdateServer updates an existing server with new details
-func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+// UpdateServer updates an existing server with new details. With an editor, only the server's
+// original publisher may edit it. Unless revision is 0, the edit fails with ErrRevisionMismatch
+// when the version's revision has moved on from it.
+func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editor *Publisher, revision int) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus, editor, revision)
 	})
 }
 
 // updateServerInTransaction contains the actual UpdateServer logic within a transaction
-func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editor *Publisher, revision int) (*apiv0.ServerResponse, error) {
 	// Get current server to check if it's deleted or being deleted
 	currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
 	if err != nil {
@@ -218,6 +379,23 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	if err := s.checkServerPublisher(ctx, tx, serverName, editor); err != nil {
+		return nil, err
+	}
+
+	// Compare the revision once the lock is held, as the version may have changed while the
+	// request was validated
+	if revision != 0 {
+		lockedServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if lockedServer.Meta.Official == nil || lockedServer.Meta.Official.Revision != revision {
+			return nil, ErrRevisionMismatch
+		}
+		currentServer = lockedServer
+	}
+
 	// Merge the request with the current server, preserving metadata
 	updatedServer := *req
 
@@ -226,21 +404,47 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Check the servers it declares relationships to are published, unless it is or is being deleted
+	if !skipRegistryValidation {
+		if err := s.validateRelationshipTargets(ctx, tx, updatedServer); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update server in database
 	updatedServerResponse, err := s.db.UpdateServer(ctx, tx, serverName, version, &updatedServer)
 	if err != nil {
 		return nil, err
 	}
 
-	// Handle status change if provided
+	// Handle status change if provided, dropping the details of the previous change
 	if newStatus != nil {
-		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		if currentServer.Meta.Official == nil || string(currentServer.Meta.Official.Status) != *newStatus {
+			if err := s.db.SetServerStatusDetails(ctx, tx, serverName, version, nil); err != nil {
+				return nil, err
+			}
+		}
+		updatedServerResponse, err = s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
 		if err != nil {
 			return nil, err
 		}
-		return updatedWithStatus, nil
 	}
 
+	if err := s.dropStaleSignature(ctx, tx, &updatedServerResponse.Server); err != nil {
+		return nil, err
+	}
+	if err := s.dropStaleAttachments(ctx, tx, &updatedServerResponse.Server); err != nil {
+		return nil, err
+	}
+	if err := s.db.SetServerTaxonomy(ctx, tx, serverName, version); err != nil {
+		return nil, err
+	}
+	if err := s.db.RecordServerPulls(ctx, tx, serverName); err != nil {
+		return nil, err
+	}
+	if err := s.enqueueWebhookEvent(ctx, tx, serverUpdateEvent(currentServer, updatedServerResponse), updatedServerResponse); err != nil {
+		return nil, err
+	}
 	return updatedServerResponse, nil
 }
 
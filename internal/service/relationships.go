@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// GetServerRelationships builds the relationship graph around the latest version of a server:
+// what it declares, and what other servers declare to it
+func (s *registryServiceImpl) GetServerRelationships(ctx context.Context, serverName string) (*apiv0.ServerRelationshipGraph, error) {
+	ctx = database.ReadFromReplica(ctx)
+	latest, err := s.db.GetServerByName(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+	incoming, err := s.db.ListRelationshipsTo(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &apiv0.ServerRelationshipGraph{Name: serverName, Edges: []apiv0.ServerRelationshipEdge{}}
+	for _, rel := range latest.Server.Relationships {
+		graph.Edges = append(graph.Edges, apiv0.ServerRelationshipEdge{From: serverName, Type: rel.Type, To: rel.Name})
+	}
+	graph.Edges = append(graph.Edges, incoming...)
+	return graph, nil
+}
+
+// validateRelationshipTargets checks that every server a server declares a relationship to is published
+func (s *registryServiceImpl) validateRelationshipTargets(ctx context.Context, tx pgx.Tx, serverJSON apiv0.ServerJSON) error {
+	for _, rel := range serverJSON.Relationships {
+		_, err := s.db.GetServerByName(ctx, tx, rel.Name)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+
+		redirect, err := s.db.GetServerRedirect(ctx, tx, rel.Name)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if redirect != nil {
+			return fmt.Errorf("%w: related server %s is now published as %s", database.ErrInvalidInput, rel.Name, redirect.To)
+		}
+		return fmt.Errorf("%w: related server %s is not published in the registry", database.ErrInvalidInput, rel.Name)
+	}
+	return nil
+}
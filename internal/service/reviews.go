@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// MaxReviewsPerDay is how many servers a principal can review in a day, to slow down review
+// bombing. Updating an earlier review doesn't count.
+const MaxReviewsPerDay = 20
+
+// ErrReviewLimitReached is returned when a principal reviewed too many servers in the last day
+var ErrReviewLimitReached = fmt.Errorf("review limit reached: at most %d new reviews a day", MaxReviewsPerDay)
+
+// ListServerReviews returns the rating of a server with a page of its reviews, most recently
+// updated first. The cursor is the offset of the next page.
+func (s *registryServiceImpl) ListServerReviews(ctx context.Context, serverName string, offset, limit int) (*apiv0.ServerReviewListResponse, error) {
+	ctx = database.ReadFromReplica(ctx)
+	if _, err := s.db.GetServerByName(ctx, nil, serverName); err != nil {
+		return nil, err
+	}
+
+	rating, err := s.db.GetServerRating(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.db.ListServerReviews(ctx, nil, serverName, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	nextCursor := ""
+	if len(reviews) == limit && offset+limit < rating.Count {
+		nextCursor = fmt.Sprint(offset + limit)
+	}
+	return &apiv0.ServerReviewListResponse{
+		Rating:   *rating,
+		Reviews:  reviews,
+		Metadata: apiv0.Metadata{NextCursor: nextCursor, Count: len(reviews)},
+	}, nil
+}
+
+// ReviewServer stores a principal's review of a server, replacing their earlier one. The caller
+// is responsible for checking that the reviewer doesn't publish the server.
+func (s *registryServiceImpl) ReviewServer(ctx context.Context, review *apiv0.ServerReview) (*apiv0.ServerReview, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerReview, error) {
+		if _, err := s.db.GetServerByName(ctx, tx, review.ServerName); err != nil {
+			return nil, err
+		}
+
+		// Only new reviews count towards the daily limit
+		count, err := s.db.CountServerReviewsSince(ctx, tx, review.Reviewer, time.Now().Add(-24*time.Hour))
+		if err != nil {
+			return nil, err
+		}
+		if count >= MaxReviewsPerDay {
+			if _, err := s.db.GetServerReview(ctx, tx, review.ServerName, review.Reviewer); errors.Is(err, database.ErrNotFound) {
+				return nil, ErrReviewLimitReached
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		return s.db.UpsertServerReview(ctx, tx, review)
+	})
+}
+
+// DeleteServerReview deletes a principal's review of a server
+func (s *registryServiceImpl) DeleteServerReview(ctx context.Context, serverName string, reviewer apiv0.Principal) error {
+	return s.db.DeleteServerReview(ctx, nil, serverName, reviewer)
+}
+
+// SetServerReviewHidden hides a review, which then no longer shows or counts towards the rating,
+// or shows a hidden one again
+func (s *registryServiceImpl) SetServerReviewHidden(ctx context.Context, id string, hidden bool) (*apiv0.ServerReview, error) {
+	return s.db.SetServerReviewHidden(ctx, nil, id, hidden)
+}
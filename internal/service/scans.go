@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// RecordPackageScan records the vulnerabilities an image scanner found in one of the OCI images
+// of a server version, replacing the image's earlier scan, and returns the version with its
+// updated summary
+func (s *registryServiceImpl) RecordPackageScan(
+	ctx context.Context, serverName, version, identifier string, scan *apiv0.VulnerabilityScan,
+) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		server, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(server.Server.Packages, func(pkg model.Package) bool {
+			return pkg.RegistryType == model.RegistryTypeOCI && pkg.Identifier == identifier
+		}) {
+			return nil, fmt.Errorf("%w: version %s of %s has no OCI package %s", database.ErrInvalidInput, version, serverName, identifier)
+		}
+
+		if scan.ScannedAt.IsZero() {
+			scan.ScannedAt = time.Now()
+		}
+		if err := s.db.SetPackageScan(ctx, tx, serverName, version, identifier, scan); err != nil {
+			return nil, err
+		}
+
+		return s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -9,16 +10,240 @@ import (
 
 // RegistryService defines the interface for registry operations
 type RegistryService interface {
+	// PingDatabase check the database is reachable
+	PingDatabase(ctx context.Context) error
+	// PendingMigrations list the migrations this build has that the database hasn't applied
+	PendingMigrations(ctx context.Context) ([]string, error)
 	// ListServers retrieve all servers with optional filtering
 	ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	// CountServers count the server entries matching a filter, reporting whether the count is an estimate
+	CountServers(ctx context.Context, filter *database.ServerFilter) (int, bool, error)
+	// SearchServers match a full-text query against the latest version of every server, most relevant first
+	SearchServers(ctx context.Context, query, cursor string, limit int) ([]apiv0.ServerSearchResult, string, error)
+	// CreateSnapshot return a recent snapshot of the registry for paging through a consistent state
+	CreateSnapshot(ctx context.Context) (*database.Snapshot, error)
+	// PutExport store a document rendered from the whole registry, replacing the previous rendering
+	PutExport(ctx context.Context, export *database.Export) error
+	// GetExport retrieve the latest rendering of an export, with its body gzip-encoded or not
+	GetExport(ctx context.Context, name string, gzipped bool) (*database.Export, error)
 	// GetServerByName retrieve latest version of a server by server name
 	GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetServerBatch retrieve many server versions at once, in order, with nil where none matches
+	GetServerBatch(ctx context.Context, names, versions []string) ([]*apiv0.ServerResponse, error)
+	// GetRegistryStats count the listed servers by registry type and transport, and the publishes of each recent day
+	GetRegistryStats(ctx context.Context) (*apiv0.RegistryStats, error)
+	// ListCategories count the listed servers in each publisher-provided category, most common first
+	ListCategories(ctx context.Context) ([]apiv0.TaxonomyTerm, error)
+	// ListTags count the listed servers with each publisher-provided tag, most common first
+	ListTags(ctx context.Context) ([]apiv0.TaxonomyTerm, error)
+	// ListTrendingServers rank the latest versions of servers by their requests and pull growth over the last days
+	ListTrendingServers(ctx context.Context, days, limit int) ([]apiv0.TrendingServer, error)
+	// ListServerActivity retrieve the server versions published or updated most recently, with who published each server
+	ListServerActivity(ctx context.Context, limit int) ([]ServerActivity, error)
+	// GetServerPublisher retrieve the identity recorded as first publishing a server
+	GetServerPublisher(ctx context.Context, serverName string) (*apiv0.Principal, error)
+	// RecordServerUsage count a request for a server's details or an install of it towards its usage, skipping bots
+	RecordServerUsage(ctx context.Context, serverName, kind, userAgent string) error
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// UpdateServer updates an existing server and optionally its status
-	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
+	// PublishServer create a new server version as its original publisher with an optional signature, or return the one an earlier publish with the same idempotency key created
+	PublishServer(ctx context.Context, req *apiv0.ServerJSON, idempotencyKey string, signature *Signature, publisher *Publisher) (*apiv0.ServerResponse, bool, error)
+	// UpdateServer updates an existing server and optionally its status, as its original publisher unless editor is nil, failing when its revision isn't the given one unless that is 0
+	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, editor *Publisher, revision int) (*apiv0.ServerResponse, error)
+	// SetServerStatus change the status of a server version, or of every version when version is empty
+	SetServerStatus(ctx context.Context, serverName, version string, update *apiv0.ServerStatusUpdate) ([]*apiv0.ServerResponse, error)
+	// ImportServers publish a batch of seed entries in a single transaction, reporting how each fared
+	ImportServers(ctx context.Context, entries []*apiv0.ServerResponse, dryRun bool) (*apiv0.ImportReport, error)
+	// ClaimServer move a seeded server to its maintainer's namespace, leaving a redirect behind
+	ClaimServer(ctx context.Context, serverName string, claimant apiv0.Principal, verify ClaimVerifier) (*apiv0.ServerRedirect, error)
+	// GetServerRedirect retrieve where a server that moved can now be found
+	GetServerRedirect(ctx context.Context, serverName string) (*apiv0.ServerRedirect, error)
+	// RenameServer move every version of a server to a new name, leaving a redirect behind
+	RenameServer(ctx context.Context, serverName, newName string) (*apiv0.ServerRedirect, error)
+	// AddServerAlias make an unused name redirect to a server
+	AddServerAlias(ctx context.Context, serverName, alias string) (*apiv0.ServerRedirect, error)
+	// ListServerRedirects list the aliases and former names of a server
+	ListServerRedirects(ctx context.Context, serverName string) ([]apiv0.ServerRedirect, error)
+	// DeleteServerRedirect stop a name redirecting to a server
+	DeleteServerRedirect(ctx context.Context, serverName, from string) error
+	// YankServerVersion yank a version of a server, or restore a yanked one
+	YankServerVersion(ctx context.Context, serverName, version string, yanked bool) (*apiv0.ServerResponse, error)
+	// SetServerChannel tag a version of a server into a release channel
+	SetServerChannel(ctx context.Context, serverName, channel, version string) (*apiv0.ServerChannel, error)
+	// ListServerChannels list the release channels of a server, starting with latest
+	ListServerChannels(ctx context.Context, serverName string) ([]apiv0.ServerChannel, error)
+	// DeleteServerChannel remove a release channel from a server
+	DeleteServerChannel(ctx context.Context, serverName, channel string) error
+	// GetServerByChannel retrieve the version of a server a release channel points at
+	GetServerByChannel(ctx context.Context, serverName, channel string) (*apiv0.ServerResponse, error)
+	// GetServerRelationships build the relationship graph around the latest version of a server
+	GetServerRelationships(ctx context.Context, serverName string) (*apiv0.ServerRelationshipGraph, error)
+	// GetSearchRanking retrieve the search ranking in effect
+	GetSearchRanking(ctx context.Context) (*apiv0.SearchRanking, error)
+	// SetSearchRanking override the configured search ranking
+	SetSearchRanking(ctx context.Context, ranking *apiv0.SearchRanking) (*apiv0.SearchRanking, error)
+	// ResetSearchRanking drop an admin's search ranking, returning the configured one
+	ResetSearchRanking(ctx context.Context) (*apiv0.SearchRanking, error)
+	// RecordPackageScan record the vulnerabilities found in an OCI image of a server version
+	RecordPackageScan(ctx context.Context, serverName, version, identifier string, scan *apiv0.VulnerabilityScan) (*apiv0.ServerResponse, error)
+	// RequestCapabilityExtraction queue the extraction of the capabilities of a server version
+	RequestCapabilityExtraction(ctx context.Context, serverName, version string) (*apiv0.ServerResponse, error)
+	// ClaimCapabilityExtraction hand the server version waiting longest for extraction to a sandbox worker
+	ClaimCapabilityExtraction(ctx context.Context) (*apiv0.ServerResponse, error)
+	// RecordCapabilities record what a sandbox worker extracted from a server version
+	RecordCapabilities(ctx context.Context, serverName, version string, capabilities *apiv0.Capabilities) error
+	// ProposeServerUpdate propose publishing a new version of a server for a newer upstream release
+	ProposeServerUpdate(ctx context.Context, serverName string, release UpstreamRelease) (*apiv0.UpdateProposal, error)
+	// ListUpdateProposals list the update proposals of a server, or of every server, most recent first
+	ListUpdateProposals(ctx context.Context, serverName, status string, limit int) ([]apiv0.UpdateProposal, error)
+	// PublishUpdateProposal publish the version an update proposal proposes
+	PublishUpdateProposal(ctx context.Context, serverName, id string) (*apiv0.ServerResponse, error)
+	// DismissUpdateProposal dismiss an update proposal
+	DismissUpdateProposal(ctx context.Context, serverName, id string) error
+	// GetUpdatePolicy retrieve how new upstream releases of a server are handled
+	GetUpdatePolicy(ctx context.Context, serverName string) (*apiv0.UpdatePolicy, error)
+	// SetUpdatePolicy set how new upstream releases of a server are handled
+	SetUpdatePolicy(ctx context.Context, serverName string, policy *apiv0.UpdatePolicy) (*apiv0.UpdatePolicy, error)
+	// GetSigningKey retrieve the public key a server's versions must be signed with
+	GetSigningKey(ctx context.Context, serverName string) (*apiv0.SigningKey, error)
+	// SetSigningKey register the public key a server's versions must be signed with, as its original publisher unless editor is nil
+	SetSigningKey(ctx context.Context, serverName string, key *apiv0.SigningKey, editor *Publisher) (*apiv0.SigningKey, error)
+	// DeleteSigningKey delete a server's signing key, as its original publisher unless editor is nil
+	DeleteSigningKey(ctx context.Context, serverName string, editor *Publisher) error
+	// GetServerSignature retrieve the signature a server version was published with
+	GetServerSignature(ctx context.Context, serverName, version string) (*apiv0.ServerSignature, error)
+	// AttachPackageDocument attach an SBOM or provenance document to an OCI package of a server version, as its original publisher unless editor is nil
+	AttachPackageDocument(ctx context.Context, serverName, version string, packageIndex int, kind string, content []byte, editor *Publisher) (*apiv0.PackageAttachment, error)
+	// GetPackageAttachment retrieve a document attached to a package of a server version, with its contents
+	GetPackageAttachment(ctx context.Context, serverName, version string, packageIndex int, kind string) (*database.PackageDocument, error)
+	// ListPackageAttachments list the documents attached to the packages of a server version
+	ListPackageAttachments(ctx context.Context, serverName, version string) ([]apiv0.PackageAttachment, error)
+	// DeletePackageAttachment delete a document attached to a package of a server version, as its original publisher unless editor is nil
+	DeletePackageAttachment(ctx context.Context, serverName, version string, packageIndex int, kind string, editor *Publisher) error
+	// ListServerAdvisories list the security advisories of a server
+	ListServerAdvisories(ctx context.Context, serverName string) ([]apiv0.SecurityAdvisory, error)
+	// PublishServerAdvisory attach a security advisory to the versions of a server it affects
+	PublishServerAdvisory(ctx context.Context, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error)
+	// UpdateServerAdvisory replace the contents of a security advisory
+	UpdateServerAdvisory(ctx context.Context, advisory *apiv0.SecurityAdvisory) (*apiv0.SecurityAdvisory, error)
+	// WithdrawServerAdvisory delete a security advisory published in error
+	WithdrawServerAdvisory(ctx context.Context, serverName, id string) error
+	// ListAdvisoryFeed list the security advisories of every server published or updated after a time
+	ListAdvisoryFeed(ctx context.Context, since time.Time, cursor string, limit int) ([]apiv0.SecurityAdvisory, string, error)
+	// ListServerReviews return the rating of a server with a page of its reviews
+	ListServerReviews(ctx context.Context, serverName string, offset, limit int) (*apiv0.ServerReviewListResponse, error)
+	// ReviewServer store a principal's review of a server, replacing their earlier one
+	ReviewServer(ctx context.Context, review *apiv0.ServerReview) (*apiv0.ServerReview, error)
+	// DeleteServerReview delete a principal's review of a server
+	DeleteServerReview(ctx context.Context, serverName string, reviewer apiv0.Principal) error
+	// SetServerReviewHidden hide a review, or show a hidden one again
+	SetServerReviewHidden(ctx context.Context, id string, hidden bool) (*apiv0.ServerReview, error)
+	// GetNamespace retrieve the recorded owner, organization and delegates of a namespace
+	GetNamespace(ctx context.Context, namespace string) (*apiv0.NamespaceResponse, error)
+	// RequestNamespaceChange propose a namespace transfer or publish delegation to another principal
+	RequestNamespaceChange(ctx context.Context, kind, namespace string, from, to apiv0.Principal) (*apiv0.NamespaceRequest, error)
+	// ListNamespaceRequests list the pending requests made by or addressed to a principal
+	ListNamespaceRequests(ctx context.Context, principal apiv0.Principal) ([]apiv0.NamespaceRequest, error)
+	// AcceptNamespaceRequest accept a request on behalf of its recipient
+	AcceptNamespaceRequest(ctx context.Context, id string, caller apiv0.Principal) (*apiv0.NamespaceRequest, error)
+	// DeclineNamespaceRequest decline (recipient) or cancel (requester) a pending request
+	DeclineNamespaceRequest(ctx context.Context, id string, caller apiv0.Principal) (*apiv0.NamespaceRequest, error)
+	// RevokeNamespaceDelegate remove a delegate's publish rights to a namespace
+	RevokeNamespaceDelegate(ctx context.Context, namespace string, delegate, actor apiv0.Principal) error
+	// GetNamespaceAuditLog list the most recent audit entries of a namespace
+	GetNamespaceAuditLog(ctx context.Context, namespace string, limit int) ([]apiv0.NamespaceAuditEntry, error)
+	// VerifyNamespace record that a principal proved control of the domain or GitHub organization a namespace is named after
+	VerifyNamespace(ctx context.Context, namespace, method string, by apiv0.Principal) (*apiv0.NamespaceVerification, error)
+	// RevokeNamespaceVerification drop the verification of a namespace
+	RevokeNamespaceVerification(ctx context.Context, namespace string, actor apiv0.Principal) error
+	// ClaimNamespace create a principal's claim on a namespace, or start their previous claim over
+	ClaimNamespace(ctx context.Context, namespace, method string, claimant apiv0.Principal) (*apiv0.NamespaceClaim, error)
+	// ListNamespaceClaims list the claims on a namespace, or only a claimant's when claimant isn't nil
+	ListNamespaceClaims(ctx context.Context, namespace string, claimant *apiv0.Principal) ([]apiv0.NamespaceClaim, error)
+	// GetNamespaceClaim retrieve a claim on a namespace by ID
+	GetNamespaceClaim(ctx context.Context, namespace, id string) (*apiv0.NamespaceClaim, error)
+	// WithdrawNamespaceClaim delete a claim on a namespace
+	WithdrawNamespaceClaim(ctx context.Context, namespace, id string, actor apiv0.Principal) error
+	// LeaseNamespaceClaimCheck hand the claim that has been due for a check longest to a verifier
+	LeaseNamespaceClaimCheck(ctx context.Context) (*database.NamespaceClaimCheck, error)
+	// RecordNamespaceClaimCheck record whether a verifier found a claim's proof, scheduling its next check
+	RecordNamespaceClaimCheck(ctx context.Context, claim *database.NamespaceClaimCheck, checkErr error) error
+	// CreateOrganization create an organization with its creator as the first owner
+	CreateOrganization(ctx context.Context, name string, owner apiv0.Principal) (*apiv0.Organization, error)
+	// GetOrganization retrieve an organization with its members and namespaces
+	GetOrganization(ctx context.Context, name string) (*apiv0.Organization, error)
+	// SetOrganizationMember add a member to an organization or change their role
+	SetOrganizationMember(ctx context.Context, organization string, member apiv0.OrganizationMember) (*apiv0.Organization, error)
+	// RemoveOrganizationMember remove a member from an organization
+	RemoveOrganizationMember(ctx context.Context, organization string, member apiv0.Principal) (*apiv0.Organization, error)
+	// AddOrganizationNamespace hand a namespace over to an organization
+	AddOrganizationNamespace(ctx context.Context, organization, namespace string, actor apiv0.Principal) (*apiv0.Organization, error)
+	// RemoveOrganizationNamespace release a namespace from an organization
+	RemoveOrganizationNamespace(ctx context.Context, organization, namespace string, actor apiv0.Principal) (*apiv0.Organization, error)
+	// CreatePersonalAccessToken create a personal access token, returning its secret once
+	CreatePersonalAccessToken(ctx context.Context, owner apiv0.Principal, name string, permissions []apiv0.TokenPermission, expiresAt time.Time) (*apiv0.CreatedPersonalAccessToken, error)
+	// ListPersonalAccessTokens list a principal's personal access tokens
+	ListPersonalAccessTokens(ctx context.Context, owner apiv0.Principal) ([]apiv0.PersonalAccessToken, error)
+	// GetPersonalAccessToken retrieve one of a principal's personal access tokens
+	GetPersonalAccessToken(ctx context.Context, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error)
+	// RotatePersonalAccessToken replace the secret of one of a principal's tokens, returning the new secret once
+	RotatePersonalAccessToken(ctx context.Context, id string, owner apiv0.Principal, expiresAt *time.Time) (*apiv0.CreatedPersonalAccessToken, error)
+	// RevokePersonalAccessToken revoke one of a principal's personal access tokens
+	RevokePersonalAccessToken(ctx context.Context, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error)
+	// ListNotificationSubscriptions list an owner's notification subscriptions for a namespace
+	ListNotificationSubscriptions(ctx context.Context, namespace string, owner apiv0.Principal) ([]apiv0.NotificationSubscription, error)
+	// CreateNotificationSubscription subscribe an owner to events in a namespace
+	CreateNotificationSubscription(ctx context.Context, sub *apiv0.NotificationSubscription) (*apiv0.NotificationSubscription, error)
+	// DeleteNotificationSubscription remove one of an owner's notification subscriptions
+	DeleteNotificationSubscription(ctx context.Context, namespace, id string, owner apiv0.Principal) error
+	// CreateWebhook register a webhook for a principal, returning its signing secret
+	CreateWebhook(ctx context.Context, owner apiv0.Principal, url string, events []string) (*apiv0.CreatedWebhook, error)
+	// GetWebhook retrieve a webhook by ID
+	GetWebhook(ctx context.Context, id string) (*apiv0.Webhook, error)
+	// ListWebhooks list a principal's webhooks
+	ListWebhooks(ctx context.Context, owner apiv0.Principal) ([]apiv0.Webhook, error)
+	// ListFailingWebhooks list the webhooks whose last delivery attempt failed, those failing the longest first
+	ListFailingWebhooks(ctx context.Context, limit int) ([]apiv0.Webhook, error)
+	// DeleteWebhook remove one of a principal's webhooks
+	DeleteWebhook(ctx context.Context, id string, owner apiv0.Principal) error
+	// ListWebhookDeliveries list the most recent deliveries to a webhook
+	ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]apiv0.WebhookDelivery, error)
+	// ClaimWebhookDelivery hand the pending delivery that has been due longest to a dispatcher
+	ClaimWebhookDelivery(ctx context.Context) (*database.WebhookDeliveryAttempt, error)
+	// RecordWebhookDeliveryAttempt record how a delivery attempt went, scheduling a retry if it failed
+	RecordWebhookDeliveryAttempt(ctx context.Context, attempt *database.WebhookDeliveryAttempt, responseStatus int, deliveryErr error) error
+	// EnqueueJob queue a job, reporting false when a job with the same dedupe key was already queued
+	EnqueueJob(ctx context.Context, job *apiv0.Job) (bool, error)
+	// ClaimJob hand the due job of one of the given kinds that has been due longest to a worker, leasing it for a while
+	ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*apiv0.Job, error)
+	// FinishJob record how a job's attempt went, retrying it at retryAt if it failed or leaving it dead when that is nil
+	FinishJob(ctx context.Context, job *apiv0.Job, jobErr error, retryAt *time.Time) error
+	// ListJobs list the jobs with a status, most recently created first
+	ListJobs(ctx context.Context, status string, limit int) ([]apiv0.Job, error)
+	// RetryJob queue a dead job again, with its attempts reset
+	RetryJob(ctx context.Context, id string) (*apiv0.Job, error)
+	// PruneJobs delete the jobs that succeeded before a time and the dead ones that died before another, returning how many
+	PruneJobs(ctx context.Context, succeededBefore, deadBefore time.Time) (int, error)
+	// RecordAuditEvent append an event to the audit log
+	RecordAuditEvent(ctx context.Context, event *apiv0.AuditEvent) error
+	// ListAuditEvents list the audit events matching a filter, newest first
+	ListAuditEvents(ctx context.Context, filter *database.AuditFilter, cursor string, limit int) ([]apiv0.AuditEvent, string, error)
+	// QuarantineServer hide a server from listings until it is reinstated or deleted, notifying its namespace
+	QuarantineServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error)
+	// ReinstateServer lift the quarantine of a server
+	ReinstateServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error)
+	// DeleteQuarantinedServer delete a quarantined server, hiding it until it is restored or purged
+	DeleteQuarantinedServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error)
+	// RestoreServer bring back a deleted server that wasn't purged yet, quarantined
+	RestoreServer(ctx context.Context, serverName, reason string, moderator apiv0.Principal) (*apiv0.ModerationCase, error)
+	// PurgeDeletedServers permanently delete the servers deleted before a time
+	PurgeDeletedServers(ctx context.Context, deletedBefore time.Time) (int, error)
+	// ListModerationCases list the moderation cases with a status, or every case, most recent first
+	ListModerationCases(ctx context.Context, status string, limit int) ([]apiv0.ModerationCase, error)
+	// UsePersonalAccessToken look up a personal access token by its secret and record its use
+	UsePersonalAccessToken(ctx context.Context, secret string) (*apiv0.PersonalAccessToken, error)
 }
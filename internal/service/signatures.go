@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrSignatureRequired is returned when a server with a signing key is published without a
+// signature
+var ErrSignatureRequired = errors.New("server has a signing key, so its versions must be published with a signature made with it")
+
+// Signature is a detached signature a publisher made over the canonical server.json they publish
+type Signature struct {
+	Format    string
+	Signature string
+}
+
+// GetSigningKey returns the public key a server's versions must be signed with, or
+// database.ErrNotFound when it has none
+func (s *registryServiceImpl) GetSigningKey(ctx context.Context, serverName string) (*apiv0.SigningKey, error) {
+	return s.db.GetSigningKey(ctx, nil, serverName)
+}
+
+// SetSigningKey registers the public key a server's versions must be signed with from then on.
+// Like publishing, anyone may register a key for a server nobody is recorded as publishing, so
+// that its first version can be signed too.
+func (s *registryServiceImpl) SetSigningKey(ctx context.Context, serverName string, key *apiv0.SigningKey, editor *Publisher) (*apiv0.SigningKey, error) {
+	keyID, err := signing.KeyID(key.Format, key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.SigningKey, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if err := s.checkServerPublisher(ctx, tx, serverName, editor); err != nil {
+			return nil, err
+		}
+		return s.db.SetSigningKey(ctx, tx, serverName, &apiv0.SigningKey{Format: key.Format, PublicKey: key.PublicKey, KeyID: keyID})
+	})
+}
+
+// DeleteSigningKey deletes a server's signing key, so that its versions may be published unsigned
+// again
+func (s *registryServiceImpl) DeleteSigningKey(ctx context.Context, serverName string, editor *Publisher) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return err
+		}
+		if err := s.checkServerPublisher(ctx, tx, serverName, editor); err != nil {
+			return err
+		}
+		return s.db.DeleteSigningKey(ctx, tx, serverName)
+	})
+}
+
+// GetServerSignature returns the signature a server version was published with, of its latest
+// version when version is empty or 'latest'. Versions published unsigned return
+// database.ErrNotFound.
+func (s *registryServiceImpl) GetServerSignature(ctx context.Context, serverName, version string) (*apiv0.ServerSignature, error) {
+	ctx = database.ReadFromReplica(ctx)
+	version, err := s.resolveVersion(ctx, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.GetServerSignature(ctx, nil, serverName, version)
+}
+
+// verifyServerSignature checks a server.json's signature before it's published, returning the
+// signature to store with the version, or nil when it's unsigned. A server with a signing key
+// must be signed with it; other servers' signatures are stored unverified.
+func (s *registryServiceImpl) verifyServerSignature(ctx context.Context, tx pgx.Tx, server *apiv0.ServerJSON, signature *Signature) (*apiv0.ServerSignature, error) {
+	key, err := s.db.GetSigningKey(ctx, tx, server.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	if signature == nil {
+		if key != nil {
+			return nil, ErrSignatureRequired
+		}
+		return nil, nil
+	}
+
+	canonical, err := signing.Canonicalize(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to canonicalize server JSON: %w", err)
+	}
+	stored := &apiv0.ServerSignature{
+		ServerName: server.Name,
+		Version:    server.Version,
+		Format:     signature.Format,
+		Signature:  signature.Signature,
+		Digest:     signing.Digest(canonical),
+	}
+	switch signature.Format {
+	case signing.FormatMinisign, signing.FormatCosign:
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q, expected %s or %s", signing.ErrInvalidSignature, signature.Format, signing.FormatMinisign, signing.FormatCosign)
+	}
+	if key != nil {
+		if signature.Format != key.Format {
+			return nil, fmt.Errorf("%w: the server's signing key is a %s key", signing.ErrInvalidSignature, key.Format)
+		}
+		if err := signing.Verify(key.Format, key.PublicKey, canonical, signature.Signature); err != nil {
+			return nil, err
+		}
+		stored.KeyID = key.KeyID
+		stored.Verified = true
+	}
+	return stored, nil
+}
+
+// dropStaleSignature deletes the signature of a server version whose edit changed the canonical
+// server.json it was made over
+func (s *registryServiceImpl) dropStaleSignature(ctx context.Context, tx pgx.Tx, server *apiv0.ServerJSON) error {
+	signature, err := s.db.GetServerSignature(ctx, tx, server.Name, server.Version)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	canonical, err := signing.Canonicalize(server)
+	if err != nil {
+		return fmt.Errorf("failed to canonicalize server JSON: %w", err)
+	}
+	if signing.Digest(canonical) == signature.Digest {
+		return nil
+	}
+	return s.db.DeleteServerSignature(ctx, tx, server.Name, server.Version)
+}
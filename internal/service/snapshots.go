@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+const (
+	// snapshotTTL is how long clients can page through a snapshot
+	snapshotTTL = time.Hour
+	// snapshotReuseWindow is how recent a snapshot must be to hand it out again instead of taking a
+	// new one, which bounds how often the registry is copied however many clients start a sync
+	snapshotReuseWindow = time.Minute
+)
+
+// ErrSnapshotExpired is returned when reading a snapshot that doesn't exist or has expired
+var ErrSnapshotExpired = errors.New("snapshot not found or expired")
+
+// CreateSnapshot returns a snapshot of the registry that list reads can page through. A snapshot
+// taken in the last minute is shared rather than taking another.
+func (s *registryServiceImpl) CreateSnapshot(ctx context.Context) (*database.Snapshot, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.Snapshot, error) {
+		newest, err := s.db.GetNewestSnapshot(ctx, tx)
+		if err == nil && time.Since(newest.CreatedAt) < snapshotReuseWindow {
+			return newest, nil
+		}
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		return s.db.CreateSnapshot(ctx, tx, time.Now().Add(snapshotTTL))
+	})
+}
+
+// checkSnapshot checks that the snapshot a filter reads, if any, still exists
+func (s *registryServiceImpl) checkSnapshot(ctx context.Context, filter *database.ServerFilter) error {
+	if filter == nil || filter.Snapshot == nil {
+		return nil
+	}
+	snapshot, err := s.db.GetSnapshot(ctx, nil, *filter.Snapshot)
+	if errors.Is(err, database.ErrNotFound) || (err == nil && time.Now().After(snapshot.ExpiresAt)) {
+		return ErrSnapshotExpired
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"regexp"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// StatsDays is how many days of publishes registry statistics cover
+const StatsDays = 30
+
+// Kinds of server usage
+const (
+	ServerUsageRequest = "request"
+	ServerUsageInstall = "install"
+)
+
+// botUserAgentPattern matches the user agents of crawlers, link previews and uptime monitors, whose
+// requests aren't usage
+var botUserAgentPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|preview|monitor|uptime|headless|lighthouse|pingdom|facebookexternalhit`)
+
+// GetRegistryStats counts the servers listings show and the versions published over the last StatsDays days
+func (s *registryServiceImpl) GetRegistryStats(ctx context.Context) (*apiv0.RegistryStats, error) {
+	ctx = database.ReadFromReplica(ctx)
+	return s.db.GetRegistryStats(ctx, nil, StatsDays)
+}
+
+// ListTrendingServers returns the latest versions of the servers used most over the last days,
+// by the requests the registry served for them and the growth of their publisher-reported pulls
+func (s *registryServiceImpl) ListTrendingServers(ctx context.Context, days, limit int) ([]apiv0.TrendingServer, error) {
+	ctx = database.ReadFromReplica(ctx)
+	usages, err := s.db.ListTrendingServers(ctx, nil, days, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(usages))
+	for i, usage := range usages {
+		names[i] = usage.ServerName
+	}
+	servers, err := s.db.GetServerBatch(ctx, nil, names, make([]string, len(names)))
+	if err != nil {
+		return nil, err
+	}
+
+	trending := make([]apiv0.TrendingServer, 0, len(usages))
+	for i, server := range servers {
+		// Skip servers that moved or were purged since they were ranked
+		if server == nil {
+			continue
+		}
+		trending = append(trending, apiv0.TrendingServer{
+			Server:   server.Server,
+			Meta:     server.Meta,
+			Requests: usages[i].Requests,
+			Pulls:    usages[i].Pulls,
+		})
+	}
+	return trending, nil
+}
+
+// RecordServerUsage counts a request for a server's details or an install of it towards its usage.
+// Requests from bots aren't counted, and below a sample rate of 1 only a share of the others are,
+// each standing in for those skipped.
+func (s *registryServiceImpl) RecordServerUsage(ctx context.Context, serverName, kind, userAgent string) error {
+	rate := s.cfg.UsageSampleRate
+	if rate <= 0 || botUserAgentPattern.MatchString(userAgent) {
+		return nil
+	}
+	weight := int64(1)
+	if rate < 1 {
+		if rand.Float64() >= rate {
+			return nil
+		}
+		weight = int64(math.Round(1 / rate))
+	}
+
+	switch kind {
+	case ServerUsageRequest:
+		return s.db.RecordServerUsage(ctx, nil, serverName, weight, 0)
+	case ServerUsageInstall:
+		return s.db.RecordServerUsage(ctx, nil, serverName, 0, weight)
+	default:
+		return fmt.Errorf("%w: unknown usage kind %q", database.ErrInvalidInput, kind)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Errors for status changes the lifecycle doesn't allow
+var (
+	ErrServerDeleted        = errors.New("deleted servers cannot be restored")
+	ErrUnknownReplacement   = errors.New("replacedBy must name a published server")
+	ErrServerReplacesItself = errors.New("a server cannot be replaced by itself")
+)
+
+// SetServerStatus changes the lifecycle status of a server version, or of every version of the
+// server when version is empty, recording why and what replaces it. Deleted versions can't be
+// restored: changing a single deleted version fails, and changing a whole server leaves its
+// deleted versions as they are. It returns the versions that changed.
+func (s *registryServiceImpl) SetServerStatus(ctx context.Context, serverName, version string, update *apiv0.ServerStatusUpdate) ([]*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) ([]*apiv0.ServerResponse, error) {
+		return s.setServerStatusInTransaction(ctx, tx, serverName, version, update)
+	})
+}
+
+// setServerStatusInTransaction contains the actual SetServerStatus logic within a transaction
+func (s *registryServiceImpl) setServerStatusInTransaction(
+	ctx context.Context, tx pgx.Tx, serverName, version string, update *apiv0.ServerStatusUpdate,
+) ([]*apiv0.ServerResponse, error) {
+	if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+		return nil, err
+	}
+
+	if update.ReplacedBy != "" {
+		if update.ReplacedBy == serverName {
+			return nil, ErrServerReplacesItself
+		}
+		if _, err := s.db.GetServerByName(ctx, tx, update.ReplacedBy); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, ErrUnknownReplacement
+			}
+			return nil, err
+		}
+	}
+
+	var versions []*apiv0.ServerResponse
+	if version != "" {
+		current, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if isDeleted(current) && update.Status != model.StatusDeleted {
+			return nil, ErrServerDeleted
+		}
+		versions = []*apiv0.ServerResponse{current}
+	} else {
+		all, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+		for _, current := range all {
+			if !isDeleted(current) || update.Status == model.StatusDeleted {
+				versions = append(versions, current)
+			}
+		}
+	}
+
+	var details *apiv0.StatusDetails
+	if update.Reason != "" || update.ReplacedBy != "" {
+		details = &apiv0.StatusDetails{Reason: update.Reason, ReplacedBy: update.ReplacedBy}
+	}
+
+	changed := make([]*apiv0.ServerResponse, 0, len(versions))
+	for _, current := range versions {
+		if _, err := s.db.SetServerStatus(ctx, tx, serverName, current.Server.Version, string(update.Status)); err != nil {
+			return nil, err
+		}
+		if err := s.db.SetServerStatusDetails(ctx, tx, serverName, current.Server.Version, details); err != nil {
+			return nil, err
+		}
+
+		updated, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, current.Server.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read updated server: %w", err)
+		}
+		if err := s.enqueueWebhookEvent(ctx, tx, serverUpdateEvent(current, updated), updated); err != nil {
+			return nil, err
+		}
+		changed = append(changed, updated)
+	}
+
+	return changed, nil
+}
+
+// isDeleted reports whether a server version has been deleted
+func isDeleted(server *apiv0.ServerResponse) bool {
+	return server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDeleted
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListCategories counts the servers listings show in each publisher-provided category
+func (s *registryServiceImpl) ListCategories(ctx context.Context) ([]apiv0.TaxonomyTerm, error) {
+	ctx = database.ReadFromReplica(ctx)
+	return s.db.ListCategories(ctx, nil)
+}
+
+// ListTags counts the servers listings show with each publisher-provided tag
+func (s *registryServiceImpl) ListTags(ctx context.Context) ([]apiv0.TaxonomyTerm, error) {
+	ctx = database.ReadFromReplica(ctx)
+	return s.db.ListTags(ctx, nil)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, so that leaked tokens are easy
+// to recognize and scan for
+const PersonalAccessTokenPrefix = "mcp_pat_"
+
+// personalAccessTokenDisplayLength is how much of a token is kept in clear to help recognize it
+const personalAccessTokenDisplayLength = len(PersonalAccessTokenPrefix) + 4
+
+// Errors returned when a personal access token can't be used
+var (
+	ErrInvalidPersonalAccessToken = errors.New("invalid personal access token")
+	ErrPersonalAccessTokenRevoked = errors.New("personal access token has been revoked")
+	ErrPersonalAccessTokenExpired = errors.New("personal access token has expired")
+)
+
+// CreatePersonalAccessToken creates a personal access token acting as owner. The secret is
+// returned once and only its hash is stored. The caller is responsible for checking that the
+// owner holds the permissions given to the token.
+func (s *registryServiceImpl) CreatePersonalAccessToken(
+	ctx context.Context, owner apiv0.Principal, name string, permissions []apiv0.TokenPermission, expiresAt time.Time,
+) (*apiv0.CreatedPersonalAccessToken, error) {
+	secret, err := newPersonalAccessTokenSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.db.CreatePersonalAccessToken(ctx, nil, &apiv0.PersonalAccessToken{
+		Name:        name,
+		Prefix:      secret[:personalAccessTokenDisplayLength],
+		Owner:       owner,
+		Permissions: permissions,
+		ExpiresAt:   expiresAt,
+	}, hashPersonalAccessToken(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.CreatedPersonalAccessToken{PersonalAccessToken: *token, Token: secret}, nil
+}
+
+// ListPersonalAccessTokens lists a principal's personal access tokens, including expired and
+// revoked ones
+func (s *registryServiceImpl) ListPersonalAccessTokens(ctx context.Context, owner apiv0.Principal) ([]apiv0.PersonalAccessToken, error) {
+	return s.db.ListPersonalAccessTokens(ctx, nil, owner)
+}
+
+// GetPersonalAccessToken retrieves one of a principal's personal access tokens
+func (s *registryServiceImpl) GetPersonalAccessToken(ctx context.Context, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error) {
+	return s.db.GetPersonalAccessToken(ctx, nil, id, owner)
+}
+
+// RotatePersonalAccessToken replaces the secret of one of a principal's tokens, keeping its name
+// and permissions. The old secret stops working straight away. The token keeps its expiry unless
+// expiresAt is given, which it must be for expired tokens. The new secret is returned once.
+func (s *registryServiceImpl) RotatePersonalAccessToken(
+	ctx context.Context, id string, owner apiv0.Principal, expiresAt *time.Time,
+) (*apiv0.CreatedPersonalAccessToken, error) {
+	current, err := s.db.GetPersonalAccessToken(ctx, nil, id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if current.RevokedAt != nil {
+		return nil, ErrPersonalAccessTokenRevoked
+	}
+	if expiresAt == nil {
+		if time.Now().After(current.ExpiresAt) {
+			return nil, ErrPersonalAccessTokenExpired
+		}
+		expiresAt = &current.ExpiresAt
+	}
+
+	secret, err := newPersonalAccessTokenSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.db.RotatePersonalAccessToken(ctx, nil, id, owner, secret[:personalAccessTokenDisplayLength], hashPersonalAccessToken(secret), *expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.CreatedPersonalAccessToken{PersonalAccessToken: *token, Token: secret}, nil
+}
+
+// RevokePersonalAccessToken revokes one of a principal's personal access tokens
+func (s *registryServiceImpl) RevokePersonalAccessToken(ctx context.Context, id string, owner apiv0.Principal) (*apiv0.PersonalAccessToken, error) {
+	return s.db.RevokePersonalAccessToken(ctx, nil, id, owner)
+}
+
+// UsePersonalAccessToken looks up a personal access token by its secret, checks that it can
+// still be used and records its use
+func (s *registryServiceImpl) UsePersonalAccessToken(ctx context.Context, secret string) (*apiv0.PersonalAccessToken, error) {
+	if !strings.HasPrefix(secret, PersonalAccessTokenPrefix) {
+		return nil, ErrInvalidPersonalAccessToken
+	}
+
+	token, err := s.db.GetPersonalAccessTokenByHash(ctx, nil, hashPersonalAccessToken(secret))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, ErrInvalidPersonalAccessToken
+		}
+		return nil, err
+	}
+	if token.RevokedAt != nil {
+		return nil, ErrPersonalAccessTokenRevoked
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, ErrPersonalAccessTokenExpired
+	}
+
+	if err := s.db.TouchPersonalAccessToken(ctx, nil, token.ID); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	token.LastUsedAt = &now
+	return token, nil
+}
+
+func newPersonalAccessTokenSecret() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return PersonalAccessTokenPrefix + hex.EncodeToString(randomBytes), nil
+}
+
+func hashPersonalAccessToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Statuses of update proposals
+const (
+	UpdateProposalPending   = "pending"
+	UpdateProposalPublished = "published"
+	UpdateProposalDismissed = "dismissed"
+)
+
+// ErrProposalResolved is returned when publishing or dismissing a proposal that was already
+// published or dismissed
+var ErrProposalResolved = errors.New("update proposal was already published or dismissed")
+
+// UpstreamRelease is a release of the repository a server is built from
+type UpstreamRelease struct {
+	Tag string
+	URL string
+}
+
+// ProposeServerUpdate proposes publishing a new version of a server for an upstream release
+// newer than its latest version, or publishes it straight away when the server's update policy
+// allows. Releases are only proposed once: proposing one again returns
+// database.ErrAlreadyExists.
+func (s *registryServiceImpl) ProposeServerUpdate(ctx context.Context, serverName string, release UpstreamRelease) (*apiv0.UpdateProposal, error) {
+	latest, err := s.db.GetServerByName(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	version := strings.TrimPrefix(release.Tag, "v")
+	if !IsSemanticVersion(version) || !IsSemanticVersion(latest.Server.Version) ||
+		compareSemanticVersions(version, latest.Server.Version) <= 0 {
+		return nil, fmt.Errorf("%w: release %s is not a newer semantic version than %s", database.ErrInvalidInput, release.Tag, latest.Server.Version)
+	}
+
+	server, err := proposedServerJSON(&latest.Server, version)
+	if err != nil {
+		return nil, err
+	}
+	proposal, err := s.db.CreateUpdateProposal(ctx, nil, &apiv0.UpdateProposal{
+		ServerName:     serverName,
+		Version:        version,
+		CurrentVersion: latest.Server.Version,
+		ReleaseTag:     release.Tag,
+		ReleaseURL:     release.URL,
+		Server:         *server,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.db.GetUpdatePolicy(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+	if policy.AutoPublish {
+		if _, err := s.PublishUpdateProposal(ctx, serverName, proposal.ID); err == nil {
+			return s.db.GetUpdateProposal(ctx, nil, serverName, proposal.ID)
+		}
+		// Publishing failed, leaving the proposal pending with the error for the publisher to fix
+		if proposal, err = s.db.GetUpdateProposal(ctx, nil, serverName, proposal.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	namespace, _, _ := strings.Cut(serverName, "/")
+	s.notifier.Notify(notifications.Event{
+		Type:       notifications.EventUpdateAvailable,
+		Namespace:  namespace,
+		ServerName: serverName,
+		Version:    version,
+		Message:    fmt.Sprintf("%s %s was released upstream and is waiting to be published", serverName, version),
+	})
+	return proposal, nil
+}
+
+// ListUpdateProposals lists the update proposals of a server, or of every server when serverName
+// is empty, most recent first. An empty status lists proposals of any status.
+func (s *registryServiceImpl) ListUpdateProposals(ctx context.Context, serverName, status string, limit int) ([]apiv0.UpdateProposal, error) {
+	if serverName != "" {
+		if _, err := s.db.GetServerByName(ctx, nil, serverName); err != nil {
+			return nil, err
+		}
+	}
+	return s.db.ListUpdateProposals(ctx, nil, serverName, status, limit)
+}
+
+// PublishUpdateProposal publishes the version an update proposal proposes. When publishing
+// fails, the proposal stays pending and records why.
+func (s *registryServiceImpl) PublishUpdateProposal(ctx context.Context, serverName, id string) (*apiv0.ServerResponse, error) {
+	proposal, err := s.db.GetUpdateProposal(ctx, nil, serverName, id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != UpdateProposalPending {
+		return nil, ErrProposalResolved
+	}
+
+	// The server may have been renamed since the proposal was made
+	proposal.Server.Name = serverName
+	published, err := s.CreateServer(ctx, &proposal.Server)
+	if err != nil {
+		if statusErr := s.db.SetUpdateProposalStatus(ctx, nil, id, UpdateProposalPending, err.Error()); statusErr != nil {
+			return nil, statusErr
+		}
+		return nil, err
+	}
+
+	if err := s.db.SetUpdateProposalStatus(ctx, nil, id, UpdateProposalPublished, ""); err != nil {
+		return nil, err
+	}
+	return published, nil
+}
+
+// DismissUpdateProposal dismisses an update proposal, so that its release isn't proposed again
+func (s *registryServiceImpl) DismissUpdateProposal(ctx context.Context, serverName, id string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		proposal, err := s.db.GetUpdateProposal(ctx, tx, serverName, id)
+		if err != nil {
+			return err
+		}
+		if proposal.Status != UpdateProposalPending {
+			return ErrProposalResolved
+		}
+		return s.db.SetUpdateProposalStatus(ctx, tx, id, UpdateProposalDismissed, "")
+	})
+}
+
+// GetUpdatePolicy retrieves how new upstream releases of a server are handled
+func (s *registryServiceImpl) GetUpdatePolicy(ctx context.Context, serverName string) (*apiv0.UpdatePolicy, error) {
+	if _, err := s.db.GetServerByName(ctx, nil, serverName); err != nil {
+		return nil, err
+	}
+	return s.db.GetUpdatePolicy(ctx, nil, serverName)
+}
+
+// SetUpdatePolicy sets how new upstream releases of a server are handled
+func (s *registryServiceImpl) SetUpdatePolicy(ctx context.Context, serverName string, policy *apiv0.UpdatePolicy) (*apiv0.UpdatePolicy, error) {
+	if _, err := s.db.GetServerByName(ctx, nil, serverName); err != nil {
+		return nil, err
+	}
+	if err := s.db.SetUpdatePolicy(ctx, nil, serverName, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// proposedServerJSON derives the server entry of a new version from the current one. Packages
+// that were versioned along with the server, through their version or their image tag, move to
+// the new version too; everything else stays the same.
+func proposedServerJSON(current *apiv0.ServerJSON, version string) (*apiv0.ServerJSON, error) {
+	// Copy through JSON so the proposal shares no slices or maps with the current version
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(currentJSON, &server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	oldVersion := server.Version
+	server.Version = version
+	for i := range server.Packages {
+		pkg := &server.Packages[i]
+		if pkg.Version == oldVersion {
+			pkg.Version = version
+		}
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			for _, prefix := range []string{"", "v"} {
+				if image, found := strings.CutSuffix(pkg.Identifier, ":"+prefix+oldVersion); found {
+					pkg.Identifier = image + ":" + prefix + version
+				}
+			}
+		}
+	}
+	return &server, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// What verifying a namespace proved control of
+const (
+	NamespaceVerificationDomain    = "domain"
+	NamespaceVerificationGitHubOrg = "github-org"
+)
+
+// VerifyNamespace records that a principal proved control of the domain or GitHub organization
+// a namespace is named after. The caller is responsible for checking the proof.
+func (s *registryServiceImpl) VerifyNamespace(ctx context.Context, namespace, method string, by apiv0.Principal) (*apiv0.NamespaceVerification, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.NamespaceVerification, error) {
+		verification, err := s.db.SetNamespaceVerification(ctx, tx, &apiv0.NamespaceVerification{
+			Namespace:  namespace,
+			Method:     method,
+			VerifiedBy: by,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+			Namespace: namespace,
+			Action:    "namespace-verified",
+			Actor:     by,
+		}); err != nil {
+			return nil, err
+		}
+		return verification, nil
+	})
+}
+
+// RevokeNamespaceVerification drops the verification of a namespace, e.g. after its domain lapsed
+func (s *registryServiceImpl) RevokeNamespaceVerification(ctx context.Context, namespace string, actor apiv0.Principal) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteNamespaceVerification(ctx, tx, namespace); err != nil {
+			return err
+		}
+		return s.db.AddNamespaceAuditEntry(ctx, tx, &apiv0.NamespaceAuditEntry{
+			Namespace: namespace,
+			Action:    "namespace-verification-revoked",
+			Actor:     actor,
+		})
+	})
+}
+
+// getNamespaceVerification retrieves how a namespace was verified, or nil if it wasn't
+func (s *registryServiceImpl) getNamespaceVerification(ctx context.Context, namespace string) (*apiv0.NamespaceVerification, error) {
+	verification, err := s.db.GetNamespaceVerification(ctx, nil, namespace)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	return verification, err
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Types of event webhooks can be sent
+const (
+	WebhookEventServerPublished  = "server.published"
+	WebhookEventServerUpdated    = "server.updated"
+	WebhookEventServerDeprecated = "server.deprecated"
+	WebhookEventServerDeleted    = "server.deleted"
+)
+
+// WebhookEventTypes lists every type of webhook event, in the order they are documented
+var WebhookEventTypes = []string{WebhookEventServerPublished, WebhookEventServerUpdated, WebhookEventServerDeprecated, WebhookEventServerDeleted}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookSecretPrefix starts every webhook signing secret, so that leaked secrets are easy to recognize
+const WebhookSecretPrefix = "whsec_"
+
+const (
+	// MaxWebhookDeliveryAttempts is how many times a delivery is attempted before it fails
+	MaxWebhookDeliveryAttempts = 8
+	// WebhookDeliveryLease is how long a claimed delivery waits before it is attempted again
+	// when the attempt's outcome was never recorded
+	WebhookDeliveryLease = 2 * time.Minute
+	// webhookRetryDelay is the wait after the first failed attempt, doubling after each one
+	webhookRetryDelay = 30 * time.Second
+)
+
+// CreateWebhook registers a webhook for a principal, generating the secret its deliveries are
+// signed with. The secret is only returned here. The caller is responsible for checking that the
+// URL and events are valid.
+func (s *registryServiceImpl) CreateWebhook(ctx context.Context, owner apiv0.Principal, url string, events []string) (*apiv0.CreatedWebhook, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook, err := s.db.CreateWebhook(ctx, nil, &apiv0.Webhook{
+		Owner:  owner,
+		URL:    url,
+		Events: events,
+		Secret: WebhookSecretPrefix + hex.EncodeToString(randomBytes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.CreatedWebhook{Webhook: *webhook, Secret: webhook.Secret}, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (s *registryServiceImpl) GetWebhook(ctx context.Context, id string) (*apiv0.Webhook, error) {
+	return s.db.GetWebhook(ctx, nil, id)
+}
+
+// ListWebhooks lists a principal's webhooks
+func (s *registryServiceImpl) ListWebhooks(ctx context.Context, owner apiv0.Principal) ([]apiv0.Webhook, error) {
+	return s.db.ListWebhooks(ctx, nil, owner)
+}
+
+// ListFailingWebhooks lists the webhooks whose last delivery attempt failed, those failing the
+// longest first
+func (s *registryServiceImpl) ListFailingWebhooks(ctx context.Context, limit int) ([]apiv0.Webhook, error) {
+	return s.db.ListFailingWebhooks(ctx, nil, limit)
+}
+
+// DeleteWebhook removes one of a principal's webhooks, dropping its pending deliveries
+func (s *registryServiceImpl) DeleteWebhook(ctx context.Context, id string, owner apiv0.Principal) error {
+	return s.db.DeleteWebhook(ctx, nil, id, owner)
+}
+
+// ListWebhookDeliveries lists the most recent deliveries to a webhook
+func (s *registryServiceImpl) ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]apiv0.WebhookDelivery, error) {
+	return s.db.ListWebhookDeliveries(ctx, nil, webhookID, limit)
+}
+
+// ClaimWebhookDelivery hands the pending delivery that has been due longest to a dispatcher
+func (s *registryServiceImpl) ClaimWebhookDelivery(ctx context.Context) (*database.WebhookDeliveryAttempt, error) {
+	return s.db.ClaimWebhookDelivery(ctx, nil, time.Now().Add(WebhookDeliveryLease))
+}
+
+// RecordWebhookDeliveryAttempt records how a delivery attempt went. A failed attempt is retried
+// with exponential backoff until the delivery runs out of attempts.
+func (s *registryServiceImpl) RecordWebhookDeliveryAttempt(
+	ctx context.Context, attempt *database.WebhookDeliveryAttempt, responseStatus int, deliveryErr error,
+) error {
+	now := time.Now()
+	outcome := &apiv0.WebhookDelivery{ID: attempt.ID, ResponseStatus: responseStatus}
+	switch {
+	case deliveryErr == nil:
+		outcome.Status = WebhookDeliverySucceeded
+		outcome.DeliveredAt = &now
+	case attempt.Attempt >= MaxWebhookDeliveryAttempts:
+		outcome.Status = WebhookDeliveryFailed
+		outcome.Error = deliveryErr.Error()
+	default:
+		next := now.Add(WebhookRetryDelay(attempt.Attempt))
+		outcome.Status = WebhookDeliveryPending
+		outcome.Error = deliveryErr.Error()
+		outcome.NextAttemptAt = &next
+	}
+
+	return s.db.RecordWebhookDeliveryAttempt(ctx, nil, outcome)
+}
+
+// WebhookRetryDelay returns how long to wait before retrying a delivery after its nth failed attempt
+func WebhookRetryDelay(attempt int) time.Duration {
+	return webhookRetryDelay << (max(attempt, 1) - 1)
+}
+
+// enqueueWebhookEvent queues an event about a server version for the webhooks that want it, in
+// the transaction that caused it, so that events are sent exactly for the changes that commit
+func (s *registryServiceImpl) enqueueWebhookEvent(ctx context.Context, tx pgx.Tx, event string, server *apiv0.ServerResponse) error {
+	payload, err := json.Marshal(apiv0.WebhookEvent{
+		Type:       event,
+		OccurredAt: time.Now().UTC(),
+		Server:     *server,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	return s.db.EnqueueWebhookDeliveries(ctx, tx, event, payload)
+}
+
+// serverUpdateEvent returns the webhook event of an edit: a deprecation or deletion when it
+// moved the version to that status, and an update otherwise
+func serverUpdateEvent(before, after *apiv0.ServerResponse) string {
+	if before.Meta.Official == nil || after.Meta.Official == nil || before.Meta.Official.Status == after.Meta.Official.Status {
+		return WebhookEventServerUpdated
+	}
+	if after.Meta.Official.Status == model.StatusDeprecated {
+		return WebhookEventServerDeprecated
+	}
+	if after.Meta.Official.Status == model.StatusDeleted {
+		return WebhookEventServerDeleted
+	}
+	return WebhookEventServerUpdated
+}
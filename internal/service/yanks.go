@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// YankServerVersion yanks a version of a server, or restores a yanked one. Yanked versions are
+// never latest and drop out of the release channels that pointed at them, but stay fetchable
+// by exact version.
+func (s *registryServiceImpl) YankServerVersion(ctx context.Context, serverName, version string, yanked bool) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.SetServerYanked(ctx, tx, serverName, version, yanked); err != nil {
+			return nil, err
+		}
+		if yanked {
+			if err := s.db.DeleteVersionChannels(ctx, tx, serverName, version); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.updateLatestVersion(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		return s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+	})
+}
+
+// updateLatestVersion marks the highest version of a server that isn't yanked as its latest
+func (s *registryServiceImpl) updateLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) error {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return err
+	}
+
+	var latest *apiv0.ServerResponse
+	for _, candidate := range versions {
+		if candidate.Meta.Official == nil || candidate.Meta.Official.YankedAt != nil {
+			continue
+		}
+		if latest == nil || CompareVersions(
+			candidate.Server.Version,
+			latest.Server.Version,
+			candidate.Meta.Official.PublishedAt,
+			latest.Meta.Official.PublishedAt,
+		) > 0 {
+			latest = candidate
+		}
+	}
+
+	if err := s.db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+		return err
+	}
+	if latest == nil {
+		return nil
+	}
+	return s.db.MarkAsLatest(ctx, tx, serverName, latest.Server.Version)
+}
@@ -0,0 +1,236 @@
+// Package signing verifies the detached signatures publishers make over server.json documents
+// with minisign or cosign, so that consumers can check a server version came from its publisher
+// rather than trusting the registry alone
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Signature formats
+const (
+	// FormatMinisign is a minisign signature file, as written by minisign -S
+	FormatMinisign = "minisign"
+	// FormatCosign is a base64 signature made with a cosign key pair, as written by
+	// cosign sign-blob --key
+	FormatCosign = "cosign"
+)
+
+// ErrInvalidSignature is returned when a signature or public key can't be parsed, or the
+// signature doesn't verify
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Canonicalize returns the bytes of a server.json that signatures are made over: the server.json
+// as the registry encodes it, with object keys sorted and no whitespace, as jq -cS prints it
+func Canonicalize(server *apiv0.ServerJSON) ([]byte, error) {
+	encoded, err := json.Marshal(server)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	// Maps are encoded with their keys sorted
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(canonical.Bytes(), []byte("\n")), nil
+}
+
+// Digest returns the hex-encoded SHA-256 of a server.json's canonical bytes
+func Digest(canonical []byte) string {
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// KeyID parses a public key of a format and returns its ID: minisign's key ID, or the
+// hex-encoded SHA-256 of a cosign key's DER encoding
+func KeyID(format, publicKey string) (string, error) {
+	switch format {
+	case FormatMinisign:
+		key, err := parseMinisignKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		return minisignKeyID(key.id), nil
+	case FormatCosign:
+		_, der, err := parseCosignKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		sum := sha256.Sum256(der)
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return "", fmt.Errorf("%w: unknown format %q, expected %s or %s", ErrInvalidSignature, format, FormatMinisign, FormatCosign)
+	}
+}
+
+// Verify checks that a signature of a format over a message was made with the private key of a
+// public key of the same format
+func Verify(format, publicKey string, message []byte, signature string) error {
+	switch format {
+	case FormatMinisign:
+		key, err := parseMinisignKey(publicKey)
+		if err != nil {
+			return err
+		}
+		return verifyMinisign(key, message, signature)
+	case FormatCosign:
+		key, _, err := parseCosignKey(publicKey)
+		if err != nil {
+			return err
+		}
+		return verifyCosign(key, message, signature)
+	default:
+		return fmt.Errorf("%w: unknown format %q, expected %s or %s", ErrInvalidSignature, format, FormatMinisign, FormatCosign)
+	}
+}
+
+type minisignKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+// parseMinisignKey parses a minisign public key, either the .pub file or just its base64 line
+func parseMinisignKey(publicKey string) (*minisignKey, error) {
+	encoded := strings.TrimSpace(publicKey)
+	if lines := strings.Split(encoded, "\n"); len(lines) > 1 {
+		encoded = strings.TrimSpace(lines[len(lines)-1])
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(data) != 2+8+ed25519.PublicKeySize || string(data[:2]) != "Ed" {
+		return nil, fmt.Errorf("%w: not a minisign public key", ErrInvalidSignature)
+	}
+	return &minisignKey{id: data[2:10], key: ed25519.PublicKey(data[10:])}, nil
+}
+
+// minisignKeyID formats a key ID the way minisign prints it: its little-endian bytes as
+// uppercase hex
+func minisignKeyID(id []byte) string {
+	reversed := make([]byte, len(id))
+	for i, b := range id {
+		reversed[len(id)-1-i] = b
+	}
+	return strings.ToUpper(hex.EncodeToString(reversed))
+}
+
+// verifyMinisign checks a minisign signature file: the signature over the message, or over its
+// BLAKE2b-512 hash for prehashed signatures, and the global signature over the trusted comment
+func verifyMinisign(key *minisignKey, message []byte, signature string) error {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(signature, "\r\n", "\n")), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "untrusted comment:") || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("%w: not a minisign signature file", ErrInvalidSignature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign signature", ErrInvalidSignature)
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign global signature", ErrInvalidSignature)
+	}
+	if !bytes.Equal(sig[2:10], key.id) {
+		return fmt.Errorf("%w: signed with key %s rather than %s", ErrInvalidSignature, minisignKeyID(sig[2:10]), minisignKeyID(key.id))
+	}
+
+	signed := message
+	switch string(sig[:2]) {
+	case "Ed":
+	case "ED":
+		hash := blake2b.Sum512(message)
+		signed = hash[:]
+	default:
+		return fmt.Errorf("%w: unknown minisign algorithm %q", ErrInvalidSignature, sig[:2])
+	}
+	if !ed25519.Verify(key.key, signed, sig[10:]) {
+		return fmt.Errorf("%w: signature doesn't match the server.json", ErrInvalidSignature)
+	}
+
+	trustedComment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if !ed25519.Verify(key.key, slices.Concat(sig[10:], []byte(trustedComment)), globalSig) {
+		return fmt.Errorf("%w: trusted comment doesn't match its signature", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// parseCosignKey parses a PEM-encoded public key of a cosign key pair, returning it and its DER
+// encoding
+func parseCosignKey(publicKey string) (crypto.PublicKey, []byte, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(publicKey)))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, nil, fmt.Errorf("%w: not a PEM-encoded public key", ErrInvalidSignature)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return key, block.Bytes, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported public key type %T", ErrInvalidSignature, key)
+	}
+}
+
+// verifyCosign checks a base64 signature cosign made with a key pair: ECDSA over the message's
+// hash of the curve's size, Ed25519 over the message, or RSA PKCS #1 v1.5 over its SHA-256
+func verifyCosign(key crypto.PublicKey, message []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return fmt.Errorf("%w: cosign signatures are base64-encoded", ErrInvalidSignature)
+	}
+
+	valid := false
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		var digest []byte
+		switch key.Curve {
+		case elliptic.P384():
+			sum := sha512.Sum384(message)
+			digest = sum[:]
+		case elliptic.P521():
+			sum := sha512.Sum512(message)
+			digest = sum[:]
+		default:
+			sum := sha256.Sum256(message)
+			digest = sum[:]
+		}
+		valid = ecdsa.VerifyASN1(key, digest, sig)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, message, sig)
+	case *rsa.PublicKey:
+		sum := sha256.Sum256(message)
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig) == nil
+	}
+	if !valid {
+		return fmt.Errorf("%w: signature doesn't match the server.json", ErrInvalidSignature)
+	}
+	return nil
+}
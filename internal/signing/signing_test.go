@@ -0,0 +1,122 @@
+package signing_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/modelcontextprotocol/registry/internal/signing"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// minisignPair makes a minisign key pair, returning the .pub file and a function that signs like
+// minisign -S, prehashed unless legacy is set
+func minisignPair(t *testing.T) (string, func(message []byte, legacy bool) string) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+	publicFile := "untrusted comment: minisign public key 0807060504030201\n" +
+		base64.StdEncoding.EncodeToString(slices.Concat([]byte("Ed"), keyID, public)) + "\n"
+	sign := func(message []byte, legacy bool) string {
+		algorithm, signed := "ED", message
+		if legacy {
+			algorithm = "Ed"
+		} else {
+			hash := blake2b.Sum512(message)
+			signed = hash[:]
+		}
+		sig := ed25519.Sign(private, signed)
+		trustedComment := "timestamp:1760000000\tfile:server.json\thashed"
+		globalSig := ed25519.Sign(private, slices.Concat(sig, []byte(trustedComment)))
+		return "untrusted comment: signature from minisign secret key\n" +
+			base64.StdEncoding.EncodeToString(slices.Concat([]byte(algorithm), keyID, sig)) + "\n" +
+			"trusted comment: " + trustedComment + "\n" +
+			base64.StdEncoding.EncodeToString(globalSig) + "\n"
+	}
+	return publicFile, sign
+}
+
+func TestCanonicalize(t *testing.T) {
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Forecasts <for> you & me",
+		Version:     "1.0.0",
+		Repository:  model.Repository{URL: "https://github.com/alice/weather", Source: "github"},
+	}
+
+	canonical, err := signing.Canonicalize(server)
+	require.NoError(t, err)
+	assert.Equal(t, `{"$schema":"`+model.CurrentSchemaURL+`","description":"Forecasts <for> you & me","name":"io.github.alice/weather",`+
+		`"repository":{"source":"github","url":"https://github.com/alice/weather"},"version":"1.0.0"}`, string(canonical))
+	assert.Len(t, signing.Digest(canonical), 64)
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	publicKey, sign := minisignPair(t)
+	message := []byte(`{"name":"io.github.alice/weather"}`)
+
+	keyID, err := signing.KeyID(signing.FormatMinisign, publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, "0807060504030201", keyID)
+
+	assert.NoError(t, signing.Verify(signing.FormatMinisign, publicKey, message, sign(message, false)))
+	assert.NoError(t, signing.Verify(signing.FormatMinisign, publicKey, message, sign(message, true)), "legacy signatures aren't prehashed")
+
+	err = signing.Verify(signing.FormatMinisign, publicKey, []byte(`{"name":"io.github.mallory/weather"}`), sign(message, false))
+	assert.ErrorIs(t, err, signing.ErrInvalidSignature)
+
+	otherKey, otherSign := minisignPair(t)
+	assert.ErrorIs(t, signing.Verify(signing.FormatMinisign, otherKey, message, sign(message, false)), signing.ErrInvalidSignature)
+	assert.ErrorIs(t, signing.Verify(signing.FormatMinisign, publicKey, message, otherSign(message, false)), signing.ErrInvalidSignature)
+
+	_, err = signing.KeyID(signing.FormatMinisign, "not a key")
+	assert.ErrorIs(t, err, signing.ErrInvalidSignature)
+}
+
+func TestVerifyCosign(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	message := []byte(`{"name":"io.github.alice/weather"}`)
+	digest := sha256.Sum256(message)
+	sig, err := ecdsa.SignASN1(rand.Reader, private, digest[:])
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(sig)
+
+	keyID, err := signing.KeyID(signing.FormatCosign, publicKey)
+	require.NoError(t, err)
+	assert.Len(t, keyID, 64)
+
+	assert.NoError(t, signing.Verify(signing.FormatCosign, publicKey, message, signature))
+	assert.ErrorIs(t, signing.Verify(signing.FormatCosign, publicKey, []byte(`{}`), signature), signing.ErrInvalidSignature)
+	assert.ErrorIs(t, signing.Verify(signing.FormatCosign, publicKey, message, "not base64!"), signing.ErrInvalidSignature)
+
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(edPublic)
+	require.NoError(t, err)
+	edPublicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	edSignature := base64.StdEncoding.EncodeToString(ed25519.Sign(edPrivate, message))
+	assert.NoError(t, signing.Verify(signing.FormatCosign, edPublicKey, message, edSignature))
+	assert.ErrorIs(t, signing.Verify(signing.FormatCosign, edPublicKey, message, signature), signing.ErrInvalidSignature)
+
+	assert.ErrorIs(t, signing.Verify("gpg", publicKey, message, signature), signing.ErrInvalidSignature)
+}
@@ -32,6 +32,15 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// CacheRequests tracks lookups in the in-process caches, by cache and whether they hit
+	CacheRequests metric.Int64Counter
+
+	// JobRuns tracks attempts of background jobs, by kind and result
+	JobRuns metric.Int64Counter
+
+	// JobDuration tracks how long attempts of background jobs take
+	JobDuration metric.Float64Histogram
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -73,11 +82,41 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("failed to create service up gauge: %w", err)
 	}
 
+	cacheRequests, err := meter.Int64Counter(
+		Namespace+".cache.requests",
+		metric.WithDescription("Total number of lookups in in-process caches, by cache and result (hit or miss)"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache request counter: %w", err)
+	}
+
+	jobRuns, err := meter.Int64Counter(
+		Namespace+".jobs.runs",
+		metric.WithDescription("Total number of background job attempts, by kind and result (succeeded, retried or dead)"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job run counter: %w", err)
+	}
+
+	jobDuration, err := meter.Float64Histogram(
+		Namespace+".jobs.duration",
+		metric.WithDescription("Duration of background job attempts in seconds"),
+		metric.WithExplicitBucketBoundaries(
+			0.01, 0.1, 0.5, 1.0, 5.0, 10.0, 30.0, 60.0, 300.0, 600.0,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job duration histogram: %w", err)
+	}
+
 	return &Metrics{
 		Requests:        req,
 		RequestDuration: reqDuration,
 		ErrorCount:      errCount,
 		Up:              up,
+		CacheRequests:   cacheRequests,
+		JobRuns:         jobRuns,
+		JobDuration:     jobDuration,
 	}, nil
 }
 
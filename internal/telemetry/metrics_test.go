@@ -37,6 +37,8 @@ func TestNewMetrics(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, metrics)
 			assert.NotNil(t, metrics.Requests)
+			assert.NotNil(t, metrics.CacheRequests)
+			assert.NotNil(t, metrics.JobRuns)
 		})
 	}
 }
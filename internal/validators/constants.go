@@ -29,6 +29,10 @@ var (
 	// Server name validation errors
 	ErrMultipleSlashesInServerName = errors.New("server name cannot contain multiple slashes")
 	ErrInvalidServerNameFormat     = errors.New("server name format is invalid")
+
+	// Relationship validation errors
+	ErrInvalidRelationship  = errors.New("invalid relationship")
+	ErrTooManyRelationships = errors.New("too many relationships")
 )
 
 // RepositorySource represents valid repository sources
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -63,15 +64,35 @@ func getRegistryConfig(registryBaseURL, namespace, repo string) *RegistryConfig
 // OCIManifest represents an OCI image manifest
 type OCIManifest struct {
 	Manifests []struct {
-		Digest string `json:"digest"`
+		Digest   string       `json:"digest"`
+		Platform *OCIPlatform `json:"platform,omitempty"`
 	} `json:"manifests,omitempty"`
 	Config struct {
 		Digest string `json:"digest"`
 	} `json:"config,omitempty"`
+	Layers []struct {
+		Size int64 `json:"size"`
+	} `json:"layers,omitempty"`
+}
+
+// OCIPlatform is the platform an image is built for
+type OCIPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// String formats the platform as os/architecture, with /variant when it has one
+func (p OCIPlatform) String() string {
+	if p.Variant != "" {
+		return p.OS + "/" + p.Architecture + "/" + p.Variant
+	}
+	return p.OS + "/" + p.Architecture
 }
 
 // OCIImageConfig represents an OCI image configuration
 type OCIImageConfig struct {
+	OCIPlatform
 	Config struct {
 		Labels map[string]string `json:"Labels"`
 	} `json:"config"`
@@ -146,6 +167,90 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	return validateServerNameAnnotation(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, ociRef.Tag, configDigest, serverName)
 }
 
+// Labels declaring the resources an MCP server image expects to run with
+const (
+	OCIMemoryLabel = "io.modelcontextprotocol.server.memory"
+	OCICPUsLabel   = "io.modelcontextprotocol.server.cpus"
+)
+
+// OCIImageInfo is what can be found out about an OCI image from its manifests and config
+type OCIImageInfo struct {
+	// Platforms the image is built for, as os/architecture with /variant when it has one
+	Platforms []string
+	// Size is the compressed size of the image's layers in bytes. For multi-platform images it
+	// is the size of the first platform in the image index.
+	Size int64
+	// Memory and CPUs are the resources the image declares it expects, from its labels
+	Memory string
+	CPUs   string
+}
+
+// InspectOCIImage reads the platforms, size and declared resources of an OCI image. Platforms
+// come from its image index for multi-platform images, or from its config otherwise. Index
+// entries for attestations, whose platform is unknown, are left out.
+func InspectOCIImage(ctx context.Context, identifier string) (*OCIImageInfo, error) {
+	ociRef, err := ParseOCIReference(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference: %w", err)
+	}
+	registryBaseURL := ociRef.GetRegistryBaseURL()
+	registryConfig := getRegistryConfig(registryBaseURL, ociRef.Namespace, ociRef.Image)
+	if registryConfig == nil {
+		return nil, fmt.Errorf("unsupported registry: %s", registryBaseURL)
+	}
+
+	manifestRef := ociRef.Tag
+	if ociRef.Digest != "" {
+		manifestRef = ociRef.Digest
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	manifest, err := fetchImageManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifestRef)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &OCIImageInfo{}
+	if len(manifest.Manifests) > 0 {
+		platformDigest := ""
+		for _, entry := range manifest.Manifests {
+			if entry.Platform == nil || entry.Platform.OS == "unknown" || entry.Platform.Architecture == "unknown" {
+				continue
+			}
+			if platform := entry.Platform.String(); !slices.Contains(info.Platforms, platform) {
+				info.Platforms = append(info.Platforms, platform)
+			}
+			if platformDigest == "" {
+				platformDigest = entry.Digest
+			}
+		}
+		if platformDigest == "" {
+			return info, nil
+		}
+		if manifest, err = getSpecificManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, platformDigest); err != nil {
+			return nil, fmt.Errorf("failed to get specific manifest: %w", err)
+		}
+	}
+
+	if manifest.Config.Digest == "" {
+		return nil, fmt.Errorf("manifest missing config digest - invalid or corrupted manifest")
+	}
+	for _, layer := range manifest.Layers {
+		info.Size += layer.Size
+	}
+
+	config, err := getImageConfig(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifest.Config.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image config: %w", err)
+	}
+	if info.Platforms == nil && config.OS != "" && config.Architecture != "" {
+		info.Platforms = []string{config.OCIPlatform.String()}
+	}
+	info.Memory = config.Config.Labels[OCIMemoryLabel]
+	info.CPUs = config.Config.Labels[OCICPUsLabel]
+	return info, nil
+}
+
 // validateRegistryURL validates that the registry base URL is supported
 func validateRegistryURL(registryURL string) error {
 	if registryURL != model.RegistryURLDocker && registryURL != model.RegistryURLGHCR {
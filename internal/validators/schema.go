@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ValidateServerJSONSchema validates raw server.json data against the schema generated
+// from apiv0.ServerJSON. This is the same schema the API enforces on request bodies
+// before any handler runs, so it catches type, pattern and length errors that the Go
+// validators assume have already been rejected.
+func ValidateServerJSONSchema(data []byte) ([]*huma.ErrorDetail, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	registry := huma.NewMapRegistry("#/components/schemas/", huma.DefaultSchemaNamer)
+	schema := registry.Schema(reflect.TypeOf(apiv0.ServerJSON{}), true, "")
+
+	pb := huma.NewPathBuffer([]byte{}, 0)
+	res := &huma.ValidateResult{}
+	huma.Validate(registry, schema, pb, huma.ModeWriteToServer, value, res)
+
+	details := make([]*huma.ErrorDetail, 0, len(res.Errors))
+	for _, err := range res.Errors {
+		if detail, ok := err.(huma.ErrorDetailer); ok {
+			details = append(details, detail.ErrorDetail())
+			continue
+		}
+		details = append(details, &huma.ErrorDetail{Message: err.Error()})
+	}
+
+	return details, nil
+}
@@ -93,6 +93,12 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 		return err
 	}
 
+	// Validate relationships to other servers if provided
+	// Whether the related servers exist is checked during publish
+	if err := validateRelationships(serverJSON.Name, serverJSON.Relationships); err != nil {
+		return err
+	}
+
 	// Validate all packages (basic field validation)
 	// Detailed package validation (including registry checks) is done during publish
 	for _, pkg := range serverJSON.Packages {
@@ -121,6 +127,41 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 	return nil
 }
 
+// maxRelationships bounds how many relationships a server can declare
+const maxRelationships = 20
+
+func validateRelationships(serverName string, relationships []model.Relationship) error {
+	// Skip validation if no relationships are provided (optional field)
+	if len(relationships) == 0 {
+		return nil
+	}
+	if len(relationships) > maxRelationships {
+		return fmt.Errorf("%w: %d declared, at most %d are allowed", ErrTooManyRelationships, len(relationships), maxRelationships)
+	}
+
+	seen := make(map[model.Relationship]bool, len(relationships))
+	for i, rel := range relationships {
+		switch rel.Type {
+		case model.RelationshipSupersedes, model.RelationshipRequires, model.RelationshipBundleOf:
+		default:
+			return fmt.Errorf("%w at index %d: type must be one of %s, %s, %s", ErrInvalidRelationship, i,
+				model.RelationshipSupersedes, model.RelationshipRequires, model.RelationshipBundleOf)
+		}
+		if !serverNameRegex.MatchString(rel.Name) {
+			return fmt.Errorf("%w at index %d: %s is not a valid server name", ErrInvalidRelationship, i, rel.Name)
+		}
+		if rel.Name == serverName {
+			return fmt.Errorf("%w at index %d: a server can't relate to itself", ErrInvalidRelationship, i)
+		}
+		if seen[rel] {
+			return fmt.Errorf("%w at index %d: %s %s is declared twice", ErrInvalidRelationship, i, rel.Type, rel.Name)
+		}
+		seen[rel] = true
+	}
+
+	return nil
+}
+
 func validateRepository(obj *model.Repository) error {
 	// Skip validation for empty repository (optional field)
 	if obj.URL == "" && obj.Source == "" {
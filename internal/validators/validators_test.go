@@ -1849,6 +1849,94 @@ func TestValidateTitle(t *testing.T) {
 			},
 			expectedError: "title cannot be only whitespace",
 		},
+		// Relationship validation tests
+		{
+			name: "Accepts supersedes and requires relationships",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Relationships: []model.Relationship{
+					{Type: model.RelationshipSupersedes, Name: "com.example/old-server"},
+					{Type: model.RelationshipRequires, Name: "com.example/auth-server"},
+				},
+			},
+			expectedError: "",
+		},
+		{
+			name: "Rejects unknown relationship type",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Relationships: []model.Relationship{
+					{Type: "replaces", Name: "com.example/old-server"},
+				},
+			},
+			expectedError: "invalid relationship at index 0: type must be one of supersedes, requires, bundle-of",
+		},
+		{
+			name: "Rejects relationship to itself",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Relationships: []model.Relationship{
+					{Type: model.RelationshipBundleOf, Name: "com.example/test-server"},
+				},
+			},
+			expectedError: "invalid relationship at index 0: a server can't relate to itself",
+		},
+		{
+			name: "Rejects duplicate relationship",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Relationships: []model.Relationship{
+					{Type: model.RelationshipRequires, Name: "com.example/auth-server"},
+					{Type: model.RelationshipRequires, Name: "com.example/auth-server"},
+				},
+			},
+			expectedError: "invalid relationship at index 1: requires com.example/auth-server is declared twice",
+		},
+		{
+			name: "Rejects relationship with invalid server name",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Relationships: []model.Relationship{
+					{Type: model.RelationshipRequires, Name: "auth-server"},
+				},
+			},
+			expectedError: "invalid relationship at index 0: auth-server is not a valid server name",
+		},
 		// Icon validation tests
 		{
 			name: "Accepts valid icon with HTTPS URL",
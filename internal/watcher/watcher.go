@@ -0,0 +1,177 @@
+// Package watcher follows the upstream repositories of servers for new releases and proposes
+// publishing them
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// pageSize is how many servers the watcher lists at a time
+const pageSize = 100
+
+// errNoRelease is returned for repositories without a published release
+var errNoRelease = errors.New("repository has no releases")
+
+// Watcher polls the GitHub repositories servers declare for releases newer than their latest
+// version
+type Watcher struct {
+	registry   service.RegistryService
+	httpClient *http.Client
+	apiURL     string
+	token      string
+}
+
+// NewWatcher creates a watcher that reads releases from the configured GitHub API
+func NewWatcher(registry service.RegistryService, cfg *config.Config) *Watcher {
+	return &Watcher{
+		registry:   registry,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		apiURL:     strings.TrimSuffix(cfg.UpstreamWatchGitHubAPIURL, "/"),
+		token:      cfg.UpstreamWatchGitHubToken,
+	}
+}
+
+// Run checks upstream releases every interval until the context is cancelled, logging failures
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(ctx); err != nil {
+			log.Printf("Failed to poll upstream releases: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the latest version of every server with a GitHub repository once, proposing the
+// releases that are newer. Servers in a subfolder of a repository are skipped, since the
+// releases of a monorepo aren't theirs alone.
+func (w *Watcher) Poll(ctx context.Context) error {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+	releases := map[string]*release{}
+
+	cursor := ""
+	for {
+		servers, nextCursor, err := w.registry.ListServers(ctx, filter, cursor, pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list servers: %w", err)
+		}
+
+		for _, server := range servers {
+			repo, ok := gitHubRepository(server)
+			if !ok {
+				continue
+			}
+
+			latest, seen := releases[repo]
+			if !seen {
+				if latest, err = w.latestRelease(ctx, repo); err != nil && !errors.Is(err, errNoRelease) {
+					log.Printf("Failed to get the latest release of %s: %v", repo, err)
+				}
+				releases[repo] = latest
+			}
+			if latest == nil || !newerVersion(latest.TagName, server.Server.Version) {
+				continue
+			}
+
+			_, err := w.registry.ProposeServerUpdate(ctx, server.Server.Name, service.UpstreamRelease{Tag: latest.TagName, URL: latest.HTMLURL})
+			if err != nil && !errors.Is(err, database.ErrAlreadyExists) {
+				log.Printf("Failed to propose %s %s: %v", server.Server.Name, latest.TagName, err)
+			}
+		}
+
+		if nextCursor == "" {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// release is the part of a GitHub release the watcher uses
+type release struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// latestRelease gets the latest release of a GitHub repository, which leaves out drafts and
+// prereleases
+func (w *Watcher) latestRelease(ctx context.Context, repo string) (*release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.apiURL+"/repos/"+repo+"/releases/latest", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if w.token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.token)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errNoRelease
+	default:
+		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+
+	var latest release
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return nil, fmt.Errorf("failed to decode release: %w", err)
+	}
+	return &latest, nil
+}
+
+// gitHubRepository returns the owner/name of the GitHub repository a server is built from
+func gitHubRepository(server *apiv0.ServerResponse) (string, bool) {
+	if server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDeleted {
+		return "", false
+	}
+	repository := server.Server.Repository
+	if repository.Source != "github" || repository.Subfolder != "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(repository.URL)
+	if err != nil || parsed.Host != "github.com" {
+		return "", false
+	}
+	owner, name, found := strings.Cut(strings.Trim(parsed.Path, "/"), "/")
+	name = strings.TrimSuffix(name, ".git")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	return owner + "/" + name, true
+}
+
+// newerVersion reports whether a release tag is a semantic version above the server's version
+func newerVersion(tag, version string) bool {
+	tagVersion := strings.TrimPrefix(tag, "v")
+	return service.IsSemanticVersion(tagVersion) && service.IsSemanticVersion(version) &&
+		service.CompareVersions(tagVersion, version, time.Time{}, time.Time{}) > 0
+}
@@ -0,0 +1,92 @@
+package watcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/watcher"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPoll_ProposesNewerReleases(t *testing.T) {
+	ctx := context.Background()
+	releases := map[string]string{
+		"/repos/acme/weather/releases/latest": "v1.1.0",
+		"/repos/acme/maps/releases/latest":    "v2.0.0",
+		"/repos/acme/search/releases/latest":  "v0.9.0",
+	}
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tag, ok := releases[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"tag_name": tag, "html_url": "https://github.com" + r.URL.Path})
+	}))
+	defer github.Close()
+
+	cfg := &config.Config{UpstreamWatchGitHubAPIURL: github.URL}
+	registry := service.NewRegistryService(database.NewTestDB(t), cfg)
+	for _, server := range []struct{ name, repo string }{
+		{"com.acme/weather", "weather"},
+		{"com.acme/maps", "maps"},
+		{"com.acme/search", "search"},
+		{"com.acme/unreleased", "unreleased"},
+	} {
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "Test server",
+			Version:     "1.0.0",
+			Repository:  model.Repository{URL: "https://github.com/acme/" + server.repo, Source: "github"},
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNPM, Identifier: "@acme/" + server.repo, Version: "1.0.0", Transport: model.Transport{Type: "stdio"}},
+				{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/acme/" + server.repo + ":v1.0.0", Transport: model.Transport{Type: "stdio"}},
+			},
+		})
+		require.NoError(t, err)
+	}
+	_, err := registry.SetUpdatePolicy(ctx, "com.acme/maps", &apiv0.UpdatePolicy{AutoPublish: true})
+	require.NoError(t, err)
+
+	w := watcher.NewWatcher(registry, cfg)
+	require.NoError(t, w.Poll(ctx))
+
+	// Releases are proposed with the packages versioned along with the server
+	proposals, err := registry.ListUpdateProposals(ctx, "com.acme/weather", "", 10)
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	proposal := proposals[0]
+	assert.Equal(t, service.UpdateProposalPending, proposal.Status)
+	assert.Equal(t, "1.1.0", proposal.Version)
+	assert.Equal(t, "1.1.0", proposal.Server.Version)
+	assert.Equal(t, "1.1.0", proposal.Server.Packages[0].Version)
+	assert.Equal(t, "ghcr.io/acme/weather:v1.1.0", proposal.Server.Packages[1].Identifier)
+
+	// The policy of maps publishes its release straight away
+	latest, err := registry.GetServerByName(ctx, "com.acme/maps")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", latest.Server.Version)
+
+	queue, err := registry.ListUpdateProposals(ctx, "", service.UpdateProposalPending, 10)
+	require.NoError(t, err)
+	assert.Len(t, queue, 1, "older releases and repositories without releases aren't proposed")
+
+	// Polling again doesn't propose a dismissed release again
+	require.NoError(t, registry.DismissUpdateProposal(ctx, "com.acme/weather", proposal.ID))
+	require.NoError(t, w.Poll(ctx))
+	proposals, err = registry.ListUpdateProposals(ctx, "com.acme/weather", "", 10)
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	assert.Equal(t, service.UpdateProposalDismissed, proposals[0].Status)
+}
@@ -0,0 +1,100 @@
+// Package webhooks delivers registry events to the webhooks consumers register, retrying failed
+// deliveries with backoff
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+)
+
+// DeliveryHeader carries the ID of a delivery, which stays the same across its attempts so that
+// receivers can drop duplicates
+const DeliveryHeader = "X-MCP-Registry-Delivery"
+
+// DeliveryQueue hands out the deliveries that are due and records how their attempts went
+type DeliveryQueue interface {
+	ClaimWebhookDelivery(ctx context.Context) (*database.WebhookDeliveryAttempt, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, attempt *database.WebhookDeliveryAttempt, responseStatus int, deliveryErr error) error
+}
+
+// Dispatcher posts due deliveries to their webhooks, one at a time
+type Dispatcher struct {
+	queue      DeliveryQueue
+	httpClient *http.Client
+}
+
+// NewDispatcher creates a dispatcher for the deliveries of a queue. Webhooks are only posted to
+// public addresses over https, redirects included, since users choose their URLs.
+func NewDispatcher(queue DeliveryQueue) *Dispatcher {
+	return NewDispatcherWithClient(queue, safehttp.NewClient(10*time.Second, "https"))
+}
+
+// NewDispatcherWithClient creates a dispatcher that posts deliveries with the given client
+func NewDispatcherWithClient(queue DeliveryQueue, httpClient *http.Client) *Dispatcher {
+	return &Dispatcher{
+		queue:      queue,
+		httpClient: httpClient,
+	}
+}
+
+// Poll attempts every delivery that is due. A webhook that can't be reached or doesn't answer
+// with a 2xx status fails the attempt, which the queue schedules to be retried.
+func (d *Dispatcher) Poll(ctx context.Context) error {
+	for ctx.Err() == nil {
+		attempt, err := d.queue.ClaimWebhookDelivery(ctx)
+		if errors.Is(err, database.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to claim a delivery: %w", err)
+		}
+
+		status, err := d.send(ctx, attempt)
+		if err != nil {
+			log.Printf("Failed attempt %d to deliver %s to webhook %s: %v", attempt.Attempt, attempt.Event, attempt.WebhookID, err)
+		}
+
+		// Record the outcome even when ctx was cancelled mid-attempt, so the delivery isn't left
+		// leased until it goes stale
+		if err := d.queue.RecordWebhookDeliveryAttempt(context.WithoutCancel(ctx), attempt, status, err); err != nil {
+			return fmt.Errorf("failed to record delivery %s: %w", attempt.ID, err)
+		}
+	}
+	return ctx.Err()
+}
+
+// send posts a delivery's payload to its webhook, signed with the webhook's secret, returning the
+// status the webhook answered with
+func (d *Dispatcher) send(ctx context.Context, attempt *database.WebhookDeliveryAttempt) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, attempt.URL, bytes.NewReader(attempt.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mcp-registry")
+	req.Header.Set(notifications.EventHeader, attempt.Event)
+	req.Header.Set(notifications.SignatureHeader, notifications.Sign(attempt.Secret, attempt.Payload))
+	req.Header.Set(DeliveryHeader, attempt.ID)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
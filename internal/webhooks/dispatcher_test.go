@@ -0,0 +1,104 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/notifications"
+	"github.com/modelcontextprotocol/registry/internal/safehttp"
+	"github.com/modelcontextprotocol/registry/internal/webhooks"
+)
+
+type recordedAttempt struct {
+	id             string
+	responseStatus int
+	err            error
+}
+
+type fakeQueue struct {
+	due      []*database.WebhookDeliveryAttempt
+	recorded []recordedAttempt
+}
+
+func (q *fakeQueue) ClaimWebhookDelivery(_ context.Context) (*database.WebhookDeliveryAttempt, error) {
+	if len(q.due) == 0 {
+		return nil, database.ErrNotFound
+	}
+	attempt := q.due[0]
+	q.due = q.due[1:]
+	return attempt, nil
+}
+
+func (q *fakeQueue) RecordWebhookDeliveryAttempt(_ context.Context, attempt *database.WebhookDeliveryAttempt, responseStatus int, deliveryErr error) error {
+	q.recorded = append(q.recorded, recordedAttempt{id: attempt.ID, responseStatus: responseStatus, err: deliveryErr})
+	return nil
+}
+
+func TestPoll_SignsDeliveriesAndRecordsOutcomes(t *testing.T) {
+	payload := []byte(`{"type":"server.published"}`)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, payload, body)
+		assert.Equal(t, notifications.Sign("whsec_test", body), r.Header.Get(notifications.SignatureHeader))
+		assert.Equal(t, "server.published", r.Header.Get(notifications.EventHeader))
+
+		if r.Header.Get(webhooks.DeliveryHeader) == "broken" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(webhook.Close)
+
+	queue := &fakeQueue{due: []*database.WebhookDeliveryAttempt{
+		{ID: "ok", Event: "server.published", Payload: payload, Attempt: 1, URL: webhook.URL, Secret: "whsec_test"},
+		{ID: "broken", Event: "server.published", Payload: payload, Attempt: 3, URL: webhook.URL, Secret: "whsec_test"},
+	}}
+
+	require.NoError(t, webhooks.NewDispatcherWithClient(queue, webhook.Client()).Poll(context.Background()))
+
+	require.Len(t, queue.recorded, 2)
+	assert.Equal(t, "ok", queue.recorded[0].id)
+	assert.Equal(t, http.StatusOK, queue.recorded[0].responseStatus)
+	assert.NoError(t, queue.recorded[0].err)
+	assert.Equal(t, "broken", queue.recorded[1].id)
+	assert.Equal(t, http.StatusServiceUnavailable, queue.recorded[1].responseStatus)
+	assert.ErrorContains(t, queue.recorded[1].err, "status 503")
+}
+
+func TestPoll_RecordsUnreachableWebhooks(t *testing.T) {
+	webhook := httptest.NewServer(http.NotFoundHandler())
+	webhook.Close()
+
+	queue := &fakeQueue{due: []*database.WebhookDeliveryAttempt{
+		{ID: "1", Event: "server.deleted", Payload: []byte(`{}`), Attempt: 1, URL: webhook.URL, Secret: "whsec_test"},
+	}}
+
+	require.NoError(t, webhooks.NewDispatcherWithClient(queue, webhook.Client()).Poll(context.Background()))
+
+	require.Len(t, queue.recorded, 1)
+	assert.Zero(t, queue.recorded[0].responseStatus)
+	assert.Error(t, queue.recorded[0].err)
+}
+
+func TestPoll_RefusesPrivateAddresses(t *testing.T) {
+	var requests int
+	webhook := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { requests++ }))
+	t.Cleanup(webhook.Close)
+
+	queue := &fakeQueue{due: []*database.WebhookDeliveryAttempt{
+		{ID: "1", Event: "server.published", Payload: []byte(`{}`), Attempt: 1, URL: webhook.URL, Secret: "whsec_test"},
+	}}
+
+	require.NoError(t, webhooks.NewDispatcher(queue).Poll(context.Background()))
+
+	require.Len(t, queue.recorded, 1)
+	assert.ErrorIs(t, queue.recorded[0].err, safehttp.ErrForbiddenAddress)
+	assert.Zero(t, requests)
+}
@@ -1,16 +1,92 @@
 package v0
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 type RegistryExtensions struct {
-	Status      model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
-	PublishedAt time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
-	UpdatedAt   time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
-	IsLatest    bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Status          model.Status       `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
+	PublishedAt     time.Time          `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
+	UpdatedAt       time.Time          `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
+	IsLatest        bool               `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	YankedAt        *time.Time         `json:"yankedAt,omitempty" format:"date-time" doc:"When the version was yanked. Yanked versions are left out of listings and never latest, but can still be fetched by exact version."`
+	Verified        bool               `json:"verified,omitempty" doc:"Whether the server's namespace is verified: its owner proved control of the domain or GitHub organization it is named after"`
+	Advisory        string             `json:"advisory,omitempty" enum:"low,moderate,high,critical" doc:"Highest severity of the security advisories affecting this version, if any"`
+	Vulnerabilities *VulnerabilityScan `json:"vulnerabilities,omitempty" doc:"Known vulnerabilities in the version's OCI images, summed across images, from the registry's image scanner. Left out for versions that haven't been scanned"`
+	Images          []OCIImage         `json:"images,omitempty" doc:"What the registry found out about the version's OCI images while validating them at publish time"`
+	Capabilities    *Capabilities      `json:"capabilities,omitempty" doc:"Tools, resources and prompts the version listed when the registry ran it in a sandbox. Left out for versions that haven't been extracted"`
+	StatusDetails   *StatusDetails     `json:"statusDetails,omitempty" doc:"Why the version was deprecated or deleted and what replaces it, when its publisher said so"`
+	Quarantine      *Quarantine        `json:"quarantine,omitempty" doc:"Why and when an admin quarantined the server. Quarantined servers are left out of listings until they are reinstated or deleted"`
+	Usage           *Usage             `json:"usage,omitempty" doc:"How much the server was used over the last 30 days, not counting today. Left out for servers that weren't used"`
+	Revision        int                `json:"revision,omitempty" doc:"Revision of the version, increased by every edit"`
+}
+
+// StatusDetails explains a version's latest status change
+type StatusDetails struct {
+	Reason     string    `json:"reason,omitempty" doc:"Why the status changed" example:"Superseded by the v2 server, which supports streamable HTTP"`
+	ReplacedBy string    `json:"replacedBy,omitempty" doc:"Name of the server clients should move to" example:"io.github.user/weather-v2"`
+	ChangedAt  time.Time `json:"changedAt" format:"date-time" doc:"When the status changed"`
+}
+
+// ServerStatusUpdate is a change of the lifecycle status of a server or server version
+type ServerStatusUpdate struct {
+	Status     model.Status `json:"status" enum:"active,deprecated,deleted" doc:"New status. Deleted versions can't be restored."`
+	Reason     string       `json:"reason,omitempty" maxLength:"1000" doc:"Why the status changes, shown to clients alongside it"`
+	ReplacedBy string       `json:"replacedBy,omitempty" maxLength:"200" doc:"Name of a published server clients should move to" example:"io.github.user/weather-v2"`
+}
+
+// Capabilities is the inventory of what a server version offers, as listed by the server itself
+// when the registry ran it in a sandbox, rather than as its publisher describes it
+type Capabilities struct {
+	Status      string               `json:"status" enum:"pending,running,succeeded,failed" doc:"State of the latest extraction. The inventory of an earlier extraction is kept until a new one succeeds"`
+	RequestedAt time.Time            `json:"requestedAt" format:"date-time" doc:"When the latest extraction was requested"`
+	ExtractedAt *time.Time           `json:"extractedAt,omitempty" format:"date-time" doc:"When the inventory was extracted"`
+	Error       string               `json:"error,omitempty" doc:"Why the latest extraction failed"`
+	Tools       []CapabilityTool     `json:"tools,omitempty" doc:"Tools the server listed"`
+	Resources   []CapabilityResource `json:"resources,omitempty" doc:"Resources the server listed"`
+	Prompts     []CapabilityPrompt   `json:"prompts,omitempty" doc:"Prompts the server listed"`
+}
+
+// CapabilityTool is a tool listed by a server's tools/list
+type CapabilityTool struct {
+	Name        string `json:"name" doc:"Tool name" example:"get_forecast"`
+	Title       string `json:"title,omitempty" doc:"Human-readable tool title"`
+	Description string `json:"description,omitempty" doc:"Tool description"`
+}
+
+// CapabilityResource is a resource listed by a server's resources/list
+type CapabilityResource struct {
+	URI         string `json:"uri" doc:"Resource URI" example:"weather://stations"`
+	Name        string `json:"name" doc:"Resource name"`
+	Description string `json:"description,omitempty" doc:"Resource description"`
+	MimeType    string `json:"mimeType,omitempty" doc:"MIME type of the resource"`
+}
+
+// CapabilityPrompt is a prompt listed by a server's prompts/list
+type CapabilityPrompt struct {
+	Name        string `json:"name" doc:"Prompt name" example:"summarize_forecast"`
+	Description string `json:"description,omitempty" doc:"Prompt description"`
+}
+
+// OCIImage is what the registry found out about an OCI package's image when it was published
+type OCIImage struct {
+	Identifier string   `json:"identifier" doc:"Identifier of the OCI package" example:"docker.io/example/weather:1.0.0"`
+	Platforms  []string `json:"platforms" doc:"Platforms the image is built for, as os/architecture with /variant when the image declares one"`
+	Size       int64    `json:"size,omitempty" doc:"Compressed size of the image's layers in bytes, which is what installing it downloads. For multi-platform images, the size of the first platform" example:"52428800"`
+	Memory     string   `json:"memory,omitempty" doc:"Memory the image declares it expects, from its io.modelcontextprotocol.server.memory label" example:"512Mi"`
+	CPUs       string   `json:"cpus,omitempty" doc:"CPUs the image declares it expects, from its io.modelcontextprotocol.server.cpus label" example:"0.5"`
+}
+
+// VulnerabilityScan summarizes the known vulnerabilities found by scanning OCI images
+type VulnerabilityScan struct {
+	Critical  int       `json:"critical" minimum:"0" doc:"Number of critical vulnerabilities"`
+	High      int       `json:"high" minimum:"0" doc:"Number of high severity vulnerabilities"`
+	Moderate  int       `json:"moderate" minimum:"0" doc:"Number of moderate severity vulnerabilities"`
+	Low       int       `json:"low" minimum:"0" doc:"Number of low severity vulnerabilities"`
+	ScannedAt time.Time `json:"scannedAt" format:"date-time" doc:"When the images were scanned; the oldest scan when a version has several images"`
 }
 
 type ResponseMeta struct {
@@ -27,25 +103,591 @@ type ServerListResponse struct {
 	Metadata Metadata         `json:"metadata" doc:"Pagination metadata"`
 }
 
+// ServerExport is a snapshot of every server version in the registry, for CDNs and offline mirrors
+type ServerExport struct {
+	GeneratedAt time.Time        `json:"generatedAt" format:"date-time" doc:"When the snapshot was taken"`
+	Servers     []ServerResponse `json:"servers" doc:"Every version of every server that isn't quarantined, including yanked and deleted versions"`
+	Metadata    Metadata         `json:"metadata" doc:"Number of versions"`
+}
+
 type ServerMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty" doc:"Publisher-provided metadata for downstream registries"`
 }
 
 type ServerJSON struct {
-	Schema      string            `json:"$schema" required:"true" minLength:"1" format:"uri" doc:"JSON Schema URI for this server.json format" example:"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json"`
-	Name        string            `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
-	Description string            `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
-	Title       string            `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
-	Repository  model.Repository  `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
-	Version     string            `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
-	WebsiteURL  string            `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
-	Icons       []model.Icon      `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
-	Packages    []model.Package   `json:"packages,omitempty" doc:"Array of package configurations"`
-	Remotes     []model.Transport `json:"remotes,omitempty" doc:"Array of remote configurations"`
-	Meta        *ServerMeta       `json:"_meta,omitempty" doc:"Extension metadata using reverse DNS namespacing for vendor-specific data"`
+	Schema        string               `json:"$schema" required:"true" minLength:"1" format:"uri" doc:"JSON Schema URI for this server.json format" example:"https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json"`
+	Name          string               `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
+	Description   string               `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
+	Title         string               `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
+	Repository    model.Repository     `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
+	Version       string               `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
+	WebsiteURL    string               `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
+	Icons         []model.Icon         `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
+	Packages      []model.Package      `json:"packages,omitempty" doc:"Array of package configurations"`
+	Remotes       []model.Transport    `json:"remotes,omitempty" doc:"Array of remote configurations"`
+	Relationships []model.Relationship `json:"relationships,omitempty" doc:"Optional relationships to other servers in the registry, so clients can point users to replacements and companions."`
+	Meta          *ServerMeta          `json:"_meta,omitempty" doc:"Extension metadata using reverse DNS namespacing for vendor-specific data"`
+}
+
+// ServerSearchResult is the latest version of a server matching a full-text search
+type ServerSearchResult struct {
+	Server    ServerJSON   `json:"server" doc:"Server configuration and metadata"`
+	Meta      ResponseMeta `json:"_meta" doc:"Registry-managed metadata"`
+	Rank      float64      `json:"rank" minimum:"0" maximum:"1" doc:"Relevance of the server to the query, from 0 to 1. Matches in names and titles count most, then descriptions and tags, then tools" example:"0.42"`
+	Highlight string       `json:"highlight" doc:"The server's description, HTML-escaped, with the words matching the query wrapped in <mark> tags" example:"Weather <mark>forecasts</mark> via OpenWeatherMap"`
+}
+
+// ServerSearchResponse is a page of full-text search results, most relevant first
+type ServerSearchResponse struct {
+	Results  []ServerSearchResult `json:"results" doc:"Matching servers, most relevant first"`
+	Metadata Metadata             `json:"metadata" doc:"Pagination metadata"`
+}
+
+// ServerBatchItem names a server version to get in a batch
+type ServerBatchItem struct {
+	Name    string `json:"name" minLength:"1" maxLength:"200" doc:"Server name" example:"io.github.example/weather"`
+	Version string `json:"version,omitempty" required:"false" doc:"Exact version to get, or 'latest' (the default) for the latest version" example:"1.0.0"`
+}
+
+// ServerBatchResult is the outcome of getting one server version in a batch
+type ServerBatchResult struct {
+	Name     string          `json:"name" doc:"Server name, as requested"`
+	Version  string          `json:"version" doc:"Version, as requested" example:"latest"`
+	Status   int             `json:"status" enum:"200,301,404" doc:"200 if the version was found, 301 if the server moved to another name, or 404 if it wasn't found"`
+	Location string          `json:"location,omitempty" doc:"For status 301, the name the server can now be found under" example:"io.github.example/forecast"`
+	Server   *ServerResponse `json:"server,omitempty" doc:"For status 200, the server version"`
+}
+
+// ServerBatchResponse is the result of a batch fetch, with one result per requested item
+type ServerBatchResponse struct {
+	Results  []ServerBatchResult `json:"results" doc:"One result per requested item, in request order"`
+	Metadata Metadata            `json:"metadata" doc:"Result metadata"`
 }
 
 type Metadata struct {
 	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results. Use this exact value in the cursor query parameter of your next request."`
 	Count      int    `json:"count" doc:"Number of items in current page"`
+	Total      *int   `json:"total,omitempty" doc:"Number of items matching the query across all pages. Only returned when count=true is requested." example:"1234"`
+	Estimated  bool   `json:"estimated,omitempty" doc:"Whether total is an estimate, which it is when more than 10000 items match"`
+	Snapshot   string `json:"snapshot,omitempty" doc:"Snapshot the results were read from. Pass it as the snapshot query parameter along with the cursor to keep paging through the same registry state."`
+}
+
+// Principal identifies who a registry token was issued to: the authentication method and the
+// subject within it (a GitHub login, a domain, an OIDC subject)
+type Principal struct {
+	AuthMethod string `json:"authMethod" enum:"github-at,github-oidc,oidc,dns,http" doc:"Authentication method the principal signs in with" example:"github-at"`
+	Subject    string `json:"subject" minLength:"1" maxLength:"255" doc:"Identity within that method, e.g. a GitHub username or a domain" example:"octocat"`
+}
+
+type NamespaceResponse struct {
+	Namespace     string                 `json:"namespace" doc:"Namespace, the part of server names before the slash" example:"io.github.octocat"`
+	Owner         *Principal             `json:"owner,omitempty" doc:"Recorded owner, set once the namespace has been transferred. Without one, whoever the namespace's authentication method grants it to owns it."`
+	Organization  string                 `json:"organization,omitempty" doc:"Organization the namespace belongs to. Its members' roles then decide who may publish, edit and delete." example:"acme"`
+	Delegates     []Principal            `json:"delegates" doc:"Principals the owner has delegated publish rights to"`
+	Verification  *NamespaceVerification `json:"verification,omitempty" doc:"How the namespace was verified, if it was"`
+	Claimants     []Principal            `json:"claimants" doc:"Principals with a verified, unexpired claim on the namespace, who may publish to it like its owner"`
+	ClaimRequired bool                   `json:"claimRequired,omitempty" doc:"Whether publishing requires a verified claim, since the namespace has no recorded owner or organization and the registry requires claims"`
+}
+
+type NamespaceVerification struct {
+	Namespace  string    `json:"namespace" doc:"Verified namespace" example:"com.example"`
+	Method     string    `json:"method" enum:"domain,github-org" doc:"What was proven: control of the domain, or membership of the GitHub organization, the namespace is named after"`
+	VerifiedBy Principal `json:"verifiedBy" doc:"Principal whose token proved it"`
+	VerifiedAt time.Time `json:"verifiedAt" format:"date-time"`
+}
+
+type NamespaceClaim struct {
+	ID          string          `json:"id" doc:"Claim ID"`
+	Namespace   string          `json:"namespace" doc:"Claimed namespace" example:"com.example"`
+	Method      string          `json:"method" enum:"dns,http,github" doc:"How control of the namespace is proven: a DNS TXT record or HTTPS well-known file with the challenge, or a GitHub identity for io.github namespaces"`
+	Claimant    Principal       `json:"claimant" doc:"Principal the claim grants the namespace to"`
+	Status      string          `json:"status" enum:"pending,verified,expired,failed" doc:"Whether the proof is waiting to be found, was found, was taken down after it was found, or was never found"`
+	Challenge   *ClaimChallenge `json:"challenge,omitempty" doc:"What to publish to prove control of a domain namespace"`
+	LastError   string          `json:"lastError,omitempty" doc:"Why the latest check failed"`
+	CreatedAt   time.Time       `json:"createdAt" format:"date-time"`
+	VerifiedAt  *time.Time      `json:"verifiedAt,omitempty" format:"date-time" doc:"When the proof was last found"`
+	ExpiresAt   *time.Time      `json:"expiresAt,omitempty" format:"date-time" doc:"When the claim stops granting the namespace unless the proof is found again"`
+	NextCheckAt *time.Time      `json:"nextCheckAt,omitempty" format:"date-time" doc:"When the registry looks for the proof next"`
+}
+
+// NamespaceClaimRequest is the body of a request to claim a namespace
+type NamespaceClaimRequest struct {
+	Method string `json:"method" enum:"dns,http,github" doc:"How to prove control of the namespace: dns or http for a domain namespace, github for an io.github namespace"`
+}
+
+// ClaimChallenge is where a domain namespace's claimant publishes the challenge token
+type ClaimChallenge struct {
+	Token       string `json:"token" doc:"Challenge token"`
+	RecordName  string `json:"recordName,omitempty" doc:"Name of the TXT record to create, for DNS claims" example:"_mcp-registry-challenge.example.com"`
+	RecordValue string `json:"recordValue,omitempty" doc:"Value of the TXT record, for DNS claims" example:"mcp-registry-challenge=3f9a…"`
+	URL         string `json:"url,omitempty" format:"uri" doc:"URL to serve the token at as plain text, for HTTP claims" example:"https://example.com/.well-known/mcp-registry-challenge"`
+}
+
+type NamespaceClaimListResponse struct {
+	Claims   []NamespaceClaim `json:"claims" doc:"Namespace claims, most recent first"`
+	Metadata Metadata         `json:"metadata" doc:"Pagination metadata"`
+}
+
+type NamespaceRequest struct {
+	ID         string     `json:"id" doc:"Request ID"`
+	Kind       string     `json:"kind" enum:"transfer,delegation" doc:"Whether the request transfers ownership or delegates publish rights"`
+	Namespace  string     `json:"namespace" doc:"Namespace the request is about" example:"io.github.octocat"`
+	From       Principal  `json:"from" doc:"Owner who made the request"`
+	To         Principal  `json:"to" doc:"Principal who must accept the request"`
+	Status     string     `json:"status" enum:"pending,accepted,declined,cancelled" doc:"Request status"`
+	CreatedAt  time.Time  `json:"createdAt" format:"date-time"`
+	ExpiresAt  time.Time  `json:"expiresAt" format:"date-time" doc:"Pending requests can no longer be accepted after this time"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty" format:"date-time"`
+}
+
+type NamespaceRequestListResponse struct {
+	Requests []NamespaceRequest `json:"requests" doc:"Pending requests made by or addressed to the caller"`
+}
+
+type NamespaceAuditEntry struct {
+	ID           int64      `json:"id"`
+	Namespace    string     `json:"namespace"`
+	Action       string     `json:"action" doc:"What happened, e.g. transfer-requested or delegation-revoked" example:"transfer-accepted"`
+	Actor        Principal  `json:"actor" doc:"Who did it"`
+	Target       *Principal `json:"target,omitempty" doc:"Who it was done to, if anyone"`
+	RequestID    string     `json:"requestId,omitempty" doc:"Transfer or delegation request the entry belongs to"`
+	Organization string     `json:"organization,omitempty" doc:"Organization the namespace was added to or removed from"`
+	CreatedAt    time.Time  `json:"createdAt" format:"date-time"`
+}
+
+type NamespaceAuditResponse struct {
+	Entries []NamespaceAuditEntry `json:"entries" doc:"Audit entries, newest first"`
+}
+
+type OrganizationMember struct {
+	Principal
+	Role string `json:"role" enum:"owner,publisher,viewer" doc:"Owners manage the organization and may delete servers, publishers may publish and edit servers, viewers may only see the organization"`
+}
+
+type Organization struct {
+	Name       string               `json:"name" doc:"Organization name" example:"acme"`
+	CreatedAt  time.Time            `json:"createdAt" format:"date-time"`
+	Members    []OrganizationMember `json:"members" doc:"Members and their roles"`
+	Namespaces []string             `json:"namespaces" doc:"Namespaces owned by the organization"`
+}
+
+type TokenPermission struct {
+	Action   string `json:"action" enum:"publish,edit" doc:"Action the token may take"`
+	Resource string `json:"resource" doc:"Server names the action applies to, with an optional trailing wildcard" example:"io.github.octocat/*"`
+}
+
+type PersonalAccessToken struct {
+	ID          string            `json:"id" doc:"Token ID"`
+	Name        string            `json:"name" doc:"Name given to the token when it was created" example:"release workflow"`
+	Prefix      string            `json:"prefix" doc:"First characters of the token, to help recognize it" example:"mcp_pat_3f2a"`
+	Owner       Principal         `json:"owner" doc:"Principal the token acts as"`
+	Permissions []TokenPermission `json:"permissions" doc:"What the token may do, a subset of what its owner could do when creating it"`
+	CreatedAt   time.Time         `json:"createdAt" format:"date-time"`
+	ExpiresAt   time.Time         `json:"expiresAt" format:"date-time"`
+	LastUsedAt  *time.Time        `json:"lastUsedAt,omitempty" format:"date-time" doc:"When the token was last exchanged for a registry JWT"`
+	RevokedAt   *time.Time        `json:"revokedAt,omitempty" format:"date-time"`
+	RotatedAt   *time.Time        `json:"rotatedAt,omitempty" format:"date-time" doc:"When the token's secret was last replaced"`
+}
+
+type CreatedPersonalAccessToken struct {
+	PersonalAccessToken
+	Token string `json:"token" doc:"The token itself. It is only ever returned here, so store it safely."`
+}
+
+type PersonalAccessTokenListResponse struct {
+	Tokens []PersonalAccessToken `json:"tokens" doc:"The caller's tokens, newest first"`
+}
+
+type NotificationSubscription struct {
+	ID        string    `json:"id" doc:"Subscription ID"`
+	Namespace string    `json:"namespace" doc:"Namespace the subscription is about" example:"io.github.octocat"`
+	Owner     Principal `json:"owner" doc:"Owner who set up the subscription"`
+	Channel   string    `json:"channel" enum:"email,webhook" doc:"How notifications are delivered"`
+	Target    string    `json:"target" doc:"Email address or HTTPS webhook URL" example:"https://example.com/hooks/mcp-registry"`
+	Events    []string  `json:"events" doc:"Events to be notified of: version-published, revalidation-failed, server-reported, ownership-claim-attempted, update-available, server-moderated"`
+	CreatedAt time.Time `json:"createdAt" format:"date-time"`
+	// Secret signs webhook deliveries. It is never returned by the API.
+	Secret string `json:"-"`
+}
+
+type NotificationSubscriptionListResponse struct {
+	Subscriptions []NotificationSubscription `json:"subscriptions" doc:"The caller's subscriptions for the namespace"`
+}
+
+type Webhook struct {
+	ID                  string     `json:"id" doc:"Webhook ID"`
+	Owner               Principal  `json:"owner" doc:"Principal who registered the webhook"`
+	URL                 string     `json:"url" doc:"HTTPS URL events are posted to" example:"https://example.com/hooks/mcp-registry"`
+	Events              []string   `json:"events" doc:"Events posted to the URL: server.published, server.updated, server.deprecated, server.deleted"`
+	CreatedAt           time.Time  `json:"createdAt" format:"date-time"`
+	ConsecutiveFailures int        `json:"consecutiveFailures" doc:"Delivery attempts that failed since the last one that succeeded"`
+	LastDeliveryAt      *time.Time `json:"lastDeliveryAt,omitempty" format:"date-time" doc:"When a delivery was last attempted"`
+	LastError           string     `json:"lastError,omitempty" doc:"Why the last failed delivery attempt failed"`
+	// Secret signs deliveries. It is only returned when the webhook is registered.
+	Secret string `json:"-"`
+}
+
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret" doc:"Secret deliveries are signed with, as an HMAC-SHA256 in the X-MCP-Registry-Signature header. It is only ever returned here, so store it safely."`
+}
+
+type WebhookListResponse struct {
+	Webhooks []Webhook `json:"webhooks" doc:"Webhooks, oldest first"`
+}
+
+// WebhookEvent is the JSON body of webhook deliveries
+type WebhookEvent struct {
+	Type       string         `json:"type" doc:"Event type" example:"server.published"`
+	OccurredAt time.Time      `json:"occurredAt" format:"date-time"`
+	Server     ServerResponse `json:"server" doc:"Server version the event is about, as it was after the event"`
+}
+
+type WebhookDelivery struct {
+	ID             string     `json:"id" doc:"Delivery ID, also sent in the X-MCP-Registry-Delivery header"`
+	WebhookID      string     `json:"webhookId" doc:"Webhook the event is delivered to"`
+	Event          string     `json:"event" doc:"Event type" example:"server.published"`
+	Status         string     `json:"status" enum:"pending,succeeded,failed" doc:"Whether the event was delivered, is waiting for its next attempt, or ran out of attempts"`
+	Attempts       int        `json:"attempts" doc:"Delivery attempts made so far"`
+	ResponseStatus int        `json:"responseStatus,omitempty" doc:"HTTP status the webhook answered the last attempt with"`
+	Error          string     `json:"error,omitempty" doc:"Why the last attempt failed"`
+	CreatedAt      time.Time  `json:"createdAt" format:"date-time"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty" format:"date-time" doc:"When a pending delivery is next attempted"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty" format:"date-time"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries" doc:"Deliveries, most recent first"`
+}
+
+// Job is an asynchronous registry task in the job queue
+type Job struct {
+	ID          string          `json:"id" doc:"Job ID"`
+	Kind        string          `json:"kind" doc:"What the job does" example:"webhooks.deliver"`
+	Payload     json.RawMessage `json:"payload,omitempty" doc:"Input of the job"`
+	DedupeKey   string          `json:"dedupeKey,omitempty" doc:"Key that keeps the job from being enqueued more than once"`
+	Status      string          `json:"status" enum:"queued,running,succeeded,dead" doc:"Whether the job waits for its next attempt, runs, succeeded, or ran out of attempts"`
+	Attempts    int             `json:"attempts" doc:"Attempts made so far, including a running one"`
+	MaxAttempts int             `json:"maxAttempts" doc:"Attempts the job gets before it is dead"`
+	RunAt       time.Time       `json:"runAt" format:"date-time" doc:"When a queued job is due, or when the lease of a running one runs out"`
+	LastError   string          `json:"lastError,omitempty" doc:"Why the last attempt failed"`
+	CreatedAt   time.Time       `json:"createdAt" format:"date-time"`
+	FinishedAt  *time.Time      `json:"finishedAt,omitempty" format:"date-time" doc:"When the job succeeded or died"`
+}
+
+type JobListResponse struct {
+	Jobs []Job `json:"jobs" doc:"Jobs, most recently created first"`
+}
+
+type ServerRedirect struct {
+	From      string    `json:"from" doc:"Name that redirects: a previous name of the server or an alias" example:"com.docker.mcp/weather"`
+	To        string    `json:"to" doc:"Current server name" example:"io.github.octocat/weather"`
+	Reason    string    `json:"reason" enum:"claim,rename,alias" doc:"Why the name redirects: the server was claimed or renamed away from it, or it was added as an alias"`
+	CreatedAt time.Time `json:"createdAt" format:"date-time"`
+}
+
+type ServerRedirectListResponse struct {
+	Redirects []ServerRedirect `json:"redirects" doc:"Names that redirect to the server, oldest first"`
+}
+
+// SearchRanking tunes how search results are ordered. A search matches the fields given weight,
+// and results are ordered by the sum of the weights of the fields they match plus the boosts.
+type SearchRanking struct {
+	NameWeight            float64    `json:"nameWeight" minimum:"0" doc:"Score for matching the server name. Fields with a weight of 0 aren't searched." example:"3"`
+	TitleWeight           float64    `json:"titleWeight" minimum:"0" doc:"Score for matching the title" example:"2"`
+	DescriptionWeight     float64    `json:"descriptionWeight" minimum:"0" doc:"Score for matching the description" example:"1"`
+	FreshnessBoost        float64    `json:"freshnessBoost" minimum:"0" doc:"Score added for a version published just now, halving every freshnessHalfLifeDays" example:"0.5"`
+	FreshnessHalfLifeDays float64    `json:"freshnessHalfLifeDays" minimum:"1" doc:"Days for the freshness boost to halve" example:"90"`
+	PopularityBoost       float64    `json:"popularityBoost" minimum:"0" doc:"Score added for a million publisher-reported pulls, on a log scale" example:"1"`
+	VerifiedBoost         float64    `json:"verifiedBoost" minimum:"0" doc:"Score added for servers in verified namespaces" example:"0.5"`
+	UpdatedAt             *time.Time `json:"updatedAt,omitempty" readOnly:"true" doc:"When an admin last changed the ranking. Unset while the deployment's configured ranking applies."`
+}
+
+type ServerRelationshipEdge struct {
+	From string `json:"from" doc:"Server that declares the relationship" example:"io.github.octocat/weather"`
+	Type string `json:"type" enum:"supersedes,requires,bundle-of" doc:"Declared relationship" example:"supersedes"`
+	To   string `json:"to" doc:"Server the relationship points at" example:"io.github.octocat/weather-legacy"`
+}
+
+type ServerRelationshipGraph struct {
+	Name  string                   `json:"name" doc:"Server the graph is centered on" example:"io.github.octocat/weather"`
+	Edges []ServerRelationshipEdge `json:"edges" doc:"Relationships the latest version of the server declares, followed by those the latest versions of other servers declare to it"`
+}
+
+type ServerChannel struct {
+	Channel   string    `json:"channel" enum:"latest,stable,beta" doc:"Release channel. latest always follows the latest version and can't be set." example:"stable"`
+	Version   string    `json:"version" doc:"Version the channel resolves to" example:"1.0.2"`
+	UpdatedAt time.Time `json:"updatedAt" format:"date-time" doc:"When the channel last moved"`
+}
+
+type ServerChannelListResponse struct {
+	Channels []ServerChannel `json:"channels" doc:"Release channels of the server, starting with latest"`
+}
+
+type ServerReview struct {
+	ID         string    `json:"id" doc:"Review ID"`
+	ServerName string    `json:"serverName" doc:"Server the review is about" example:"io.github.octocat/weather"`
+	Reviewer   Principal `json:"reviewer" doc:"Principal who left the review"`
+	Rating     int       `json:"rating" minimum:"1" maximum:"5" doc:"Rating from 1 to 5" example:"4"`
+	Review     string    `json:"review,omitempty" maxLength:"500" doc:"Short review" example:"Reliable forecasts, easy to set up."`
+	Hidden     bool      `json:"hidden,omitempty" doc:"Whether an admin hid the review. Hidden reviews don't count towards the rating."`
+	CreatedAt  time.Time `json:"createdAt" format:"date-time"`
+	UpdatedAt  time.Time `json:"updatedAt" format:"date-time" doc:"When the reviewer last changed the review"`
+}
+
+type ServerRating struct {
+	Average float64 `json:"average" doc:"Average rating, or 0 without ratings" example:"4.2"`
+	Count   int     `json:"count" doc:"Number of ratings" example:"17"`
+}
+
+type ServerReviewListResponse struct {
+	Rating   ServerRating   `json:"rating" doc:"Rating of the server across all its reviews"`
+	Reviews  []ServerReview `json:"reviews" doc:"Reviews of the server, most recently updated first"`
+	Metadata Metadata       `json:"metadata" doc:"Pagination metadata"`
+}
+
+type SecurityAdvisory struct {
+	ID               string    `json:"id" doc:"Advisory ID"`
+	ServerName       string    `json:"serverName" doc:"Server the advisory is about" example:"io.github.octocat/weather"`
+	Summary          string    `json:"summary" minLength:"1" maxLength:"200" doc:"One-line summary of the vulnerability" example:"Path traversal in file resources"`
+	Details          string    `json:"details,omitempty" maxLength:"10000" doc:"Description of the vulnerability and its impact, in Markdown"`
+	Severity         string    `json:"severity" enum:"low,moderate,high,critical" doc:"Severity of the vulnerability" example:"high"`
+	AffectedVersions []string  `json:"affectedVersions" minItems:"1" maxItems:"500" doc:"Published versions of the server the vulnerability affects" example:"[\"1.0.0\",\"1.0.1\"]"`
+	Remediation      string    `json:"remediation,omitempty" maxLength:"1000" doc:"How to fix or mitigate it, e.g. the version to upgrade to" example:"Upgrade to 1.0.2"`
+	Aliases          []string  `json:"aliases,omitempty" maxItems:"10" doc:"IDs of the vulnerability in other databases, such as CVE or GHSA IDs" example:"[\"CVE-2025-12345\"]"`
+	PublishedBy      Principal `json:"publishedBy" doc:"Principal who published the advisory"`
+	PublishedAt      time.Time `json:"publishedAt" format:"date-time"`
+	UpdatedAt        time.Time `json:"updatedAt" format:"date-time"`
+}
+
+type SecurityAdvisoryListResponse struct {
+	Advisories []SecurityAdvisory `json:"advisories" doc:"Security advisories"`
+	Metadata   Metadata           `json:"metadata" doc:"Pagination metadata"`
+}
+
+type UpdateProposal struct {
+	ID             string     `json:"id" doc:"Proposal ID"`
+	ServerName     string     `json:"serverName" doc:"Server the update is for" example:"io.github.octocat/weather"`
+	Version        string     `json:"version" doc:"Proposed version, from the release tag" example:"1.1.0"`
+	CurrentVersion string     `json:"currentVersion" doc:"Latest version of the server when the release was found" example:"1.0.0"`
+	ReleaseTag     string     `json:"releaseTag" doc:"Tag of the upstream release" example:"v1.1.0"`
+	ReleaseURL     string     `json:"releaseUrl,omitempty" format:"uri" doc:"Upstream release page"`
+	Server         ServerJSON `json:"server" doc:"Server entry that publishing the proposal would publish: the current version's, with the version and matching package versions and image tags updated"`
+	Status         string     `json:"status" enum:"pending,published,dismissed" doc:"Whether the proposal is waiting for the publisher, was published or was dismissed"`
+	Error          string     `json:"error,omitempty" doc:"Why publishing the proposal last failed"`
+	CreatedAt      time.Time  `json:"createdAt" format:"date-time"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty" format:"date-time" doc:"When the proposal was published or dismissed"`
+}
+
+type UpdateProposalListResponse struct {
+	Proposals []UpdateProposal `json:"proposals" doc:"Update proposals, most recent first"`
+	Metadata  Metadata         `json:"metadata" doc:"Pagination metadata"`
+}
+
+type UpdatePolicy struct {
+	AutoPublish bool `json:"autoPublish" doc:"Whether new upstream releases are published automatically rather than proposed"`
+}
+
+// SigningKey is the public key a server's versions must be signed with to be published
+type SigningKey struct {
+	Format    string    `json:"format" enum:"minisign,cosign" doc:"Tool the key pair belongs to"`
+	PublicKey string    `json:"publicKey" maxLength:"8192" doc:"The public key: minisign's .pub file, or cosign's PEM-encoded cosign.pub" example:"untrusted comment: minisign public key 4D1E5C1A7F3B2E90\nRWSQLjt/GlweTf3mZ3dB2U0e1zSgN+6oB2KdmtbeIuz8W4vrMjrOz9Xb"`
+	KeyID     string    `json:"keyId,omitempty" readOnly:"true" doc:"ID of the key: minisign's key ID, or the SHA-256 of the cosign key's DER encoding"`
+	UpdatedAt time.Time `json:"updatedAt,omitempty" readOnly:"true" format:"date-time" doc:"When the key was registered"`
+}
+
+// PackageAttachment is a supply-chain document a publisher attached to an OCI package of a server
+// version: an SBOM listing what the image contains, or SLSA provenance saying how it was built
+type PackageAttachment struct {
+	PackageIndex int       `json:"packageIndex" doc:"Index of the package in the version's packages" example:"0"`
+	Identifier   string    `json:"identifier" doc:"Identifier of the OCI package" example:"docker.io/example/weather:1.0.0"`
+	Kind         string    `json:"kind" enum:"sbom,provenance" doc:"What the document is"`
+	Format       string    `json:"format" enum:"spdx,cyclonedx,slsa" doc:"Format of the document"`
+	MediaType    string    `json:"mediaType" doc:"Media type the document is served as" example:"application/spdx+json"`
+	Digest       string    `json:"digest" doc:"Digest of the document as published" example:"sha256:3b5d5c3712955042212316173ccf37be800d3fbe4b5a9e1f0c3a6f2a38f1e9c4"`
+	Size         int64     `json:"size" doc:"Size of the document in bytes" example:"48213"`
+	CreatedAt    time.Time `json:"createdAt" format:"date-time" doc:"When the document was attached"`
+}
+
+// PackageAttachmentListResponse is the documents attached to the packages of a server version
+type PackageAttachmentListResponse struct {
+	Attachments []PackageAttachment `json:"attachments"`
+}
+
+// ServerSignature is a publisher's detached signature over a server version's canonical server.json
+type ServerSignature struct {
+	ServerName string    `json:"serverName" doc:"Name of the server" example:"io.github.octocat/weather"`
+	Version    string    `json:"version" doc:"Version the signature is over" example:"1.0.0"`
+	Format     string    `json:"format" enum:"minisign,cosign" doc:"Tool that made the signature"`
+	Signature  string    `json:"signature" doc:"The signature file as the tool wrote it: minisign's .minisig, or cosign's base64 signature"`
+	Digest     string    `json:"digest" doc:"Hex-encoded SHA-256 of the canonical server.json the signature is over" example:"3b5d5c3712955042212316173ccf37be800d3fbe4b5a9e1f0c3a6f2a38f1e9c4"`
+	KeyID      string    `json:"keyId,omitempty" doc:"ID of the signing key the registry verified the signature with"`
+	Verified   bool      `json:"verified" doc:"Whether the registry verified the signature with the server's signing key when it was published. Unverified signatures were published before a key was registered."`
+	CreatedAt  time.Time `json:"createdAt" format:"date-time" doc:"When the signature was published"`
+}
+
+type ImportResult struct {
+	Index   int    `json:"index" doc:"Position of the entry in the seed array"`
+	Name    string `json:"name,omitempty" doc:"Server name of the entry" example:"io.github.octocat/weather"`
+	Version string `json:"version,omitempty" doc:"Version of the entry" example:"1.0.0"`
+	Status  string `json:"status" enum:"ok,failed" doc:"Whether the entry is valid and could be published"`
+	Error   string `json:"error,omitempty" doc:"Why the entry failed"`
+}
+
+type ImportReport struct {
+	Applied bool           `json:"applied" doc:"Whether the batch was saved. Batches are saved only when every entry succeeds and it isn't a dry run."`
+	Created int            `json:"created" doc:"Entries that succeeded"`
+	Failed  int            `json:"failed" doc:"Entries that failed"`
+	Results []ImportResult `json:"results" doc:"Result of each entry, in seed order"`
+}
+
+type AuditEvent struct {
+	ID           int64      `json:"id"`
+	Action       string     `json:"action" doc:"Operation performed, e.g. publish-server or set-server-status" example:"publish-server"`
+	Method       string     `json:"method" doc:"HTTP method of the request" example:"POST"`
+	Path         string     `json:"path" doc:"Path of the request" example:"/v0/publish"`
+	Status       int        `json:"status" doc:"HTTP status the request was answered with" example:"200"`
+	Actor        *Principal `json:"actor,omitempty" doc:"Who made the request, if it was authenticated"`
+	IP           string     `json:"ip,omitempty" doc:"IP address the request came from" example:"192.0.2.1"`
+	ServerName   string     `json:"serverName,omitempty" doc:"Server the action was on, as named at the time" example:"io.github.octocat/weather"`
+	Version      string     `json:"version,omitempty" doc:"Version the action was on" example:"1.0.0"`
+	BeforeDigest string     `json:"beforeDigest,omitempty" doc:"SHA-256 of the version's server.json before the action"`
+	AfterDigest  string     `json:"afterDigest,omitempty" doc:"SHA-256 of the version's server.json after the action"`
+	CreatedAt    time.Time  `json:"createdAt" format:"date-time"`
+}
+
+type AuditEventListResponse struct {
+	Events   []AuditEvent `json:"events" doc:"Audit events, newest first"`
+	Metadata Metadata     `json:"metadata" doc:"Pagination metadata"`
+}
+
+type ModerationCase struct {
+	ID         string     `json:"id" doc:"Case ID"`
+	ServerName string     `json:"serverName" doc:"Server the case is about, as named when it was quarantined" example:"io.github.octocat/weather"`
+	Status     string     `json:"status" enum:"quarantined,reinstated,deleted" doc:"Whether the server is still quarantined, or was reinstated or permanently deleted"`
+	Reason     string     `json:"reason" doc:"Why the server was quarantined" example:"Impersonates the official weather.gov server"`
+	Moderator  Principal  `json:"moderator" doc:"Admin who quarantined the server"`
+	CreatedAt  time.Time  `json:"createdAt" format:"date-time"`
+	Resolution string     `json:"resolution,omitempty" doc:"Why the server was reinstated or deleted"`
+	ResolvedBy *Principal `json:"resolvedBy,omitempty" doc:"Admin who reinstated or deleted the server"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty" format:"date-time" doc:"When the server was reinstated or deleted"`
+}
+
+type ModerationCaseListResponse struct {
+	Cases    []ModerationCase `json:"cases" doc:"Moderation cases, most recent first"`
+	Metadata Metadata         `json:"metadata" doc:"Pagination metadata"`
+}
+
+// ModerationDecision is an admin's reason for quarantining, reinstating or deleting a server
+type ModerationDecision struct {
+	Reason string `json:"reason" minLength:"1" maxLength:"1000" doc:"Why the server is quarantined, reinstated or deleted. Quarantine reasons are shown to clients and the publisher" example:"Impersonates the official weather.gov server"`
+}
+
+// Quarantine is the open moderation case of a quarantined server
+type Quarantine struct {
+	Reason        string    `json:"reason" doc:"Why an admin quarantined the server"`
+	QuarantinedAt time.Time `json:"quarantinedAt" format:"date-time" doc:"When the server was quarantined"`
+}
+
+// Usage is how much a server was used over a period, counted by the registry
+type Usage struct {
+	Requests int64 `json:"requests" doc:"Requests the registry served for the server's details" example:"1520"`
+	Installs int64 `json:"installs" doc:"Installs clients reported to the registry" example:"340"`
+}
+
+// RegistryStats is an overview of the servers in the registry, for catalog landing pages
+type RegistryStats struct {
+	TotalServers    int            `json:"totalServers" doc:"Servers whose latest version listings show: not yanked, deleted or quarantined" example:"1234"`
+	ByRegistryType  map[string]int `json:"byRegistryType" doc:"Number of those servers with a package from each registry" example:"{\"npm\":640,\"pypi\":310,\"oci\":220}"`
+	ByTransport     map[string]int `json:"byTransport" doc:"Number of those servers with a package or remote using each transport" example:"{\"stdio\":1100,\"streamable-http\":180}"`
+	PublishesPerDay []DailyCount   `json:"publishesPerDay" doc:"Versions published on each of the last 30 days in UTC, oldest first"`
+}
+
+// DailyCount is a count for a day
+type DailyCount struct {
+	Date  string `json:"date" format:"date" doc:"Day in UTC" example:"2025-08-07"`
+	Count int    `json:"count" minimum:"0" example:"42"`
+}
+
+// TaxonomyTerm is a category or tag with how many servers have it
+type TaxonomyTerm struct {
+	Name  string `json:"name" doc:"The category or tag, lower-cased" example:"weather"`
+	Count int    `json:"count" minimum:"1" doc:"Servers whose latest version listings show has it" example:"12"`
+}
+
+// CategoriesResponse is the categories of the servers in the registry, most common first
+type CategoriesResponse struct {
+	Categories []TaxonomyTerm `json:"categories" doc:"Categories listed under io.modelcontextprotocol.registry/publisher-provided, most common first"`
+}
+
+// TagsResponse is the tags of the servers in the registry, most common first
+type TagsResponse struct {
+	Tags []TaxonomyTerm `json:"tags" doc:"Tags listed under io.modelcontextprotocol.registry/publisher-provided, most common first"`
+}
+
+// TrendingServer is the latest version of a server with how much it was used recently
+type TrendingServer struct {
+	Server   ServerJSON   `json:"server" doc:"Server configuration and metadata"`
+	Meta     ResponseMeta `json:"_meta" doc:"Registry-managed metadata"`
+	Requests int64        `json:"requests" minimum:"0" doc:"Requests the registry served for the server's details during the period" example:"1520"`
+	Pulls    int64        `json:"pulls" minimum:"0" doc:"How much the pull count its publisher reports grew during the period" example:"8300"`
+}
+
+// TrendingServersResponse is the servers used most recently, most used first
+type TrendingServersResponse struct {
+	Servers  []TrendingServer `json:"servers" doc:"Trending servers, ranked by requests plus pulls during the period"`
+	Metadata Metadata         `json:"metadata" doc:"Pagination metadata"`
+}
+
+// Kinds of change in a server diff
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// ServerDiff is what changed between two versions of a server
+type ServerDiff struct {
+	Name     string        `json:"name" doc:"Server name" example:"io.github.user/weather"`
+	From     string        `json:"from" doc:"Version the changes are from" example:"1.2.0"`
+	To       string        `json:"to" doc:"Version the changes are to" example:"1.3.0"`
+	Packages []PackageDiff `json:"packages" doc:"Packages added, removed or changed, matched by registry type and identifier (without the tag or digest of OCI images)"`
+	Remotes  []RemoteDiff  `json:"remotes" doc:"Remotes added, removed or changed, matched by URL"`
+	Metadata []FieldChange `json:"metadata" doc:"Other server.json fields that changed, including _meta"`
+}
+
+// PackageDiff is a package added, removed or changed between two versions of a server
+type PackageDiff struct {
+	Change               string        `json:"change" enum:"added,removed,changed" doc:"How the package changed"`
+	RegistryType         string        `json:"registryType" doc:"Registry type of the package" example:"npm"`
+	Identifier           string        `json:"identifier" doc:"Identifier of the package in the newer version, or the older one if it was removed" example:"@user/weather-mcp"`
+	Fields               []FieldChange `json:"fields,omitempty" doc:"Fields of the package that changed, other than its inputs"`
+	EnvironmentVariables []InputDiff   `json:"environmentVariables,omitempty" doc:"Environment variables added, removed or changed, matched by name"`
+	RuntimeArguments     []InputDiff   `json:"runtimeArguments,omitempty" doc:"Runtime arguments added, removed or changed, matched by name, or value hint or position for positional arguments"`
+	PackageArguments     []InputDiff   `json:"packageArguments,omitempty" doc:"Package arguments added, removed or changed, matched by name, or value hint or position for positional arguments"`
+}
+
+// RemoteDiff is a remote added, removed or changed between two versions of a server
+type RemoteDiff struct {
+	Change  string        `json:"change" enum:"added,removed,changed" doc:"How the remote changed"`
+	Type    string        `json:"type" doc:"Transport of the remote in the newer version, or the older one if it was removed" example:"streamable-http"`
+	URL     string        `json:"url" doc:"URL of the remote" example:"https://mcp.example.com/mcp"`
+	Fields  []FieldChange `json:"fields,omitempty" doc:"Fields of the remote that changed, other than its headers"`
+	Headers []InputDiff   `json:"headers,omitempty" doc:"Headers added, removed or changed, matched by name"`
+}
+
+// InputDiff is an environment variable, argument or header added, removed or changed between two
+// versions of a server
+type InputDiff struct {
+	Change string        `json:"change" enum:"added,removed,changed" doc:"How the input changed"`
+	Name   string        `json:"name" doc:"Name of the input, or value hint or position (as #1, #2...) of positional arguments" example:"API_KEY"`
+	Fields []FieldChange `json:"fields,omitempty" doc:"Fields of the input that changed"`
+}
+
+// FieldChange is a field whose value changed. Fields of nested objects are named by their path,
+// joined by dots.
+type FieldChange struct {
+	Field string `json:"field" doc:"Path of the field" example:"transport.type"`
+	From  any    `json:"from,omitempty" doc:"Value in the older version, left out when the field was added"`
+	To    any    `json:"to,omitempty" doc:"Value in the newer version, left out when the field was removed"`
 }
@@ -27,6 +27,13 @@ const (
 	TransportTypeStdio          = "stdio"
 )
 
+// Client Capabilities - authentication methods a client can declare it supports when listing
+// servers, alongside the transport types
+const (
+	ClientCapabilityOAuth   = "oauth"
+	ClientCapabilityHeaders = "headers"
+)
+
 // Runtime Hints - supported package runtime hints
 const (
 	RuntimeHintNPX    = "npx"
@@ -35,6 +42,13 @@ const (
 	RuntimeHintDNX    = "dnx"
 )
 
+// Relationship Types - supported relationships between servers
+const (
+	RelationshipSupersedes = "supersedes"
+	RelationshipRequires   = "requires"
+	RelationshipBundleOf   = "bundle-of"
+)
+
 // Schema versions
 const (
 	// CurrentSchemaVersion is the current supported schema version date
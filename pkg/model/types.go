@@ -105,3 +105,8 @@ type Icon struct {
 	Sizes    []string `json:"sizes,omitempty" doc:"Optional array of strings that specify sizes at which the icon can be used. Each string should be in WxH format (e.g., '48x48', '96x96') or 'any' for scalable formats like SVG. If not provided, the client should assume that the icon can be used at any size." items.pattern:"^(\\d+x\\d+|any)$"`
 	Theme    *string  `json:"theme,omitempty" enum:"light,dark" doc:"Optional specifier for the theme this icon is designed for. 'light' indicates the icon is designed to be used with a light background, and 'dark' indicates the icon is designed to be used with a dark background. If not provided, the client should assume the icon can be used with any theme."`
 }
+
+type Relationship struct {
+	Type string `json:"type" required:"true" enum:"supersedes,requires,bundle-of" doc:"How this server relates to the other one: it replaces it (supersedes), needs it alongside (requires), or packages its functionality (bundle-of)." example:"supersedes"`
+	Name string `json:"name" required:"true" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Name of the related server. It must be published in the registry." example:"io.github.user/weather-legacy"`
+}
@@ -24,7 +24,8 @@ else
     echo "Marking server $SERVER_NAME as deleted..."
 fi
 
-# Update server status to deleted
+# Update server status to deleted, whatever edits were made since it was read
 curl -X PUT "$ENDPOINT" \
   -H "Authorization: Bearer ${REGISTRY_TOKEN}" \
+  -H "If-Match: *" \
   -H "Content-Type: application/json"
\ No newline at end of file
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/modelcontextprotocol/registry/internal/api/rpc"
+)
+
+const protoOutputPath = "docs/reference/api/registry.proto"
+
+func main() {
+	var check bool
+	flag.BoolVar(&check, "check", false, "Check if the .proto file is in sync (exit 1 if not)")
+	flag.Parse()
+
+	rendered, err := rpc.Proto()
+	if err != nil {
+		log.Fatalf("Failed to render protobuf definitions: %v", err)
+	}
+
+	if check {
+		existing, err := os.ReadFile(protoOutputPath)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", protoOutputPath, err)
+		}
+		if string(existing) != rendered {
+			log.Fatalf("%s is out of sync with the registry's Go types. Run 'make generate-proto' to update it.", protoOutputPath)
+		}
+		log.Printf("%s is in sync", protoOutputPath)
+		return
+	}
+
+	if err := os.WriteFile(protoOutputPath, []byte(rendered), 0o600); err != nil {
+		log.Fatalf("Failed to write %s: %v", protoOutputPath, err)
+	}
+	log.Printf("Wrote %s", protoOutputPath)
+}